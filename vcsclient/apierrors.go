@@ -0,0 +1,220 @@
+package vcsclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+)
+
+var (
+	// ErrNotFound is matched by the errors of requests for resources that don't exist
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized is matched by the errors of requests that were rejected due to missing or insufficient credentials
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited is matched by the errors of requests that were rejected due to rate limiting
+	ErrRateLimited = errors.New("rate limited")
+	// ErrConflict is matched by the errors of requests that conflict with the current state of a resource, such as creating an existing branch
+	ErrConflict = errors.New("conflict")
+)
+
+// The maximum number of bytes read from the body of an error response to find the error code
+const maxErrorResponseBytes = 64 * 1024
+
+// The headers used by the VCS providers for the request ID
+var requestIDHeaders = []string{"X-GitHub-Request-Id", "X-Request-Id", "X-Arequestid", "X-Amzn-Requestid"}
+
+// APIError is an error response of the VCS provider.
+// Use errors.Is with ErrNotFound, ErrUnauthorized, ErrRateLimited and ErrConflict to check its kind.
+type APIError struct {
+	// The HTTP status code of the response
+	StatusCode int
+	// The error code reported by the VCS provider, if any
+	Code string
+	// The ID of the request reported by the VCS provider, if any
+	RequestID string
+	// The error returned by the VCS provider library
+	Err error
+}
+
+func (err *APIError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *APIError) Unwrap() error {
+	return err.Err
+}
+
+func (err *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		// AWS CodeCommit responds with 400 and an error code such as RepositoryDoesNotExistException
+		return err.StatusCode == http.StatusNotFound || err.StatusCode == http.StatusGone || strings.HasSuffix(err.Code, "DoesNotExistException")
+	case ErrUnauthorized:
+		return err.StatusCode == http.StatusUnauthorized || err.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return err.StatusCode == http.StatusTooManyRequests
+	case ErrConflict:
+		return err.StatusCode == http.StatusConflict || strings.HasSuffix(err.Code, "AlreadyExistsException")
+	}
+	return false
+}
+
+func (err *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+type responseRecorderKey struct{}
+
+// responseRecorder records the last response received by a VcsClient method, to describe the error returned by the method
+type responseRecorder struct {
+	mutex      sync.Mutex
+	statusCode int
+	response   *APIError
+}
+
+func withResponseRecorder(ctx context.Context) (context.Context, *responseRecorder) {
+	recorder := &responseRecorder{}
+	return context.WithValue(ctx, responseRecorderKey{}, recorder), recorder
+}
+
+func getResponseRecorder(ctx context.Context) *responseRecorder {
+	recorder, _ := ctx.Value(responseRecorderKey{}).(*responseRecorder)
+	return recorder
+}
+
+// record records the response. The body of error responses is read to find the error code, and can still be read afterwards.
+func (recorder *responseRecorder) record(response *http.Response) {
+	if recorder == nil || response == nil {
+		return
+	}
+	var recorded *APIError
+	if response.StatusCode >= http.StatusBadRequest {
+		recorded = &APIError{StatusCode: response.StatusCode, RequestID: getRequestID(response.Header)}
+		if response.Body != nil {
+			body, err := io.ReadAll(io.LimitReader(response.Body, maxErrorResponseBytes))
+			if err == nil {
+				recorded.Code = getErrorCode(body)
+			}
+			response.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), response.Body), response.Body}
+		}
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.statusCode = response.StatusCode
+	recorder.response = recorded
+}
+
+// lastStatusCode returns the status code of the last response, or zero if no response was received
+func (recorder *responseRecorder) lastStatusCode() int {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.statusCode
+}
+
+// wrapError wraps the error with an APIError, if the last recorded response is an error response
+func (recorder *responseRecorder) wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var rateLimitErr *RateLimitError
+	var apiErr *APIError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &apiErr) {
+		return err
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if recorder.response == nil {
+		return getLibraryAPIError(err)
+	}
+	return &APIError{StatusCode: recorder.response.StatusCode, Code: recorder.response.Code, RequestID: recorder.response.RequestID, Err: err}
+}
+
+// getLibraryAPIError wraps the errors of VCS provider libraries whose requests aren't recorded
+func getLibraryAPIError(err error) error {
+	var azureErr *azuredevops.WrappedError
+	if !errors.As(err, &azureErr) {
+		var azureValueErr azuredevops.WrappedError
+		if errors.As(err, &azureValueErr) {
+			azureErr = &azureValueErr
+		}
+	}
+	if azureErr != nil && azureErr.StatusCode != nil {
+		apiErr := &APIError{StatusCode: *azureErr.StatusCode, Err: err}
+		if azureErr.TypeKey != nil {
+			apiErr.Code = *azureErr.TypeKey
+		}
+		return apiErr
+	}
+	// The Bitbucket cloud library returns the status of the response as the error
+	if statusCode := getStatusLineCode(err.Error()); statusCode != 0 {
+		return &APIError{StatusCode: statusCode, Err: err}
+	}
+	return err
+}
+
+// getStatusLineCode returns the status code of a message such as "404 Not Found", or zero if the message isn't an HTTP status
+func getStatusLineCode(message string) int {
+	statusCode, err := strconv.Atoi(strings.SplitN(message, " ", 2)[0])
+	if err != nil || message != fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)) {
+		return 0
+	}
+	return statusCode
+}
+
+func getRequestID(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if requestID := header.Get(name); requestID != "" {
+			return requestID
+		}
+	}
+	return ""
+}
+
+// getErrorCode returns the error code in the body of an error response, or an empty string if it has no code
+func getErrorCode(body []byte) string {
+	var errorBody struct {
+		// Azure Repos
+		TypeKey string `json:"typeKey"`
+		// GitLab
+		Error interface{} `json:"error"`
+		// GitHub and Bitbucket server
+		Errors interface{} `json:"errors"`
+		// AWS CodeCommit
+		Type string `json:"__type"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err != nil {
+		return ""
+	}
+	if errorBody.TypeKey != "" {
+		return errorBody.TypeKey
+	}
+	if errorBody.Type != "" {
+		// The type may be prefixed by the namespace of the service, such as "com.amazonaws.codecommit#BranchDoesNotExistException"
+		return errorBody.Type[strings.LastIndex(errorBody.Type, "#")+1:]
+	}
+	if code, ok := errorBody.Error.(string); ok {
+		return code
+	}
+	if errorsList, ok := errorBody.Errors.([]interface{}); ok && len(errorsList) > 0 {
+		if firstError, ok := errorsList[0].(map[string]interface{}); ok {
+			for _, key := range []string{"code", "exceptionName"} {
+				if code, ok := firstError[key].(string); ok {
+					return code
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		statusCode  int
+		expectedErr error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusGone, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusInternalServerError, nil},
+	}
+	for _, test := range tests {
+		t.Run(http.StatusText(test.statusCode), func(t *testing.T) {
+			libraryErr := errors.New("library error")
+			err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: test.statusCode, Err: libraryErr})
+			assert.ErrorIs(t, err, libraryErr)
+			for _, kind := range []error{ErrNotFound, ErrUnauthorized, ErrRateLimited, ErrConflict} {
+				assert.Equal(t, kind == test.expectedErr, errors.Is(err, kind), kind.Error())
+			}
+		})
+	}
+	assert.ErrorIs(t, &RateLimitError{StatusCode: http.StatusForbidden}, ErrRateLimited)
+	assert.NotErrorIs(t, &RateLimitError{StatusCode: http.StatusForbidden}, ErrUnauthorized)
+}
+
+func TestAPIErrorsClient(t *testing.T) {
+	tests := []struct {
+		vcsProvider       vcsutils.VcsProvider
+		statusCode        int
+		header            string
+		body              string
+		expectedErr       error
+		expectedCode      string
+		expectedRequestID string
+	}{
+		{vcsutils.GitHub, http.StatusNotFound, "X-GitHub-Request-Id", `{"message":"Not Found"}`, ErrNotFound, "", "github-request"},
+		{vcsutils.GitHub, http.StatusUnprocessableEntity, "X-GitHub-Request-Id", `{"message":"Validation Failed","errors":[{"code":"already_exists"}]}`, nil, "already_exists", "github-request"},
+		{vcsutils.GitLab, http.StatusUnauthorized, "X-Request-Id", `{"error":"invalid_token"}`, ErrUnauthorized, "invalid_token", "gitlab-request"},
+		{vcsutils.BitbucketServer, http.StatusConflict, "X-Arequestid", `{"errors":[{"exceptionName":"com.atlassian.bitbucket.repository.DuplicateRefException"}]}`, ErrConflict, "com.atlassian.bitbucket.repository.DuplicateRefException", "bitbucket-request"},
+		{vcsutils.Gitea, http.StatusNotFound, "X-Request-Id", `{"message":"The target couldn't be found."}`, ErrNotFound, "", "gitea-request"},
+		{vcsutils.AWSCodeCommit, http.StatusBadRequest, "X-Amzn-Requestid", `{"__type":"com.amazonaws.codecommit#BranchDoesNotExistException","message":"Branch does not exist"}`, ErrNotFound, "BranchDoesNotExistException", "aws-request"},
+	}
+	for _, test := range tests {
+		t.Run(test.vcsProvider.String(), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.RequestURI == "/api/v4/" {
+					return
+				}
+				w.Header().Set(test.header, test.expectedRequestID)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(test.statusCode)
+				_, err := w.Write([]byte(test.body))
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+
+			client := buildAPIErrorsClient(t, test.vcsProvider, true, server)
+			_, err := client.GetLatestCommit(context.Background(), owner, repo1, "master")
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, test.statusCode, apiErr.StatusCode)
+			assert.Equal(t, test.expectedCode, apiErr.Code)
+			assert.Equal(t, test.expectedRequestID, apiErr.RequestID)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestAPIErrorsClient_BitbucketCloud(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// The requests of the Bitbucket cloud library aren't recorded, so the status is taken from its error
+	_, err := buildAPIErrorsClient(t, vcsutils.BitbucketCloud, true, server).GetRepositoryInfo(context.Background(), owner, repo1)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAPIErrorsClient_Pager(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	pager := buildAPIErrorsClient(t, vcsutils.GitHub, false, server).ListBranchesPager(owner, repo1, 10)
+	_, err := pager.Next(context.Background())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAPIErrorsClient_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	err := buildClient(t, vcsutils.Gitea, false, server).TestConnection(context.Background())
+	assert.ErrorIs(t, err, ErrRateLimited)
+	var rateLimitErr *RateLimitError
+	assert.ErrorAs(t, err, &rateLimitErr)
+}
+
+func TestResponseRecorder(t *testing.T) {
+	ctx, recorder := withResponseRecorder(context.Background())
+	assert.Same(t, recorder, getResponseRecorder(ctx))
+	assert.Nil(t, getResponseRecorder(context.Background()))
+
+	libraryErr := errors.New("library error")
+	assert.NoError(t, recorder.wrapError(nil))
+	assert.Equal(t, libraryErr, recorder.wrapError(libraryErr))
+
+	// The body of a recorded error response can still be read
+	response := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"typeKey":"GitRepositoryNotFoundException"}`))}
+	recorder.record(response)
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"typeKey":"GitRepositoryNotFoundException"}`, string(body))
+	assert.Equal(t, &APIError{StatusCode: http.StatusNotFound, Code: "GitRepositoryNotFoundException", Err: libraryErr}, recorder.wrapError(libraryErr))
+
+	// Errors are wrapped only if the last response is an error response
+	recorder.record(&http.Response{StatusCode: http.StatusOK})
+	assert.Equal(t, libraryErr, recorder.wrapError(libraryErr))
+}
+
+func TestGetLibraryAPIError(t *testing.T) {
+	statusCode := http.StatusNotFound
+	typeKey := "GitRepositoryNotFoundException"
+	azureErr := &azuredevops.WrappedError{StatusCode: &statusCode, TypeKey: &typeKey}
+	assert.Equal(t, &APIError{StatusCode: statusCode, Code: typeKey, Err: azureErr}, getLibraryAPIError(azureErr))
+
+	azureValueErr := azuredevops.WrappedError{StatusCode: &statusCode}
+	assert.Equal(t, &APIError{StatusCode: statusCode, Err: azureValueErr}, getLibraryAPIError(azureValueErr))
+
+	bitbucketErr := errors.New("409 Conflict")
+	assert.Equal(t, &APIError{StatusCode: http.StatusConflict, Err: bitbucketErr}, getLibraryAPIError(bitbucketErr))
+
+	otherErr := errors.New("404 branches were found")
+	assert.Equal(t, otherErr, getLibraryAPIError(otherErr))
+}
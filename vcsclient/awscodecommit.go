@@ -0,0 +1,1331 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit/types"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+var errAWSCodeCommitRateLimitNotSupported = errors.New("AWS CodeCommit doesn't report rate limits")
+var errAWSCodeCommitTagsNotSupported = errors.New("tags are not supported by the AWS CodeCommit API, use git to push them")
+var errAWSCodeCommitReleasesNotSupported = errors.New("releases are not supported on AWS CodeCommit")
+var errAWSCodeCommitBranchProtectionNotSupported = errors.New("branch protection is not supported on AWS CodeCommit, whose branches are protected by IAM policies and approval rule templates")
+var errAWSCodeCommitWebhooksNotSupported = errors.New("webhooks are not supported on AWS CodeCommit, whose notifications are delivered to SNS topics by repository triggers or EventBridge rules")
+var errAWSCodeCommitCommitStatusesNotSupported = errors.New("commit statuses and check runs are not supported on AWS CodeCommit")
+var errAWSCodeCommitRepositoryDownloadNotSupported = errors.New("downloading a repository archive is not supported by the AWS CodeCommit API, use git to clone the repository")
+var errAWSCodeCommitDraftPullRequestNotSupported = errors.New("draft pull requests are not supported on AWS CodeCommit")
+var errAWSCodeCommitAutoMergeNotSupported = errors.New("pull request auto-merge is not supported on AWS CodeCommit")
+var errAWSCodeCommitRetargetPullRequestNotSupported = errors.New("changing the target branch of a pull request is not supported on AWS CodeCommit")
+var errAWSCodeCommitRequestChangesNotSupported = errors.New("requesting changes in a pull request review is not supported on AWS CodeCommit")
+var errAWSCodeCommitCommentIDsNotSupported = errors.New("updating and deleting comments is not supported on AWS CodeCommit, whose comment IDs aren't numeric")
+var errAWSCodeCommitReviewCommentsNotSupported = errors.New("pull request review comments are not supported on AWS CodeCommit")
+var errAWSCodeCommitPullRequestDetailsNotSupported = errors.New("the diff, commits and mergeable state of pull requests are not supported on AWS CodeCommit")
+var errAWSCodeCommitCommitHistoryNotSupported = errors.New("listing and comparing commits is not supported by the AWS CodeCommit API")
+var errAWSCodeCommitKeysNotSupported = errors.New("deploy keys are not supported on AWS CodeCommit, whose SSH keys are uploaded to IAM users")
+var errAWSCodeCommitCollaboratorsNotSupported = errors.New("collaborators are not supported on AWS CodeCommit, whose repository permissions are managed by IAM policies")
+var errAWSCodeCommitForksNotSupported = errors.New("forks are not supported on AWS CodeCommit")
+var errAWSCodeCommitVisibilityNotSupported = errors.New("AWS CodeCommit repositories are always private")
+var errAWSCodeCommitLabelsNotSupported = errors.New("labels are not supported on AWS CodeCommit")
+var errAWSCodeCommitIssuesNotSupported = errors.New("issues are not supported on AWS CodeCommit")
+var errAWSCodeCommitCodeScanningNotSupported = errors.New("code scanning is not supported on AWS CodeCommit")
+var errAWSCodeCommitCodeSearchNotSupported = errors.New("code search is not supported on AWS CodeCommit")
+var errAWSCodeCommitEnvironmentsNotSupported = errors.New("repository environments are not supported on AWS CodeCommit")
+var errAWSCodeCommitBlameNotSupported = errors.New("blame is not supported by the AWS CodeCommit API")
+var errAWSCodeCommitLanguagesNotSupported = errors.New("repository languages are not supported on AWS CodeCommit")
+var errAWSCodeCommitDefaultReviewersNotSupported = errors.New("default reviewers are not supported on AWS CodeCommit, whose approval rule templates refer to IAM identities")
+var errAWSCodeCommitPipelinesNotSupported = errors.New("pipelines are not supported on AWS CodeCommit, whose builds run on AWS CodePipeline or CodeBuild")
+var errAWSCodeCommitCommitVerificationNotSupported = errors.New("commit signatures are not supported on AWS CodeCommit")
+var errAWSCodeCommitSecurityAlertsNotSupported = errors.New("security alerts are not supported on AWS CodeCommit")
+var errAWSCodeCommitCodeInsightsNotSupported = errors.New("Code Insights reports are not supported on AWS CodeCommit")
+
+// The maximum number of repositories BatchGetRepositories accepts
+const awsCodeCommitBatchGetRepositoriesLimit = 25
+
+// The prefix of the references of pull requests, which the API returns in full
+const awsCodeCommitBranchPrefix = "refs/heads/"
+
+// AWSCodeCommitClient API version 2015-04-13.
+// Repositories belong to the AWS account and region of the client, so the owner parameters are ignored.
+type AWSCodeCommitClient struct {
+	vcsInfo VcsInfo
+	logger  Log
+}
+
+// NewAWSCodeCommitClient create a new AWSCodeCommitClient
+func NewAWSCodeCommitClient(vcsInfo VcsInfo, logger Log) (*AWSCodeCommitClient, error) {
+	return &AWSCodeCommitClient{vcsInfo: vcsInfo, logger: logger}, nil
+}
+
+// The username and token are used as the access key ID and secret access key. If they aren't set, the default AWS credential chain is used.
+func (client *AWSCodeCommitClient) buildCodeCommitClient(ctx context.Context) (*codecommit.Client, error) {
+	// CodeCommit accepts POST requests only, and rejects throttled requests with 400 responses,
+	// so the requests are retried by the AWS SDK according to the retry policy, rather than by the HTTP client
+	retryPolicy := client.vcsInfo.RetryPolicy
+	httpVcsInfo := client.vcsInfo
+	httpVcsInfo.RetryPolicy = RetryPolicy{}
+	options := []func(*config.LoadOptions) error{
+		config.WithRegion(client.vcsInfo.Region),
+		config.WithHTTPClient(newHTTPClient(httpVcsInfo, client.logger)),
+		config.WithRetryer(func() aws.Retryer {
+			return retry.NewStandard(func(retryOptions *retry.StandardOptions) {
+				retryOptions.MaxAttempts = 1
+				if retryPolicy.MaxAttempts > 1 {
+					retryOptions.MaxAttempts = retryPolicy.MaxAttempts
+				}
+				retryOptions.MaxBackoff = retryPolicy.maxBackoff()
+			})
+		}),
+	}
+	if client.vcsInfo.Token != "" {
+		options = append(options, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(client.vcsInfo.Username, client.vcsInfo.Token, "")))
+	}
+	awsConfig, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return codecommit.NewFromConfig(awsConfig, func(codeCommitOptions *codecommit.Options) {
+		if client.vcsInfo.APIEndpoint != "" {
+			codeCommitOptions.BaseEndpoint = aws.String(client.vcsInfo.APIEndpoint)
+		}
+	}), nil
+}
+
+// TestConnection on AWS CodeCommit
+func (client *AWSCodeCommitClient) TestConnection(ctx context.Context) error {
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = codeCommitClient.ListRepositories(ctx, &codecommit.ListRepositoriesInput{})
+	return err
+}
+
+// GetRateLimitInfo on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetRateLimitInfo(ctx context.Context) (RateLimitInfo, error) {
+	return RateLimitInfo{}, errAWSCodeCommitRateLimitNotSupported
+}
+
+// ListRepositories on AWS CodeCommit. The repositories are grouped by the ID of the AWS account that owns them.
+func (client *AWSCodeCommitClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
+	repositories, err := client.ListRepositoriesWithOptions(ctx, "", ListRepositoriesOptions{})
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	for _, repository := range repositories {
+		results[repository.Owner] = append(results[repository.Owner], repository.Repository)
+	}
+	return results, nil
+}
+
+// ListRepositoriesWithOptions on AWS CodeCommit. The number of repositories per page isn't configurable.
+func (client *AWSCodeCommitClient) ListRepositoriesWithOptions(ctx context.Context, owner string, options ListRepositoriesOptions) ([]RepositoryDetails, error) {
+	var nextToken *string
+	// Pages are requested by the token of the previous page, so the pages before the requested page are fetched and skipped
+	pagesToSkip := firstPage(options.Page) - 1
+	pager := newPager(func(ctx context.Context) ([]RepositoryDetails, bool, error) {
+		codeCommitClient, err := client.buildCodeCommitClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		for ; ; pagesToSkip-- {
+			output, err := codeCommitClient.ListRepositories(ctx, &codecommit.ListRepositoriesInput{NextToken: nextToken})
+			if err != nil {
+				return nil, false, err
+			}
+			nextToken = output.NextToken
+			if pagesToSkip > 0 && nextToken != nil {
+				continue
+			}
+			if pagesToSkip > 0 {
+				return nil, false, nil
+			}
+			names := make([]string, 0, len(output.Repositories))
+			for _, repository := range output.Repositories {
+				names = append(names, aws.ToString(repository.RepositoryName))
+			}
+			metadata, err := getAWSCodeCommitRepositoriesMetadata(ctx, codeCommitClient, names)
+			if err != nil {
+				return nil, false, err
+			}
+			var results []RepositoryDetails
+			for _, repositoryMetadata := range metadata {
+				repositoryDetails := RepositoryDetails{
+					Owner:          aws.ToString(repositoryMetadata.AccountId),
+					Repository:     aws.ToString(repositoryMetadata.RepositoryName),
+					RepositoryInfo: mapAWSCodeCommitRepositoryToRepositoryInfo(repositoryMetadata),
+					Updated:        aws.ToTime(repositoryMetadata.LastModifiedDate),
+				}
+				if options.matches(repositoryDetails) {
+					results = append(results, repositoryDetails)
+				}
+			}
+			return results, nextToken != nil, nil
+		}
+	})
+	return listPageOrAll(ctx, pager, options.Page)
+}
+
+func getAWSCodeCommitRepositoriesMetadata(ctx context.Context, codeCommitClient *codecommit.Client, names []string) ([]types.RepositoryMetadata, error) {
+	var results []types.RepositoryMetadata
+	for start := 0; start < len(names); start += awsCodeCommitBatchGetRepositoriesLimit {
+		end := start + awsCodeCommitBatchGetRepositoriesLimit
+		if end > len(names) {
+			end = len(names)
+		}
+		output, err := codeCommitClient.BatchGetRepositories(ctx, &codecommit.BatchGetRepositoriesInput{RepositoryNames: names[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, output.Repositories...)
+	}
+	return results, nil
+}
+
+// ListBranches on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	return client.ListBranchesPager(owner, repository, 0).All(ctx)
+}
+
+// ListBranchesPager on AWS CodeCommit. The number of branches per page isn't configurable.
+func (client *AWSCodeCommitClient) ListBranchesPager(owner, repository string, perPage int) *Pager[string] {
+	var nextToken *string
+	return newPager(func(ctx context.Context) ([]string, bool, error) {
+		codeCommitClient, err := client.buildCodeCommitClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		output, err := codeCommitClient.ListBranches(ctx, &codecommit.ListBranchesInput{RepositoryName: aws.String(repository), NextToken: nextToken})
+		if err != nil {
+			return nil, false, err
+		}
+		nextToken = output.NextToken
+		return output.Branches, nextToken != nil, nil
+	})
+}
+
+// ListAllBranches on AWS CodeCommit. The API lists the branch names only, so the head commit of each branch is fetched separately.
+func (client *AWSCodeCommitClient) ListAllBranches(ctx context.Context, owner, repository, prefix string) ([]BranchDetails, error) {
+	branches, err := client.ListBranches(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []BranchDetails
+	for _, branch := range branches {
+		if !strings.HasPrefix(branch, prefix) {
+			continue
+		}
+		output, err := codeCommitClient.GetBranch(ctx, &codecommit.GetBranchInput{RepositoryName: aws.String(repository), BranchName: aws.String(branch)})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, BranchDetails{Name: branch, CommitHash: aws.ToString(output.Branch.CommitId)})
+	}
+	return results, nil
+}
+
+// CreateBranch on AWS CodeCommit. The branch is created from the head of the fromRef branch, or from the fromRef commit if there's no such branch.
+func (client *AWSCodeCommitClient) CreateBranch(ctx context.Context, owner, repository, branchName, fromRef string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "branchName": branchName, "fromRef": fromRef})
+	if err != nil {
+		return err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	commitID := fromRef
+	branch, err := codeCommitClient.GetBranch(ctx, &codecommit.GetBranchInput{RepositoryName: aws.String(repository), BranchName: aws.String(fromRef)})
+	var branchNotFoundErr *types.BranchDoesNotExistException
+	if err != nil && !errors.As(err, &branchNotFoundErr) {
+		return err
+	}
+	if err == nil {
+		commitID = aws.ToString(branch.Branch.CommitId)
+	}
+	_, err = codeCommitClient.CreateBranch(ctx, &codecommit.CreateBranchInput{
+		RepositoryName: aws.String(repository),
+		BranchName:     aws.String(branchName),
+		CommitId:       aws.String(commitID),
+	})
+	return err
+}
+
+// DeleteBranch on AWS CodeCommit
+func (client *AWSCodeCommitClient) DeleteBranch(ctx context.Context, owner, repository, branchName string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "branchName": branchName})
+	if err != nil {
+		return err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = codeCommitClient.DeleteBranch(ctx, &codecommit.DeleteBranchInput{RepositoryName: aws.String(repository), BranchName: aws.String(branchName)})
+	return err
+}
+
+// ListTags on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	return nil, errAWSCodeCommitTagsNotSupported
+}
+
+// CreateTag on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateTag(ctx context.Context, owner, repository, tagName, targetSha, message string) error {
+	return errAWSCodeCommitTagsNotSupported
+}
+
+// CreateRelease on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateRelease(ctx context.Context, owner, repository string, release ReleaseInfo) error {
+	return errAWSCodeCommitReleasesNotSupported
+}
+
+// GetLatestRelease on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetLatestRelease(ctx context.Context, owner, repository string) (*ReleaseInfo, error) {
+	return nil, errAWSCodeCommitReleasesNotSupported
+}
+
+// UploadReleaseAsset on AWS CodeCommit
+func (client *AWSCodeCommitClient) UploadReleaseAsset(ctx context.Context, owner, repository, tagName, assetPath string) error {
+	return errAWSCodeCommitReleasesNotSupported
+}
+
+// GetBranchProtection on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error) {
+	return nil, errAWSCodeCommitBranchProtectionNotSupported
+}
+
+// SetBranchProtection on AWS CodeCommit
+func (client *AWSCodeCommitClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtection) error {
+	return errAWSCodeCommitBranchProtectionNotSupported
+}
+
+// GetRequiredChecks on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetRequiredChecks(ctx context.Context, owner, repository, branch string) (RequiredChecksInfo, error) {
+	return RequiredChecksInfo{}, errAWSCodeCommitBranchProtectionNotSupported
+}
+
+// GetRequiredApprovals on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetRequiredApprovals(ctx context.Context, owner, repository, branch string) (RequiredApprovalsInfo, error) {
+	return RequiredApprovalsInfo{}, errAWSCodeCommitBranchProtectionNotSupported
+}
+
+// GetTagProtection on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetTagProtection(ctx context.Context, owner, repository, pattern string) (*TagProtection, error) {
+	return nil, errAWSCodeCommitTagsNotSupported
+}
+
+// SetTagProtection on AWS CodeCommit
+func (client *AWSCodeCommitClient) SetTagProtection(ctx context.Context, owner, repository, pattern string, protection TagProtection) error {
+	return errAWSCodeCommitTagsNotSupported
+}
+
+// CreateWebhook on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", errAWSCodeCommitWebhooksNotSupported
+}
+
+// UpdateWebhook on AWS CodeCommit
+func (client *AWSCodeCommitClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token, webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	return errAWSCodeCommitWebhooksNotSupported
+}
+
+// CreateWebhookWithOptions on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateWebhookWithOptions(ctx context.Context, owner, repository string, options WebhookOptions) (WebhookInfo, string, error) {
+	return WebhookInfo{}, "", errAWSCodeCommitWebhooksNotSupported
+}
+
+// DeleteWebhook on AWS CodeCommit
+func (client *AWSCodeCommitClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	return errAWSCodeCommitWebhooksNotSupported
+}
+
+// ListWebhooks on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	return nil, errAWSCodeCommitWebhooksNotSupported
+}
+
+// SetWebhookActive on AWS CodeCommit
+func (client *AWSCodeCommitClient) SetWebhookActive(ctx context.Context, owner, repository, webhookID string, active bool) error {
+	return errAWSCodeCommitWebhooksNotSupported
+}
+
+// CreateOrgWebhook on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateOrgWebhook(ctx context.Context, owner string, options WebhookOptions) (WebhookInfo, string, error) {
+	return WebhookInfo{}, "", errAWSCodeCommitWebhooksNotSupported
+}
+
+// ListOrgWebhooks on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListOrgWebhooks(ctx context.Context, owner string) ([]WebhookInfo, error) {
+	return nil, errAWSCodeCommitWebhooksNotSupported
+}
+
+// DeleteOrgWebhook on AWS CodeCommit
+func (client *AWSCodeCommitClient) DeleteOrgWebhook(ctx context.Context, owner, webhookID string) error {
+	return errAWSCodeCommitWebhooksNotSupported
+}
+
+// SetCommitStatus on AWS CodeCommit
+func (client *AWSCodeCommitClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error {
+	return errAWSCodeCommitCommitStatusesNotSupported
+}
+
+// SetCommitStatuses on AWS CodeCommit
+func (client *AWSCodeCommitClient) SetCommitStatuses(ctx context.Context, owner, repository, ref string, statuses []CommitStatusInfo) error {
+	return errAWSCodeCommitCommitStatusesNotSupported
+}
+
+// ListCommitStatuses on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListCommitStatuses(ctx context.Context, owner, repository, ref string) ([]CommitStatusInfo, error) {
+	return nil, errAWSCodeCommitCommitStatusesNotSupported
+}
+
+// CreateCheckRun on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateCheckRun(ctx context.Context, owner, repository, ref string, checkRun CheckRunInfo) (int64, error) {
+	return 0, errAWSCodeCommitCommitStatusesNotSupported
+}
+
+// UpdateCheckRun on AWS CodeCommit
+func (client *AWSCodeCommitClient) UpdateCheckRun(ctx context.Context, owner, repository, ref string, checkRunID int64, checkRun CheckRunInfo) error {
+	return errAWSCodeCommitCommitStatusesNotSupported
+}
+
+// CreateCodeInsightsReport on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, sha string, report CodeInsightsReport) error {
+	return errAWSCodeCommitCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on AWS CodeCommit
+func (client *AWSCodeCommitClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, sha, reportID string, annotations []CodeInsightsAnnotation) error {
+	return errAWSCodeCommitCodeInsightsNotSupported
+}
+
+// TriggerPipeline on AWS CodeCommit
+func (client *AWSCodeCommitClient) TriggerPipeline(ctx context.Context, owner, repository, pipeline, ref string, inputs map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errAWSCodeCommitPipelinesNotSupported
+}
+
+// GetPipelineStatus on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetPipelineStatus(ctx context.Context, owner, repository, runID string) (PipelineInfo, error) {
+	return PipelineInfo{}, errAWSCodeCommitPipelinesNotSupported
+}
+
+// DownloadRepository on AWS CodeCommit
+func (client *AWSCodeCommitClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	return errAWSCodeCommitRepositoryDownloadNotSupported
+}
+
+// DownloadRepositoryAtRef on AWS CodeCommit
+func (client *AWSCodeCommitClient) DownloadRepositoryAtRef(ctx context.Context, owner, repository, ref, localPath string, format ArchiveFormat) error {
+	return errAWSCodeCommitRepositoryDownloadNotSupported
+}
+
+// GetRepositoryArchive on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat) (io.ReadCloser, error) {
+	return nil, errAWSCodeCommitRepositoryDownloadNotSupported
+}
+
+// DownloadRepositoryPath on AWS CodeCommit
+func (client *AWSCodeCommitClient) DownloadRepositoryPath(ctx context.Context, owner, repository, ref, path, localPath string) error {
+	return errAWSCodeCommitRepositoryDownloadNotSupported
+}
+
+// CreatePullRequest on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "sourceBranch": sourceBranch, "targetBranch": targetBranch, "title": title})
+	if err != nil {
+		return err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	input := &codecommit.CreatePullRequestInput{
+		Title: aws.String(title),
+		Targets: []types.Target{{
+			RepositoryName:       aws.String(repository),
+			SourceReference:      aws.String(sourceBranch),
+			DestinationReference: aws.String(targetBranch),
+		}},
+	}
+	if description != "" {
+		input.Description = aws.String(description)
+	}
+	client.logger.Debug("creating new pull request:", title)
+	_, err = codeCommitClient.CreatePullRequest(ctx, input)
+	return err
+}
+
+// CreateDraftPullRequest on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateDraftPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error {
+	return errAWSCodeCommitDraftPullRequestNotSupported
+}
+
+// MarkPullRequestReady on AWS CodeCommit
+func (client *AWSCodeCommitClient) MarkPullRequestReady(ctx context.Context, owner, repository string, pullRequestID int) error {
+	return errAWSCodeCommitDraftPullRequestNotSupported
+}
+
+// MergePullRequest on AWS CodeCommit. RebaseMerge fast-forwards the target branch, which fails if the source branch isn't based on its head.
+func (client *AWSCodeCommitClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	id := aws.String(strconv.Itoa(pullRequestID))
+	var message *string
+	if commitMessage != "" {
+		message = aws.String(commitMessage)
+	}
+	client.logger.Debug("merging pull request:", pullRequestID)
+	switch mergeStrategy {
+	case SquashMerge:
+		_, err = codeCommitClient.MergePullRequestBySquash(ctx, &codecommit.MergePullRequestBySquashInput{
+			PullRequestId: id, RepositoryName: aws.String(repository), CommitMessage: message})
+	case RebaseMerge:
+		_, err = codeCommitClient.MergePullRequestByFastForward(ctx, &codecommit.MergePullRequestByFastForwardInput{
+			PullRequestId: id, RepositoryName: aws.String(repository)})
+	default:
+		_, err = codeCommitClient.MergePullRequestByThreeWay(ctx, &codecommit.MergePullRequestByThreeWayInput{
+			PullRequestId: id, RepositoryName: aws.String(repository), CommitMessage: message})
+	}
+	return err
+}
+
+// EnablePullRequestAutoMerge on AWS CodeCommit
+func (client *AWSCodeCommitClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy) error {
+	return errAWSCodeCommitAutoMergeNotSupported
+}
+
+// DisablePullRequestAutoMerge on AWS CodeCommit
+func (client *AWSCodeCommitClient) DisablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int) error {
+	return errAWSCodeCommitAutoMergeNotSupported
+}
+
+// UpdatePullRequest on AWS CodeCommit. The title, description and state are updated by separate requests.
+func (client *AWSCodeCommitClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranch string, pullRequestID int, state *PullRequestState) error {
+	if err := validatePullRequestState(state); err != nil {
+		return err
+	}
+	if targetBranch != "" {
+		return errAWSCodeCommitRetargetPullRequestNotSupported
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	id := aws.String(strconv.Itoa(pullRequestID))
+	client.logger.Debug("updating pull request:", pullRequestID)
+	if title != "" {
+		if _, err = codeCommitClient.UpdatePullRequestTitle(ctx, &codecommit.UpdatePullRequestTitleInput{PullRequestId: id, Title: aws.String(title)}); err != nil {
+			return err
+		}
+	}
+	if body != "" {
+		if _, err = codeCommitClient.UpdatePullRequestDescription(ctx, &codecommit.UpdatePullRequestDescriptionInput{PullRequestId: id, Description: aws.String(body)}); err != nil {
+			return err
+		}
+	}
+	if state != nil {
+		status := types.PullRequestStatusEnumOpen
+		if *state == PullRequestClosed {
+			status = types.PullRequestStatusEnumClosed
+		}
+		_, err = codeCommitClient.UpdatePullRequestStatus(ctx, &codecommit.UpdatePullRequestStatusInput{PullRequestId: id, PullRequestStatus: status})
+	}
+	return err
+}
+
+// ApprovePullRequest on AWS CodeCommit
+func (client *AWSCodeCommitClient) ApprovePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := client.getPullRequest(ctx, codeCommitClient, pullRequestID)
+	if err != nil {
+		return err
+	}
+	// Approvals apply to a revision of the pull request, which changes when the source branch is updated
+	_, err = codeCommitClient.UpdatePullRequestApprovalState(ctx, &codecommit.UpdatePullRequestApprovalStateInput{
+		PullRequestId: pullRequest.PullRequestId,
+		RevisionId:    pullRequest.RevisionId,
+		ApprovalState: types.ApprovalStateApprove,
+	})
+	return err
+}
+
+// SubmitPullRequestReview on AWS CodeCommit. The review body is added as a pull request comment.
+func (client *AWSCodeCommitClient) SubmitPullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, reviewEvent ReviewEvent, body string) error {
+	if reviewEvent == ReviewRequestChanges {
+		return errAWSCodeCommitRequestChangesNotSupported
+	}
+	if reviewEvent == ReviewApprove {
+		if err := client.ApprovePullRequest(ctx, owner, repository, pullRequestID); err != nil {
+			return err
+		}
+	}
+	if body == "" {
+		return nil
+	}
+	return client.AddPullRequestComment(ctx, owner, repository, body, pullRequestID)
+}
+
+// AddPullRequestComment on AWS CodeCommit
+func (client *AWSCodeCommitClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := client.getPullRequest(ctx, codeCommitClient, pullRequestID)
+	if err != nil {
+		return err
+	}
+	// Comments are posted on the comparison between the commits of the target and source branches
+	target := getAWSCodeCommitPullRequestTarget(pullRequest)
+	_, err = codeCommitClient.PostCommentForPullRequest(ctx, &codecommit.PostCommentForPullRequestInput{
+		PullRequestId:  pullRequest.PullRequestId,
+		RepositoryName: aws.String(repository),
+		BeforeCommitId: target.DestinationCommit,
+		AfterCommitId:  target.SourceCommit,
+		Content:        aws.String(content),
+	})
+	return err
+}
+
+// ListPullRequestComments on AWS CodeCommit. The comment IDs aren't numeric, so the IDs of the returned comments are zero.
+func (client *AWSCodeCommitClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []CommentInfo
+	input := &codecommit.GetCommentsForPullRequestInput{PullRequestId: aws.String(strconv.Itoa(pullRequestID)), RepositoryName: aws.String(repository)}
+	for {
+		output, err := codeCommitClient.GetCommentsForPullRequest(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, commentsForPullRequest := range output.CommentsForPullRequestData {
+			for _, comment := range commentsForPullRequest.Comments {
+				if comment.Deleted {
+					continue
+				}
+				results = append(results, CommentInfo{Content: aws.ToString(comment.Content), Created: aws.ToTime(comment.CreationDate)})
+			}
+		}
+		if output.NextToken == nil {
+			return results, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// UpdatePullRequestComment on AWS CodeCommit
+func (client *AWSCodeCommitClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int, commentID int64) error {
+	return errAWSCodeCommitCommentIDsNotSupported
+}
+
+// DeletePullRequestComment on AWS CodeCommit
+func (client *AWSCodeCommitClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) error {
+	return errAWSCodeCommitCommentIDsNotSupported
+}
+
+// AddCommentReaction on AWS CodeCommit
+func (client *AWSCodeCommitClient) AddCommentReaction(ctx context.Context, owner, repository string, pullRequestID int, commentID int64, reaction Reaction) error {
+	return errAWSCodeCommitCommentIDsNotSupported
+}
+
+// ListCommentReactions on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListCommentReactions(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) ([]ReactionInfo, error) {
+	return nil, errAWSCodeCommitCommentIDsNotSupported
+}
+
+// AddPullRequestReviewComment on AWS CodeCommit
+func (client *AWSCodeCommitClient) AddPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) error {
+	return errAWSCodeCommitReviewCommentsNotSupported
+}
+
+// ListPullRequestReviewComments on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewComment, error) {
+	return nil, errAWSCodeCommitReviewCommentsNotSupported
+}
+
+// CreatePullRequestThread on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreatePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (string, error) {
+	return "", errAWSCodeCommitReviewCommentsNotSupported
+}
+
+// ResolvePullRequestThread on AWS CodeCommit
+func (client *AWSCodeCommitClient) ResolvePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	return errAWSCodeCommitReviewCommentsNotSupported
+}
+
+// ListOpenPullRequests on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	return client.ListOpenPullRequestsWithFilter(ctx, owner, repository, PullRequestFilter{})
+}
+
+// ListOpenPullRequestsWithFilter on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListOpenPullRequestsWithFilter(ctx context.Context, owner, repository string, filter PullRequestFilter) ([]PullRequestInfo, error) {
+	return listPageOrAll(ctx, client.ListOpenPullRequestsPager(owner, repository, filter), filter.Page)
+}
+
+// ListOpenPullRequestsPager on AWS CodeCommit. The API lists the pull request IDs only, so each pull request is fetched separately.
+func (client *AWSCodeCommitClient) ListOpenPullRequestsPager(owner, repository string, filter PullRequestFilter) *Pager[PullRequestInfo] {
+	input := &codecommit.ListPullRequestsInput{RepositoryName: aws.String(repository), PullRequestStatus: types.PullRequestStatusEnumOpen}
+	if filter.PerPage > 0 {
+		input.MaxResults = aws.Int32(int32(filter.PerPage))
+	}
+	// Pages are requested by the token of the previous page, so the pages before the requested page are fetched and skipped
+	pagesToSkip := firstPage(filter.Page) - 1
+	return newPager(func(ctx context.Context) ([]PullRequestInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		codeCommitClient, err := client.buildCodeCommitClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		output, err := codeCommitClient.ListPullRequests(ctx, input)
+		for ; err == nil && pagesToSkip > 0 && output.NextToken != nil; pagesToSkip-- {
+			input.NextToken = output.NextToken
+			output, err = codeCommitClient.ListPullRequests(ctx, input)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if pagesToSkip > 0 {
+			return nil, false, nil
+		}
+		var results []PullRequestInfo
+		for _, id := range output.PullRequestIds {
+			pullRequestOutput, err := codeCommitClient.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: aws.String(id)})
+			if err != nil {
+				return nil, false, err
+			}
+			pullRequestInfo := mapAWSCodeCommitPullRequestToPullRequestInfo(pullRequestOutput.PullRequest)
+			if filter.matches(pullRequestInfo, aws.ToTime(pullRequestOutput.PullRequest.LastActivityDate)) {
+				results = append(results, pullRequestInfo)
+			}
+		}
+		input.NextToken = output.NextToken
+		return results, input.NextToken != nil, nil
+	})
+}
+
+// GetPullRequestByID on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestInfo, error) {
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	pullRequest, err := client.getPullRequest(ctx, codeCommitClient, pullRequestID)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapAWSCodeCommitPullRequestToPullRequestInfo(pullRequest), nil
+}
+
+// GetPullRequestDetails on AWS CodeCommit. Commit statuses aren't supported, so they aren't listed
+func (client *AWSCodeCommitClient) GetPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestDetails, error) {
+	return getPullRequestDetails(ctx, client, owner, repository, pullRequestID, false)
+}
+
+func (client *AWSCodeCommitClient) getPullRequest(ctx context.Context, codeCommitClient *codecommit.Client, pullRequestID int) (*types.PullRequest, error) {
+	output, err := codeCommitClient.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: aws.String(strconv.Itoa(pullRequestID))})
+	if err != nil {
+		return nil, err
+	}
+	return output.PullRequest, nil
+}
+
+// ListPullRequestFiles on AWS CodeCommit. The number of added and deleted lines isn't available.
+func (client *AWSCodeCommitClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pullRequest, err := client.getPullRequest(ctx, codeCommitClient, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	// The files are compared with the merge base, so changes made to the target branch after the source branch was created aren't listed
+	target := getAWSCodeCommitPullRequestTarget(pullRequest)
+	input := &codecommit.GetDifferencesInput{
+		RepositoryName:        aws.String(repository),
+		BeforeCommitSpecifier: target.MergeBase,
+		AfterCommitSpecifier:  target.SourceCommit,
+	}
+	var results []PullRequestFile
+	for {
+		output, err := codeCommitClient.GetDifferences(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, difference := range output.Differences {
+			results = append(results, mapAWSCodeCommitDifferenceToPullRequestFile(difference))
+		}
+		if output.NextToken == nil {
+			return results, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// GetPullRequestMergeableState on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetPullRequestMergeableState(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestMergeableState, error) {
+	return PullRequestMergeableState{}, errAWSCodeCommitPullRequestDetailsNotSupported
+}
+
+// GetPullRequestDiff on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	return "", errAWSCodeCommitPullRequestDetailsNotSupported
+}
+
+// ListPullRequestCommits on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListPullRequestCommits(ctx context.Context, owner, repository string, pullRequestID int) ([]CommitInfo, error) {
+	return nil, errAWSCodeCommitPullRequestDetailsNotSupported
+}
+
+// GetDefaultReviewers on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetDefaultReviewers(ctx context.Context, owner, repository, sourceBranch, targetBranch string) ([]string, error) {
+	return nil, errAWSCodeCommitDefaultReviewersNotSupported
+}
+
+// GetLatestCommit on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	output, err := codeCommitClient.GetBranch(ctx, &codecommit.GetBranchInput{RepositoryName: aws.String(repository), BranchName: aws.String(branch)})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return client.getCommit(ctx, codeCommitClient, repository, aws.ToString(output.Branch.CommitId))
+}
+
+// AddSshKeyToRepository on AWS CodeCommit
+func (client *AWSCodeCommitClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
+	return errAWSCodeCommitKeysNotSupported
+}
+
+// ListDeployKeys on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListDeployKeys(ctx context.Context, owner, repository string) ([]DeployKeyInfo, error) {
+	return nil, errAWSCodeCommitKeysNotSupported
+}
+
+// AddDeployKey on AWS CodeCommit
+func (client *AWSCodeCommitClient) AddDeployKey(ctx context.Context, owner, repository, title, key string, readOnly bool) (DeployKeyInfo, error) {
+	return DeployKeyInfo{}, errAWSCodeCommitKeysNotSupported
+}
+
+// DeleteDeployKey on AWS CodeCommit
+func (client *AWSCodeCommitClient) DeleteDeployKey(ctx context.Context, owner, repository string, keyID int) error {
+	return errAWSCodeCommitKeysNotSupported
+}
+
+// GetRepositoryInfo on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	output, err := codeCommitClient.GetRepository(ctx, &codecommit.GetRepositoryInput{RepositoryName: aws.String(repository)})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapAWSCodeCommitRepositoryToRepositoryInfo(*output.RepositoryMetadata), nil
+}
+
+// GetRepositoryLanguages on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetRepositoryLanguages(_ context.Context, _, _ string) (map[string]float64, error) {
+	return nil, errAWSCodeCommitLanguagesNotSupported
+}
+
+// CreateRepository on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateRepository(ctx context.Context, owner, name string, options CreateRepositoryOptions) error {
+	err := validateParametersNotBlank(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+	if err = validateCreateRepositoryOptions(options); err != nil {
+		return err
+	}
+	if !options.isPrivate() {
+		return errAWSCodeCommitVisibilityNotSupported
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	input := &codecommit.CreateRepositoryInput{RepositoryName: aws.String(name)}
+	if options.Description != "" {
+		input.RepositoryDescription = aws.String(options.Description)
+	}
+	if _, err = codeCommitClient.CreateRepository(ctx, input); err != nil || !options.InitWithReadme {
+		return err
+	}
+	// New repositories are empty, and the branch of the first commit becomes the default branch
+	defaultBranch := options.DefaultBranch
+	if defaultBranch == "" {
+		defaultBranch = "main"
+	}
+	_, err = codeCommitClient.CreateCommit(ctx, &codecommit.CreateCommitInput{
+		RepositoryName: aws.String(name),
+		BranchName:     aws.String(defaultBranch),
+		CommitMessage:  aws.String("Initial commit"),
+		PutFiles:       []types.PutFileEntry{{FilePath: aws.String("README.md"), FileContent: []byte("# " + name + "\n")}},
+	})
+	return err
+}
+
+// DeleteRepository on AWS CodeCommit
+func (client *AWSCodeCommitClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = codeCommitClient.DeleteRepository(ctx, &codecommit.DeleteRepositoryInput{RepositoryName: aws.String(repository)})
+	return err
+}
+
+// ForkRepository on AWS CodeCommit
+func (client *AWSCodeCommitClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string, waitUntilReady bool) (ForkInfo, error) {
+	return ForkInfo{}, errAWSCodeCommitForksNotSupported
+}
+
+// ListCollaborators on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	return nil, errAWSCodeCommitCollaboratorsNotSupported
+}
+
+// AddCollaborator on AWS CodeCommit
+func (client *AWSCodeCommitClient) AddCollaborator(ctx context.Context, owner, repository, username string, permission RepositoryPermission) error {
+	return errAWSCodeCommitCollaboratorsNotSupported
+}
+
+// RemoveCollaborator on AWS CodeCommit
+func (client *AWSCodeCommitClient) RemoveCollaborator(ctx context.Context, owner, repository, username string) error {
+	return errAWSCodeCommitCollaboratorsNotSupported
+}
+
+// GetUserPermission on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetUserPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	return PermissionNone, errAWSCodeCommitCollaboratorsNotSupported
+}
+
+// GetCommitBySha on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "sha": sha})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return client.getCommit(ctx, codeCommitClient, repository, sha)
+}
+
+func (client *AWSCodeCommitClient) getCommit(ctx context.Context, codeCommitClient *codecommit.Client, repository, sha string) (CommitInfo, error) {
+	output, err := codeCommitClient.GetCommit(ctx, &codecommit.GetCommitInput{RepositoryName: aws.String(repository), CommitId: aws.String(sha)})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapAWSCodeCommitCommitToCommitInfo(output.Commit), nil
+}
+
+// ListCommits on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	return nil, errAWSCodeCommitCommitHistoryNotSupported
+}
+
+// GetCommit on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetCommit(ctx context.Context, owner, repository, sha string) (CommitDetails, error) {
+	return CommitDetails{}, errAWSCodeCommitCommitHistoryNotSupported
+}
+
+// GetCommitVerification on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetCommitVerification(ctx context.Context, owner, repository, sha string) (CommitVerificationInfo, error) {
+	return CommitVerificationInfo{}, errAWSCodeCommitCommitVerificationNotSupported
+}
+
+// CompareCommits on AWS CodeCommit
+func (client *AWSCodeCommitClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	return CommitsComparison{}, errAWSCodeCommitCommitHistoryNotSupported
+}
+
+// GetCommitsBetween on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetCommitsBetween(ctx context.Context, owner, repository, fromSha, toSha string) ([]CommitInfo, error) {
+	return nil, errAWSCodeCommitCommitHistoryNotSupported
+}
+
+// ListContributors on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListContributors(_ context.Context, _, _ string) ([]ContributorInfo, error) {
+	return nil, errAWSCodeCommitCommitHistoryNotSupported
+}
+
+// GetCommitActivity on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetCommitActivity(_ context.Context, _, _ string, _ time.Time) ([]CommitActivityInfo, error) {
+	return nil, errAWSCodeCommitCommitHistoryNotSupported
+}
+
+// CreateLabel on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	return errAWSCodeCommitLabelsNotSupported
+}
+
+// GetLabel on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	return nil, errAWSCodeCommitLabelsNotSupported
+}
+
+// ListLabels on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	return nil, errAWSCodeCommitLabelsNotSupported
+}
+
+// ListLabelsPager on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListLabelsPager(owner, repository string, perPage int) *Pager[LabelInfo] {
+	return newPager(func(ctx context.Context) ([]LabelInfo, bool, error) {
+		return nil, false, errAWSCodeCommitLabelsNotSupported
+	})
+}
+
+// UpdateLabel on AWS CodeCommit
+func (client *AWSCodeCommitClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	return errAWSCodeCommitLabelsNotSupported
+}
+
+// DeleteLabel on AWS CodeCommit
+func (client *AWSCodeCommitClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	return errAWSCodeCommitLabelsNotSupported
+}
+
+// ListPullRequestLabels on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	return nil, errAWSCodeCommitLabelsNotSupported
+}
+
+// UnlabelPullRequest on AWS CodeCommit
+func (client *AWSCodeCommitClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	return errAWSCodeCommitLabelsNotSupported
+}
+
+// CreateIssue on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateIssue(ctx context.Context, owner, repository, title, body string, labels []string) (IssueInfo, error) {
+	return IssueInfo{}, errAWSCodeCommitIssuesNotSupported
+}
+
+// AddIssueComment on AWS CodeCommit
+func (client *AWSCodeCommitClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	return errAWSCodeCommitIssuesNotSupported
+}
+
+// ListIssues on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListIssues(ctx context.Context, owner, repository string, filter IssueFilter) ([]IssueInfo, error) {
+	return nil, errAWSCodeCommitIssuesNotSupported
+}
+
+// UpdateIssueState on AWS CodeCommit
+func (client *AWSCodeCommitClient) UpdateIssueState(ctx context.Context, owner, repository string, issueID int, state IssueState) error {
+	return errAWSCodeCommitIssuesNotSupported
+}
+
+// UploadCodeScanning on AWS CodeCommit
+func (client *AWSCodeCommitClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	return "", errAWSCodeCommitCodeScanningNotSupported
+}
+
+// UploadScanResults on AWS CodeCommit
+func (client *AWSCodeCommitClient) UploadScanResults(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	return "", errAWSCodeCommitCodeScanningNotSupported
+}
+
+// ListSecurityAlerts on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListSecurityAlerts(ctx context.Context, owner, repository string) ([]SecurityAlertInfo, error) {
+	return nil, errAWSCodeCommitSecurityAlertsNotSupported
+}
+
+// DownloadFileFromRepo on AWS CodeCommit
+func (client *AWSCodeCommitClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	output, err := codeCommitClient.GetFile(ctx, &codecommit.GetFileInput{
+		RepositoryName:  aws.String(repository),
+		CommitSpecifier: aws.String(branch),
+		FilePath:        aws.String(path),
+	})
+	// CodeCommit responds with 400 to requests for missing files
+	var fileNotFoundErr *types.FileDoesNotExistException
+	if errors.As(err, &fileNotFoundErr) {
+		return nil, http.StatusNotFound, err
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return output.FileContent, http.StatusOK, nil
+}
+
+// GetFileContent on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetFileContent(ctx context.Context, owner, repository, ref, path string) (FileContent, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "ref": ref, "path": path})
+	if err != nil {
+		return FileContent{}, err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return FileContent{}, err
+	}
+	output, err := codeCommitClient.GetFile(ctx, &codecommit.GetFileInput{
+		RepositoryName:  aws.String(repository),
+		CommitSpecifier: aws.String(ref),
+		FilePath:        aws.String(path),
+	})
+	if err != nil {
+		return FileContent{}, err
+	}
+	return FileContent{Path: aws.ToString(output.FilePath), Content: output.FileContent, Size: output.FileSize, Sha: aws.ToString(output.BlobId)}, nil
+}
+
+// GetFileBlame on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetFileBlame(_ context.Context, _, _, _, _ string) ([]BlameLine, error) {
+	return nil, errAWSCodeCommitBlameNotSupported
+}
+
+// ListRepositoryTree on AWS CodeCommit. Each directory is listed in a separate request, and the sizes of the files aren't provided.
+func (client *AWSCodeCommitClient) ListRepositoryTree(ctx context.Context, _, repository, ref string, recursive bool) ([]TreeEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return walkRepositoryTree(ctx, recursive, func(ctx context.Context, directory string) ([]TreeEntry, error) {
+		output, err := codeCommitClient.GetFolder(ctx, &codecommit.GetFolderInput{
+			RepositoryName:  aws.String(repository),
+			CommitSpecifier: aws.String(ref),
+			FolderPath:      aws.String("/" + directory),
+		})
+		if err != nil {
+			return nil, err
+		}
+		var results []TreeEntry
+		for _, folder := range output.SubFolders {
+			results = append(results, TreeEntry{Path: strings.TrimPrefix(aws.ToString(folder.AbsolutePath), "/"), Type: TreeEntryDirectory})
+		}
+		for _, file := range output.Files {
+			results = append(results, TreeEntry{Path: strings.TrimPrefix(aws.ToString(file.AbsolutePath), "/"), Type: TreeEntryFile})
+		}
+		for _, symbolicLink := range output.SymbolicLinks {
+			results = append(results, TreeEntry{Path: strings.TrimPrefix(aws.ToString(symbolicLink.AbsolutePath), "/"), Type: TreeEntryFile})
+		}
+		for _, subModule := range output.SubModules {
+			results = append(results, TreeEntry{Path: strings.TrimPrefix(aws.ToString(subModule.AbsolutePath), "/"), Type: TreeEntrySubmodule})
+		}
+		return results, nil
+	})
+}
+
+// SearchCode on AWS CodeCommit
+func (client *AWSCodeCommitClient) SearchCode(ctx context.Context, scope CodeSearchScope, query string) ([]CodeSearchResult, error) {
+	return nil, errAWSCodeCommitCodeSearchNotSupported
+}
+
+// CreateOrUpdateFile on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateOrUpdateFile(ctx context.Context, owner, repository, branch, path string, content []byte, commitMessage string) error {
+	return client.CommitFiles(ctx, owner, repository, branch, commitMessage, []FileChange{{Type: UpdateFile, Path: path, Content: content}})
+}
+
+// CommitFiles on AWS CodeCommit
+func (client *AWSCodeCommitClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, changes []FileChange) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch, "commitMessage": commitMessage})
+	if err != nil {
+		return err
+	}
+	codeCommitClient, err := client.buildCodeCommitClient(ctx)
+	if err != nil {
+		return err
+	}
+	// The commit must be based on the head of the branch
+	branchOutput, err := codeCommitClient.GetBranch(ctx, &codecommit.GetBranchInput{RepositoryName: aws.String(repository), BranchName: aws.String(branch)})
+	if err != nil {
+		return err
+	}
+	input := &codecommit.CreateCommitInput{
+		RepositoryName: aws.String(repository),
+		BranchName:     aws.String(branch),
+		ParentCommitId: branchOutput.Branch.CommitId,
+		CommitMessage:  aws.String(commitMessage),
+	}
+	for _, change := range changes {
+		if change.Type == DeleteFile {
+			input.DeleteFiles = append(input.DeleteFiles, types.DeleteFileEntry{FilePath: aws.String(change.Path)})
+			continue
+		}
+		input.PutFiles = append(input.PutFiles, types.PutFileEntry{FilePath: aws.String(change.Path), FileContent: change.Content})
+	}
+	_, err = codeCommitClient.CreateCommit(ctx, input)
+	return err
+}
+
+// GetRepositoryEnvironmentInfo on AWS CodeCommit
+func (client *AWSCodeCommitClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errAWSCodeCommitEnvironmentsNotSupported
+}
+
+// ListEnvironments on AWS CodeCommit
+func (client *AWSCodeCommitClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errAWSCodeCommitEnvironmentsNotSupported
+}
+
+// CreateDeployment on AWS CodeCommit
+func (client *AWSCodeCommitClient) CreateDeployment(ctx context.Context, owner, repository string, deployment DeploymentInfo) (int64, error) {
+	return 0, errAWSCodeCommitEnvironmentsNotSupported
+}
+
+// SetDeploymentStatus on AWS CodeCommit
+func (client *AWSCodeCommitClient) SetDeploymentStatus(ctx context.Context, owner, repository string, deploymentID int64, status DeploymentStatusInfo) error {
+	return errAWSCodeCommitEnvironmentsNotSupported
+}
+
+// Pull requests are opened between branches of a single repository, so they have a single target
+func getAWSCodeCommitPullRequestTarget(pullRequest *types.PullRequest) types.PullRequestTarget {
+	if len(pullRequest.PullRequestTargets) == 0 {
+		return types.PullRequestTarget{}
+	}
+	return pullRequest.PullRequestTargets[0]
+}
+
+func mapAWSCodeCommitPullRequestToPullRequestInfo(pullRequest *types.PullRequest) PullRequestInfo {
+	id, _ := strconv.ParseInt(aws.ToString(pullRequest.PullRequestId), 10, 64)
+	target := getAWSCodeCommitPullRequestTarget(pullRequest)
+	state := PullRequestOpen
+	if pullRequest.PullRequestStatus == types.PullRequestStatusEnumClosed {
+		state = PullRequestClosed
+		if target.MergeMetadata != nil && target.MergeMetadata.IsMerged {
+			state = PullRequestMerged
+		}
+	}
+	return PullRequestInfo{
+		ID: id,
+		Source: BranchInfo{
+			Name:       strings.TrimPrefix(aws.ToString(target.SourceReference), awsCodeCommitBranchPrefix),
+			Repository: aws.ToString(target.RepositoryName),
+		},
+		Target: BranchInfo{
+			Name:       strings.TrimPrefix(aws.ToString(target.DestinationReference), awsCodeCommitBranchPrefix),
+			Repository: aws.ToString(target.RepositoryName),
+		},
+		Title:  aws.ToString(pullRequest.Title),
+		Body:   aws.ToString(pullRequest.Description),
+		State:  state,
+		Author: getAWSCodeCommitUsername(aws.ToString(pullRequest.AuthorArn)),
+	}
+}
+
+func mapAWSCodeCommitRepositoryToRepositoryInfo(repository types.RepositoryMetadata) RepositoryInfo {
+	return RepositoryInfo{
+		CloneInfo:            CloneInfo{HTTP: aws.ToString(repository.CloneUrlHttp), SSH: aws.ToString(repository.CloneUrlSsh)},
+		RepositoryVisibility: Private,
+		DefaultBranch:        aws.ToString(repository.DefaultBranch),
+		Description:          aws.ToString(repository.RepositoryDescription),
+	}
+}
+
+func mapAWSCodeCommitCommitToCommitInfo(commit *types.Commit) CommitInfo {
+	commitInfo := CommitInfo{
+		Hash:         aws.ToString(commit.CommitId),
+		Message:      aws.ToString(commit.Message),
+		ParentHashes: commit.Parents,
+	}
+	if commit.Author != nil {
+		commitInfo.AuthorName = aws.ToString(commit.Author.Name)
+	}
+	if commit.Committer != nil {
+		commitInfo.CommitterName = aws.ToString(commit.Committer.Name)
+		// The date is formatted as in git objects, in seconds from epoch followed by the time zone offset, such as "1484167798 -0800"
+		commitInfo.Timestamp, _ = strconv.ParseInt(strings.Fields(aws.ToString(commit.Committer.Date) + " ")[0], 10, 64)
+	}
+	return commitInfo
+}
+
+func mapAWSCodeCommitDifferenceToPullRequestFile(difference types.Difference) PullRequestFile {
+	var beforePath, afterPath string
+	if difference.BeforeBlob != nil {
+		beforePath = aws.ToString(difference.BeforeBlob.Path)
+	}
+	if difference.AfterBlob != nil {
+		afterPath = aws.ToString(difference.AfterBlob.Path)
+	}
+	switch {
+	case difference.ChangeType == types.ChangeTypeEnumAdded:
+		return PullRequestFile{Path: afterPath, Status: FileAdded}
+	case difference.ChangeType == types.ChangeTypeEnumDeleted:
+		return PullRequestFile{Path: beforePath, Status: FileDeleted}
+	case beforePath != afterPath:
+		return PullRequestFile{Path: afterPath, PreviousPath: beforePath, Status: FileRenamed}
+	default:
+		return PullRequestFile{Path: afterPath, Status: FileModified}
+	}
+}
+
+// Get the name of the IAM user or of the role session from an ARN such as arn:aws:iam::123456789012:user/frogger
+func getAWSCodeCommitUsername(userArn string) string {
+	return userArn[strings.LastIndex(userArn, "/")+1:]
+}
+
+// Make sure the client implements the interface
+var _ VcsClient = (*AWSCodeCommitClient)(nil)
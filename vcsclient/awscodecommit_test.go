@@ -0,0 +1,455 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	awsCodeCommitAccount    = "123456789012"
+	awsCodeCommitCommitHash = "5c4ef1049f1d27deadbeeff313e0730018be182b"
+	awsCodeCommitMergeBase  = "3b1b0c2e6f3a4f5e9c8d7a6b5c4d3e2f1a0b9c8d"
+)
+
+var awsCodeCommitPullRequest = map[string]interface{}{
+	"pullRequestId":     "1",
+	"title":             "Update README.md",
+	"description":       "Frogs are green",
+	"pullRequestStatus": "OPEN",
+	"authorArn":         "arn:aws:iam::123456789012:user/frogger",
+	"revisionId":        "revision-1",
+	"lastActivityDate":  1679217652,
+	"pullRequestTargets": []map[string]interface{}{{
+		"repositoryName":       repo1,
+		"sourceReference":      "refs/heads/" + branch1,
+		"destinationReference": "refs/heads/" + branch2,
+		"sourceCommit":         awsCodeCommitCommitHash,
+		"destinationCommit":    awsCodeCommitMergeBase,
+		"mergeBase":            awsCodeCommitMergeBase,
+	}},
+}
+
+var awsCodeCommitRepository = map[string]interface{}{
+	"accountId":             awsCodeCommitAccount,
+	"repositoryName":        repo1,
+	"repositoryDescription": "Frog repository",
+	"defaultBranch":         branch1,
+	"cloneUrlHttp":          "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/" + repo1,
+	"cloneUrlSsh":           "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/" + repo1,
+	"lastModifiedDate":      1679217652,
+}
+
+func TestAWSCodeCommitClient_Connection(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{"ListRepositories": map[string]interface{}{}})
+
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.Len(t, requests["ListRepositories"], 1)
+
+	err := createBadAWSCodeCommitClient(t).TestConnection(ctx)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestAWSCodeCommitClient_ListRepositories(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"ListRepositories":     map[string]interface{}{"repositories": []map[string]interface{}{{"repositoryName": repo1}}},
+		"BatchGetRepositories": map[string]interface{}{"repositories": []map[string]interface{}{awsCodeCommitRepository}},
+	})
+
+	actualRepositories, err := client.ListRepositories(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{awsCodeCommitAccount: {repo1}}, actualRepositories)
+	assert.Equal(t, []interface{}{repo1}, requests["BatchGetRepositories"][0]["repositoryNames"])
+
+	details, err := client.ListRepositoriesWithOptions(ctx, "", ListRepositoriesOptions{})
+	require.NoError(t, err)
+	require.Len(t, details, 1)
+	assert.Equal(t, time.Unix(1679217652, 0), details[0].Updated.Local())
+	assert.Equal(t, Private, details[0].RepositoryInfo.RepositoryVisibility)
+}
+
+func TestAWSCodeCommitClient_Branches(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"ListBranches": map[string]interface{}{"branches": []string{branch1, branch2}},
+		"GetBranch":    map[string]interface{}{"branch": map[string]interface{}{"branchName": branch1, "commitId": awsCodeCommitCommitHash}},
+		"CreateBranch": map[string]interface{}{},
+		"DeleteBranch": map[string]interface{}{},
+	})
+
+	branches, err := client.ListBranches(ctx, "", repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{branch1, branch2}, branches)
+
+	allBranches, err := client.ListAllBranches(ctx, "", repo1, "branch-1")
+	require.NoError(t, err)
+	assert.Equal(t, []BranchDetails{{Name: branch1, CommitHash: awsCodeCommitCommitHash}}, allBranches)
+
+	require.NoError(t, client.CreateBranch(ctx, "", repo1, "new-branch", branch1))
+	assert.Equal(t, map[string]interface{}{"repositoryName": repo1, "branchName": "new-branch", "commitId": awsCodeCommitCommitHash}, requests["CreateBranch"][0])
+
+	require.NoError(t, client.DeleteBranch(ctx, "", repo1, "new-branch"))
+	assert.Equal(t, map[string]interface{}{"repositoryName": repo1, "branchName": "new-branch"}, requests["DeleteBranch"][0])
+}
+
+func TestAWSCodeCommitClient_CreatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{"CreatePullRequest": map[string]interface{}{"pullRequest": awsCodeCommitPullRequest}})
+
+	require.NoError(t, client.CreatePullRequest(ctx, "", repo1, branch1, branch2, "PR title", "PR body"))
+	request := requests["CreatePullRequest"][0]
+	assert.Equal(t, "PR title", request["title"])
+	assert.Equal(t, "PR body", request["description"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"repositoryName": repo1, "sourceReference": branch1, "destinationReference": branch2}}, request["targets"])
+}
+
+func TestAWSCodeCommitClient_GetPullRequestByID(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{"GetPullRequest": map[string]interface{}{"pullRequest": awsCodeCommitPullRequest}})
+
+	pullRequest, err := client.GetPullRequestByID(ctx, "", repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestInfo{
+		ID:     1,
+		Title:  "Update README.md",
+		Body:   "Frogs are green",
+		State:  PullRequestOpen,
+		Author: username,
+		Source: BranchInfo{Name: branch1, Repository: repo1},
+		Target: BranchInfo{Name: branch2, Repository: repo1},
+	}, pullRequest)
+	assert.Equal(t, "1", requests["GetPullRequest"][0]["pullRequestId"])
+}
+
+func TestAWSCodeCommitClient_ListOpenPullRequests(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"ListPullRequests": map[string]interface{}{"pullRequestIds": []string{"1"}},
+		"GetPullRequest":   map[string]interface{}{"pullRequest": awsCodeCommitPullRequest},
+	})
+
+	pullRequests, err := client.ListOpenPullRequests(ctx, "", repo1)
+	require.NoError(t, err)
+	require.Len(t, pullRequests, 1)
+	assert.Equal(t, int64(1), pullRequests[0].ID)
+	assert.Equal(t, "OPEN", requests["ListPullRequests"][0]["pullRequestStatus"])
+
+	pullRequests, err = client.ListOpenPullRequestsWithFilter(ctx, "", repo1, PullRequestFilter{SourceBranch: branch2})
+	require.NoError(t, err)
+	assert.Empty(t, pullRequests)
+}
+
+func TestAWSCodeCommitClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"MergePullRequestByThreeWay":    map[string]interface{}{},
+		"MergePullRequestBySquash":      map[string]interface{}{},
+		"MergePullRequestByFastForward": map[string]interface{}{},
+	})
+
+	require.NoError(t, client.MergePullRequest(ctx, "", repo1, 1, MergeCommit, "Merge message"))
+	assert.Equal(t, map[string]interface{}{"pullRequestId": "1", "repositoryName": repo1, "commitMessage": "Merge message"}, requests["MergePullRequestByThreeWay"][0])
+	require.NoError(t, client.MergePullRequest(ctx, "", repo1, 1, SquashMerge, ""))
+	assert.Equal(t, map[string]interface{}{"pullRequestId": "1", "repositoryName": repo1}, requests["MergePullRequestBySquash"][0])
+	require.NoError(t, client.MergePullRequest(ctx, "", repo1, 1, RebaseMerge, ""))
+	assert.Len(t, requests["MergePullRequestByFastForward"], 1)
+}
+
+func TestAWSCodeCommitClient_UpdatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"UpdatePullRequestTitle":       map[string]interface{}{"pullRequest": awsCodeCommitPullRequest},
+		"UpdatePullRequestDescription": map[string]interface{}{"pullRequest": awsCodeCommitPullRequest},
+		"UpdatePullRequestStatus":      map[string]interface{}{"pullRequest": awsCodeCommitPullRequest},
+	})
+
+	state := PullRequestClosed
+	require.NoError(t, client.UpdatePullRequest(ctx, "", repo1, "New title", "New body", "", 1, &state))
+	assert.Equal(t, "New title", requests["UpdatePullRequestTitle"][0]["title"])
+	assert.Equal(t, "New body", requests["UpdatePullRequestDescription"][0]["description"])
+	assert.Equal(t, "CLOSED", requests["UpdatePullRequestStatus"][0]["pullRequestStatus"])
+
+	err := client.UpdatePullRequest(ctx, "", repo1, "", "", branch2, 1, nil)
+	assert.ErrorIs(t, err, errAWSCodeCommitRetargetPullRequestNotSupported)
+}
+
+func TestAWSCodeCommitClient_PullRequestReviews(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"GetPullRequest":                 map[string]interface{}{"pullRequest": awsCodeCommitPullRequest},
+		"UpdatePullRequestApprovalState": map[string]interface{}{},
+		"PostCommentForPullRequest":      map[string]interface{}{},
+	})
+
+	require.NoError(t, client.SubmitPullRequestReview(ctx, "", repo1, 1, ReviewApprove, "Looks good"))
+	assert.Equal(t, map[string]interface{}{"pullRequestId": "1", "revisionId": "revision-1", "approvalState": "APPROVE"}, requests["UpdatePullRequestApprovalState"][0])
+	// The idempotency token is generated by the AWS SDK
+	assert.NotEmpty(t, requests["PostCommentForPullRequest"][0]["clientRequestToken"])
+	delete(requests["PostCommentForPullRequest"][0], "clientRequestToken")
+	assert.Equal(t, map[string]interface{}{
+		"pullRequestId":  "1",
+		"repositoryName": repo1,
+		"beforeCommitId": awsCodeCommitMergeBase,
+		"afterCommitId":  awsCodeCommitCommitHash,
+		"content":        "Looks good",
+	}, requests["PostCommentForPullRequest"][0])
+
+	err := client.SubmitPullRequestReview(ctx, "", repo1, 1, ReviewRequestChanges, "")
+	assert.ErrorIs(t, err, errAWSCodeCommitRequestChangesNotSupported)
+}
+
+func TestAWSCodeCommitClient_ListPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	client, _ := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"GetCommentsForPullRequest": map[string]interface{}{"commentsForPullRequestData": []map[string]interface{}{{
+			"comments": []map[string]interface{}{
+				{"commentId": "comment-1", "content": "Hello", "creationDate": 1679217652},
+				{"commentId": "comment-2", "deleted": true, "creationDate": 1679217652},
+			},
+		}}},
+	})
+
+	comments, err := client.ListPullRequestComments(ctx, "", repo1, 1)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "Hello", comments[0].Content)
+	assert.Equal(t, int64(1679217652), comments[0].Created.Unix())
+}
+
+func TestAWSCodeCommitClient_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"GetPullRequest": map[string]interface{}{"pullRequest": awsCodeCommitPullRequest},
+		"GetDifferences": map[string]interface{}{"differences": []map[string]interface{}{
+			{"changeType": "A", "afterBlob": map[string]interface{}{"path": "added.txt"}},
+			{"changeType": "D", "beforeBlob": map[string]interface{}{"path": "deleted.txt"}},
+			{"changeType": "M", "beforeBlob": map[string]interface{}{"path": "old.txt"}, "afterBlob": map[string]interface{}{"path": "new.txt"}},
+			{"changeType": "M", "beforeBlob": map[string]interface{}{"path": "README.md"}, "afterBlob": map[string]interface{}{"path": "README.md"}},
+		}},
+	})
+
+	files, err := client.ListPullRequestFiles(ctx, "", repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "added.txt", Status: FileAdded},
+		{Path: "deleted.txt", Status: FileDeleted},
+		{Path: "new.txt", PreviousPath: "old.txt", Status: FileRenamed},
+		{Path: "README.md", Status: FileModified},
+	}, files)
+	assert.Equal(t, awsCodeCommitMergeBase, requests["GetDifferences"][0]["beforeCommitSpecifier"])
+	assert.Equal(t, awsCodeCommitCommitHash, requests["GetDifferences"][0]["afterCommitSpecifier"])
+}
+
+func TestAWSCodeCommitClient_GetLatestCommit(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"GetBranch": map[string]interface{}{"branch": map[string]interface{}{"branchName": branch1, "commitId": awsCodeCommitCommitHash}},
+		"GetCommit": map[string]interface{}{"commit": map[string]interface{}{
+			"commitId":  awsCodeCommitCommitHash,
+			"message":   "Initial commit\n",
+			"parents":   []string{awsCodeCommitMergeBase},
+			"author":    map[string]interface{}{"name": "Frogger", "date": "1679217652 +0200"},
+			"committer": map[string]interface{}{"name": "Frogger", "date": "1679217652 +0200"},
+		}},
+	})
+
+	commit, err := client.GetLatestCommit(ctx, "", repo1, branch1)
+	require.NoError(t, err)
+	assert.Equal(t, CommitInfo{
+		Hash:          awsCodeCommitCommitHash,
+		AuthorName:    "Frogger",
+		CommitterName: "Frogger",
+		Timestamp:     1679217652,
+		Message:       "Initial commit\n",
+		ParentHashes:  []string{awsCodeCommitMergeBase},
+	}, commit)
+	assert.Equal(t, awsCodeCommitCommitHash, requests["GetCommit"][0]["commitId"])
+}
+
+func TestAWSCodeCommitClient_Repositories(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"GetRepository":    map[string]interface{}{"repositoryMetadata": awsCodeCommitRepository},
+		"CreateRepository": map[string]interface{}{"repositoryMetadata": awsCodeCommitRepository},
+		"CreateCommit":     map[string]interface{}{},
+		"DeleteRepository": map[string]interface{}{},
+	})
+
+	repositoryInfo, err := client.GetRepositoryInfo(ctx, "", repo1)
+	require.NoError(t, err)
+	assert.Equal(t, RepositoryInfo{
+		CloneInfo:            CloneInfo{HTTP: "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/" + repo1, SSH: "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/" + repo1},
+		RepositoryVisibility: Private,
+		DefaultBranch:        branch1,
+		Description:          "Frog repository",
+	}, repositoryInfo)
+
+	require.NoError(t, client.CreateRepository(ctx, "", repo1, CreateRepositoryOptions{Description: "Frog repository", InitWithReadme: true}))
+	assert.Equal(t, map[string]interface{}{"repositoryName": repo1, "repositoryDescription": "Frog repository"}, requests["CreateRepository"][0])
+	assert.Equal(t, "main", requests["CreateCommit"][0]["branchName"])
+
+	public := Public
+	err = client.CreateRepository(ctx, "", repo2, CreateRepositoryOptions{Visibility: &public})
+	assert.ErrorIs(t, err, errAWSCodeCommitVisibilityNotSupported)
+
+	require.NoError(t, client.DeleteRepository(ctx, "", repo1))
+	assert.Equal(t, map[string]interface{}{"repositoryName": repo1}, requests["DeleteRepository"][0])
+}
+
+func TestAWSCodeCommitClient_Files(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"GetFile":      map[string]interface{}{"filePath": "README.md", "fileContent": []byte("Hello"), "fileSize": 5, "blobId": "blob-1", "commitId": awsCodeCommitCommitHash},
+		"GetBranch":    map[string]interface{}{"branch": map[string]interface{}{"branchName": branch1, "commitId": awsCodeCommitCommitHash}},
+		"CreateCommit": map[string]interface{}{},
+	})
+
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, "", repo1, branch1, "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, []byte("Hello"), content)
+
+	fileContent, err := client.GetFileContent(ctx, "", repo1, branch1, "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, FileContent{Path: "README.md", Content: []byte("Hello"), Size: 5, Sha: "blob-1"}, fileContent)
+
+	changes := []FileChange{{Type: UpdateFile, Path: "README.md", Content: []byte("Hi")}, {Type: DeleteFile, Path: "old.txt"}}
+	require.NoError(t, client.CommitFiles(ctx, "", repo1, branch1, "Update files", changes))
+	request := requests["CreateCommit"][0]
+	assert.Equal(t, awsCodeCommitCommitHash, request["parentCommitId"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"filePath": "README.md", "fileContent": "SGk="}}, request["putFiles"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"filePath": "old.txt"}}, request["deleteFiles"])
+}
+
+func TestAWSCodeCommitClient_ListRepositoryTree(t *testing.T) {
+	ctx := context.Background()
+	client, requests := createAWSCodeCommitServerAndClient(t, map[string]interface{}{
+		"GetFolder": map[string]interface{}{
+			"folderPath":    "/",
+			"files":         []map[string]interface{}{{"absolutePath": "README.md", "relativePath": "README.md"}},
+			"subFolders":    []map[string]interface{}{{"absolutePath": "src", "relativePath": "src"}},
+			"subModules":    []map[string]interface{}{{"absolutePath": "vendor/lib", "relativePath": "lib"}},
+			"symbolicLinks": []map[string]interface{}{{"absolutePath": "LICENSE", "relativePath": "LICENSE"}},
+		},
+	})
+
+	tree, err := client.ListRepositoryTree(ctx, "", repo1, branch1, false)
+	require.NoError(t, err)
+	assert.Equal(t, []TreeEntry{
+		{Path: "src", Type: TreeEntryDirectory},
+		{Path: "README.md", Type: TreeEntryFile},
+		{Path: "LICENSE", Type: TreeEntryFile},
+		{Path: "vendor/lib", Type: TreeEntrySubmodule},
+	}, tree)
+	assert.Equal(t, map[string]interface{}{"repositoryName": repo1, "commitSpecifier": branch1, "folderPath": "/"}, requests["GetFolder"][0])
+}
+
+func TestAWSCodeCommitClient_DownloadFileFromRepoNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte(`{"__type":"FileDoesNotExistException","message":"File does not exist"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	_, statusCode, err := buildAPIErrorsClient(t, vcsutils.AWSCodeCommit, true, server).DownloadFileFromRepo(context.Background(), "", repo1, branch1, "README.md")
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAWSCodeCommitClient_RetryPolicy(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write([]byte(`{"__type":"ThrottlingException","message":"Rate exceeded"}`))
+			assert.NoError(t, err)
+			return
+		}
+		_, err := w.Write([]byte(`{}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	client, err := NewClientBuilder(vcsutils.AWSCodeCommit).ApiEndpoint(server.URL).Region(region).Username(username).Token(token).
+		RetryPolicy(RetryPolicy{MaxAttempts: 2, MaxBackoff: time.Millisecond}).Build()
+	require.NoError(t, err)
+	assert.NoError(t, client.TestConnection(context.Background()))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestAWSCodeCommitClient_UnsupportedOperations(t *testing.T) {
+	ctx := context.Background()
+	client, _ := createAWSCodeCommitServerAndClient(t, map[string]interface{}{})
+
+	_, _, err := client.CreateWebhook(ctx, "", repo1, branch1, "https://froggit.example.com", vcsutils.Push)
+	assert.ErrorIs(t, err, errAWSCodeCommitWebhooksNotSupported)
+	_, err = client.ListTags(ctx, "", repo1)
+	assert.ErrorIs(t, err, errAWSCodeCommitTagsNotSupported)
+	err = client.SetCommitStatus(ctx, Pass, "", repo1, branch1, "title", "description", "")
+	assert.ErrorIs(t, err, errAWSCodeCommitCommitStatusesNotSupported)
+	_, err = client.ListLabelsPager("", repo1, 0).Next(ctx)
+	assert.ErrorIs(t, err, errAWSCodeCommitLabelsNotSupported)
+	_, err = client.CreateDeployment(ctx, "", repo1, DeploymentInfo{Environment: envName, Ref: branch1})
+	assert.ErrorIs(t, err, errAWSCodeCommitEnvironmentsNotSupported)
+	_, err = client.ListSecurityAlerts(ctx, "", repo1)
+	assert.ErrorIs(t, err, errAWSCodeCommitSecurityAlertsNotSupported)
+	_, err = client.UploadScanResults(ctx, "", repo1, branch1, testSarifScanResults)
+	assert.ErrorIs(t, err, errAWSCodeCommitCodeScanningNotSupported)
+	err = client.CreateCodeInsightsReport(ctx, "", repo1, "abc123", CodeInsightsReport{ID: "report"})
+	assert.ErrorIs(t, err, errAWSCodeCommitCodeInsightsNotSupported)
+	err = client.AddCommentReaction(ctx, "", repo1, 1, 10, ReactionThumbsUp)
+	assert.ErrorIs(t, err, errAWSCodeCommitCommentIDsNotSupported)
+}
+
+// createAWSCodeCommitServerAndClient creates a server that responds to the operations with the responses, keyed by the names of the operations.
+// Returns the bodies of the requests received by the server, keyed by the names of the operations.
+func createAWSCodeCommitServerAndClient(t *testing.T, responses map[string]interface{}) (VcsClient, map[string][]map[string]interface{}) {
+	requests := make(map[string][]map[string]interface{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operation := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "CodeCommit_20150413.")
+		assert.Contains(t, r.Header.Get("Authorization"), "Credential="+username+"/")
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var request map[string]interface{}
+		assert.NoError(t, json.Unmarshal(body, &request))
+		requests[operation] = append(requests[operation], request)
+
+		response, exists := responses[operation]
+		if !assert.True(t, exists, operation) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+	return buildClient(t, vcsutils.AWSCodeCommit, true, server), requests
+}
+
+func createBadAWSCodeCommitClient(t *testing.T) VcsClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(http.StatusForbidden)
+		_, err := w.Write([]byte(`{"__type":"UnrecognizedClientException","message":"The security token included in the request is invalid."}`))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	return buildAPIErrorsClient(t, vcsutils.AWSCodeCommit, true, server)
+}
@@ -2,15 +2,45 @@ package vcsclient
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/location"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelines"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/projectanalysis"
 	"io"
 	"net/http"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
+)
+
+var (
+	// Azure Repos creates and deletes refs by updating them from or to the zero object ID
+	azureReposZeroObjectID = "0000000000000000000000000000000000000000"
+	commitShaRegexp        = regexp.MustCompile("^[0-9a-fA-F]{40}$")
+)
+
+var errAzureReposRepositorySettingsNotSupported = errors.New("the visibility, description and README of new repositories are not supported on Azure Repos")
+var errAzureReposUpdatedSinceFilterNotSupported = errors.New("filtering pull requests by update time is not supported on Azure Repos")
+var errAzureReposTarGzArchiveNotSupported = errors.New("downloading a tar.gz repository archive is not supported on Azure Repos, which supports zip archives only")
+
+// The number of pull requests fetched in each request, when the filter doesn't set the page size
+const azureReposPullRequestsPageSize = 100
+
+const azureReposTagsRefPrefix = "refs/tags/"
+
+// Azure Repos reviewer votes
+const (
+	azureReposApprovedVote      = 10
+	azureReposWaitForAuthorVote = -5
 )
 
 // Azure Devops API version 6
@@ -25,6 +55,12 @@ func NewAzureReposClient(vcsInfo VcsInfo, logger Log) (*AzureReposClient, error)
 	client := &AzureReposClient{vcsInfo: vcsInfo, logger: logger}
 	baseUrl := strings.TrimSuffix(client.vcsInfo.APIEndpoint, string(os.PathSeparator))
 	client.connectionDetails = azuredevops.NewPatConnection(baseUrl, client.vcsInfo.Token)
+	// The Azure DevOps library creates its own HTTP clients, so only the timeout of the HTTP client applies to its requests
+	if vcsInfo.HTTPClient != nil && vcsInfo.HTTPClient.Timeout > 0 {
+		timeout := vcsInfo.HTTPClient.Timeout
+		client.connectionDetails.Timeout = &timeout
+	}
+	client.connectionDetails.TlsConfig = getTLSConfig(vcsInfo)
 	return client, nil
 }
 
@@ -42,6 +78,11 @@ func (client *AzureReposClient) TestConnection(ctx context.Context) error {
 	return err
 }
 
+// GetRateLimitInfo on Azure Repos
+func (client *AzureReposClient) GetRateLimitInfo(ctx context.Context) (RateLimitInfo, error) {
+	return RateLimitInfo{}, getUnsupportedInAzureError("get rate limit info")
+}
+
 // ListRepositories on Azure Repos
 func (client *AzureReposClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
@@ -59,6 +100,11 @@ func (client *AzureReposClient) ListRepositories(ctx context.Context) (map[strin
 	return repositories, nil
 }
 
+// ListRepositoriesWithOptions on Azure Repos
+func (client *AzureReposClient) ListRepositoriesWithOptions(ctx context.Context, owner string, options ListRepositoriesOptions) ([]RepositoryDetails, error) {
+	return nil, getUnsupportedInAzureError("list repositories with options")
+}
+
 // ListBranches on Azure Repos
 func (client *AzureReposClient) ListBranches(ctx context.Context, _, repository string) ([]string, error) {
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
@@ -76,8 +122,240 @@ func (client *AzureReposClient) ListBranches(ctx context.Context, _, repository
 	return branches, nil
 }
 
+// ListBranchesPager on Azure Repos. The branches API isn't paginated, so all the branches are returned in a single page.
+func (client *AzureReposClient) ListBranchesPager(owner, repository string, _ int) *Pager[string] {
+	return newPager(func(ctx context.Context) ([]string, bool, error) {
+		branches, err := client.ListBranches(ctx, owner, repository)
+		return branches, false, err
+	})
+}
+
+// ListAllBranches on Azure Repos
+func (client *AzureReposClient) ListAllBranches(ctx context.Context, _, repository, prefix string) ([]BranchDetails, error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The branches stats API is not paginated, all branches are returned in a single response
+	gitBranchStats, err := azureReposGitClient.GetBranches(ctx, git.GetBranchesArgs{Project: &client.vcsInfo.Project, RepositoryId: &repository})
+	if err != nil {
+		return nil, err
+	}
+	var results []BranchDetails
+	for _, branch := range *gitBranchStats {
+		if branch.Name == nil || !strings.HasPrefix(*branch.Name, prefix) {
+			continue
+		}
+		branchDetails := BranchDetails{Name: *branch.Name}
+		if branch.Commit != nil && branch.Commit.CommitId != nil {
+			branchDetails.CommitHash = *branch.Commit.CommitId
+		}
+		results = append(results, branchDetails)
+	}
+	return results, nil
+}
+
+// CreateBranch on Azure Repos
+func (client *AzureReposClient) CreateBranch(ctx context.Context, _, repository, branchName, fromRef string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "branch name": branchName, "from ref": fromRef})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	commitID := fromRef
+	if !commitShaRegexp.MatchString(fromRef) {
+		if commitID, err = client.getBranchCommitID(ctx, azureReposGitClient, repository, fromRef); err != nil {
+			return err
+		}
+	}
+	refName := vcsutils.AddBranchPrefix(branchName)
+	client.logger.Debug("creating branch", branchName, "from", fromRef)
+	return client.updateRef(ctx, azureReposGitClient, repository, git.GitRefUpdate{
+		Name:        &refName,
+		OldObjectId: &azureReposZeroObjectID,
+		NewObjectId: &commitID,
+	})
+}
+
+// DeleteBranch on Azure Repos
+func (client *AzureReposClient) DeleteBranch(ctx context.Context, _, repository, branchName string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "branch name": branchName})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Azure Repos requires the current commit of the branch in order to delete it
+	commitID, err := client.getBranchCommitID(ctx, azureReposGitClient, repository, branchName)
+	if err != nil {
+		return err
+	}
+	refName := vcsutils.AddBranchPrefix(branchName)
+	client.logger.Debug("deleting branch", branchName)
+	return client.updateRef(ctx, azureReposGitClient, repository, git.GitRefUpdate{
+		Name:        &refName,
+		OldObjectId: &commitID,
+		NewObjectId: &azureReposZeroObjectID,
+	})
+}
+
+// ListTags on Azure Repos
+func (client *AzureReposClient) ListTags(ctx context.Context, _, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Annotated tags point to a tag object, so the tags are peeled to get the tagged commit
+	filter, peelTags := "tags/", true
+	args := git.GetRefsArgs{RepositoryId: &repository, Project: &client.vcsInfo.Project, Filter: &filter, PeelTags: &peelTags}
+	var results []TagInfo
+	for {
+		refs, err := azureReposGitClient.GetRefs(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs.Value {
+			tagInfo := TagInfo{
+				Name:       strings.TrimPrefix(vcsutils.DefaultIfNotNil(ref.Name), azureReposTagsRefPrefix),
+				CommitHash: vcsutils.DefaultIfNotNil(ref.PeeledObjectId),
+			}
+			if tagInfo.CommitHash == "" {
+				tagInfo.CommitHash = vcsutils.DefaultIfNotNil(ref.ObjectId)
+			}
+			results = append(results, tagInfo)
+		}
+		if refs.ContinuationToken == "" {
+			break
+		}
+		args.ContinuationToken = &refs.ContinuationToken
+	}
+	return results, nil
+}
+
+// CreateTag on Azure Repos
+func (client *AzureReposClient) CreateTag(ctx context.Context, _, repository, tagName, targetSha, message string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "tag name": tagName, "target sha": targetSha})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("creating tag", tagName, "on", targetSha)
+	if message != "" {
+		_, err = azureReposGitClient.CreateAnnotatedTag(ctx, git.CreateAnnotatedTagArgs{
+			TagObject: &git.GitAnnotatedTag{
+				Name:         &tagName,
+				Message:      &message,
+				TaggedObject: &git.GitObject{ObjectId: &targetSha},
+			},
+			RepositoryId: &repository,
+			Project:      &client.vcsInfo.Project,
+		})
+		return err
+	}
+	refName := azureReposTagsRefPrefix + tagName
+	return client.updateRef(ctx, azureReposGitClient, repository, git.GitRefUpdate{
+		Name:        &refName,
+		OldObjectId: &azureReposZeroObjectID,
+		NewObjectId: &targetSha,
+	})
+}
+
+// CreateRelease on Azure Repos
+func (client *AzureReposClient) CreateRelease(ctx context.Context, owner, repository string, release ReleaseInfo) error {
+	return getUnsupportedInAzureError("create release")
+}
+
+// GetLatestRelease on Azure Repos
+func (client *AzureReposClient) GetLatestRelease(ctx context.Context, owner, repository string) (*ReleaseInfo, error) {
+	return nil, getUnsupportedInAzureError("get latest release")
+}
+
+// UploadReleaseAsset on Azure Repos
+func (client *AzureReposClient) UploadReleaseAsset(ctx context.Context, owner, repository, tagName, assetPath string) error {
+	return getUnsupportedInAzureError("upload release asset")
+}
+
+// GetBranchProtection on Azure Repos
+func (client *AzureReposClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error) {
+	return nil, getUnsupportedInAzureError("get branch protection")
+}
+
+// SetBranchProtection on Azure Repos
+func (client *AzureReposClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtection) error {
+	return getUnsupportedInAzureError("set branch protection")
+}
+
+// GetRequiredChecks on Azure Repos
+func (client *AzureReposClient) GetRequiredChecks(ctx context.Context, owner, repository, branch string) (RequiredChecksInfo, error) {
+	return RequiredChecksInfo{}, getUnsupportedInAzureError("get required checks")
+}
+
+// GetRequiredApprovals on Azure Repos
+func (client *AzureReposClient) GetRequiredApprovals(ctx context.Context, owner, repository, branch string) (RequiredApprovalsInfo, error) {
+	return RequiredApprovalsInfo{}, getUnsupportedInAzureError("get required approvals")
+}
+
+// GetTagProtection on Azure Repos
+func (client *AzureReposClient) GetTagProtection(ctx context.Context, owner, repository, pattern string) (*TagProtection, error) {
+	return nil, getUnsupportedInAzureError("get tag protection")
+}
+
+// SetTagProtection on Azure Repos
+func (client *AzureReposClient) SetTagProtection(ctx context.Context, owner, repository, pattern string, protection TagProtection) error {
+	return getUnsupportedInAzureError("set tag protection")
+}
+
+func (client *AzureReposClient) getBranchCommitID(ctx context.Context, azureReposGitClient git.Client, repository, branch string) (string, error) {
+	branchStats, err := azureReposGitClient.GetBranch(ctx, git.GetBranchArgs{
+		RepositoryId: &repository,
+		Name:         &branch,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return "", err
+	}
+	if branchStats.Commit == nil || branchStats.Commit.CommitId == nil {
+		return "", fmt.Errorf("couldn't find the latest commit of branch %s", branch)
+	}
+	return *branchStats.Commit.CommitId, nil
+}
+
+func (client *AzureReposClient) updateRef(ctx context.Context, azureReposGitClient git.Client, repository string, refUpdate git.GitRefUpdate) error {
+	results, err := azureReposGitClient.UpdateRefs(ctx, git.UpdateRefsArgs{
+		RefUpdates:   &[]git.GitRefUpdate{refUpdate},
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	for _, result := range *results {
+		if result.Success == nil || !*result.Success {
+			return fmt.Errorf("failed to update ref %s: %s", *refUpdate.Name, getAzureReposRefUpdateFailureReason(result))
+		}
+	}
+	return nil
+}
+
 // DownloadRepository on Azure Repos
-func (client *AzureReposClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) (err error) {
+func (client *AzureReposClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	return client.DownloadRepositoryAtRef(ctx, owner, repository, branch, localPath, Zip)
+}
+
+// DownloadRepositoryAtRef on Azure Repos
+func (client *AzureReposClient) DownloadRepositoryAtRef(ctx context.Context, owner, repository, ref, localPath string, format ArchiveFormat) (err error) {
 	wd, err := os.Getwd()
 	if err != nil {
 		return
@@ -92,23 +370,17 @@ func (client *AzureReposClient) DownloadRepository(ctx context.Context, owner, r
 			err = e
 		}
 	}()
-	res, err := client.sendDownloadRepoRequest(ctx, repository, branch)
-	defer func() {
-		if res.Body != nil {
-			e := res.Body.Close()
-			if err == nil {
-				err = e
-			}
-		}
-	}()
-	if err != nil {
-		return
-	}
-	zipFileContent, err := io.ReadAll(res.Body)
+	archive, err := client.GetRepositoryArchive(ctx, owner, repository, ref, format)
 	if err != nil {
 		return
 	}
-	err = vcsutils.Unzip(zipFileContent, localPath)
+	defer func() {
+		e := archive.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	err = extractRepositoryArchive(archive, format, localPath, false, "")
 	if err != nil {
 		return err
 	}
@@ -118,12 +390,49 @@ func (client *AzureReposClient) DownloadRepository(ctx context.Context, owner, r
 		fmt.Sprintf("https://%s@%s/%s/_git/%s", owner, strings.TrimPrefix(client.connectionDetails.BaseUrl, "https://"), client.vcsInfo.Project, repository))
 }
 
-func (client *AzureReposClient) sendDownloadRepoRequest(ctx context.Context, repository string, branch string) (res *http.Response, err error) {
-	downloadRepoUrl := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&$format=zip",
+// GetRepositoryArchive on Azure Repos
+func (client *AzureReposClient) GetRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat) (io.ReadCloser, error) {
+	if format != Zip {
+		return nil, errAzureReposTarGzArchiveNotSupported
+	}
+	res, err := client.sendDownloadRepoRequest(ctx, repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// DownloadRepositoryPath on Azure Repos
+func (client *AzureReposClient) DownloadRepositoryPath(ctx context.Context, owner, repository, ref, path, localPath string) (err error) {
+	if err = validateParametersNotBlank(map[string]string{"repository": repository, "path": path}); err != nil {
+		return
+	}
+	// The files of the directory are extracted from the repository archive
+	archive, err := client.GetRepositoryArchive(ctx, owner, repository, ref, Zip)
+	if err != nil {
+		return
+	}
+	defer func() {
+		e := archive.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	client.logger.Info("extracting", path, "from", repository)
+	return extractRepositoryArchive(archive, Zip, localPath, false, path)
+}
+
+func (client *AzureReposClient) sendDownloadRepoRequest(ctx context.Context, repository string, ref string) (res *http.Response, err error) {
+	version, versionType := ref, getAzureReposVersionType(ref)
+	if strings.HasPrefix(ref, azureReposTagsRefPrefix) {
+		version, versionType = strings.TrimPrefix(ref, azureReposTagsRefPrefix), git.GitVersionTypeValues.Tag
+	}
+	downloadRepoUrl := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&versionDescriptor[versionType]=%s&$format=zip",
 		client.connectionDetails.BaseUrl,
 		client.vcsInfo.Project,
 		repository,
-		branch)
+		version,
+		versionType)
 	client.logger.Debug("download url:", downloadRepoUrl)
 	headers := map[string]string{
 		"Authorization":  client.connectionDetails.AuthorizationString,
@@ -131,7 +440,7 @@ func (client *AzureReposClient) sendDownloadRepoRequest(ctx context.Context, rep
 		"resolveLfs":     "true",
 		"includeContent": "true",
 	}
-	httpClient := &http.Client{}
+	httpClient := newHTTPClient(client.vcsInfo, client.logger)
 	var req *http.Request
 	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, downloadRepoUrl, nil); err != nil {
 		return
@@ -143,6 +452,7 @@ func (client *AzureReposClient) sendDownloadRepoRequest(ctx context.Context, rep
 		return
 	}
 	if err = vcsutils.CheckResponseStatusWithBody(res, http.StatusOK); err != nil {
+		_ = res.Body.Close()
 		return &http.Response{}, err
 	}
 	client.logger.Info(repository, "downloaded successfully, starting with repository extraction")
@@ -151,6 +461,15 @@ func (client *AzureReposClient) sendDownloadRepoRequest(ctx context.Context, rep
 
 // CreatePullRequest on Azure Repos
 func (client *AzureReposClient) CreatePullRequest(ctx context.Context, _, repository, sourceBranch, targetBranch, title, description string) error {
+	return client.createPullRequest(ctx, repository, sourceBranch, targetBranch, title, description, false)
+}
+
+// CreateDraftPullRequest on Azure Repos
+func (client *AzureReposClient) CreateDraftPullRequest(ctx context.Context, _, repository, sourceBranch, targetBranch, title, description string) error {
+	return client.createPullRequest(ctx, repository, sourceBranch, targetBranch, title, description, true)
+}
+
+func (client *AzureReposClient) createPullRequest(ctx context.Context, repository, sourceBranch, targetBranch, title, description string, draft bool) error {
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return err
@@ -164,6 +483,7 @@ func (client *AzureReposClient) CreatePullRequest(ctx context.Context, _, reposi
 			SourceRefName: &sourceBranch,
 			TargetRefName: &targetBranch,
 			Title:         &title,
+			IsDraft:       &draft,
 		},
 		RepositoryId: &repository,
 		Project:      &client.vcsInfo.Project,
@@ -171,6 +491,175 @@ func (client *AzureReposClient) CreatePullRequest(ctx context.Context, _, reposi
 	return err
 }
 
+// MarkPullRequestReady on Azure Repos
+func (client *AzureReposClient) MarkPullRequestReady(ctx context.Context, _, repository string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	draft := false
+	client.logger.Debug("marking pull request as ready:", pullRequestID)
+	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{IsDraft: &draft},
+		RepositoryId:           &repository,
+		PullRequestId:          &pullRequestID,
+		Project:                &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// MergePullRequest on Azure Repos
+func (client *AzureReposClient) MergePullRequest(ctx context.Context, _, repository string, pullRequestID int,
+	mergeStrategy MergeStrategy, commitMessage string) error {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Completing a pull request requires the last merge source commit, which is taken from the current pull request
+	pullRequest, err := azureReposGitClient.GetPullRequest(ctx, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	completionOptions := &git.GitPullRequestCompletionOptions{
+		MergeStrategy: getAzureReposMergeStrategy(mergeStrategy),
+	}
+	if commitMessage != "" {
+		completionOptions.MergeCommitMessage = &commitMessage
+	}
+	client.logger.Debug("completing pull request:", pullRequestID)
+	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			Status:                &git.PullRequestStatusValues.Completed,
+			LastMergeSourceCommit: pullRequest.LastMergeSourceCommit,
+			CompletionOptions:     completionOptions,
+		},
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// EnablePullRequestAutoMerge on Azure Repos
+func (client *AzureReposClient) EnablePullRequestAutoMerge(_ context.Context, _, _ string, _ int, _ MergeStrategy) error {
+	return getUnsupportedInAzureError("pull request auto-merge")
+}
+
+// DisablePullRequestAutoMerge on Azure Repos
+func (client *AzureReposClient) DisablePullRequestAutoMerge(_ context.Context, _, _ string, _ int) error {
+	return getUnsupportedInAzureError("pull request auto-merge")
+}
+
+// UpdatePullRequest on Azure Repos
+func (client *AzureReposClient) UpdatePullRequest(ctx context.Context, _, repository, title, body, targetBranch string,
+	pullRequestID int, state *PullRequestState) error {
+	err := validateParametersNotBlank(map[string]string{
+		"repository": repository,
+	})
+	if err != nil {
+		return err
+	}
+	if err = validatePullRequestState(state); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest := &git.GitPullRequest{}
+	if title != "" {
+		pullRequest.Title = &title
+	}
+	if body != "" {
+		pullRequest.Description = &body
+	}
+	if targetBranch != "" {
+		targetRefName := vcsutils.AddBranchPrefix(targetBranch)
+		pullRequest.TargetRefName = &targetRefName
+	}
+	if state != nil {
+		pullRequest.Status = &git.PullRequestStatusValues.Active
+		if *state == PullRequestClosed {
+			pullRequest.Status = &git.PullRequestStatusValues.Abandoned
+		}
+	}
+	client.logger.Debug("updating pull request:", pullRequestID)
+	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: pullRequest,
+		RepositoryId:           &repository,
+		PullRequestId:          &pullRequestID,
+		Project:                &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// ApprovePullRequest on Azure Repos
+func (client *AzureReposClient) ApprovePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	return client.SubmitPullRequestReview(ctx, owner, repository, pullRequestID, ReviewApprove, "")
+}
+
+// SubmitPullRequestReview on Azure Repos
+func (client *AzureReposClient) SubmitPullRequestReview(ctx context.Context, _, repository string, pullRequestID int,
+	reviewEvent ReviewEvent, body string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"repository": repository,
+	})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewBody(reviewEvent, body); err != nil {
+		return err
+	}
+	if reviewEvent != ReviewComment {
+		if err = client.votePullRequest(ctx, repository, pullRequestID, reviewEvent); err != nil {
+			return err
+		}
+	}
+	// Azure Repos votes have no body, so the review body is added as a comment
+	if body != "" {
+		return client.AddPullRequestComment(ctx, "", repository, body, pullRequestID)
+	}
+	return nil
+}
+
+// votePullRequest sets the vote of the authenticated user on a pull request
+func (client *AzureReposClient) votePullRequest(ctx context.Context, repository string, pullRequestID int, reviewEvent ReviewEvent) error {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Reviewers are identified by their ID, which is taken from the authenticated user of the connection
+	connectionData, err := location.NewClient(ctx, client.connectionDetails).GetConnectionData(ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return err
+	}
+	if connectionData.AuthenticatedUser == nil || connectionData.AuthenticatedUser.Id == nil {
+		return errors.New("failed to get the authenticated user of the connection")
+	}
+	reviewerID := connectionData.AuthenticatedUser.Id.String()
+	vote := azureReposApprovedVote
+	if reviewEvent == ReviewRequestChanges {
+		vote = azureReposWaitForAuthorVote
+	}
+	client.logger.Debug("voting on pull request:", pullRequestID)
+	_, err = azureReposGitClient.CreatePullRequestReviewer(ctx, git.CreatePullRequestReviewerArgs{
+		Reviewer:      &git.IdentityRefWithVote{Vote: &vote},
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		ReviewerId:    &reviewerID,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
 // AddPullRequestComment on Azure Repos
 func (client *AzureReposClient) AddPullRequestComment(ctx context.Context, _, repository, content string, pullRequestID int) error {
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
@@ -226,23 +715,157 @@ func (client *AzureReposClient) ListPullRequestComments(ctx context.Context, _,
 	return commentInfo, nil
 }
 
-// ListOpenPullRequests on Azure Repos
-func (client *AzureReposClient) ListOpenPullRequests(ctx context.Context, _, repository string) ([]PullRequestInfo, error) {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+// UpdatePullRequestComment on Azure Repos.
+// The comment ID is the ID of the thread opened by AddPullRequestComment, and its first comment is updated.
+func (client *AzureReposClient) UpdatePullRequestComment(ctx context.Context, _, repository, content string, pullRequestID int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "content": content})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	client.logger.Debug("fetching open pull requests in", repository)
-	pullRequests, err := azureReposGitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
-		RepositoryId:   &repository,
-		Project:        &client.vcsInfo.Project,
-		SearchCriteria: &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active},
-	})
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	var pullRequestsInfo []PullRequestInfo
-	for _, pullRequest := range *pullRequests {
+	// The first comment of a thread has the ID 1
+	threadID, firstCommentID := int(commentID), 1
+	_, err = azureReposGitClient.UpdateComment(ctx, git.UpdateCommentArgs{
+		Comment:       &git.Comment{Content: &content},
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &threadID,
+		CommentId:     &firstCommentID,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// DeletePullRequestComment on Azure Repos.
+// The comment ID is the ID of the thread opened by AddPullRequestComment, and its first comment is deleted.
+func (client *AzureReposClient) DeletePullRequestComment(ctx context.Context, _, repository string, pullRequestID int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// The first comment of a thread has the ID 1
+	threadID, firstCommentID := int(commentID), 1
+	return azureReposGitClient.DeleteComment(ctx, git.DeleteCommentArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &threadID,
+		CommentId:     &firstCommentID,
+		Project:       &client.vcsInfo.Project,
+	})
+}
+
+// AddCommentReaction on Azure Repos
+func (client *AzureReposClient) AddCommentReaction(ctx context.Context, owner, repository string, pullRequestID int, commentID int64, reaction Reaction) error {
+	return getUnsupportedInAzureError("comment reactions")
+}
+
+// ListCommentReactions on Azure Repos
+func (client *AzureReposClient) ListCommentReactions(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) ([]ReactionInfo, error) {
+	return nil, getUnsupportedInAzureError("comment reactions")
+}
+
+// AddPullRequestReviewComment on Azure Repos
+func (client *AzureReposClient) AddPullRequestReviewComment(ctx context.Context, _, repository string, pullRequestID int,
+	comment PullRequestReviewComment) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewComment(comment); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	startLine := comment.StartLine
+	if startLine == 0 {
+		startLine = comment.Line
+	}
+	// The file path of the thread context must start with a slash
+	filePath := "/" + strings.TrimPrefix(comment.Path, "/")
+	// Offsets start at 1, so that the thread spans from the beginning of the first line to the beginning of the last one
+	offset := 1
+	_, err = azureReposGitClient.CreateThread(ctx, git.CreateThreadArgs{
+		CommentThread: &git.GitPullRequestCommentThread{
+			Comments: &[]git.Comment{{Content: &comment.Content}},
+			Status:   &git.CommentThreadStatusValues.Active,
+			ThreadContext: &git.CommentThreadContext{
+				FilePath:       &filePath,
+				RightFileStart: &git.CommentPosition{Line: &startLine, Offset: &offset},
+				RightFileEnd:   &git.CommentPosition{Line: &comment.Line, Offset: &offset},
+			},
+		},
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// ListPullRequestReviewComments on Azure Repos
+func (client *AzureReposClient) ListPullRequestReviewComments(ctx context.Context, _, repository string, pullRequestID int) ([]PullRequestReviewComment, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := azureReposGitClient.GetThreads(ctx, git.GetThreadsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestReviewComment
+	for _, thread := range *threads {
+		// Only threads with a file context are anchored to the diff, the thread content is its first comment
+		if thread.ThreadContext == nil || thread.ThreadContext.FilePath == nil || thread.Comments == nil || len(*thread.Comments) == 0 {
+			continue
+		}
+		results = append(results, mapAzureReposThreadToReviewComment(thread))
+	}
+	return results, nil
+}
+
+// CreatePullRequestThread on Azure Repos
+func (client *AzureReposClient) CreatePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (string, error) {
+	return "", getUnsupportedInAzureError("pull request threads")
+}
+
+// ResolvePullRequestThread on Azure Repos
+func (client *AzureReposClient) ResolvePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	return getUnsupportedInAzureError("pull request threads")
+}
+
+// ListOpenPullRequests on Azure Repos
+func (client *AzureReposClient) ListOpenPullRequests(ctx context.Context, _, repository string) ([]PullRequestInfo, error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Debug("fetching open pull requests in", repository)
+	pullRequests, err := azureReposGitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
+		RepositoryId:   &repository,
+		Project:        &client.vcsInfo.Project,
+		SearchCriteria: &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var pullRequestsInfo []PullRequestInfo
+	for _, pullRequest := range *pullRequests {
 		// Trim the branches prefix and get the actual branches name
 		shortSourceName := (*pullRequest.SourceRefName)[strings.LastIndex(*pullRequest.SourceRefName, "/")+1:]
 		shortTargetName := (*pullRequest.TargetRefName)[strings.LastIndex(*pullRequest.TargetRefName, "/")+1:]
@@ -256,11 +879,207 @@ func (client *AzureReposClient) ListOpenPullRequests(ctx context.Context, _, rep
 				Name:       shortTargetName,
 				Repository: repository,
 			},
+			Draft: vcsutils.DefaultIfNotNil(pullRequest.IsDraft),
 		})
 	}
 	return pullRequestsInfo, nil
 }
 
+// ListOpenPullRequestsWithFilter on Azure Repos
+func (client *AzureReposClient) ListOpenPullRequestsWithFilter(ctx context.Context, owner, repository string, filter PullRequestFilter) ([]PullRequestInfo, error) {
+	return listPageOrAll(ctx, client.ListOpenPullRequestsPager(owner, repository, filter), filter.Page)
+}
+
+// ListOpenPullRequestsPager on Azure Repos
+func (client *AzureReposClient) ListOpenPullRequestsPager(_, repository string, filter PullRequestFilter) *Pager[PullRequestInfo] {
+	searchCriteria := &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active}
+	if filter.SourceBranch != "" {
+		sourceRefName := vcsutils.AddBranchPrefix(filter.SourceBranch)
+		searchCriteria.SourceRefName = &sourceRefName
+	}
+	if filter.TargetBranch != "" {
+		targetRefName := vcsutils.AddBranchPrefix(filter.TargetBranch)
+		searchCriteria.TargetRefName = &targetRefName
+	}
+	pageSize := filter.PerPage
+	if pageSize == 0 {
+		pageSize = azureReposPullRequestsPageSize
+	}
+	skip := (firstPage(filter.Page) - 1) * pageSize
+	return newPager(func(ctx context.Context) ([]PullRequestInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{
+			"repository": repository,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		// Azure Repos pull requests don't have an update date
+		if !filter.UpdatedSince.IsZero() {
+			return nil, false, errAzureReposUpdatedSinceFilterNotSupported
+		}
+		azureReposGitClient, err := client.buildAzureReposClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching open pull requests in", repository)
+		pullRequests, err := azureReposGitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
+			RepositoryId:   &repository,
+			Project:        &client.vcsInfo.Project,
+			SearchCriteria: searchCriteria,
+			Skip:           &skip,
+			Top:            &pageSize,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		var results []PullRequestInfo
+		for i := range *pullRequests {
+			pullRequestInfo := mapAzureReposPullRequestToPullRequestInfo(&(*pullRequests)[i], repository)
+			if filter.matches(pullRequestInfo, time.Time{}) {
+				results = append(results, pullRequestInfo)
+			}
+		}
+		skip += pageSize
+		// Azure Repos doesn't return the total count, so a full page means there may be more pull requests
+		return results, len(*pullRequests) == pageSize, nil
+	})
+}
+
+// GetPullRequestByID on Azure Repos
+func (client *AzureReposClient) GetPullRequestByID(ctx context.Context, _, repository string, pullRequestID int) (PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"repository": repository,
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	client.logger.Debug("fetching pull request", pullRequestID, "in", repository)
+	pullRequest, err := azureReposGitClient.GetPullRequest(ctx, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapAzureReposPullRequestToPullRequestInfo(pullRequest, repository), nil
+}
+
+// GetPullRequestDetails on Azure Repos
+func (client *AzureReposClient) GetPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestDetails, error) {
+	return getPullRequestDetails(ctx, client, owner, repository, pullRequestID, true)
+}
+
+// GetPullRequestMergeableState on Azure Repos. Mergeable reflects the test merge of the pull request only, and not the branch policies.
+func (client *AzureReposClient) GetPullRequestMergeableState(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestMergeableState, error) {
+	pullRequest, err := client.GetPullRequestByID(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	// Azure Repos reports whether the test merge succeeded or conflicted, and leaves the merge status unset while it's computed
+	if pullRequest.Mergeable == nil {
+		return PullRequestMergeableState{}, nil
+	}
+	return PullRequestMergeableState{Mergeable: *pullRequest.Mergeable, HasConflicts: !*pullRequest.Mergeable}, nil
+}
+
+// ListPullRequestFiles on Azure Repos
+func (client *AzureReposClient) ListPullRequestFiles(ctx context.Context, _, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"repository": repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	iterations, err := azureReposGitClient.GetPullRequestIterations(ctx, git.GetPullRequestIterationsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if iterations == nil || len(*iterations) == 0 {
+		return []PullRequestFile{}, nil
+	}
+	// The changes of the latest iteration are compared to the common commit of the source and target branches
+	lastIterationID := (*iterations)[len(*iterations)-1].Id
+	var results []PullRequestFile
+	for skip := 0; ; {
+		changes, err := azureReposGitClient.GetPullRequestIterationChanges(ctx, git.GetPullRequestIterationChangesArgs{
+			RepositoryId:  &repository,
+			PullRequestId: &pullRequestID,
+			IterationId:   lastIterationID,
+			Project:       &client.vcsInfo.Project,
+			Skip:          &skip,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if changes.ChangeEntries != nil {
+			for _, change := range *changes.ChangeEntries {
+				results = append(results, mapAzureReposPullRequestChange(change))
+			}
+		}
+		if changes.NextSkip == nil || *changes.NextSkip == 0 {
+			return results, nil
+		}
+		skip = *changes.NextSkip
+	}
+}
+
+// GetPullRequestDiff on Azure Repos
+func (client *AzureReposClient) GetPullRequestDiff(_ context.Context, _, _ string, _ int) (string, error) {
+	return "", getUnsupportedInAzureError("get pull request diff")
+}
+
+// ListPullRequestCommits on Azure Repos
+func (client *AzureReposClient) ListPullRequestCommits(ctx context.Context, _, repository string, pullRequestID int) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitInfo
+	for continuationToken := ""; ; {
+		args := git.GetPullRequestCommitsArgs{
+			RepositoryId:  &repository,
+			PullRequestId: &pullRequestID,
+			Project:       &client.vcsInfo.Project,
+		}
+		if continuationToken != "" {
+			args.ContinuationToken = &continuationToken
+		}
+		response, err := azureReposGitClient.GetPullRequestCommits(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range response.Value {
+			results = append(results, mapAzureReposCommitToCommitInfo(commit))
+		}
+		if response.ContinuationToken == "" {
+			return results, nil
+		}
+		continuationToken = response.ContinuationToken
+	}
+}
+
+// GetDefaultReviewers on Azure Repos
+func (client *AzureReposClient) GetDefaultReviewers(_ context.Context, _, _, _, _ string) ([]string, error) {
+	return nil, getUnsupportedInAzureError("get default reviewers")
+}
+
 // GetLatestCommit on Azure Repos
 func (client *AzureReposClient) GetLatestCommit(ctx context.Context, _, repository, branch string) (CommitInfo, error) {
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
@@ -278,16 +1097,7 @@ func (client *AzureReposClient) GetLatestCommit(ctx context.Context, _, reposito
 	}
 	if len(*commits) > 0 {
 		// The latest commit is the first in the list
-		latestCommit := (*commits)[0]
-		latestCommitInfo = CommitInfo{
-			Hash:          vcsutils.DefaultIfNotNil(latestCommit.CommitId),
-			AuthorName:    vcsutils.DefaultIfNotNil(latestCommit.Author.Name),
-			CommitterName: vcsutils.DefaultIfNotNil(latestCommit.Committer.Name),
-			Url:           vcsutils.DefaultIfNotNil(latestCommit.Url),
-			Timestamp:     latestCommit.Committer.Date.Time.Unix(),
-			Message:       vcsutils.DefaultIfNotNil(latestCommit.Comment),
-			ParentHashes:  vcsutils.DefaultIfNotNil(latestCommit.Parents),
-		}
+		latestCommitInfo = mapAzureReposCommitToCommitInfo((*commits)[0])
 	}
 	return latestCommitInfo, nil
 }
@@ -301,67 +1111,980 @@ func (client *AzureReposClient) AddSshKeyToRepository(ctx context.Context, owner
 	return getUnsupportedInAzureError("add ssh key to repository")
 }
 
+// ListDeployKeys on Azure Repos
+func (client *AzureReposClient) ListDeployKeys(ctx context.Context, owner, repository string) ([]DeployKeyInfo, error) {
+	return nil, getUnsupportedInAzureError("list deploy keys")
+}
+
+// AddDeployKey on Azure Repos
+func (client *AzureReposClient) AddDeployKey(ctx context.Context, owner, repository, title, key string, readOnly bool) (DeployKeyInfo, error) {
+	return DeployKeyInfo{}, getUnsupportedInAzureError("add deploy key")
+}
+
+// DeleteDeployKey on Azure Repos
+func (client *AzureReposClient) DeleteDeployKey(ctx context.Context, owner, repository string, keyID int) error {
+	return getUnsupportedInAzureError("delete deploy key")
+}
+
 // GetRepositoryInfo on Azure Repos
 func (client *AzureReposClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
 	return RepositoryInfo{}, getUnsupportedInAzureError("get repository info")
 }
 
-// GetCommitBySha on Azure Repos
-func (client *AzureReposClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
-	return CommitInfo{}, getUnsupportedInAzureError("get commit by sha")
-}
-
-// CreateLabel on Azure Repos
-func (client *AzureReposClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
-	return getUnsupportedInAzureError("create label")
+// GetRepositoryLanguages on Azure Repos. The languages are taken from the language metrics of the project, which Azure DevOps updates periodically.
+func (client *AzureReposClient) GetRepositoryLanguages(ctx context.Context, _, repository string) (map[string]float64, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "project": client.vcsInfo.Project})
+	if err != nil {
+		return nil, err
+	}
+	analysisClient, err := projectanalysis.NewClient(ctx, client.connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+	analytics, err := analysisClient.GetProjectLanguageAnalytics(ctx, projectanalysis.GetProjectLanguageAnalyticsArgs{Project: &client.vcsInfo.Project})
+	if err != nil {
+		return nil, err
+	}
+	results := map[string]float64{}
+	if analytics.RepositoryLanguageAnalytics == nil {
+		return results, nil
+	}
+	for _, repositoryAnalytics := range *analytics.RepositoryLanguageAnalytics {
+		if !strings.EqualFold(vcsutils.DefaultIfNotNil(repositoryAnalytics.Name), repository) || repositoryAnalytics.LanguageBreakdown == nil {
+			continue
+		}
+		for _, language := range *repositoryAnalytics.LanguageBreakdown {
+			if language.Name != nil && language.LanguagePercentage != nil {
+				results[*language.Name] = *language.LanguagePercentage
+			}
+		}
+	}
+	return results, nil
 }
 
-// GetLabel on Azure Repos
-func (client *AzureReposClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
-	return nil, getUnsupportedInAzureError("get label")
+// CreateRepository on Azure Repos
+func (client *AzureReposClient) CreateRepository(ctx context.Context, _, name string, options CreateRepositoryOptions) error {
+	err := validateParametersNotBlank(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+	if err = validateCreateRepositoryOptions(options); err != nil {
+		return err
+	}
+	if options.Visibility != nil || options.Description != "" || options.InitWithReadme {
+		return errAzureReposRepositorySettingsNotSupported
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = azureReposGitClient.CreateRepository(ctx, git.CreateRepositoryArgs{
+		GitRepositoryToCreate: &git.GitRepositoryCreateOptions{Name: &name},
+		Project:               &client.vcsInfo.Project,
+	})
+	return err
 }
 
-// ListPullRequestLabels on Azure Repos
-func (client *AzureReposClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	return nil, getUnsupportedInAzureError("list pull request labels")
+// DeleteRepository on Azure Repos
+func (client *AzureReposClient) DeleteRepository(ctx context.Context, _, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Azure Repos deletes repositories by their ID
+	repo, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{RepositoryId: &repository, Project: &client.vcsInfo.Project})
+	if err != nil {
+		return err
+	}
+	return azureReposGitClient.DeleteRepository(ctx, git.DeleteRepositoryArgs{RepositoryId: repo.Id, Project: &client.vcsInfo.Project})
 }
 
-// UnlabelPullRequest on Azure Repos
-func (client *AzureReposClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
-	return getUnsupportedInAzureError("unlabel pull request")
+// ForkRepository on Azure Repos
+func (client *AzureReposClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string, waitUntilReady bool) (ForkInfo, error) {
+	return ForkInfo{}, getUnsupportedInAzureError("fork repository")
 }
 
-// UploadCodeScanning on Azure Repos
-func (client *AzureReposClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
-	return "", getUnsupportedInAzureError("upload code scanning")
+// ListCollaborators on Azure Repos
+func (client *AzureReposClient) ListCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	return nil, getUnsupportedInAzureError("list collaborators")
 }
 
-// CreateWebhook on Azure Repos
-func (client *AzureReposClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
-	return "", "", getUnsupportedInAzureError("create webhook")
+// AddCollaborator on Azure Repos
+func (client *AzureReposClient) AddCollaborator(ctx context.Context, owner, repository, username string, permission RepositoryPermission) error {
+	return getUnsupportedInAzureError("add collaborator")
 }
 
-// UpdateWebhook on Azure Repos
-func (client *AzureReposClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token, webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
-	return getUnsupportedInAzureError("update webhook")
+// RemoveCollaborator on Azure Repos
+func (client *AzureReposClient) RemoveCollaborator(ctx context.Context, owner, repository, username string) error {
+	return getUnsupportedInAzureError("remove collaborator")
 }
 
-// DeleteWebhook on Azure Repos
-func (client *AzureReposClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
-	return getUnsupportedInAzureError("delete webhook")
+// GetUserPermission on Azure Repos
+func (client *AzureReposClient) GetUserPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	return PermissionNone, getUnsupportedInAzureError("get user permission")
 }
 
-// SetCommitStatus on Azure Repos
-func (client *AzureReposClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error {
-	return getUnsupportedInAzureError("set commit status")
+// GetCommitBySha on Azure Repos
+func (client *AzureReposClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	return CommitInfo{}, getUnsupportedInAzureError("get commit by sha")
 }
 
-// DownloadFileFromRepo on Azure Repos
-func (client *AzureReposClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
-	return nil, 0, getUnsupportedInAzureError("download file from repo")
+// ListCommits on Azure Repos
+func (client *AzureReposClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	return listPageOrAll(ctx, client.listCommitsPager(repository, options), options.Page)
 }
 
-// GetRepositoryEnvironmentInfo on GitLab
-func (client *AzureReposClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
-	return RepositoryEnvironmentInfo{}, getUnsupportedInAzureError("get repository environment info")
+func (client *AzureReposClient) listCommitsPager(repository string, options ListCommitsOptions) *Pager[CommitInfo] {
+	pageSize := options.PerPage
+	if pageSize == 0 {
+		pageSize = azureReposPullRequestsPageSize
+	}
+	skip := (firstPage(options.Page) - 1) * pageSize
+	searchCriteria := &git.GitQueryCommitsCriteria{Skip: &skip, Top: &pageSize}
+	if options.Branch != "" {
+		searchCriteria.ItemVersion = &git.GitVersionDescriptor{Version: &options.Branch, VersionType: &git.GitVersionTypeValues.Branch}
+	}
+	if options.Path != "" {
+		searchCriteria.ItemPath = &options.Path
+	}
+	if options.Author != "" {
+		searchCriteria.Author = &options.Author
+	}
+	if !options.Since.IsZero() {
+		fromDate := options.Since.UTC().Format(time.RFC3339)
+		searchCriteria.FromDate = &fromDate
+	}
+	if !options.Until.IsZero() {
+		toDate := options.Until.UTC().Format(time.RFC3339)
+		searchCriteria.ToDate = &toDate
+	}
+	return newPager(func(ctx context.Context) ([]CommitInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		azureReposGitClient, err := client.buildAzureReposClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching commits in", repository)
+		commits, err := azureReposGitClient.GetCommits(ctx, git.GetCommitsArgs{
+			RepositoryId:   &repository,
+			Project:        &client.vcsInfo.Project,
+			SearchCriteria: searchCriteria,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]CommitInfo, 0, len(*commits))
+		for _, commit := range *commits {
+			results = append(results, mapAzureReposCommitToCommitInfo(commit))
+		}
+		skip += len(*commits)
+		return results, len(*commits) == pageSize, nil
+	})
+}
+
+// GetCommit on Azure Repos. Azure Repos doesn't return the number of added and deleted lines.
+func (client *AzureReposClient) GetCommit(ctx context.Context, _, repository, sha string) (CommitDetails, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "sha": sha})
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	commit, err := azureReposGitClient.GetCommit(ctx, git.GetCommitArgs{CommitId: &sha, RepositoryId: &repository, Project: &client.vcsInfo.Project})
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	result := CommitDetails{CommitInfo: mapAzureReposCommitToCommitInfo(git.GitCommitRef{
+		CommitId:  commit.CommitId,
+		Author:    commit.Author,
+		Committer: commit.Committer,
+		Url:       commit.Url,
+		Comment:   commit.Comment,
+		Parents:   commit.Parents,
+	})}
+	pageSize := azureReposPullRequestsPageSize
+	for skip := 0; ; {
+		changes, err := azureReposGitClient.GetChanges(ctx, git.GetChangesArgs{
+			CommitId:     &sha,
+			RepositoryId: &repository,
+			Project:      &client.vcsInfo.Project,
+			Skip:         &skip,
+			Top:          &pageSize,
+		})
+		if err != nil {
+			return CommitDetails{}, err
+		}
+		pageChanges := vcsutils.DefaultIfNotNil(changes.Changes)
+		for _, change := range pageChanges {
+			file, isFile, err := mapAzureReposCommitDiffChange(change)
+			if err != nil {
+				return CommitDetails{}, err
+			}
+			if isFile {
+				result.Files = append(result.Files, file)
+			}
+		}
+		if len(pageChanges) < pageSize {
+			return result, nil
+		}
+		skip += len(pageChanges)
+	}
+}
+
+// GetCommitVerification on Azure Repos
+func (client *AzureReposClient) GetCommitVerification(ctx context.Context, owner, repository, sha string) (CommitVerificationInfo, error) {
+	return CommitVerificationInfo{}, getUnsupportedInAzureError("get commit verification")
+}
+
+// CompareCommits on Azure Repos
+func (client *AzureReposClient) CompareCommits(ctx context.Context, _, repository, base, head string) (CommitsComparison, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	baseVersionType, headVersionType := getAzureReposVersionType(base), getAzureReposVersionType(head)
+	// The changes are compared to the common commit of base and head
+	diffCommonCommit := true
+	var result CommitsComparison
+	for skip := 0; ; {
+		diffs, err := azureReposGitClient.GetCommitDiffs(ctx, git.GetCommitDiffsArgs{
+			RepositoryId:            &repository,
+			Project:                 &client.vcsInfo.Project,
+			DiffCommonCommit:        &diffCommonCommit,
+			Skip:                    &skip,
+			BaseVersionDescriptor:   &git.GitBaseVersionDescriptor{BaseVersion: &base, BaseVersionType: &baseVersionType},
+			TargetVersionDescriptor: &git.GitTargetVersionDescriptor{TargetVersion: &head, TargetVersionType: &headVersionType},
+		})
+		if err != nil {
+			return CommitsComparison{}, err
+		}
+		if skip == 0 {
+			result.AheadBy, result.BehindBy = vcsutils.DefaultIfNotNil(diffs.AheadCount), vcsutils.DefaultIfNotNil(diffs.BehindCount)
+		}
+		changes := vcsutils.DefaultIfNotNil(diffs.Changes)
+		for _, change := range changes {
+			file, isFile, err := mapAzureReposCommitDiffChange(change)
+			if err != nil {
+				return CommitsComparison{}, err
+			}
+			if isFile {
+				result.Files = append(result.Files, file)
+			}
+		}
+		if vcsutils.DefaultIfNotNil(diffs.AllChangesIncluded) || len(changes) == 0 {
+			break
+		}
+		skip += len(changes)
+	}
+	if result.AheadBy == 0 {
+		return result, nil
+	}
+	commits, err := azureReposGitClient.GetCommits(ctx, git.GetCommitsArgs{
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+		SearchCriteria: &git.GitQueryCommitsCriteria{
+			ItemVersion:    &git.GitVersionDescriptor{Version: &head, VersionType: &headVersionType},
+			CompareVersion: &git.GitVersionDescriptor{Version: &base, VersionType: &baseVersionType},
+			Top:            &result.AheadBy,
+		},
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	for _, commit := range *commits {
+		result.Commits = append(result.Commits, mapAzureReposCommitToCommitInfo(commit))
+	}
+	return result, nil
+}
+
+// GetCommitsBetween on Azure Repos
+func (client *AzureReposClient) GetCommitsBetween(ctx context.Context, _, repository, fromSha, toSha string) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"repository": repository,
+		"fromSha":    fromSha,
+		"toSha":      toSha,
+	})
+	if err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := azureReposPullRequestsPageSize
+	var results []CommitInfo
+	for skip := 0; ; {
+		commits, err := azureReposGitClient.GetCommits(ctx, git.GetCommitsArgs{
+			RepositoryId: &repository,
+			Project:      &client.vcsInfo.Project,
+			SearchCriteria: &git.GitQueryCommitsCriteria{
+				ItemVersion:    &git.GitVersionDescriptor{Version: &toSha, VersionType: &git.GitVersionTypeValues.Commit},
+				CompareVersion: &git.GitVersionDescriptor{Version: &fromSha, VersionType: &git.GitVersionTypeValues.Commit},
+				Skip:           &skip,
+				Top:            &pageSize,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range *commits {
+			results = append(results, mapAzureReposCommitToCommitInfo(commit))
+		}
+		if len(*commits) < pageSize {
+			return results, nil
+		}
+		skip += len(*commits)
+	}
+}
+
+// ListContributors on Azure Repos. The contributors are counted from the commits, since Azure Repos doesn't provide a contributors API.
+func (client *AzureReposClient) ListContributors(ctx context.Context, owner, repository string) ([]ContributorInfo, error) {
+	return listContributorsFromCommits(ctx, client, owner, repository)
+}
+
+// GetCommitActivity on Azure Repos. The activity is counted from the commits, since Azure Repos doesn't provide commit statistics.
+func (client *AzureReposClient) GetCommitActivity(ctx context.Context, owner, repository string, since time.Time) ([]CommitActivityInfo, error) {
+	return getCommitActivityFromCommits(ctx, client, owner, repository, since)
+}
+
+// CreateLabel on Azure Repos
+func (client *AzureReposClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	return getUnsupportedInAzureError("create label")
+}
+
+// GetLabel on Azure Repos
+func (client *AzureReposClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	return nil, getUnsupportedInAzureError("get label")
+}
+
+// ListLabels on Azure Repos
+func (client *AzureReposClient) ListLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	return nil, getUnsupportedInAzureError("list labels")
+}
+
+// ListLabelsPager on Azure Repos
+func (client *AzureReposClient) ListLabelsPager(owner, repository string, perPage int) *Pager[LabelInfo] {
+	return newPager(func(ctx context.Context) ([]LabelInfo, bool, error) {
+		return nil, false, getUnsupportedInAzureError("list labels")
+	})
+}
+
+// UpdateLabel on Azure Repos
+func (client *AzureReposClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	return getUnsupportedInAzureError("update label")
+}
+
+// DeleteLabel on Azure Repos
+func (client *AzureReposClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	return getUnsupportedInAzureError("delete label")
+}
+
+// ListPullRequestLabels on Azure Repos
+func (client *AzureReposClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	return nil, getUnsupportedInAzureError("list pull request labels")
+}
+
+// UnlabelPullRequest on Azure Repos
+func (client *AzureReposClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	return getUnsupportedInAzureError("unlabel pull request")
+}
+
+// CreateIssue on Azure Repos
+func (client *AzureReposClient) CreateIssue(ctx context.Context, owner, repository, title, body string, labels []string) (IssueInfo, error) {
+	return IssueInfo{}, getUnsupportedInAzureError("create issue")
+}
+
+// AddIssueComment on Azure Repos
+func (client *AzureReposClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	return getUnsupportedInAzureError("add issue comment")
+}
+
+// ListIssues on Azure Repos
+func (client *AzureReposClient) ListIssues(ctx context.Context, owner, repository string, filter IssueFilter) ([]IssueInfo, error) {
+	return nil, getUnsupportedInAzureError("list issues")
+}
+
+// UpdateIssueState on Azure Repos
+func (client *AzureReposClient) UpdateIssueState(ctx context.Context, owner, repository string, issueID int, state IssueState) error {
+	return getUnsupportedInAzureError("update issue state")
+}
+
+// UploadCodeScanning on Azure Repos
+func (client *AzureReposClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	return "", getUnsupportedInAzureError("upload code scanning")
+}
+
+// UploadScanResults on Azure Repos
+func (client *AzureReposClient) UploadScanResults(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	return "", getUnsupportedInAzureError("upload scan results")
+}
+
+// ListSecurityAlerts on Azure Repos
+func (client *AzureReposClient) ListSecurityAlerts(ctx context.Context, owner, repository string) ([]SecurityAlertInfo, error) {
+	return nil, getUnsupportedInAzureError("list security alerts")
+}
+
+// CreateWebhook on Azure Repos
+func (client *AzureReposClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", getUnsupportedInAzureError("create webhook")
+}
+
+// UpdateWebhook on Azure Repos
+func (client *AzureReposClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token, webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	return getUnsupportedInAzureError("update webhook")
+}
+
+// CreateWebhookWithOptions on Azure Repos
+func (client *AzureReposClient) CreateWebhookWithOptions(ctx context.Context, owner, repository string, options WebhookOptions) (WebhookInfo, string, error) {
+	return WebhookInfo{}, "", getUnsupportedInAzureError("create webhook")
+}
+
+// DeleteWebhook on Azure Repos
+func (client *AzureReposClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	return getUnsupportedInAzureError("delete webhook")
+}
+
+// ListWebhooks on Azure Repos
+func (client *AzureReposClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	return nil, getUnsupportedInAzureError("list webhooks")
+}
+
+// SetWebhookActive on Azure Repos
+func (client *AzureReposClient) SetWebhookActive(ctx context.Context, owner, repository, webhookID string, active bool) error {
+	return getUnsupportedInAzureError("set webhook active")
+}
+
+// CreateOrgWebhook on Azure Repos
+func (client *AzureReposClient) CreateOrgWebhook(ctx context.Context, owner string, options WebhookOptions) (WebhookInfo, string, error) {
+	return WebhookInfo{}, "", getUnsupportedInAzureError("create organization webhook")
+}
+
+// ListOrgWebhooks on Azure Repos
+func (client *AzureReposClient) ListOrgWebhooks(ctx context.Context, owner string) ([]WebhookInfo, error) {
+	return nil, getUnsupportedInAzureError("list organization webhooks")
+}
+
+// DeleteOrgWebhook on Azure Repos
+func (client *AzureReposClient) DeleteOrgWebhook(ctx context.Context, owner, webhookID string) error {
+	return getUnsupportedInAzureError("delete organization webhook")
+}
+
+// SetCommitStatus on Azure Repos
+func (client *AzureReposClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error {
+	return getUnsupportedInAzureError("set commit status")
+}
+
+// SetCommitStatuses on Azure Repos
+func (client *AzureReposClient) SetCommitStatuses(ctx context.Context, owner, repository, ref string, statuses []CommitStatusInfo) error {
+	return getUnsupportedInAzureError("set commit statuses")
+}
+
+// ListCommitStatuses on Azure Repos
+func (client *AzureReposClient) ListCommitStatuses(ctx context.Context, owner, repository, ref string) ([]CommitStatusInfo, error) {
+	return nil, getUnsupportedInAzureError("list commit statuses")
+}
+
+// CreateCheckRun on Azure Repos
+func (client *AzureReposClient) CreateCheckRun(ctx context.Context, owner, repository, ref string, checkRun CheckRunInfo) (int64, error) {
+	return 0, getUnsupportedInAzureError("create check run")
+}
+
+// UpdateCheckRun on Azure Repos
+func (client *AzureReposClient) UpdateCheckRun(ctx context.Context, owner, repository, ref string, checkRunID int64, checkRun CheckRunInfo) error {
+	return getUnsupportedInAzureError("update check run")
+}
+
+// CreateCodeInsightsReport on Azure Repos
+func (client *AzureReposClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, sha string, report CodeInsightsReport) error {
+	return getUnsupportedInAzureError("code insights reports")
+}
+
+// AddCodeInsightsAnnotations on Azure Repos
+func (client *AzureReposClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, sha, reportID string, annotations []CodeInsightsAnnotation) error {
+	return getUnsupportedInAzureError("code insights reports")
+}
+
+// TriggerPipeline on Azure Repos. The pipeline is the numeric ID of an Azure Pipelines pipeline of the project, which is run on the ref of its repository.
+func (client *AzureReposClient) TriggerPipeline(ctx context.Context, _, _, pipeline, ref string, inputs map[string]string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"pipeline": pipeline, "ref": ref, "project": client.vcsInfo.Project})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	pipelineID, err := strconv.Atoi(pipeline)
+	if err != nil {
+		return PipelineInfo{}, fmt.Errorf("the pipeline of Azure Pipelines must be its numeric ID: %w", err)
+	}
+	pipelinesClient := pipelines.NewClient(ctx, client.connectionDetails)
+	if !strings.HasPrefix(ref, "refs/") {
+		ref = "refs/heads/" + ref
+	}
+	variables := map[string]pipelines.Variable{}
+	for name := range inputs {
+		value := inputs[name]
+		variables[name] = pipelines.Variable{Value: &value}
+	}
+	run, err := pipelinesClient.RunPipeline(ctx, pipelines.RunPipelineArgs{
+		Project:    &client.vcsInfo.Project,
+		PipelineId: &pipelineID,
+		RunParameters: &pipelines.RunPipelineParameters{
+			Resources: &pipelines.RunResourcesParameters{Repositories: &map[string]pipelines.RepositoryResourceParameters{"self": {RefName: &ref}}},
+			Variables: &variables,
+		},
+	})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	info := PipelineInfo{ID: strconv.Itoa(vcsutils.DefaultIfNotNil(run.Id)), Ref: ref, WebURL: getAzureWebLink(run.Links)}
+	var state, result string
+	if run.State != nil {
+		state = string(*run.State)
+	}
+	if run.Result != nil {
+		result = string(*run.Result)
+	}
+	info.State = getAzurePipelineStatus(state, result)
+	return info, nil
+}
+
+// GetPipelineStatus on Azure Repos. The run ID of Azure Pipelines is the ID of its build.
+func (client *AzureReposClient) GetPipelineStatus(ctx context.Context, _, _, runID string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"run ID": runID, "project": client.vcsInfo.Project})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	buildID, err := strconv.Atoi(runID)
+	if err != nil {
+		return PipelineInfo{}, fmt.Errorf("the run ID of Azure Pipelines must be numeric: %w", err)
+	}
+	buildClient, err := build.NewClient(ctx, client.connectionDetails)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	run, err := buildClient.GetBuild(ctx, build.GetBuildArgs{Project: &client.vcsInfo.Project, BuildId: &buildID})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	info := PipelineInfo{ID: runID, Ref: vcsutils.DefaultIfNotNil(run.SourceBranch), WebURL: getAzureWebLink(run.Links)}
+	var status, result string
+	if run.Status != nil {
+		status = string(*run.Status)
+	}
+	if run.Result != nil {
+		result = string(*run.Result)
+	}
+	info.State = getAzurePipelineStatus(status, result)
+	return info, nil
+}
+
+// getAzurePipelineStatus returns the status of a pipeline run or a build, which has a result only once it's completed
+func getAzurePipelineStatus(state, result string) CommitStatus {
+	if state != "completed" {
+		return InProgress
+	}
+	switch result {
+	case "succeeded", "partiallySucceeded":
+		return Pass
+	case "failed":
+		return Fail
+	}
+	return Error
+}
+
+// getAzureWebLink returns the web link of the _links field of Azure DevOps resources
+func getAzureWebLink(links interface{}) string {
+	linksMap, ok := links.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	web, ok := linksMap["web"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	href, _ := web["href"].(string)
+	return href
+}
+
+// DownloadFileFromRepo on Azure Repos
+func (client *AzureReposClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	return nil, 0, getUnsupportedInAzureError("download file from repo")
+}
+
+// GetFileContent on Azure Repos
+func (client *AzureReposClient) GetFileContent(ctx context.Context, _, repository, ref, path string) (FileContent, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "ref": ref, "path": path})
+	if err != nil {
+		return FileContent{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return FileContent{}, err
+	}
+	versionType := getAzureReposVersionType(ref)
+	includeContent := true
+	item, err := azureReposGitClient.GetItem(ctx, git.GetItemArgs{
+		RepositoryId:      &repository,
+		Path:              &path,
+		Project:           &client.vcsInfo.Project,
+		IncludeContent:    &includeContent,
+		VersionDescriptor: &git.GitVersionDescriptor{Version: &ref, VersionType: &versionType},
+	})
+	if err != nil {
+		return FileContent{}, err
+	}
+	if item.IsFolder != nil && *item.IsFolder {
+		return FileContent{}, fmt.Errorf("%s is not a file", path)
+	}
+	result := FileContent{Path: path}
+	if item.Content != nil {
+		result.Content = []byte(*item.Content)
+		result.Size = int64(len(result.Content))
+	}
+	if item.ObjectId != nil {
+		result.Sha = *item.ObjectId
+	}
+	return result, nil
+}
+
+// GetFileBlame on Azure Repos
+func (client *AzureReposClient) GetFileBlame(_ context.Context, _, _, _, _ string) ([]BlameLine, error) {
+	return nil, getUnsupportedInAzureError("get file blame")
+}
+
+// ListRepositoryTree on Azure Repos. Azure Repos doesn't provide the sizes of the files.
+func (client *AzureReposClient) ListRepositoryTree(ctx context.Context, _, repository, ref string, recursive bool) ([]TreeEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	versionType := getAzureReposVersionType(ref)
+	scopePath := "/"
+	recursionLevel := git.VersionControlRecursionTypeValues.OneLevel
+	if recursive {
+		recursionLevel = git.VersionControlRecursionTypeValues.Full
+	}
+	items, err := azureReposGitClient.GetItems(ctx, git.GetItemsArgs{
+		RepositoryId:      &repository,
+		Project:           &client.vcsInfo.Project,
+		ScopePath:         &scopePath,
+		RecursionLevel:    &recursionLevel,
+		VersionDescriptor: &git.GitVersionDescriptor{Version: &ref, VersionType: &versionType},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var results []TreeEntry
+	for _, item := range *items {
+		path := strings.TrimPrefix(vcsutils.DefaultIfNotNil(item.Path), "/")
+		// The root directory is included in the items
+		if path == "" {
+			continue
+		}
+		results = append(results, TreeEntry{Path: path, Type: getGitTreeEntryType(string(vcsutils.DefaultIfNotNil(item.GitObjectType)))})
+	}
+	return results, nil
+}
+
+// SearchCode on Azure Repos
+func (client *AzureReposClient) SearchCode(ctx context.Context, scope CodeSearchScope, query string) ([]CodeSearchResult, error) {
+	return nil, getUnsupportedInAzureError("code search")
+}
+
+// CreateOrUpdateFile on Azure Repos
+func (client *AzureReposClient) CreateOrUpdateFile(ctx context.Context, _, repository, branch, path string, content []byte, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"repository":     repository,
+		"branch":         branch,
+		"path":           path,
+		"commit message": commitMessage,
+	})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	change := FileChange{Type: UpdateFile, Path: path, Content: content}
+	_, err = azureReposGitClient.GetItem(ctx, git.GetItemArgs{
+		RepositoryId:      &repository,
+		Path:              &path,
+		Project:           &client.vcsInfo.Project,
+		VersionDescriptor: &git.GitVersionDescriptor{Version: &branch, VersionType: &git.GitVersionTypeValues.Branch},
+	})
+	if err != nil {
+		if !isAzureReposNotFoundError(err) {
+			return err
+		}
+		change.Type = AddFile
+	}
+	client.logger.Debug("committing file", path, "to branch", branch)
+	return client.pushChanges(ctx, azureReposGitClient, repository, branch, commitMessage, []FileChange{change})
+}
+
+// CommitFiles on Azure Repos
+func (client *AzureReposClient) CommitFiles(ctx context.Context, _, repository, branch, commitMessage string, changes []FileChange) error {
+	err := validateParametersNotBlank(map[string]string{
+		"repository":     repository,
+		"branch":         branch,
+		"commit message": commitMessage,
+	})
+	if err != nil {
+		return err
+	}
+	if err = validateFileChanges(changes); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("committing", len(changes), "files to branch", branch)
+	return client.pushChanges(ctx, azureReposGitClient, repository, branch, commitMessage, changes)
+}
+
+// pushChanges pushes a single commit containing the given file changes on top of the branch
+func (client *AzureReposClient) pushChanges(ctx context.Context, azureReposGitClient git.Client, repository, branch, commitMessage string, changes []FileChange) error {
+	// Pushing to a branch requires its current commit
+	commitID, err := client.getBranchCommitID(ctx, azureReposGitClient, repository, branch)
+	if err != nil {
+		return err
+	}
+	refName := vcsutils.AddBranchPrefix(branch)
+	gitChanges := make([]interface{}, 0, len(changes))
+	for _, change := range changes {
+		path := change.Path
+		gitChange := git.GitChange{ChangeType: getAzureReposChangeType(change.Type), Item: git.GitItem{Path: &path}}
+		if change.Type != DeleteFile {
+			encodedContent := base64.StdEncoding.EncodeToString(change.Content)
+			gitChange.NewContent = &git.ItemContent{Content: &encodedContent, ContentType: &git.ItemContentTypeValues.Base64Encoded}
+		}
+		gitChanges = append(gitChanges, gitChange)
+	}
+	_, err = azureReposGitClient.CreatePush(ctx, git.CreatePushArgs{
+		Push: &git.GitPush{
+			RefUpdates: &[]git.GitRefUpdate{{Name: &refName, OldObjectId: &commitID}},
+			Commits:    &[]git.GitCommitRef{{Comment: &commitMessage, Changes: &gitChanges}},
+		},
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// GetRepositoryEnvironmentInfo on GitLab
+func (client *AzureReposClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, getUnsupportedInAzureError("get repository environment info")
+}
+
+// ListEnvironments on Azure Repos
+func (client *AzureReposClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, getUnsupportedInAzureError("list environments")
+}
+
+// CreateDeployment on Azure Repos
+func (client *AzureReposClient) CreateDeployment(ctx context.Context, owner, repository string, deployment DeploymentInfo) (int64, error) {
+	return 0, getUnsupportedInAzureError("create deployment")
+}
+
+// SetDeploymentStatus on Azure Repos
+func (client *AzureReposClient) SetDeploymentStatus(ctx context.Context, owner, repository string, deploymentID int64, status DeploymentStatusInfo) error {
+	return getUnsupportedInAzureError("set deployment status")
+}
+
+func getAzureReposMergeStrategy(mergeStrategy MergeStrategy) *git.GitPullRequestMergeStrategy {
+	switch mergeStrategy {
+	case SquashMerge:
+		return &git.GitPullRequestMergeStrategyValues.Squash
+	case RebaseMerge:
+		return &git.GitPullRequestMergeStrategyValues.Rebase
+	}
+	return &git.GitPullRequestMergeStrategyValues.NoFastForward
+}
+
+func getAzureReposRefUpdateFailureReason(result git.GitRefUpdateResult) string {
+	if result.CustomMessage != nil && *result.CustomMessage != "" {
+		return *result.CustomMessage
+	}
+	if result.UpdateStatus != nil {
+		return string(*result.UpdateStatus)
+	}
+	return "unknown reason"
+}
+
+func mapAzureReposCommitToCommitInfo(commit git.GitCommitRef) CommitInfo {
+	return CommitInfo{
+		Hash:          vcsutils.DefaultIfNotNil(commit.CommitId),
+		AuthorName:    vcsutils.DefaultIfNotNil(commit.Author.Name),
+		CommitterName: vcsutils.DefaultIfNotNil(commit.Committer.Name),
+		Url:           vcsutils.DefaultIfNotNil(commit.Url),
+		Timestamp:     commit.Committer.Date.Time.Unix(),
+		Message:       vcsutils.DefaultIfNotNil(commit.Comment),
+		ParentHashes:  vcsutils.DefaultIfNotNil(commit.Parents),
+	}
+}
+
+// mapAzureReposCommitDiffChange maps a change of the commit diffs API, which the library returns untyped.
+// Returns false for changes of folders.
+func mapAzureReposCommitDiffChange(change interface{}) (PullRequestFile, bool, error) {
+	changeBytes, err := json.Marshal(change)
+	if err != nil {
+		return PullRequestFile{}, false, err
+	}
+	var pullRequestChange git.GitPullRequestChange
+	if err = json.Unmarshal(changeBytes, &pullRequestChange); err != nil {
+		return PullRequestFile{}, false, err
+	}
+	if item, ok := pullRequestChange.Item.(map[string]interface{}); ok && item["isFolder"] == true {
+		return PullRequestFile{}, false, nil
+	}
+	return mapAzureReposPullRequestChange(pullRequestChange), true, nil
+}
+
+func mapAzureReposPullRequestChange(change git.GitPullRequestChange) PullRequestFile {
+	file := PullRequestFile{Status: FileModified}
+	if item, ok := change.Item.(map[string]interface{}); ok {
+		file.Path, _ = item["path"].(string)
+	}
+	if change.ChangeType == nil {
+		return file
+	}
+	// The change type is a comma separated list of flags, such as "edit, rename"
+	changeType := string(*change.ChangeType)
+	switch {
+	case strings.Contains(changeType, string(git.VersionControlChangeTypeValues.Add)):
+		file.Status = FileAdded
+	case strings.Contains(changeType, string(git.VersionControlChangeTypeValues.Delete)):
+		file.Status = FileDeleted
+	case strings.Contains(changeType, string(git.VersionControlChangeTypeValues.Rename)):
+		file.Status = FileRenamed
+		if change.OriginalPath != nil {
+			file.PreviousPath = *change.OriginalPath
+		}
+	}
+	return file
+}
+
+func mapAzureReposThreadToReviewComment(thread git.GitPullRequestCommentThread) PullRequestReviewComment {
+	firstComment := (*thread.Comments)[0]
+	reviewComment := PullRequestReviewComment{
+		CommentInfo: CommentInfo{
+			ID:      int64(vcsutils.DefaultIfNotNil(thread.Id)),
+			Content: vcsutils.DefaultIfNotNil(firstComment.Content),
+		},
+		Path: strings.TrimPrefix(*thread.ThreadContext.FilePath, "/"),
+	}
+	if thread.PublishedDate != nil {
+		reviewComment.Created = thread.PublishedDate.Time
+	}
+	start, end := thread.ThreadContext.RightFileStart, thread.ThreadContext.RightFileEnd
+	if end == nil {
+		// Comments on removed lines are anchored to the old file version
+		start, end = thread.ThreadContext.LeftFileStart, thread.ThreadContext.LeftFileEnd
+	}
+	if end != nil {
+		reviewComment.Line = vcsutils.DefaultIfNotNil(end.Line)
+	}
+	if start != nil && vcsutils.DefaultIfNotNil(start.Line) != reviewComment.Line {
+		reviewComment.StartLine = vcsutils.DefaultIfNotNil(start.Line)
+	}
+	return reviewComment
+}
+
+func mapAzureReposPullRequestToPullRequestInfo(pullRequest *git.GitPullRequest, repository string) PullRequestInfo {
+	pullRequestInfo := PullRequestInfo{
+		Source: BranchInfo{Name: getAzureReposShortRefName(pullRequest.SourceRefName), Repository: repository},
+		Target: BranchInfo{Name: getAzureReposShortRefName(pullRequest.TargetRefName), Repository: repository},
+		Title:  vcsutils.DefaultIfNotNil(pullRequest.Title),
+		Body:   vcsutils.DefaultIfNotNil(pullRequest.Description),
+		Draft:  vcsutils.DefaultIfNotNil(pullRequest.IsDraft),
+	}
+	if pullRequest.PullRequestId != nil {
+		pullRequestInfo.ID = int64(*pullRequest.PullRequestId)
+	}
+	if pullRequest.ForkSource != nil && pullRequest.ForkSource.Repository != nil {
+		pullRequestInfo.Source.Repository = vcsutils.DefaultIfNotNil(pullRequest.ForkSource.Repository.Name)
+	}
+	if pullRequest.Status != nil {
+		switch *pullRequest.Status {
+		case git.PullRequestStatusValues.Completed:
+			pullRequestInfo.State = PullRequestMerged
+		case git.PullRequestStatusValues.Abandoned:
+			pullRequestInfo.State = PullRequestClosed
+		}
+	}
+	if pullRequest.CreatedBy != nil {
+		pullRequestInfo.Author = vcsutils.DefaultIfNotNil(pullRequest.CreatedBy.UniqueName)
+	}
+	if pullRequest.Labels != nil {
+		for _, label := range *pullRequest.Labels {
+			pullRequestInfo.Labels = append(pullRequestInfo.Labels, vcsutils.DefaultIfNotNil(label.Name))
+		}
+	}
+	if pullRequest.MergeStatus != nil {
+		switch *pullRequest.MergeStatus {
+		case git.PullRequestAsyncStatusValues.Succeeded:
+			mergeable := true
+			pullRequestInfo.Mergeable = &mergeable
+		case git.PullRequestAsyncStatusValues.Conflicts:
+			mergeable := false
+			pullRequestInfo.Mergeable = &mergeable
+		}
+	}
+	return pullRequestInfo
+}
+
+// getAzureReposShortRefName trims the "refs/heads/" prefix and returns the actual branch name
+func getAzureReposShortRefName(refName *string) string {
+	return strings.TrimPrefix(vcsutils.DefaultIfNotNil(refName), "refs/heads/")
+}
+
+func getAzureReposChangeType(changeType FileChangeType) *git.VersionControlChangeType {
+	switch changeType {
+	case UpdateFile:
+		return &git.VersionControlChangeTypeValues.Edit
+	case DeleteFile:
+		return &git.VersionControlChangeTypeValues.Delete
+	}
+	return &git.VersionControlChangeTypeValues.Add
+}
+
+func isAzureReposNotFoundError(err error) bool {
+	var wrappedError azuredevops.WrappedError
+	if errors.As(err, &wrappedError) {
+		return wrappedError.StatusCode != nil && *wrappedError.StatusCode == http.StatusNotFound
+	}
+	var wrappedErrorPointer *azuredevops.WrappedError
+	if errors.As(err, &wrappedErrorPointer) {
+		return wrappedErrorPointer.StatusCode != nil && *wrappedErrorPointer.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// getAzureReposVersionType returns the type of a version, which is either a commit hash or a branch name
+func getAzureReposVersionType(version string) git.GitVersionType {
+	if commitShaRegexp.MatchString(version) {
+		return git.GitVersionTypeValues.Commit
+	}
+	return git.GitVersionTypeValues.Branch
 }
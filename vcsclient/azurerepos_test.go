@@ -5,13 +5,17 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -28,6 +32,14 @@ func TestAzureRepos_Connection(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAzureRepos_GetRateLimitInfo(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.GetRateLimitInfo(ctx)
+	assert.Error(t, err)
+}
+
 func TestAzureRepos_ListRepositories(t *testing.T) {
 	type ListRepositoryResponse struct {
 		Value []git.GitRepository
@@ -55,6 +67,14 @@ func TestAzureRepos_ListRepositories(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{})
+	assert.Error(t, err)
+}
+
 func TestAzureRepos_TestListBranches(t *testing.T) {
 	type ListBranchesResponse struct {
 		Value []git.GitBranchStats
@@ -80,6 +100,139 @@ func TestAzureRepos_TestListBranches(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_TestListBranchesPager(t *testing.T) {
+	ctx := context.Background()
+	branchesResponse, err := json.Marshal(map[string]interface{}{
+		"value": []git.GitBranchStats{{Name: &branch1}, {Name: &branch2}},
+		"count": 2,
+	})
+	require.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, branchesResponse, "listBranches", createAzureReposHandler)
+	defer cleanUp()
+
+	pager := client.ListBranchesPager("", repo1, 1)
+	page, err := pager.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{branch1, branch2}, page)
+	assert.False(t, pager.HasNext())
+}
+
+func TestAzureRepos_TestListAllBranches(t *testing.T) {
+	type ListBranchesResponse struct {
+		Value []git.GitBranchStats
+		Count int
+	}
+	featureBranch, sha := "feature", "sha1"
+	res := ListBranchesResponse{
+		Value: []git.GitBranchStats{{Name: &branch1, Commit: &git.GitCommitRef{CommitId: &sha}}, {Name: &featureBranch}},
+		Count: 2,
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "listBranches", createAzureReposHandler)
+	defer cleanUp()
+	resp, err := client.ListAllBranches(ctx, "", repo1, "branch-")
+	assert.NoError(t, err)
+	assert.Equal(t, []BranchDetails{{Name: branch1, CommitHash: sha}}, resp)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListAllBranches(ctx, "", repo1, "")
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestCreateBranch(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	res := map[string]interface{}{"value": []git.GitRefUpdateResult{{Success: &[]bool{true}[0]}}, "count": 1}
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, res, "updateRefs", createAzureReposHandler)
+	defer cleanUp()
+	err := client.CreateBranch(ctx, "", repo1, branch1, sha)
+	assert.NoError(t, err)
+
+	failedRes := map[string]interface{}{"value": []git.GitRefUpdateResult{{Success: &[]bool{false}[0], UpdateStatus: &git.GitRefUpdateStatusValues.InvalidRefName}}, "count": 1}
+	failingClient, failingCleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, failedRes, "updateRefs", createAzureReposHandler)
+	defer failingCleanUp()
+	err = failingClient.CreateBranch(ctx, "", repo1, branch1, sha)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalidRefName")
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.CreateBranch(ctx, "", repo1, branch1, "master")
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestDeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	branchResponse, err := json.Marshal(git.GitBranchStats{Name: &branch1, Commit: &git.GitCommitRef{CommitId: &sha}})
+	assert.NoError(t, err)
+	refsResponse, err := json.Marshal(map[string]interface{}{"value": []git.GitRefUpdateResult{{Success: &[]bool{true}[0]}}, "count": 1})
+	assert.NoError(t, err)
+	refsHandler := createAzureReposHandler(t, "updateRefs", refsResponse, http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "listBranches") {
+			_, err := w.Write(branchResponse)
+			assert.NoError(t, err)
+			return
+		}
+		refsHandler(w, r)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	err = client.DeleteBranch(ctx, "", repo1, branch1)
+	assert.NoError(t, err)
+}
+
+func TestAzureRepos_TestListTags(t *testing.T) {
+	ctx := context.Background()
+	lightweightTag, annotatedTag := "refs/tags/v1.0.0", "refs/tags/v1.1.0"
+	sha1, sha2, tagObjectID := "sha1", "sha2", "tag-object-id"
+	res := map[string]interface{}{"value": []git.GitRef{
+		{Name: &lightweightTag, ObjectId: &sha1},
+		{Name: &annotatedTag, ObjectId: &tagObjectID, PeeledObjectId: &sha2},
+	}, "count": 2}
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, res, "updateRefs?filter=tags%2F&peelTags=true", createAzureReposHandler)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, "", repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitHash: "sha1"}, {Name: "v1.1.0", CommitHash: "sha2"}}, tags)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListTags(ctx, "", repo1)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestCreateTag(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	tagResponse, err := json.Marshal(git.GitAnnotatedTag{})
+	assert.NoError(t, err)
+	server := httptest.NewServer(createAzureReposHandler(t, "annotatedTags", tagResponse, http.StatusOK))
+	defer server.Close()
+	// Creating annotated tags requires the project
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Username("frogger").Token(token).Project("project").Build()
+	require.NoError(t, err)
+	err = client.CreateTag(ctx, "", repo1, "v1.0.0", sha, "Release 1.0.0")
+	assert.NoError(t, err)
+
+	res := map[string]interface{}{"value": []git.GitRefUpdateResult{{Success: &[]bool{true}[0]}}, "count": 1}
+	lightweightClient, lightweightCleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, res, "updateRefs", createAzureReposHandler)
+	defer lightweightCleanUp()
+	err = lightweightClient.CreateTag(ctx, "", repo1, "v1.0.0", sha, "")
+	assert.NoError(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.CreateTag(ctx, "", repo1, "v1.0.0", sha, "Release 1.0.0")
+	assert.Error(t, err)
+}
+
 func TestAzureRepos_TestDownloadRepository(t *testing.T) {
 	ctx := context.Background()
 	dir, err := os.MkdirTemp("", "")
@@ -89,7 +242,7 @@ func TestAzureRepos_TestDownloadRepository(t *testing.T) {
 	repoFile, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "hello_world.zip"))
 	require.NoError(t, err)
 
-	downloadURL := fmt.Sprintf("/%s/_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&$format=zip",
+	downloadURL := fmt.Sprintf("/%s/_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&versionDescriptor[versionType]=branch&$format=zip",
 		"",
 		repo1,
 		branch1)
@@ -107,6 +260,36 @@ func TestAzureRepos_TestDownloadRepository(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_DownloadRepositoryAtRef(t *testing.T) {
+	ctx := context.Background()
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "hello_world.zip"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		ref             string
+		expectedVersion string
+	}{
+		{ref: "6dcb09b5b57875f334f61aebed695e2e4193db5e", expectedVersion: "versionDescriptor[version]=6dcb09b5b57875f334f61aebed695e2e4193db5e&versionDescriptor[versionType]=commit"},
+		{ref: "refs/tags/v1.0.0", expectedVersion: "versionDescriptor[version]=v1.0.0&versionDescriptor[versionType]=tag"},
+	}
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			dir := t.TempDir()
+			client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, repoFile,
+				fmt.Sprintf("/_apis/git/repositories/%s/items/items?path=/&%s&$format=zip", repo1, test.expectedVersion), createAzureReposHandler)
+			defer cleanUp()
+			err := client.DownloadRepositoryAtRef(ctx, "", repo1, test.ref, dir, Zip)
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(dir, "README.md"))
+		})
+	}
+
+	client, err := NewClientBuilder(vcsutils.AzureRepos).Build()
+	require.NoError(t, err)
+	err = client.DownloadRepositoryAtRef(ctx, owner, repo1, branch1, t.TempDir(), TarGz)
+	assert.ErrorIs(t, err, errAzureReposTarGzArchiveNotSupported)
+}
+
 func TestAzureRepos_TestCreatePullRequest(t *testing.T) {
 	type CreatePullRequestResponse struct {
 		Value git.GitPullRequest
@@ -137,6 +320,136 @@ func TestAzureRepos_TestCreatePullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_TestCreateDraftPullRequest(t *testing.T) {
+	ctx := context.Background()
+	pullRequestsHandler := createAzureReposHandler(t, "getPullRequests", []byte(`{"pullRequestId":1,"isDraft":true}`), http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "getPullRequests") {
+			assert.Equal(t, http.MethodPost, r.Method)
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(b), `"isDraft":true`)
+		}
+		pullRequestsHandler(w, r)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	err := client.CreateDraftPullRequest(ctx, "", repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	err = badClient.CreateDraftPullRequest(ctx, "", repo1, branch1, branch2, "PR title", "PR body")
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestMarkPullRequestReady(t *testing.T) {
+	ctx := context.Background()
+	pullRequestsHandler := createAzureReposHandler(t, "getPullRequests", []byte(`{"pullRequestId":1,"isDraft":false}`), http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "getPullRequests") {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"isDraft":false}`, string(b))
+		}
+		pullRequestsHandler(w, r)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	err := client.MarkPullRequestReady(ctx, "", repo1, 1)
+	assert.NoError(t, err)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	err = badClient.MarkPullRequestReady(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestMergePullRequest(t *testing.T) {
+	commitID := "86d6919952702f9ab03bc95b45687f145a663de0"
+	pullRequestID := 1
+	res := git.GitPullRequest{
+		PullRequestId:         &pullRequestID,
+		LastMergeSourceCommit: &git.GitCommitRef{CommitId: &commitID},
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", createAzureReposHandler)
+	defer cleanUp()
+	err = client.MergePullRequest(ctx, "", repo1, 1, SquashMerge, "Merge commit message")
+	assert.NoError(t, err)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	err = badClient.MergePullRequest(ctx, "", repo1, 1, SquashMerge, "Merge commit message")
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_PullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	err := client.EnablePullRequestAutoMerge(ctx, "", repo1, 1, SquashMerge)
+	assert.Error(t, err)
+	err = client.DisablePullRequestAutoMerge(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestUpdatePullRequest(t *testing.T) {
+	pullRequestID := 1
+	res := git.GitPullRequest{PullRequestId: &pullRequestID}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", createAzureReposHandler)
+	defer cleanUp()
+	closed := PullRequestClosed
+	err = client.UpdatePullRequest(ctx, "", repo1, "New title", "New body", "dev", pullRequestID, &closed)
+	assert.NoError(t, err)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	err = badClient.UpdatePullRequest(ctx, "", repo1, "New title", "New body", "dev", pullRequestID, &closed)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestSubmitPullRequestReview(t *testing.T) {
+	ctx := context.Background()
+	connectionDataResponse := []byte(`{"authenticatedUser":{"id":"c3b7b7a4-7f2b-4c8e-9d0a-5d0e8f1a2b3c"}}`)
+	reviewerHandler := createAzureReposHandler(t, "pullRequestReviewers", []byte(`{"vote":10}`), http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "connectionData") {
+			_, err := w.Write(connectionDataResponse)
+			assert.NoError(t, err)
+			return
+		}
+		if strings.Contains(r.RequestURI, "pullRequestReviewers") {
+			assert.Equal(t, http.MethodPut, r.Method)
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"vote":10}`, string(b))
+		}
+		reviewerHandler(w, r)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	err := client.ApprovePullRequest(ctx, "", repo1, 1)
+	assert.NoError(t, err)
+
+	err = client.SubmitPullRequestReview(ctx, "", repo1, 1, ReviewComment, "")
+	assert.Error(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.SubmitPullRequestReview(ctx, "", repo1, 1, ReviewRequestChanges, "")
+	assert.Error(t, err)
+}
+
 func TestAzureRepos_TestAddPullRequestComment(t *testing.T) {
 	type AddPullRequestCommentResponse struct {
 		Value git.GitPullRequestCommentThread
@@ -193,6 +506,172 @@ func TestAzureRepos_TestListOpenPullRequests(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_TestListOpenPullRequestsWithFilter(t *testing.T) {
+	pullRequestID, otherPullRequestID := 1, 2
+	author, otherAuthor := "frogger@jfrog.com", "toad@jfrog.com"
+	sourceRefName, targetRefName := "refs/heads/"+branch1, "refs/heads/"+branch2
+	response, err := json.Marshal(map[string]interface{}{
+		"value": []git.GitPullRequest{
+			{PullRequestId: &pullRequestID, SourceRefName: &sourceRefName, TargetRefName: &targetRefName, CreatedBy: &webapi.IdentityRef{UniqueName: &author}},
+			{PullRequestId: &otherPullRequestID, SourceRefName: &sourceRefName, TargetRefName: &targetRefName, CreatedBy: &webapi.IdentityRef{UniqueName: &otherAuthor}},
+		},
+		"count": 2,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response,
+		"getPullRequests?%24skip=2&%24top=2&searchCriteria.sourceRefName=refs%2Fheads%2F"+branch1, createAzureReposHandler)
+	defer cleanUp()
+	result, err := client.ListOpenPullRequestsWithFilter(ctx, "", repo1, PullRequestFilter{Author: author, SourceBranch: branch1, Page: 2, PerPage: 2})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(1), result[0].ID)
+	assert.Equal(t, author, result[0].Author)
+
+	_, err = client.ListOpenPullRequestsWithFilter(ctx, "", repo1, PullRequestFilter{UpdatedSince: time.Now()})
+	assert.ErrorIs(t, err, errAzureReposUpdatedSinceFilterNotSupported)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	_, err = badClient.ListOpenPullRequestsWithFilter(ctx, "", repo1, PullRequestFilter{})
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	iterationsResponse, err := json.Marshal(map[string]interface{}{"value": []git.GitPullRequestIteration{{Id: &[]int{1}[0]}, {Id: &[]int{2}[0]}}, "count": 2})
+	assert.NoError(t, err)
+	changesResponse := []byte(`{"changeEntries":[` +
+		`{"changeType":"edit","item":{"path":"/go.mod"}},` +
+		`{"changeType":"add","item":{"path":"/new.go"}},` +
+		`{"changeType":"edit, rename","item":{"path":"/b.go"},"originalPath":"/a.go"}]}`)
+	changesHandler := createAzureReposHandler(t, "iterationChanges", changesResponse, http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "pullRequestIterations") {
+			_, err := w.Write(iterationsResponse)
+			assert.NoError(t, err)
+			return
+		}
+		changesHandler(w, r)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	files, err := client.ListPullRequestFiles(ctx, "", repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "/go.mod", Status: FileModified},
+		{Path: "/new.go", Status: FileAdded},
+		{Path: "/b.go", PreviousPath: "/a.go", Status: FileRenamed},
+	}, files)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListPullRequestFiles(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestGetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.GetPullRequestDiff(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestListPullRequestCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "commits.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "pullRequestCommits", createAzureReposHandler)
+	defer cleanUp()
+
+	commits, err := client.ListPullRequestCommits(ctx, "", repo1, 1)
+	require.NoError(t, err)
+	require.Len(t, commits, 3)
+	assert.Equal(t, CommitInfo{
+		Hash:          "86d6919952702f9ab03bc95b45687f145a663de0",
+		AuthorName:    "Test User",
+		CommitterName: "Test User",
+		Url:           "https://dev.azure.com/testuser/0b8072c4-ad86-4edb-a8f2-06dbc07e3e2d/_apis/git/repositories/94c1dba8-d9d9-4600-94b4-1a51acb43220/commits/86d6919952702f9ab03bc95b45687f145a663de0",
+		Timestamp:     1667812601,
+		Message:       "Updated package.json",
+	}, commits[0])
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListPullRequestCommits(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestGetPullRequestByID(t *testing.T) {
+	pullRequestID := 1
+	title, description, author, label := "Fix all the bugs", "Pull request body", "testuser@example.com", "bug"
+	sourceRefName, targetRefName := "refs/heads/"+branch1, "refs/heads/"+branch2
+	res := git.GitPullRequest{
+		PullRequestId: &pullRequestID,
+		Title:         &title,
+		Description:   &description,
+		SourceRefName: &sourceRefName,
+		TargetRefName: &targetRefName,
+		Status:        &git.PullRequestStatusValues.Completed,
+		CreatedBy:     &webapi.IdentityRef{UniqueName: &author},
+		Labels:        &[]core.WebApiTagDefinition{{Name: &label}},
+		MergeStatus:   &git.PullRequestAsyncStatusValues.Conflicts,
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", createAzureReposHandler)
+	defer cleanUp()
+
+	pullRequest, err := client.GetPullRequestByID(ctx, "", repo1, pullRequestID)
+	require.NoError(t, err)
+	mergeable := false
+	assert.Equal(t, PullRequestInfo{
+		ID:        1,
+		Source:    BranchInfo{Name: branch1, Repository: repo1},
+		Target:    BranchInfo{Name: branch2, Repository: repo1},
+		Title:     title,
+		Body:      description,
+		State:     PullRequestMerged,
+		Author:    author,
+		Labels:    []string{label},
+		Mergeable: &mergeable,
+	}, pullRequest)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetPullRequestByID(ctx, "", repo1, pullRequestID)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_GetPullRequestMergeableState(t *testing.T) {
+	pullRequestID := 1
+	sourceRefName, targetRefName := "refs/heads/"+branch1, "refs/heads/"+branch2
+	res := git.GitPullRequest{
+		PullRequestId: &pullRequestID,
+		SourceRefName: &sourceRefName,
+		TargetRefName: &targetRefName,
+		Status:        &git.PullRequestStatusValues.Active,
+		MergeStatus:   &git.PullRequestAsyncStatusValues.Succeeded,
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", createAzureReposHandler)
+	defer cleanUp()
+
+	state, err := client.GetPullRequestMergeableState(ctx, "", repo1, pullRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestMergeableState{Mergeable: true}, state)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetPullRequestMergeableState(ctx, "", repo1, pullRequestID)
+	assert.Error(t, err)
+}
+
 func TestListPullRequestComments(t *testing.T) {
 	type ListPullRequestCommentsResponse struct {
 		Value []git.GitPullRequestCommentThread
@@ -238,6 +717,91 @@ func TestListPullRequestComments(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_TestUpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(`{"id":1,"content":"New content"}`),
+		"pullRequestThreadComments", createAzureReposHandler)
+	defer cleanUp()
+	err := client.UpdatePullRequestComment(ctx, "", repo1, "New content", 1, 123)
+	assert.NoError(t, err)
+
+	err = client.UpdatePullRequestComment(ctx, "", repo1, "", 1, 123)
+	assert.Error(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.UpdatePullRequestComment(ctx, "", repo1, "New content", 1, 123)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestDeletePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "pullRequestThreadComments", createAzureReposHandler)
+	defer cleanUp()
+	err := client.DeletePullRequestComment(ctx, "", repo1, 1, 123)
+	assert.NoError(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.DeletePullRequestComment(ctx, "", repo1, 1, 123)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestAddPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	threadsHandler := createAzureReposHandler(t, "pullRequestComments", []byte(`{"id":123}`), http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "pullRequestComments") {
+			assert.Equal(t, http.MethodPost, r.Method)
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"comments":[{"content":"Use a constant"}],"status":"active","threadContext":{"filePath":"/main.go",`+
+				`"rightFileEnd":{"line":5,"offset":1},"rightFileStart":{"line":3,"offset":1}}}`, string(b))
+		}
+		threadsHandler(w, r)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	comment := PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go", Line: 5, StartLine: 3}
+	err := client.AddPullRequestReviewComment(ctx, "", repo1, 1, comment)
+	assert.NoError(t, err)
+
+	err = client.AddPullRequestReviewComment(ctx, "", repo1, 1, PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go"})
+	assert.Error(t, err)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	err = badClient.AddPullRequestReviewComment(ctx, "", repo1, 1, comment)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestListPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"count":3,"value":[` +
+		`{"id":1,"publishedDate":"2022-05-16T11:04:07Z","comments":[{"id":1,"content":"General comment"}]},` +
+		`{"id":2,"publishedDate":"2022-05-16T11:04:07Z","comments":[{"id":1,"content":"Use a constant"},{"id":2,"content":"Done"}],` +
+		`"threadContext":{"filePath":"/main.go","rightFileStart":{"line":3,"offset":1},"rightFileEnd":{"line":5,"offset":1}}},` +
+		`{"id":3,"publishedDate":"2022-05-16T11:04:07Z","comments":[{"id":1,"content":"Removed"}],` +
+		`"threadContext":{"filePath":"/go.mod","leftFileStart":{"line":7,"offset":1},"leftFileEnd":{"line":7,"offset":1}}}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "pullRequestComments", createAzureReposHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestReviewComments(ctx, "", repo1, 1)
+	require.NoError(t, err)
+	expectedCreated, err := time.Parse(time.RFC3339, "2022-05-16T11:04:07Z")
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewComment{
+		{CommentInfo: CommentInfo{ID: 2, Content: "Use a constant", Created: expectedCreated}, Path: "main.go", Line: 5, StartLine: 3},
+		{CommentInfo: CommentInfo{ID: 3, Content: "Removed", Created: expectedCreated}, Path: "go.mod", Line: 7},
+	}, result)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListPullRequestReviewComments(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
 func TestAzureRepos_TestGetLatestCommit(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "commits.json"))
@@ -270,6 +834,18 @@ func TestAzureReposClient_AddSshKeyToRepository(t *testing.T) {
 	assert.Error(t, client.AddSshKeyToRepository(ctx, owner, repo1, "", "", 0777))
 }
 
+func TestAzureReposClient_DeployKeys(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.ListDeployKeys(ctx, owner, repo1)
+	assert.Error(t, err)
+	_, err = client.AddDeployKey(ctx, owner, repo1, "frogbot", "ssh-rsa AAAA...", true)
+	assert.Error(t, err)
+	err = client.DeleteDeployKey(ctx, owner, repo1, 7)
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_CreateLabel(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -285,6 +861,85 @@ func TestAzureReposClient_GetRepositoryInfo(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_GetRepositoryLanguages(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"repositoryLanguageAnalytics":[
+		{"name":"repo-2","languageBreakdown":[{"name":"Java","languagePercentage":100}]},
+		{"name":"repo-1","languageBreakdown":[{"name":"Go","languagePercentage":75},{"name":"Shell","languagePercentage":25}]}
+	]}`)
+	server := httptest.NewServer(createAzureReposHandler(t, "languageMetrics", response, http.StatusOK))
+	defer server.Close()
+	// The language metrics are of the project
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Username("frogger").Token(token).Project("project").Build()
+	require.NoError(t, err)
+
+	languages, err := client.GetRepositoryLanguages(ctx, "", repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"Go": 75, "Shell": 25}, languages)
+
+	// The project is required
+	projectlessClient, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "languageMetrics", createAzureReposHandler)
+	defer cleanUp()
+	_, err = projectlessClient.GetRepositoryLanguages(ctx, "", repo1)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_CreateRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, git.GitRepository{Name: &repo1},
+		"listRepositories", createAzureReposHandler)
+	defer cleanUp()
+
+	err := client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{})
+	assert.NoError(t, err)
+
+	err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Description: "Frogs"})
+	assert.ErrorIs(t, err, errAzureReposRepositorySettingsNotSupported)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	err = badClient.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{})
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_DeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	repositoryID := uuid.New()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, git.GitRepository{Id: &repositoryID, Name: &repo1},
+		"listRepositories", createAzureReposHandler)
+	defer cleanUp()
+
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	err = badClient.DeleteRepository(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.ForkRepository(ctx, owner, repo1, "", false)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_Collaborators(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.ListCollaborators(ctx, owner, repo1)
+	assert.Error(t, err)
+	err = client.AddCollaborator(ctx, owner, repo1, username, PermissionRead)
+	assert.Error(t, err)
+	err = client.RemoveCollaborator(ctx, owner, repo1, username)
+	assert.Error(t, err)
+	_, err = client.GetUserPermission(ctx, owner, repo1, username)
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_GetCommitBySha(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -293,6 +948,125 @@ func TestAzureReposClient_GetCommitBySha(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	diffsResponse := []byte(`{"aheadCount":1,"behindCount":2,"allChangesIncluded":true,"changes":[` +
+		`{"changeType":"edit","item":{"path":"/go.mod"}},` +
+		`{"changeType":"add","item":{"path":"/docs","isFolder":true}},` +
+		`{"changeType":"add","item":{"path":"/docs/new.md"}}]}`)
+	commitsResponse := []byte(`{"count":1,"value":[{"commitId":"sha1","comment":"Add feature","parents":["sha0"],` +
+		`"author":{"name":"Example User","date":"2023-03-18T14:56:28Z"},"committer":{"name":"Administrator","date":"2023-03-18T14:56:28Z"}}]}`)
+	commitsHandler := createAzureReposHandler(t, "getLatestCommit", commitsResponse, http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "commitDiffs") {
+			_, err := w.Write(diffsResponse)
+			assert.NoError(t, err)
+			return
+		}
+		commitsHandler(w, r)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	result, err := client.CompareCommits(ctx, "", repo1, "main", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.AheadBy)
+	assert.Equal(t, 2, result.BehindBy)
+	require.Len(t, result.Commits, 1)
+	assert.Equal(t, "sha1", result.Commits[0].Hash)
+	assert.Equal(t, "Add feature", result.Commits[0].Message)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "/go.mod", Status: FileModified},
+		{Path: "/docs/new.md", Status: FileAdded},
+	}, result.Files)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.CompareCommits(ctx, "", repo1, "main", "feature")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_GetCommitsBetween(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"count":2,"value":[` +
+		`{"commitId":"sha3","comment":"Third","author":{"name":"Example User","date":"2023-03-18T14:56:28Z"},"committer":{"name":"Administrator","date":"2023-03-18T14:56:28Z"}},` +
+		`{"commitId":"sha2","comment":"Second","author":{"name":"Example User","date":"2023-03-18T14:56:28Z"},"committer":{"name":"Administrator","date":"2023-03-18T14:56:28Z"}}]}`)
+	commitsHandler := createAzureReposHandler(t, "getLatestCommit", response, http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "getLatestCommit") {
+			query := r.URL.Query()
+			assert.Equal(t, "sha3", query.Get("searchCriteria.itemVersion.version"))
+			assert.Equal(t, "commit", query.Get("searchCriteria.itemVersion.versionType"))
+			assert.Equal(t, "sha0", query.Get("searchCriteria.compareVersion.version"))
+			assert.Equal(t, "0", query.Get("searchCriteria.$skip"))
+		}
+		commitsHandler(w, r)
+	}))
+	defer server.Close()
+
+	commits, err := buildClient(t, vcsutils.AzureRepos, true, server).GetCommitsBetween(ctx, "", repo1, "sha0", "sha3")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "sha3", commits[0].Hash)
+	assert.Equal(t, "Third", commits[0].Message)
+	assert.Equal(t, "Example User", commits[0].AuthorName)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetCommitsBetween(ctx, "", repo1, "sha0", "sha3")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "commits.json"))
+	require.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response,
+		"getLatestCommit?searchCriteria.%24skip=10&searchCriteria.%24top=10&searchCriteria.author=frogger&searchCriteria.fromDate=2022-11-01T00%3A00%3A00Z&searchCriteria.itemPath=README.md",
+		createAzureReposHandler)
+	defer cleanUp()
+
+	options := ListCommitsOptions{Branch: branch1, Path: "README.md", Author: username, Since: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC), Page: 2, PerPage: 10}
+	result, err := client.ListCommits(ctx, "", repo1, options)
+	require.NoError(t, err)
+	require.NotEmpty(t, result)
+	assert.Equal(t, "86d6919952702f9ab03bc95b45687f145a663de0", result[0].Hash)
+	assert.Equal(t, "Updated package.json", result[0].Message)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListCommits(ctx, "", repo1, options)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_GetCommit(t *testing.T) {
+	ctx := context.Background()
+	commitResponse := []byte(`{"commitId":"sha1","comment":"Add feature","parents":["sha0"],` +
+		`"author":{"name":"Example User","date":"2023-03-18T14:56:28Z"},"committer":{"name":"Administrator","date":"2023-03-18T14:56:28Z"}}`)
+	changesResponse := []byte(`{"changes":[{"changeType":"edit","item":{"path":"/go.mod"}},` +
+		`{"changeType":"add","item":{"path":"/docs","isFolder":true}},{"changeType":"add","item":{"path":"/docs/new.md"}}]}`)
+	commitHandler := createAzureReposHandler(t, "getLatestCommit", commitResponse, http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "commitChanges") {
+			_, err := w.Write(changesResponse)
+			assert.NoError(t, err)
+			return
+		}
+		commitHandler(w, r)
+	}))
+	defer server.Close()
+
+	result, err := buildClient(t, vcsutils.AzureRepos, true, server).GetCommit(ctx, "", repo1, "sha1")
+	require.NoError(t, err)
+	assert.Equal(t, "sha1", result.Hash)
+	assert.Equal(t, "Example User", result.AuthorName)
+	assert.Equal(t, []string{"sha0"}, result.ParentHashes)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "/go.mod", Status: FileModified},
+		{Path: "/docs/new.md", Status: FileAdded},
+	}, result.Files)
+}
+
 func TestAzureReposClient_ListPullRequestLabels(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -301,6 +1075,32 @@ func TestAzureReposClient_ListPullRequestLabels(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_ListLabels(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.ListLabels(ctx, owner, repo1)
+	assert.Error(t, err)
+	err = client.UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: "new-name"})
+	assert.Error(t, err)
+	err = client.DeleteLabel(ctx, owner, repo1, labelName)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_Issues(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.CreateIssue(ctx, owner, repo1, "title", "body", nil)
+	assert.Error(t, err)
+	err = client.AddIssueComment(ctx, owner, repo1, "comment", 1)
+	assert.Error(t, err)
+	_, err = client.ListIssues(ctx, owner, repo1, IssueFilter{})
+	assert.Error(t, err)
+	err = client.UpdateIssueState(ctx, owner, repo1, 1, IssueClosed)
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_UnlabelPullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -317,6 +1117,28 @@ func TestAzureReposClient_UploadCodeScanning(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_Releases(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	err := client.CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0"})
+	assert.Error(t, err)
+	_, err = client.GetLatestRelease(ctx, owner, repo1)
+	assert.Error(t, err)
+	err = client.UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", "asset.zip")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_BranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.Error(t, err)
+	err = client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{RestrictPushes: true})
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_DownloadFileFromRepo(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -325,6 +1147,148 @@ func TestAzureReposClient_DownloadFileFromRepo(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_GetFileContent(t *testing.T) {
+	ctx := context.Background()
+	content, objectID := "Hello World!", "sha1"
+	response, err := json.Marshal(git.GitItem{Content: &content, ObjectId: &objectID})
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "getItem", createAzureReposHandler)
+	defer cleanUp()
+
+	fileContent, err := client.GetFileContent(ctx, "", repo1, branch1, "go.mod")
+	assert.NoError(t, err)
+	assert.Equal(t, FileContent{Path: "go.mod", Content: []byte(content), Size: int64(len(content)), Sha: objectID}, fileContent)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetFileContent(ctx, "", repo1, branch1, "go.mod")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ListRepositoryTree(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"count":4,"value":[
+		{"path":"/","isFolder":true,"gitObjectType":"tree"},
+		{"path":"/go.mod","gitObjectType":"blob"},
+		{"path":"/src","isFolder":true,"gitObjectType":"tree"},
+		{"path":"/src/main.go","gitObjectType":"blob"}
+	]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "getItem", createAzureReposHandler)
+	defer cleanUp()
+
+	tree, err := client.ListRepositoryTree(ctx, "", repo1, branch1, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []TreeEntry{
+		{Path: "go.mod", Type: TreeEntryFile},
+		{Path: "src", Type: TreeEntryDirectory},
+		{Path: "src/main.go", Type: TreeEntryFile},
+	}, tree)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListRepositoryTree(ctx, "", repo1, branch1, true)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.SearchCode(ctx, CodeSearchScope{Owner: owner}, "exec.Command")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_CreateOrUpdateFile(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	branchResponse, err := json.Marshal(git.GitBranchStats{Name: &branch1, Commit: &git.GitCommitRef{CommitId: &sha}})
+	assert.NoError(t, err)
+	pushResponse, err := json.Marshal(git.GitPush{})
+	assert.NoError(t, err)
+	pushHandler := createAzureReposHandler(t, "createPush", pushResponse, http.StatusCreated)
+	for _, fileExists := range []bool{true, false} {
+		expectedChangeType := "edit"
+		if !fileExists {
+			expectedChangeType = "add"
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.Contains(r.RequestURI, "getItem"):
+				if !fileExists {
+					w.WriteHeader(http.StatusNotFound)
+				}
+				_, err := w.Write([]byte(`{"path":"/go.mod"}`))
+				assert.NoError(t, err)
+			case strings.Contains(r.RequestURI, "listBranches"):
+				_, err := w.Write(branchResponse)
+				assert.NoError(t, err)
+			case strings.Contains(r.RequestURI, "createPush"):
+				var push git.GitPush
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&push))
+				assert.Equal(t, sha, *(*push.RefUpdates)[0].OldObjectId)
+				change := (*(*push.Commits)[0].Changes)[0].(map[string]interface{})
+				assert.Equal(t, expectedChangeType, change["changeType"])
+				assert.Equal(t, "SGVsbG8gV29ybGQh", change["newContent"].(map[string]interface{})["content"])
+				r.Body = io.NopCloser(strings.NewReader(""))
+				pushHandler(w, r)
+			default:
+				pushHandler(w, r)
+			}
+		}))
+		client := buildClient(t, vcsutils.AzureRepos, true, server)
+		err = client.CreateOrUpdateFile(ctx, "", repo1, branch1, "go.mod", []byte("Hello World!"), "Update go.mod")
+		assert.NoError(t, err)
+		server.Close()
+	}
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.CreateOrUpdateFile(ctx, "", repo1, branch1, "go.mod", []byte("Hello World!"), "Update go.mod")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	branchResponse, err := json.Marshal(git.GitBranchStats{Name: &branch1, Commit: &git.GitCommitRef{CommitId: &sha}})
+	assert.NoError(t, err)
+	pushResponse, err := json.Marshal(git.GitPush{})
+	assert.NoError(t, err)
+	pushHandler := createAzureReposHandler(t, "createPush", pushResponse, http.StatusCreated)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.RequestURI, "listBranches"):
+			_, err := w.Write(branchResponse)
+			assert.NoError(t, err)
+		case strings.Contains(r.RequestURI, "createPush"):
+			var push git.GitPush
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&push))
+			assert.Equal(t, "Update dependencies", *(*push.Commits)[0].Comment)
+			changes := *(*push.Commits)[0].Changes
+			require.Len(t, changes, 3)
+			for i, expectedChangeType := range []string{"add", "edit", "delete"} {
+				assert.Equal(t, expectedChangeType, changes[i].(map[string]interface{})["changeType"])
+			}
+			assert.Nil(t, changes[2].(map[string]interface{})["newContent"])
+			pushHandler(w, r)
+		default:
+			pushHandler(w, r)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	err = client.CommitFiles(ctx, "", repo1, branch1, "Update dependencies", []FileChange{
+		{Type: AddFile, Path: "go.sum", Content: []byte("Hello World!")},
+		{Type: UpdateFile, Path: "go.mod", Content: []byte("Hello World!")},
+		{Type: DeleteFile, Path: "old.txt"},
+	})
+	assert.NoError(t, err)
+
+	err = client.CommitFiles(ctx, "", repo1, branch1, "", []FileChange{{Type: DeleteFile, Path: "old.txt"}})
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -333,6 +1297,26 @@ func TestAzureReposClient_CreateWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_CreateWebhookWithOptions(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, _, err := client.CreateWebhookWithOptions(ctx, owner, repo1, WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}})
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_OrgWebhooks(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, _, err := client.CreateOrgWebhook(ctx, owner, WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}})
+	assert.Error(t, err)
+	_, err = client.ListOrgWebhooks(ctx, owner)
+	assert.Error(t, err)
+	err = client.DeleteOrgWebhook(ctx, owner, "1")
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_UpdateWebhook(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -349,6 +1333,22 @@ func TestAzureReposClient_DeleteWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.ListWebhooks(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_SetWebhookActive(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	err := client.SetWebhookActive(ctx, owner, repo1, "", false)
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_SetCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -357,6 +1357,45 @@ func TestAzureReposClient_SetCommitStatus(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_CommitStatuses(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	err := client.SetCommitStatuses(ctx, owner, repo1, "", []CommitStatusInfo{{State: Pass, Title: "build"}})
+	assert.Error(t, err)
+	_, err = client.ListCommitStatuses(ctx, owner, repo1, "")
+	assert.Error(t, err)
+	_, err = client.CreateCheckRun(ctx, owner, repo1, "", CheckRunInfo{Name: "build"})
+	assert.Error(t, err)
+	err = client.UpdateCheckRun(ctx, owner, repo1, "", 1, CheckRunInfo{Name: "build"})
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_Pipelines(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id":42,"state":"inProgress","_links":{"web":{"href":"https://dev.azure.com/jfrog/project/_build/results?buildId=42"}}}`)
+	server := httptest.NewServer(createAzureReposHandler(t, "pipelineRuns", response, http.StatusOK))
+	defer server.Close()
+	// The pipelines are of the project
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Username("frogger").Token(token).Project("project").Build()
+	require.NoError(t, err)
+
+	run, err := client.TriggerPipeline(ctx, "", repo1, "7", branch1, map[string]string{"target": "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, PipelineInfo{ID: "42", Ref: "refs/heads/" + branch1, State: InProgress, WebURL: "https://dev.azure.com/jfrog/project/_build/results?buildId=42"}, run)
+	_, err = client.TriggerPipeline(ctx, "", repo1, "build.yml", branch1, nil)
+	assert.Error(t, err)
+
+	response = []byte(`{"id":42,"status":"completed","result":"partiallySucceeded","sourceBranch":"refs/heads/branch-1"}`)
+	buildServer := httptest.NewServer(createAzureReposHandler(t, "getBuild", response, http.StatusOK))
+	defer buildServer.Close()
+	client, err = NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(buildServer.URL).Username("frogger").Token(token).Project("project").Build()
+	require.NoError(t, err)
+	run, err = client.GetPipelineStatus(ctx, "", repo1, "42")
+	require.NoError(t, err)
+	assert.Equal(t, PipelineInfo{ID: "42", Ref: "refs/heads/" + branch1, State: Pass}, run)
+}
+
 func TestAzureReposClient_GetLabel(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -413,7 +1452,7 @@ func createBadAzureReposClient(t *testing.T, response []byte) (VcsClient, func()
 		vcsutils.AzureRepos,
 		true,
 		response,
-		fmt.Sprintf("bad^endpoint/%s/_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&$format=zip",
+		fmt.Sprintf("bad^endpoint/%s/_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&versionDescriptor[versionType]=branch&$format=zip",
 			"",
 			repo1,
 			branch1),
@@ -5,8 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/mail"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,13 +20,15 @@ import (
 
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/ktrysmt/go-bitbucket"
+	"golang.org/x/oauth2"
 )
 
 // BitbucketCloudClient API version 2.0
 type BitbucketCloudClient struct {
-	vcsInfo VcsInfo
-	url     *url.URL
-	logger  Log
+	vcsInfo     VcsInfo
+	url         *url.URL
+	logger      Log
+	tokenSource oauth2.TokenSource
 }
 
 // NewBitbucketCloudClient create a new BitbucketCloudClient
@@ -29,6 +37,9 @@ func NewBitbucketCloudClient(vcsInfo VcsInfo, logger Log) (*BitbucketCloudClient
 		vcsInfo: vcsInfo,
 		logger:  logger,
 	}
+	if vcsInfo.TokenSource != nil {
+		bitbucketClient.tokenSource = oauth2.ReuseTokenSource(nil, vcsInfo.TokenSource)
+	}
 	if vcsInfo.APIEndpoint != "" {
 		url, err := url.Parse(vcsInfo.APIEndpoint)
 		if err != nil {
@@ -41,6 +52,11 @@ func NewBitbucketCloudClient(vcsInfo VcsInfo, logger Log) (*BitbucketCloudClient
 
 func (client *BitbucketCloudClient) buildBitbucketCloudClient(_ context.Context) *bitbucket.Client {
 	bitbucketClient := bitbucket.NewBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+	bitbucketClient.HttpClient = newHTTPClient(client.vcsInfo, client.logger)
+	if client.tokenSource != nil {
+		// The OAuth tokens replace the basic authentication, and are refreshed when they expire
+		bitbucketClient.HttpClient = newTokenSourceHTTPClient(client.vcsInfo, client.logger, client.tokenSource)
+	}
 	if client.url != nil {
 		bitbucketClient.SetApiBaseURL(*client.url)
 	}
@@ -54,6 +70,33 @@ func (client *BitbucketCloudClient) TestConnection(ctx context.Context) error {
 	return err
 }
 
+// GetRateLimitInfo on Bitbucket cloud. Bitbucket cloud reports the rate limits in the headers of every response.
+func (client *BitbucketCloudClient) GetRateLimitInfo(ctx context.Context) (RateLimitInfo, error) {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/user", nil)
+	if err != nil {
+		return RateLimitInfo{}, err
+	}
+	req.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	response, err := bitbucketClient.HttpClient.Do(req)
+	if err != nil {
+		return RateLimitInfo{}, err
+	}
+	defer func() {
+		_ = vcsutils.DiscardResponseBody(response)
+		_ = response.Body.Close()
+	}()
+	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK); err != nil {
+		return RateLimitInfo{}, err
+	}
+	return getRateLimitInfo(response.Header), nil
+}
+
 // ListRepositories on Bitbucket cloud
 func (client *BitbucketCloudClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
@@ -74,6 +117,124 @@ func (client *BitbucketCloudClient) ListRepositories(ctx context.Context) (map[s
 	return results, nil
 }
 
+// ListRepositoriesWithOptions on Bitbucket cloud. The owner is the workspace. Repositories can't be archived on Bitbucket cloud.
+func (client *BitbucketCloudClient) ListRepositoriesWithOptions(ctx context.Context, owner string, options ListRepositoriesOptions) ([]RepositoryDetails, error) {
+	if options.Visibility != nil && *options.Visibility == Internal {
+		return nil, errBitbucketInternalRepositoriesNotSupported
+	}
+	return listPageOrAll(ctx, client.listRepositoriesPager(owner, options), options.Page)
+}
+
+func (client *BitbucketCloudClient) listRepositoriesPager(owner string, options ListRepositoriesOptions) *Pager[RepositoryDetails] {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	query := url.Values{}
+	if filter := getBitbucketCloudRepositoriesQuery(options); filter != "" {
+		query.Set("q", filter)
+	}
+	if options.Page > 0 {
+		query.Set("page", strconv.Itoa(options.Page))
+	}
+	if options.PerPage > 0 {
+		query.Set("pagelen", strconv.Itoa(options.PerPage))
+	}
+	u := fmt.Sprintf("%s/repositories/%s", endpoint, owner)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return newPager(func(ctx context.Context) ([]RepositoryDetails, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner})
+		if err != nil {
+			return nil, false, err
+		}
+		var repos bitbucketCloudRepositoriesPage
+		if err = client.getJSON(ctx, u, &repos); err != nil {
+			return nil, false, err
+		}
+		var results []RepositoryDetails
+		for _, repo := range repos.Values {
+			details := mapBitbucketCloudRepositoryToRepositoryDetails(repo)
+			if options.matches(details) {
+				results = append(results, details)
+			}
+		}
+		u = repos.Next
+		return results, u != "", nil
+	})
+}
+
+// getBitbucketCloudRepositoriesQuery returns the Bitbucket query language filter of the visibility, language and update time options
+func getBitbucketCloudRepositoriesQuery(options ListRepositoriesOptions) string {
+	var conditions []string
+	if options.Visibility != nil {
+		conditions = append(conditions, fmt.Sprintf("is_private = %t", *options.Visibility == Private))
+	}
+	if options.Language != "" {
+		conditions = append(conditions, "language = "+strconv.Quote(strings.ToLower(options.Language)))
+	}
+	if !options.UpdatedSince.IsZero() {
+		conditions = append(conditions, "updated_on >= "+options.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+type bitbucketCloudRepositoriesPage struct {
+	Values []bitbucketCloudRepository `json:"values"`
+	Next   string                     `json:"next"`
+}
+
+type bitbucketCloudRepository struct {
+	Slug        string    `json:"slug"`
+	IsPrivate   bool      `json:"is_private"`
+	Description string    `json:"description"`
+	Language    string    `json:"language"`
+	Size        int64     `json:"size"`
+	UpdatedOn   time.Time `json:"updated_on"`
+	Mainbranch  struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+	Workspace struct {
+		Slug string `json:"slug"`
+	} `json:"workspace"`
+	Links struct {
+		Clone []struct {
+			Name string `json:"name"`
+			Href string `json:"href"`
+		} `json:"clone"`
+	} `json:"links"`
+}
+
+func mapBitbucketCloudRepositoryToRepositoryDetails(repo bitbucketCloudRepository) RepositoryDetails {
+	var info CloneInfo
+	for _, link := range repo.Links.Clone {
+		switch strings.ToLower(link.Name) {
+		case "https":
+			info.HTTP = link.Href
+		case "ssh":
+			info.SSH = link.Href
+		}
+	}
+	visibility := Public
+	if repo.IsPrivate {
+		visibility = Private
+	}
+	return RepositoryDetails{
+		Owner:      repo.Workspace.Slug,
+		Repository: repo.Slug,
+		RepositoryInfo: RepositoryInfo{
+			RepositoryVisibility: visibility,
+			CloneInfo:            info,
+			DefaultBranch:        repo.Mainbranch.Name,
+			Description:          repo.Description,
+			Size:                 repo.Size,
+		},
+		Language: repo.Language,
+		Updated:  repo.UpdatedOn,
+	}
+}
+
 // ListBranches on Bitbucket cloud
 func (client *BitbucketCloudClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
@@ -89,6 +250,380 @@ func (client *BitbucketCloudClient) ListBranches(ctx context.Context, owner, rep
 	return results, nil
 }
 
+// ListBranchesPager on Bitbucket cloud
+func (client *BitbucketCloudClient) ListBranchesPager(owner, repository string, perPage int) *Pager[string] {
+	options := &bitbucket.RepositoryBranchOptions{Owner: owner, RepoSlug: repository, Pagelen: perPage, PageNum: 1}
+	return newPager(func(ctx context.Context) ([]string, bool, error) {
+		bitbucketClient := client.buildBitbucketCloudClient(ctx)
+		branches, err := bitbucketClient.Repositories.Repository.ListBranches(options)
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]string, 0, len(branches.Branches))
+		for _, branch := range branches.Branches {
+			results = append(results, branch.Name)
+		}
+		options.PageNum++
+		return results, branches.Next != "", nil
+	})
+}
+
+// ListAllBranches on Bitbucket cloud
+func (client *BitbucketCloudClient) ListAllBranches(ctx context.Context, owner, repository, prefix string) ([]BranchDetails, error) {
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	options := &bitbucket.RepositoryBranchOptions{Owner: owner, RepoSlug: repository, Pagelen: 100}
+	if prefix != "" {
+		// The "~" operator matches anywhere in the branch name, so the prefix is verified below as well
+		options.Query = fmt.Sprintf("name ~ %q", prefix)
+	}
+	var results []BranchDetails
+	for options.PageNum = 1; ; options.PageNum++ {
+		branches, err := bitbucketClient.Repositories.Repository.ListBranches(options)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches.Branches {
+			if strings.HasPrefix(branch.Name, prefix) {
+				hash, _ := branch.Target["hash"].(string)
+				results = append(results, BranchDetails{Name: branch.Name, CommitHash: hash})
+			}
+		}
+		if branches.Next == "" {
+			break
+		}
+	}
+	return results, nil
+}
+
+// CreateBranch on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateBranch(ctx context.Context, owner, repository, branchName, fromRef string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":       owner,
+		"repository":  repository,
+		"branch name": branchName,
+		"from ref":    fromRef,
+	})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	client.logger.Debug("creating branch", branchName, "from", fromRef)
+	// The target hash accepts either a commit hash or a branch name
+	_, err = bitbucketClient.Repositories.Repository.CreateBranch(&bitbucket.RepositoryBranchCreationOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		Name:     branchName,
+		Target:   bitbucket.RepositoryBranchTarget{Hash: fromRef},
+	})
+	return err
+}
+
+// DeleteBranch on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteBranch(ctx context.Context, owner, repository, branchName string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch name": branchName})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	client.logger.Debug("deleting branch", branchName)
+	return bitbucketClient.Repositories.Repository.DeleteBranch(&bitbucket.RepositoryBranchDeleteOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		RefName:  branchName,
+	})
+}
+
+// ListTags on Bitbucket cloud
+func (client *BitbucketCloudClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	// The tags of the Bitbucket cloud library don't include the message, so the requests are sent directly
+	var results []TagInfo
+	for u := fmt.Sprintf("%s/repositories/%s/%s/refs/tags?pagelen=100", endpoint, owner, repository); u != ""; {
+		var tags bitbucketCloudTagsPage
+		if err = client.getJSON(ctx, u, &tags); err != nil {
+			return nil, err
+		}
+		for _, tag := range tags.Values {
+			results = append(results, TagInfo{Name: tag.Name, CommitHash: tag.Target.Hash, Message: tag.Message})
+		}
+		u = tags.Next
+	}
+	return results, nil
+}
+
+// CreateTag on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateTag(ctx context.Context, owner, repository, tagName, targetSha, message string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"tag name":   tagName,
+		"target sha": targetSha,
+	})
+	if err != nil {
+		return err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	client.logger.Debug("creating tag", tagName, "on", targetSha)
+	u := fmt.Sprintf("%s/repositories/%s/%s/refs/tags", endpoint, owner, repository)
+	return client.sendJSON(ctx, http.MethodPost, u, bitbucketCloudCreateTagRequest{
+		Name:    tagName,
+		Target:  bitbucketCloudCommitHash{Hash: targetSha},
+		Message: message,
+	})
+}
+
+type bitbucketCloudTagsPage struct {
+	Values []struct {
+		Name    string                   `json:"name"`
+		Message string                   `json:"message"`
+		Target  bitbucketCloudCommitHash `json:"target"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+type bitbucketCloudCreateTagRequest struct {
+	Name   string                   `json:"name"`
+	Target bitbucketCloudCommitHash `json:"target"`
+	// An annotated tag is created if the message isn't empty
+	Message string `json:"message,omitempty"`
+}
+
+type bitbucketCloudCommitHash struct {
+	Hash string `json:"hash"`
+}
+
+// CreateRelease on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateRelease(ctx context.Context, owner, repository string, release ReleaseInfo) error {
+	err := validateParametersNotBlank(map[string]string{"tag name": release.TagName, "target": release.Target})
+	if err != nil {
+		return err
+	}
+	return client.CreateTag(ctx, owner, repository, release.TagName, release.Target, getBitbucketReleaseTagMessage(release))
+}
+
+// GetLatestRelease on Bitbucket cloud
+func (client *BitbucketCloudClient) GetLatestRelease(ctx context.Context, owner, repository string) (*ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	var tags bitbucketCloudTagsPage
+	err = client.getJSON(ctx, fmt.Sprintf("%s/repositories/%s/%s/refs/tags?sort=-target.date&pagelen=1", endpoint, owner, repository), &tags)
+	if err != nil || len(tags.Values) == 0 {
+		return nil, err
+	}
+	return mapBitbucketTagToReleaseInfo(tags.Values[0].Name, tags.Values[0].Message), nil
+}
+
+// UploadReleaseAsset on Bitbucket cloud. Bitbucket cloud downloads aren't associated with tags, so the file is uploaded to the repository downloads.
+func (client *BitbucketCloudClient) UploadReleaseAsset(ctx context.Context, owner, repository, _, assetPath string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "asset path": assetPath})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	client.logger.Debug("uploading release asset", assetPath)
+	_, err = bitbucketClient.Repositories.Downloads.Create(&bitbucket.DownloadsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		FilePath: assetPath,
+		FileName: filepath.Base(assetPath),
+	})
+	return err
+}
+
+// GetBranchProtection on Bitbucket cloud
+func (client *BitbucketCloudClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return nil, err
+	}
+	restrictions, err := client.getBranchRestrictions(ctx, owner, repository, branch)
+	if err != nil || len(restrictions) == 0 {
+		return nil, err
+	}
+	branchProtection := &BranchProtection{}
+	for _, restriction := range restrictions {
+		switch restriction.Kind {
+		case bitbucketCloudPushRestriction:
+			branchProtection.RestrictPushes = true
+			for _, user := range restriction.Users {
+				branchProtection.AllowedPushUsers = append(branchProtection.AllowedPushUsers, user.UUID)
+			}
+		case bitbucketCloudApprovalsRestriction:
+			branchProtection.RequiredApprovingReviewCount = restriction.Value
+		}
+	}
+	return branchProtection, nil
+}
+
+// SetBranchProtection on Bitbucket cloud
+func (client *BitbucketCloudClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtection) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	if len(protection.RequiredStatusChecks) > 0 {
+		return errBitbucketCloudStatusChecksNotSupported
+	}
+	restrictions, err := client.getBranchRestrictions(ctx, owner, repository, branch)
+	if err != nil {
+		return err
+	}
+	restrictionsURL := client.getBranchRestrictionsURL(owner, repository)
+	for _, restriction := range restrictions {
+		if restriction.Kind != bitbucketCloudPushRestriction && restriction.Kind != bitbucketCloudApprovalsRestriction {
+			continue
+		}
+		client.logger.Debug("deleting restriction", restriction.ID, "of branch", branch)
+		if err = client.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", restrictionsURL, restriction.ID), nil); err != nil {
+			return err
+		}
+	}
+	var newRestrictions []bitbucketCloudCreateBranchRestrictionRequest
+	if protection.RestrictPushes {
+		users := []bitbucketCloudBranchRestrictionUser{}
+		for _, uuid := range protection.AllowedPushUsers {
+			users = append(users, bitbucketCloudBranchRestrictionUser{UUID: uuid})
+		}
+		newRestrictions = append(newRestrictions, bitbucketCloudCreateBranchRestrictionRequest{
+			Kind: bitbucketCloudPushRestriction, BranchMatchKind: "glob", Pattern: branch, Users: users})
+	}
+	if protection.RequiredApprovingReviewCount > 0 {
+		newRestrictions = append(newRestrictions, bitbucketCloudCreateBranchRestrictionRequest{
+			Kind: bitbucketCloudApprovalsRestriction, BranchMatchKind: "glob", Pattern: branch, Value: protection.RequiredApprovingReviewCount})
+	}
+	for _, restriction := range newRestrictions {
+		client.logger.Debug("creating restriction", restriction.Kind, "of branch", branch)
+		if err = client.sendJSON(ctx, http.MethodPost, restrictionsURL, restriction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRequiredChecks on Bitbucket cloud. Bitbucket Cloud requires a number of successful builds, rather than named commit statuses.
+func (client *BitbucketCloudClient) GetRequiredChecks(ctx context.Context, owner, repository, branch string) (RequiredChecksInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return RequiredChecksInfo{}, err
+	}
+	restrictions, err := client.getBranchRestrictions(ctx, owner, repository, branch)
+	if err != nil {
+		return RequiredChecksInfo{}, err
+	}
+	for _, restriction := range restrictions {
+		if restriction.Kind == bitbucketCloudPassingBuildsRestriction {
+			return RequiredChecksInfo{AllMustPass: true}, nil
+		}
+	}
+	return RequiredChecksInfo{}, nil
+}
+
+// GetRequiredApprovals on Bitbucket cloud
+func (client *BitbucketCloudClient) GetRequiredApprovals(ctx context.Context, owner, repository, branch string) (RequiredApprovalsInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return RequiredApprovalsInfo{}, err
+	}
+	restrictions, err := client.getBranchRestrictions(ctx, owner, repository, branch)
+	if err != nil {
+		return RequiredApprovalsInfo{}, err
+	}
+	requiredApprovals := RequiredApprovalsInfo{}
+	for _, restriction := range restrictions {
+		switch restriction.Kind {
+		case bitbucketCloudApprovalsRestriction, bitbucketCloudDefaultReviewerApprovalsRestriction:
+			if restriction.Value > requiredApprovals.Count {
+				requiredApprovals.Count = restriction.Value
+			}
+		case bitbucketCloudResetApprovalsRestriction:
+			requiredApprovals.DismissStaleApprovals = true
+		}
+	}
+	if requiredApprovals.Count == 0 {
+		return RequiredApprovalsInfo{}, nil
+	}
+	return requiredApprovals, nil
+}
+
+// GetTagProtection on Bitbucket cloud
+func (client *BitbucketCloudClient) GetTagProtection(ctx context.Context, owner, repository, pattern string) (*TagProtection, error) {
+	return nil, errBitbucketCloudTagProtectionNotSupported
+}
+
+// SetTagProtection on Bitbucket cloud
+func (client *BitbucketCloudClient) SetTagProtection(ctx context.Context, owner, repository, pattern string, protection TagProtection) error {
+	return errBitbucketCloudTagProtectionNotSupported
+}
+
+func (client *BitbucketCloudClient) getBranchRestrictions(ctx context.Context, owner, repository, branch string) ([]bitbucketCloudBranchRestriction, error) {
+	var results []bitbucketCloudBranchRestriction
+	for u := fmt.Sprintf("%s?pattern=%s", client.getBranchRestrictionsURL(owner, repository), url.QueryEscape(branch)); u != ""; {
+		var restrictions bitbucketCloudBranchRestrictionsPage
+		if err := client.getJSON(ctx, u, &restrictions); err != nil {
+			return nil, err
+		}
+		results = append(results, restrictions.Values...)
+		u = restrictions.Next
+	}
+	return results, nil
+}
+
+func (client *BitbucketCloudClient) getBranchRestrictionsURL(owner, repository string) string {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return fmt.Sprintf("%s/repositories/%s/%s/branch-restrictions", endpoint, owner, repository)
+}
+
+const (
+	bitbucketCloudPushRestriction                     = "push"
+	bitbucketCloudApprovalsRestriction                = "require_approvals_to_merge"
+	bitbucketCloudDefaultReviewerApprovalsRestriction = "require_default_reviewer_approvals_to_merge"
+	bitbucketCloudResetApprovalsRestriction           = "reset_pullrequest_approvals_on_change"
+	bitbucketCloudPassingBuildsRestriction            = "require_passing_builds_to_merge"
+)
+
+type bitbucketCloudBranchRestrictionsPage struct {
+	Values []bitbucketCloudBranchRestriction `json:"values"`
+	Next   string                            `json:"next"`
+}
+
+type bitbucketCloudBranchRestriction struct {
+	ID    int                                   `json:"id"`
+	Kind  string                                `json:"kind"`
+	Value int                                   `json:"value"`
+	Users []bitbucketCloudBranchRestrictionUser `json:"users"`
+}
+
+type bitbucketCloudCreateBranchRestrictionRequest struct {
+	Kind            string                                `json:"kind"`
+	BranchMatchKind string                                `json:"branch_match_kind"`
+	Pattern         string                                `json:"pattern"`
+	Value           int                                   `json:"value,omitempty"`
+	Users           []bitbucketCloudBranchRestrictionUser `json:"users,omitempty"`
+}
+
+type bitbucketCloudBranchRestrictionUser struct {
+	UUID string `json:"uuid"`
+}
+
 // AddSshKeyToRepository on Bitbucket cloud, the deploy-key is always read-only.
 func (client *BitbucketCloudClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, _ Permission) error {
 	err := validateParametersNotBlank(map[string]string{
@@ -138,32 +673,141 @@ func (client *BitbucketCloudClient) AddSshKeyToRepository(ctx context.Context, o
 	return nil
 }
 
-type bitbucketCloudAddSSHKeyRequest struct {
-	Key   string `json:"key"`
-	Label string `json:"label"`
+// ListDeployKeys on Bitbucket cloud
+func (client *BitbucketCloudClient) ListDeployKeys(ctx context.Context, owner, repository string) ([]DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	var results []DeployKeyInfo
+	for u := client.getDeployKeysURL(owner, repository); u != ""; {
+		var keys bitbucketCloudDeployKeysPage
+		if err = client.getJSON(ctx, u, &keys); err != nil {
+			return nil, err
+		}
+		for _, key := range keys.Values {
+			results = append(results, mapBitbucketCloudDeployKeyToDeployKeyInfo(key))
+		}
+		u = keys.Next
+	}
+	return results, nil
 }
 
-// CreateWebhook on Bitbucket cloud
-func (client *BitbucketCloudClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
-	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
-	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	token := vcsutils.CreateToken()
-	options := &bitbucket.WebhooksOptions{
-		Active:   true,
-		Owner:    owner,
-		RepoSlug: repository,
-		Url:      payloadURL + "?token=" + url.QueryEscape(token),
-		Events:   getBitbucketCloudWebhookEvents(webhookEvents...),
-	}
-	response, err := bitbucketClient.Repositories.Webhooks.Create(options)
+// AddDeployKey on Bitbucket cloud
+func (client *BitbucketCloudClient) AddDeployKey(ctx context.Context, owner, repository, title, key string, readOnly bool) (DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title, "key": key})
 	if err != nil {
-		return "", "", err
+		return DeployKeyInfo{}, err
 	}
-	id, err := getBitbucketCloudWebhookID(response)
+	if !readOnly {
+		return DeployKeyInfo{}, errBitbucketCloudWriteDeployKeysNotSupported
+	}
+	var deployKey bitbucketCloudDeployKey
+	err = client.sendJSONWithResult(ctx, http.MethodPost, client.getDeployKeysURL(owner, repository),
+		bitbucketCloudAddSSHKeyRequest{Label: title, Key: key}, &deployKey)
 	if err != nil {
-		return "", "", err
+		return DeployKeyInfo{}, err
 	}
-	return id, token, err
+	return mapBitbucketCloudDeployKeyToDeployKeyInfo(deployKey), nil
+}
+
+// DeleteDeployKey on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteDeployKey(ctx context.Context, owner, repository string, keyID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	return client.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", client.getDeployKeysURL(owner, repository), keyID), nil)
+}
+
+func (client *BitbucketCloudClient) getDeployKeysURL(owner, repository string) string {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return fmt.Sprintf("%s/repositories/%s/%s/deploy-keys", endpoint, owner, repository)
+}
+
+type bitbucketCloudDeployKeysPage struct {
+	Values []bitbucketCloudDeployKey `json:"values"`
+	Next   string                    `json:"next"`
+}
+
+type bitbucketCloudDeployKey struct {
+	ID    int    `json:"id"`
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+// Deploy keys on Bitbucket cloud are read-only
+func mapBitbucketCloudDeployKeyToDeployKeyInfo(key bitbucketCloudDeployKey) DeployKeyInfo {
+	return DeployKeyInfo{ID: key.ID, Title: key.Label, Key: key.Key, ReadOnly: true}
+}
+
+type bitbucketCloudAddSSHKeyRequest struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+// CreateWebhook on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
+	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	token := vcsutils.CreateToken()
+	options := &bitbucket.WebhooksOptions{
+		Active:   true,
+		Owner:    owner,
+		RepoSlug: repository,
+		Url:      payloadURL + "?token=" + url.QueryEscape(token),
+		Events:   getBitbucketCloudWebhookEvents(webhookEvents...),
+	}
+	response, err := bitbucketClient.Repositories.Webhooks.Create(options)
+	if err != nil {
+		return "", "", err
+	}
+	id, err := getBitbucketCloudWebhookID(response)
+	if err != nil {
+		return "", "", err
+	}
+	return id, token, err
+}
+
+// CreateWebhookWithOptions on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateWebhookWithOptions(ctx context.Context, owner, repository string,
+	options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	if options.contentType() != WebhookContentTypeJSON {
+		return WebhookInfo{}, "", errBitbucketWebhookFormContentTypeNotSupported
+	}
+	return client.createWebhookWithOptions(ctx, client.getWebhooksURL(owner, repository), options)
+}
+
+// CreateOrgWebhook on Bitbucket cloud creates a webhook on the workspace
+func (client *BitbucketCloudClient) CreateOrgWebhook(ctx context.Context, owner string, options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	if options.contentType() != WebhookContentTypeJSON {
+		return WebhookInfo{}, "", errBitbucketWebhookFormContentTypeNotSupported
+	}
+	return client.createWebhookWithOptions(ctx, client.getWorkspaceWebhooksURL(owner), options)
+}
+
+func (client *BitbucketCloudClient) createWebhookWithOptions(ctx context.Context, webhooksURL string, options WebhookOptions) (WebhookInfo, string, error) {
+	token := vcsutils.CreateToken()
+	request := bitbucketCloudWebhook{
+		URL:                  options.PayloadURL + "?token=" + url.QueryEscape(token),
+		Events:               getBitbucketCloudWebhookEvents(options.Events...),
+		Active:               true,
+		SkipCertVerification: options.InsecureSSL,
+	}
+	var hook bitbucketCloudWebhook
+	if err := client.sendJSONWithResult(ctx, http.MethodPost, webhooksURL, request, &hook); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	return mapBitbucketCloudWebhookToWebhookInfo(hook), token, nil
 }
 
 // UpdateWebhook on Bitbucket cloud
@@ -194,6 +838,110 @@ func (client *BitbucketCloudClient) DeleteWebhook(ctx context.Context, owner, re
 	return err
 }
 
+// ListWebhooks on Bitbucket cloud
+func (client *BitbucketCloudClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	return client.listWebhooks(ctx, client.getWebhooksURL(owner, repository))
+}
+
+// ListOrgWebhooks on Bitbucket cloud lists the webhooks of the workspace
+func (client *BitbucketCloudClient) ListOrgWebhooks(ctx context.Context, owner string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner})
+	if err != nil {
+		return nil, err
+	}
+	return client.listWebhooks(ctx, client.getWorkspaceWebhooksURL(owner))
+}
+
+// DeleteOrgWebhook on Bitbucket cloud deletes a webhook of the workspace
+func (client *BitbucketCloudClient) DeleteOrgWebhook(ctx context.Context, owner, webhookID string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "webhookID": webhookID})
+	if err != nil {
+		return err
+	}
+	return client.sendJSON(ctx, http.MethodDelete, client.getWorkspaceWebhooksURL(owner)+"/"+webhookID, nil)
+}
+
+func (client *BitbucketCloudClient) listWebhooks(ctx context.Context, webhooksURL string) ([]WebhookInfo, error) {
+	var webhooks []WebhookInfo
+	for u := webhooksURL; u != ""; {
+		var hooks bitbucketCloudWebhooksPage
+		if err := client.getJSON(ctx, u, &hooks); err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks.Values {
+			webhooks = append(webhooks, mapBitbucketCloudWebhookToWebhookInfo(hook))
+		}
+		u = hooks.Next
+	}
+	return webhooks, nil
+}
+
+// SetWebhookActive on Bitbucket cloud
+func (client *BitbucketCloudClient) SetWebhookActive(ctx context.Context, owner, repository, webhookID string, active bool) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "webhookID": webhookID})
+	if err != nil {
+		return err
+	}
+	return client.sendJSON(ctx, http.MethodPut, client.getWebhooksURL(owner, repository)+"/"+webhookID,
+		bitbucketCloudWebhookActiveRequest{Active: active})
+}
+
+func (client *BitbucketCloudClient) getWebhooksURL(owner, repository string) string {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return fmt.Sprintf("%s/repositories/%s/%s/hooks", endpoint, owner, repository)
+}
+
+func (client *BitbucketCloudClient) getWorkspaceWebhooksURL(owner string) string {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return fmt.Sprintf("%s/workspaces/%s/hooks", endpoint, owner)
+}
+
+type bitbucketCloudWebhooksPage struct {
+	Values []bitbucketCloudWebhook `json:"values"`
+	Next   string                  `json:"next"`
+}
+
+type bitbucketCloudWebhook struct {
+	UUID                 string   `json:"uuid,omitempty"`
+	URL                  string   `json:"url"`
+	Events               []string `json:"events"`
+	Active               bool     `json:"active"`
+	SkipCertVerification bool     `json:"skip_cert_verification"`
+}
+
+type bitbucketCloudWebhookActiveRequest struct {
+	Active bool `json:"active"`
+}
+
+// The webhook token is sent as a query parameter of the payload URL, so it is removed from the URL
+func mapBitbucketCloudWebhookToWebhookInfo(hook bitbucketCloudWebhook) WebhookInfo {
+	payloadURL := hook.URL
+	if parsedURL, err := url.Parse(hook.URL); err == nil {
+		query := parsedURL.Query()
+		query.Del("token")
+		parsedURL.RawQuery = query.Encode()
+		payloadURL = parsedURL.String()
+	}
+	return WebhookInfo{
+		ID:          strings.TrimRight(strings.TrimLeft(hook.UUID, "{"), "}"),
+		PayloadURL:  payloadURL,
+		Events:      mapProviderWebhookEvents(hook.Events, getBitbucketCloudWebhookEvents),
+		Active:      hook.Active,
+		ContentType: WebhookContentTypeJSON,
+		InsecureSSL: hook.SkipCertVerification,
+	}
+}
+
 // SetCommitStatus on Bitbucket cloud
 func (client *BitbucketCloudClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository,
 	ref, title, description, detailsURL string) error {
@@ -213,9 +961,187 @@ func (client *BitbucketCloudClient) SetCommitStatus(ctx context.Context, commitS
 	return err
 }
 
+// SetCommitStatuses on Bitbucket cloud
+func (client *BitbucketCloudClient) SetCommitStatuses(ctx context.Context, owner, repository, ref string, statuses []CommitStatusInfo) error {
+	return setCommitStatusesOneByOne(ctx, client, owner, repository, ref, statuses)
+}
+
+// CreateCheckRun on Bitbucket cloud. Bitbucket cloud doesn't support check runs, so a commit status is set instead.
+func (client *BitbucketCloudClient) CreateCheckRun(ctx context.Context, owner, repository, ref string, checkRun CheckRunInfo) (int64, error) {
+	return 0, setCheckRunCommitStatus(ctx, client, owner, repository, ref, checkRun)
+}
+
+// UpdateCheckRun on Bitbucket cloud. Bitbucket cloud doesn't support check runs, so the commit status is set instead.
+func (client *BitbucketCloudClient) UpdateCheckRun(ctx context.Context, owner, repository, ref string, _ int64, checkRun CheckRunInfo) error {
+	return setCheckRunCommitStatus(ctx, client, owner, repository, ref, checkRun)
+}
+
+// TriggerPipeline on Bitbucket cloud. If the pipeline is empty, the pipeline of the branch is run, and otherwise the custom pipeline with this name.
+func (client *BitbucketCloudClient) TriggerPipeline(ctx context.Context, owner, repository, pipeline, ref string, inputs map[string]string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	request := bitbucketCloudPipeline{Target: bitbucketCloudPipelineTarget{Type: "pipeline_ref_target", RefType: "branch", RefName: ref}}
+	if pipeline != "" {
+		request.Target.Selector = &bitbucketCloudPipelineSelector{Type: "custom", Pattern: pipeline}
+	}
+	for _, name := range getSortedKeys(inputs) {
+		request.Variables = append(request.Variables, bitbucketCloudPipelineVariable{Key: name, Value: inputs[name]})
+	}
+	var run bitbucketCloudPipeline
+	if err = client.sendJSONWithResult(ctx, http.MethodPost, client.getPipelinesURL(owner, repository)+"/", request, &run); err != nil {
+		return PipelineInfo{}, err
+	}
+	return client.mapBitbucketCloudPipelineToPipelineInfo(owner, repository, run), nil
+}
+
+// GetPipelineStatus on Bitbucket cloud. The run ID is the UUID of the pipeline.
+func (client *BitbucketCloudClient) GetPipelineStatus(ctx context.Context, owner, repository, runID string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "run ID": runID})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	var run bitbucketCloudPipeline
+	if err = client.getJSON(ctx, client.getPipelinesURL(owner, repository)+"/"+url.PathEscape(runID), &run); err != nil {
+		return PipelineInfo{}, err
+	}
+	return client.mapBitbucketCloudPipelineToPipelineInfo(owner, repository, run), nil
+}
+
+func (client *BitbucketCloudClient) getPipelinesURL(owner, repository string) string {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return fmt.Sprintf("%s/repositories/%s/%s/pipelines", endpoint, owner, repository)
+}
+
+func (client *BitbucketCloudClient) mapBitbucketCloudPipelineToPipelineInfo(owner, repository string, run bitbucketCloudPipeline) PipelineInfo {
+	return PipelineInfo{
+		ID:     run.UUID,
+		Ref:    run.Target.RefName,
+		State:  getBitbucketCloudPipelineStatus(run.State.Name, run.State.Result.Name),
+		WebURL: fmt.Sprintf("https://bitbucket.org/%s/%s/pipelines/results/%d", owner, repository, run.BuildNumber),
+	}
+}
+
+// getBitbucketCloudPipelineStatus returns the status of a pipeline, which has a result only once it's completed
+func getBitbucketCloudPipelineStatus(state, result string) CommitStatus {
+	if state != "COMPLETED" {
+		return InProgress
+	}
+	switch result {
+	case "SUCCESSFUL":
+		return Pass
+	case "FAILED", "STOPPED", "EXPIRED":
+		return Fail
+	}
+	return Error
+}
+
+type bitbucketCloudPipeline struct {
+	UUID        string                           `json:"uuid,omitempty"`
+	BuildNumber int                              `json:"build_number,omitempty"`
+	Target      bitbucketCloudPipelineTarget     `json:"target"`
+	Variables   []bitbucketCloudPipelineVariable `json:"variables,omitempty"`
+	State       struct {
+		Name   string `json:"name"`
+		Result struct {
+			Name string `json:"name"`
+		} `json:"result"`
+	} `json:"state"`
+}
+
+type bitbucketCloudPipelineTarget struct {
+	Type     string                          `json:"type"`
+	RefType  string                          `json:"ref_type"`
+	RefName  string                          `json:"ref_name"`
+	Selector *bitbucketCloudPipelineSelector `json:"selector,omitempty"`
+}
+
+type bitbucketCloudPipelineSelector struct {
+	Type    string `json:"type"`
+	Pattern string `json:"pattern"`
+}
+
+type bitbucketCloudPipelineVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ListCommitStatuses on Bitbucket cloud
+func (client *BitbucketCloudClient) ListCommitStatuses(ctx context.Context, owner, repository, ref string) ([]CommitStatusInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	// The Bitbucket Cloud library doesn't follow the pagination of the commit statuses, so the requests are sent directly
+	var results []CommitStatusInfo
+	for u := fmt.Sprintf("%s/repositories/%s/%s/commit/%s/statuses", endpoint, owner, repository, ref); u != ""; {
+		var statuses bitbucketCloudCommitStatusesPage
+		if err = client.getJSON(ctx, u, &statuses); err != nil {
+			return nil, err
+		}
+		for _, status := range statuses.Values {
+			results = append(results, CommitStatusInfo{
+				State:         getBitbucketCommitStatus(status.State),
+				Title:         status.Key,
+				Description:   status.Description,
+				DetailsURL:    status.URL,
+				CreatedAt:     status.CreatedOn,
+				LastUpdatedAt: status.UpdatedOn,
+			})
+		}
+		u = statuses.Next
+	}
+	return results, nil
+}
+
+type bitbucketCloudCommitStatusesPage struct {
+	Values []struct {
+		State       string    `json:"state"`
+		Key         string    `json:"key"`
+		Description string    `json:"description"`
+		URL         string    `json:"url"`
+		CreatedOn   time.Time `json:"created_on"`
+		UpdatedOn   time.Time `json:"updated_on"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
 // DownloadRepository on Bitbucket cloud
 func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owner, repository, branch,
 	localPath string) error {
+	return client.DownloadRepositoryAtRef(ctx, owner, repository, branch, localPath, TarGz)
+}
+
+// DownloadRepositoryAtRef on Bitbucket cloud
+func (client *BitbucketCloudClient) DownloadRepositoryAtRef(ctx context.Context, owner, repository, ref,
+	localPath string, format ArchiveFormat) error {
+	archive, err := client.GetRepositoryArchive(ctx, owner, repository, ref, format)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = archive.Close() }()
+	client.logger.Info(repository, "downloaded successfully, starting with repository extraction")
+	err = extractRepositoryArchive(archive, format, localPath, true, "")
+	if err != nil {
+		return err
+	}
+	client.logger.Info("extracted repository successfully")
+	// Generate .git folder with remote details
+	return vcsutils.CreateDotGitFolderWithRemote(localPath, "origin",
+		fmt.Sprintf("https://bitbucket.org/%s/%s.git", owner, repository))
+}
+
+// GetRepositoryArchive on Bitbucket cloud
+func (client *BitbucketCloudClient) GetRepositoryArchive(ctx context.Context, owner, repository, ref string,
+	format ArchiveFormat) (io.ReadCloser, error) {
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	client.logger.Debug("getting Bitbucket Cloud archive link to download")
 	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
@@ -223,38 +1149,116 @@ func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owne
 		RepoSlug: repository,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	downloadLink, err := getDownloadLink(repo, branch)
+	downloadLink, err := getDownloadLink(repo, ref, format)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	client.logger.Debug("received archive url:", downloadLink)
 	getRequest, err := http.NewRequestWithContext(ctx, "GET", downloadLink, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(client.vcsInfo.Username) > 0 || len(client.vcsInfo.Token) > 0 {
 		getRequest.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
 	}
 
 	response, err := bitbucketClient.HttpClient.Do(getRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK); err != nil {
+		_ = response.Body.Close()
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+// DownloadRepositoryPath on Bitbucket cloud
+func (client *BitbucketCloudClient) DownloadRepositoryPath(ctx context.Context, owner, repository, ref, path, localPath string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref, "path": path})
+	if err != nil {
+		return err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	// Bitbucket Cloud has no archive of a single directory, so the files of the directory are downloaded one by one
+	srcURL := fmt.Sprintf("%s/repositories/%s/%s/src/%s", endpoint, owner, repository, url.PathEscape(ref))
+	client.logger.Info("downloading", path, "from", repository)
+	return client.downloadSrcDirectory(ctx, srcURL, strings.Trim(path, "/"), localPath)
+}
+
+// downloadSrcDirectory downloads the files of a directory and its subdirectories from the src API
+func (client *BitbucketCloudClient) downloadSrcDirectory(ctx context.Context, srcURL, dirPath, localPath string) error {
+	for u := fmt.Sprintf("%s/%s/?pagelen=100", srcURL, (&url.URL{Path: dirPath}).EscapedPath()); u != ""; {
+		var entries bitbucketCloudSrcPage
+		if err := client.getJSON(ctx, u, &entries); err != nil {
+			return err
+		}
+		for _, entry := range entries.Values {
+			var err error
+			switch entry.Type {
+			case "commit_directory":
+				err = client.downloadSrcDirectory(ctx, srcURL, entry.Path, localPath)
+			case "commit_file":
+				err = client.downloadSrcFile(ctx, srcURL+"/"+(&url.URL{Path: entry.Path}).EscapedPath(),
+					filepath.Join(localPath, filepath.FromSlash(entry.Path)))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		u = entries.Next
+	}
+	return nil
+}
+
+func (client *BitbucketCloudClient) downloadSrcFile(ctx context.Context, u, localFilePath string) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	response, err := bitbucketClient.HttpClient.Do(req)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
 	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK); err != nil {
 		return err
 	}
-	client.logger.Info(repository, "downloaded successfully, starting with repository extraction")
-	err = vcsutils.Untar(localPath, response.Body, true)
+	if err = os.MkdirAll(filepath.Dir(localFilePath), 0750); err != nil {
+		return err
+	}
+	file, err := os.Create(filepath.Clean(localFilePath))
 	if err != nil {
 		return err
 	}
-	client.logger.Info("extracted repository successfully")
-	// Generate .git folder with remote details
-	return vcsutils.CreateDotGitFolderWithRemote(localPath, "origin",
-		fmt.Sprintf("https://bitbucket.org/%s/%s.git", owner, repository))
+	defer func() {
+		e := file.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	_, err = io.Copy(file, response.Body)
+	return err
+}
+
+type bitbucketCloudSrcPage struct {
+	Values []struct {
+		Path string `json:"path"`
+		// commit_file, commit_directory, or commit_link for submodules
+		Type string `json:"type"`
+	} `json:"values"`
+	Next string `json:"next"`
 }
 
 // CreatePullRequest on Bitbucket cloud
@@ -275,22 +1279,201 @@ func (client *BitbucketCloudClient) CreatePullRequest(ctx context.Context, owner
 	return err
 }
 
-// CreatePullRequest on Bitbucket cloud
-func (client *BitbucketCloudClient) ListOpenPullRequests(ctx context.Context, owner, repository string) (res []PullRequestInfo, err error) {
-	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// CreateDraftPullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateDraftPullRequest(ctx context.Context, owner, repository, sourceBranch,
+	targetBranch, title, description string) error {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	// Draft pull requests aren't supported by the Bitbucket Cloud library, so the request is sent directly
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests", endpoint, owner, repository)
+	client.logger.Debug("creating new draft pull request:", title)
+	return client.sendJSON(ctx, http.MethodPost, u, bitbucketCloudCreatePullRequest{
+		Title:       title,
+		Description: description,
+		Source:      bitbucketCloudPullRequestRef{Branch: bitbucketCloudBranchName{Name: sourceBranch}},
+		Destination: bitbucketCloudPullRequestRef{Branch: bitbucketCloudBranchName{Name: targetBranch}},
+		Draft:       true,
+	})
+}
+
+// MarkPullRequestReady on Bitbucket cloud
+func (client *BitbucketCloudClient) MarkPullRequestReady(ctx context.Context, owner, repository string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	client.logger.Debug("fetching open pull requests in", repository)
-	options := &bitbucket.PullRequestsOptions{
-		Owner:    owner,
-		RepoSlug: repository,
-		States:   []string{"OPEN"},
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
 	}
-	pullRequests, err := bitbucketClient.Repositories.PullRequests.Gets(options)
+	draft := false
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d", endpoint, owner, repository, pullRequestID)
+	client.logger.Debug("marking pull request as ready:", pullRequestID)
+	return client.sendJSON(ctx, http.MethodPut, u, bitbucketCloudUpdatePullRequest{Draft: &draft})
+}
+
+// MergePullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int,
+	mergeStrategy MergeStrategy, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return
+		return err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	// The merge strategy isn't supported by the Bitbucket Cloud library, so the request is sent directly
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/merge", endpoint, owner, repository, pullRequestID)
+	mergeRequest := bitbucketCloudMergeRequest{
+		Message:       commitMessage,
+		MergeStrategy: getBitbucketCloudMergeStrategy(mergeStrategy),
+	}
+	client.logger.Debug("merging pull request:", pullRequestID)
+	return client.sendJSON(ctx, http.MethodPost, u, mergeRequest)
+}
+
+// EnablePullRequestAutoMerge on Bitbucket cloud
+func (client *BitbucketCloudClient) EnablePullRequestAutoMerge(_ context.Context, _, _ string, _ int, _ MergeStrategy) error {
+	return errBitbucketCloudAutoMergeNotSupported
+}
+
+// DisablePullRequestAutoMerge on Bitbucket cloud
+func (client *BitbucketCloudClient) DisablePullRequestAutoMerge(_ context.Context, _, _ string, _ int) error {
+	return errBitbucketCloudAutoMergeNotSupported
+}
+
+type bitbucketCloudMergeRequest struct {
+	Message       string `json:"message,omitempty"`
+	MergeStrategy string `json:"merge_strategy"`
+}
+
+// UpdatePullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranch string,
+	pullRequestID int, state *PullRequestState) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validatePullRequestState(state); err != nil {
+		return err
+	}
+	var pullRequest PullRequestInfo
+	if state != nil {
+		if pullRequest, err = client.GetPullRequestByID(ctx, owner, repository, pullRequestID); err != nil {
+			return err
+		}
+		if *state == PullRequestOpen && pullRequest.State != PullRequestOpen {
+			return errBitbucketCloudReopenPullRequestNotSupported
+		}
+	}
+	if title != "" || body != "" || targetBranch != "" {
+		endpoint := client.vcsInfo.APIEndpoint
+		if endpoint == "" {
+			endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+		}
+		// The library's update replaces all the pull request fields, so only the requested fields are sent directly
+		u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d", endpoint, owner, repository, pullRequestID)
+		updateRequest := bitbucketCloudUpdatePullRequest{Title: title, Description: body}
+		if targetBranch != "" {
+			updateRequest.Destination = &bitbucketCloudPullRequestRef{Branch: bitbucketCloudBranchName{Name: targetBranch}}
+		}
+		client.logger.Debug("updating pull request:", pullRequestID)
+		if err = client.sendJSON(ctx, http.MethodPut, u, updateRequest); err != nil {
+			return err
+		}
+	}
+	if state != nil && *state == PullRequestClosed && pullRequest.State == PullRequestOpen {
+		client.logger.Debug("declining pull request:", pullRequestID)
+		_, err = client.buildBitbucketCloudClient(ctx).Repositories.PullRequests.Decline(&bitbucket.PullRequestsOptions{
+			Owner:    owner,
+			RepoSlug: repository,
+			ID:       fmt.Sprint(pullRequestID),
+		})
+	}
+	return err
+}
+
+// ApprovePullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) ApprovePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	return client.SubmitPullRequestReview(ctx, owner, repository, pullRequestID, ReviewApprove, "")
+}
+
+// SubmitPullRequestReview on Bitbucket cloud
+func (client *BitbucketCloudClient) SubmitPullRequestReview(ctx context.Context, owner, repository string, pullRequestID int,
+	reviewEvent ReviewEvent, body string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewBody(reviewEvent, body); err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	options := &bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		ID:       fmt.Sprint(pullRequestID),
+	}
+	switch reviewEvent {
+	case ReviewApprove:
+		client.logger.Debug("approving pull request:", pullRequestID)
+		_, err = bitbucketClient.Repositories.PullRequests.Approve(options)
+	case ReviewRequestChanges:
+		client.logger.Debug("requesting changes on pull request:", pullRequestID)
+		_, err = bitbucketClient.Repositories.PullRequests.RequestChanges(options)
+	}
+	if err != nil {
+		return err
+	}
+	// Bitbucket approvals have no body, so the review body is added as a comment
+	if body != "" {
+		return client.AddPullRequestComment(ctx, owner, repository, body, pullRequestID)
+	}
+	return nil
+}
+
+type bitbucketCloudUpdatePullRequest struct {
+	Title       string                        `json:"title,omitempty"`
+	Description string                        `json:"description,omitempty"`
+	Destination *bitbucketCloudPullRequestRef `json:"destination,omitempty"`
+	Draft       *bool                         `json:"draft,omitempty"`
+}
+
+type bitbucketCloudCreatePullRequest struct {
+	Title       string                       `json:"title"`
+	Description string                       `json:"description,omitempty"`
+	Source      bitbucketCloudPullRequestRef `json:"source"`
+	Destination bitbucketCloudPullRequestRef `json:"destination"`
+	Draft       bool                         `json:"draft"`
+}
+
+type bitbucketCloudPullRequestRef struct {
+	Branch bitbucketCloudBranchName `json:"branch"`
+}
+
+type bitbucketCloudBranchName struct {
+	Name string `json:"name"`
+}
+
+// CreatePullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) ListOpenPullRequests(ctx context.Context, owner, repository string) (res []PullRequestInfo, err error) {
+	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	client.logger.Debug("fetching open pull requests in", repository)
+	options := &bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		States:   []string{"OPEN"},
+	}
+	pullRequests, err := bitbucketClient.Repositories.PullRequests.Gets(options)
+	if err != nil {
+		return
 	}
 	parsedPullRequests, err := extractPullRequestsFromResponse(pullRequests)
 	if err != nil {
@@ -299,6 +1482,307 @@ func (client *BitbucketCloudClient) ListOpenPullRequests(ctx context.Context, ow
 	return mapBitbucketCloudPullRequestToPullRequestInfo(parsedPullRequests), nil
 }
 
+// ListOpenPullRequestsWithFilter on Bitbucket cloud
+func (client *BitbucketCloudClient) ListOpenPullRequestsWithFilter(ctx context.Context, owner, repository string, filter PullRequestFilter) ([]PullRequestInfo, error) {
+	return listPageOrAll(ctx, client.ListOpenPullRequestsPager(owner, repository, filter), filter.Page)
+}
+
+// ListOpenPullRequestsPager on Bitbucket cloud
+func (client *BitbucketCloudClient) ListOpenPullRequestsPager(owner, repository string, filter PullRequestFilter) *Pager[PullRequestInfo] {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	query := url.Values{"q": {buildBitbucketCloudPullRequestQuery(filter)}}
+	if filter.Page > 0 {
+		query.Set("page", strconv.Itoa(filter.Page))
+	}
+	if filter.PerPage > 0 {
+		query.Set("pagelen", strconv.Itoa(filter.PerPage))
+	}
+	// The Bitbucket Cloud library doesn't support filtering pull requests, so the requests are sent directly
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests?%s", endpoint, owner, repository, query.Encode())
+	return newPager(func(ctx context.Context) ([]PullRequestInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		if filter.Label != "" {
+			return nil, false, errLabelsNotSupported
+		}
+		var pullRequests pullRequestsFullResponse
+		if err = client.getJSON(ctx, u, &pullRequests); err != nil {
+			return nil, false, err
+		}
+		results := make([]PullRequestInfo, 0, len(pullRequests.Values))
+		for _, pullRequest := range pullRequests.Values {
+			results = append(results, mapBitbucketCloudPullRequestDetailsToPullRequestInfo(pullRequest))
+		}
+		u = pullRequests.Next
+		return results, u != "", nil
+	})
+}
+
+// buildBitbucketCloudPullRequestQuery builds a Bitbucket query language expression that finds the open pull requests matching the filter
+func buildBitbucketCloudPullRequestQuery(filter PullRequestFilter) string {
+	conditions := []string{`state="OPEN"`}
+	if filter.Author != "" {
+		conditions = append(conditions, "author.nickname="+strconv.Quote(filter.Author))
+	}
+	if filter.SourceBranch != "" {
+		conditions = append(conditions, "source.branch.name="+strconv.Quote(filter.SourceBranch))
+	}
+	if filter.TargetBranch != "" {
+		conditions = append(conditions, "destination.branch.name="+strconv.Quote(filter.TargetBranch))
+	}
+	if !filter.UpdatedSince.IsZero() {
+		conditions = append(conditions, "updated_on>="+filter.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// GetPullRequestByID on Bitbucket cloud
+func (client *BitbucketCloudClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	client.logger.Debug("fetching pull request", pullRequestID, "in", repository)
+	pullRequest, err := bitbucketClient.Repositories.PullRequests.Get(&bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		ID:       fmt.Sprint(pullRequestID),
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	var parsedPullRequest pullRequestFullDetails
+	if err = extractStructFromResponse(pullRequest, &parsedPullRequest); err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapBitbucketCloudPullRequestDetailsToPullRequestInfo(parsedPullRequest), nil
+}
+
+// GetPullRequestDetails on Bitbucket cloud
+func (client *BitbucketCloudClient) GetPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestDetails, error) {
+	return getPullRequestDetails(ctx, client, owner, repository, pullRequestID, true)
+}
+
+// GetPullRequestMergeableState on Bitbucket cloud. Bitbucket Cloud doesn't report whether the source branch is behind the target branch, and doesn't support status checks as branch restrictions.
+func (client *BitbucketCloudClient) GetPullRequestMergeableState(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestMergeableState, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	pullRequest, err := bitbucketClient.Repositories.PullRequests.Get(&bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		ID:       fmt.Sprint(pullRequestID),
+	})
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	var parsedPullRequest bitbucketCloudPullRequestParticipants
+	if err = extractStructFromResponse(pullRequest, &parsedPullRequest); err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	// Conflicts are reported as changed files whose status is "merge conflict"
+	var state PullRequestMergeableState
+	for u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/diffstat", endpoint, owner, repository, pullRequestID); u != "" && !state.HasConflicts; {
+		var diffStat bitbucketCloudDiffStatPage
+		if err = client.getJSON(ctx, u, &diffStat); err != nil {
+			return PullRequestMergeableState{}, err
+		}
+		for _, entry := range diffStat.Values {
+			if entry.Status == "merge conflict" {
+				state.HasConflicts = true
+			}
+		}
+		u = diffStat.Next
+	}
+	protection, err := client.GetBranchProtection(ctx, owner, repository, parsedPullRequest.Target.Name.Str)
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	if protection != nil {
+		approvals := 0
+		for _, participant := range parsedPullRequest.Participants {
+			if participant.Approved {
+				approvals++
+			}
+		}
+		state.MissingApprovals = getMissingApprovals(protection.RequiredApprovingReviewCount, approvals)
+	}
+	state.Mergeable = parsedPullRequest.State == "OPEN" && !parsedPullRequest.Draft && state.isMergeable()
+	return state, nil
+}
+
+// bitbucketCloudPullRequestParticipants is a single pull request, along with its participants
+type bitbucketCloudPullRequestParticipants struct {
+	pullRequestFullDetails
+	Participants []struct {
+		Approved bool `json:"approved"`
+	} `json:"participants"`
+}
+
+// ListPullRequestFiles on Bitbucket cloud
+func (client *BitbucketCloudClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	// The diffstat API isn't supported by the Bitbucket Cloud library, so the requests are sent directly
+	return client.getDiffStat(ctx, fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/diffstat", endpoint, owner, repository, pullRequestID))
+}
+
+// getDiffStat gets the changed files of all the pages, starting at the given URL
+func (client *BitbucketCloudClient) getDiffStat(ctx context.Context, u string) ([]PullRequestFile, error) {
+	var results []PullRequestFile
+	for u != "" {
+		var diffStat bitbucketCloudDiffStatPage
+		if err := client.getJSON(ctx, u, &diffStat); err != nil {
+			return nil, err
+		}
+		for _, entry := range diffStat.Values {
+			file := PullRequestFile{
+				Path:      entry.New.Path,
+				Status:    getBitbucketCloudFileStatus(entry.Status),
+				Additions: entry.LinesAdded,
+				Deletions: entry.LinesRemoved,
+			}
+			switch file.Status {
+			case FileDeleted:
+				file.Path = entry.Old.Path
+			case FileRenamed:
+				file.PreviousPath = entry.Old.Path
+			}
+			results = append(results, file)
+		}
+		u = diffStat.Next
+	}
+	return results, nil
+}
+
+type bitbucketCloudDiffStatPage struct {
+	Values []struct {
+		Status       string                   `json:"status"`
+		LinesAdded   int                      `json:"lines_added"`
+		LinesRemoved int                      `json:"lines_removed"`
+		Old          bitbucketCloudCommitFile `json:"old"`
+		New          bitbucketCloudCommitFile `json:"new"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+type bitbucketCloudCommitFile struct {
+	Path string `json:"path"`
+}
+
+// GetPullRequestDiff on Bitbucket cloud
+func (client *BitbucketCloudClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return "", err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	response, err := bitbucketClient.Repositories.PullRequests.Diff(&bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		ID:       fmt.Sprint(pullRequestID),
+	})
+	if err != nil {
+		return "", err
+	}
+	diffReader, ok := response.(io.ReadCloser)
+	if !ok {
+		return "", fmt.Errorf("unexpected diff response type: %T", response)
+	}
+	defer func() {
+		_ = diffReader.Close()
+	}()
+	diff, err := io.ReadAll(diffReader)
+	return string(diff), err
+}
+
+// ListPullRequestCommits on Bitbucket cloud
+func (client *BitbucketCloudClient) ListPullRequestCommits(ctx context.Context, owner, repository string, pullRequestID int) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	// The Bitbucket Cloud library doesn't follow the pagination of the pull request commits, so the requests are sent directly
+	return client.getCommits(ctx, fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/commits", endpoint, owner, repository, pullRequestID))
+}
+
+// GetDefaultReviewers on Bitbucket cloud. The default reviewers of the repository and of its project are returned for all the branches.
+func (client *BitbucketCloudClient) GetDefaultReviewers(ctx context.Context, owner, repository, sourceBranch, targetBranch string) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "source branch": sourceBranch, "target branch": targetBranch})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	var results []string
+	for u := fmt.Sprintf("%s/repositories/%s/%s/effective-default-reviewers?pagelen=100", endpoint, owner, repository); u != ""; {
+		var reviewers bitbucketCloudDefaultReviewersPage
+		if err = client.getJSON(ctx, u, &reviewers); err != nil {
+			return nil, err
+		}
+		for _, reviewer := range reviewers.Values {
+			results = appendUniqueOwners(results, reviewer.User.AccountID)
+		}
+		u = reviewers.Next
+	}
+	return results, nil
+}
+
+type bitbucketCloudDefaultReviewersPage struct {
+	Values []struct {
+		User struct {
+			AccountID string `json:"account_id"`
+		} `json:"user"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// getCommits gets the commits of all the pages, starting at the given URL
+func (client *BitbucketCloudClient) getCommits(ctx context.Context, u string) ([]CommitInfo, error) {
+	var results []CommitInfo
+	for u != "" {
+		var commits commitResponse
+		if err := client.getJSON(ctx, u, &commits); err != nil {
+			return nil, err
+		}
+		for _, commit := range commits.Values {
+			results = append(results, mapBitbucketCloudCommitToCommitInfo(commit))
+		}
+		u = commits.Next
+	}
+	return results, nil
+}
+
 // AddPullRequestComment on Bitbucket cloud
 func (client *BitbucketCloudClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
@@ -336,139 +1820,1013 @@ func (client *BitbucketCloudClient) ListPullRequestComments(ctx context.Context,
 	if err != nil {
 		return
 	}
-	return mapBitbucketCloudCommentToCommentInfo(parsedComments), nil
+	return mapBitbucketCloudCommentToCommentInfo(parsedComments), nil
+}
+
+// UpdatePullRequestComment on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	// The Bitbucket Cloud library doesn't support updating comments, so the request is sent directly
+	u := client.pullRequestCommentURL(owner, repository, pullRequestID, commentID)
+	return client.sendJSON(ctx, http.MethodPut, u, commentRequest{Content: commentContent{Raw: content}})
+}
+
+// DeletePullRequestComment on Bitbucket cloud
+func (client *BitbucketCloudClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	return client.sendJSON(ctx, http.MethodDelete, client.pullRequestCommentURL(owner, repository, pullRequestID, commentID), nil)
+}
+
+// AddCommentReaction on Bitbucket cloud
+func (client *BitbucketCloudClient) AddCommentReaction(ctx context.Context, owner, repository string, pullRequestID int, commentID int64, reaction Reaction) error {
+	return errBitbucketCommentReactionsNotSupported
+}
+
+// ListCommentReactions on Bitbucket cloud
+func (client *BitbucketCloudClient) ListCommentReactions(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) ([]ReactionInfo, error) {
+	return nil, errBitbucketCommentReactionsNotSupported
+}
+
+func (client *BitbucketCloudClient) pullRequestCommentURL(owner, repository string, pullRequestID int, commentID int64) string {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments/%d", endpoint, owner, repository, pullRequestID, commentID)
+}
+
+// AddPullRequestReviewComment on Bitbucket cloud
+func (client *BitbucketCloudClient) AddPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int,
+	comment PullRequestReviewComment) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewComment(comment); err != nil {
+		return err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	inlineCommentRequest := commentRequest{
+		Content: commentContent{Raw: comment.Content},
+		Inline:  &commentInline{Path: comment.Path, To: comment.Line, StartTo: comment.StartLine},
+	}
+	// The Bitbucket Cloud library doesn't support inline comments, so the request is sent directly
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments", endpoint, owner, repository, pullRequestID)
+	return client.sendJSON(ctx, http.MethodPost, u, inlineCommentRequest)
+}
+
+// ListPullRequestReviewComments on Bitbucket cloud
+func (client *BitbucketCloudClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewComment, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	var results []PullRequestReviewComment
+	for u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments", endpoint, owner, repository, pullRequestID); u != ""; {
+		var comments commentsResponse
+		if err = client.getJSON(ctx, u, &comments); err != nil {
+			return nil, err
+		}
+		for _, comment := range comments.Values {
+			if comment.Inline == nil || comment.IsDeleted {
+				continue
+			}
+			results = append(results, mapBitbucketCloudCommentToReviewComment(comment))
+		}
+		u = comments.Next
+	}
+	return results, nil
+}
+
+// CreatePullRequestThread on Bitbucket cloud
+func (client *BitbucketCloudClient) CreatePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (string, error) {
+	return "", errBitbucketPullRequestThreadsNotSupported
+}
+
+// ResolvePullRequestThread on Bitbucket cloud
+func (client *BitbucketCloudClient) ResolvePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	return errBitbucketPullRequestThreadsNotSupported
+}
+
+// GetLatestCommit on Bitbucket cloud
+func (client *BitbucketCloudClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     branch,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	bitbucketClient.Pagelen = 1
+	options := &bitbucket.CommitsOptions{
+		Owner:       owner,
+		RepoSlug:    repository,
+		Branchortag: branch,
+	}
+	commits, err := bitbucketClient.Repositories.Commits.GetCommits(options)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	parsedCommits, err := extractCommitFromResponse(commits)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	if len(parsedCommits.Values) > 0 {
+		latestCommit := parsedCommits.Values[0]
+		return mapBitbucketCloudCommitToCommitInfo(latestCommit), nil
+	}
+	return CommitInfo{}, nil
+}
+
+// GetRepositoryInfo on Bitbucket cloud
+func (client *BitbucketCloudClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapBitbucketCloudRepositoryToRepositoryInfo(repo)
+}
+
+// GetRepositoryLanguages on Bitbucket cloud. Bitbucket Cloud provides the main language of the repository only, which is set by its owner.
+func (client *BitbucketCloudClient) GetRepositoryLanguages(ctx context.Context, owner, repository string) (map[string]float64, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := map[string]float64{}
+	if repo.Language != "" {
+		results[repo.Language] = 100
+	}
+	return results, nil
+}
+
+// CreateRepository on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateRepository(ctx context.Context, owner, name string, options CreateRepositoryOptions) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "name": name})
+	if err != nil {
+		return err
+	}
+	if err = validateBitbucketCreateRepositoryOptions(options); err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	_, err = bitbucketClient.Repositories.Repository.Create(&bitbucket.RepositoryOptions{
+		Owner:       owner,
+		RepoSlug:    name,
+		Scm:         "git",
+		IsPrivate:   strconv.FormatBool(options.isPrivate()),
+		Description: options.Description,
+	})
+	return err
+}
+
+// DeleteRepository on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	_, err = bitbucketClient.Repositories.Repository.Delete(&bitbucket.RepositoryOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	return err
+}
+
+// ForkRepository on Bitbucket cloud
+func (client *BitbucketCloudClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string, _ bool) (ForkInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	// Bitbucket cloud returns the fork once its content is available
+	fork, err := bitbucketClient.Repositories.Repository.Fork(&bitbucket.RepositoryForkOptions{
+		FromOwner: owner,
+		FromSlug:  repository,
+		Owner:     targetOwner,
+	})
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	repositoryInfo, err := mapBitbucketCloudRepositoryToRepositoryInfo(fork)
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	forkOwner, _, _ := strings.Cut(fork.Full_name, "/")
+	return ForkInfo{Owner: forkOwner, Repository: fork.Slug, RepositoryInfo: repositoryInfo}, nil
+}
+
+// ListCollaborators on Bitbucket cloud. Only the permissions granted to users directly on the repository are returned.
+func (client *BitbucketCloudClient) ListCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := client.getUserPermissions(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]CollaboratorInfo, 0, len(permissions))
+	for _, permission := range permissions {
+		results = append(results, CollaboratorInfo{Username: permission.User.AccountID, Permission: mapBitbucketCloudPermissionToRepositoryPermission(permission.Permission)})
+	}
+	return results, nil
+}
+
+// AddCollaborator on Bitbucket cloud
+func (client *BitbucketCloudClient) AddCollaborator(ctx context.Context, owner, repository, username string, permission RepositoryPermission) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return err
+	}
+	if err = validateCollaboratorPermission(permission); err != nil {
+		return err
+	}
+	return client.sendJSON(ctx, http.MethodPut, client.getUserPermissionsURL(owner, repository)+"/"+url.PathEscape(username),
+		bitbucketCloudUserPermissionRequest{Permission: getBitbucketCloudPermissionName(permission)})
+}
+
+// RemoveCollaborator on Bitbucket cloud
+func (client *BitbucketCloudClient) RemoveCollaborator(ctx context.Context, owner, repository, username string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return err
+	}
+	return client.sendJSON(ctx, http.MethodDelete, client.getUserPermissionsURL(owner, repository)+"/"+url.PathEscape(username), nil)
+}
+
+// GetUserPermission on Bitbucket cloud. Only the permission granted to the user directly on the repository is returned.
+func (client *BitbucketCloudClient) GetUserPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return PermissionNone, err
+	}
+	permissions, err := client.getUserPermissions(ctx, owner, repository)
+	if err != nil {
+		return PermissionNone, err
+	}
+	for _, permission := range permissions {
+		if permission.User.AccountID == username {
+			return mapBitbucketCloudPermissionToRepositoryPermission(permission.Permission), nil
+		}
+	}
+	return PermissionNone, nil
+}
+
+func (client *BitbucketCloudClient) getUserPermissions(ctx context.Context, owner, repository string) ([]bitbucketCloudUserPermission, error) {
+	var results []bitbucketCloudUserPermission
+	for u := client.getUserPermissionsURL(owner, repository); u != ""; {
+		var permissions bitbucketCloudUserPermissionsPage
+		if err := client.getJSON(ctx, u, &permissions); err != nil {
+			return nil, err
+		}
+		results = append(results, permissions.Values...)
+		u = permissions.Next
+	}
+	return results, nil
+}
+
+func (client *BitbucketCloudClient) getUserPermissionsURL(owner, repository string) string {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return fmt.Sprintf("%s/repositories/%s/%s/permissions-config/users", endpoint, owner, repository)
+}
+
+type bitbucketCloudUserPermissionsPage struct {
+	Values []bitbucketCloudUserPermission `json:"values"`
+	Next   string                         `json:"next"`
+}
+
+type bitbucketCloudUserPermission struct {
+	User struct {
+		AccountID string `json:"account_id"`
+	} `json:"user"`
+	Permission string `json:"permission"`
+}
+
+type bitbucketCloudUserPermissionRequest struct {
+	Permission string `json:"permission"`
+}
+
+// GetCommitBySha on Bitbucket cloud
+func (client *BitbucketCloudClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	options := &bitbucket.CommitsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		Revision: sha,
+	}
+	commit, err := bitbucketClient.Repositories.Commits.GetCommit(options)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	parsedCommit, err := extractCommitDetailsFromResponse(commit)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapBitbucketCloudCommitToCommitInfo(parsedCommit), nil
+}
+
+// ListCommits on Bitbucket cloud. Bitbucket cloud doesn't filter the commits by author or date, so these filters are applied to each fetched page.
+func (client *BitbucketCloudClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	return listPageOrAll(ctx, client.listCommitsPager(owner, repository, options), options.Page)
+}
+
+func (client *BitbucketCloudClient) listCommitsPager(owner, repository string, options ListCommitsOptions) *Pager[CommitInfo] {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	query := url.Values{}
+	if options.Path != "" {
+		query.Set("path", options.Path)
+	}
+	if options.Page > 0 {
+		query.Set("page", strconv.Itoa(options.Page))
+	}
+	if options.PerPage > 0 {
+		query.Set("pagelen", strconv.Itoa(options.PerPage))
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/commits", endpoint, owner, repository)
+	if options.Branch != "" {
+		u += "/" + url.PathEscape(options.Branch)
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return newPager(func(ctx context.Context) ([]CommitInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		var commits commitResponse
+		if err = client.getJSON(ctx, u, &commits); err != nil {
+			return nil, false, err
+		}
+		var results []CommitInfo
+		for _, commit := range commits.Values {
+			if options.matches(commit.Date, getBitbucketCloudCommitAuthorIdentities(commit)...) {
+				results = append(results, mapBitbucketCloudCommitToCommitInfo(commit))
+			}
+		}
+		u = commits.Next
+		return results, u != "", nil
+	})
+}
+
+// GetCommit on Bitbucket cloud
+func (client *BitbucketCloudClient) GetCommit(ctx context.Context, owner, repository, sha string) (CommitDetails, error) {
+	commitInfo, err := client.GetCommitBySha(ctx, owner, repository, sha)
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	files, err := client.getDiffStat(ctx, fmt.Sprintf("%s/repositories/%s/%s/diffstat/%s", endpoint, owner, repository, url.PathEscape(sha)))
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	result := CommitDetails{CommitInfo: commitInfo, Files: files}
+	for _, file := range files {
+		result.Additions += file.Additions
+		result.Deletions += file.Deletions
+	}
+	return result, nil
+}
+
+// GetCommitVerification on Bitbucket cloud
+func (client *BitbucketCloudClient) GetCommitVerification(ctx context.Context, owner, repository, sha string) (CommitVerificationInfo, error) {
+	return CommitVerificationInfo{}, errBitbucketCommitVerificationNotSupported
+}
+
+// CompareCommits on Bitbucket cloud
+func (client *BitbucketCloudClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	repositoryURL := fmt.Sprintf("%s/repositories/%s/%s", endpoint, owner, repository)
+	commits, err := client.getCommits(ctx, fmt.Sprintf("%s/commits/%s?exclude=%s", repositoryURL, url.PathEscape(head), url.QueryEscape(base)))
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	behindCommits, err := client.getCommits(ctx, fmt.Sprintf("%s/commits/%s?exclude=%s", repositoryURL, url.PathEscape(base), url.QueryEscape(head)))
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	// Bitbucket Cloud compares the first revision of the spec to its merge base with the second revision
+	files, err := client.getDiffStat(ctx, fmt.Sprintf("%s/diffstat/%s..%s", repositoryURL, url.PathEscape(head), url.PathEscape(base)))
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	return CommitsComparison{AheadBy: len(commits), BehindBy: len(behindCommits), Commits: commits, Files: files}, nil
+}
+
+// GetCommitsBetween on Bitbucket cloud
+func (client *BitbucketCloudClient) GetCommitsBetween(ctx context.Context, owner, repository, fromSha, toSha string) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"fromSha":    fromSha,
+		"toSha":      toSha,
+	})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return client.getCommits(ctx, fmt.Sprintf("%s/repositories/%s/%s/commits/%s?exclude=%s", endpoint, owner, repository, url.PathEscape(toSha), url.QueryEscape(fromSha)))
+}
+
+// ListContributors on Bitbucket cloud. The contributors are counted from the commits, since Bitbucket cloud doesn't provide a contributors API.
+func (client *BitbucketCloudClient) ListContributors(ctx context.Context, owner, repository string) ([]ContributorInfo, error) {
+	return listContributorsFromCommits(ctx, client, owner, repository)
+}
+
+// GetCommitActivity on Bitbucket cloud. The activity is counted from the commits, since Bitbucket cloud doesn't provide commit statistics.
+func (client *BitbucketCloudClient) GetCommitActivity(ctx context.Context, owner, repository string, since time.Time) ([]CommitActivityInfo, error) {
+	return getCommitActivityFromCommits(ctx, client, owner, repository, since)
+}
+
+// CreateLabel on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	return errLabelsNotSupported
+}
+
+// GetLabel on Bitbucket cloud
+func (client *BitbucketCloudClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	return nil, errLabelsNotSupported
+}
+
+// ListLabels on Bitbucket cloud
+func (client *BitbucketCloudClient) ListLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	return nil, errLabelsNotSupported
+}
+
+// ListLabelsPager on Bitbucket cloud
+func (client *BitbucketCloudClient) ListLabelsPager(owner, repository string, perPage int) *Pager[LabelInfo] {
+	return newPager(func(ctx context.Context) ([]LabelInfo, bool, error) {
+		return nil, false, errLabelsNotSupported
+	})
+}
+
+// UpdateLabel on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	return errLabelsNotSupported
+}
+
+// DeleteLabel on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	return errLabelsNotSupported
+}
+
+// ListPullRequestLabels on Bitbucket cloud
+func (client *BitbucketCloudClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	return nil, errLabelsNotSupported
+}
+
+// UnlabelPullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	return errLabelsNotSupported
+}
+
+// CreateIssue on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateIssue(ctx context.Context, owner, repository, title, body string, labels []string) (IssueInfo, error) {
+	return IssueInfo{}, errIssuesNotSupported
+}
+
+// AddIssueComment on Bitbucket cloud
+func (client *BitbucketCloudClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	return errIssuesNotSupported
+}
+
+// ListIssues on Bitbucket cloud
+func (client *BitbucketCloudClient) ListIssues(ctx context.Context, owner, repository string, filter IssueFilter) ([]IssueInfo, error) {
+	return nil, errIssuesNotSupported
+}
+
+// UpdateIssueState on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdateIssueState(ctx context.Context, owner, repository string, issueID int, state IssueState) error {
+	return errIssuesNotSupported
+}
+
+// UploadCodeScanning on Bitbucket cloud
+func (client *BitbucketCloudClient) UploadCodeScanning(ctx context.Context, owner string, repository string, branch string, scanResults string) (string, error) {
+	return "", errBitbucketCodeScanningNotSupported
+}
+
+// UploadScanResults on Bitbucket cloud reports the scan results as a Code Insights report of the latest commit of the branch
+func (client *BitbucketCloudClient) UploadScanResults(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return "", err
+	}
+	results, err := parseSarif(scanResults)
+	if err != nil {
+		return "", err
+	}
+	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return "", err
+	}
+	report, annotations := getScanResultsCodeInsights(results)
+	if err = client.CreateCodeInsightsReport(ctx, owner, repository, commit.Hash, report); err != nil {
+		return "", err
+	}
+	return report.ID, client.AddCodeInsightsAnnotations(ctx, owner, repository, commit.Hash, report.ID, annotations)
+}
+
+// CreateCodeInsightsReport on Bitbucket cloud. The reports are created as security reports.
+func (client *BitbucketCloudClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, sha string, report CodeInsightsReport) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha, "report ID": report.ID})
+	if err != nil {
+		return err
+	}
+	return client.sendJSON(ctx, http.MethodPut, client.getCodeInsightsReportURL(owner, repository, sha, report.ID), bitbucketCloudReport{
+		Title:      report.Title,
+		Details:    report.Details,
+		ReportType: "SECURITY",
+		Reporter:   report.Reporter,
+		Link:       report.Link,
+		Result:     getBitbucketCloudReportResult(report.Result),
+	})
+}
+
+// AddCodeInsightsAnnotations on Bitbucket cloud. The annotations are added in batches of 100, the maximum of a single request.
+func (client *BitbucketCloudClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, sha, reportID string, annotations []CodeInsightsAnnotation) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha, "report ID": reportID})
+	if err != nil {
+		return err
+	}
+	annotationsURL := client.getCodeInsightsReportURL(owner, repository, sha, reportID) + "/annotations"
+	for start := 0; start < len(annotations); start += bitbucketCloudMaxAnnotationsPerRequest {
+		end := start + bitbucketCloudMaxAnnotationsPerRequest
+		if end > len(annotations) {
+			end = len(annotations)
+		}
+		request := make([]bitbucketCloudAnnotation, 0, end-start)
+		for _, annotation := range annotations[start:end] {
+			request = append(request, mapCodeInsightsAnnotationToBitbucketCloudAnnotation(annotation))
+		}
+		if err = client.sendJSON(ctx, http.MethodPost, annotationsURL, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *BitbucketCloudClient) getCodeInsightsReportURL(owner, repository, sha, reportID string) string {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return fmt.Sprintf("%s/repositories/%s/%s/commit/%s/reports/%s", endpoint, owner, repository, sha, url.PathEscape(reportID))
+}
+
+// The limits of Code Insights annotations on Bitbucket cloud
+const (
+	bitbucketCloudAnnotationSummaryMaxLength = 450
+	bitbucketCloudMaxAnnotationsPerRequest   = 100
+)
+
+type bitbucketCloudReport struct {
+	Title      string `json:"title"`
+	Details    string `json:"details"`
+	ReportType string `json:"report_type"`
+	Reporter   string `json:"reporter,omitempty"`
+	Link       string `json:"link,omitempty"`
+	Result     string `json:"result"`
+}
+
+type bitbucketCloudAnnotation struct {
+	ExternalID     string `json:"external_id"`
+	AnnotationType string `json:"annotation_type"`
+	Summary        string `json:"summary"`
+	Details        string `json:"details,omitempty"`
+	Path           string `json:"path,omitempty"`
+	Line           int    `json:"line,omitempty"`
+	Severity       string `json:"severity"`
+	Link           string `json:"link,omitempty"`
+}
+
+func mapCodeInsightsAnnotationToBitbucketCloudAnnotation(annotation CodeInsightsAnnotation) bitbucketCloudAnnotation {
+	return bitbucketCloudAnnotation{
+		ExternalID:     annotation.ExternalID,
+		AnnotationType: getBitbucketCodeInsightsAnnotationType(annotation.Type),
+		Summary:        truncateBitbucketText(annotation.Message, bitbucketCloudAnnotationSummaryMaxLength),
+		Details:        annotation.Details,
+		Path:           annotation.Path,
+		Line:           annotation.Line,
+		Severity:       getBitbucketCloudAnnotationSeverity(annotation.Severity),
+		Link:           annotation.Link,
+	}
 }
 
-// GetLatestCommit on Bitbucket cloud
-func (client *BitbucketCloudClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+func getBitbucketCloudReportResult(result CommitStatus) string {
+	switch result {
+	case Pass:
+		return "PASSED"
+	case InProgress:
+		return "PENDING"
+	}
+	return "FAILED"
+}
+
+func getBitbucketCloudAnnotationSeverity(severity SecuritySeverity) string {
+	switch severity {
+	case SeverityCritical:
+		return "CRITICAL"
+	case SeverityHigh:
+		return "HIGH"
+	case SeverityMedium:
+		return "MEDIUM"
+	}
+	return "LOW"
+}
+
+// ListSecurityAlerts on Bitbucket cloud
+func (client *BitbucketCloudClient) ListSecurityAlerts(ctx context.Context, owner, repository string) ([]SecurityAlertInfo, error) {
+	return nil, errBitbucketSecurityAlertsNotSupported
+}
+
+// DownloadFileFromRepo on Bitbucket cloud
+func (client *BitbucketCloudClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	return nil, 0, errBitbucketDownloadFileFromRepoNotSupported
+}
+
+// GetFileContent on Bitbucket cloud. The raw content API doesn't provide the SHA of the file.
+func (client *BitbucketCloudClient) GetFileContent(ctx context.Context, owner, repository, ref, path string) (FileContent, error) {
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
-		"branch":     branch,
+		"ref":        ref,
+		"path":       path,
 	})
 	if err != nil {
-		return CommitInfo{}, err
+		return FileContent{}, err
 	}
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	bitbucketClient.Pagelen = 1
-	options := &bitbucket.CommitsOptions{
-		Owner:       owner,
-		RepoSlug:    repository,
-		Branchortag: branch,
-	}
-	commits, err := bitbucketClient.Repositories.Commits.GetCommits(options)
+	blob, err := bitbucketClient.Repositories.Repository.GetFileBlob(&bitbucket.RepositoryBlobOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		Ref:      ref,
+		Path:     path,
+	})
 	if err != nil {
-		return CommitInfo{}, err
+		return FileContent{}, err
 	}
-	parsedCommits, err := extractCommitFromResponse(commits)
+	return FileContent{Path: path, Content: blob.Content, Size: int64(len(blob.Content))}, nil
+}
+
+// GetFileBlame on Bitbucket cloud
+func (client *BitbucketCloudClient) GetFileBlame(_ context.Context, _, _, _, _ string) ([]BlameLine, error) {
+	return nil, errBitbucketCloudBlameNotSupported
+}
+
+// ListRepositoryTree on Bitbucket cloud. Each directory is listed in a separate request.
+func (client *BitbucketCloudClient) ListRepositoryTree(ctx context.Context, owner, repository, ref string, recursive bool) ([]TreeEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
 	if err != nil {
-		return CommitInfo{}, err
+		return nil, err
 	}
-	if len(parsedCommits.Values) > 0 {
-		latestCommit := parsedCommits.Values[0]
-		return mapBitbucketCloudCommitToCommitInfo(latestCommit), nil
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
 	}
-	return CommitInfo{}, nil
+	srcURL := fmt.Sprintf("%s/repositories/%s/%s/src/%s/", endpoint, owner, repository, url.PathEscape(ref))
+	return walkRepositoryTree(ctx, recursive, func(ctx context.Context, directory string) ([]TreeEntry, error) {
+		var results []TreeEntry
+		u := srcURL
+		if directory != "" {
+			u += (&url.URL{Path: directory}).EscapedPath() + "/"
+		}
+		for u != "" {
+			var page bitbucketCloudDirectoryPage
+			if err := client.getJSON(ctx, u, &page); err != nil {
+				return nil, err
+			}
+			for _, entry := range page.Values {
+				entryType := TreeEntryFile
+				if entry.Type == "commit_directory" {
+					entryType = TreeEntryDirectory
+				}
+				results = append(results, TreeEntry{Path: entry.Path, Type: entryType, Size: entry.Size})
+			}
+			u = page.Next
+		}
+		return results, nil
+	})
 }
 
-// GetRepositoryInfo on Bitbucket cloud
-func (client *BitbucketCloudClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
-	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
-		return RepositoryInfo{}, err
+type bitbucketCloudDirectoryPage struct {
+	Values []struct {
+		Path string `json:"path"`
+		Type string `json:"type"`
+		Size int64  `json:"size"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// SearchCode on Bitbucket cloud. The owner is the workspace, and code search must be enabled for it.
+func (client *BitbucketCloudClient) SearchCode(ctx context.Context, scope CodeSearchScope, query string) ([]CodeSearchResult, error) {
+	return listPageOrAll(ctx, client.searchCodePager(scope, query), scope.Page)
+}
+
+func (client *BitbucketCloudClient) searchCodePager(scope CodeSearchScope, query string) *Pager[CodeSearchResult] {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
 	}
-	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
-		Owner:    owner,
-		RepoSlug: repository,
-	})
-	if err != nil {
-		return RepositoryInfo{}, err
+	searchQuery := query
+	if scope.Repository != "" {
+		searchQuery += " repo:" + scope.Repository
 	}
+	urlQuery := url.Values{"search_query": {searchQuery}}
+	if scope.Page > 0 {
+		urlQuery.Set("page", strconv.Itoa(scope.Page))
+	}
+	if scope.PerPage > 0 {
+		urlQuery.Set("pagelen", strconv.Itoa(scope.PerPage))
+	}
+	u := fmt.Sprintf("%s/workspaces/%s/search/code?%s", endpoint, scope.Owner, urlQuery.Encode())
+	return newPager(func(ctx context.Context) ([]CodeSearchResult, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": scope.Owner, "query": query})
+		if err != nil {
+			return nil, false, err
+		}
+		var searchResults bitbucketCloudCodeSearchPage
+		if err = client.getJSON(ctx, u, &searchResults); err != nil {
+			return nil, false, err
+		}
+		results := make([]CodeSearchResult, 0, len(searchResults.Values))
+		for _, searchResult := range searchResults.Values {
+			results = append(results, mapBitbucketCloudCodeSearchResult(searchResult))
+		}
+		u = searchResults.Next
+		return results, u != "", nil
+	})
+}
 
-	holder := struct {
-		Clone []struct {
-			Name string `mapstructure:"name"`
-			HRef string `mapstructure:"href"`
-		} `mapstructure:"clone"`
-	}{}
+type bitbucketCloudCodeSearchPage struct {
+	Values []bitbucketCloudCodeSearchResult `json:"values"`
+	Next   string                           `json:"next"`
+}
 
-	if err := mapstructure.Decode(repo.Links, &holder); err != nil {
-		return RepositoryInfo{}, err
-	}
+type bitbucketCloudCodeSearchResult struct {
+	ContentMatches []struct {
+		Lines []struct {
+			Segments []struct {
+				Text string `json:"text"`
+			} `json:"segments"`
+		} `json:"lines"`
+	} `json:"content_matches"`
+	File struct {
+		Path  string `json:"path"`
+		Links struct {
+			Self link `json:"self"`
+		} `json:"links"`
+	} `json:"file"`
+}
 
-	var info CloneInfo
-	for _, link := range holder.Clone {
-		switch strings.ToLower(link.Name) {
-		case "https":
-			info.HTTP = link.HRef
-		case "ssh":
-			info.SSH = link.HRef
+// The repository of a code search result is taken from the file link, such as <endpoint>/repositories/<workspace>/<repository>/src/<commit>/<path>
+func mapBitbucketCloudCodeSearchResult(searchResult bitbucketCloudCodeSearchResult) CodeSearchResult {
+	result := CodeSearchResult{Path: searchResult.File.Path}
+	if _, repositoryPath, found := strings.Cut(searchResult.File.Links.Self.Href, "/repositories/"); found {
+		if pathParts := strings.SplitN(repositoryPath, "/", 3); len(pathParts) > 1 {
+			result.Owner, result.Repository = pathParts[0], pathParts[1]
+		}
+	}
+	for _, contentMatch := range searchResult.ContentMatches {
+		lines := make([]string, 0, len(contentMatch.Lines))
+		for _, line := range contentMatch.Lines {
+			var lineText strings.Builder
+			for _, segment := range line.Segments {
+				lineText.WriteString(segment.Text)
+			}
+			lines = append(lines, lineText.String())
 		}
+		result.Fragments = append(result.Fragments, strings.Join(lines, "\n"))
 	}
-	return RepositoryInfo{RepositoryVisibility: getBitbucketCloudRepositoryVisibility(repo), CloneInfo: info}, nil
+	return result
 }
 
-// GetCommitBySha on Bitbucket cloud
-func (client *BitbucketCloudClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+// CreateOrUpdateFile on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateOrUpdateFile(ctx context.Context, owner, repository, branch, path string, content []byte, commitMessage string) error {
 	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"sha":        sha,
+		"owner":          owner,
+		"repository":     repository,
+		"branch":         branch,
+		"path":           path,
+		"commit message": commitMessage,
 	})
 	if err != nil {
-		return CommitInfo{}, err
+		return err
 	}
+	client.logger.Debug("committing file", path, "to branch", branch)
+	return client.commitFiles(ctx, owner, repository, branch, commitMessage, []FileChange{{Type: UpdateFile, Path: path, Content: content}})
+}
 
-	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	options := &bitbucket.CommitsOptions{
-		Owner:    owner,
-		RepoSlug: repository,
-		Revision: sha,
+// CommitFiles on Bitbucket cloud
+func (client *BitbucketCloudClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, changes []FileChange) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":          owner,
+		"repository":     repository,
+		"branch":         branch,
+		"commit message": commitMessage,
+	})
+	if err != nil {
+		return err
 	}
-	commit, err := bitbucketClient.Repositories.Commits.GetCommit(options)
+	if err = validateFileChanges(changes); err != nil {
+		return err
+	}
+	client.logger.Debug("committing", len(changes), "files to branch", branch)
+	return client.commitFiles(ctx, owner, repository, branch, commitMessage, changes)
+}
+
+// commitFiles creates a single commit containing the given file changes, using the src endpoint.
+// Added and updated files are sent as form files, named by their path in the repository.
+// Deleted files are listed in the "files" field.
+func (client *BitbucketCloudClient) commitFiles(ctx context.Context, owner, repository, branch, commitMessage string, changes []FileChange) error {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	for name, value := range map[string]string{"branch": branch, "message": commitMessage} {
+		if err := writer.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+	for _, change := range changes {
+		if change.Type == DeleteFile {
+			if err := writer.WriteField("files", change.Path); err != nil {
+				return err
+			}
+			continue
+		}
+		filePart, err := writer.CreateFormFile(change.Path, change.Path)
+		if err != nil {
+			return err
+		}
+		if _, err = filePart.Write(change.Content); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/src", endpoint, owner, repository)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, body)
 	if err != nil {
-		return CommitInfo{}, err
+		return err
 	}
-	parsedCommit, err := extractCommitDetailsFromResponse(commit)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	response, err := bitbucketClient.HttpClient.Do(req)
 	if err != nil {
-		return CommitInfo{}, err
+		return err
 	}
-	return mapBitbucketCloudCommitToCommitInfo(parsedCommit), nil
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	return vcsutils.CheckResponseStatusWithBody(response, http.StatusCreated)
 }
 
-// CreateLabel on Bitbucket cloud
-func (client *BitbucketCloudClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
-	return errLabelsNotSupported
+// getJSON sends a GET request, for APIs that aren't supported by the Bitbucket Cloud library, and decodes the JSON response
+func (client *BitbucketCloudClient) getJSON(ctx context.Context, u string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	response, err := bitbucketClient.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK); err != nil {
+		return err
+	}
+	return json.NewDecoder(response.Body).Decode(result)
 }
 
-// GetLabel on Bitbucket cloud
-func (client *BitbucketCloudClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
-	return nil, errLabelsNotSupported
+// sendJSON sends a request with an optional JSON body, for APIs that aren't supported by the Bitbucket Cloud library
+func (client *BitbucketCloudClient) sendJSON(ctx context.Context, method, u string, payload interface{}) error {
+	return client.sendJSONWithResult(ctx, method, u, payload, nil)
 }
 
-// ListPullRequestLabels on Bitbucket cloud
-func (client *BitbucketCloudClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	return nil, errLabelsNotSupported
+// sendJSONWithResult sends a request like sendJSON, and decodes the JSON response into result, unless it's nil
+func (client *BitbucketCloudClient) sendJSONWithResult(ctx context.Context, method, u string, payload, result interface{}) error {
+	body := new(bytes.Buffer)
+	if payload != nil {
+		if err := json.NewEncoder(body).Encode(payload); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	response, err := bitbucketClient.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK, http.StatusCreated, http.StatusNoContent); err != nil || result == nil {
+		return err
+	}
+	return json.NewDecoder(response.Body).Decode(result)
 }
 
-// UnlabelPullRequest on Bitbucket cloud
-func (client *BitbucketCloudClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
-	return errLabelsNotSupported
+// GetRepositoryEnvironmentInfo on Bitbucket cloud
+func (client *BitbucketCloudClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errBitbucketGetRepoEnvironmentInfoNotSupported
 }
 
-// UploadCodeScanning on Bitbucket cloud
-func (client *BitbucketCloudClient) UploadCodeScanning(ctx context.Context, owner string, repository string, branch string, scanResults string) (string, error) {
-	return "", errBitbucketCodeScanningNotSupported
+// ListEnvironments on Bitbucket cloud
+func (client *BitbucketCloudClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errBitbucketDeploymentsNotSupported
 }
 
-// DownloadFileFromRepo on Bitbucket cloud
-func (client *BitbucketCloudClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
-	return nil, 0, errBitbucketDownloadFileFromRepoNotSupported
+// CreateDeployment on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateDeployment(ctx context.Context, owner, repository string, deployment DeploymentInfo) (int64, error) {
+	return 0, errBitbucketDeploymentsNotSupported
 }
 
-// GetRepositoryEnvironmentInfo on Bitbucket cloud
-func (client *BitbucketCloudClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
-	return RepositoryEnvironmentInfo{}, errBitbucketGetRepoEnvironmentInfoNotSupported
+// SetDeploymentStatus on Bitbucket cloud
+func (client *BitbucketCloudClient) SetDeploymentStatus(ctx context.Context, owner, repository string, deploymentID int64, status DeploymentStatusInfo) error {
+	return errBitbucketDeploymentsNotSupported
 }
 
 func extractCommitFromResponse(commits interface{}) (*commitResponse, error) {
@@ -512,6 +2870,22 @@ type pullRequestsDetails struct {
 	ID     int64             `json:"id"`
 	Target pullRequestBranch `json:"destination"`
 	Source pullRequestBranch `json:"source"`
+	Draft  bool              `json:"draft"`
+}
+
+// pullRequestFullDetails is a single pull request, as returned from the get pull request API
+type pullRequestFullDetails struct {
+	pullRequestsDetails
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	State       string    `json:"state"`
+	Author      user      `json:"author"`
+	UpdatedOn   time.Time `json:"updated_on"`
+}
+
+type pullRequestsFullResponse struct {
+	Values []pullRequestFullDetails `json:"values"`
+	Next   string                   `json:"next"`
 }
 
 type pullRequestBranch struct {
@@ -527,6 +2901,7 @@ type pullRequestRepository struct {
 
 type commentsResponse struct {
 	Values []commentDetails `json:"values"`
+	Next   string           `json:"next"`
 }
 
 type commentDetails struct {
@@ -535,6 +2910,19 @@ type commentDetails struct {
 	IsDeleted bool           `json:"deleted"`
 	Content   commentContent `json:"content"`
 	Created   time.Time      `json:"created_on"`
+	Inline    *commentInline `json:"inline,omitempty"`
+}
+
+type commentInline struct {
+	Path    string `json:"path"`
+	From    int    `json:"from,omitempty"`
+	To      int    `json:"to,omitempty"`
+	StartTo int    `json:"start_to,omitempty"`
+}
+
+type commentRequest struct {
+	Content commentContent `json:"content"`
+	Inline  *commentInline `json:"inline,omitempty"`
 }
 
 type commentContent struct {
@@ -543,6 +2931,7 @@ type commentContent struct {
 
 type commitResponse struct {
 	Values []commitDetails `json:"values"`
+	Next   string          `json:"next"`
 }
 
 type commitDetails struct {
@@ -550,7 +2939,9 @@ type commitDetails struct {
 	Date    time.Time `json:"date"`
 	Message string    `json:"message"`
 	Author  struct {
-		User user `json:"user"`
+		// The name and email of the author, such as "Frogger <frogger@example.com>"
+		Raw  string `json:"raw"`
+		User user   `json:"user"`
 	} `json:"author"`
 	Links struct {
 		Self link `json:"self"`
@@ -562,6 +2953,7 @@ type commitDetails struct {
 
 type user struct {
 	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
 }
 type link struct {
 	Href string `json:"href"`
@@ -596,13 +2988,19 @@ func getBitbucketCloudWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []st
 			events = append(events, "pullrequest:fulfilled")
 		case vcsutils.Push:
 			events = append(events, "repo:push")
+		case vcsutils.RepoForked:
+			events = append(events, "repo:fork")
+		case vcsutils.RepoUpdated:
+			events = append(events, "repo:updated")
+		case vcsutils.CommitStatusUpdated:
+			events = append(events, "repo:commit_status_created", "repo:commit_status_updated")
 		}
 	}
 	return events
 }
 
 // The get repository request returns HTTP link to the repository - extract the link from the response.
-func getDownloadLink(repo *bitbucket.Repository, branch string) (string, error) {
+func getDownloadLink(repo *bitbucket.Repository, ref string, format ArchiveFormat) (string, error) {
 	repositoryHTMLLinks := &link{}
 	b, err := json.Marshal(repo.Links["html"])
 	if err != nil {
@@ -616,7 +3014,11 @@ func getDownloadLink(repo *bitbucket.Repository, branch string) (string, error)
 	if htmlLink == "" {
 		return "", fmt.Errorf("couldn't find repository HTML link: %s", repo.Links["html"])
 	}
-	return htmlLink + "/get/" + branch + ".tar.gz", err
+	extension := ".tar.gz"
+	if format == Zip {
+		extension = ".zip"
+	}
+	return htmlLink + "/get/" + ref + extension, err
 }
 
 func mapBitbucketCloudCommitToCommitInfo(parsedCommit commitDetails) CommitInfo {
@@ -635,6 +3037,15 @@ func mapBitbucketCloudCommitToCommitInfo(parsedCommit commitDetails) CommitInfo
 	}
 }
 
+// getBitbucketCloudCommitAuthorIdentities returns the names and email of the commit author
+func getBitbucketCloudCommitAuthorIdentities(commit commitDetails) []string {
+	identities := []string{commit.Author.User.DisplayName, commit.Author.User.Nickname}
+	if address, err := mail.ParseAddress(commit.Author.Raw); err == nil {
+		identities = append(identities, address.Name, address.Address)
+	}
+	return identities
+}
+
 func mapBitbucketCloudCommentToCommentInfo(parsedComments *commentsResponse) []CommentInfo {
 	comments := make([]CommentInfo, len(parsedComments.Values))
 	for i, comment := range parsedComments.Values {
@@ -647,6 +3058,24 @@ func mapBitbucketCloudCommentToCommentInfo(parsedComments *commentsResponse) []C
 	return comments
 }
 
+func mapBitbucketCloudCommentToReviewComment(comment commentDetails) PullRequestReviewComment {
+	line := comment.Inline.To
+	if line == 0 {
+		// Comments on removed lines are anchored to the old file version
+		line = comment.Inline.From
+	}
+	return PullRequestReviewComment{
+		CommentInfo: CommentInfo{
+			ID:      comment.ID,
+			Content: comment.Content.Raw,
+			Created: comment.Created,
+		},
+		Path:      comment.Inline.Path,
+		Line:      line,
+		StartLine: comment.Inline.StartTo,
+	}
+}
+
 func mapBitbucketCloudPullRequestToPullRequestInfo(parsedPullRequests *pullRequestsResponse) []PullRequestInfo {
 	pullRequests := make([]PullRequestInfo, len(parsedPullRequests.Values))
 	for i, pullRequest := range parsedPullRequests.Values {
@@ -660,14 +3089,117 @@ func mapBitbucketCloudPullRequestToPullRequestInfo(parsedPullRequests *pullReque
 				Name:       pullRequest.Target.Name.Str,
 				Repository: pullRequest.Target.Repository.Name,
 			},
+			Draft: pullRequest.Draft,
 		}
 	}
 	return pullRequests
 }
 
+func mapBitbucketCloudPullRequestDetailsToPullRequestInfo(pullRequest pullRequestFullDetails) PullRequestInfo {
+	state := PullRequestOpen
+	switch pullRequest.State {
+	case "MERGED":
+		state = PullRequestMerged
+	case "DECLINED", "SUPERSEDED":
+		state = PullRequestClosed
+	}
+	return PullRequestInfo{
+		ID:     pullRequest.ID,
+		Source: mapBitbucketCloudPullRequestBranch(pullRequest.Source),
+		Target: mapBitbucketCloudPullRequestBranch(pullRequest.Target),
+		Title:  pullRequest.Title,
+		Body:   pullRequest.Description,
+		State:  state,
+		Draft:  pullRequest.Draft,
+		Author: pullRequest.Author.Nickname,
+	}
+}
+
+func mapBitbucketCloudPullRequestBranch(branch pullRequestBranch) BranchInfo {
+	// The repository full name is in the form of "owner/repository"
+	owner, repository, _ := strings.Cut(branch.Repository.Name, "/")
+	return BranchInfo{Name: branch.Name.Str, Repository: repository, Owner: owner}
+}
+
+func mapBitbucketCloudPermissionToRepositoryPermission(permission string) RepositoryPermission {
+	switch permission {
+	case "admin":
+		return PermissionAdmin
+	case "write":
+		return PermissionWrite
+	case "read":
+		return PermissionRead
+	default:
+		return PermissionNone
+	}
+}
+
+func getBitbucketCloudPermissionName(permission RepositoryPermission) string {
+	switch permission {
+	case PermissionAdmin:
+		return "admin"
+	case PermissionWrite:
+		return "write"
+	default:
+		return "read"
+	}
+}
+
+// Repositories can't be archived on Bitbucket cloud, and the Bitbucket cloud client doesn't decode their size
+func mapBitbucketCloudRepositoryToRepositoryInfo(repo *bitbucket.Repository) (RepositoryInfo, error) {
+	holder := struct {
+		Clone []struct {
+			Name string `mapstructure:"name"`
+			HRef string `mapstructure:"href"`
+		} `mapstructure:"clone"`
+	}{}
+
+	if err := mapstructure.Decode(repo.Links, &holder); err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	var info CloneInfo
+	for _, link := range holder.Clone {
+		switch strings.ToLower(link.Name) {
+		case "https":
+			info.HTTP = link.HRef
+		case "ssh":
+			info.SSH = link.HRef
+		}
+	}
+	return RepositoryInfo{
+		RepositoryVisibility: getBitbucketCloudRepositoryVisibility(repo),
+		CloneInfo:            info,
+		DefaultBranch:        repo.Mainbranch.Name,
+		Description:          repo.Description,
+	}, nil
+}
+
 func getBitbucketCloudRepositoryVisibility(repo *bitbucket.Repository) RepositoryVisibility {
 	if repo.Is_private {
 		return Private
 	}
 	return Public
 }
+
+func getBitbucketCloudMergeStrategy(mergeStrategy MergeStrategy) string {
+	switch mergeStrategy {
+	case SquashMerge:
+		return "squash"
+	case RebaseMerge:
+		return "rebase_fast_forward"
+	}
+	return "merge_commit"
+}
+
+func getBitbucketCloudFileStatus(status string) FileStatus {
+	switch status {
+	case "added":
+		return FileAdded
+	case "removed":
+		return FileDeleted
+	case "renamed":
+		return FileRenamed
+	}
+	return FileModified
+}
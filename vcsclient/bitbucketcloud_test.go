@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -54,6 +57,34 @@ func TestBitbucketCloud_ConnectionWhenContextTimesOut(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestBitbucketCloud_GetRateLimitInfo(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user", r.RequestURI)
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", "999")
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	rateLimitInfo, err := buildClient(t, vcsutils.BitbucketCloud, true, server).GetRateLimitInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitInfo{Limit: 1000, Remaining: 999}, rateLimitInfo)
+
+	// Bitbucket cloud reports the rate limits only on some of the APIs
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte("{}"), "/user", createBitbucketCloudHandler)
+	defer cleanUp()
+	rateLimitInfo, err = client.GetRateLimitInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitInfo{}, rateLimitInfo)
+}
+
+func TestBitbucketCloud_TokenSource(t *testing.T) {
+	assertTokenSourceRefresh(t, vcsutils.BitbucketCloud, "{}")
+}
+
 func TestBitbucketCloud_ListRepositories(t *testing.T) {
 	ctx := context.Background()
 	mockResponse := map[string][]bitbucket.Repository{
@@ -67,6 +98,64 @@ func TestBitbucketCloud_ListRepositories(t *testing.T) {
 	assert.Equal(t, map[string][]string{username: {repo1, repo2}}, actualRepositories)
 }
 
+func TestBitbucketCloud_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	const firstPageURI = "/repositories/jfrog?pagelen=1&q=is_private+%3D+true+AND+language+%3D+%22go%22+AND+updated_on+%3E%3D+2023-01-01T00%3A00%3A00Z"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case firstPageURI:
+			response = `{"values":[{"slug":"repo-1","is_private":true,"language":"go","size":2048,"updated_on":"2023-03-01T00:00:00Z",` +
+				`"mainbranch":{"name":"main"},"workspace":{"slug":"jfrog"},"links":{"clone":[{"name":"https","href":"https://bitbucket.org/jfrog/repo-1.git"}]}}],` +
+				`"next":"` + serverURL + firstPageURI + `&page=2"}`
+		case firstPageURI + "&page=2":
+			response = `{"values":[{"slug":"repo-2","is_private":true,"language":"go","updated_on":"2023-02-01T00:00:00Z","workspace":{"slug":"jfrog"}}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	private := Private
+	result, err := client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{
+		Visibility:   &private,
+		Language:     "Go",
+		UpdatedSince: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		PerPage:      1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryDetails{
+		{
+			Owner:      owner,
+			Repository: repo1,
+			RepositoryInfo: RepositoryInfo{
+				RepositoryVisibility: Private,
+				CloneInfo:            CloneInfo{HTTP: "https://bitbucket.org/jfrog/repo-1.git"},
+				DefaultBranch:        "main",
+				Size:                 2048,
+			},
+			Language: "go",
+			Updated:  time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Owner:          owner,
+			Repository:     repo2,
+			RepositoryInfo: RepositoryInfo{RepositoryVisibility: Private},
+			Language:       "go",
+			Updated:        time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, result)
+
+	internal := Internal
+	_, err = client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{Visibility: &internal})
+	assert.ErrorIs(t, err, errBitbucketInternalRepositoriesNotSupported)
+}
+
 func TestBitbucketCloud_ListBranches(t *testing.T) {
 	ctx := context.Background()
 	mockResponse := map[string][]bitbucket.BranchModel{
@@ -80,6 +169,249 @@ func TestBitbucketCloud_ListBranches(t *testing.T) {
 	assert.ElementsMatch(t, actualRepositories, []string{branch1, branch2})
 }
 
+func TestBitbucketCloud_ListBranchesPager(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/refs/branches?page=1&pagelen=1":
+			response = `{"values":[{"name":"branch-1"}],"next":"http://ignored"}`
+		case "/repositories/jfrog/repo-1/refs/branches?page=2&pagelen=1":
+			response = `{"values":[{"name":"branch-2"}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	pager := buildClient(t, vcsutils.BitbucketCloud, true, server).ListBranchesPager(owner, repo1, 1)
+
+	page, err := pager.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{branch1}, page)
+	assert.True(t, pager.HasNext())
+	page, err = pager.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{branch2}, page)
+	assert.False(t, pager.HasNext())
+}
+
+func TestBitbucketCloud_ListAllBranches(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]map[string]interface{}{
+		"values": {
+			{"name": branch1, "target": map[string]string{"hash": "sha1"}},
+			{"name": "my-branch-2", "target": map[string]string{"hash": "sha2"}},
+		},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, mockResponse,
+		"/repositories/jfrog/repo-1/refs/branches?page=1&pagelen=100&q=name+~+%22branch-%22", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	actualBranches, err := client.ListAllBranches(ctx, owner, repo1, "branch-")
+	assert.NoError(t, err)
+	assert.Equal(t, []BranchDetails{{Name: branch1, CommitHash: "sha1"}}, actualBranches)
+}
+
+func TestBitbucketCloud_CreateBranch(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true,
+		map[string]interface{}{"name": branch1, "target": map[string]string{"hash": "sha1"}},
+		fmt.Sprintf("/repositories/%s/%s/refs/branches", owner, repo1), http.StatusCreated,
+		[]byte(`{"name":"branch-1","target":{"hash":"master"}}`), http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.CreateBranch(ctx, owner, repo1, branch1, "master")
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_DeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte{},
+		fmt.Sprintf("/repositories/%s/%s/refs/branches/%s", owner, repo1, branch1), http.StatusNoContent,
+		[]byte{}, http.MethodDelete, createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_ListTags(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/refs/tags?pagelen=100":
+			response = `{"values":[{"name":"v1.0.0","message":"Release 1.0.0","target":{"hash":"sha1"}}],"next":"` +
+				serverURL + `/repositories/jfrog/repo-1/refs/tags?pagelen=100&page=2"}`
+		case "/repositories/jfrog/repo-1/refs/tags?pagelen=100&page=2":
+			response = `{"values":[{"name":"v1.1.0","target":{"hash":"sha2"}}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitHash: "sha1", Message: "Release 1.0.0"}, {Name: "v1.1.0", CommitHash: "sha2"}}, tags)
+}
+
+func TestBitbucketCloud_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte{},
+		fmt.Sprintf("/repositories/%s/%s/refs/tags", owner, repo1), http.StatusCreated,
+		[]byte(`{"name":"v1.0.0","target":{"hash":"sha1"},"message":"Release 1.0.0"}`+"\n"), http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.CreateTag(ctx, owner, repo1, "v1.0.0", "sha1", "Release 1.0.0")
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_CreateRelease(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte{},
+		fmt.Sprintf("/repositories/%s/%s/refs/tags", owner, repo1), http.StatusCreated,
+		[]byte(`{"name":"v1.0.0","target":{"hash":"sha1"},"message":"Release 1.0.0\n\nBug fixes"}`+"\n"), http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0", Target: "sha1", Name: "Release 1.0.0", Description: "Bug fixes"})
+	assert.NoError(t, err)
+
+	err = client.CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0"})
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_GetLatestRelease(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true,
+		[]byte(`{"values":[{"name":"v1.0.0","message":"Release 1.0.0\n\nBug fixes\n","target":{"hash":"sha1"}}]}`),
+		"/repositories/jfrog/repo-1/refs/tags?sort=-target.date&pagelen=1", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	release, err := client.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, &ReleaseInfo{TagName: "v1.0.0", Name: "Release 1.0.0", Description: "Bug fixes"}, release)
+
+	noReleasesClient, noReleasesCleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(`{"values":[]}`),
+		"/repositories/jfrog/repo-1/refs/tags?sort=-target.date&pagelen=1", createBitbucketCloudHandler)
+	defer noReleasesCleanUp()
+	release, err = noReleasesClient.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Nil(t, release)
+}
+
+func TestBitbucketCloud_UploadReleaseAsset(t *testing.T) {
+	ctx := context.Background()
+	assetPath := filepath.Join(t.TempDir(), "asset.zip")
+	require.NoError(t, os.WriteFile(assetPath, []byte("asset content"), 0600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/jfrog/repo-1/downloads", r.RequestURI)
+		assert.Equal(t, http.MethodPost, r.Method)
+		file, header, err := r.FormFile("files")
+		assert.NoError(t, err)
+		content, err := io.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "asset.zip", header.Filename)
+		assert.Equal(t, "asset content", string(content))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	err := buildClient(t, vcsutils.BitbucketCloud, true, server).UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", assetPath)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true,
+		[]byte(`{"values":[{"id":1,"kind":"push","users":[{"uuid":"{frogger-uuid}"}]},{"id":2,"kind":"require_approvals_to_merge","value":2},{"id":3,"kind":"delete"}]}`),
+		"/repositories/jfrog/repo-1/branch-restrictions?pattern=master", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	protection, err := client.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, &BranchProtection{
+		RequiredApprovingReviewCount: 2,
+		RestrictPushes:               true,
+		AllowedPushUsers:             []string{"{frogger-uuid}"},
+	}, protection)
+
+	notProtectedClient, notProtectedCleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(`{"values":[]}`),
+		"/repositories/jfrog/repo-1/branch-restrictions?pattern=master", createBitbucketCloudHandler)
+	defer notProtectedCleanUp()
+	protection, err = notProtectedClient.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Nil(t, protection)
+}
+
+func TestBitbucketCloud_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	restrictionsURI := "/repositories/jfrog/repo-1/branch-restrictions"
+	var deletedRestrictions, createdRestrictions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case restrictionsURI + "?pattern=master":
+			_, err := w.Write([]byte(`{"values":[{"id":1,"kind":"push"},{"id":2,"kind":"delete"}]}`))
+			assert.NoError(t, err)
+		case restrictionsURI + "/1":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			deletedRestrictions = append(deletedRestrictions, r.RequestURI)
+			w.WriteHeader(http.StatusNoContent)
+		case restrictionsURI:
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			createdRestrictions = append(createdRestrictions, string(body))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{
+		RequiredApprovingReviewCount: 1,
+		RestrictPushes:               true,
+		AllowedPushUsers:             []string{"{frogger-uuid}"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{restrictionsURI + "/1"}, deletedRestrictions)
+	assert.Equal(t, []string{
+		`{"kind":"push","branch_match_kind":"glob","pattern":"master","users":[{"uuid":"{frogger-uuid}"}]}` + "\n",
+		`{"kind":"require_approvals_to_merge","branch_match_kind":"glob","pattern":"master","value":1}` + "\n",
+	}, createdRestrictions)
+
+	err = client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{RequiredStatusChecks: []string{"build"}})
+	assert.ErrorIs(t, err, errBitbucketCloudStatusChecksNotSupported)
+}
+
+func TestBitbucketCloud_GetRequiredChecksAndApprovals(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true,
+		[]byte(`{"values":[{"id":1,"kind":"require_approvals_to_merge","value":1},{"id":2,"kind":"require_default_reviewer_approvals_to_merge","value":2},
+			{"id":3,"kind":"reset_pullrequest_approvals_on_change"},{"id":4,"kind":"require_passing_builds_to_merge","value":1}]}`),
+		"/repositories/jfrog/repo-1/branch-restrictions?pattern=master", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	requiredChecks, err := client.GetRequiredChecks(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredChecksInfo{AllMustPass: true}, requiredChecks)
+	requiredApprovals, err := client.GetRequiredApprovals(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredApprovalsInfo{Count: 2, DismissStaleApprovals: true}, requiredApprovals)
+
+	_, err = client.GetRequiredApprovals(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
 func TestBitbucketCloud_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id, err := uuid.NewUUID()
@@ -95,6 +427,25 @@ func TestBitbucketCloud_CreateWebhook(t *testing.T) {
 	assert.Equal(t, id.String(), actualID)
 }
 
+func TestBitbucketCloud_CreateWebhookWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"uuid":"{1a2b}","url":"https://jfrog.com?token=abc","active":true,"events":["repo:push"],"skip_cert_verification":true}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/hooks", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	options := WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, InsecureSSL: true}
+	webhook, token, err := client.CreateWebhookWithOptions(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{ID: "1a2b", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON, InsecureSSL: true}, webhook)
+
+	options.ContentType = WebhookContentTypeForm
+	_, _, err = client.CreateWebhookWithOptions(ctx, owner, repo1, options)
+	assert.ErrorIs(t, err, errBitbucketWebhookFormContentTypeNotSupported)
+}
+
 func TestBitbucketCloud_UpdateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id, err := uuid.NewUUID()
@@ -119,6 +470,66 @@ func TestBitbucketCloud_DeleteWebhook(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBitbucketCloud_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"uuid":"{1a2b}","url":"https://jfrog.com/hook?token=abc","active":true,` +
+		`"events":["pullrequest:created","repo:push"],"skip_cert_verification":true}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/hooks", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{
+		{ID: "1a2b", PayloadURL: "https://jfrog.com/hook", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.Push}, Active: true, ContentType: WebhookContentTypeJSON, InsecureSSL: true},
+	}, webhooks)
+}
+
+func TestBitbucketCloud_SetWebhookActive(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(`{"uuid":"{1a2b}","active":false}`),
+		fmt.Sprintf("/repositories/%s/%s/hooks/1a2b", owner, repo1), http.StatusOK, []byte(`{"active":false}`+"\n"), http.MethodPut,
+		createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.SetWebhookActive(ctx, owner, repo1, "1a2b", false)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_CreateOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"uuid":"{1a2b}","url":"https://jfrog.com?token=abc","active":true,"events":["repo:push"]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/workspaces/jfrog/hooks", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	webhook, token, err := client.CreateOrgWebhook(ctx, owner, WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{ID: "1a2b", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON}, webhook)
+}
+
+func TestBitbucketCloud_ListOrgWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"uuid":"{1a2b}","url":"https://jfrog.com?token=abc","active":true,"events":["repo:push"]}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/workspaces/jfrog/hooks", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListOrgWebhooks(ctx, owner)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{{ID: "1a2b", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON}}, webhooks)
+}
+
+func TestBitbucketCloud_DeleteOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/workspaces/jfrog/hooks/1a2b", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	err := client.DeleteOrgWebhook(ctx, owner, "1a2b")
+	assert.NoError(t, err)
+}
+
 func TestBitbucketCloud_SetCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	ref := "9caf1c431fb783b669f0f909bd018b40f2ea3808"
@@ -130,6 +541,76 @@ func TestBitbucketCloud_SetCommitStatus(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBitbucketCloud_ListCommitStatuses(t *testing.T) {
+	ctx := context.Background()
+	ref := "9caf1c431fb783b669f0f909bd018b40f2ea3808"
+	response := []byte(`{"values":[
+		{"state":"FAILED","key":"test","description":"2 tests failed","url":"https://ci.example.com/test","created_on":"2023-01-01T10:00:00Z","updated_on":"2023-01-01T10:10:00Z"},
+		{"state":"INPROGRESS","key":"scan","created_on":"2023-01-01T10:00:00Z","updated_on":"2023-01-01T10:00:00Z"}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/jfrog/repo-1/commit/%s/statuses", ref), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	statuses, err := client.ListCommitStatuses(ctx, owner, repo1, ref)
+	require.NoError(t, err)
+	assert.Equal(t, []CommitStatusInfo{
+		{State: Fail, Title: "test", Description: "2 tests failed", DetailsURL: "https://ci.example.com/test",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 10, 0, 0, time.UTC)},
+		{State: InProgress, Title: "scan",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)},
+	}, statuses)
+
+	_, err = client.ListCommitStatuses(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_Pipelines(t *testing.T) {
+	ctx := context.Background()
+	uuid := "{4f4e5c5a-0a1b-4c2d-8e3f-1a2b3c4d5e6f}"
+	var request bitbucketCloudPipeline
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/pipelines/":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			response = `{"uuid":"` + uuid + `","build_number":7,"target":{"ref_name":"branch-1"},"state":{"name":"PENDING"}}`
+		case "/repositories/jfrog/repo-1/pipelines/" + url.PathEscape(uuid):
+			response = `{"uuid":"` + uuid + `","build_number":7,"target":{"ref_name":"branch-1"},"state":{"name":"COMPLETED","result":{"name":"SUCCESSFUL"}}}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	run, err := client.TriggerPipeline(ctx, owner, repo1, "deploy", branch1, map[string]string{"TARGET": "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, PipelineInfo{ID: uuid, Ref: branch1, State: InProgress, WebURL: "https://bitbucket.org/jfrog/repo-1/pipelines/results/7"}, run)
+	assert.Equal(t, bitbucketCloudPipelineTarget{Type: "pipeline_ref_target", RefType: "branch", RefName: branch1,
+		Selector: &bitbucketCloudPipelineSelector{Type: "custom", Pattern: "deploy"}}, request.Target)
+	assert.Equal(t, []bitbucketCloudPipelineVariable{{Key: "TARGET", Value: "staging"}}, request.Variables)
+
+	run, err = client.GetPipelineStatus(ctx, owner, repo1, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, Pass, run.State)
+
+	// Without a custom pipeline, the pipeline of the branch is run
+	request = bitbucketCloudPipeline{}
+	_, err = client.TriggerPipeline(ctx, owner, repo1, "", branch1, nil)
+	require.NoError(t, err)
+	assert.Nil(t, request.Target.Selector)
+}
+
+func TestGetBitbucketCloudPipelineStatus(t *testing.T) {
+	assert.Equal(t, InProgress, getBitbucketCloudPipelineStatus("IN_PROGRESS", ""))
+	assert.Equal(t, Pass, getBitbucketCloudPipelineStatus("COMPLETED", "SUCCESSFUL"))
+	assert.Equal(t, Fail, getBitbucketCloudPipelineStatus("COMPLETED", "STOPPED"))
+	assert.Equal(t, Error, getBitbucketCloudPipelineStatus("COMPLETED", "ERROR"))
+}
+
 func TestBitbucketCloud_DownloadRepository(t *testing.T) {
 	ctx := context.Background()
 	dir, err := os.MkdirTemp("", "")
@@ -145,6 +626,42 @@ func TestBitbucketCloud_DownloadRepository(t *testing.T) {
 	assert.DirExists(t, filepath.Join(dir, ".git"))
 }
 
+func TestBitbucketCloud_DownloadRepositoryPath(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	srcURI := "/repositories/jfrog/repo-1/src/master/"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case srcURI + "services/a/?pagelen=100":
+			response = `{"values":[{"path":"services/a/main.go","type":"commit_file"},{"path":"services/a/pkg","type":"commit_directory"}],` +
+				`"next":"http://` + r.Host + srcURI + `services/a/?pagelen=100&page=2"}`
+		case srcURI + "services/a/?pagelen=100&page=2":
+			response = `{"values":[{"path":"services/a/lib","type":"commit_link"}]}`
+		case srcURI + "services/a/pkg/?pagelen=100":
+			response = `{"values":[{"path":"services/a/pkg/util.go","type":"commit_file"}]}`
+		case srcURI + "services/a/main.go":
+			response = "package main"
+		case srcURI + "services/a/pkg/util.go":
+			response = "package pkg"
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	err := buildClient(t, vcsutils.BitbucketCloud, true, server).DownloadRepositoryPath(ctx, owner, repo1, "master", "/services/a/", dir)
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "services", "a", "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main", string(content))
+	content, err = os.ReadFile(filepath.Join(dir, "services", "a", "pkg", "util.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package pkg", string(content))
+}
+
 func TestBitbucketCloud_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/repositories/jfrog/repo-1/pullrequests/", createBitbucketCloudHandler)
@@ -154,23 +671,311 @@ func TestBitbucketCloud_CreatePullRequest(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBitbucketCloud_CreateDraftPullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"PR title","description":"PR body","source":{"branch":{"name":"branch-1"}},` +
+		`"destination":{"branch":{"name":"branch-2"}},"draft":true}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/pullrequests", http.StatusCreated, expectedBody, http.MethodPost,
+		createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateDraftPullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_MarkPullRequestReady(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/pullrequests/1", http.StatusOK, []byte(`{"draft":false}`+"\n"), http.MethodPut,
+		createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.MarkPullRequestReady(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"message":"Merge commit message","merge_strategy":"squash"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/pullrequests/1/merge", http.StatusOK, expectedBody, http.MethodPost,
+		createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.MergePullRequest(ctx, owner, repo1, 1, SquashMerge, "Merge commit message")
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_MergePullRequestConflict(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"merge_strategy":"merge_commit"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/pullrequests/1/merge", http.StatusConflict, expectedBody, http.MethodPost,
+		createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.MergePullRequest(ctx, owner, repo1, 1, MergeCommit, "")
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_PullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "", createBitbucketCloudHandler)
+	defer cleanUp()
+	err := client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, SquashMerge)
+	assert.ErrorIs(t, err, errBitbucketCloudAutoMergeNotSupported)
+	err = client.DisablePullRequestAutoMerge(ctx, owner, repo1, 1)
+	assert.ErrorIs(t, err, errBitbucketCloudAutoMergeNotSupported)
+}
+
+func TestBitbucketCloud_UpdatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"New title","destination":{"branch":{"name":"dev"}}}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/pullrequests/1", http.StatusOK, expectedBody, http.MethodPut,
+		createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdatePullRequest(ctx, owner, repo1, "New title", "", "dev", 1, nil)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_UpdatePullRequestState(t *testing.T) {
+	ctx := context.Background()
+	pullRequestState := "OPEN"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repositories/jfrog/repo-1/pullrequests/1":
+			_, err := w.Write([]byte(`{"id":1,"state":"` + pullRequestState + `"}`))
+			require.NoError(t, err)
+		case "POST /repositories/jfrog/repo-1/pullrequests/1/decline":
+			_, err := w.Write([]byte(`{"id":1,"state":"DECLINED"}`))
+			require.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	closed := PullRequestClosed
+	err := client.UpdatePullRequest(ctx, owner, repo1, "", "", "", 1, &closed)
+	assert.NoError(t, err)
+
+	pullRequestState = "DECLINED"
+	open := PullRequestOpen
+	err = client.UpdatePullRequest(ctx, owner, repo1, "", "", "", 1, &open)
+	assert.ErrorIs(t, err, errBitbucketCloudReopenPullRequestNotSupported)
+}
+
+func TestBitbucketCloud_SubmitPullRequestReview(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		_, err := w.Write([]byte("{}"))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.SubmitPullRequestReview(ctx, owner, repo1, 1, ReviewRequestChanges, "Please fix")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST /repositories/jfrog/repo-1/pullrequests/1/request-changes",
+		"POST /repositories/jfrog/repo-1/pullrequests/1/comments",
+	}, requests)
+
+	err = client.SubmitPullRequestReview(ctx, owner, repo1, 1, ReviewComment, "")
+	assert.Error(t, err)
+}
+
 func TestBitbucketCloud_ListOpenPullRequests(t *testing.T) {
 	ctx := context.Background()
-	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pull_requests_list_response.json"))
-	assert.NoError(t, err)
-	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
-		fmt.Sprintf("/repositories/%s/%s/pullrequests/?state=OPEN", owner, repo1), createBitbucketCloudHandler)
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/?state=OPEN", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequests(ctx, owner, repo1)
+
+	require.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.True(t, reflect.DeepEqual(PullRequestInfo{
+		ID:     3,
+		Source: BranchInfo{Name: "test-2", Repository: "user17/test"},
+		Target: BranchInfo{Name: "master", Repository: "user17/test"},
+	}, result[0]))
+}
+
+func TestBitbucketCloud_ListOpenPullRequestsWithFilter(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"id":1,"title":"Feature","state":"OPEN","author":{"nickname":"frogger"},` +
+		`"source":{"branch":{"name":"feature"},"repository":{"full_name":"jfrog/repo-1"}},` +
+		`"destination":{"branch":{"name":"main"},"repository":{"full_name":"jfrog/repo-1"}}}],"next":"http://ignored"}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests?page=2&pagelen=10&q=%s", owner, repo1,
+			url.QueryEscape(`state="OPEN" AND author.nickname="frogger" AND destination.branch.name="main" AND updated_on>=2023-01-02T00:00:00Z`)),
+		createBitbucketCloudHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{
+		Author:       "frogger",
+		TargetBranch: "main",
+		UpdatedSince: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		Page:         2,
+		PerPage:      10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []PullRequestInfo{{
+		ID:     1,
+		Source: BranchInfo{Name: "feature", Repository: repo1, Owner: owner},
+		Target: BranchInfo{Name: "main", Repository: repo1, Owner: owner},
+		Title:  "Feature",
+		State:  PullRequestOpen,
+		Author: "frogger",
+	}}, result)
+
+	_, err = client.ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{Label: "bug"})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
+func TestBitbucketCloud_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/pullrequests/1/diffstat":
+			response = `{"values":[{"status":"modified","lines_added":2,"lines_removed":1,"old":{"path":"go.mod"},"new":{"path":"go.mod"}},` +
+				`{"status":"removed","lines_removed":3,"old":{"path":"go.sum"}}],"next":"` + serverURL + `/repositories/jfrog/repo-1/pullrequests/1/diffstat?page=2"}`
+		case "/repositories/jfrog/repo-1/pullrequests/1/diffstat?page=2":
+			response = `{"values":[{"status":"renamed","old":{"path":"old.go"},"new":{"path":"new.go"}}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "go.mod", Status: FileModified, Additions: 2, Deletions: 1},
+		{Path: "go.sum", Status: FileDeleted, Deletions: 3},
+		{Path: "new.go", PreviousPath: "old.go", Status: FileRenamed},
+	}, files)
+}
+
+func TestBitbucketCloud_GetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	expectedDiff := "diff --git a/go.mod b/go.mod\n"
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(expectedDiff),
+		"/repositories/jfrog/repo-1/pullrequests/1/diff", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDiff, diff)
+}
+
+func TestBitbucketCloud_ListPullRequestCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "commit_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/commits", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	commits, err := client.ListPullRequestCommits(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	require.Len(t, commits, 13)
+	assert.Equal(t, CommitInfo{
+		Hash:          "ec05bacb91d757b4b6b2a11a0676471020e89fb5",
+		AuthorName:    "user",
+		CommitterName: "",
+		Url:           "https://api.bitbucket.org/2.0/repositories/user2/setup-jfrog-cli/commit/ec05bacb91d757b4b6b2a11a0676471020e89fb5",
+		Timestamp:     1591040823,
+		Message:       "Fix README.md: yaml\n",
+		ParentHashes:  []string{"774aa0fb252bccbc2a7e01060ef4d4be0b0eeaa9", "def26c6128ebe11fac555fe58b59227e9655dc4d"},
+	}, commits[0])
+}
+
+func TestBitbucketCloud_GetDefaultReviewers(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"reviewer_type":"repository","user":{"account_id":"123:frogger"}},{"reviewer_type":"project","user":{"account_id":"456:toad"}}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		"/repositories/jfrog/repo-1/effective-default-reviewers?pagelen=100", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	reviewers, err := client.GetDefaultReviewers(ctx, owner, repo1, branch1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"123:frogger", "456:toad"}, reviewers)
+
+	_, err = client.GetDefaultReviewers(ctx, "", repo1, branch1, "master")
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_GetPullRequestByID(t *testing.T) {
+	ctx := context.Background()
+	response := `{"id":1,"title":"Fix all the bugs","description":"Pull request body","state":"DECLINED","draft":true,
+"author":{"display_name":"Example User","nickname":"example"},
+"source":{"branch":{"name":"feature"},"repository":{"full_name":"forker/fork-repo"}},
+"destination":{"branch":{"name":"master"},"repository":{"full_name":"jfrog/repo-1"}}}`
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(response),
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1", owner, repo1), createBitbucketCloudHandler)
 	defer cleanUp()
 
-	result, err := client.ListOpenPullRequests(ctx, owner, repo1)
+	pullRequest, err := client.GetPullRequestByID(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestInfo{
+		ID:     1,
+		Source: BranchInfo{Name: "feature", Repository: "fork-repo", Owner: "forker"},
+		Target: BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		Title:  "Fix all the bugs",
+		Body:   "Pull request body",
+		State:  PullRequestClosed,
+		Draft:  true,
+		Author: "example",
+	}, pullRequest)
+
+	_, err = client.GetPullRequestByID(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_GetPullRequestMergeableState(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/pullrequests/1":
+			response = `{"id":1,"state":"OPEN","destination":{"branch":{"name":"master"}},
+"participants":[{"role":"REVIEWER","approved":true},{"role":"PARTICIPANT","approved":false}]}`
+		case "/repositories/jfrog/repo-1/pullrequests/1/diffstat":
+			response = `{"values":[{"status":"modified","new":{"path":"README.md"}},{"status":"merge conflict","new":{"path":"go.mod"}}]}`
+		case "/repositories/jfrog/repo-1/branch-restrictions?pattern=master":
+			response = `{"values":[{"id":1,"kind":"require_approvals_to_merge","value":2}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+			return
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
 
+	state, err := client.GetPullRequestMergeableState(ctx, owner, repo1, 1)
 	require.NoError(t, err)
-	assert.Len(t, result, 3)
-	assert.True(t, reflect.DeepEqual(PullRequestInfo{
-		ID:     3,
-		Source: BranchInfo{Name: "test-2", Repository: "user17/test"},
-		Target: BranchInfo{Name: "master", Repository: "user17/test"},
-	}, result[0]))
+	assert.Equal(t, PullRequestMergeableState{HasConflicts: true, MissingApprovals: 1}, state)
+
+	_, err = client.GetPullRequestMergeableState(ctx, "", repo1, 1)
+	assert.Error(t, err)
 }
 
 func TestBitbucketCloud_AddPullRequestComment(t *testing.T) {
@@ -203,6 +1008,68 @@ func TestBitbucketCloud_ListPullRequestComments(t *testing.T) {
 	}, result[0])
 }
 
+func TestBitbucketCloud_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"content":{"raw":"New content"}}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/pullrequests/1/comments/301545835", http.StatusOK, expectedBody, http.MethodPut,
+		createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, "New content", 1, 301545835)
+	assert.NoError(t, err)
+
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "", 1, 301545835)
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_DeletePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, []byte{},
+		"/repositories/jfrog/repo-1/pullrequests/1/comments/301545835", http.StatusNoContent, createBitbucketCloudHandler)
+	defer cleanUp()
+
+	err := client.DeletePullRequestComment(ctx, owner, repo1, 1, 301545835)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_AddPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"content":{"raw":"Use a constant"},"inline":{"path":"main.go","to":5,"start_to":3}}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/pullrequests/1/comments", http.StatusCreated, expectedBody, http.MethodPost,
+		createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	comment := PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go", Line: 5, StartLine: 3}
+	err := client.AddPullRequestReviewComment(ctx, owner, repo1, 1, comment)
+	assert.NoError(t, err)
+
+	err = client.AddPullRequestReviewComment(ctx, owner, repo1, 1, PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go", Line: 3, StartLine: 5})
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_ListPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[` +
+		`{"id":1,"content":{"raw":"General comment"},"created_on":"2022-05-16T11:04:07.075827+00:00"},` +
+		`{"id":2,"content":{"raw":"Use a constant"},"created_on":"2022-05-16T11:04:07.075827+00:00","inline":{"path":"main.go","to":5,"start_to":3}},` +
+		`{"id":3,"content":{"raw":"Removed"},"created_on":"2022-05-16T11:04:07.075827+00:00","inline":{"path":"go.mod","from":7}},` +
+		`{"id":4,"deleted":true,"content":{"raw":""},"created_on":"2022-05-16T11:04:07.075827+00:00","inline":{"path":"go.mod","to":8}}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	expectedCreated, err := time.Parse(time.RFC3339, "2022-05-16T11:04:07.075827+00:00")
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewComment{
+		{CommentInfo: CommentInfo{ID: 2, Content: "Use a constant", Created: expectedCreated}, Path: "main.go", Line: 5, StartLine: 3},
+		{CommentInfo: CommentInfo{ID: 3, Content: "Removed", Created: expectedCreated}, Path: "go.mod", Line: 7},
+	}, result)
+}
+
 func TestBitbucketCloud_GetLatestCommit(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "commit_list_response.json"))
@@ -304,6 +1171,47 @@ func TestBitbucketCloud_AddSshKeyToRepositoryNotFound(t *testing.T) {
 	require.EqualError(t, err, "404 Not Found")
 }
 
+func TestBitbucketCloud_ListDeployKeys(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"id":7,"key":"ssh-rsa AAAA...","label":"frogbot"}]}`)
+	client, closeServer := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/deploy-keys", owner, repo1), createBitbucketCloudHandler)
+	defer closeServer()
+
+	keys, err := client.ListDeployKeys(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []DeployKeyInfo{{ID: 7, Title: "frogbot", Key: "ssh-rsa AAAA...", ReadOnly: true}}, keys)
+}
+
+func TestBitbucketCloud_AddDeployKey(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "add_ssh_key_response.json"))
+	require.NoError(t, err)
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/deploy-keys", owner, repo1), http.StatusOK,
+		[]byte(`{"key":"ssh-rsa AAAA...","label":"My deploy key"}`+"\n"), http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	key, err := client.AddDeployKey(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", true)
+	require.NoError(t, err)
+	assert.Equal(t, 123, key.ID)
+	assert.True(t, key.ReadOnly)
+
+	_, err = client.AddDeployKey(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", false)
+	assert.ErrorIs(t, err, errBitbucketCloudWriteDeployKeysNotSupported)
+}
+
+func TestBitbucketCloud_DeleteDeployKey(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte{},
+		fmt.Sprintf("/repositories/%s/%s/deploy-keys/7", owner, repo1), http.StatusNoContent, []byte{}, http.MethodDelete,
+		createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.DeleteDeployKey(ctx, owner, repo1, 7)
+	assert.NoError(t, err)
+}
+
 func TestBitbucketCloud_GetCommitBySha(t *testing.T) {
 	ctx := context.Background()
 	sha := "f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c"
@@ -344,6 +1252,132 @@ func TestBitbucketCloud_GetCommitByShaNotFound(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestBitbucketCloud_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/commits/master?path=README.md":
+			response = `{"values":[{"hash":"sha1","date":"2023-06-01T00:00:00+00:00","author":{"raw":"Frogger <frogger@example.com>","user":{"display_name":"Frogger"}}},
+				{"hash":"sha2","date":"2023-07-01T00:00:00+00:00","author":{"raw":"Frogger <frogger@example.com>"}}],
+				"next":"http://` + r.Host + `/repositories/jfrog/repo-1/commits/master?page=2&path=README.md"}`
+		case "/repositories/jfrog/repo-1/commits/master?page=2&path=README.md":
+			response = `{"values":[{"hash":"sha3","date":"2023-06-15T00:00:00+00:00","author":{"raw":"Toad <toad@example.com>"},"parents":[{"hash":"sha4"}]}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	// The author and date filters are applied by the client
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+	options := ListCommitsOptions{Branch: "master", Path: "README.md", Author: "frogger@example.com", Until: time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)}
+	result, err := client.ListCommits(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "sha1", result[0].Hash)
+	assert.Equal(t, "Frogger", result[0].AuthorName)
+
+	result, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master", Path: "README.md", Page: 2})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "sha3", result[0].Hash)
+	assert.Equal(t, []string{"sha4"}, result[0].ParentHashes)
+}
+
+func TestBitbucketCloud_GetCommit(t *testing.T) {
+	ctx := context.Background()
+	sha := "f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c"
+	commitResponse, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "commit_single_response.json"))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/commit/" + sha:
+			response = commitResponse
+		case "/repositories/jfrog/repo-1/diffstat/" + sha:
+			response = []byte(`{"values":[{"status":"modified","lines_added":2,"lines_removed":1,"old":{"path":"go.mod"},"new":{"path":"go.mod"}},
+				{"status":"added","lines_added":3,"new":{"path":"new.txt"}}]}`)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	result, err := buildClient(t, vcsutils.BitbucketCloud, true, server).GetCommit(ctx, owner, repo1, sha)
+	require.NoError(t, err)
+	assert.Equal(t, sha, result.Hash)
+	assert.Equal(t, "Update image name\n", result.Message)
+	assert.Equal(t, 5, result.Additions)
+	assert.Equal(t, 1, result.Deletions)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "go.mod", Status: FileModified, Additions: 2, Deletions: 1},
+		{Path: "new.txt", Status: FileAdded, Additions: 3},
+	}, result.Files)
+}
+
+func TestBitbucketCloud_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/commits/feature?exclude=main":
+			response = `{"values":[{"hash":"sha1","message":"Add feature","date":"2020-06-01T19:47:03+00:00","author":{"user":{"display_name":"user"}},"parents":[{"hash":"sha0"}]}]}`
+		case "/repositories/jfrog/repo-1/commits/main?exclude=feature":
+			response = `{"values":[{"hash":"sha2"},{"hash":"sha3"}]}`
+		case "/repositories/jfrog/repo-1/diffstat/feature..main":
+			response = `{"values":[{"status":"modified","lines_added":2,"lines_removed":1,"old":{"path":"go.mod"},"new":{"path":"go.mod"}}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	result, err := buildClient(t, vcsutils.BitbucketCloud, true, server).CompareCommits(ctx, owner, repo1, "main", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.AheadBy)
+	assert.Equal(t, 2, result.BehindBy)
+	require.Len(t, result.Commits, 1)
+	assert.Equal(t, "sha1", result.Commits[0].Hash)
+	assert.Equal(t, []string{"sha0"}, result.Commits[0].ParentHashes)
+	assert.Equal(t, []PullRequestFile{{Path: "go.mod", Status: FileModified, Additions: 2, Deletions: 1}}, result.Files)
+}
+
+func TestBitbucketCloud_GetCommitsBetween(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/commits/sha3?exclude=sha0":
+			response = `{"values":[{"hash":"sha3","message":"Third","author":{"user":{"display_name":"user"}}},{"hash":"sha2"}],` +
+				`"next":"` + serverURL + `/repositories/jfrog/repo-1/commits/sha3?exclude=sha0&page=2"}`
+		case "/repositories/jfrog/repo-1/commits/sha3?exclude=sha0&page=2":
+			response = `{"values":[{"hash":"sha1"}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	commits, err := buildClient(t, vcsutils.BitbucketCloud, true, server).GetCommitsBetween(ctx, owner, repo1, "sha0", "sha3")
+	require.NoError(t, err)
+	require.Len(t, commits, 3)
+	assert.Equal(t, "sha3", commits[0].Hash)
+	assert.Equal(t, "Third", commits[0].Message)
+	assert.Equal(t, "user", commits[0].AuthorName)
+	assert.Equal(t, "sha1", commits[2].Hash)
+}
+
 func createBitbucketCloudWithBodyHandler(t *testing.T, expectedURI string, response []byte, expectedRequestBody []byte,
 	expectedStatusCode int, expectedHTTPMethod string) http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
@@ -380,11 +1414,113 @@ func TestBitbucketCloud_GetRepositoryInfo(t *testing.T) {
 				HTTP: "https://bitbucket.org/jfrog/jfrog-setup-cli.git",
 				SSH:  "git@bitbucket.org:jfrog/jfrog-setup-cli.git",
 			},
+			DefaultBranch: "master",
 		},
 		res,
 	)
 }
 
+func TestBitbucketCloud_GetRepositoryLanguages(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, []byte(`{"slug":"repo-1","language":"go"}`),
+		fmt.Sprintf("/repositories/%s/%s", owner, repo1), http.StatusOK, createBitbucketCloudHandler)
+	defer cleanUp()
+
+	languages, err := client.GetRepositoryLanguages(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"go": 100}, languages)
+}
+
+func TestBitbucketCloud_CreateRepository(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(`{"slug":"repo-1"}`),
+		fmt.Sprintf("/repositories/%s/%s", owner, repo1), http.StatusOK,
+		[]byte(`{"description":"Frogs","is_private":true,"name":"repo-1","scm":"git"}`), http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Description: "Frogs"})
+	assert.NoError(t, err)
+
+	internal := Internal
+	err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Visibility: &internal})
+	assert.ErrorIs(t, err, errBitbucketInternalRepositoriesNotSupported)
+}
+
+func TestBitbucketCloud_DeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte{},
+		fmt.Sprintf("/repositories/%s/%s", owner, repo1), http.StatusNoContent, []byte{}, http.MethodDelete,
+		createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "repository_response.json"))
+	require.NoError(t, err)
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/forks", owner, repo1), http.StatusCreated, []byte(`{"workspace":{"slug":"frogs"}}`), http.MethodPost,
+		createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	forkInfo, err := client.ForkRepository(ctx, owner, repo1, "frogs", true)
+	require.NoError(t, err)
+	assert.Equal(t, ForkInfo{
+		Owner:      owner,
+		Repository: "jfrog-setup-cli",
+		RepositoryInfo: RepositoryInfo{
+			RepositoryVisibility: Public,
+			CloneInfo:            CloneInfo{HTTP: "https://bitbucket.org/jfrog/jfrog-setup-cli.git", SSH: "git@bitbucket.org:jfrog/jfrog-setup-cli.git"},
+			DefaultBranch:        "master",
+		},
+	}, forkInfo)
+}
+
+func TestBitbucketCloud_ListCollaborators(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"user":{"account_id":"557058:frogger"},"permission":"admin"},{"user":{"account_id":"557058:toad"},"permission":"read"}]}`)
+	client, closeServer := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/permissions-config/users", owner, repo1), createBitbucketCloudHandler)
+	defer closeServer()
+
+	collaborators, err := client.ListCollaborators(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "557058:frogger", Permission: PermissionAdmin}, {Username: "557058:toad", Permission: PermissionRead}}, collaborators)
+
+	permission, err := client.GetUserPermission(ctx, owner, repo1, "557058:toad")
+	require.NoError(t, err)
+	assert.Equal(t, PermissionRead, permission)
+
+	permission, err = client.GetUserPermission(ctx, owner, repo1, "557058:tadpole")
+	require.NoError(t, err)
+	assert.Equal(t, PermissionNone, permission)
+}
+
+func TestBitbucketCloud_AddCollaborator(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(`{"permission":"write"}`),
+		fmt.Sprintf("/repositories/%s/%s/permissions-config/users/557058:frogger", owner, repo1), http.StatusOK,
+		[]byte(`{"permission":"write"}`+"\n"), http.MethodPut, createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.AddCollaborator(ctx, owner, repo1, "557058:frogger", PermissionWrite)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_RemoveCollaborator(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte{},
+		fmt.Sprintf("/repositories/%s/%s/permissions-config/users/557058:frogger", owner, repo1), http.StatusNoContent,
+		[]byte{}, http.MethodDelete, createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.RemoveCollaborator(ctx, owner, repo1, "557058:frogger")
+	assert.NoError(t, err)
+}
+
 func TestBitbucketCloud_CreateLabel(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
@@ -403,6 +1539,138 @@ func TestBitbucketCloudClient_DownloadFileFromRepo(t *testing.T) {
 	assert.ErrorIs(t, err, errBitbucketDownloadFileFromRepoNotSupported)
 }
 
+func TestBitbucketCloud_GetFileContent(t *testing.T) {
+	ctx := context.Background()
+	expectedPayload := []byte("hello world")
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, expectedPayload,
+		"/repositories/jfrog/repo-1/src/branch-1/hello-world", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	fileContent, err := client.GetFileContent(ctx, owner, repo1, branch1, "hello-world")
+	assert.NoError(t, err)
+	assert.Equal(t, FileContent{Path: "hello-world", Content: expectedPayload, Size: int64(len(expectedPayload))}, fileContent)
+}
+
+func TestBitbucketCloud_ListRepositoryTree(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/src/branch-1/":
+			response = `{"values":[{"path":"go.mod","type":"commit_file","size":12}],"next":"` + serverURL + `/repositories/jfrog/repo-1/src/branch-1/?page=2"}`
+		case "/repositories/jfrog/repo-1/src/branch-1/?page=2":
+			response = `{"values":[{"path":"src","type":"commit_directory"}]}`
+		case "/repositories/jfrog/repo-1/src/branch-1/src/":
+			response = `{"values":[{"path":"src/main.go","type":"commit_file","size":120}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	tree, err := client.ListRepositoryTree(ctx, owner, repo1, branch1, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []TreeEntry{
+		{Path: "go.mod", Type: TreeEntryFile, Size: 12},
+		{Path: "src", Type: TreeEntryDirectory},
+		{Path: "src/main.go", Type: TreeEntryFile, Size: 120},
+	}, tree)
+
+	_, err = client.ListRepositoryTree(ctx, owner, repo1, "", true)
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	const firstPageURI = "/workspaces/jfrog/search/code?search_query=exec.Command+repo%3Arepo-1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case firstPageURI:
+			response = `{"values":[{"file":{"path":"main.go","links":{"self":{"href":"` + serverURL + `/repositories/jfrog/repo-1/src/sha1/main.go"}}},` +
+				`"content_matches":[{"lines":[{"line":3,"segments":[{"text":"func run() {"}]},` +
+				`{"line":4,"segments":[{"text":"cmd := "},{"text":"exec.Command","match":true},{"text":"(name)"}]}]}]}],` +
+				`"next":"` + serverURL + firstPageURI + `&page=2"}`
+		case firstPageURI + "&page=2":
+			response = `{"values":[{"file":{"path":"utils/run.go","links":{"self":{"href":"` + serverURL + `/repositories/jfrog/repo-1/src/sha1/utils/run.go"}}}}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	results, err := client.SearchCode(ctx, CodeSearchScope{Owner: owner, Repository: repo1}, "exec.Command")
+	require.NoError(t, err)
+	assert.Equal(t, []CodeSearchResult{
+		{Owner: owner, Repository: repo1, Path: "main.go", Fragments: []string{"func run() {\ncmd := exec.Command(name)"}},
+		{Owner: owner, Repository: repo1, Path: "utils/run.go"},
+	}, results)
+}
+
+func TestBitbucketCloud_CreateOrUpdateFile(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repositories/jfrog/repo-1/src", r.RequestURI)
+		assert.NoError(t, r.ParseMultipartForm(1024))
+		assert.Equal(t, branch1, r.FormValue("branch"))
+		assert.Equal(t, "Add hello-world", r.FormValue("message"))
+		file, _, err := r.FormFile("dir/hello-world")
+		require.NoError(t, err)
+		content, err := io.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", string(content))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "dir/hello-world", []byte("hello world"), "Add hello-world")
+	assert.NoError(t, err)
+
+	err = client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "dir/hello-world", []byte("hello world"), "")
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repositories/jfrog/repo-1/src", r.RequestURI)
+		assert.NoError(t, r.ParseMultipartForm(1024))
+		assert.Equal(t, branch1, r.FormValue("branch"))
+		assert.Equal(t, "Update dependencies", r.FormValue("message"))
+		assert.Equal(t, []string{"go.sum"}, r.MultipartForm.Value["files"])
+		file, _, err := r.FormFile("go.mod")
+		require.NoError(t, err)
+		content, err := io.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", string(content))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", []FileChange{
+		{Type: UpdateFile, Path: "go.mod", Content: []byte("hello world")},
+		{Type: DeleteFile, Path: "go.sum"},
+	})
+	assert.NoError(t, err)
+
+	err = client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", []FileChange{})
+	assert.Error(t, err)
+}
+
 func TestBitbucketCloud_GetLabel(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
@@ -421,6 +1689,36 @@ func TestBitbucketCloud_ListPullRequestLabels(t *testing.T) {
 	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
+func TestBitbucketCloud_ListLabels(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.ListLabels(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+	_, err = client.ListLabelsPager(owner, repo1, 0).Next(ctx)
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+	err = client.UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: "new-name"})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+	err = client.DeleteLabel(ctx, owner, repo1, labelName)
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
+func TestBitbucketCloud_Issues(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.CreateIssue(ctx, owner, repo1, "title", "body", nil)
+	assert.ErrorIs(t, err, errIssuesNotSupported)
+	err = client.AddIssueComment(ctx, owner, repo1, "comment", 1)
+	assert.ErrorIs(t, err, errIssuesNotSupported)
+	_, err = client.ListIssues(ctx, owner, repo1, IssueFilter{})
+	assert.ErrorIs(t, err, errIssuesNotSupported)
+	err = client.UpdateIssueState(ctx, owner, repo1, 1, IssueClosed)
+	assert.ErrorIs(t, err, errIssuesNotSupported)
+}
+
 func TestBitbucketCloud_UnlabelPullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
@@ -439,6 +1737,30 @@ func TestBitbucketCloud_GetRepositoryEnvironmentInfo(t *testing.T) {
 	assert.ErrorIs(t, err, errBitbucketGetRepoEnvironmentInfoNotSupported)
 }
 
+func TestBitbucketCloud_Deployments(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.ListEnvironments(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+	_, err = client.CreateDeployment(ctx, owner, repo1, DeploymentInfo{Environment: envName, Ref: branch1})
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+	err = client.SetDeploymentStatus(ctx, owner, repo1, 1, DeploymentStatusInfo{State: Pass})
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+}
+
+func TestBitbucketCloud_TagProtection(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.GetTagProtection(ctx, owner, repo1, "v*")
+	assert.ErrorIs(t, err, errBitbucketCloudTagProtectionNotSupported)
+	err = client.SetTagProtection(ctx, owner, repo1, "v*", TagProtection{})
+	assert.ErrorIs(t, err, errBitbucketCloudTagProtectionNotSupported)
+}
+
 func TestBitbucketCloud_getRepositoryVisibility(t *testing.T) {
 	assert.Equal(t, Private, getBitbucketCloudRepositoryVisibility(&bitbucket.Repository{Is_private: true}))
 	assert.Equal(t, Public, getBitbucketCloudRepositoryVisibility(&bitbucket.Repository{Is_private: false}))
@@ -462,3 +1784,114 @@ func createBitbucketCloudHandler(t *testing.T, expectedURI string, response []by
 		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
 	}
 }
+
+func TestBitbucketCloud_GetCommitVerification(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	_, err = client.GetCommitVerification(context.Background(), owner, repo1, "sha1")
+	assert.ErrorIs(t, err, errBitbucketCommitVerificationNotSupported)
+}
+
+func TestBitbucketCloud_ListSecurityAlerts(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	_, err = client.ListSecurityAlerts(context.Background(), owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketSecurityAlertsNotSupported)
+}
+
+func TestBitbucketCloud_UploadScanResults(t *testing.T) {
+	ctx := context.Background()
+	commitsResponse, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "commit_list_response.json"))
+	require.NoError(t, err)
+	reportURI := "/repositories/jfrog/repo-1/commit/ec05bacb91d757b4b6b2a11a0676471020e89fb5/reports/jfrog-xray"
+	var report bitbucketCloudReport
+	var annotations []bitbucketCloudAnnotation
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repositories/jfrog/repo-1/commits/master?pagelen=1":
+			response = commitsResponse
+		case "PUT " + reportURI:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+			response = []byte("{}")
+		case "POST " + reportURI + "/annotations":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&annotations))
+			response = []byte("[]")
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	reportID, err := client.UploadScanResults(ctx, owner, repo1, "master", testSarifScanResults)
+	require.NoError(t, err)
+	assert.Equal(t, "jfrog-xray", reportID)
+	assert.Equal(t, bitbucketCloudReport{Title: "JFrog Xray", Details: "2 issues found", ReportType: "SECURITY", Reporter: "JFrog Xray", Result: "FAILED"}, report)
+	assert.Equal(t, []bitbucketCloudAnnotation{
+		{ExternalID: "jfrog-xray-1", AnnotationType: "VULNERABILITY", Summary: "Prototype Pollution in lodash", Details: "lodash 4.17.15. Fixed in Versions: [4.17.19]",
+			Path: "package.json", Line: 12, Severity: "CRITICAL"},
+		{ExternalID: "jfrog-xray-2", AnnotationType: "VULNERABILITY", Summary: "go/unused-variable", Details: "Unused variable | x", Path: "main.go", Line: 3, Severity: "LOW"},
+	}, annotations)
+}
+
+func TestBitbucketCloud_CodeInsights(t *testing.T) {
+	ctx := context.Background()
+	reportURI := "/repositories/jfrog/repo-1/commit/abc123/reports/my-report"
+	var report bitbucketCloudReport
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "PUT " + reportURI:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+		case "POST " + reportURI + "/annotations":
+			var annotations []bitbucketCloudAnnotation
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&annotations))
+			assert.Equal(t, "CODE_SMELL", annotations[0].AnnotationType)
+			batchSizes = append(batchSizes, len(annotations))
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.CreateCodeInsightsReport(ctx, owner, repo1, "abc123", CodeInsightsReport{ID: "my-report", Title: "Lint", Details: "Linting",
+		Reporter: "linter", Link: "https://ci.example.com/1", Result: InProgress})
+	require.NoError(t, err)
+	assert.Equal(t, bitbucketCloudReport{Title: "Lint", Details: "Linting", ReportType: "SECURITY", Reporter: "linter", Link: "https://ci.example.com/1",
+		Result: "PENDING"}, report)
+
+	annotations := make([]CodeInsightsAnnotation, 150)
+	for i := range annotations {
+		annotations[i] = CodeInsightsAnnotation{ExternalID: strconv.Itoa(i), Type: CodeInsightsCodeSmell, Message: "Unused variable", Path: "main.go", Line: i + 1}
+	}
+	err = client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abc123", "my-report", annotations)
+	require.NoError(t, err)
+	assert.Equal(t, []int{100, 50}, batchSizes)
+
+	err = client.CreateCodeInsightsReport(ctx, owner, repo1, "abc123", CodeInsightsReport{})
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_PullRequestThreads(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	_, err = client.CreatePullRequestThread(context.Background(), owner, repo1, 1, PullRequestReviewComment{CommentInfo: CommentInfo{Content: "comment"}})
+	assert.ErrorIs(t, err, errBitbucketPullRequestThreadsNotSupported)
+	err = client.ResolvePullRequestThread(context.Background(), owner, repo1, 1, "1", true)
+	assert.ErrorIs(t, err, errBitbucketPullRequestThreadsNotSupported)
+}
+
+func TestBitbucketCloud_CommentReactions(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	err = client.AddCommentReaction(context.Background(), owner, repo1, 1, 10, ReactionThumbsUp)
+	assert.ErrorIs(t, err, errBitbucketCommentReactionsNotSupported)
+	_, err = client.ListCommentReactions(context.Background(), owner, repo1, 1, 10)
+	assert.ErrorIs(t, err, errBitbucketCommentReactionsNotSupported)
+}
@@ -2,13 +2,38 @@ package vcsclient
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var errLabelsNotSupported = errors.New("labels are not supported on Bitbucket")
+var errIssuesNotSupported = errors.New("issues are not supported on Bitbucket")
+var errBitbucketInternalRepositoriesNotSupported = errors.New("internal repositories are not supported on Bitbucket")
+var errBitbucketInitRepositoryWithReadmeNotSupported = errors.New("initializing a repository with a README is not supported on Bitbucket")
 var errBitbucketCodeScanningNotSupported = errors.New("code scanning is not supported on Bitbucket")
 
 var errBitbucketDownloadFileFromRepoNotSupported = errors.New("download file from repo is currently not supported on Bitbucket")
+var errBitbucketServerCommitFilesNotSupported = errors.New("committing multiple files in a single commit is not supported on Bitbucket Server")
+var errBitbucketCloudReopenPullRequestNotSupported = errors.New("reopening a declined pull request is not supported on Bitbucket Cloud")
+var errBitbucketServerDraftPullRequestNotSupported = errors.New("draft pull requests are not supported on Bitbucket Server")
+var errBitbucketServerReviewUsernameRequired = errors.New("requesting changes on Bitbucket Server requires the client to be built with the reviewer's username")
 var errBitbucketGetRepoEnvironmentInfoNotSupported = errors.New("get repository environment info is currently not supported on Bitbucket")
+var errBitbucketServerReleaseAssetsNotSupported = errors.New("release assets are not supported on Bitbucket Server")
+var errBitbucketServerBranchProtectionChecksNotSupported = errors.New("required reviews and status checks are configured in the repository merge checks on Bitbucket Server, and aren't supported by branch protection")
+var errBitbucketCloudWriteDeployKeysNotSupported = errors.New("deploy keys with write access are not supported on Bitbucket Cloud")
+var errBitbucketWebhookFormContentTypeNotSupported = errors.New("webhook payloads are always sent as JSON on Bitbucket")
+var errBitbucketCloudStatusChecksNotSupported = errors.New("requiring named status checks is not supported on Bitbucket Cloud")
+var errBitbucketServerRepositoryFiltersNotSupported = errors.New("filtering repositories by language or update time is not supported on Bitbucket Server")
+var errBitbucketCloudAutoMergeNotSupported = errors.New("pull request auto-merge is not supported on Bitbucket Cloud")
+var errBitbucketCloudBlameNotSupported = errors.New("blame is not supported by the Bitbucket Cloud API")
+var errBitbucketServerLanguagesNotSupported = errors.New("repository languages are not supported on Bitbucket Server")
+var errBitbucketServerPipelinesNotSupported = errors.New("pipelines are not supported on Bitbucket Server, whose builds run on external CI servers")
+var errBitbucketDeploymentsNotSupported = errors.New("environments and deployments are currently not supported on Bitbucket")
+var errBitbucketCloudTagProtectionNotSupported = errors.New("tag protection is not supported on Bitbucket Cloud, whose branch restrictions apply to branches only")
+var errBitbucketCommitVerificationNotSupported = errors.New("commit signature verification is not supported by the Bitbucket API")
+var errBitbucketSecurityAlertsNotSupported = errors.New("security alerts are not supported on Bitbucket")
+var errBitbucketPullRequestThreadsNotSupported = errors.New("resolvable pull request threads are not supported on Bitbucket")
+var errBitbucketCommentReactionsNotSupported = errors.New("comment reactions are not supported by the Bitbucket API")
 
 func getBitbucketCommitState(commitState CommitStatus) string {
 	switch commitState {
@@ -21,3 +46,90 @@ func getBitbucketCommitState(commitState CommitStatus) string {
 	}
 	return ""
 }
+
+func getBitbucketCommitStatus(state string) CommitStatus {
+	switch state {
+	case "SUCCESSFUL":
+		return Pass
+	case "FAILED":
+		return Fail
+	case "INPROGRESS":
+		return InProgress
+	}
+	return Error
+}
+
+// Bitbucket has no releases, so a release is stored as an annotated tag, whose message is the release name followed by its description
+func getBitbucketReleaseTagMessage(release ReleaseInfo) string {
+	name := release.Name
+	if name == "" {
+		name = release.TagName
+	}
+	return strings.TrimSpace(name + "\n\n" + release.Description)
+}
+
+func mapBitbucketTagToReleaseInfo(tagName, message string) *ReleaseInfo {
+	name, description, _ := strings.Cut(strings.TrimSpace(message), "\n\n")
+	if name == "" {
+		name = tagName
+	}
+	return &ReleaseInfo{TagName: tagName, Name: name, Description: description}
+}
+
+// validateBitbucketCreateRepositoryOptions makes sure the settings of a new repository are supported on Bitbucket
+func validateBitbucketCreateRepositoryOptions(options CreateRepositoryOptions) error {
+	if err := validateCreateRepositoryOptions(options); err != nil {
+		return err
+	}
+	if options.Visibility != nil && *options.Visibility == Internal {
+		return errBitbucketInternalRepositoriesNotSupported
+	}
+	if options.InitWithReadme {
+		return errBitbucketInitRepositoryWithReadmeNotSupported
+	}
+	return nil
+}
+
+// getScanResultsCodeInsights returns the Code Insights report of scan results, and an annotation of each finding
+func getScanResultsCodeInsights(results scanResults) (CodeInsightsReport, []CodeInsightsAnnotation) {
+	report := CodeInsightsReport{ID: results.reportID(), Title: results.Tool, Details: "No issues found", Reporter: results.Tool, Result: Pass}
+	if report.Title == "" {
+		report.Title = "Scan results"
+	}
+	if len(results.Findings) > 0 {
+		report.Details = fmt.Sprintf("%d issues found", len(results.Findings))
+		report.Result = Fail
+	}
+	annotations := make([]CodeInsightsAnnotation, 0, len(results.Findings))
+	for i, finding := range results.Findings {
+		annotations = append(annotations, CodeInsightsAnnotation{
+			ExternalID: fmt.Sprintf("%s-%d", report.ID, i+1),
+			Type:       CodeInsightsVulnerability,
+			Severity:   finding.Severity,
+			Path:       finding.Path,
+			Line:       finding.StartLine,
+			Message:    finding.Title,
+			Details:    finding.Message,
+		})
+	}
+	return report, annotations
+}
+
+func getBitbucketCodeInsightsAnnotationType(annotationType CodeInsightsAnnotationType) string {
+	switch annotationType {
+	case CodeInsightsCodeSmell:
+		return "CODE_SMELL"
+	case CodeInsightsBug:
+		return "BUG"
+	}
+	return "VULNERABILITY"
+}
+
+// truncateBitbucketText truncates a text to the maximal length of a Code Insights field
+func truncateBitbucketText(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-3]) + "..."
+}
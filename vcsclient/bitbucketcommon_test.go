@@ -13,3 +13,16 @@ func TestBitbucketClient_getBitbucketCommitState(t *testing.T) {
 	assert.Equal(t, "INPROGRESS", getBitbucketCommitState(InProgress))
 	assert.Equal(t, "", getBitbucketCommitState(5))
 }
+
+func TestBitbucketClient_getBitbucketReleaseTagMessage(t *testing.T) {
+	assert.Equal(t, "Release 1.0.0\n\nBug fixes", getBitbucketReleaseTagMessage(ReleaseInfo{TagName: "v1.0.0", Name: "Release 1.0.0", Description: "Bug fixes"}))
+	assert.Equal(t, "v1.0.0\n\nBug fixes", getBitbucketReleaseTagMessage(ReleaseInfo{TagName: "v1.0.0", Description: "Bug fixes"}))
+	assert.Equal(t, "v1.0.0", getBitbucketReleaseTagMessage(ReleaseInfo{TagName: "v1.0.0"}))
+}
+
+func TestBitbucketClient_mapBitbucketTagToReleaseInfo(t *testing.T) {
+	assert.Equal(t, &ReleaseInfo{TagName: "v1.0.0", Name: "Release 1.0.0", Description: "Bug fixes\n\nMore fixes"},
+		mapBitbucketTagToReleaseInfo("v1.0.0", "Release 1.0.0\n\nBug fixes\n\nMore fixes\n"))
+	assert.Equal(t, &ReleaseInfo{TagName: "v1.0.0", Name: "Release 1.0.0"}, mapBitbucketTagToReleaseInfo("v1.0.0", "Release 1.0.0"))
+	assert.Equal(t, &ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0"}, mapBitbucketTagToReleaseInfo("v1.0.0", ""))
+}
@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -15,9 +18,11 @@ import (
 	bitbucketv1 "github.com/gfleury/go-bitbucket-v1"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/mitchellh/mapstructure"
-	"golang.org/x/oauth2"
 )
 
+// The page size Bitbucket Server uses when the request sets no limit
+const bitbucketServerDefaultPageSize = 25
+
 // BitbucketServerClient API version 1.0
 type BitbucketServerClient struct {
 	vcsInfo VcsInfo
@@ -34,10 +39,7 @@ func NewBitbucketServerClient(vcsInfo VcsInfo, logger Log) (*BitbucketServerClie
 }
 
 func (client *BitbucketServerClient) buildBitbucketClient(ctx context.Context) (*bitbucketv1.DefaultApiService, error) {
-	// Bitbucket API Endpoint ends with '/rest'
-	if !strings.HasSuffix(client.vcsInfo.APIEndpoint, "/rest") {
-		client.vcsInfo.APIEndpoint += "/rest"
-	}
+	client.addRestSuffixToEndpoint()
 
 	bbClient := bitbucketv1.NewAPIClient(ctx, &bitbucketv1.Configuration{
 		HTTPClient: client.buildHTTPClient(ctx),
@@ -46,12 +48,15 @@ func (client *BitbucketServerClient) buildBitbucketClient(ctx context.Context) (
 	return bbClient.DefaultApi, nil
 }
 
-func (client *BitbucketServerClient) buildHTTPClient(ctx context.Context) *http.Client {
-	httpClient := &http.Client{}
-	if client.vcsInfo.Token != "" {
-		httpClient = oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: client.vcsInfo.Token}))
+// Bitbucket API Endpoint ends with '/rest'
+func (client *BitbucketServerClient) addRestSuffixToEndpoint() {
+	if !strings.HasSuffix(client.vcsInfo.APIEndpoint, "/rest") {
+		client.vcsInfo.APIEndpoint += "/rest"
 	}
-	return httpClient
+}
+
+func (client *BitbucketServerClient) buildHTTPClient(_ context.Context) *http.Client {
+	return newBearerTokenHTTPClient(client.vcsInfo, client.logger)
 }
 
 // TestConnection on Bitbucket server
@@ -66,6 +71,19 @@ func (client *BitbucketServerClient) TestConnection(ctx context.Context) error {
 	return err
 }
 
+// GetRateLimitInfo on Bitbucket server. Bitbucket server reports the rate limits in the headers of every response.
+func (client *BitbucketServerClient) GetRateLimitInfo(ctx context.Context) (RateLimitInfo, error) {
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return RateLimitInfo{}, err
+	}
+	response, err := bitbucketClient.GetApplicationProperties()
+	if err != nil {
+		return RateLimitInfo{}, err
+	}
+	return getRateLimitInfo(response.Header), nil
+}
+
 // ListRepositories on Bitbucket server
 func (client *BitbucketServerClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
 	bitbucketClient, err := client.buildBitbucketClient(ctx)
@@ -99,6 +117,59 @@ func (client *BitbucketServerClient) ListRepositories(ctx context.Context) (map[
 	return results, nil
 }
 
+// ListRepositoriesWithOptions on Bitbucket server. The owner is the project key, or ~username for personal repositories.
+// Bitbucket server doesn't filter the repositories, so the filters are applied to each fetched page.
+// The default branches of the repositories aren't returned.
+func (client *BitbucketServerClient) ListRepositoriesWithOptions(ctx context.Context, owner string, options ListRepositoriesOptions) ([]RepositoryDetails, error) {
+	if options.Visibility != nil && *options.Visibility == Internal {
+		return nil, errBitbucketInternalRepositoriesNotSupported
+	}
+	if options.Language != "" || !options.UpdatedSince.IsZero() {
+		return nil, errBitbucketServerRepositoryFiltersNotSupported
+	}
+	return listPageOrAll(ctx, client.listRepositoriesPager(owner, options), options.Page)
+}
+
+func (client *BitbucketServerClient) listRepositoriesPager(owner string, options ListRepositoriesOptions) *Pager[RepositoryDetails] {
+	pageSize := options.PerPage
+	if pageSize == 0 {
+		pageSize = bitbucketServerDefaultPageSize
+	}
+	requestOptions := map[string]interface{}{"limit": pageSize, "start": (firstPage(options.Page) - 1) * pageSize}
+	return newPager(func(ctx context.Context) ([]RepositoryDetails, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner})
+		if err != nil {
+			return nil, false, err
+		}
+		bitbucketClient, err := client.buildBitbucketClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		apiResponse, err := bitbucketClient.GetRepositoriesWithOptions(owner, requestOptions)
+		if err != nil {
+			return nil, false, err
+		}
+		var repos []bitbucketServerRepository
+		if err = mapstructure.Decode(apiResponse.Values["values"], &repos); err != nil {
+			return nil, false, err
+		}
+		var results []RepositoryDetails
+		for _, repo := range repos {
+			details := RepositoryDetails{
+				Owner:          repo.Project.Key,
+				Repository:     repo.Slug,
+				RepositoryInfo: mapBitbucketServerRepositoryToRepositoryInfo(repo),
+			}
+			if options.matches(details) {
+				results = append(results, details)
+			}
+		}
+		hasNextPage, nextPageStart := bitbucketv1.HasNextPage(apiResponse)
+		requestOptions["start"] = nextPageStart
+		return results, hasNextPage, nil
+	})
+}
+
 // ListBranches on Bitbucket server
 func (client *BitbucketServerClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
 	bitbucketClient, err := client.buildBitbucketClient(ctx)
@@ -125,243 +196,1489 @@ func (client *BitbucketServerClient) ListBranches(ctx context.Context, owner, re
 	return results, nil
 }
 
-// AddSshKeyToRepository on Bitbucket server
-func (client *BitbucketServerClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
-	// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-ssh-rest.html
-	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"key name":   keyName,
-		"public key": publicKey,
+// ListBranchesPager on Bitbucket server
+func (client *BitbucketServerClient) ListBranchesPager(owner, repository string, perPage int) *Pager[string] {
+	options := createPaginationOptions(0)
+	if perPage > 0 {
+		options["limit"] = perPage
+	}
+	return newPager(func(ctx context.Context) ([]string, bool, error) {
+		bitbucketClient, err := client.buildBitbucketClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		apiResponse, err := bitbucketClient.GetBranches(owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		branches, err := bitbucketv1.GetBranchesResponse(apiResponse)
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]string, 0, len(branches))
+		for _, branch := range branches {
+			results = append(results, branch.ID)
+		}
+		hasNextPage, nextPageStart := bitbucketv1.HasNextPage(apiResponse)
+		options["start"] = nextPageStart
+		return results, hasNextPage, nil
 	})
+}
+
+// ListAllBranches on Bitbucket server
+func (client *BitbucketServerClient) ListAllBranches(ctx context.Context, owner, repository, prefix string) ([]BranchDetails, error) {
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	var results []BranchDetails
+	var apiResponse *bitbucketv1.APIResponse
+	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		options := createPaginationOptions(nextPageStart)
+		if prefix != "" {
+			// filterText matches anywhere in the branch name, so the prefix is verified below as well
+			options["filterText"] = prefix
+		}
+		apiResponse, err = bitbucketClient.GetBranches(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		branches, err := bitbucketv1.GetBranchesResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
 
-	accessPermission := "REPO_READ"
-	if permission == ReadWrite {
-		accessPermission = "REPO_WRITE"
+		for _, branch := range branches {
+			if strings.HasPrefix(branch.DisplayID, prefix) {
+				results = append(results, BranchDetails{Name: branch.DisplayID, CommitHash: branch.LatestCommit})
+			}
+		}
 	}
 
-	url := fmt.Sprintf("%s/keys/1.0/projects/%s/repos/%s/ssh", client.vcsInfo.APIEndpoint, owner, repository)
-	addKeyRequest := bitbucketServerAddSSHKeyRequest{
-		Key:        bitbucketServerSSHKey{Text: publicKey, Label: keyName},
-		Permission: accessPermission,
-	}
+	return results, nil
+}
 
-	body := new(bytes.Buffer)
-	err = json.NewEncoder(body).Encode(addKeyRequest)
-	if err != nil {
-		return err
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+// CreateBranch on Bitbucket server
+func (client *BitbucketServerClient) CreateBranch(ctx context.Context, owner, repository, branchName, fromRef string) error {
+	// https://docs.atlassian.com/bitbucket-server/rest/7.21.0/bitbucket-rest.html
+	err := validateParametersNotBlank(map[string]string{
+		"owner":       owner,
+		"repository":  repository,
+		"branch name": branchName,
+		"from ref":    fromRef,
+	})
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	client.logger.Debug("creating branch", branchName, "from", fromRef)
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/branches", client.vcsInfo.APIEndpoint, owner, repository)
+	return client.sendJSONRequest(ctx, http.MethodPost, url, bitbucketServerCreateBranchRequest{Name: branchName, StartPoint: fromRef})
+}
 
-	httpClient := client.buildHTTPClient(ctx)
-	response, err := httpClient.Do(req)
+// DeleteBranch on Bitbucket server
+func (client *BitbucketServerClient) DeleteBranch(ctx context.Context, owner, repository, branchName string) error {
+	// https://docs.atlassian.com/bitbucket-server/rest/7.21.0/bitbucket-branch-rest.html
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch name": branchName})
 	if err != nil {
 		return err
 	}
-	defer func() { _ = response.Body.Close() }()
-
-	if response.StatusCode >= 300 {
-		bodyBytes, err := io.ReadAll(response.Body)
-		if err != nil {
-			return err
-		}
-		return fmt.Errorf("status: %v, body: %s", response.Status, bodyBytes)
-	}
-	_ = vcsutils.DiscardResponseBody(response)
-	return nil
+	client.logger.Debug("deleting branch", branchName)
+	url := fmt.Sprintf("%s/branch-utils/1.0/projects/%s/repos/%s/branches", client.vcsInfo.APIEndpoint, owner, repository)
+	return client.sendJSONRequest(ctx, http.MethodDelete, url, bitbucketServerDeleteBranchRequest{Name: vcsutils.AddBranchPrefix(branchName)})
 }
 
-type bitbucketServerAddSSHKeyRequest struct {
-	Key        bitbucketServerSSHKey `json:"key"`
-	Permission string                `json:"permission"`
+type bitbucketServerCreateBranchRequest struct {
+	Name       string `json:"name"`
+	StartPoint string `json:"startPoint"`
 }
 
-type bitbucketServerSSHKey struct {
-	Text  string `json:"text"`
-	Label string `json:"label"`
+type bitbucketServerDeleteBranchRequest struct {
+	Name string `json:"name"`
 }
 
-// CreateWebhook on Bitbucket server
-func (client *BitbucketServerClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
-	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
-	bitbucketClient, err := client.buildBitbucketClient(ctx)
-	if err != nil {
-		return "", "", err
-	}
-	token := vcsutils.CreateToken()
-	hook := createBitbucketServerHook(token, payloadURL, webhookEvents...)
-	response, err := bitbucketClient.CreateWebhook(owner, repository, hook, []string{})
+// ListTags on Bitbucket server
+func (client *BitbucketServerClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
-	webhoodID, err := getBitbucketServerWebhookID(response)
-	if err != nil {
-		return "", "", err
+	// The tags API of the Bitbucket server library doesn't support pagination, so the requests are sent directly
+	tagsURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/tags", client.vcsInfo.APIEndpoint, owner, repository)
+	var results []TagInfo
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		responseBody, err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s?start=%d", tagsURL, nextPageStart), nil, "")
+		if err != nil {
+			return nil, err
+		}
+		var tags bitbucketServerTagsPage
+		if err = json.Unmarshal(responseBody, &tags); err != nil {
+			return nil, err
+		}
+		for _, tag := range tags.Values {
+			results = append(results, TagInfo{Name: tag.DisplayID, CommitHash: tag.LatestCommit})
+		}
+		isLastPage, nextPageStart = tags.IsLastPage, tags.NextPageStart
 	}
-	return webhoodID, token, err
+	return results, nil
 }
 
-// UpdateWebhook on Bitbucket server
-func (client *BitbucketServerClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
-	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
-	bitbucketClient, err := client.buildBitbucketClient(ctx)
+// CreateTag on Bitbucket server
+func (client *BitbucketServerClient) CreateTag(ctx context.Context, owner, repository, tagName, targetSha, message string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"tag name":   tagName,
+		"target sha": targetSha,
+	})
 	if err != nil {
 		return err
 	}
-	webhookIDInt32, err := strconv.ParseInt(webhookID, 10, 32)
+	client.logger.Debug("creating tag", tagName, "on", targetSha)
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/tags", client.vcsInfo.APIEndpoint, owner, repository)
+	return client.sendJSONRequest(ctx, http.MethodPost, url, bitbucketServerCreateTagRequest{Name: tagName, StartPoint: targetSha, Message: message})
+}
+
+type bitbucketServerTagsPage struct {
+	Values []struct {
+		DisplayID    string `json:"displayId"`
+		LatestCommit string `json:"latestCommit"`
+	} `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+type bitbucketServerCreateTagRequest struct {
+	Name       string `json:"name"`
+	StartPoint string `json:"startPoint"`
+	// An annotated tag is created if the message isn't empty
+	Message string `json:"message,omitempty"`
+}
+
+// CreateRelease on Bitbucket server
+func (client *BitbucketServerClient) CreateRelease(ctx context.Context, owner, repository string, release ReleaseInfo) error {
+	err := validateParametersNotBlank(map[string]string{"tag name": release.TagName, "target": release.Target})
 	if err != nil {
 		return err
 	}
-	hook := createBitbucketServerHook(token, payloadURL, webhookEvents...)
-	_, err = bitbucketClient.UpdateWebhook(owner, repository, int32(webhookIDInt32), hook, []string{})
-	return err
+	return client.CreateTag(ctx, owner, repository, release.TagName, release.Target, getBitbucketReleaseTagMessage(release))
 }
 
-// DeleteWebhook on Bitbucket server
-func (client *BitbucketServerClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
-	bitbucketClient, err := client.buildBitbucketClient(ctx)
+// GetLatestRelease on Bitbucket server. The tag message isn't returned by Bitbucket server, so the release is named after its tag.
+func (client *BitbucketServerClient) GetLatestRelease(ctx context.Context, owner, repository string) (*ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	webhookIDInt32, err := strconv.ParseInt(webhookID, 10, 32)
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/tags?orderBy=MODIFICATION&limit=1", client.vcsInfo.APIEndpoint, owner, repository)
+	responseBody, err := client.sendRequest(ctx, http.MethodGet, url, nil, "")
 	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = bitbucketClient.DeleteWebhook(owner, repository, int32(webhookIDInt32))
-	return err
+	var tags bitbucketServerTagsPage
+	if err = json.Unmarshal(responseBody, &tags); err != nil || len(tags.Values) == 0 {
+		return nil, err
+	}
+	return mapBitbucketTagToReleaseInfo(tags.Values[0].DisplayID, ""), nil
 }
 
-// SetCommitStatus on Bitbucket server
-func (client *BitbucketServerClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, _, _, ref, title,
-	description, detailsURL string) error {
-	bitbucketClient, err := client.buildBitbucketClient(ctx)
-	if err != nil {
-		return err
-	}
-	_, err = bitbucketClient.SetCommitStatus(ref, bitbucketv1.BuildStatus{
-		State:       getBitbucketCommitState(commitStatus),
-		Key:         title,
-		Description: description,
-		Url:         detailsURL,
-	})
-	return err
+// UploadReleaseAsset on Bitbucket server
+func (client *BitbucketServerClient) UploadReleaseAsset(ctx context.Context, owner, repository, tagName, assetPath string) error {
+	return errBitbucketServerReleaseAssetsNotSupported
 }
 
-// DownloadRepository on Bitbucket server
-func (client *BitbucketServerClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
-	bitbucketClient, err := client.buildBitbucketClient(ctx)
+// GetBranchProtection on Bitbucket server
+func (client *BitbucketServerClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	params := map[string]interface{}{"format": "tgz"}
-	branch = strings.TrimSpace(branch)
-	if branch != "" {
-		params["at"] = branch
+	restrictions, err := client.getBranchRestrictions(ctx, owner, repository, branch)
+	if err != nil || len(restrictions) == 0 {
+		return nil, err
 	}
-	response, err := bitbucketClient.GetArchive(owner, repository, params)
-	if err != nil {
-		return err
+	branchProtection := &BranchProtection{}
+	for _, restriction := range restrictions {
+		if !isBitbucketServerPushRestriction(restriction.Type) {
+			continue
+		}
+		branchProtection.RestrictPushes = true
+		for _, user := range restriction.Users {
+			branchProtection.AllowedPushUsers = append(branchProtection.AllowedPushUsers, user.Name)
+		}
 	}
-	client.logger.Info(repository, "downloaded successfully, starting with repository extraction")
-	err = vcsutils.Untar(localPath, bytes.NewReader(response.Payload), false)
+	return branchProtection, nil
+}
+
+// SetBranchProtection on Bitbucket server
+func (client *BitbucketServerClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtection) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
 	if err != nil {
 		return err
 	}
-	client.logger.Info("extracted repository successfully")
-	// Generate .git folder with remote details
-	return vcsutils.CreateDotGitFolderWithRemote(localPath, "origin",
-		fmt.Sprintf("%s/scm/%s/%s.git", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository))
-}
-
-// CreatePullRequest on Bitbucket server
-func (client *BitbucketServerClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
-	title, description string) error {
-	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if protection.RequiredApprovingReviewCount > 0 || len(protection.RequiredStatusChecks) > 0 {
+		return errBitbucketServerBranchProtectionChecksNotSupported
+	}
+	restrictions, err := client.getBranchRestrictions(ctx, owner, repository, branch)
 	if err != nil {
 		return err
 	}
-	bitbucketRepo := &bitbucketv1.Repository{
-		Slug: repository,
-		Project: &bitbucketv1.Project{
-			Key: owner,
-		},
+	restrictionsURL := client.getBranchRestrictionsURL(owner, repository)
+	for _, restriction := range restrictions {
+		if !isBitbucketServerPushRestriction(restriction.Type) {
+			continue
+		}
+		client.logger.Debug("deleting restriction", restriction.ID, "of branch", branch)
+		if _, err = client.sendRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", restrictionsURL, restriction.ID), nil, ""); err != nil {
+			return err
+		}
 	}
-	options := bitbucketv1.PullRequest{
-		Title:       title,
-		Description: description,
-		FromRef: bitbucketv1.PullRequestRef{
-			ID:         vcsutils.AddBranchPrefix(sourceBranch),
-			Repository: *bitbucketRepo,
-		},
-		ToRef: bitbucketv1.PullRequestRef{
-			ID:         vcsutils.AddBranchPrefix(targetBranch),
-			Repository: *bitbucketRepo,
-		},
+	if !protection.RestrictPushes {
+		return nil
 	}
-	_, err = bitbucketClient.CreatePullRequest(owner, repository, options)
-	return err
+	client.logger.Debug("restricting pushes to branch", branch)
+	return client.sendJSONRequest(ctx, http.MethodPost, restrictionsURL, bitbucketServerCreateBranchRestrictionRequest{
+		Type:    bitbucketServerReadOnlyRestriction,
+		Matcher: bitbucketServerBranchMatcher{ID: "refs/heads/" + branch, Type: bitbucketServerBranchMatcherType{ID: "BRANCH"}},
+		Users:   append([]string{}, protection.AllowedPushUsers...),
+	})
 }
 
-// ListOpenPullRequests on Bitbucket server
-func (client *BitbucketServerClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	bitbucketClient, err := client.buildBitbucketClient(ctx)
+// GetRequiredChecks on Bitbucket server. The builds required by the merge checks of the repository are returned.
+func (client *BitbucketServerClient) GetRequiredChecks(ctx context.Context, owner, repository, branch string) (RequiredChecksInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
 	if err != nil {
-		return nil, err
+		return RequiredChecksInfo{}, err
 	}
-	var results []PullRequestInfo
-	var apiResponse *bitbucketv1.APIResponse
-	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
-		apiResponse, err = bitbucketClient.GetPullRequestsPage(owner, repository, createPaginationOptions(nextPageStart))
+	settings, err := client.getPullRequestSettings(ctx, owner, repository)
+	if err != nil {
+		return RequiredChecksInfo{}, err
+	}
+	requiredChecks := RequiredChecksInfo{AllMustPass: settings.RequiredSuccessfulBuilds > 0}
+	// Required builds are supported since Bitbucket Data Center 7.14
+	conditionsURL := fmt.Sprintf("%s/required-builds/latest/projects/%s/repos/%s/conditions", client.vcsInfo.APIEndpoint, owner, repository)
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		responseBody, err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s?start=%d", conditionsURL, nextPageStart), nil, "")
 		if err != nil {
-			return nil, err
+			return RequiredChecksInfo{}, err
 		}
-		pullRequests, err := bitbucketv1.GetPullRequestsResponse(apiResponse)
-		if err != nil {
-			return nil, err
+		var conditions bitbucketServerRequiredBuildsPage
+		if err = json.Unmarshal(responseBody, &conditions); err != nil {
+			return RequiredChecksInfo{}, err
 		}
-		for _, pullRequest := range pullRequests {
-			if pullRequest.Open {
-				results = append(results, PullRequestInfo{
-					ID: int64(pullRequest.ID),
-					Source: BranchInfo{
-						Name:       pullRequest.FromRef.ID,
-						Repository: pullRequest.FromRef.Repository.Slug},
-					Target: BranchInfo{
-						Name:       pullRequest.ToRef.ID,
-						Repository: pullRequest.ToRef.Repository.Slug},
-				})
+		for _, condition := range conditions.Values {
+			if isBitbucketServerRefMatcherOfBranch(condition.RefMatcher, branch) {
+				requiredChecks.Titles = appendUniqueOwners(requiredChecks.Titles, condition.BuildParentKeys...)
 			}
 		}
+		isLastPage, nextPageStart = conditions.IsLastPage, conditions.NextPageStart
 	}
-	return results, nil
+	return requiredChecks, nil
 }
 
-// AddPullRequestComment on Bitbucket server
-func (client *BitbucketServerClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+// GetRequiredApprovals on Bitbucket server. The approvals are required by the pull request settings of the repository, for all the branches.
+func (client *BitbucketServerClient) GetRequiredApprovals(ctx context.Context, owner, repository, branch string) (RequiredApprovalsInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
 	if err != nil {
-		return err
+		return RequiredApprovalsInfo{}, err
 	}
-	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	settings, err := client.getPullRequestSettings(ctx, owner, repository)
 	if err != nil {
-		return err
+		return RequiredApprovalsInfo{}, err
 	}
-	_, err = bitbucketClient.CreatePullRequestComment(owner, repository, pullRequestID, bitbucketv1.Comment{
-		Text: content,
-	}, []string{"application/json"})
-
-	return err
+	return RequiredApprovalsInfo{Count: settings.RequiredApprovers}, nil
 }
 
-// ListPullRequestComments on Bitbucket server
-func (client *BitbucketServerClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+// GetTagProtection on Bitbucket server. The tags are protected by a read-only restriction of the refs/tags/ pattern.
+func (client *BitbucketServerClient) GetTagProtection(ctx context.Context, owner, repository, pattern string) (*TagProtection, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pattern": pattern})
+	if err != nil {
+		return nil, err
+	}
+	restrictions, err := client.getRefRestrictions(ctx, owner, repository, bitbucketServerTagsPrefix+pattern)
+	if err != nil {
+		return nil, err
+	}
+	for _, restriction := range restrictions {
+		if restriction.Type != bitbucketServerReadOnlyRestriction {
+			continue
+		}
+		tagProtection := &TagProtection{}
+		for _, user := range restriction.Users {
+			tagProtection.AllowedUsers = append(tagProtection.AllowedUsers, user.Name)
+		}
+		return tagProtection, nil
+	}
+	return nil, nil
+}
+
+// SetTagProtection on Bitbucket server
+func (client *BitbucketServerClient) SetTagProtection(ctx context.Context, owner, repository, pattern string, protection TagProtection) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pattern": pattern})
+	if err != nil {
+		return err
+	}
+	matcherID := bitbucketServerTagsPrefix + pattern
+	restrictions, err := client.getRefRestrictions(ctx, owner, repository, matcherID)
+	if err != nil {
+		return err
+	}
+	restrictionsURL := client.getBranchRestrictionsURL(owner, repository)
+	for _, restriction := range restrictions {
+		if restriction.Type != bitbucketServerReadOnlyRestriction {
+			continue
+		}
+		client.logger.Debug("deleting restriction", restriction.ID, "of tags", pattern)
+		if _, err = client.sendRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", restrictionsURL, restriction.ID), nil, ""); err != nil {
+			return err
+		}
+	}
+	client.logger.Debug("protecting tags", pattern)
+	return client.sendJSONRequest(ctx, http.MethodPost, restrictionsURL, bitbucketServerCreateBranchRestrictionRequest{
+		Type:    bitbucketServerReadOnlyRestriction,
+		Matcher: bitbucketServerBranchMatcher{ID: matcherID, Type: bitbucketServerBranchMatcherType{ID: "PATTERN"}},
+		Users:   append([]string{}, protection.AllowedUsers...),
+	})
+}
+
+func (client *BitbucketServerClient) getPullRequestSettings(ctx context.Context, owner, repository string) (bitbucketServerPullRequestSettings, error) {
+	client.addRestSuffixToEndpoint()
+	settingsURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/settings/pull-requests", client.vcsInfo.APIEndpoint, owner, repository)
+	var settings bitbucketServerPullRequestSettings
+	responseBody, err := client.sendRequest(ctx, http.MethodGet, settingsURL, nil, "")
+	if err != nil {
+		return settings, err
+	}
+	err = json.Unmarshal(responseBody, &settings)
+	return settings, err
+}
+
+// A ref matcher of the BRANCH type matches a single branch, and a matcher of the PATTERN type matches the branches that match its wildcards.
+// Matchers of the branching model can't be resolved, so they are ignored.
+func isBitbucketServerRefMatcherOfBranch(matcher bitbucketServerBranchMatcher, branch string) bool {
+	switch matcher.Type.ID {
+	case "ANY_REF":
+		return true
+	case "BRANCH":
+		return matcher.ID == "refs/heads/"+branch
+	case "PATTERN":
+		return matchesBranchPattern(strings.TrimPrefix(matcher.ID, "refs/heads/"), branch)
+	}
+	return false
+}
+
+type bitbucketServerPullRequestSettings struct {
+	RequiredApprovers        int `json:"requiredApprovers"`
+	RequiredSuccessfulBuilds int `json:"requiredSuccessfulBuilds"`
+}
+
+type bitbucketServerRequiredBuildsPage struct {
+	Values []struct {
+		BuildParentKeys []string                     `json:"buildParentKeys"`
+		RefMatcher      bitbucketServerBranchMatcher `json:"refMatcher"`
+	} `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+func (client *BitbucketServerClient) getBranchRestrictions(ctx context.Context, owner, repository, branch string) ([]bitbucketServerBranchRestriction, error) {
+	return client.getRefRestrictions(ctx, owner, repository, "refs/heads/"+branch)
+}
+
+// getRefRestrictions returns the restrictions whose matcher ID is the ref or the pattern
+func (client *BitbucketServerClient) getRefRestrictions(ctx context.Context, owner, repository, matcherID string) ([]bitbucketServerBranchRestriction, error) {
+	restrictionsURL := fmt.Sprintf("%s?matcherId=%s", client.getBranchRestrictionsURL(owner, repository), url.QueryEscape(matcherID))
+	responseBody, err := client.sendRequest(ctx, http.MethodGet, restrictionsURL, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var restrictions bitbucketServerBranchRestrictionsPage
+	err = json.Unmarshal(responseBody, &restrictions)
+	return restrictions.Values, err
+}
+
+func (client *BitbucketServerClient) getBranchRestrictionsURL(owner, repository string) string {
+	return fmt.Sprintf("%s/branch-permissions/2.0/projects/%s/repos/%s/restrictions", client.vcsInfo.APIEndpoint, owner, repository)
+}
+
+// Restrictions of these types block pushes to the branch, except for the users listed in the restriction
+func isBitbucketServerPushRestriction(restrictionType string) bool {
+	return restrictionType == bitbucketServerReadOnlyRestriction || restrictionType == "pull-request-only"
+}
+
+const bitbucketServerReadOnlyRestriction = "read-only"
+
+// Restrictions apply to tags if their pattern matches the full ref of the tags
+const bitbucketServerTagsPrefix = "refs/tags/"
+
+type bitbucketServerBranchRestrictionsPage struct {
+	Values []bitbucketServerBranchRestriction `json:"values"`
+}
+
+type bitbucketServerBranchRestriction struct {
+	ID    int    `json:"id"`
+	Type  string `json:"type"`
+	Users []struct {
+		Name string `json:"name"`
+	} `json:"users"`
+}
+
+type bitbucketServerCreateBranchRestrictionRequest struct {
+	Type    string                       `json:"type"`
+	Matcher bitbucketServerBranchMatcher `json:"matcher"`
+	Users   []string                     `json:"users"`
+}
+
+type bitbucketServerBranchMatcher struct {
+	ID   string                           `json:"id"`
+	Type bitbucketServerBranchMatcherType `json:"type"`
+}
+
+type bitbucketServerBranchMatcherType struct {
+	ID string `json:"id"`
+}
+
+// AddSshKeyToRepository on Bitbucket server
+func (client *BitbucketServerClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
+	// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-ssh-rest.html
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"key name":   keyName,
+		"public key": publicKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	accessPermission := "REPO_READ"
+	if permission == ReadWrite {
+		accessPermission = "REPO_WRITE"
+	}
+
+	url := fmt.Sprintf("%s/keys/1.0/projects/%s/repos/%s/ssh", client.vcsInfo.APIEndpoint, owner, repository)
+	addKeyRequest := bitbucketServerAddSSHKeyRequest{
+		Key:        bitbucketServerSSHKey{Text: publicKey, Label: keyName},
+		Permission: accessPermission,
+	}
+
+	return client.sendJSONRequest(ctx, http.MethodPost, url, addKeyRequest)
+}
+
+// ListDeployKeys on Bitbucket server
+func (client *BitbucketServerClient) ListDeployKeys(ctx context.Context, owner, repository string) ([]DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	keysURL := client.getDeployKeysURL(owner, repository)
+	var results []DeployKeyInfo
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		responseBody, err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s?start=%d", keysURL, nextPageStart), nil, "")
+		if err != nil {
+			return nil, err
+		}
+		var keys bitbucketServerDeployKeysPage
+		if err = json.Unmarshal(responseBody, &keys); err != nil {
+			return nil, err
+		}
+		for _, key := range keys.Values {
+			results = append(results, mapBitbucketServerDeployKeyToDeployKeyInfo(key))
+		}
+		isLastPage, nextPageStart = keys.IsLastPage, keys.NextPageStart
+	}
+	return results, nil
+}
+
+// AddDeployKey on Bitbucket server
+func (client *BitbucketServerClient) AddDeployKey(ctx context.Context, owner, repository, title, key string, readOnly bool) (DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title, "key": key})
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	addKeyRequest := bitbucketServerDeployKey{Key: bitbucketServerSSHKey{Text: key, Label: title}, Permission: "REPO_WRITE"}
+	if readOnly {
+		addKeyRequest.Permission = "REPO_READ"
+	}
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(addKeyRequest); err != nil {
+		return DeployKeyInfo{}, err
+	}
+	responseBody, err := client.sendRequest(ctx, http.MethodPost, client.getDeployKeysURL(owner, repository), body, "application/json")
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	var deployKey bitbucketServerDeployKey
+	if err = json.Unmarshal(responseBody, &deployKey); err != nil {
+		return DeployKeyInfo{}, err
+	}
+	return mapBitbucketServerDeployKeyToDeployKeyInfo(deployKey), nil
+}
+
+// DeleteDeployKey on Bitbucket server
+func (client *BitbucketServerClient) DeleteDeployKey(ctx context.Context, owner, repository string, keyID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	_, err = client.sendRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", client.getDeployKeysURL(owner, repository), keyID), nil, "")
+	return err
+}
+
+func (client *BitbucketServerClient) getDeployKeysURL(owner, repository string) string {
+	client.addRestSuffixToEndpoint()
+	return fmt.Sprintf("%s/keys/1.0/projects/%s/repos/%s/ssh", client.vcsInfo.APIEndpoint, owner, repository)
+}
+
+type bitbucketServerDeployKeysPage struct {
+	Values        []bitbucketServerDeployKey `json:"values"`
+	IsLastPage    bool                       `json:"isLastPage"`
+	NextPageStart int                        `json:"nextPageStart"`
+}
+
+type bitbucketServerDeployKey struct {
+	Key        bitbucketServerSSHKey `json:"key"`
+	Permission string                `json:"permission"`
+}
+
+func mapBitbucketServerDeployKeyToDeployKeyInfo(key bitbucketServerDeployKey) DeployKeyInfo {
+	return DeployKeyInfo{ID: key.Key.ID, Title: key.Key.Label, Key: key.Key.Text, ReadOnly: key.Permission == "REPO_READ"}
+}
+
+// sendJSONRequest sends a request with a JSON body, for APIs that aren't supported by the Bitbucket server library
+func (client *BitbucketServerClient) sendJSONRequest(ctx context.Context, method, url string, payload interface{}) error {
+	body := new(bytes.Buffer)
+	err := json.NewEncoder(body).Encode(payload)
+	if err != nil {
+		return err
+	}
+	_, err = client.sendRequest(ctx, method, url, body, "application/json")
+	return err
+}
+
+// sendRequest sends a request and returns the response body
+func (client *BitbucketServerClient) sendRequest(ctx context.Context, method, url string, body io.Reader, contentType string) ([]byte, error) {
+	responseBody, err := client.sendStreamRequest(ctx, method, url, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = responseBody.Close() }()
+	return io.ReadAll(responseBody)
+}
+
+// sendStreamRequest sends a request and returns the response body as a stream, which must be closed by the caller
+func (client *BitbucketServerClient) sendStreamRequest(ctx context.Context, method, url string, body io.Reader, contentType string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	httpClient := client.buildHTTPClient(ctx)
+	response, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= 300 {
+		defer func() { _ = response.Body.Close() }()
+		bodyBytes, err := io.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("status: %v, body: %s", response.Status, bodyBytes)
+	}
+	return response.Body, nil
+}
+
+type bitbucketServerComment struct {
+	Version int32  `json:"version"`
+	Text    string `json:"text"`
+}
+
+type bitbucketServerUpdatePullRequest struct {
+	Version     int32               `json:"version"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	ToRef       *bitbucketServerRef `json:"toRef,omitempty"`
+}
+
+type bitbucketServerRef struct {
+	ID string `json:"id"`
+}
+
+type bitbucketServerParticipantStatus struct {
+	Status string `json:"status"`
+}
+
+type bitbucketServerAddSSHKeyRequest struct {
+	Key        bitbucketServerSSHKey `json:"key"`
+	Permission string                `json:"permission"`
+}
+
+type bitbucketServerSSHKey struct {
+	ID    int    `json:"id,omitempty"`
+	Text  string `json:"text"`
+	Label string `json:"label"`
+}
+
+// CreateWebhook on Bitbucket server
+func (client *BitbucketServerClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
+	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	token := vcsutils.CreateToken()
+	hook := createBitbucketServerHook(token, payloadURL, webhookEvents...)
+	response, err := bitbucketClient.CreateWebhook(owner, repository, hook, []string{})
+	if err != nil {
+		return "", "", err
+	}
+	webhoodID, err := getBitbucketServerWebhookID(response)
+	if err != nil {
+		return "", "", err
+	}
+	return webhoodID, token, err
+}
+
+// CreateWebhookWithOptions on Bitbucket server
+func (client *BitbucketServerClient) CreateWebhookWithOptions(ctx context.Context, owner, repository string,
+	options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	if options.contentType() != WebhookContentTypeJSON {
+		return WebhookInfo{}, "", errBitbucketWebhookFormContentTypeNotSupported
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	token := vcsutils.CreateToken()
+	response, err := bitbucketClient.CreateWebhook(owner, repository, createBitbucketServerHookWithOptions(token, options), []string{})
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	var createdHook bitbucketServerWebhook
+	if err = mapstructure.Decode(response.Values, &createdHook); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	return mapBitbucketServerWebhookToWebhookInfo(createdHook), token, nil
+}
+
+// CreateOrgWebhook on Bitbucket server creates a webhook on the project
+func (client *BitbucketServerClient) CreateOrgWebhook(ctx context.Context, owner string, options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	if options.contentType() != WebhookContentTypeJSON {
+		return WebhookInfo{}, "", errBitbucketWebhookFormContentTypeNotSupported
+	}
+	token := vcsutils.CreateToken()
+	body := new(bytes.Buffer)
+	if err := json.NewEncoder(body).Encode(createBitbucketServerHookWithOptions(token, options)); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	responseBody, err := client.sendRequest(ctx, http.MethodPost, client.getProjectWebhooksURL(owner), body, "application/json")
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	var hook bitbucketServerWebhook
+	if err = json.Unmarshal(responseBody, &hook); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	return mapBitbucketServerWebhookToWebhookInfo(hook), token, nil
+}
+
+// ListOrgWebhooks on Bitbucket server lists the webhooks of the project
+func (client *BitbucketServerClient) ListOrgWebhooks(ctx context.Context, owner string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner})
+	if err != nil {
+		return nil, err
+	}
+	webhooksURL := client.getProjectWebhooksURL(owner)
+	var webhooks []WebhookInfo
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		responseBody, err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s?start=%d", webhooksURL, nextPageStart), nil, "")
+		if err != nil {
+			return nil, err
+		}
+		var hooks bitbucketServerWebhooksPage
+		if err = json.Unmarshal(responseBody, &hooks); err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks.Values {
+			webhooks = append(webhooks, mapBitbucketServerWebhookToWebhookInfo(hook))
+		}
+		isLastPage, nextPageStart = hooks.IsLastPage, hooks.NextPageStart
+	}
+	return webhooks, nil
+}
+
+// DeleteOrgWebhook on Bitbucket server deletes a webhook of the project
+func (client *BitbucketServerClient) DeleteOrgWebhook(ctx context.Context, owner, webhookID string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "webhookID": webhookID})
+	if err != nil {
+		return err
+	}
+	_, err = client.sendRequest(ctx, http.MethodDelete, client.getProjectWebhooksURL(owner)+"/"+webhookID, nil, "")
+	return err
+}
+
+func (client *BitbucketServerClient) getProjectWebhooksURL(owner string) string {
+	client.addRestSuffixToEndpoint()
+	return fmt.Sprintf("%s/api/1.0/projects/%s/webhooks", client.vcsInfo.APIEndpoint, owner)
+}
+
+// UpdateWebhook on Bitbucket server
+func (client *BitbucketServerClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
+	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt32, err := strconv.ParseInt(webhookID, 10, 32)
+	if err != nil {
+		return err
+	}
+	hook := createBitbucketServerHook(token, payloadURL, webhookEvents...)
+	_, err = bitbucketClient.UpdateWebhook(owner, repository, int32(webhookIDInt32), hook, []string{})
+	return err
+}
+
+// DeleteWebhook on Bitbucket server
+func (client *BitbucketServerClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt32, err := strconv.ParseInt(webhookID, 10, 32)
+	if err != nil {
+		return err
+	}
+	_, err = bitbucketClient.DeleteWebhook(owner, repository, int32(webhookIDInt32))
+	return err
+}
+
+// ListWebhooks on Bitbucket server
+func (client *BitbucketServerClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := bitbucketClient.FindWebhooks(owner, repository, nil)
+	if err != nil {
+		return nil, err
+	}
+	var hooks []bitbucketServerWebhook
+	if err = mapstructure.Decode(response.Values["values"], &hooks); err != nil {
+		return nil, err
+	}
+	webhooks := make([]WebhookInfo, 0, len(hooks))
+	for _, hook := range hooks {
+		webhooks = append(webhooks, mapBitbucketServerWebhookToWebhookInfo(hook))
+	}
+	return webhooks, nil
+}
+
+// SetWebhookActive on Bitbucket server
+func (client *BitbucketServerClient) SetWebhookActive(ctx context.Context, owner, repository, webhookID string, active bool) error {
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt32, err := strconv.ParseInt(webhookID, 10, 32)
+	if err != nil {
+		return err
+	}
+	response, err := bitbucketClient.GetWebhook(owner, repository, int32(webhookIDInt32), nil)
+	if err != nil {
+		return err
+	}
+	var hook bitbucketServerWebhook
+	if err = mapstructure.Decode(response.Values, &hook); err != nil {
+		return err
+	}
+	// The configuration is left out, so the secret of the webhook is kept
+	hookUpdate := &map[string]interface{}{
+		"name":   hook.Name,
+		"url":    hook.URL,
+		"events": hook.Events,
+		"active": active,
+	}
+	if hook.SslVerificationRequired != nil {
+		(*hookUpdate)["sslVerificationRequired"] = *hook.SslVerificationRequired
+	}
+	_, err = bitbucketClient.UpdateWebhook(owner, repository, int32(webhookIDInt32), hookUpdate, []string{})
+	return err
+}
+
+// SetCommitStatus on Bitbucket server
+func (client *BitbucketServerClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, _, _, ref, title,
+	description, detailsURL string) error {
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = bitbucketClient.SetCommitStatus(ref, bitbucketv1.BuildStatus{
+		State:       getBitbucketCommitState(commitStatus),
+		Key:         title,
+		Description: description,
+		Url:         detailsURL,
+	})
+	return err
+}
+
+// SetCommitStatuses on Bitbucket server
+func (client *BitbucketServerClient) SetCommitStatuses(ctx context.Context, owner, repository, ref string, statuses []CommitStatusInfo) error {
+	return setCommitStatusesOneByOne(ctx, client, owner, repository, ref, statuses)
+}
+
+// CreateCheckRun on Bitbucket server. Bitbucket server doesn't support check runs, so a commit status is set instead.
+func (client *BitbucketServerClient) CreateCheckRun(ctx context.Context, owner, repository, ref string, checkRun CheckRunInfo) (int64, error) {
+	return 0, setCheckRunCommitStatus(ctx, client, owner, repository, ref, checkRun)
+}
+
+// UpdateCheckRun on Bitbucket server. Bitbucket server doesn't support check runs, so the commit status is set instead.
+func (client *BitbucketServerClient) UpdateCheckRun(ctx context.Context, owner, repository, ref string, _ int64, checkRun CheckRunInfo) error {
+	return setCheckRunCommitStatus(ctx, client, owner, repository, ref, checkRun)
+}
+
+// TriggerPipeline on Bitbucket server
+func (client *BitbucketServerClient) TriggerPipeline(ctx context.Context, owner, repository, pipeline, ref string, inputs map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketServerPipelinesNotSupported
+}
+
+// GetPipelineStatus on Bitbucket server
+func (client *BitbucketServerClient) GetPipelineStatus(ctx context.Context, owner, repository, runID string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketServerPipelinesNotSupported
+}
+
+// ListCommitStatuses on Bitbucket server
+func (client *BitbucketServerClient) ListCommitStatuses(ctx context.Context, _, _, ref string) ([]CommitStatusInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	client.addRestSuffixToEndpoint()
+	// The build status API of the Bitbucket server library doesn't support pagination, so the requests are sent directly
+	statusesURL := fmt.Sprintf("%s/build-status/1.0/commits/%s", client.vcsInfo.APIEndpoint, url.PathEscape(ref))
+	var results []CommitStatusInfo
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		responseBody, err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s?start=%d", statusesURL, nextPageStart), nil, "")
+		if err != nil {
+			return nil, err
+		}
+		var statuses bitbucketServerBuildStatusesPage
+		if err = json.Unmarshal(responseBody, &statuses); err != nil {
+			return nil, err
+		}
+		for _, status := range statuses.Values {
+			results = append(results, CommitStatusInfo{
+				State:         getBitbucketCommitStatus(status.State),
+				Title:         status.Key,
+				Description:   status.Description,
+				DetailsURL:    status.Url,
+				CreatedAt:     time.UnixMilli(status.DateAdded),
+				LastUpdatedAt: time.UnixMilli(status.DateAdded),
+			})
+		}
+		isLastPage, nextPageStart = statuses.IsLastPage, statuses.NextPageStart
+	}
+	return results, nil
+}
+
+type bitbucketServerBuildStatusesPage struct {
+	Values        []bitbucketv1.BuildStatus `json:"values"`
+	IsLastPage    bool                      `json:"isLastPage"`
+	NextPageStart int                       `json:"nextPageStart"`
+}
+
+// DownloadRepository on Bitbucket server
+func (client *BitbucketServerClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	return client.DownloadRepositoryAtRef(ctx, owner, repository, branch, localPath, TarGz)
+}
+
+// DownloadRepositoryAtRef on Bitbucket server
+func (client *BitbucketServerClient) DownloadRepositoryAtRef(ctx context.Context, owner, repository, ref, localPath string, format ArchiveFormat) error {
+	archive, err := client.GetRepositoryArchive(ctx, owner, repository, ref, format)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = archive.Close() }()
+	client.logger.Info(repository, "downloaded successfully, starting with repository extraction")
+	err = extractRepositoryArchive(archive, format, localPath, false, "")
+	if err != nil {
+		return err
+	}
+	client.logger.Info("extracted repository successfully")
+	// Generate .git folder with remote details
+	return vcsutils.CreateDotGitFolderWithRemote(localPath, "origin",
+		fmt.Sprintf("%s/scm/%s/%s.git", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository))
+}
+
+// GetRepositoryArchive on Bitbucket server
+func (client *BitbucketServerClient) GetRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat) (io.ReadCloser, error) {
+	return client.getRepositoryArchive(ctx, owner, repository, ref, format, "")
+}
+
+// DownloadRepositoryPath on Bitbucket server
+func (client *BitbucketServerClient) DownloadRepositoryPath(ctx context.Context, owner, repository, ref, path, localPath string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "path": path})
+	if err != nil {
+		return err
+	}
+	archive, err := client.getRepositoryArchive(ctx, owner, repository, ref, TarGz, path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = archive.Close() }()
+	client.logger.Info("extracting", path, "from", repository)
+	return extractRepositoryArchive(archive, TarGz, localPath, false, path)
+}
+
+// getRepositoryArchive returns the archive of the repository, or of a single directory of the repository if the path isn't empty
+func (client *BitbucketServerClient) getRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat, path string) (io.ReadCloser, error) {
+	client.addRestSuffixToEndpoint()
+	params := url.Values{"format": []string{"tgz"}}
+	if format == Zip {
+		params.Set("format", "zip")
+	}
+	ref = strings.TrimSpace(ref)
+	if ref != "" {
+		params.Set("at", ref)
+	}
+	if path != "" {
+		params.Set("path", path)
+	}
+	archiveURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/archive?%s", client.vcsInfo.APIEndpoint, owner, repository, params.Encode())
+	return client.sendStreamRequest(ctx, http.MethodGet, archiveURL, nil, "")
+}
+
+// CreatePullRequest on Bitbucket server
+func (client *BitbucketServerClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) error {
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	bitbucketRepo := &bitbucketv1.Repository{
+		Slug: repository,
+		Project: &bitbucketv1.Project{
+			Key: owner,
+		},
+	}
+	options := bitbucketv1.PullRequest{
+		Title:       title,
+		Description: description,
+		FromRef: bitbucketv1.PullRequestRef{
+			ID:         vcsutils.AddBranchPrefix(sourceBranch),
+			Repository: *bitbucketRepo,
+		},
+		ToRef: bitbucketv1.PullRequestRef{
+			ID:         vcsutils.AddBranchPrefix(targetBranch),
+			Repository: *bitbucketRepo,
+		},
+	}
+	_, err = bitbucketClient.CreatePullRequest(owner, repository, options)
+	return err
+}
+
+// CreateDraftPullRequest on Bitbucket server
+func (client *BitbucketServerClient) CreateDraftPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) error {
+	return errBitbucketServerDraftPullRequestNotSupported
+}
+
+// MarkPullRequestReady on Bitbucket server
+func (client *BitbucketServerClient) MarkPullRequestReady(ctx context.Context, owner, repository string, pullRequestID int) error {
+	return errBitbucketServerDraftPullRequestNotSupported
+}
+
+// MergePullRequest on Bitbucket server
+func (client *BitbucketServerClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int,
+	mergeStrategy MergeStrategy, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	// The current version of the pull request is required in order to merge it
+	response, err := bitbucketClient.GetPullRequest(owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(response)
+	if err != nil {
+		return err
+	}
+	mergeOptions := map[string]string{"strategyId": getBitbucketServerMergeStrategyID(mergeStrategy)}
+	if commitMessage != "" {
+		mergeOptions["message"] = commitMessage
+	}
+	client.logger.Debug("merging pull request:", pullRequestID)
+	_, err = bitbucketClient.Merge(owner, repository, pullRequestID, map[string]interface{}{"version": pullRequest.Version},
+		mergeOptions, []string{"application/json"})
+	return err
+}
+
+// EnablePullRequestAutoMerge on Bitbucket server. Auto-merge requires Bitbucket Data Center 8.15 or later.
+func (client *BitbucketServerClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("enabling auto-merge of pull request:", pullRequestID)
+	return client.sendJSONRequest(ctx, http.MethodPost, client.getAutoMergeURL(owner, repository, pullRequestID),
+		bitbucketServerAutoMergeRequest{StrategyID: getBitbucketServerMergeStrategyID(mergeStrategy)})
+}
+
+// DisablePullRequestAutoMerge on Bitbucket server
+func (client *BitbucketServerClient) DisablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("disabling auto-merge of pull request:", pullRequestID)
+	_, err = client.sendRequest(ctx, http.MethodDelete, client.getAutoMergeURL(owner, repository, pullRequestID), nil, "")
+	return err
+}
+
+func (client *BitbucketServerClient) getAutoMergeURL(owner, repository string, pullRequestID int) string {
+	client.addRestSuffixToEndpoint()
+	return fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/pull-requests/%d/auto-merge", client.vcsInfo.APIEndpoint, owner, repository, pullRequestID)
+}
+
+type bitbucketServerAutoMergeRequest struct {
+	StrategyID string `json:"strategyId"`
+}
+
+// UpdatePullRequest on Bitbucket server
+func (client *BitbucketServerClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranch string,
+	pullRequestID int, state *PullRequestState) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validatePullRequestState(state); err != nil {
+		return err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Every change to the pull request requires its current version
+	response, err := bitbucketClient.GetPullRequest(owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(response)
+	if err != nil {
+		return err
+	}
+	// A declined pull request must be reopened before it can be edited
+	if state != nil && *state == PullRequestOpen && pullRequest.State == "DECLINED" {
+		client.logger.Debug("reopening pull request:", pullRequestID)
+		response, err = bitbucketClient.Reopen(owner, repository, int64(pullRequestID), map[string]interface{}{"version": pullRequest.Version})
+		if err != nil {
+			return err
+		}
+		if pullRequest, err = bitbucketv1.GetPullRequestResponse(response); err != nil {
+			return err
+		}
+	}
+	if title != "" || body != "" || targetBranch != "" {
+		if pullRequest, err = client.editPullRequest(ctx, owner, repository, title, body, targetBranch, pullRequest); err != nil {
+			return err
+		}
+	}
+	if state != nil && *state == PullRequestClosed && pullRequest.Open {
+		client.logger.Debug("declining pull request:", pullRequestID)
+		_, err = bitbucketClient.Decline(owner, repository, int64(pullRequestID), map[string]interface{}{"version": pullRequest.Version})
+	}
+	return err
+}
+
+// editPullRequest updates the title, description and target branch of a pull request, and returns the updated pull request
+func (client *BitbucketServerClient) editPullRequest(ctx context.Context, owner, repository, title, body, targetBranch string,
+	pullRequest bitbucketv1.PullRequest) (bitbucketv1.PullRequest, error) {
+	// The title and description are replaced, so unchanged values are taken from the current pull request
+	payload := bitbucketServerUpdatePullRequest{
+		Version:     pullRequest.Version,
+		Title:       pullRequest.Title,
+		Description: pullRequest.Description,
+	}
+	if title != "" {
+		payload.Title = title
+	}
+	if body != "" {
+		payload.Description = body
+	}
+	if targetBranch != "" {
+		payload.ToRef = &bitbucketServerRef{ID: vcsutils.AddBranchPrefix(targetBranch)}
+	}
+	requestBody := new(bytes.Buffer)
+	if err := json.NewEncoder(requestBody).Encode(payload); err != nil {
+		return bitbucketv1.PullRequest{}, err
+	}
+	client.logger.Debug("updating pull request:", pullRequest.ID)
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/pull-requests/%d", client.vcsInfo.APIEndpoint, owner, repository, pullRequest.ID)
+	responseBody, err := client.sendRequest(ctx, http.MethodPut, url, requestBody, "application/json")
+	if err != nil {
+		return bitbucketv1.PullRequest{}, err
+	}
+	var updatedPullRequest bitbucketv1.PullRequest
+	err = json.Unmarshal(responseBody, &updatedPullRequest)
+	return updatedPullRequest, err
+}
+
+// ApprovePullRequest on Bitbucket server
+func (client *BitbucketServerClient) ApprovePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	return client.SubmitPullRequestReview(ctx, owner, repository, pullRequestID, ReviewApprove, "")
+}
+
+// SubmitPullRequestReview on Bitbucket server
+func (client *BitbucketServerClient) SubmitPullRequestReview(ctx context.Context, owner, repository string, pullRequestID int,
+	reviewEvent ReviewEvent, body string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewBody(reviewEvent, body); err != nil {
+		return err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	switch reviewEvent {
+	case ReviewApprove:
+		client.logger.Debug("approving pull request:", pullRequestID)
+		_, err = bitbucketClient.Approve(owner, repository, int64(pullRequestID))
+	case ReviewRequestChanges:
+		// The participant status is set by the user slug, which can't be taken from the access token
+		if client.vcsInfo.Username == "" {
+			return errBitbucketServerReviewUsernameRequired
+		}
+		client.logger.Debug("requesting changes on pull request:", pullRequestID)
+		url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/pull-requests/%d/participants/%s",
+			client.vcsInfo.APIEndpoint, owner, repository, pullRequestID, client.vcsInfo.Username)
+		err = client.sendJSONRequest(ctx, http.MethodPut, url, bitbucketServerParticipantStatus{Status: "NEEDS_WORK"})
+	}
+	if err != nil {
+		return err
+	}
+	// Bitbucket approvals have no body, so the review body is added as a comment
+	if body != "" {
+		return client.AddPullRequestComment(ctx, owner, repository, body, pullRequestID)
+	}
+	return nil
+}
+
+// ListOpenPullRequests on Bitbucket server
+func (client *BitbucketServerClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestInfo
+	var apiResponse *bitbucketv1.APIResponse
+	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		apiResponse, err = bitbucketClient.GetPullRequestsPage(owner, repository, createPaginationOptions(nextPageStart))
+		if err != nil {
+			return nil, err
+		}
+		pullRequests, err := bitbucketv1.GetPullRequestsResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range pullRequests {
+			if pullRequest.Open {
+				results = append(results, PullRequestInfo{
+					ID: int64(pullRequest.ID),
+					Source: BranchInfo{
+						Name:       pullRequest.FromRef.ID,
+						Repository: pullRequest.FromRef.Repository.Slug},
+					Target: BranchInfo{
+						Name:       pullRequest.ToRef.ID,
+						Repository: pullRequest.ToRef.Repository.Slug},
+				})
+			}
+		}
+	}
+	return results, nil
+}
+
+// ListOpenPullRequestsWithFilter on Bitbucket server
+func (client *BitbucketServerClient) ListOpenPullRequestsWithFilter(ctx context.Context, owner, repository string, filter PullRequestFilter) ([]PullRequestInfo, error) {
+	return listPageOrAll(ctx, client.ListOpenPullRequestsPager(owner, repository, filter), filter.Page)
+}
+
+// ListOpenPullRequestsPager on Bitbucket server
+func (client *BitbucketServerClient) ListOpenPullRequestsPager(owner, repository string, filter PullRequestFilter) *Pager[PullRequestInfo] {
+	pageSize := filter.PerPage
+	if pageSize == 0 {
+		pageSize = bitbucketServerDefaultPageSize
+	}
+	options := map[string]interface{}{"state": "OPEN", "limit": pageSize, "start": (firstPage(filter.Page) - 1) * pageSize}
+	if filter.TargetBranch != "" {
+		options["at"] = vcsutils.AddBranchPrefix(filter.TargetBranch)
+	}
+	return newPager(func(ctx context.Context) ([]PullRequestInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		if filter.Label != "" {
+			return nil, false, errLabelsNotSupported
+		}
+		bitbucketClient, err := client.buildBitbucketClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		apiResponse, err := bitbucketClient.GetPullRequestsPage(owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		pullRequests, err := bitbucketv1.GetPullRequestsResponse(apiResponse)
+		if err != nil {
+			return nil, false, err
+		}
+		var results []PullRequestInfo
+		for _, pullRequest := range pullRequests {
+			pullRequestInfo := mapBitbucketServerPullRequestToPullRequestInfo(pullRequest)
+			if filter.matches(pullRequestInfo, time.UnixMilli(pullRequest.UpdatedDate)) {
+				results = append(results, pullRequestInfo)
+			}
+		}
+		hasNextPage, nextPageStart := bitbucketv1.HasNextPage(apiResponse)
+		options["start"] = nextPageStart
+		return results, hasNextPage, nil
+	})
+}
+
+// GetPullRequestByID on Bitbucket server
+func (client *BitbucketServerClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	client.logger.Debug("fetching pull request", pullRequestID, "in", repository)
+	response, err := bitbucketClient.GetPullRequest(owner, repository, pullRequestID)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(response)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapBitbucketServerPullRequestToPullRequestInfo(pullRequest), nil
+}
+
+// GetPullRequestDetails on Bitbucket server
+func (client *BitbucketServerClient) GetPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestDetails, error) {
+	return getPullRequestDetails(ctx, client, owner, repository, pullRequestID, true)
+}
+
+// GetPullRequestMergeableState on Bitbucket server. The merge checks of Bitbucket Server, such as the required approvals, are reflected in Mergeable only.
+func (client *BitbucketServerClient) GetPullRequestMergeableState(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestMergeableState, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	apiResponse, err := bitbucketClient.CanMerge(owner, repository, int64(pullRequestID))
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	var mergeResponse bitbucketv1.MergeGetResponse
+	if err = mapstructure.Decode(apiResponse.Values, &mergeResponse); err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	return PullRequestMergeableState{
+		Mergeable:    mergeResponse.CanMerge && !mergeResponse.Conflicted,
+		HasConflicts: mergeResponse.Conflicted,
+	}, nil
+}
+
+// ListPullRequestFiles on Bitbucket server
+func (client *BitbucketServerClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	changesURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/pull-requests/%d/changes", client.vcsInfo.APIEndpoint, owner, repository, pullRequestID)
+	return client.getChanges(ctx, changesURL, url.Values{})
+}
+
+// getChanges gets all the pages of a changes API.
+// The changes APIs of the Bitbucket server library don't support pagination, so the requests are sent directly.
+func (client *BitbucketServerClient) getChanges(ctx context.Context, changesURL string, query url.Values) ([]PullRequestFile, error) {
+	var results []PullRequestFile
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		query.Set("start", strconv.Itoa(nextPageStart))
+		responseBody, err := client.sendRequest(ctx, http.MethodGet, changesURL+"?"+query.Encode(), nil, "")
+		if err != nil {
+			return nil, err
+		}
+		var changes bitbucketServerChangesPage
+		if err = json.Unmarshal(responseBody, &changes); err != nil {
+			return nil, err
+		}
+		for _, change := range changes.Values {
+			file := PullRequestFile{Path: change.Path.ToString, Status: getBitbucketServerFileStatus(change.Type)}
+			if file.Status == FileRenamed {
+				file.PreviousPath = change.SrcPath.ToString
+			}
+			results = append(results, file)
+		}
+		isLastPage, nextPageStart = changes.IsLastPage, changes.NextPageStart
+	}
+	return results, nil
+}
+
+type bitbucketServerChangesPage struct {
+	Values []struct {
+		Type    string              `json:"type"`
+		Path    bitbucketServerPath `json:"path"`
+		SrcPath bitbucketServerPath `json:"srcPath"`
+	} `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+type bitbucketServerPath struct {
+	ToString string `json:"toString"`
+}
+
+// GetPullRequestDiff on Bitbucket server
+func (client *BitbucketServerClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return "", err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	response, err := bitbucketClient.GetPullRequestDiffRaw(owner, repository, pullRequestID, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(response.Payload), nil
+}
+
+// ListPullRequestCommits on Bitbucket server
+func (client *BitbucketServerClient) ListPullRequestCommits(ctx context.Context, owner, repository string, pullRequestID int) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitInfo
+	var apiResponse *bitbucketv1.APIResponse
+	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		apiResponse, err = bitbucketClient.GetPullRequestCommitsWithOptions(owner, repository, pullRequestID, createPaginationOptions(nextPageStart))
+		if err != nil {
+			return nil, err
+		}
+		commits, err := bitbucketv1.GetCommitsResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			results = append(results, client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository))
+		}
+	}
+	return results, nil
+}
+
+// GetDefaultReviewers on Bitbucket server
+func (client *BitbucketServerClient) GetDefaultReviewers(ctx context.Context, owner, repository, sourceBranch, targetBranch string) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "source branch": sourceBranch, "target branch": targetBranch})
+	if err != nil {
+		return nil, err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := bitbucketClient.GetRepository(owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	var repositoryDetails bitbucketServerRepository
+	if err = mapstructure.Decode(repo.Values, &repositoryDetails); err != nil {
+		return nil, err
+	}
+	// Pull requests are created within the repository, so it's both the source and the target repository
+	query := url.Values{
+		"sourceRepoId": []string{strconv.Itoa(repositoryDetails.ID)},
+		"targetRepoId": []string{strconv.Itoa(repositoryDetails.ID)},
+		"sourceRefId":  []string{"refs/heads/" + sourceBranch},
+		"targetRefId":  []string{"refs/heads/" + targetBranch},
+	}
+	reviewersURL := fmt.Sprintf("%s/default-reviewers/1.0/projects/%s/repos/%s/reviewers?%s", client.vcsInfo.APIEndpoint, owner, repository, query.Encode())
+	responseBody, err := client.sendRequest(ctx, http.MethodGet, reviewersURL, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var users []struct {
+		Name string `json:"name"`
+	}
+	if err = json.Unmarshal(responseBody, &users); err != nil {
+		return nil, err
+	}
+	reviewers := make([]string, 0, len(users))
+	for _, user := range users {
+		reviewers = append(reviewers, user.Name)
+	}
+	return reviewers, nil
+}
+
+// AddPullRequestComment on Bitbucket server
+func (client *BitbucketServerClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = bitbucketClient.CreatePullRequestComment(owner, repository, pullRequestID, bitbucketv1.Comment{
+		Text: content,
+	}, []string{"application/json"})
+
+	return err
+}
+
+// ListPullRequestComments on Bitbucket server
+func (client *BitbucketServerClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
 	bitbucketClient, err := client.buildBitbucketClient(ctx)
 	if err != nil {
 		return nil, err
@@ -391,6 +1708,147 @@ func (client *BitbucketServerClient) ListPullRequestComments(ctx context.Context
 	return results, nil
 }
 
+// UpdatePullRequestComment on Bitbucket server
+func (client *BitbucketServerClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	if _, err = client.buildBitbucketClient(ctx); err != nil {
+		return err
+	}
+	comment, err := client.getPullRequestComment(ctx, owner, repository, pullRequestID, commentID)
+	if err != nil {
+		return err
+	}
+	// The Bitbucket server library doesn't send the comment when updating it, so the request is sent directly
+	client.logger.Debug("updating comment on pull request:", pullRequestID)
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%d", client.vcsInfo.APIEndpoint, owner, repository, pullRequestID, commentID)
+	return client.sendJSONRequest(ctx, http.MethodPut, url, bitbucketServerComment{Version: comment.Version, Text: content})
+}
+
+// DeletePullRequestComment on Bitbucket server
+func (client *BitbucketServerClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if _, err = client.buildBitbucketClient(ctx); err != nil {
+		return err
+	}
+	comment, err := client.getPullRequestComment(ctx, owner, repository, pullRequestID, commentID)
+	if err != nil {
+		return err
+	}
+	// The Bitbucket server library fails to parse the empty response of the deletion, so the request is sent directly
+	client.logger.Debug("deleting comment on pull request:", pullRequestID)
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%d?version=%d",
+		client.vcsInfo.APIEndpoint, owner, repository, pullRequestID, commentID, comment.Version)
+	_, err = client.sendRequest(ctx, http.MethodDelete, url, nil, "")
+	return err
+}
+
+// AddCommentReaction on Bitbucket server
+func (client *BitbucketServerClient) AddCommentReaction(ctx context.Context, owner, repository string, pullRequestID int, commentID int64, reaction Reaction) error {
+	return errBitbucketCommentReactionsNotSupported
+}
+
+// ListCommentReactions on Bitbucket server
+func (client *BitbucketServerClient) ListCommentReactions(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) ([]ReactionInfo, error) {
+	return nil, errBitbucketCommentReactionsNotSupported
+}
+
+// getPullRequestComment returns a pull request comment with its current version, which is required to change the comment
+func (client *BitbucketServerClient) getPullRequestComment(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) (bitbucketServerComment, error) {
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%d", client.vcsInfo.APIEndpoint, owner, repository, pullRequestID, commentID)
+	responseBody, err := client.sendRequest(ctx, http.MethodGet, url, nil, "")
+	if err != nil {
+		return bitbucketServerComment{}, err
+	}
+	var comment bitbucketServerComment
+	err = json.Unmarshal(responseBody, &comment)
+	return comment, err
+}
+
+// AddPullRequestReviewComment on Bitbucket server
+func (client *BitbucketServerClient) AddPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int,
+	comment PullRequestReviewComment) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewComment(comment); err != nil {
+		return err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Bitbucket server anchors comments to a single line, so the comment is anchored to the last line of the range.
+	// The anchor is set on an added line in the new version of the file.
+	client.logger.Debug("adding diff comment on pull request:", pullRequestID)
+	_, err = bitbucketClient.CreatePullRequestComment(owner, repository, pullRequestID, bitbucketv1.Comment{
+		Text: comment.Content,
+		Anchor: &bitbucketv1.Anchor{
+			DiffType: bitbucketv1.DiffTypeEffective,
+			Line:     comment.Line,
+			LineType: bitbucketv1.LineTypeAdded,
+			FileType: bitbucketv1.FileTypeTo,
+			Path:     comment.Path,
+		},
+	}, []string{"application/json"})
+	return err
+}
+
+// ListPullRequestReviewComments on Bitbucket server
+func (client *BitbucketServerClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewComment, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestReviewComment
+	var apiResponse *bitbucketv1.APIResponse
+	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		apiResponse, err = bitbucketClient.GetActivities(owner, repository, int64(pullRequestID), createPaginationOptions(nextPageStart))
+		if err != nil {
+			return nil, err
+		}
+		activities, err := bitbucketv1.GetActivitiesResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, activity := range activities.Values {
+			// Only new comments that are anchored to a file are review comments
+			if activity.Action == "COMMENTED" && activity.CommentAction == "ADDED" && activity.CommentAnchor.Path != "" {
+				results = append(results, PullRequestReviewComment{
+					CommentInfo: CommentInfo{
+						ID:      int64(activity.Comment.ID),
+						Created: time.UnixMilli(activity.Comment.CreatedDate),
+						Content: activity.Comment.Text,
+					},
+					Path: activity.CommentAnchor.Path,
+					Line: activity.CommentAnchor.Line,
+				})
+			}
+		}
+	}
+	return results, nil
+}
+
+// CreatePullRequestThread on Bitbucket server
+func (client *BitbucketServerClient) CreatePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (string, error) {
+	return "", errBitbucketPullRequestThreadsNotSupported
+}
+
+// ResolvePullRequestThread on Bitbucket server
+func (client *BitbucketServerClient) ResolvePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	return errBitbucketPullRequestThreadsNotSupported
+}
+
 type projectsResponse struct {
 	Values []struct {
 		Key string `json:"key,omitempty"`
@@ -447,32 +1905,245 @@ func (client *BitbucketServerClient) GetRepositoryInfo(ctx context.Context, owne
 	if err != nil {
 		return RepositoryInfo{}, err
 	}
+	var repositoryDetails bitbucketServerRepository
+	if err = mapstructure.Decode(repo.Values, &repositoryDetails); err != nil {
+		return RepositoryInfo{}, err
+	}
+	info := mapBitbucketServerRepositoryToRepositoryInfo(repositoryDetails)
+	// The default branch of an empty repository isn't found
+	defaultBranchResponse, err := bitbucketClient.GetDefaultBranch(owner, repository)
+	if err != nil {
+		if defaultBranchResponse == nil || defaultBranchResponse.StatusCode != http.StatusNotFound {
+			return RepositoryInfo{}, err
+		}
+		return info, nil
+	}
+	defaultBranch, err := bitbucketv1.GetBranchResponse(defaultBranchResponse)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	info.DefaultBranch = defaultBranch.DisplayID
+	return info, nil
+}
+
+// GetRepositoryLanguages on Bitbucket server
+func (client *BitbucketServerClient) GetRepositoryLanguages(_ context.Context, _, _ string) (map[string]float64, error) {
+	return nil, errBitbucketServerLanguagesNotSupported
+}
 
-	holder := struct {
-		Links struct {
-			Clone []struct {
-				Name string `mapstructure:"name"`
-				HRef string `mapstructure:"href"`
-			} `mapstructure:"clone"`
-		} `mapstructure:"links"`
-		Public bool `mapstructure:"public"`
-	}{}
+// CreateRepository on Bitbucket server
+func (client *BitbucketServerClient) CreateRepository(ctx context.Context, owner, name string, options CreateRepositoryOptions) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "name": name})
+	if err != nil {
+		return err
+	}
+	if err = validateBitbucketCreateRepositoryOptions(options); err != nil {
+		return err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = bitbucketClient.CreateRepository(owner, bitbucketv1.Repository{
+		Name:        name,
+		Description: options.Description,
+		ScmID:       "git",
+		Public:      !options.isPrivate(),
+	})
+	return err
+}
 
-	if err := mapstructure.Decode(repo.Values, &holder); err != nil {
-		return RepositoryInfo{}, err
+// DeleteRepository on Bitbucket server
+func (client *BitbucketServerClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
 	}
+	// Bitbucket server accepts the deletion without a response body, which the Bitbucket server library fails to parse
+	client.addRestSuffixToEndpoint()
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s", client.vcsInfo.APIEndpoint, owner, repository)
+	_, err = client.sendRequest(ctx, http.MethodDelete, url, nil, "")
+	return err
+}
 
-	var info CloneInfo
-	for _, cloneLink := range holder.Links.Clone {
-		switch cloneLink.Name {
-		case "http":
-			info.HTTP = cloneLink.HRef
-		case "ssh":
-			info.SSH = cloneLink.HRef
+// ForkRepository on Bitbucket server
+func (client *BitbucketServerClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string, waitUntilReady bool) (ForkInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	// Without a project, Bitbucket server forks the repository into the personal project of the user
+	forkRequest := bitbucketServerForkRequest{}
+	if targetOwner != "" {
+		forkRequest.Project = &bitbucketServerProject{Key: targetOwner}
+	}
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(forkRequest); err != nil {
+		return ForkInfo{}, err
+	}
+	client.addRestSuffixToEndpoint()
+	forkURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s", client.vcsInfo.APIEndpoint, owner, repository)
+	responseBody, err := client.sendRequest(ctx, http.MethodPost, forkURL, body, "application/json")
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	var fork bitbucketServerRepository
+	if err = json.Unmarshal(responseBody, &fork); err != nil {
+		return ForkInfo{}, err
+	}
+	forkInfo := ForkInfo{Owner: fork.Project.Key, Repository: fork.Slug, RepositoryInfo: mapBitbucketServerRepositoryToRepositoryInfo(fork)}
+	if !waitUntilReady {
+		return forkInfo, nil
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	// Bitbucket server copies the content of the fork in the background
+	err = pollUntilReady(ctx, func() (bool, error) {
+		repo, err := bitbucketClient.GetRepository(fork.Project.Key, fork.Slug)
+		if err != nil {
+			return false, err
+		}
+		if err = mapstructure.Decode(repo.Values, &fork); err != nil {
+			return false, err
+		}
+		switch fork.State {
+		case "INITIALISATION_FAILED":
+			return false, fmt.Errorf("failed to fork %s/%s", owner, repository)
+		case "INITIALISING":
+			return false, nil
+		default:
+			return true, nil
+		}
+	})
+	return forkInfo, err
+}
+
+type bitbucketServerForkRequest struct {
+	Project *bitbucketServerProject `json:"project,omitempty"`
+}
+
+type bitbucketServerProject struct {
+	Key string `json:"key" mapstructure:"key"`
+}
+
+type bitbucketServerRepository struct {
+	ID      int                    `json:"id" mapstructure:"id"`
+	Slug    string                 `json:"slug" mapstructure:"slug"`
+	State   string                 `json:"state" mapstructure:"state"`
+	Public  bool                   `json:"public" mapstructure:"public"`
+	Project bitbucketServerProject `json:"project" mapstructure:"project"`
+	// Repositories can be archived since Bitbucket server 8.0
+	Archived    bool   `json:"archived" mapstructure:"archived"`
+	Description string `json:"description" mapstructure:"description"`
+	Links       struct {
+		Clone []struct {
+			Name string `json:"name" mapstructure:"name"`
+			HRef string `json:"href" mapstructure:"href"`
+		} `json:"clone" mapstructure:"clone"`
+	} `json:"links" mapstructure:"links"`
+}
+
+// ListCollaborators on Bitbucket server. Only the permissions granted to users directly on the repository are returned.
+func (client *BitbucketServerClient) ListCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := client.getUserPermissions(ctx, owner, repository, "")
+	if err != nil {
+		return nil, err
+	}
+	results := make([]CollaboratorInfo, 0, len(permissions))
+	for _, permission := range permissions {
+		results = append(results, CollaboratorInfo{Username: permission.User.Name, Permission: mapBitbucketServerPermissionToRepositoryPermission(permission.Permission)})
+	}
+	return results, nil
+}
+
+// AddCollaborator on Bitbucket server
+func (client *BitbucketServerClient) AddCollaborator(ctx context.Context, owner, repository, username string, permission RepositoryPermission) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return err
+	}
+	if err = validateCollaboratorPermission(permission); err != nil {
+		return err
+	}
+	query := url.Values{"name": {username}, "permission": {getBitbucketServerPermissionName(permission)}}
+	_, err = client.sendRequest(ctx, http.MethodPut, client.getUserPermissionsURL(owner, repository)+"?"+query.Encode(), nil, "")
+	return err
+}
+
+// RemoveCollaborator on Bitbucket server
+func (client *BitbucketServerClient) RemoveCollaborator(ctx context.Context, owner, repository, username string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return err
+	}
+	query := url.Values{"name": {username}}
+	_, err = client.sendRequest(ctx, http.MethodDelete, client.getUserPermissionsURL(owner, repository)+"?"+query.Encode(), nil, "")
+	return err
+}
+
+// GetUserPermission on Bitbucket server. Only the permission granted to the user directly on the repository is returned.
+func (client *BitbucketServerClient) GetUserPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return PermissionNone, err
+	}
+	// The filter matches users by a part of their name
+	permissions, err := client.getUserPermissions(ctx, owner, repository, username)
+	if err != nil {
+		return PermissionNone, err
+	}
+	for _, permission := range permissions {
+		if strings.EqualFold(permission.User.Name, username) {
+			return mapBitbucketServerPermissionToRepositoryPermission(permission.Permission), nil
+		}
+	}
+	return PermissionNone, nil
+}
+
+func (client *BitbucketServerClient) getUserPermissions(ctx context.Context, owner, repository, filter string) ([]bitbucketServerUserPermission, error) {
+	permissionsURL := client.getUserPermissionsURL(owner, repository)
+	var results []bitbucketServerUserPermission
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		query := url.Values{"start": {strconv.Itoa(nextPageStart)}}
+		if filter != "" {
+			query.Set("filter", filter)
+		}
+		responseBody, err := client.sendRequest(ctx, http.MethodGet, permissionsURL+"?"+query.Encode(), nil, "")
+		if err != nil {
+			return nil, err
 		}
+		var permissions bitbucketServerUserPermissionsPage
+		if err = json.Unmarshal(responseBody, &permissions); err != nil {
+			return nil, err
+		}
+		results = append(results, permissions.Values...)
+		isLastPage, nextPageStart = permissions.IsLastPage, permissions.NextPageStart
 	}
+	return results, nil
+}
 
-	return RepositoryInfo{RepositoryVisibility: getBitbucketServerRepositoryVisibility(holder.Public), CloneInfo: info}, nil
+func (client *BitbucketServerClient) getUserPermissionsURL(owner, repository string) string {
+	client.addRestSuffixToEndpoint()
+	return fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/permissions/users", client.vcsInfo.APIEndpoint, owner, repository)
+}
+
+type bitbucketServerUserPermissionsPage struct {
+	Values        []bitbucketServerUserPermission `json:"values"`
+	IsLastPage    bool                            `json:"isLastPage"`
+	NextPageStart int                             `json:"nextPageStart"`
+}
+
+type bitbucketServerUserPermission struct {
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
+	Permission string `json:"permission"`
 }
 
 // GetCommitBySha on Bitbucket server
@@ -485,22 +2156,172 @@ func (client BitbucketServerClient) GetCommitBySha(ctx context.Context, owner, r
 	if err != nil {
 		return CommitInfo{}, err
 	}
-
+
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	apiResponse, err := bitbucketClient.GetCommit(owner, repository, sha, nil)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	commit := bitbucketv1.Commit{}
+	err = unmarshalAPIResponseValues(apiResponse, &commit)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository), nil
+}
+
+// ListCommits on Bitbucket server. Bitbucket server doesn't filter the commits by author or date, so these filters are applied to each fetched page.
+func (client *BitbucketServerClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	return listPageOrAll(ctx, client.listCommitsPager(owner, repository, options), options.Page)
+}
+
+func (client *BitbucketServerClient) listCommitsPager(owner, repository string, options ListCommitsOptions) *Pager[CommitInfo] {
+	pageSize := options.PerPage
+	if pageSize == 0 {
+		pageSize = bitbucketServerDefaultPageSize
+	}
+	requestOptions := map[string]interface{}{"limit": pageSize, "start": (firstPage(options.Page) - 1) * pageSize}
+	if options.Branch != "" {
+		requestOptions["until"] = options.Branch
+	}
+	if options.Path != "" {
+		requestOptions["path"] = options.Path
+	}
+	return newPager(func(ctx context.Context) ([]CommitInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		bitbucketClient, err := client.buildBitbucketClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		apiResponse, err := bitbucketClient.GetCommits(owner, repository, requestOptions)
+		if err != nil {
+			return nil, false, err
+		}
+		commits, err := bitbucketv1.GetCommitsResponse(apiResponse)
+		if err != nil {
+			return nil, false, err
+		}
+		var results []CommitInfo
+		for _, commit := range commits {
+			if options.matches(time.UnixMilli(commit.CommitterTimestamp), commit.Author.Name, commit.Author.EmailAddress, commit.Author.Slug) {
+				results = append(results, client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository))
+			}
+		}
+		hasNextPage, nextPageStart := bitbucketv1.HasNextPage(apiResponse)
+		requestOptions["start"] = nextPageStart
+		return results, hasNextPage, nil
+	})
+}
+
+// GetCommit on Bitbucket server. Bitbucket server doesn't return the number of added and deleted lines.
+func (client *BitbucketServerClient) GetCommit(ctx context.Context, owner, repository, sha string) (CommitDetails, error) {
+	commitInfo, err := client.GetCommitBySha(ctx, owner, repository, sha)
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	// GetCommitBySha has a value receiver, so the suffix it adds to the endpoint is added here as well
+	client.addRestSuffixToEndpoint()
+	changesURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/commits/%s/changes", client.vcsInfo.APIEndpoint, owner, repository, url.PathEscape(sha))
+	files, err := client.getChanges(ctx, changesURL, url.Values{})
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	return CommitDetails{CommitInfo: commitInfo, Files: files}, nil
+}
+
+// GetCommitVerification on Bitbucket server
+func (client *BitbucketServerClient) GetCommitVerification(ctx context.Context, owner, repository, sha string) (CommitVerificationInfo, error) {
+	return CommitVerificationInfo{}, errBitbucketCommitVerificationNotSupported
+}
+
+// CompareCommits on Bitbucket server
+func (client *BitbucketServerClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	commits, err := client.getCommitsBetween(bitbucketClient, owner, repository, base, head)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	behindCommits, err := client.getCommitsBetween(bitbucketClient, owner, repository, head, base)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	// The compare API returns the changes in the "from" ref that aren't in the "to" ref
+	changesURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/compare/changes", client.vcsInfo.APIEndpoint, owner, repository)
+	files, err := client.getChanges(ctx, changesURL, url.Values{"from": {head}, "to": {base}})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	return CommitsComparison{AheadBy: len(commits), BehindBy: len(behindCommits), Commits: commits, Files: files}, nil
+}
+
+// GetCommitsBetween on Bitbucket server
+func (client *BitbucketServerClient) GetCommitsBetween(ctx context.Context, owner, repository, fromSha, toSha string) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"fromSha":    fromSha,
+		"toSha":      toSha,
+	})
+	if err != nil {
+		return nil, err
+	}
 	bitbucketClient, err := client.buildBitbucketClient(ctx)
 	if err != nil {
-		return CommitInfo{}, err
+		return nil, err
 	}
+	return client.getCommitsBetween(bitbucketClient, owner, repository, fromSha, toSha)
+}
 
-	apiResponse, err := bitbucketClient.GetCommit(owner, repository, sha, nil)
-	if err != nil {
-		return CommitInfo{}, err
-	}
-	commit := bitbucketv1.Commit{}
-	err = unmarshalAPIResponseValues(apiResponse, &commit)
-	if err != nil {
-		return CommitInfo{}, err
+// ListContributors on Bitbucket server. The contributors are counted from the commits, since Bitbucket server doesn't provide a contributors API.
+func (client *BitbucketServerClient) ListContributors(ctx context.Context, owner, repository string) ([]ContributorInfo, error) {
+	return listContributorsFromCommits(ctx, client, owner, repository)
+}
+
+// GetCommitActivity on Bitbucket server. The activity is counted from the commits, since Bitbucket server doesn't provide commit statistics.
+func (client *BitbucketServerClient) GetCommitActivity(ctx context.Context, owner, repository string, since time.Time) ([]CommitActivityInfo, error) {
+	return getCommitActivityFromCommits(ctx, client, owner, repository, since)
+}
+
+// getCommitsBetween gets the commits that are reachable from until, but not from since
+func (client *BitbucketServerClient) getCommitsBetween(bitbucketClient *bitbucketv1.DefaultApiService, owner, repository, since, until string) ([]CommitInfo, error) {
+	var results []CommitInfo
+	var apiResponse *bitbucketv1.APIResponse
+	var err error
+	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		options := createPaginationOptions(nextPageStart)
+		options["since"], options["until"] = since, until
+		apiResponse, err = bitbucketClient.GetCommits(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		commits, err := bitbucketv1.GetCommitsResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			results = append(results, client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository))
+		}
 	}
-	return client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository), nil
+	return results, nil
 }
 
 // CreateLabel on Bitbucket server
@@ -513,6 +2334,28 @@ func (client *BitbucketServerClient) GetLabel(ctx context.Context, owner, reposi
 	return nil, errLabelsNotSupported
 }
 
+// ListLabels on Bitbucket server
+func (client *BitbucketServerClient) ListLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	return nil, errLabelsNotSupported
+}
+
+// ListLabelsPager on Bitbucket server
+func (client *BitbucketServerClient) ListLabelsPager(owner, repository string, perPage int) *Pager[LabelInfo] {
+	return newPager(func(ctx context.Context) ([]LabelInfo, bool, error) {
+		return nil, false, errLabelsNotSupported
+	})
+}
+
+// UpdateLabel on Bitbucket server
+func (client *BitbucketServerClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	return errLabelsNotSupported
+}
+
+// DeleteLabel on Bitbucket server
+func (client *BitbucketServerClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	return errLabelsNotSupported
+}
+
 // ListPullRequestLabels on Bitbucket server
 func (client *BitbucketServerClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
 	return nil, errLabelsNotSupported
@@ -523,11 +2366,46 @@ func (client *BitbucketServerClient) UnlabelPullRequest(ctx context.Context, own
 	return errLabelsNotSupported
 }
 
+// CreateIssue on Bitbucket server
+func (client *BitbucketServerClient) CreateIssue(ctx context.Context, owner, repository, title, body string, labels []string) (IssueInfo, error) {
+	return IssueInfo{}, errIssuesNotSupported
+}
+
+// AddIssueComment on Bitbucket server
+func (client *BitbucketServerClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	return errIssuesNotSupported
+}
+
+// ListIssues on Bitbucket server
+func (client *BitbucketServerClient) ListIssues(ctx context.Context, owner, repository string, filter IssueFilter) ([]IssueInfo, error) {
+	return nil, errIssuesNotSupported
+}
+
+// UpdateIssueState on Bitbucket server
+func (client *BitbucketServerClient) UpdateIssueState(ctx context.Context, owner, repository string, issueID int, state IssueState) error {
+	return errIssuesNotSupported
+}
+
 // GetRepositoryEnvironmentInfo on Bitbucket server
 func (client *BitbucketServerClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
 	return RepositoryEnvironmentInfo{}, errBitbucketGetRepoEnvironmentInfoNotSupported
 }
 
+// ListEnvironments on Bitbucket server
+func (client *BitbucketServerClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errBitbucketDeploymentsNotSupported
+}
+
+// CreateDeployment on Bitbucket server
+func (client *BitbucketServerClient) CreateDeployment(ctx context.Context, owner, repository string, deployment DeploymentInfo) (int64, error) {
+	return 0, errBitbucketDeploymentsNotSupported
+}
+
+// SetDeploymentStatus on Bitbucket server
+func (client *BitbucketServerClient) SetDeploymentStatus(ctx context.Context, owner, repository string, deploymentID int64, status DeploymentStatusInfo) error {
+	return errBitbucketDeploymentsNotSupported
+}
+
 // Get all projects for which the authenticated user has the PROJECT_VIEW permission
 func (client *BitbucketServerClient) listProjects(bitbucketClient *bitbucketv1.DefaultApiService) ([]string, error) {
 	var apiResponse *bitbucketv1.APIResponse
@@ -570,6 +2448,288 @@ func (client *BitbucketServerClient) DownloadFileFromRepo(ctx context.Context, o
 	return resp.Payload, resp.StatusCode, err
 }
 
+// GetFileContent on Bitbucket server. The raw content API doesn't provide the SHA of the file.
+func (client *BitbucketServerClient) GetFileContent(ctx context.Context, owner, repository, ref, path string) (FileContent, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"ref":        ref,
+		"path":       path,
+	})
+	if err != nil {
+		return FileContent{}, err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return FileContent{}, err
+	}
+	resp, err := bitbucketClient.GetContent_11(owner, repository, path, map[string]interface{}{"at": ref})
+	if err != nil {
+		return FileContent{}, err
+	}
+	return FileContent{Path: path, Content: resp.Payload, Size: int64(len(resp.Payload))}, nil
+}
+
+// GetFileBlame on Bitbucket server
+func (client *BitbucketServerClient) GetFileBlame(ctx context.Context, owner, repository, ref, path string) ([]BlameLine, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"ref":        ref,
+		"path":       path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	blameURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/browse/%s?at=%s&blame&noContent", client.vcsInfo.APIEndpoint, owner, repository,
+		(&url.URL{Path: path}).EscapedPath(), url.QueryEscape(ref))
+	responseBody, err := client.sendRequest(ctx, http.MethodGet, blameURL, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var blameRanges []bitbucketServerBlameRange
+	if err = json.Unmarshal(responseBody, &blameRanges); err != nil {
+		return nil, err
+	}
+	var blame []BlameLine
+	for _, blameRange := range blameRanges {
+		// Older versions of Bitbucket name the commit ID commitHash
+		commitHash := blameRange.CommitID
+		if commitHash == "" {
+			commitHash = blameRange.CommitHash
+		}
+		for line := blameRange.LineNumber; line < blameRange.LineNumber+blameRange.SpannedLines; line++ {
+			blame = append(blame, BlameLine{
+				Line:        line,
+				CommitHash:  commitHash,
+				AuthorName:  blameRange.Author.Name,
+				AuthorEmail: blameRange.Author.EmailAddress,
+				Timestamp:   blameRange.AuthorTimestamp / 1000,
+			})
+		}
+	}
+	return blame, nil
+}
+
+type bitbucketServerBlameRange struct {
+	Author struct {
+		Name         string `json:"name"`
+		EmailAddress string `json:"emailAddress"`
+	} `json:"author"`
+	// Milliseconds from epoch
+	AuthorTimestamp int64  `json:"authorTimestamp"`
+	CommitID        string `json:"commitId"`
+	CommitHash      string `json:"commitHash"`
+	LineNumber      int    `json:"lineNumber"`
+	SpannedLines    int    `json:"spannedLines"`
+}
+
+// ListRepositoryTree on Bitbucket server. Each directory is listed in a separate request.
+func (client *BitbucketServerClient) ListRepositoryTree(ctx context.Context, owner, repository, ref string, recursive bool) ([]TreeEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	return walkRepositoryTree(ctx, recursive, func(ctx context.Context, directory string) ([]TreeEntry, error) {
+		return client.listDirectory(ctx, owner, repository, ref, directory)
+	})
+}
+
+func (client *BitbucketServerClient) listDirectory(ctx context.Context, owner, repository, ref, directory string) ([]TreeEntry, error) {
+	browseURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/browse/%s?at=%s", client.vcsInfo.APIEndpoint, owner, repository,
+		(&url.URL{Path: directory}).EscapedPath(), url.QueryEscape(ref))
+	var results []TreeEntry
+	for nextPageStart, isLastPage := 0, false; !isLastPage; {
+		responseBody, err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s&start=%d", browseURL, nextPageStart), nil, "")
+		if err != nil {
+			return nil, err
+		}
+		var page bitbucketServerDirectoryPage
+		if err = json.Unmarshal(responseBody, &page); err != nil {
+			return nil, err
+		}
+		for _, child := range page.Children.Values {
+			results = append(results, TreeEntry{
+				Path: strings.TrimPrefix(directory+"/"+child.Path.ToString, "/"),
+				Type: getBitbucketServerTreeEntryType(child.Type),
+				Size: child.Size,
+			})
+		}
+		nextPageStart, isLastPage = page.Children.NextPageStart, page.Children.IsLastPage
+	}
+	return results, nil
+}
+
+func getBitbucketServerTreeEntryType(childType string) TreeEntryType {
+	switch childType {
+	case "DIRECTORY":
+		return TreeEntryDirectory
+	case "SUBMODULE":
+		return TreeEntrySubmodule
+	}
+	return TreeEntryFile
+}
+
+type bitbucketServerDirectoryPage struct {
+	Children struct {
+		IsLastPage    bool `json:"isLastPage"`
+		NextPageStart int  `json:"nextPageStart"`
+		Values        []struct {
+			Path struct {
+				ToString string `json:"toString"`
+			} `json:"path"`
+			Type string `json:"type"`
+			Size int64  `json:"size"`
+		} `json:"values"`
+	} `json:"children"`
+}
+
+// SearchCode on Bitbucket server. The owner is the project key. Code search requires a search server to be configured.
+func (client *BitbucketServerClient) SearchCode(ctx context.Context, scope CodeSearchScope, query string) ([]CodeSearchResult, error) {
+	return listPageOrAll(ctx, client.searchCodePager(scope, query), scope.Page)
+}
+
+func (client *BitbucketServerClient) searchCodePager(scope CodeSearchScope, query string) *Pager[CodeSearchResult] {
+	pageSize := scope.PerPage
+	if pageSize == 0 {
+		pageSize = bitbucketServerDefaultPageSize
+	}
+	searchRequest := bitbucketServerCodeSearchRequest{Query: fmt.Sprintf("%s project:%s", query, scope.Owner)}
+	if scope.Repository != "" {
+		searchRequest.Query += " repo:" + scope.Repository
+	}
+	searchRequest.Entities.Code.Start = (firstPage(scope.Page) - 1) * pageSize
+	searchRequest.Entities.Code.Limit = pageSize
+	return newPager(func(ctx context.Context) ([]CodeSearchResult, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": scope.Owner, "query": query})
+		if err != nil {
+			return nil, false, err
+		}
+		body := new(bytes.Buffer)
+		if err = json.NewEncoder(body).Encode(searchRequest); err != nil {
+			return nil, false, err
+		}
+		client.addRestSuffixToEndpoint()
+		responseBody, err := client.sendRequest(ctx, http.MethodPost, client.vcsInfo.APIEndpoint+"/search/latest/search", body, "application/json")
+		if err != nil {
+			return nil, false, err
+		}
+		var searchResponse bitbucketServerCodeSearchResponse
+		if err = json.Unmarshal(responseBody, &searchResponse); err != nil {
+			return nil, false, err
+		}
+		results := make([]CodeSearchResult, 0, len(searchResponse.Code.Values))
+		for _, searchResult := range searchResponse.Code.Values {
+			results = append(results, mapBitbucketServerCodeSearchResult(searchResult))
+		}
+		searchRequest.Entities.Code.Start = searchResponse.Code.NextStart
+		return results, !searchResponse.Code.IsLastPage, nil
+	})
+}
+
+type bitbucketServerCodeSearchRequest struct {
+	Query    string `json:"query"`
+	Entities struct {
+		Code struct {
+			Start int `json:"start"`
+			Limit int `json:"limit"`
+		} `json:"code"`
+	} `json:"entities"`
+}
+
+type bitbucketServerCodeSearchResponse struct {
+	Code struct {
+		Values     []bitbucketServerCodeSearchResult `json:"values"`
+		IsLastPage bool                              `json:"isLastPage"`
+		NextStart  int                               `json:"nextStart"`
+	} `json:"code"`
+}
+
+type bitbucketServerCodeSearchResult struct {
+	Repository  bitbucketServerRepository `json:"repository"`
+	File        string                    `json:"file"`
+	HitContexts [][]struct {
+		Text string `json:"text"`
+	} `json:"hitContexts"`
+}
+
+// The lines of the hit contexts are HTML, in which the matches are emphasized
+func mapBitbucketServerCodeSearchResult(searchResult bitbucketServerCodeSearchResult) CodeSearchResult {
+	result := CodeSearchResult{
+		Owner:      searchResult.Repository.Project.Key,
+		Repository: searchResult.Repository.Slug,
+		Path:       searchResult.File,
+	}
+	emphasisReplacer := strings.NewReplacer("<em>", "", "</em>", "")
+	for _, hitContext := range searchResult.HitContexts {
+		lines := make([]string, 0, len(hitContext))
+		for _, line := range hitContext {
+			lines = append(lines, html.UnescapeString(emphasisReplacer.Replace(line.Text)))
+		}
+		result.Fragments = append(result.Fragments, strings.Join(lines, "\n"))
+	}
+	return result
+}
+
+// CreateOrUpdateFile on Bitbucket server
+func (client *BitbucketServerClient) CreateOrUpdateFile(ctx context.Context, owner, repository, branch, path string, content []byte, commitMessage string) error {
+	// https://docs.atlassian.com/bitbucket-server/rest/7.21.0/bitbucket-rest.html
+	err := validateParametersNotBlank(map[string]string{
+		"owner":          owner,
+		"repository":     repository,
+		"branch":         branch,
+		"path":           path,
+		"commit message": commitMessage,
+	})
+	if err != nil {
+		return err
+	}
+	bitbucketClient, err := client.buildBitbucketClient(ctx)
+	if err != nil {
+		return err
+	}
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	fields := map[string]string{"branch": branch, "message": commitMessage}
+	response, err := bitbucketClient.GetContent_11(owner, repository, path, map[string]interface{}{"at": branch})
+	if err != nil {
+		if response == nil || response.StatusCode != http.StatusNotFound {
+			return err
+		}
+	} else {
+		// Updating an existing file requires the commit the change is based on
+		latestCommit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+		if err != nil {
+			return err
+		}
+		fields["sourceCommitId"] = latestCommit.Hash
+	}
+	for name, value := range fields {
+		if err = writer.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+	contentPart, err := writer.CreateFormFile("content", path)
+	if err != nil {
+		return err
+	}
+	if _, err = contentPart.Write(content); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	client.logger.Debug("committing file", path, "to branch", branch)
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/browse/%s", client.vcsInfo.APIEndpoint, owner, repository, path)
+	_, err = client.sendRequest(ctx, http.MethodPut, url, body, writer.FormDataContentType())
+	return err
+}
+
+// CommitFiles on Bitbucket server
+func (client *BitbucketServerClient) CommitFiles(_ context.Context, _, _, _, _ string, _ []FileChange) error {
+	return errBitbucketServerCommitFilesNotSupported
+}
+
 func createPaginationOptions(nextPageStart int) map[string]interface{} {
 	return map[string]interface{}{"start": nextPageStart}
 }
@@ -599,6 +2759,40 @@ func createBitbucketServerHook(token, payloadURL string, webhookEvents ...vcsuti
 	}
 }
 
+type bitbucketServerWebhooksPage struct {
+	Values        []bitbucketServerWebhook `json:"values"`
+	IsLastPage    bool                     `json:"isLastPage"`
+	NextPageStart int                      `json:"nextPageStart"`
+}
+
+type bitbucketServerWebhook struct {
+	ID     int      `json:"id" mapstructure:"id"`
+	Name   string   `json:"name" mapstructure:"name"`
+	URL    string   `json:"url" mapstructure:"url"`
+	Events []string `json:"events" mapstructure:"events"`
+	Active bool     `json:"active" mapstructure:"active"`
+	// Missing on older Bitbucket servers, which always verify the SSL certificate
+	SslVerificationRequired *bool `json:"sslVerificationRequired,omitempty" mapstructure:"sslVerificationRequired"`
+}
+
+func mapBitbucketServerWebhookToWebhookInfo(hook bitbucketServerWebhook) WebhookInfo {
+	return WebhookInfo{
+		ID:          strconv.Itoa(hook.ID),
+		PayloadURL:  hook.URL,
+		Events:      mapProviderWebhookEvents(hook.Events, getBitbucketServerWebhookEvents),
+		Active:      hook.Active,
+		ContentType: WebhookContentTypeJSON,
+		InsecureSSL: hook.SslVerificationRequired != nil && !*hook.SslVerificationRequired,
+	}
+}
+
+func createBitbucketServerHookWithOptions(token string, options WebhookOptions) *map[string]interface{} {
+	hook := createBitbucketServerHook(token, options.PayloadURL, options.Events...)
+	(*hook)["active"] = true
+	(*hook)["sslVerificationRequired"] = !options.InsecureSSL
+	return hook
+}
+
 // Get varargs of webhook events and return a slice of Bitbucket server webhook events
 func getBitbucketServerWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []string {
 	events := make([]string, 0, len(webhookEvents))
@@ -642,9 +2836,250 @@ func (client *BitbucketServerClient) UploadCodeScanning(ctx context.Context, own
 	return "", errBitbucketCodeScanningNotSupported
 }
 
+// UploadScanResults on Bitbucket server reports the scan results as a Code Insights report of the latest commit of the branch.
+// Bitbucket server keeps up to 1000 annotations of each report, so the findings beyond them are reported in the report details only.
+func (client *BitbucketServerClient) UploadScanResults(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return "", err
+	}
+	results, err := parseSarif(scanResults)
+	if err != nil {
+		return "", err
+	}
+	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return "", err
+	}
+	report, annotations := getScanResultsCodeInsights(results)
+	if err = client.CreateCodeInsightsReport(ctx, owner, repository, commit.Hash, report); err != nil {
+		return "", err
+	}
+	// The annotations of a previous report of the same tools are replaced by the new findings
+	reportURL := client.getCodeInsightsReportURL(owner, repository, commit.Hash, report.ID)
+	if _, err = client.sendRequest(ctx, http.MethodDelete, reportURL+"/annotations", nil, ""); err != nil {
+		return "", err
+	}
+	if len(annotations) > bitbucketServerMaxAnnotationsPerReport {
+		annotations = annotations[:bitbucketServerMaxAnnotationsPerReport]
+	}
+	return report.ID, client.AddCodeInsightsAnnotations(ctx, owner, repository, commit.Hash, report.ID, annotations)
+}
+
+// CreateCodeInsightsReport on Bitbucket server
+func (client *BitbucketServerClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, sha string, report CodeInsightsReport) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha, "report ID": report.ID})
+	if err != nil {
+		return err
+	}
+	return client.sendJSONRequest(ctx, http.MethodPut, client.getCodeInsightsReportURL(owner, repository, sha, report.ID), bitbucketServerReport{
+		Title:    report.Title,
+		Details:  report.Details,
+		Reporter: report.Reporter,
+		Link:     report.Link,
+		Result:   getBitbucketServerReportResult(report.Result),
+	})
+}
+
+// AddCodeInsightsAnnotations on Bitbucket server. A report can have up to 1000 annotations.
+func (client *BitbucketServerClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, sha, reportID string, annotations []CodeInsightsAnnotation) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha, "report ID": reportID})
+	if err != nil || len(annotations) == 0 {
+		return err
+	}
+	var request bitbucketServerAnnotationsRequest
+	for _, annotation := range annotations {
+		request.Annotations = append(request.Annotations, mapCodeInsightsAnnotationToBitbucketServerAnnotation(annotation))
+	}
+	return client.sendJSONRequest(ctx, http.MethodPost, client.getCodeInsightsReportURL(owner, repository, sha, reportID)+"/annotations", request)
+}
+
+func (client *BitbucketServerClient) getCodeInsightsReportURL(owner, repository, sha, reportID string) string {
+	client.addRestSuffixToEndpoint()
+	return fmt.Sprintf("%s/insights/1.0/projects/%s/repos/%s/commits/%s/reports/%s", client.vcsInfo.APIEndpoint, owner, repository, sha, url.PathEscape(reportID))
+}
+
+// The limits of Code Insights annotations on Bitbucket server
+const (
+	bitbucketServerAnnotationMessageMaxLength = 2000
+	bitbucketServerMaxAnnotationsPerReport    = 1000
+)
+
+type bitbucketServerReport struct {
+	Title    string `json:"title"`
+	Details  string `json:"details"`
+	Reporter string `json:"reporter,omitempty"`
+	Link     string `json:"link,omitempty"`
+	Result   string `json:"result,omitempty"`
+}
+
+type bitbucketServerAnnotationsRequest struct {
+	Annotations []bitbucketServerAnnotation `json:"annotations"`
+}
+
+type bitbucketServerAnnotation struct {
+	ExternalID string `json:"externalId"`
+	Type       string `json:"type"`
+	Message    string `json:"message"`
+	Path       string `json:"path,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Severity   string `json:"severity"`
+	Link       string `json:"link,omitempty"`
+}
+
+// Bitbucket server annotations have a message only, so the details are appended to it
+func mapCodeInsightsAnnotationToBitbucketServerAnnotation(annotation CodeInsightsAnnotation) bitbucketServerAnnotation {
+	message := annotation.Message
+	if annotation.Details != "" && annotation.Details != annotation.Message {
+		message += ": " + annotation.Details
+	}
+	return bitbucketServerAnnotation{
+		ExternalID: annotation.ExternalID,
+		Type:       getBitbucketCodeInsightsAnnotationType(annotation.Type),
+		Message:    truncateBitbucketText(message, bitbucketServerAnnotationMessageMaxLength),
+		Path:       annotation.Path,
+		Line:       annotation.Line,
+		Severity:   getBitbucketServerAnnotationSeverity(annotation.Severity),
+		Link:       annotation.Link,
+	}
+}
+
+// Bitbucket server reports have no pending result
+func getBitbucketServerReportResult(result CommitStatus) string {
+	switch result {
+	case Pass:
+		return "PASS"
+	case InProgress:
+		return ""
+	}
+	return "FAIL"
+}
+
+// Bitbucket server has no critical severity
+func getBitbucketServerAnnotationSeverity(severity SecuritySeverity) string {
+	switch severity {
+	case SeverityCritical, SeverityHigh:
+		return "HIGH"
+	case SeverityMedium:
+		return "MEDIUM"
+	}
+	return "LOW"
+}
+
+// ListSecurityAlerts on Bitbucket server
+func (client *BitbucketServerClient) ListSecurityAlerts(ctx context.Context, owner, repository string) ([]SecurityAlertInfo, error) {
+	return nil, errBitbucketSecurityAlertsNotSupported
+}
+
+func mapBitbucketServerPermissionToRepositoryPermission(permission string) RepositoryPermission {
+	switch permission {
+	case "REPO_ADMIN":
+		return PermissionAdmin
+	case "REPO_WRITE":
+		return PermissionWrite
+	case "REPO_READ":
+		return PermissionRead
+	default:
+		return PermissionNone
+	}
+}
+
+func getBitbucketServerPermissionName(permission RepositoryPermission) string {
+	switch permission {
+	case PermissionAdmin:
+		return "REPO_ADMIN"
+	case PermissionWrite:
+		return "REPO_WRITE"
+	default:
+		return "REPO_READ"
+	}
+}
+
+func mapBitbucketServerRepositoryToRepositoryInfo(repo bitbucketServerRepository) RepositoryInfo {
+	var info CloneInfo
+	for _, cloneLink := range repo.Links.Clone {
+		switch cloneLink.Name {
+		case "http":
+			info.HTTP = cloneLink.HRef
+		case "ssh":
+			info.SSH = cloneLink.HRef
+		}
+	}
+	return RepositoryInfo{
+		RepositoryVisibility: getBitbucketServerRepositoryVisibility(repo.Public),
+		CloneInfo:            info,
+		Description:          repo.Description,
+		Archived:             repo.Archived,
+	}
+}
+
 func getBitbucketServerRepositoryVisibility(public bool) RepositoryVisibility {
 	if public {
 		return Public
 	}
 	return Private
 }
+
+func getBitbucketServerMergeStrategyID(mergeStrategy MergeStrategy) string {
+	switch mergeStrategy {
+	case SquashMerge:
+		return "squash"
+	case RebaseMerge:
+		return "rebase-ff-only"
+	}
+	return "no-ff"
+}
+
+func getBitbucketServerFileStatus(changeType string) FileStatus {
+	switch changeType {
+	case "ADD", "COPY":
+		return FileAdded
+	case "DELETE":
+		return FileDeleted
+	case "MOVE":
+		return FileRenamed
+	}
+	return FileModified
+}
+
+func mapBitbucketServerPullRequestToPullRequestInfo(pullRequest bitbucketv1.PullRequest) PullRequestInfo {
+	pullRequestInfo := PullRequestInfo{
+		ID:     int64(pullRequest.ID),
+		Source: mapBitbucketServerPullRequestRef(pullRequest.FromRef),
+		Target: mapBitbucketServerPullRequestRef(pullRequest.ToRef),
+		Title:  pullRequest.Title,
+		Body:   pullRequest.Description,
+		State:  getBitbucketServerPullRequestState(pullRequest.State),
+	}
+	if pullRequest.Author != nil {
+		pullRequestInfo.Author = pullRequest.Author.User.Name
+	}
+	// The merge outcome is only available after Bitbucket checks the pull request for conflicts
+	switch pullRequest.Properties.MergeResult.Outcome {
+	case "CLEAN":
+		mergeable := true
+		pullRequestInfo.Mergeable = &mergeable
+	case "CONFLICTED":
+		mergeable := false
+		pullRequestInfo.Mergeable = &mergeable
+	}
+	return pullRequestInfo
+}
+
+func mapBitbucketServerPullRequestRef(ref bitbucketv1.PullRequestRef) BranchInfo {
+	branchInfo := BranchInfo{Name: ref.DisplayID, Repository: ref.Repository.Slug}
+	if ref.Repository.Project != nil {
+		branchInfo.Owner = ref.Repository.Project.Key
+	}
+	return branchInfo
+}
+
+func getBitbucketServerPullRequestState(state string) PullRequestState {
+	switch state {
+	case "MERGED":
+		return PullRequestMerged
+	case "DECLINED":
+		return PullRequestClosed
+	}
+	return PullRequestOpen
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -58,6 +59,25 @@ func TestBitbucketServer_ConnectionWhenContextTimesOut(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestBitbucketServer_GetRateLimitInfo(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/1.0/application-properties", r.RequestURI)
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	rateLimitInfo, err := buildClient(t, vcsutils.BitbucketServer, true, server).GetRateLimitInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitInfo{Limit: 60, Remaining: 42}, rateLimitInfo)
+
+	_, err = createBadBitbucketServerClient(t).GetRateLimitInfo(ctx)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_ListRepositories(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createBitbucketServerListRepositoriesHandler)
@@ -71,6 +91,46 @@ func TestBitbucketServer_ListRepositories(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos?limit=2&start=0":
+			_, err = w.Write([]byte(`{"isLastPage":false,"nextPageStart":2,"values":[
+				{"slug":"repo-1","public":false,"archived":true,"project":{"key":"jfrog"}},
+				{"slug":"repo-2","public":false,"project":{"key":"jfrog"},"links":{"clone":[{"name":"http","href":"https://bitbucket.example.com/scm/jfrog/repo-2.git"}]}}
+			]}`))
+		case "/rest/api/1.0/projects/jfrog/repos?limit=2&start=2":
+			_, err = w.Write([]byte(`{"isLastPage":true,"values":[{"slug":"repo-3","public":true,"project":{"key":"jfrog"}}]}`))
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	private := Private
+	archived := false
+	result, err := client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{Visibility: &private, Archived: &archived, PerPage: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryDetails{{
+		Owner:      owner,
+		Repository: repo2,
+		RepositoryInfo: RepositoryInfo{
+			RepositoryVisibility: Private,
+			CloneInfo:            CloneInfo{HTTP: "https://bitbucket.example.com/scm/jfrog/repo-2.git"},
+		},
+	}}, result)
+
+	_, err = client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{UpdatedSince: time.Now()})
+	assert.ErrorIs(t, err, errBitbucketServerRepositoryFiltersNotSupported)
+
+	_, err = createBadBitbucketServerClient(t).ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{})
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_ListBranches(t *testing.T) {
 	ctx := context.Background()
 	mockResponse := map[string][]bitbucketv1.Branch{
@@ -87,6 +147,299 @@ func TestBitbucketServer_ListBranches(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListBranchesPager(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/branches?limit=1&start=0":
+			response = `{"values":[{"id":"refs/heads/branch-1"}],"isLastPage":false,"nextPageStart":1}`
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/branches?limit=1&start=1":
+			response = `{"values":[{"id":"refs/heads/branch-2"}],"isLastPage":true}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	pager := buildClient(t, vcsutils.BitbucketServer, true, server).ListBranchesPager(owner, repo1, 1)
+
+	page, err := pager.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"refs/heads/branch-1"}, page)
+	assert.True(t, pager.HasNext())
+	page, err = pager.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"refs/heads/branch-2"}, page)
+	assert.False(t, pager.HasNext())
+
+	_, err = createBadBitbucketServerClient(t).ListBranchesPager(owner, repo1, 1).Next(ctx)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListAllBranches(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]bitbucketv1.Branch{
+		"values": {{ID: "refs/heads/" + branch1, DisplayID: branch1, LatestCommit: "sha1"}, {ID: "refs/heads/my-branch-2", DisplayID: "my-branch-2", LatestCommit: "sha2"}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, mockResponse, "/rest/api/1.0/projects/jfrog/repos/repo-1/branches?filterText=branch-&start=0", createBitbucketServerHandler)
+	defer cleanUp()
+
+	actualBranches, err := client.ListAllBranches(ctx, owner, repo1, "branch-")
+	assert.NoError(t, err)
+	assert.Equal(t, []BranchDetails{{Name: branch1, CommitHash: "sha1"}}, actualBranches)
+
+	_, err = createBadBitbucketServerClient(t).ListAllBranches(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CreateBranch(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		fmt.Sprintf("/api/1.0/projects/%s/repos/%s/branches", owner, repo1), http.StatusOK,
+		[]byte(`{"name":"branch-1","startPoint":"master"}`+"\n"), http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	err := client.CreateBranch(ctx, owner, repo1, branch1, "master")
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).CreateBranch(ctx, owner, repo1, branch1, "master")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_DeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, []byte{},
+		fmt.Sprintf("/branch-utils/1.0/projects/%s/repos/%s/branches", owner, repo1), http.StatusNoContent,
+		[]byte(`{"name":"refs/heads/branch-1"}`+"\n"), http.MethodDelete, createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	err := client.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).DeleteBranch(ctx, owner, repo1, branch1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListTags(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/1.0/projects/jfrog/repos/repo-1/tags?start=0":
+			response = `{"values":[{"id":"refs/tags/v1.0.0","displayId":"v1.0.0","latestCommit":"sha1"}],"isLastPage":false,"nextPageStart":1}`
+		case "/api/1.0/projects/jfrog/repos/repo-1/tags?start=1":
+			response = `{"values":[{"id":"refs/tags/v1.1.0","displayId":"v1.1.0","latestCommit":"sha2"}],"isLastPage":true}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitHash: "sha1"}, {Name: "v1.1.0", CommitHash: "sha2"}}, tags)
+
+	_, err = createBadBitbucketServerClient(t).ListTags(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		fmt.Sprintf("/api/1.0/projects/%s/repos/%s/tags", owner, repo1), http.StatusOK,
+		[]byte(`{"name":"v1.0.0","startPoint":"sha1","message":"Release 1.0.0"}`+"\n"), http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	err := client.CreateTag(ctx, owner, repo1, "v1.0.0", "sha1", "Release 1.0.0")
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).CreateTag(ctx, owner, repo1, "v1.0.0", "sha1", "Release 1.0.0")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CreateRelease(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		fmt.Sprintf("/api/1.0/projects/%s/repos/%s/tags", owner, repo1), http.StatusOK,
+		[]byte(`{"name":"v1.0.0","startPoint":"sha1","message":"Release 1.0.0\n\nBug fixes"}`+"\n"), http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	err := client.CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0", Target: "sha1", Name: "Release 1.0.0", Description: "Bug fixes"})
+	assert.NoError(t, err)
+
+	err = client.CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0"})
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetLatestRelease(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false,
+		[]byte(`{"values":[{"id":"refs/tags/v1.0.0","displayId":"v1.0.0","latestCommit":"sha1"}],"isLastPage":false,"nextPageStart":1}`),
+		"/api/1.0/projects/jfrog/repos/repo-1/tags?orderBy=MODIFICATION&limit=1", createBitbucketServerHandler)
+	defer cleanUp()
+
+	release, err := client.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, &ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0"}, release)
+
+	_, err = createBadBitbucketServerClient(t).GetLatestRelease(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_UploadReleaseAsset(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	err = client.UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", "asset.zip")
+	assert.ErrorIs(t, err, errBitbucketServerReleaseAssetsNotSupported)
+}
+
+func TestBitbucketServer_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	restrictionsURI := "/branch-permissions/2.0/projects/jfrog/repos/repo-1/restrictions?matcherId=refs%2Fheads%2Fmaster"
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false,
+		[]byte(`{"values":[{"id":1,"type":"no-deletes","users":[]},{"id":2,"type":"read-only","users":[{"name":"frogger"}]}]}`),
+		restrictionsURI, createBitbucketServerHandler)
+	defer cleanUp()
+
+	protection, err := client.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, &BranchProtection{RestrictPushes: true, AllowedPushUsers: []string{"frogger"}}, protection)
+
+	notProtectedClient, notProtectedCleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false,
+		[]byte(`{"values":[]}`), restrictionsURI, createBitbucketServerHandler)
+	defer notProtectedCleanUp()
+	protection, err = notProtectedClient.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Nil(t, protection)
+
+	_, err = createBadBitbucketServerClient(t).GetBranchProtection(ctx, owner, repo1, "master")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	restrictionsURI := "/branch-permissions/2.0/projects/jfrog/repos/repo-1/restrictions"
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case restrictionsURI + "?matcherId=refs%2Fheads%2Fmaster":
+			_, err := w.Write([]byte(`{"values":[{"id":1,"type":"no-deletes"},{"id":2,"type":"read-only","users":[{"name":"frogger"}]}]}`))
+			assert.NoError(t, err)
+		case restrictionsURI + "/2":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		case restrictionsURI:
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"type":"read-only","matcher":{"id":"refs/heads/master","type":{"id":"BRANCH"}},"users":["frogger","frogger2"]}`+"\n", string(body))
+			_, err = w.Write([]byte(`{"id":3}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	err := client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{RestrictPushes: true, AllowedPushUsers: []string{"frogger", "frogger2"}})
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	err = client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{RequiredStatusChecks: []string{"build"}})
+	assert.ErrorIs(t, err, errBitbucketServerBranchProtectionChecksNotSupported)
+}
+
+func TestBitbucketServer_TagProtection(t *testing.T) {
+	ctx := context.Background()
+	restrictionsURI := "/branch-permissions/2.0/projects/jfrog/repos/repo-1/restrictions"
+	protected, deleted := true, false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case restrictionsURI + "?matcherId=refs%2Ftags%2Fv%2A":
+			response := `{"values":[]}`
+			if protected {
+				response = `{"values":[{"id":1,"type":"no-deletes"},{"id":2,"type":"read-only","users":[{"name":"release-bot"}]}]}`
+			}
+			_, err := w.Write([]byte(response))
+			assert.NoError(t, err)
+		case restrictionsURI + "/2":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		case restrictionsURI:
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"type":"read-only","matcher":{"id":"refs/tags/v*","type":{"id":"PATTERN"}},"users":["release-bot"]}`+"\n", string(body))
+			_, err = w.Write([]byte(`{"id":3}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	protection, err := client.GetTagProtection(ctx, owner, repo1, "v*")
+	assert.NoError(t, err)
+	assert.Equal(t, &TagProtection{AllowedUsers: []string{"release-bot"}}, protection)
+
+	err = client.SetTagProtection(ctx, owner, repo1, "v*", TagProtection{AllowedUsers: []string{"release-bot"}})
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	protected = false
+	protection, err = client.GetTagProtection(ctx, owner, repo1, "v*")
+	assert.NoError(t, err)
+	assert.Nil(t, protection)
+}
+
+func TestBitbucketServer_GetRequiredChecksAndApprovals(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/settings/pull-requests":
+			response = `{"requiredApprovers":2,"requiredSuccessfulBuilds":1}`
+		case "/rest/required-builds/latest/projects/jfrog/repos/repo-1/conditions?start=0":
+			response = `{"isLastPage":false,"nextPageStart":2,"values":[
+				{"buildParentKeys":["build"],"refMatcher":{"id":"refs/heads/master","type":{"id":"BRANCH"}}},
+				{"buildParentKeys":["release-tests"],"refMatcher":{"id":"release/*","type":{"id":"PATTERN"}}}]}`
+		case "/rest/required-builds/latest/projects/jfrog/repos/repo-1/conditions?start=2":
+			response = `{"isLastPage":true,"values":[{"buildParentKeys":["build","frogbot"],"refMatcher":{"id":"ANY_REF_MATCHER_ID","type":{"id":"ANY_REF"}}}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	requiredChecks, err := client.GetRequiredChecks(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredChecksInfo{Titles: []string{"build", "frogbot"}, AllMustPass: true}, requiredChecks)
+	requiredChecks, err = client.GetRequiredChecks(ctx, owner, repo1, "release/1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"release-tests", "build", "frogbot"}, requiredChecks.Titles)
+
+	requiredApprovals, err := client.GetRequiredApprovals(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredApprovalsInfo{Count: 2}, requiredApprovals)
+
+	_, err = createBadBitbucketServerClient(t).GetRequiredChecks(ctx, owner, repo1, "master")
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int31()
@@ -104,6 +457,25 @@ func TestBitbucketServer_CreateWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_CreateWebhookWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id":1,"url":"https://jfrog.com","active":true,"events":["repo:refs_changed"],"sslVerificationRequired":false}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/webhooks", createBitbucketServerHandler)
+	defer cleanUp()
+
+	options := WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, InsecureSSL: true}
+	webhook, token, err := client.CreateWebhookWithOptions(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON, InsecureSSL: true}, webhook)
+
+	options.ContentType = WebhookContentTypeForm
+	_, _, err = client.CreateWebhookWithOptions(ctx, owner, repo1, options)
+	assert.ErrorIs(t, err, errBitbucketWebhookFormContentTypeNotSupported)
+}
+
 func TestBitbucketServer_UpdateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int31()
@@ -135,6 +507,94 @@ func TestBitbucketServer_DeleteWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"id":1,"name":"frogbot","url":"https://jfrog.com/hook","active":true,` +
+		`"events":["pr:opened","pr:declined","pr:deleted","repo:refs_changed"]},` +
+		`{"id":2,"name":"ci","url":"https://jfrog.com/push","active":false,"events":["repo:refs_changed","pr:declined"],"sslVerificationRequired":false}],"isLastPage":true}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{
+		{ID: "1", PayloadURL: "https://jfrog.com/hook", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrRejected, vcsutils.Push}, Active: true, ContentType: WebhookContentTypeJSON},
+		{ID: "2", PayloadURL: "https://jfrog.com/push", Events: []vcsutils.WebhookEvent{vcsutils.Push}, ContentType: WebhookContentTypeJSON, InsecureSSL: true},
+	}, webhooks)
+
+	_, err = createBadBitbucketServerClient(t).ListWebhooks(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_SetWebhookActive(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/1.0/projects/jfrog/repos/repo-1/webhooks/1", r.RequestURI)
+		if r.Method == http.MethodPut {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"name":"frogbot","url":"https://jfrog.com/hook","events":["repo:refs_changed"],"active":false}`, string(body))
+		}
+		_, err := w.Write([]byte(`{"id":1,"name":"frogbot","url":"https://jfrog.com/hook","active":true,"events":["repo:refs_changed"]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	err := client.SetWebhookActive(ctx, owner, repo1, "1", false)
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).SetWebhookActive(ctx, owner, repo1, "1", false)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CreateOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id":1,"url":"https://jfrog.com","active":true,"events":["repo:refs_changed"],"sslVerificationRequired":true}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response, "/rest/api/1.0/projects/jfrog/webhooks",
+		createBitbucketServerHandler)
+	defer cleanUp()
+
+	webhook, token, err := client.CreateOrgWebhook(ctx, owner, WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON}, webhook)
+
+	_, _, err = createBadBitbucketServerClient(t).CreateOrgWebhook(ctx, owner, WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}})
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListOrgWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"id":1,"url":"https://jfrog.com","active":true,"events":["repo:refs_changed"]}],"isLastPage":true}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response, "/rest/api/1.0/projects/jfrog/webhooks?start=0",
+		createBitbucketServerHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListOrgWebhooks(ctx, owner)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON}}, webhooks)
+
+	_, err = createBadBitbucketServerClient(t).ListOrgWebhooks(ctx, owner)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_DeleteOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "/rest/api/1.0/projects/jfrog/webhooks/1",
+		createBitbucketServerHandler)
+	defer cleanUp()
+
+	err := client.DeleteOrgWebhook(ctx, owner, "1")
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).DeleteOrgWebhook(ctx, owner, "1")
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_SetCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	ref := "9caf1c431fb783b669f0f909bd018b40f2ea3808"
@@ -150,6 +610,28 @@ func TestBitbucketServer_SetCommitStatus(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListCommitStatuses(t *testing.T) {
+	ctx := context.Background()
+	ref := "9caf1c431fb783b669f0f909bd018b40f2ea3808"
+	response := []byte(`{"isLastPage":true,"values":[
+		{"state":"SUCCESSFUL","key":"build","description":"Build passed","url":"https://ci.example.com/build","dateAdded":1672567200000},
+		{"state":"STOPPED","key":"deploy","dateAdded":1672567200000}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/build-status/1.0/commits/%s?start=0", ref), createBitbucketServerHandler)
+	defer cleanUp()
+
+	statuses, err := client.ListCommitStatuses(ctx, owner, repo1, ref)
+	require.NoError(t, err)
+	assert.Equal(t, []CommitStatusInfo{
+		{State: Pass, Title: "build", Description: "Build passed", DetailsURL: "https://ci.example.com/build",
+			CreatedAt: time.UnixMilli(1672567200000), LastUpdatedAt: time.UnixMilli(1672567200000)},
+		{State: Error, Title: "deploy", CreatedAt: time.UnixMilli(1672567200000), LastUpdatedAt: time.UnixMilli(1672567200000)},
+	}, statuses)
+
+	_, err = createBadBitbucketServerClient(t).ListCommitStatuses(ctx, owner, repo1, ref)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_DownloadRepository(t *testing.T) {
 	ctx := context.Background()
 	dir, err := os.MkdirTemp("", "")
@@ -173,6 +655,39 @@ func TestBitbucketServer_DownloadRepository(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_GetRepositoryArchive(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, []byte("archive content"),
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/archive?at=v1.0.0&format=zip", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	archive, err := client.GetRepositoryArchive(ctx, owner, repo1, "v1.0.0", Zip)
+	require.NoError(t, err)
+	content, err := io.ReadAll(archive)
+	assert.NoError(t, err)
+	assert.NoError(t, archive.Close())
+	assert.Equal(t, "archive content", string(content))
+
+	_, err = createBadBitbucketServerClient(t).GetRepositoryArchive(ctx, owner, repo1, "v1.0.0", Zip)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_DownloadRepositoryPath(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	archive := createTarGzArchive(t, "", map[string]string{"services/a/main.go": "package main"})
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, archive,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/archive?at=master&format=tgz&path=services%%2Fa", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	err := client.DownloadRepositoryPath(ctx, owner, repo1, "master", "services/a", dir)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "services", "a", "main.go"))
+
+	err = createBadBitbucketServerClient(t).DownloadRepositoryPath(ctx, owner, repo1, "master", "services/a", dir)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests", createBitbucketServerHandler)
@@ -185,6 +700,143 @@ func TestBitbucketServer_CreatePullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_DraftPullRequestNotSupported(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, "", createBitbucketServerHandler)
+	defer cleanUp()
+
+	err := client.CreateDraftPullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.ErrorIs(t, err, errBitbucketServerDraftPullRequestNotSupported)
+
+	err = client.MarkPullRequestReady(ctx, owner, repo1, 1)
+	assert.ErrorIs(t, err, errBitbucketServerDraftPullRequestNotSupported)
+}
+
+func TestBitbucketServer_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1":
+			assert.Equal(t, http.MethodGet, r.Method)
+			response, err := json.Marshal(bitbucketv1.PullRequest{ID: 1, Version: 3})
+			require.NoError(t, err)
+			_, err = w.Write(response)
+			require.NoError(t, err)
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/merge?version=3":
+			assert.Equal(t, http.MethodPost, r.Method)
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"message":"Merge commit message","strategyId":"squash"}`, string(b))
+			response, err := json.Marshal(bitbucketv1.PullRequest{ID: 1, Version: 4, State: "MERGED"})
+			require.NoError(t, err)
+			_, err = w.Write(response)
+			require.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	err := client.MergePullRequest(ctx, owner, repo1, 1, SquashMerge, "Merge commit message")
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).MergePullRequest(ctx, owner, repo1, 1, SquashMerge, "Merge commit message")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_PullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		assert.Equal(t, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/auto-merge", r.RequestURI)
+		if r.Method == http.MethodPost {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"strategyId":"no-ff"}`, string(b))
+		}
+		requests = append(requests, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	err := client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, MergeCommit)
+	assert.NoError(t, err)
+	err = client.DisablePullRequestAutoMerge(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{http.MethodPost, http.MethodDelete}, requests)
+
+	err = createBadBitbucketServerClient(t).EnablePullRequestAutoMerge(ctx, owner, repo1, 1, MergeCommit)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_UpdatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var response bitbucketv1.PullRequest
+		switch r.Method + " " + r.RequestURI {
+		case "GET /rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1":
+			response = bitbucketv1.PullRequest{ID: 1, Version: 3, Title: "Old title", Description: "Old body", State: "OPEN", Open: true}
+		case "PUT /rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1":
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"version":3,"title":"New title","description":"Old body","toRef":{"id":"refs/heads/dev"}}`, string(b))
+			response = bitbucketv1.PullRequest{ID: 1, Version: 4, Title: "New title", Description: "Old body", State: "OPEN", Open: true}
+		case "POST /rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/decline?version=4":
+			response = bitbucketv1.PullRequest{ID: 1, Version: 5, State: "DECLINED", Closed: true}
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		b, err := json.Marshal(response)
+		require.NoError(t, err)
+		_, err = w.Write(b)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	closed := PullRequestClosed
+	err := client.UpdatePullRequest(ctx, owner, repo1, "New title", "", "dev", 1, &closed)
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).UpdatePullRequest(ctx, owner, repo1, "New title", "", "", 1, nil)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_SubmitPullRequestReview(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		if r.Method == http.MethodPut {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"status":"NEEDS_WORK"}`, string(b))
+		}
+		_, err := w.Write([]byte("{}"))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	err := client.ApprovePullRequest(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"POST /rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/approve"}, requests)
+
+	err = client.SubmitPullRequestReview(ctx, owner, repo1, 1, ReviewRequestChanges, "")
+	assert.ErrorIs(t, err, errBitbucketServerReviewUsernameRequired)
+
+	requests = nil
+	err = buildClient(t, vcsutils.BitbucketServer, true, server).SubmitPullRequestReview(ctx, owner, repo1, 1, ReviewRequestChanges, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PUT /rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/participants/frogger"}, requests)
+}
+
 func TestBitbucketServer_AddPullRequestComment(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/comments", createBitbucketServerHandler)
@@ -216,6 +868,176 @@ func TestBitbucketServer_ListOpenPullRequests(t *testing.T) {
 	}, result[0]))
 }
 
+func TestBitbucketServer_ListOpenPullRequestsWithFilter(t *testing.T) {
+	ctx := context.Background()
+	updated := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	ref := bitbucketv1.PullRequestRef{DisplayID: "feature", Repository: bitbucketv1.Repository{Slug: repo1}}
+	targetRef := bitbucketv1.PullRequestRef{DisplayID: "main", Repository: bitbucketv1.Repository{Slug: repo1}}
+	response, err := json.Marshal(map[string]interface{}{
+		"values": []bitbucketv1.PullRequest{
+			{ID: 1, FromRef: ref, ToRef: targetRef, UpdatedDate: updated.UnixMilli(), Author: &bitbucketv1.UserWithMetadata{User: bitbucketv1.UserWithLinks{Name: "frogger"}}},
+			{ID: 2, FromRef: ref, ToRef: targetRef, UpdatedDate: updated.UnixMilli(), Author: &bitbucketv1.UserWithMetadata{User: bitbucketv1.UserWithLinks{Name: "toad"}}},
+			{ID: 3, FromRef: ref, ToRef: targetRef, UpdatedDate: updated.Add(-time.Hour).UnixMilli(), Author: &bitbucketv1.UserWithMetadata{User: bitbucketv1.UserWithLinks{Name: "frogger"}}},
+		},
+		"isLastPage": true,
+	})
+	require.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests?at=refs%%2Fheads%%2Fmain&limit=10&start=10&state=OPEN", owner, repo1),
+		createBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{
+		Author: "frogger", SourceBranch: "feature", TargetBranch: "main", UpdatedSince: updated, Page: 2, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(1), result[0].ID)
+	assert.Equal(t, "frogger", result[0].Author)
+
+	_, err = client.ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{Label: "bug"})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+
+	_, err = createBadBitbucketServerClient(t).ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{})
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/changes?start=0":
+			response = `{"values":[{"type":"MODIFY","path":{"toString":"go.mod"}},{"type":"ADD","path":{"toString":"new.go"}}],` +
+				`"isLastPage":false,"nextPageStart":2}`
+		case "/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/changes?start=2":
+			response = `{"values":[{"type":"MOVE","path":{"toString":"b/a.go"},"srcPath":{"toString":"a/a.go"}}],"isLastPage":true}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "go.mod", Status: FileModified},
+		{Path: "new.go", Status: FileAdded},
+		{Path: "b/a.go", PreviousPath: "a/a.go", Status: FileRenamed},
+	}, files)
+
+	_, err = client.ListPullRequestFiles(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	expectedDiff := "diff --git a/go.mod b/go.mod\n"
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, []byte(expectedDiff),
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1.diff", createBitbucketServerHandler)
+	defer cleanUp()
+
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDiff, diff)
+}
+
+func TestBitbucketServer_ListPullRequestCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_list_response.json"))
+	assert.NoError(t, err)
+	client, serverUrl, cleanUp := createServerWithUrlAndClientReturningStatus(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1/commits?start=0", owner, repo1),
+		http.StatusOK, createBitbucketServerHandler)
+	defer cleanUp()
+
+	commits, err := client.ListPullRequestCommits(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []CommitInfo{{
+		Hash:          "def0123abcdef4567abcdef8987abcdef6543abc",
+		AuthorName:    "charlie",
+		CommitterName: "mark",
+		Url:           serverUrl + "/rest/api/1.0/projects/jfrog/repos/repo-1/commits/def0123abcdef4567abcdef8987abcdef6543abc",
+		Timestamp:     1548720847610,
+		Message:       "More work on feature 1",
+		ParentHashes:  []string{"abcdef0123abcdef4567abcdef8987abcdef6543", "qwerty0123abcdef4567abcdef8987abcdef6543"},
+	}}, commits)
+
+	_, err = createBadBitbucketServerClient(t).ListPullRequestCommits(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetDefaultReviewers(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1":
+			response = `{"id":7,"slug":"repo-1"}`
+		case "/rest/default-reviewers/1.0/projects/jfrog/repos/repo-1/reviewers?sourceRefId=refs%2Fheads%2Fbranch-1&sourceRepoId=7&targetRefId=refs%2Fheads%2Fmaster&targetRepoId=7":
+			response = `[{"name":"frogger","slug":"frogger"},{"name":"toad","slug":"toad"}]`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	reviewers, err := client.GetDefaultReviewers(ctx, owner, repo1, branch1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"frogger", "toad"}, reviewers)
+
+	_, err = client.GetDefaultReviewers(ctx, owner, repo1, branch1, "")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetPullRequestByID(t *testing.T) {
+	ctx := context.Background()
+	response := `{"id":1,"title":"Fix all the bugs","description":"Pull request body","state":"OPEN","open":true,
+"author":{"user":{"name":"charlie"}},"properties":{"mergeResult":{"outcome":"CLEAN"}},
+"fromRef":{"id":"refs/heads/feature","displayId":"feature","repository":{"slug":"fork-repo","project":{"key":"~CHARLIE"}}},
+"toRef":{"id":"refs/heads/master","displayId":"master","repository":{"slug":"repo-1","project":{"key":"jfrog"}}}}`
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, []byte(response),
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	pullRequest, err := client.GetPullRequestByID(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	mergeable := true
+	assert.Equal(t, PullRequestInfo{
+		ID:        1,
+		Source:    BranchInfo{Name: "feature", Repository: "fork-repo", Owner: "~CHARLIE"},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		Title:     "Fix all the bugs",
+		Body:      "Pull request body",
+		State:     PullRequestOpen,
+		Author:    "charlie",
+		Mergeable: &mergeable,
+	}, pullRequest)
+
+	_, err = createBadBitbucketServerClient(t).GetPullRequestByID(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetPullRequestMergeableState(t *testing.T) {
+	ctx := context.Background()
+	response := `{"canMerge":false,"conflicted":true,"outcome":"CONFLICTED","vetoes":[{"summaryMessage":"Requires approvals"}]}`
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, []byte(response),
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1/merge", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	state, err := client.GetPullRequestMergeableState(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestMergeableState{HasConflicts: true}, state)
+
+	_, err = createBadBitbucketServerClient(t).GetPullRequestMergeableState(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_ListPullRequestComments(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pull_request_comments_list_response.json"))
@@ -235,6 +1057,97 @@ func TestBitbucketServer_ListPullRequestComments(t *testing.T) {
 	}, result[0])
 }
 
+func TestBitbucketServer_UpdateAndDeletePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	commentURI := "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/comments/7"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET " + commentURI:
+			response = []byte(`{"id":7,"version":3,"text":"Old content"}`)
+		case "PUT " + commentURI:
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"version":3,"text":"New content"}`, string(b))
+			response = []byte(`{"id":7,"version":4,"text":"New content"}`)
+		case "DELETE " + commentURI + "?version=3":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, "New content", 1, 7)
+	assert.NoError(t, err)
+
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "", 1, 7)
+	assert.Error(t, err)
+
+	err = client.DeletePullRequestComment(ctx, owner, repo1, 1, 7)
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).UpdatePullRequestComment(ctx, owner, repo1, "New content", 1, 7)
+	assert.Error(t, err)
+
+	err = createBadBitbucketServerClient(t).DeletePullRequestComment(ctx, owner, repo1, 1, 7)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_AddPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/comments", r.Method+" "+r.RequestURI)
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), `"text":"Use a constant"`)
+		assert.Contains(t, string(b), `"line":5`)
+		assert.Contains(t, string(b), `"lineType":"ADDED"`)
+		assert.Contains(t, string(b), `"fileType":"TO"`)
+		assert.Contains(t, string(b), `"path":"main.go"`)
+		w.WriteHeader(http.StatusCreated)
+		_, err = w.Write([]byte(`{"id":1}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	comment := PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go", Line: 5, StartLine: 3}
+	err := client.AddPullRequestReviewComment(ctx, owner, repo1, 1, comment)
+	assert.NoError(t, err)
+
+	err = client.AddPullRequestReviewComment(ctx, owner, repo1, 1, PullRequestReviewComment{Path: "main.go", Line: 5})
+	assert.Error(t, err)
+
+	err = createBadBitbucketServerClient(t).AddPullRequestReviewComment(ctx, owner, repo1, 1, comment)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"isLastPage":true,"values":[` +
+		`{"id":1,"action":"COMMENTED","commentAction":"ADDED","comment":{"id":1,"text":"General comment","createdDate":1548720847370}},` +
+		`{"id":2,"action":"COMMENTED","commentAction":"ADDED","comment":{"id":2,"text":"Use a constant","createdDate":1548720847370},` +
+		`"commentAnchor":{"line":5,"lineType":"ADDED","fileType":"TO","path":"main.go"}}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1/activities?start=0", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewComment{{
+		CommentInfo: CommentInfo{ID: 2, Content: "Use a constant", Created: time.UnixMilli(1548720847370)},
+		Path:        "main.go",
+		Line:        5,
+	}}, result)
+
+	_, err = createBadBitbucketServerClient(t).ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_GetLatestCommit(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_list_response.json"))
@@ -307,108 +1220,323 @@ func TestBitbucketServer_GetLatestCommitUnknownBranch(t *testing.T) {
 
 	result, err := client.GetLatestCommit(ctx, owner, repo1, "unknown")
 
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "Status: 404 Not Found")
-	assert.Empty(t, result)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Status: 404 Not Found")
+	assert.Empty(t, result)
+}
+
+func TestBitbucketServer_AddSshKeyToRepository(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "add_ssh_key_response.json"))
+	assert.NoError(t, err)
+
+	expectedBody := []byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_READ"}` + "\n")
+
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false,
+		response, fmt.Sprintf("/keys/1.0/projects/%s/repos/%s/ssh", owner, repo1), http.StatusOK,
+		expectedBody, http.MethodPost,
+		createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	err = client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", Read)
+	require.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", Read)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_AddSshKeyToRepositoryReadWrite(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "add_ssh_key_response.json"))
+	assert.NoError(t, err)
+
+	expectedBody := []byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_WRITE"}` + "\n")
+
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false,
+		response, fmt.Sprintf("/keys/1.0/projects/%s/repos/%s/ssh", owner, repo1), http.StatusOK,
+		expectedBody, http.MethodPost,
+		createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	err = client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", ReadWrite)
+
+	require.NoError(t, err)
+}
+
+func TestBitbucketServer_AddSshKeyToRepositoryNotFound(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{
+		"errors": [
+			{
+				"context": null,
+				"exceptionName": "com.atlassian.bitbucket.project.NoSuchProjectException",
+				"message": "Project unknown does not exist."
+			}
+		]
+	}`)
+
+	expectedBody := []byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_READ"}` + "\n")
+
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false,
+		response, fmt.Sprintf("/keys/1.0/projects/%s/repos/%s/ssh", "unknown", repo1), http.StatusNotFound,
+		expectedBody, http.MethodPost,
+		createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	err := client.AddSshKeyToRepository(ctx, "unknown", repo1, "My deploy key", "ssh-rsa AAAA...", Read)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404 Not Found")
+}
+
+func TestBitbucketServer_ListDeployKeys(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"key":{"id":7,"text":"ssh-rsa AAAA...","label":"frogbot"},"permission":"REPO_WRITE"}],"isLastPage":true}`)
+	client, closeServer := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/keys/1.0/projects/%s/repos/%s/ssh?start=0", owner, repo1), createBitbucketServerHandler)
+	defer closeServer()
+
+	keys, err := client.ListDeployKeys(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []DeployKeyInfo{{ID: 7, Title: "frogbot", Key: "ssh-rsa AAAA..."}}, keys)
+
+	_, err = createBadBitbucketServerClient(t).ListDeployKeys(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_AddDeployKey(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "add_ssh_key_response.json"))
+	require.NoError(t, err)
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/keys/1.0/projects/%s/repos/%s/ssh", owner, repo1), http.StatusCreated,
+		[]byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_READ"}`+"\n"), http.MethodPost,
+		createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	key, err := client.AddDeployKey(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", true)
+	require.NoError(t, err)
+	assert.Equal(t, DeployKeyInfo{ID: 1, Title: "My deploy key", Key: "ssh-rsa AAAA..."}, key)
+
+	_, err = createBadBitbucketServerClient(t).AddDeployKey(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", true)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_DeleteDeployKey(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, []byte{},
+		fmt.Sprintf("/rest/keys/1.0/projects/%s/repos/%s/ssh/7", owner, repo1), http.StatusNoContent, []byte{}, http.MethodDelete,
+		createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	err := client.DeleteDeployKey(ctx, owner, repo1, 7)
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).DeleteDeployKey(ctx, owner, repo1, 7)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetRepositoryInfo(t *testing.T) {
+	ctx := context.Background()
+
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "repository_response.json"))
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1":
+			_, err := w.Write(response)
+			assert.NoError(t, err)
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/branches/default":
+			_, err := w.Write([]byte(`{"id":"refs/heads/main","displayId":"main","type":"BRANCH","isDefault":true}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	res, err := client.GetRepositoryInfo(ctx, owner, repo1)
+	require.NoError(t, err)
+	require.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Public,
+			CloneInfo: CloneInfo{
+				HTTP: "https://bitbucket.org/jfrog/repo-1.git",
+				SSH:  "ssh://git@bitbucket.org:jfrog/repo-1.git",
+			},
+			DefaultBranch: "main",
+		},
+		res,
+	)
+
+	_, err = createBadBitbucketServerClient(t).GetRepositoryInfo(ctx, owner, repo1)
+	assert.Error(t, err)
 }
 
-func TestBitbucketServer_AddSshKeyToRepository(t *testing.T) {
+func TestBitbucketServer_GetRepositoryInfoOfEmptyRepository(t *testing.T) {
 	ctx := context.Background()
-	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "add_ssh_key_response.json"))
-	assert.NoError(t, err)
-
-	expectedBody := []byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_READ"}` + "\n")
-
-	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false,
-		response, fmt.Sprintf("/keys/1.0/projects/%s/repos/%s/ssh", owner, repo1), http.StatusOK,
-		expectedBody, http.MethodPost,
-		createBitbucketServerWithBodyHandler)
-	defer closeServer()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "repository_response.json"))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/rest/api/1.0/projects/jfrog/repos/repo-1/branches/default" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
 
-	err = client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", Read)
+	res, err := client.GetRepositoryInfo(ctx, owner, repo1)
 	require.NoError(t, err)
+	assert.Empty(t, res.DefaultBranch)
+}
 
-	err = createBadBitbucketServerClient(t).AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", Read)
-	assert.Error(t, err)
+func TestBitbucketServer_GetRepositoryLanguages(t *testing.T) {
+	_, err := createBadBitbucketServerClient(t).GetRepositoryLanguages(context.Background(), owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketServerLanguagesNotSupported)
 }
 
-func TestBitbucketServer_AddSshKeyToRepositoryReadWrite(t *testing.T) {
+func TestBitbucketServer_CreateRepository(t *testing.T) {
 	ctx := context.Background()
-	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "add_ssh_key_response.json"))
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, []byte(`{"slug":"repo-1"}`),
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos", owner), http.StatusCreated,
+		[]byte(`{"name":"repo-1","description":"Frogs","scmId":"git","public":true}`), http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer closeServer()
+
+	public := Public
+	err := client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Description: "Frogs", Visibility: &public})
 	assert.NoError(t, err)
 
-	expectedBody := []byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_WRITE"}` + "\n")
+	err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{InitWithReadme: true})
+	assert.ErrorIs(t, err, errBitbucketInitRepositoryWithReadmeNotSupported)
 
-	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false,
-		response, fmt.Sprintf("/keys/1.0/projects/%s/repos/%s/ssh", owner, repo1), http.StatusOK,
-		expectedBody, http.MethodPost,
+	err = createBadBitbucketServerClient(t).CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{})
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_DeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, []byte{},
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s", owner, repo1), http.StatusAccepted, []byte{}, http.MethodDelete,
 		createBitbucketServerWithBodyHandler)
 	defer closeServer()
 
-	err = client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", ReadWrite)
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).DeleteRepository(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "repository_response.json"))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"project":{"key":"PRJ"}}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+		case "/rest/api/1.0/projects/PRJ/repos/jfrog-setup-cli":
+			assert.Equal(t, http.MethodGet, r.Method)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
 
+	forkInfo, err := client.ForkRepository(ctx, owner, repo1, "PRJ", true)
 	require.NoError(t, err)
+	assert.Equal(t, ForkInfo{
+		Owner:      "PRJ",
+		Repository: "jfrog-setup-cli",
+		RepositoryInfo: RepositoryInfo{
+			RepositoryVisibility: Public,
+			CloneInfo:            CloneInfo{HTTP: "https://bitbucket.org/jfrog/repo-1.git", SSH: "ssh://git@bitbucket.org:jfrog/repo-1.git"},
+		},
+	}, forkInfo)
+
+	_, err = createBadBitbucketServerClient(t).ForkRepository(ctx, owner, repo1, "", false)
+	assert.Error(t, err)
 }
 
-func TestBitbucketServer_AddSshKeyToRepositoryNotFound(t *testing.T) {
+func TestBitbucketServer_ListCollaborators(t *testing.T) {
 	ctx := context.Background()
-	response := []byte(`{
-		"errors": [
-			{
-				"context": null,
-				"exceptionName": "com.atlassian.bitbucket.project.NoSuchProjectException",
-				"message": "Project unknown does not exist."
-			}
-		]
-	}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/permissions/users?start=0":
+			response = `{"values":[{"user":{"name":"frogger"},"permission":"REPO_ADMIN"}],"isLastPage":false,"nextPageStart":1}`
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/permissions/users?start=1":
+			response = `{"values":[{"user":{"name":"toad"},"permission":"REPO_WRITE"}],"isLastPage":true}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
 
-	expectedBody := []byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_READ"}` + "\n")
+	collaborators, err := client.ListCollaborators(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "frogger", Permission: PermissionAdmin}, {Username: "toad", Permission: PermissionWrite}}, collaborators)
 
-	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false,
-		response, fmt.Sprintf("/keys/1.0/projects/%s/repos/%s/ssh", "unknown", repo1), http.StatusNotFound,
-		expectedBody, http.MethodPost,
-		createBitbucketServerWithBodyHandler)
+	_, err = createBadBitbucketServerClient(t).ListCollaborators(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_AddCollaborator(t *testing.T) {
+	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, []byte{},
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/permissions/users?name=%s&permission=REPO_READ", owner, repo1, username),
+		http.StatusNoContent, []byte{}, http.MethodPut, createBitbucketServerWithBodyHandler)
 	defer closeServer()
 
-	err := client.AddSshKeyToRepository(ctx, "unknown", repo1, "My deploy key", "ssh-rsa AAAA...", Read)
+	err := client.AddCollaborator(ctx, owner, repo1, username, PermissionRead)
+	assert.NoError(t, err)
 
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "status: 404 Not Found")
+	err = createBadBitbucketServerClient(t).AddCollaborator(ctx, owner, repo1, username, PermissionRead)
+	assert.Error(t, err)
 }
 
-func TestBitbucketServer_GetRepositoryInfo(t *testing.T) {
+func TestBitbucketServer_RemoveCollaborator(t *testing.T) {
 	ctx := context.Background()
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, []byte{},
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/permissions/users?name=%s", owner, repo1, username),
+		http.StatusNoContent, []byte{}, http.MethodDelete, createBitbucketServerWithBodyHandler)
+	defer closeServer()
 
-	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "repository_response.json"))
+	err := client.RemoveCollaborator(ctx, owner, repo1, username)
 	assert.NoError(t, err)
 
-	client, cleanUp := createServerAndClientReturningStatus(
-		t,
-		vcsutils.BitbucketServer,
-		false,
-		response,
-		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s", owner, repo1),
-		http.StatusOK,
-		createBitbucketServerHandler,
-	)
-	defer cleanUp()
+	err = createBadBitbucketServerClient(t).RemoveCollaborator(ctx, owner, repo1, username)
+	assert.Error(t, err)
+}
 
-	t.Run("ok", func(t *testing.T) {
-		res, err := client.GetRepositoryInfo(ctx, owner, repo1)
-		require.NoError(t, err)
-		require.Equal(t,
-			RepositoryInfo{
-				RepositoryVisibility: Public,
-				CloneInfo: CloneInfo{
-					HTTP: "https://bitbucket.org/jfrog/repo-1.git",
-					SSH:  "ssh://git@bitbucket.org:jfrog/repo-1.git",
-				},
-			},
-			res,
-		)
-	})
+func TestBitbucketServer_GetUserPermission(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values":[{"user":{"name":"frogger2"},"permission":"REPO_ADMIN"},{"user":{"name":"Frogger"},"permission":"REPO_WRITE"}],"isLastPage":true}`)
+	client, closeServer := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/permissions/users?filter=%s&start=0", owner, repo1, username),
+		createBitbucketServerHandler)
+	defer closeServer()
 
-	_, err = createBadBitbucketServerClient(t).GetRepositoryInfo(ctx, owner, repo1)
+	permission, err := client.GetUserPermission(ctx, owner, repo1, username)
+	require.NoError(t, err)
+	assert.Equal(t, PermissionWrite, permission)
+
+	_, err = createBadBitbucketServerClient(t).GetUserPermission(ctx, owner, repo1, username)
 	assert.Error(t, err)
 }
 
@@ -439,6 +1567,36 @@ func TestBitbucketServer_ListPullRequestLabels(t *testing.T) {
 	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
+func TestBitbucketServer_ListLabels(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	_, err = client.ListLabels(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+	_, err = client.ListLabelsPager(owner, repo1, 0).Next(ctx)
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+	err = client.UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: "new-name"})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+	err = client.DeleteLabel(ctx, owner, repo1, labelName)
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
+func TestBitbucketServer_Issues(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	_, err = client.CreateIssue(ctx, owner, repo1, "title", "body", nil)
+	assert.ErrorIs(t, err, errIssuesNotSupported)
+	err = client.AddIssueComment(ctx, owner, repo1, "comment", 1)
+	assert.ErrorIs(t, err, errIssuesNotSupported)
+	_, err = client.ListIssues(ctx, owner, repo1, IssueFilter{})
+	assert.ErrorIs(t, err, errIssuesNotSupported)
+	err = client.UpdateIssueState(ctx, owner, repo1, 1, IssueClosed)
+	assert.ErrorIs(t, err, errIssuesNotSupported)
+}
+
 func TestBitbucketServer_UnlabelPullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
@@ -457,6 +1615,19 @@ func TestBitbucketServer_GetRepositoryEnvironmentInfo(t *testing.T) {
 	assert.ErrorIs(t, err, errBitbucketGetRepoEnvironmentInfoNotSupported)
 }
 
+func TestBitbucketServer_Deployments(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	_, err = client.ListEnvironments(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+	_, err = client.CreateDeployment(ctx, owner, repo1, DeploymentInfo{Environment: envName, Ref: branch1})
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+	err = client.SetDeploymentStatus(ctx, owner, repo1, 1, DeploymentStatusInfo{State: Pass})
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+}
+
 func TestBitbucketServer_GetCommitBySha(t *testing.T) {
 	ctx := context.Background()
 	sha := "abcdef0123abcdef4567abcdef8987abcdef6543"
@@ -511,6 +1682,139 @@ func TestBitbucketServer_GetCommitByShaNotFound(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestBitbucketServer_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	commitsURI := "/rest/api/1.0/projects/jfrog/repos/repo-1/commits?limit=2&limit=2&path=README.md"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case commitsURI + "&start=0&until=master":
+			response = `{"values":[{"id":"sha1","author":{"name":"frogger"},"committerTimestamp":1700000000000},{"id":"sha2","author":{"name":"frogger"},"committerTimestamp":1600000000000}],"isLastPage":false,"nextPageStart":2}`
+		case commitsURI + "&start=2&until=master":
+			response = `{"values":[{"id":"sha3","author":{"name":"toad","emailAddress":"frogger@example.com"},"committerTimestamp":1700000000000,"parents":[{"id":"sha4"}]}],"isLastPage":true}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	// The author and date filters are applied by the client
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+	options := ListCommitsOptions{Branch: "master", Path: "README.md", Author: "frogger@example.com", Since: time.Unix(1650000000, 0), PerPage: 2}
+	result, err := client.ListCommits(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "sha3", result[0].Hash)
+	assert.Equal(t, []string{"sha4"}, result[0].ParentHashes)
+
+	options = ListCommitsOptions{Branch: "master", Path: "README.md", Author: "frogger", Page: 1, PerPage: 2}
+	result, err = client.ListCommits(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "sha1", result[0].Hash)
+	assert.Equal(t, "sha2", result[1].Hash)
+}
+
+func TestBitbucketServer_GetCommit(t *testing.T) {
+	ctx := context.Background()
+	sha := "abcdef0123abcdef4567abcdef8987abcdef6543"
+	commitResponse, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_single_response.json"))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/commits/" + sha:
+			response = commitResponse
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/commits/" + sha + "/changes?start=0":
+			response = []byte(`{"values":[{"type":"ADD","path":{"toString":"new.txt"}},{"type":"MOVE","path":{"toString":"b.txt"},"srcPath":{"toString":"a.txt"}}],"isLastPage":true}`)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	result, err := buildClient(t, vcsutils.BitbucketServer, true, server).GetCommit(ctx, owner, repo1, sha)
+	require.NoError(t, err)
+	assert.Equal(t, sha, result.Hash)
+	assert.Equal(t, "WIP on feature 1", result.Message)
+	assert.Equal(t, []string{"bbcdef0123abcdef4567abcdef8987abcdef6543"}, result.ParentHashes)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "new.txt", Status: FileAdded},
+		{Path: "b.txt", PreviousPath: "a.txt", Status: FileRenamed},
+	}, result.Files)
+
+	_, err = createBadBitbucketServerClient(t).GetCommit(ctx, owner, repo1, sha)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/commits?since=main&start=0&until=feature":
+			response = `{"values":[{"id":"sha1","message":"Add feature","author":{"name":"charlie"},"committer":{"name":"mark"},"parents":[{"id":"sha0"}]}],"isLastPage":true}`
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/commits?since=feature&start=0&until=main":
+			response = `{"values":[{"id":"sha2"},{"id":"sha3"}],"isLastPage":true}`
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/compare/changes?from=feature&start=0&to=main":
+			response = `{"values":[{"type":"MODIFY","path":{"toString":"README.md"}},{"type":"MOVE","path":{"toString":"b.txt"},"srcPath":{"toString":"a.txt"}}],"isLastPage":true}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	result, err := buildClient(t, vcsutils.BitbucketServer, true, server).CompareCommits(ctx, owner, repo1, "main", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.AheadBy)
+	assert.Equal(t, 2, result.BehindBy)
+	require.Len(t, result.Commits, 1)
+	assert.Equal(t, "sha1", result.Commits[0].Hash)
+	assert.Equal(t, "charlie", result.Commits[0].AuthorName)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "README.md", Status: FileModified},
+		{Path: "b.txt", PreviousPath: "a.txt", Status: FileRenamed},
+	}, result.Files)
+
+	_, err = createBadBitbucketServerClient(t).CompareCommits(ctx, owner, repo1, "main", "feature")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetCommitsBetween(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/commits?since=sha0&start=0&until=sha3":
+			response = `{"values":[{"id":"sha3","message":"Third","author":{"name":"charlie"}},{"id":"sha2"}],"isLastPage":false,"nextPageStart":2}`
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/commits?since=sha0&start=2&until=sha3":
+			response = `{"values":[{"id":"sha1"}],"isLastPage":true}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	commits, err := buildClient(t, vcsutils.BitbucketServer, true, server).GetCommitsBetween(ctx, owner, repo1, "sha0", "sha3")
+	require.NoError(t, err)
+	require.Len(t, commits, 3)
+	assert.Equal(t, "sha3", commits[0].Hash)
+	assert.Equal(t, "Third", commits[0].Message)
+	assert.Equal(t, "charlie", commits[0].AuthorName)
+	assert.Equal(t, "sha1", commits[2].Hash)
+
+	_, err = createBadBitbucketServerClient(t).GetCommitsBetween(ctx, owner, repo1, "sha0", "sha3")
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_UploadCodeScanning(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, "", "unsupportedTest", createBitbucketServerHandler)
@@ -541,6 +1845,169 @@ func TestBitbucketServer_DownloadFileFromRepo(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_GetFileContent(t *testing.T) {
+	ctx := context.Background()
+	expectedPayload := []byte("hello world")
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, expectedPayload, "/rest/api/1.0/projects/jfrog/repos/repo-1/raw/hello-world?at=branch-1", createBitbucketServerDownloadFileFromRepositoryHandler)
+	defer cleanUp()
+
+	fileContent, err := client.GetFileContent(ctx, owner, repo1, branch1, "hello-world")
+	assert.NoError(t, err)
+	assert.Equal(t, FileContent{Path: "hello-world", Content: expectedPayload, Size: int64(len(expectedPayload))}, fileContent)
+
+	_, err = client.GetFileContent(ctx, owner, repo1, branch1, "bad-test")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetFileBlame(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[
+		{"author":{"name":"frogger","emailAddress":"frogger@jfrog.com"},"authorTimestamp":1679151388000,"commitId":"sha1","lineNumber":1,"spannedLines":2},
+		{"author":{"name":"user","emailAddress":"user@example.com"},"authorTimestamp":1679217652000,"commitHash":"sha2","lineNumber":3,"spannedLines":1}
+	]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		"/api/1.0/projects/jfrog/repos/repo-1/browse/src/go.mod?at=branch-1&blame&noContent", createBitbucketServerHandler)
+	defer cleanUp()
+
+	blame, err := client.GetFileBlame(ctx, owner, repo1, branch1, "src/go.mod")
+	assert.NoError(t, err)
+	assert.Equal(t, []BlameLine{
+		{Line: 1, CommitHash: "sha1", AuthorName: "frogger", AuthorEmail: "frogger@jfrog.com", Timestamp: 1679151388},
+		{Line: 2, CommitHash: "sha1", AuthorName: "frogger", AuthorEmail: "frogger@jfrog.com", Timestamp: 1679151388},
+		{Line: 3, CommitHash: "sha2", AuthorName: "user", AuthorEmail: "user@example.com", Timestamp: 1679217652},
+	}, blame)
+
+	_, err = createBadBitbucketServerClient(t).GetFileBlame(ctx, owner, repo1, branch1, "src/go.mod")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListRepositoryTree(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/1.0/projects/jfrog/repos/repo-1/browse/?at=branch-1&start=0":
+			response = `{"children":{"isLastPage":false,"nextPageStart":1,"values":[{"path":{"toString":"go.mod"},"type":"FILE","size":12}]}}`
+		case "/api/1.0/projects/jfrog/repos/repo-1/browse/?at=branch-1&start=1":
+			response = `{"children":{"isLastPage":true,"values":[{"path":{"toString":"src"},"type":"DIRECTORY"},{"path":{"toString":"lib"},"type":"SUBMODULE"}]}}`
+		case "/api/1.0/projects/jfrog/repos/repo-1/browse/src?at=branch-1&start=0":
+			response = `{"children":{"isLastPage":true,"values":[{"path":{"toString":"main.go"},"type":"FILE","size":120}]}}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	tree, err := client.ListRepositoryTree(ctx, owner, repo1, branch1, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []TreeEntry{
+		{Path: "go.mod", Type: TreeEntryFile, Size: 12},
+		{Path: "src", Type: TreeEntryDirectory},
+		{Path: "lib", Type: TreeEntrySubmodule},
+		{Path: "src/main.go", Type: TreeEntryFile, Size: 120},
+	}, tree)
+
+	// The subdirectories aren't listed
+	tree, err = client.ListRepositoryTree(ctx, owner, repo1, branch1, false)
+	assert.NoError(t, err)
+	assert.Len(t, tree, 3)
+
+	_, err = createBadBitbucketServerClient(t).ListRepositoryTree(ctx, owner, repo1, branch1, false)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/search/latest/search", r.RequestURI)
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var response string
+		switch string(body) {
+		case `{"query":"exec.Command project:jfrog","entities":{"code":{"start":0,"limit":1}}}` + "\n":
+			response = `{"code":{"isLastPage":false,"nextStart":1,"values":[{"repository":{"slug":"repo-1","project":{"key":"jfrog"}},"file":"main.go",
+				"hitContexts":[[{"line":3,"text":"func run() {"},{"line":4,"text":"cmd := <em>exec.Command</em>(&quot;ls&quot;)"}]]}]}}`
+		case `{"query":"exec.Command project:jfrog","entities":{"code":{"start":1,"limit":1}}}` + "\n":
+			response = `{"code":{"isLastPage":true,"values":[{"repository":{"slug":"repo-2","project":{"key":"jfrog"}},"file":"run.go"}]}}`
+		default:
+			assert.Fail(t, "Unexpected request body "+string(body))
+		}
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	results, err := client.SearchCode(ctx, CodeSearchScope{Owner: owner, PerPage: 1}, "exec.Command")
+	require.NoError(t, err)
+	assert.Equal(t, []CodeSearchResult{
+		{Owner: owner, Repository: repo1, Path: "main.go", Fragments: []string{"func run() {\ncmd := exec.Command(\"ls\")"}},
+		{Owner: owner, Repository: repo2, Path: "run.go"},
+	}, results)
+
+	_, err = createBadBitbucketServerClient(t).SearchCode(ctx, CodeSearchScope{Owner: owner}, "exec.Command")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CreateOrUpdateFile(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "GET /rest/api/1.0/projects/jfrog/repos/repo-1/raw/hello-world?at=branch-1":
+			_, err := w.Write([]byte("hello world"))
+			assert.NoError(t, err)
+		case "GET /rest/api/1.0/projects/jfrog/repos/repo-1/raw/new-file?at=branch-1":
+			w.WriteHeader(http.StatusNotFound)
+		case "GET /rest/api/1.0/projects/jfrog/repos/repo-1/commits?limit=1&limit=1&until=branch-1":
+			_, err := w.Write([]byte(`{"values":[{"id":"abc123"}],"isLastPage":true}`))
+			assert.NoError(t, err)
+		case "PUT /rest/api/1.0/projects/jfrog/repos/repo-1/browse/hello-world":
+			assert.NoError(t, r.ParseMultipartForm(1024))
+			assert.Equal(t, "abc123", r.FormValue("sourceCommitId"))
+			assertBitbucketServerFileForm(t, r)
+		case "PUT /rest/api/1.0/projects/jfrog/repos/repo-1/browse/new-file":
+			assert.NoError(t, r.ParseMultipartForm(1024))
+			assert.Empty(t, r.FormValue("sourceCommitId"))
+			assertBitbucketServerFileForm(t, r)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	err := client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "hello-world", []byte("hello world"), "Update hello-world")
+	assert.NoError(t, err)
+
+	err = client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "new-file", []byte("hello world"), "Add new-file")
+	assert.NoError(t, err)
+
+	err = client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "", []byte("hello world"), "Add new-file")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, "", createBitbucketServerHandler)
+	defer cleanUp()
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", []FileChange{{Type: DeleteFile, Path: "go.sum"}})
+	assert.ErrorIs(t, err, errBitbucketServerCommitFilesNotSupported)
+}
+
+func assertBitbucketServerFileForm(t *testing.T, r *http.Request) {
+	assert.Equal(t, branch1, r.FormValue("branch"))
+	assert.NotEmpty(t, r.FormValue("message"))
+	file, _, err := r.FormFile("content")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", string(content))
+}
+
 func TestBitbucketServer_getRepositoryVisibility(t *testing.T) {
 	assert.Equal(t, Public, getBitbucketServerRepositoryVisibility(true))
 	assert.Equal(t, Private, getBitbucketServerRepositoryVisibility(false))
@@ -615,3 +2082,112 @@ func createBadBitbucketServerClient(t *testing.T) VcsClient {
 	require.NoError(t, err)
 	return client
 }
+
+func TestBitbucketServer_GetCommitVerification(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+	_, err = client.GetCommitVerification(context.Background(), owner, repo1, "sha1")
+	assert.ErrorIs(t, err, errBitbucketCommitVerificationNotSupported)
+}
+
+func TestBitbucketServer_ListSecurityAlerts(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+	_, err = client.ListSecurityAlerts(context.Background(), owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketSecurityAlertsNotSupported)
+}
+
+func TestBitbucketServer_UploadScanResults(t *testing.T) {
+	ctx := context.Background()
+	commitsResponse, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_list_response.json"))
+	require.NoError(t, err)
+	reportURI := "/rest/insights/1.0/projects/jfrog/repos/repo-1/commits/def0123abcdef4567abcdef8987abcdef6543abc/reports/jfrog-xray"
+	var report bitbucketServerReport
+	var annotations bitbucketServerAnnotationsRequest
+	var deletedAnnotations bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /rest/api/1.0/projects/jfrog/repos/repo-1/commits?limit=1&limit=1&until=master":
+			response = commitsResponse
+		case "PUT " + reportURI:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+			response = []byte("{}")
+		case "DELETE " + reportURI + "/annotations":
+			deletedAnnotations = true
+			w.WriteHeader(http.StatusNoContent)
+		case "POST " + reportURI + "/annotations":
+			assert.True(t, deletedAnnotations)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&annotations))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	reportID, err := client.UploadScanResults(ctx, owner, repo1, "master", testSarifScanResults)
+	require.NoError(t, err)
+	assert.Equal(t, "jfrog-xray", reportID)
+	assert.Equal(t, bitbucketServerReport{Title: "JFrog Xray", Details: "2 issues found", Reporter: "JFrog Xray", Result: "FAIL"}, report)
+	assert.Equal(t, []bitbucketServerAnnotation{
+		{ExternalID: "jfrog-xray-1", Type: "VULNERABILITY", Message: "Prototype Pollution in lodash: lodash 4.17.15. Fixed in Versions: [4.17.19]",
+			Path: "package.json", Line: 12, Severity: "HIGH"},
+		{ExternalID: "jfrog-xray-2", Type: "VULNERABILITY", Message: "go/unused-variable: Unused variable | x", Path: "main.go", Line: 3, Severity: "LOW"},
+	}, annotations.Annotations)
+}
+
+func TestBitbucketServer_CodeInsights(t *testing.T) {
+	ctx := context.Background()
+	reportURI := "/rest/insights/1.0/projects/jfrog/repos/repo-1/commits/abc123/reports/my-report"
+	var report bitbucketServerReport
+	var annotations bitbucketServerAnnotationsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "PUT " + reportURI:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+			_, err := w.Write([]byte("{}"))
+			assert.NoError(t, err)
+		case "POST " + reportURI + "/annotations":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&annotations))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	err := client.CreateCodeInsightsReport(ctx, owner, repo1, "abc123", CodeInsightsReport{ID: "my-report", Title: "Tests", Details: "Running", Result: InProgress})
+	require.NoError(t, err)
+	assert.Equal(t, bitbucketServerReport{Title: "Tests", Details: "Running"}, report)
+
+	err = client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abc123", "my-report", []CodeInsightsAnnotation{
+		{ExternalID: "1", Type: CodeInsightsBug, Severity: SeverityCritical, Path: "main.go", Line: 7, Message: "Nil dereference", Details: "x may be nil",
+			Link: "https://ci.example.com/1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []bitbucketServerAnnotation{{ExternalID: "1", Type: "BUG", Message: "Nil dereference: x may be nil", Path: "main.go", Line: 7,
+		Severity: "HIGH", Link: "https://ci.example.com/1"}}, annotations.Annotations)
+
+	// No request is sent without annotations
+	err = client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abc123", "my-report", nil)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketServer_PullRequestThreads(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+	_, err = client.CreatePullRequestThread(context.Background(), owner, repo1, 1, PullRequestReviewComment{CommentInfo: CommentInfo{Content: "comment"}})
+	assert.ErrorIs(t, err, errBitbucketPullRequestThreadsNotSupported)
+}
+
+func TestBitbucketServer_CommentReactions(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+	err = client.AddCommentReaction(context.Background(), owner, repo1, 1, 10, ReactionThumbsUp)
+	assert.ErrorIs(t, err, errBitbucketCommentReactionsNotSupported)
+}
@@ -0,0 +1,204 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBulkParallelism = 10
+	// The pause after a rate limited operation, when the VCS provider doesn't report when the rate limit resets
+	defaultBulkRateLimitPause   = time.Minute
+	defaultBulkMaxRateLimitWait = 15 * time.Minute
+)
+
+// ErrBulkOperationSkipped is the error of the operations that RunBulk skipped, after another operation failed with StopOnError
+var ErrBulkOperationSkipped = errors.New("the operation was skipped, since another operation failed")
+
+// BulkOptions configures how RunBulk runs the operations
+type BulkOptions struct {
+	// The maximum number of operations that run at once. Defaults to 10.
+	Parallelism int
+	// The maximum number of times an operation that was rejected due to rate limiting is retried.
+	// Before retrying, all the operations pause until the rate limit resets. Zero disables the retries.
+	MaxRateLimitRetries int
+	// The maximum pause for a rate limit to reset. Operations that should wait longer fail with their rate limit error. Defaults to 15 minutes.
+	MaxRateLimitWait time.Duration
+	// Skip the remaining operations after the first failure
+	StopOnError bool
+}
+
+// BulkError is returned by RunBulk when some of the operations failed
+type BulkError struct {
+	// The errors of the failed and skipped operations, by the index of their item
+	Errors map[int]error
+	// The number of operations, including the successful ones
+	Total int
+}
+
+func (err *BulkError) Error() string {
+	indexes := make([]int, 0, len(err.Errors))
+	for index := range err.Errors {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	return fmt.Sprintf("%d of %d operations failed, the first of which (item %d): %s", len(indexes), err.Total, indexes[0], err.Errors[indexes[0]])
+}
+
+// RunBulk runs the operation on each of the items concurrently, such as setting a commit status on each repository of an organization.
+// Up to options.Parallelism operations run at once. Operations that were rejected due to rate limiting pause all the operations
+// until the rate limit resets, and are retried according to the options. Combine with ClientBuilder.RateLimiter to throttle the requests as well.
+// Returns a *BulkError if any of the operations failed. If the context is done, the operations that didn't start fail with its error.
+func RunBulk[T any](ctx context.Context, items []T, options BulkOptions, operation func(ctx context.Context, item T) error) error {
+	runner := &bulkRunner{options: options, stopped: make(chan struct{})}
+	errs := make([]error, len(items))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < options.parallelism(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				if runner.isStopped() {
+					errs[index] = getBulkSkippedError(ctx)
+					continue
+				}
+				item := items[index]
+				if errs[index] = runner.run(ctx, func(ctx context.Context) error { return operation(ctx, item) }); errs[index] != nil && options.StopOnError {
+					runner.stop()
+				}
+			}
+		}()
+	}
+	started := 0
+feedItems:
+	for ; started < len(items); started++ {
+		select {
+		case indexes <- started:
+		case <-ctx.Done():
+			break feedItems
+		case <-runner.stopped:
+			break feedItems
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	for index := started; index < len(items); index++ {
+		errs[index] = getBulkSkippedError(ctx)
+	}
+	bulkErr := &BulkError{Errors: make(map[int]error), Total: len(items)}
+	for index, err := range errs {
+		if err != nil {
+			bulkErr.Errors[index] = err
+		}
+	}
+	if len(bulkErr.Errors) > 0 {
+		return bulkErr
+	}
+	return nil
+}
+
+// getBulkSkippedError returns the error of an operation that didn't start, because the context is done or another operation failed
+func getBulkSkippedError(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return ErrBulkOperationSkipped
+}
+
+func (options BulkOptions) parallelism() int {
+	if options.Parallelism > 0 {
+		return options.Parallelism
+	}
+	return defaultBulkParallelism
+}
+
+func (options BulkOptions) maxRateLimitWait() time.Duration {
+	if options.MaxRateLimitWait > 0 {
+		return options.MaxRateLimitWait
+	}
+	return defaultBulkMaxRateLimitWait
+}
+
+// bulkRunner runs the operations of RunBulk, and pauses them all when one of them is rejected due to rate limiting
+type bulkRunner struct {
+	options     BulkOptions
+	mutex       sync.Mutex
+	pausedUntil time.Time
+	// Closed when an operation failed with StopOnError
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (runner *bulkRunner) stop() {
+	runner.stopOnce.Do(func() { close(runner.stopped) })
+}
+
+func (runner *bulkRunner) isStopped() bool {
+	select {
+	case <-runner.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+func (runner *bulkRunner) run(ctx context.Context, operation func(ctx context.Context) error) error {
+	for retries := 0; ; retries++ {
+		if err := runner.waitForPause(ctx); err != nil {
+			return err
+		}
+		err := operation(ctx)
+		if err == nil || retries >= runner.options.MaxRateLimitRetries || !errors.Is(err, ErrRateLimited) {
+			return err
+		}
+		pause := getRateLimitPause(err)
+		if pause > runner.options.maxRateLimitWait() {
+			return err
+		}
+		runner.pause(pause)
+	}
+}
+
+func (runner *bulkRunner) pause(pause time.Duration) {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+	if until := time.Now().Add(pause); until.After(runner.pausedUntil) {
+		runner.pausedUntil = until
+	}
+}
+
+func (runner *bulkRunner) waitForPause(ctx context.Context) error {
+	runner.mutex.Lock()
+	pause := time.Until(runner.pausedUntil)
+	runner.mutex.Unlock()
+	if pause <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(pause)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// getRateLimitPause returns the time until the rate limit that rejected the operation resets
+func getRateLimitPause(err error) time.Duration {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		if rateLimitErr.RetryAfter > 0 {
+			return rateLimitErr.RetryAfter
+		}
+		if !rateLimitErr.RateLimit.Reset.IsZero() {
+			return nonNegative(time.Until(rateLimitErr.RateLimit.Reset))
+		}
+	}
+	return defaultBulkRateLimitPause
+}
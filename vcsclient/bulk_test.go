@@ -0,0 +1,129 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBulk(t *testing.T) {
+	var running, maxRunning int32
+	var mutex sync.Mutex
+	done := make(map[int]int)
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i
+	}
+	err := RunBulk(context.Background(), items, BulkOptions{Parallelism: 3}, func(ctx context.Context, item int) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		mutex.Lock()
+		defer mutex.Unlock()
+		done[item]++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, done, len(items))
+	for _, item := range items {
+		assert.Equal(t, 1, done[item])
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+
+	assert.NoError(t, RunBulk(context.Background(), []string{}, BulkOptions{}, func(ctx context.Context, item string) error {
+		return errors.New("no items to run on")
+	}))
+}
+
+func TestRunBulk_Errors(t *testing.T) {
+	err := RunBulk(context.Background(), []string{"repo-0", "repo-1", "repo-2", "repo-3"}, BulkOptions{}, func(ctx context.Context, repository string) error {
+		if repository == "repo-1" || repository == "repo-3" {
+			return fmt.Errorf("%s: %w", repository, ErrNotFound)
+		}
+		return nil
+	})
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, 4, bulkErr.Total)
+	assert.Len(t, bulkErr.Errors, 2)
+	assert.ErrorIs(t, bulkErr.Errors[1], ErrNotFound)
+	assert.ErrorIs(t, bulkErr.Errors[3], ErrNotFound)
+	assert.EqualError(t, err, "2 of 4 operations failed, the first of which (item 1): repo-1: not found")
+}
+
+func TestRunBulk_StopOnError(t *testing.T) {
+	var runs int32
+	err := RunBulk(context.Background(), []int{0, 1, 2, 3}, BulkOptions{Parallelism: 1, StopOnError: true}, func(ctx context.Context, item int) error {
+		atomic.AddInt32(&runs, 1)
+		if item == 1 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&runs))
+	assert.EqualError(t, bulkErr.Errors[1], "failed")
+	assert.ErrorIs(t, bulkErr.Errors[2], ErrBulkOperationSkipped)
+	assert.ErrorIs(t, bulkErr.Errors[3], ErrBulkOperationSkipped)
+}
+
+func TestRunBulk_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := RunBulk(ctx, []int{0, 1}, BulkOptions{}, func(ctx context.Context, item int) error {
+		assert.Fail(t, "Operations shouldn't run after the context is canceled")
+		return nil
+	})
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.ErrorIs(t, bulkErr.Errors[0], context.Canceled)
+	assert.ErrorIs(t, bulkErr.Errors[1], context.Canceled)
+}
+
+func TestRunBulk_RateLimit(t *testing.T) {
+	var attempts int32
+	start := time.Now()
+	err := RunBulk(context.Background(), []int{0}, BulkOptions{MaxRateLimitRetries: 1}, func(ctx context.Context, item int) error {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			return fmt.Errorf("setting the commit status: %w", &RateLimitError{StatusCode: 429, RetryAfter: 50 * time.Millisecond})
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// The rate limit resets later than the maximum wait, so the operation isn't retried
+	attempts = 0
+	err = RunBulk(context.Background(), []int{0}, BulkOptions{MaxRateLimitRetries: 1, MaxRateLimitWait: time.Second}, func(ctx context.Context, item int) error {
+		atomic.AddInt32(&attempts, 1)
+		return &RateLimitError{StatusCode: 403, RateLimit: RateLimitInfo{Reset: time.Now().Add(time.Hour)}}
+	})
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.ErrorIs(t, bulkErr.Errors[0], ErrRateLimited)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+
+	// Retries are disabled by default
+	attempts = 0
+	err = RunBulk(context.Background(), []int{0}, BulkOptions{}, func(ctx context.Context, item int) error {
+		atomic.AddInt32(&attempts, 1)
+		return &RateLimitError{StatusCode: 429, RetryAfter: time.Millisecond}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
@@ -0,0 +1,253 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The paths searched for the CODEOWNERS file. GitHub searches the .github, root and docs directories in this order,
+// while GitLab and Gitea search the root and docs directories before the .gitlab and .gitea directories.
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS", ".gitea/CODEOWNERS"}
+
+// CodeOwnersRule is a line of a CODEOWNERS file, which assigns owners to the paths that match its pattern
+type CodeOwnersRule struct {
+	// The gitignore-style pattern of the paths, such as *.go, /docs/ or src/**/test
+	Pattern string
+	// The users, groups, teams or emails that own the matching paths, such as @user or @org/team. Empty if the paths have no owners
+	Owners []string
+	// The GitLab section of the rule. Empty if the rule isn't in a section
+	Section string
+	regexp  *regexp.Regexp
+}
+
+// CodeOwners is a parsed CODEOWNERS file
+type CodeOwners struct {
+	Rules []CodeOwnersRule
+}
+
+// ParseCodeOwners parses the content of a CODEOWNERS file, in either the GitHub or the GitLab syntax.
+// GitLab sections are supported, including their default owners.
+func ParseCodeOwners(content []byte) (*CodeOwners, error) {
+	codeOwners := &CodeOwners{}
+	var section string
+	var sectionOwners []string
+	for lineNumber, line := range strings.Split(string(content), "\n") {
+		if match := codeOwnersSectionRegexp.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			section, sectionOwners = match[1], splitCodeOwnersLine(match[2])
+			continue
+		}
+		fields := splitCodeOwnersLine(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rule := CodeOwnersRule{Pattern: fields[0], Owners: fields[1:], Section: section}
+		if len(rule.Owners) == 0 {
+			rule.Owners = sectionOwners
+		}
+		var err error
+		if rule.regexp, err = codeOwnersPatternToRegexp(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid CODEOWNERS pattern in line %d: %w", lineNumber+1, err)
+		}
+		codeOwners.Rules = append(codeOwners.Rules, rule)
+	}
+	return codeOwners, nil
+}
+
+// GetOwners returns the owners of a path in the repository.
+// The last rule that matches the path determines its owners, and in GitLab files, the owners of all the sections are combined.
+func (codeOwners *CodeOwners) GetOwners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	var sections []string
+	sectionOwners := map[string][]string{}
+	for _, rule := range codeOwners.Rules {
+		if !rule.regexp.MatchString(path) {
+			continue
+		}
+		if _, exists := sectionOwners[rule.Section]; !exists {
+			sections = append(sections, rule.Section)
+		}
+		sectionOwners[rule.Section] = rule.Owners
+	}
+	var owners []string
+	for _, section := range sections {
+		owners = appendUniqueOwners(owners, sectionOwners[section]...)
+	}
+	return owners
+}
+
+// GetOwnersOfPaths returns the owners of any of the paths, in the order they are first found
+func (codeOwners *CodeOwners) GetOwnersOfPaths(paths []string) []string {
+	var owners []string
+	for _, path := range paths {
+		owners = appendUniqueOwners(owners, codeOwners.GetOwners(path)...)
+	}
+	return owners
+}
+
+// GetCodeOwners fetches and parses the CODEOWNERS file of a repository, which is searched in the .github, root, docs, .gitlab and .gitea directories.
+// Returns an error that matches ErrNotFound if the repository has no CODEOWNERS file.
+// owner      - User or organization
+// repository - VCS repository name
+// ref        - Branch name, tag or commit SHA
+func GetCodeOwners(ctx context.Context, client VcsClient, owner, repository, ref string) (*CodeOwners, error) {
+	for _, path := range codeOwnersPaths {
+		// The response is recorded, so that a missing file matches ErrNotFound even if the client doesn't wrap its errors
+		fileCtx, recorder := withResponseRecorder(ctx)
+		fileContent, err := client.GetFileContent(fileCtx, owner, repository, ref, path)
+		err = recorder.wrapError(err)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return ParseCodeOwners(fileContent.Content)
+	}
+	return nil, fmt.Errorf("CODEOWNERS file of %s at %s: %w", repository, ref, ErrNotFound)
+}
+
+// GetPullRequestCodeOwners returns the owners of the files changed in a pull request, according to the CODEOWNERS file of its target branch,
+// such as for requesting their review. The owners of both the new and the previous paths of renamed files are returned.
+// owner         - User or organization
+// repository    - VCS repository name
+// pullRequestID - Pull request ID
+func GetPullRequestCodeOwners(ctx context.Context, client VcsClient, owner, repository string, pullRequestID int) ([]string, error) {
+	pullRequest, err := client.GetPullRequestByID(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	codeOwners, err := GetCodeOwners(ctx, client, owner, repository, pullRequest.Target.Name)
+	if err != nil {
+		return nil, err
+	}
+	files, err := client.ListPullRequestFiles(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return codeOwners.GetOwnersOfPaths(getChangedPaths(files)), nil
+}
+
+// getCodeOwnersReviewers returns the owners of the files that the source branch changes, according to the CODEOWNERS file of the target branch,
+// without the @ prefix of users and teams. Returns no reviewers if the repository has no CODEOWNERS file.
+func getCodeOwnersReviewers(ctx context.Context, client VcsClient, owner, repository, sourceBranch, targetBranch string) ([]string, error) {
+	codeOwners, err := GetCodeOwners(ctx, client, owner, repository, targetBranch)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	comparison, err := client.CompareCommits(ctx, owner, repository, targetBranch, sourceBranch)
+	if err != nil {
+		return nil, err
+	}
+	owners := codeOwners.GetOwnersOfPaths(getChangedPaths(comparison.Files))
+	for i := range owners {
+		owners[i] = strings.TrimPrefix(owners[i], "@")
+	}
+	return owners, nil
+}
+
+// getChangedPaths returns the paths of the changed files, including the previous paths of the renamed files
+func getChangedPaths(files []PullRequestFile) []string {
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+		if file.PreviousPath != "" {
+			paths = append(paths, file.PreviousPath)
+		}
+	}
+	return paths
+}
+
+// splitCodeOwnersLine splits a line into its pattern and owners, without its comment.
+// A backslash escapes the next character, such as a space or a # in a pattern.
+func splitCodeOwnersLine(line string) []string {
+	var fields []string
+	var field strings.Builder
+	escaped, inField := false, false
+	for _, char := range strings.TrimSpace(line) {
+		switch {
+		case escaped:
+			field.WriteRune(char)
+			escaped = false
+			continue
+		case char == '\\':
+			escaped, inField = true, true
+			continue
+		case char == '#' && !inField:
+			return fields
+		case char == ' ' || char == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+			continue
+		}
+		field.WriteRune(char)
+		inField = true
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// GitLab section headers are [Section name], optionally preceded by ^ for optional sections,
+// and followed by [the number of required approvals] and the default owners of the section
+var codeOwnersSectionRegexp = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(.*)$`)
+
+// codeOwnersPatternToRegexp converts a gitignore-style pattern to a regexp that matches the paths it applies to.
+// A pattern without a slash, except for a trailing one, matches at any depth, and a pattern that matches a directory matches all its content.
+func codeOwnersPatternToRegexp(pattern string) (*regexp.Regexp, error) {
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+	var expression strings.Builder
+	expression.WriteString("^")
+	if !anchored {
+		expression.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		switch {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			expression.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			expression.WriteString(".*")
+			i++
+		case trimmed[i] == '*':
+			expression.WriteString("[^/]*")
+		case trimmed[i] == '?':
+			expression.WriteString("[^/]")
+		default:
+			expression.WriteString(regexp.QuoteMeta(trimmed[i : i+1]))
+		}
+	}
+	lastSegment := trimmed[strings.LastIndex(trimmed, "/")+1:]
+	switch {
+	case directoryOnly:
+		expression.WriteString("/.*$")
+	case strings.ContainsAny(lastSegment, "*?"):
+		// Wildcards match only the entries of the directory, so docs/* doesn't own docs/sub/b.md
+		expression.WriteString("$")
+	default:
+		// A literal name owns the file or the whole directory with that name
+		expression.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(expression.String())
+}
+
+func appendUniqueOwners(owners []string, newOwners ...string) []string {
+	for _, owner := range newOwners {
+		if !containsFold(owners, owner) {
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
@@ -0,0 +1,183 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gitHubCodeOwners = `# Default owners
+*       @jfrog/maintainers
+
+*.go    @go-owner # Go files
+/docs/  @docs-owner docs@jfrog.com
+src/**/test @test-owner
+/build/logs/
+my\ file.txt @space-owner
+`
+
+const gitLabCodeOwners = `* @default
+
+[Backend][2] @backend-lead
+*.go
+/internal/ @internal-owner
+
+^[Frontend docs]
+*.md @writer
+`
+
+// codeOwnersClient serves the files in its map, and the same changed files for pull requests and commit comparisons
+type codeOwnersClient struct {
+	VcsClient
+	files        map[string]string
+	requestedRef string
+}
+
+func (client *codeOwnersClient) GetFileContent(_ context.Context, owner, repository, ref, path string) (FileContent, error) {
+	client.requestedRef = ref
+	content, exists := client.files[path]
+	if !exists {
+		return FileContent{}, fmt.Errorf("%s: %w", path, ErrNotFound)
+	}
+	return FileContent{Path: path, Content: []byte(content)}, nil
+}
+
+func (client *codeOwnersClient) GetPullRequestByID(_ context.Context, owner, repository string, pullRequestID int) (PullRequestInfo, error) {
+	return PullRequestInfo{ID: int64(pullRequestID), Target: BranchInfo{Name: "master"}}, nil
+}
+
+func (client *codeOwnersClient) ListPullRequestFiles(_ context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	return []PullRequestFile{
+		{Path: "docs/README.md", Status: FileModified},
+		{Path: "cmd/main.go", PreviousPath: "main.go", Status: FileRenamed},
+	}, nil
+}
+
+func (client *codeOwnersClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	files, err := client.ListPullRequestFiles(ctx, owner, repository, 1)
+	return CommitsComparison{Files: files}, err
+}
+
+func TestParseCodeOwners(t *testing.T) {
+	codeOwners, err := ParseCodeOwners([]byte(gitHubCodeOwners))
+	require.NoError(t, err)
+	require.Len(t, codeOwners.Rules, 6)
+	assert.Equal(t, "my file.txt", codeOwners.Rules[5].Pattern)
+	assert.Empty(t, codeOwners.Rules[4].Owners)
+
+	tests := []struct {
+		path           string
+		expectedOwners []string
+	}{
+		{path: "README.md", expectedOwners: []string{"@jfrog/maintainers"}},
+		{path: "vcsclient/github.go", expectedOwners: []string{"@go-owner"}},
+		{path: "/docs/guide/index.md", expectedOwners: []string{"@docs-owner", "docs@jfrog.com"}},
+		{path: "docs", expectedOwners: []string{"@jfrog/maintainers"}},
+		{path: "vcsclient/docs/index.md", expectedOwners: []string{"@jfrog/maintainers"}},
+		{path: "src/test/data.json", expectedOwners: []string{"@test-owner"}},
+		{path: "src/a/b/test/data.json", expectedOwners: []string{"@test-owner"}},
+		{path: "build/logs/output.log", expectedOwners: nil},
+		{path: "dir/my file.txt", expectedOwners: []string{"@space-owner"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expectedOwners, codeOwners.GetOwners(tt.path))
+		})
+	}
+}
+
+func TestParseCodeOwners_Wildcards(t *testing.T) {
+	codeOwners, err := ParseCodeOwners([]byte("docs/* @docs-owner\n*.md @md-owner"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@md-owner"}, codeOwners.GetOwners("docs/a.md"))
+	assert.Equal(t, []string{"@docs-owner"}, codeOwners.GetOwners("docs/a.txt"))
+	// Wildcards match only the direct children of docs
+	assert.Empty(t, codeOwners.GetOwners("docs/sub/b.txt"))
+	assert.Equal(t, []string{"@md-owner"}, codeOwners.GetOwners("docs/sub/b.md"))
+
+	codeOwners, err = ParseCodeOwners([]byte("docs/* @docs-owner"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@docs-owner"}, codeOwners.GetOwners("docs/a.md"))
+	assert.Empty(t, codeOwners.GetOwners("docs/sub/b.md"))
+}
+
+func TestParseCodeOwners_GitLabSections(t *testing.T) {
+	codeOwners, err := ParseCodeOwners([]byte(gitLabCodeOwners))
+	require.NoError(t, err)
+	require.Len(t, codeOwners.Rules, 4)
+	assert.Equal(t, CodeOwnersRule{Pattern: "*.go", Owners: []string{"@backend-lead"}, Section: "Backend"}, withoutRegexp(codeOwners.Rules[1]))
+	assert.Equal(t, "Frontend docs", codeOwners.Rules[3].Section)
+
+	assert.Equal(t, []string{"@default", "@backend-lead"}, codeOwners.GetOwners("main.go"))
+	assert.Equal(t, []string{"@default", "@internal-owner"}, codeOwners.GetOwners("internal/main.go"))
+	assert.Equal(t, []string{"@default", "@writer"}, codeOwners.GetOwners("docs/README.md"))
+	assert.Equal(t, []string{"@default", "@backend-lead", "@writer"}, codeOwners.GetOwnersOfPaths([]string{"main.go", "README.md"}))
+}
+
+func TestGetCodeOwners(t *testing.T) {
+	ctx := context.Background()
+	client := &codeOwnersClient{files: map[string]string{"docs/CODEOWNERS": gitHubCodeOwners, "CODEOWNERS": "* @root-owner"}}
+	codeOwners, err := GetCodeOwners(ctx, client, owner, repo1, branch1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@root-owner"}, codeOwners.GetOwners("main.go"))
+	assert.Equal(t, branch1, client.requestedRef)
+
+	// Like on GitLab, the root directory is searched before the .gitlab directory
+	client = &codeOwnersClient{files: map[string]string{".gitlab/CODEOWNERS": "* @gitlab-owner", "CODEOWNERS": "* @root-owner"}}
+	codeOwners, err = GetCodeOwners(ctx, client, owner, repo1, branch1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@root-owner"}, codeOwners.GetOwners("main.go"))
+
+	_, err = GetCodeOwners(ctx, &codeOwnersClient{}, owner, repo1, branch1)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetCodeOwners_GitHub(t *testing.T) {
+	// The client doesn't wrap its errors, but the missing files are still skipped
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI != "/repos/jfrog/repo-1/contents/CODEOWNERS?ref=branch-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(`{"type":"file","path":"CODEOWNERS","encoding":"base64","content":"KiBAcm9vdC1vd25lcg=="}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	codeOwners, err := GetCodeOwners(context.Background(), buildClient(t, vcsutils.GitHub, false, server), owner, repo1, branch1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@root-owner"}, codeOwners.GetOwners("main.go"))
+}
+
+func TestGetPullRequestCodeOwners(t *testing.T) {
+	client := &codeOwnersClient{files: map[string]string{".github/CODEOWNERS": "* @default\n/main.go @previous-owner\n*.md @writer"}}
+	owners, err := GetPullRequestCodeOwners(context.Background(), client, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@writer", "@default", "@previous-owner"}, owners)
+	assert.Equal(t, "master", client.requestedRef)
+}
+
+func TestGetCodeOwnersReviewers(t *testing.T) {
+	ctx := context.Background()
+	client := &codeOwnersClient{files: map[string]string{"CODEOWNERS": "* @jfrog/maintainers\n*.go @frogger docs@jfrog.com"}}
+	reviewers, err := getCodeOwnersReviewers(ctx, client, owner, repo1, branch1, "master")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jfrog/maintainers", "frogger", "docs@jfrog.com"}, reviewers)
+	assert.Equal(t, "master", client.requestedRef)
+
+	// Without a CODEOWNERS file, there are no default reviewers
+	reviewers, err = getCodeOwnersReviewers(ctx, &codeOwnersClient{}, owner, repo1, branch1, "master")
+	require.NoError(t, err)
+	assert.Empty(t, reviewers)
+}
+
+func withoutRegexp(rule CodeOwnersRule) CodeOwnersRule {
+	rule.regexp = nil
+	return rule
+}
@@ -0,0 +1,115 @@
+package vcsclient
+
+import (
+	"context"
+	"time"
+)
+
+// The interval between polls of the commit statuses, when WaitForCommitStatusOptions.PollInterval isn't set
+const defaultCommitStatusPollInterval = 10 * time.Second
+
+// WaitForCommitStatusOptions configures WaitForCommitStatus
+type WaitForCommitStatusOptions struct {
+	// The titles of the commit statuses and check runs to wait for. If empty, all the commit statuses reported on the ref are waited for
+	RequiredTitles []string
+	// The interval between polls of the commit statuses. If 0, the commit statuses are polled every 10 seconds
+	PollInterval time.Duration
+	// The maximum time to wait. If 0, WaitForCommitStatus waits until the context is done
+	Timeout time.Duration
+}
+
+// WaitForCommitStatus polls the commit statuses and check runs of a ref using ListCommitStatuses,
+// until all the required ones pass, or one of them fails or errors.
+// Returns Pass if all the required commit statuses passed, or the state of the one that failed or errored, along with the latest commit statuses.
+// If the timeout expires or the context is done first, InProgress is returned with the error of the context.
+// owner      - User or organization
+// repository - VCS repository name
+// ref        - Branch name, tag or commit SHA
+func WaitForCommitStatus(ctx context.Context, client VcsClient, owner, repository, ref string, options WaitForCommitStatusOptions) (CommitStatus, []CommitStatusInfo, error) {
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	pollInterval := options.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultCommitStatusPollInterval
+	}
+	var latestStatuses []CommitStatusInfo
+	for {
+		statuses, err := client.ListCommitStatuses(ctx, owner, repository, ref)
+		if err != nil {
+			return InProgress, latestStatuses, err
+		}
+		latestStatuses = statuses
+		if state, completed := getRequiredCommitStatusesState(statuses, options.RequiredTitles); completed {
+			return state, statuses, nil
+		}
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return InProgress, latestStatuses, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// getRequiredCommitStatusesState returns the combined state of the required commit statuses, and whether waiting for them is completed.
+// A required commit status that wasn't reported yet is in progress. A failed or errored commit status completes the wait, since the others can't make it pass.
+func getRequiredCommitStatusesState(statuses []CommitStatusInfo, requiredTitles []string) (CommitStatus, bool) {
+	latestStatuses, reportedTitles := getLatestCommitStatuses(statuses)
+	if len(requiredTitles) == 0 {
+		if len(reportedTitles) == 0 {
+			return InProgress, false
+		}
+		requiredTitles = reportedTitles
+	}
+	state := Pass
+	for _, title := range requiredTitles {
+		status, exists := latestStatuses[title]
+		switch {
+		case !exists || status.State == InProgress:
+			state = InProgress
+		case status.State == Fail || status.State == Error:
+			return status.State, true
+		}
+	}
+	return state, state == Pass
+}
+
+// getLatestCommitStatuses returns the latest commit status of each title, and the titles in the order they were first reported.
+// A commit status may be reported more than once with the same title, such as a rerun check run.
+func getLatestCommitStatuses(statuses []CommitStatusInfo) (map[string]CommitStatusInfo, []string) {
+	latestStatuses := make(map[string]CommitStatusInfo, len(statuses))
+	var titles []string
+	for _, status := range statuses {
+		existingStatus, exists := latestStatuses[status.Title]
+		if !exists {
+			titles = append(titles, status.Title)
+		}
+		if !exists || status.LastUpdatedAt.After(existingStatus.LastUpdatedAt) {
+			latestStatuses[status.Title] = status
+		}
+	}
+	return latestStatuses, titles
+}
+
+// getMissingStatusChecks returns the required status checks that didn't pass on a commit
+func getMissingStatusChecks(ctx context.Context, client VcsClient, owner, repository, ref string, requiredStatusChecks []string) ([]string, error) {
+	if len(requiredStatusChecks) == 0 {
+		return nil, nil
+	}
+	statuses, err := client.ListCommitStatuses(ctx, owner, repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	latestStatuses, _ := getLatestCommitStatuses(statuses)
+	var missingStatusChecks []string
+	for _, title := range requiredStatusChecks {
+		if status, exists := latestStatuses[title]; !exists || status.State != Pass {
+			missingStatusChecks = append(missingStatusChecks, title)
+		}
+	}
+	return missingStatusChecks, nil
+}
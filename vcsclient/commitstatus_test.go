@@ -0,0 +1,107 @@
+package vcsclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitStatusesClient returns the next commit statuses on each call to ListCommitStatuses, and the last ones once they run out
+type commitStatusesClient struct {
+	VcsClient
+	statuses [][]CommitStatusInfo
+	calls    int
+}
+
+func (client *commitStatusesClient) ListCommitStatuses(_ context.Context, owner, repository, ref string) ([]CommitStatusInfo, error) {
+	statuses := client.statuses[0]
+	if len(client.statuses) > 1 {
+		client.statuses = client.statuses[1:]
+	}
+	client.calls++
+	return statuses, nil
+}
+
+func TestWaitForCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	client := &commitStatusesClient{statuses: [][]CommitStatusInfo{
+		{},
+		{{Title: "build", State: InProgress}, {Title: "lint", State: Pass}},
+		{{Title: "build", State: Pass}, {Title: "lint", State: Pass}},
+	}}
+
+	state, statuses, err := WaitForCommitStatus(ctx, client, owner, repo1, "master", WaitForCommitStatusOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, Pass, state)
+	assert.Equal(t, []CommitStatusInfo{{Title: "build", State: Pass}, {Title: "lint", State: Pass}}, statuses)
+	assert.Equal(t, 3, client.calls)
+}
+
+func TestWaitForCommitStatus_RequiredTitles(t *testing.T) {
+	ctx := context.Background()
+	client := &commitStatusesClient{statuses: [][]CommitStatusInfo{
+		{{Title: "build", State: Pass}, {Title: "optional", State: InProgress}},
+		{{Title: "build", State: Pass}, {Title: "optional", State: InProgress}, {Title: "scan", State: Fail}},
+	}}
+
+	state, _, err := WaitForCommitStatus(ctx, client, owner, repo1, "master",
+		WaitForCommitStatusOptions{RequiredTitles: []string{"build", "scan"}, PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, Fail, state)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestWaitForCommitStatus_Timeout(t *testing.T) {
+	ctx := context.Background()
+	client := &commitStatusesClient{statuses: [][]CommitStatusInfo{{{Title: "build", State: InProgress}}}}
+
+	state, statuses, err := WaitForCommitStatus(ctx, client, owner, repo1, "master",
+		WaitForCommitStatusOptions{PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, InProgress, state)
+	assert.Equal(t, []CommitStatusInfo{{Title: "build", State: InProgress}}, statuses)
+}
+
+func TestWaitForCommitStatus_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := &commitStatusesClient{statuses: [][]CommitStatusInfo{{{Title: "build", State: InProgress}}}}
+
+	_, _, err := WaitForCommitStatus(ctx, client, owner, repo1, "master", WaitForCommitStatusOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestGetRequiredCommitStatusesState(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name              string
+		statuses          []CommitStatusInfo
+		requiredTitles    []string
+		expectedState     CommitStatus
+		expectedCompleted bool
+	}{
+		{name: "no statuses", expectedState: InProgress},
+		{name: "required status not reported", statuses: []CommitStatusInfo{{Title: "build", State: Pass}}, requiredTitles: []string{"scan"}, expectedState: InProgress},
+		{name: "errored", statuses: []CommitStatusInfo{{Title: "build", State: InProgress}, {Title: "scan", State: Error}}, expectedState: Error, expectedCompleted: true},
+		{
+			name: "rerun passed",
+			statuses: []CommitStatusInfo{
+				{Title: "build", State: Fail, LastUpdatedAt: now.Add(-time.Minute)},
+				{Title: "build", State: Pass, LastUpdatedAt: now},
+			},
+			expectedState:     Pass,
+			expectedCompleted: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, completed := getRequiredCommitStatusesState(test.statuses, test.requiredTitles)
+			assert.Equal(t, test.expectedState, state)
+			assert.Equal(t, test.expectedCompleted, completed)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package vcsclient
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +13,7 @@ import (
 
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -16,6 +21,7 @@ const (
 	token           = "abc123"
 	basicAuthHeader = "Basic ZnJvZ2dlcjphYmMxMjM="
 	project         = "jfrog-project"
+	region          = "us-east-1"
 )
 
 var (
@@ -78,13 +84,28 @@ func createBodyHandlingServerAndClient(t *testing.T, vcsProvider vcsutils.VcsPro
 }
 
 func buildClient(t *testing.T, vcsProvider vcsutils.VcsProvider, basicAuth bool, server *httptest.Server) VcsClient {
-	clientBuilder := NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Token(token)
+	client, err := newTestClientBuilder(t, vcsProvider, basicAuth, server).Build()
+	assert.NoError(t, err)
+	return client
+}
+
+// buildAPIErrorsClient builds a client that wraps the errors caused by error responses with APIError
+func buildAPIErrorsClient(t *testing.T, vcsProvider vcsutils.VcsProvider, basicAuth bool, server *httptest.Server) VcsClient {
+	client, err := newTestClientBuilder(t, vcsProvider, basicAuth, server).WrapAPIErrors(true).Build()
+	assert.NoError(t, err)
+	return client
+}
+
+func newTestClientBuilder(t *testing.T, vcsProvider vcsutils.VcsProvider, basicAuth bool, server *httptest.Server) *ClientBuilder {
+	if vcsProvider == vcsutils.AWSCodeCommit {
+		// The AWS SDK can't add the CA bundle of the environment to the HTTP client of the VcsClient
+		t.Setenv("AWS_CA_BUNDLE", "")
+	}
+	clientBuilder := NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Token(token).Region(region)
 	if basicAuth {
 		clientBuilder = clientBuilder.Username("frogger")
 	}
-	client, err := clientBuilder.Build()
-	assert.NoError(t, err)
-	return client
+	return clientBuilder
 }
 
 func createWaitingServerAndClient(t *testing.T, provider vcsutils.VcsProvider, waitDuration time.Duration) (VcsClient, func()) {
@@ -102,12 +123,44 @@ func createWaitingServerAndClient(t *testing.T, provider vcsutils.VcsProvider, w
 
 func getAllProviders() []vcsutils.VcsProvider {
 	return []vcsutils.VcsProvider{
-		vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketServer, vcsutils.BitbucketCloud,
+		vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketServer, vcsutils.BitbucketCloud, vcsutils.Gitea,
 	}
 }
 
 func getNonBitbucketProviders() []vcsutils.VcsProvider {
 	return []vcsutils.VcsProvider{
-		vcsutils.GitHub, vcsutils.GitLab,
+		vcsutils.GitHub, vcsutils.GitLab, vcsutils.Gitea,
+	}
+}
+
+// createZipArchive creates a zip archive of a repository, whose files are in a base directory, like the archives of most VCS providers
+func createZipArchive(t *testing.T, baseDir string, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	for name, content := range files {
+		fileWriter, err := zipWriter.Create(baseDir + "/" + name)
+		require.NoError(t, err)
+		_, err = fileWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+	return buf.Bytes()
+}
+
+// createTarGzArchive creates a tar.gz archive of a repository, whose files are in a base directory if it isn't empty
+func createTarGzArchive(t *testing.T, baseDir string, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		if baseDir != "" {
+			name = baseDir + "/" + name
+		}
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
 	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
 }
@@ -1,14 +1,27 @@
 package vcsclient
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+
 	"github.com/jfrog/froggit-go/vcsutils"
+	"golang.org/x/oauth2"
 )
 
+var errInvalidCACertificates = errors.New("the CA certificates must be PEM encoded")
+
 // ClientBuilder builds VcsClient
 type ClientBuilder struct {
 	vcsProvider vcsutils.VcsProvider
 	vcsInfo     VcsInfo
 	logger      Log
+	collector   Collector
+	// Whether the errors caused by error responses of the VCS provider are wrapped with APIError
+	wrapAPIErrors bool
+	// The PEM encoded certificates added to the root CAs
+	caCertificates []byte
 }
 
 // NewClientBuilder creates new ClientBuilder
@@ -46,19 +59,163 @@ func (builder *ClientBuilder) Project(project string) *ClientBuilder {
 	return builder
 }
 
-// Build builds the VcsClient
+// Region sets the AWS region
+func (builder *ClientBuilder) Region(region string) *ClientBuilder {
+	builder.vcsInfo.Region = region
+	return builder
+}
+
+// RetryPolicy sets the policy of retrying requests that failed with a transient error
+func (builder *ClientBuilder) RetryPolicy(retryPolicy RetryPolicy) *ClientBuilder {
+	builder.vcsInfo.RetryPolicy = retryPolicy
+	return builder
+}
+
+// HTTPClient sets the HTTP client to base the requests on, for example to set a timeout or a proxy.
+// The client is copied, so the transports added by the VcsClient, such as the retries, don't affect it.
+func (builder *ClientBuilder) HTTPClient(httpClient *http.Client) *ClientBuilder {
+	builder.vcsInfo.HTTPClient = httpClient
+	return builder
+}
+
+// Transport sets the HTTP transport that sends the requests, for example to add tracing.
+// Overrides the transport of the HTTP client.
+func (builder *ClientBuilder) Transport(transport http.RoundTripper) *ClientBuilder {
+	builder.vcsInfo.Transport = transport
+	return builder
+}
+
+// RequestLogger sets the logger that receives the events of the HTTP requests sent to the VCS provider
+func (builder *ClientBuilder) RequestLogger(requestLogger RequestLogger) *ClientBuilder {
+	builder.vcsInfo.RequestLogger = requestLogger
+	return builder
+}
+
+// Collector sets the collector that receives the metrics of the VcsClient method calls
+func (builder *ClientBuilder) Collector(collector Collector) *ClientBuilder {
+	builder.collector = collector
+	return builder
+}
+
+// WrapAPIErrors sets whether the errors caused by error responses of the VCS provider are wrapped with APIError,
+// to be matched using errors.Is with ErrNotFound, ErrUnauthorized, ErrRateLimited and ErrConflict
+func (builder *ClientBuilder) WrapAPIErrors(wrapAPIErrors bool) *ClientBuilder {
+	builder.wrapAPIErrors = wrapAPIErrors
+	return builder
+}
+
+// GitHubApp sets the GitHub App installation to authenticate as, instead of the access token.
+// Installation access tokens are created using the private key of the app, and refreshed before they expire.
+func (builder *ClientBuilder) GitHubApp(appInfo GitHubAppInfo) *ClientBuilder {
+	builder.vcsInfo.GitHubApp = &appInfo
+	return builder
+}
+
+// TokenSource sets the source of the OAuth tokens to authenticate with, instead of the access token. Relevant for GitLab and Bitbucket cloud.
+// The tokens are reused until they expire, and then a new token is requested from the source.
+func (builder *ClientBuilder) TokenSource(tokenSource oauth2.TokenSource) *ClientBuilder {
+	builder.vcsInfo.TokenSource = tokenSource
+	return builder
+}
+
+// TLSConfig sets the TLS configuration of the connections to the VCS provider
+func (builder *ClientBuilder) TLSConfig(tlsConfig *tls.Config) *ClientBuilder {
+	builder.vcsInfo.TLSConfig = tlsConfig
+	return builder
+}
+
+// RootCAs sets the certificate authorities trusted when connecting to the VCS provider, such as the private CA of a self-hosted server
+func (builder *ClientBuilder) RootCAs(rootCAs *x509.CertPool) *ClientBuilder {
+	builder.vcsInfo.RootCAs = rootCAs
+	return builder
+}
+
+// CACertificates sets the PEM encoded certificates of the certificate authorities trusted when connecting to the VCS provider, in addition to the system ones.
+// Build fails if the certificates can't be parsed.
+func (builder *ClientBuilder) CACertificates(pemCerts []byte) *ClientBuilder {
+	builder.caCertificates = pemCerts
+	return builder
+}
+
+// InsecureSkipVerify disables the verification of the certificate of the VCS provider. Use only for testing.
+func (builder *ClientBuilder) InsecureSkipVerify(insecureSkipVerify bool) *ClientBuilder {
+	builder.vcsInfo.InsecureSkipVerify = insecureSkipVerify
+	return builder
+}
+
+// UseGraphQL sets whether the GitHub client reads the details of pull requests using a single GraphQL query, instead of several REST requests
+func (builder *ClientBuilder) UseGraphQL(useGraphQL bool) *ClientBuilder {
+	builder.vcsInfo.UseGraphQL = useGraphQL
+	return builder
+}
+
+// ResponseCache sets the cache of the responses of GET requests, which are revalidated using ETag and Last-Modified conditional requests.
+// Not modified responses are returned from the cache, which reduces the rate limit usage of polling. Not relevant for Azure Repos and AWS CodeCommit.
+func (builder *ClientBuilder) ResponseCache(cache ResponseCache) *ClientBuilder {
+	builder.vcsInfo.ResponseCache = cache
+	return builder
+}
+
+// RateLimiter sets the rate limiter that is consulted before sending each request, so that large concurrent scans throttle themselves
+// instead of tripping the abuse detection of the VCS provider. NewDefaultRateLimiter creates a rate limiter with the default rate of the VCS provider.
+// On Azure Repos, only the requests of repository downloads are throttled.
+func (builder *ClientBuilder) RateLimiter(limiter RateLimiter) *ClientBuilder {
+	builder.vcsInfo.RateLimiter = limiter
+	return builder
+}
+
+// Build builds the VcsClient, which is the client of the VCS provider, such as *GitHubClient.
+// If errors are wrapped with APIError or a collector is set, the client of the VCS provider is wrapped, and UnwrapClient returns it.
 func (builder *ClientBuilder) Build() (VcsClient, error) {
+	vcsInfo, err := builder.getVcsInfo()
+	if err != nil {
+		return nil, err
+	}
+	client, err := builder.buildProviderClient(vcsInfo)
+	if err != nil || client == nil {
+		return nil, err
+	}
+	if !builder.wrapAPIErrors && builder.collector == nil {
+		return client, nil
+	}
+	return &instrumentedClient{client: client, provider: builder.vcsProvider, collector: builder.collector, wrapAPIErrors: builder.wrapAPIErrors}, nil
+}
+
+// getVcsInfo returns the VCS info, with the CA certificates added to its root CAs
+func (builder *ClientBuilder) getVcsInfo() (VcsInfo, error) {
+	vcsInfo := builder.vcsInfo
+	if builder.caCertificates == nil {
+		return vcsInfo, nil
+	}
+	if vcsInfo.RootCAs != nil {
+		vcsInfo.RootCAs = vcsInfo.RootCAs.Clone()
+	} else if systemCAs, err := x509.SystemCertPool(); err == nil {
+		vcsInfo.RootCAs = systemCAs
+	} else {
+		vcsInfo.RootCAs = x509.NewCertPool()
+	}
+	if !vcsInfo.RootCAs.AppendCertsFromPEM(builder.caCertificates) {
+		return VcsInfo{}, errInvalidCACertificates
+	}
+	return vcsInfo, nil
+}
+
+func (builder *ClientBuilder) buildProviderClient(vcsInfo VcsInfo) (VcsClient, error) {
 	switch builder.vcsProvider {
 	case vcsutils.GitHub:
-		return NewGitHubClient(builder.vcsInfo, builder.logger)
+		return NewGitHubClient(vcsInfo, builder.logger)
 	case vcsutils.GitLab:
-		return NewGitLabClient(builder.vcsInfo, builder.logger)
+		return NewGitLabClient(vcsInfo, builder.logger)
 	case vcsutils.BitbucketServer:
-		return NewBitbucketServerClient(builder.vcsInfo, builder.logger)
+		return NewBitbucketServerClient(vcsInfo, builder.logger)
 	case vcsutils.BitbucketCloud:
-		return NewBitbucketCloudClient(builder.vcsInfo, builder.logger)
+		return NewBitbucketCloudClient(vcsInfo, builder.logger)
 	case vcsutils.AzureRepos:
-		return NewAzureReposClient(builder.vcsInfo, builder.logger)
+		return NewAzureReposClient(vcsInfo, builder.logger)
+	case vcsutils.Gitea:
+		return NewGiteaClient(vcsInfo, builder.logger)
+	case vcsutils.AWSCodeCommit:
+		return NewAWSCodeCommitClient(vcsInfo, builder.logger)
 	}
 	return nil, nil
 }
@@ -1,10 +1,15 @@
 package vcsclient
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -12,19 +17,59 @@ const (
 )
 
 func TestClientBuilder(t *testing.T) {
-	for _, vcsProvider := range []vcsutils.VcsProvider{vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketCloud, vcsutils.BitbucketServer, vcsutils.AzureRepos} {
+	for _, vcsProvider := range []vcsutils.VcsProvider{vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketCloud, vcsutils.BitbucketServer, vcsutils.AzureRepos, vcsutils.Gitea, vcsutils.AWSCodeCommit} {
 		t.Run(vcsProvider.String(), func(t *testing.T) {
-			clientBuilder := NewClientBuilder(vcsProvider).ApiEndpoint(apiEndpoint).Username(username).Token(token).Project(project)
+			retryPolicy := RetryPolicy{MaxAttempts: 3, Jitter: 0.5}
+			httpClient := &http.Client{Timeout: time.Minute}
+			transport := &http.Transport{}
+			tokenSource := &testTokenSource{}
+			tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+			rootCAs := x509.NewCertPool()
+			appInfo := GitHubAppInfo{AppID: 1, InstallationID: 2, PrivateKey: []byte("private-key")}
+			clientBuilder := NewClientBuilder(vcsProvider).ApiEndpoint(apiEndpoint).Username(username).Token(token).Project(project).Region(region).
+				RetryPolicy(retryPolicy).HTTPClient(httpClient).Transport(transport).GitHubApp(appInfo).TokenSource(tokenSource).
+				TLSConfig(tlsConfig).RootCAs(rootCAs).InsecureSkipVerify(true)
 			assert.NotNil(t, clientBuilder)
 			assert.Equal(t, vcsProvider, clientBuilder.vcsProvider)
 			assert.Equal(t, apiEndpoint, clientBuilder.vcsInfo.APIEndpoint)
 			assert.Equal(t, username, clientBuilder.vcsInfo.Username)
 			assert.Equal(t, token, clientBuilder.vcsInfo.Token)
 			assert.Equal(t, project, clientBuilder.vcsInfo.Project)
+			assert.Equal(t, region, clientBuilder.vcsInfo.Region)
+			assert.Equal(t, retryPolicy, clientBuilder.vcsInfo.RetryPolicy)
+			assert.Same(t, httpClient, clientBuilder.vcsInfo.HTTPClient)
+			assert.Same(t, transport, clientBuilder.vcsInfo.Transport)
+			assert.Equal(t, &appInfo, clientBuilder.vcsInfo.GitHubApp)
+			assert.Same(t, tokenSource, clientBuilder.vcsInfo.TokenSource)
+			assert.Same(t, tlsConfig, clientBuilder.vcsInfo.TLSConfig)
+			assert.Same(t, rootCAs, clientBuilder.vcsInfo.RootCAs)
+			assert.True(t, clientBuilder.vcsInfo.InsecureSkipVerify)
 		})
 	}
 }
 
+func TestClientBuilder_ProviderClient(t *testing.T) {
+	// The client of the VCS provider is returned, unless errors are wrapped or a collector is set
+	client, err := NewClientBuilder(vcsutils.GitHub).Token(token).Build()
+	require.NoError(t, err)
+	assert.IsType(t, &GitHubClient{}, client)
+
+	client, err = NewClientBuilder(vcsutils.GitHub).Token(token).WrapAPIErrors(true).Build()
+	require.NoError(t, err)
+	assert.IsType(t, &instrumentedClient{}, client)
+}
+
+func TestUnwrapClient(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.GitHub).Token(token).WrapAPIErrors(true).Build()
+	require.NoError(t, err)
+	_, ok := client.(*GitHubClient)
+	assert.False(t, ok)
+	githubClient, ok := UnwrapClient(client).(*GitHubClient)
+	assert.True(t, ok)
+	// Clients that aren't wrapped are returned as is
+	assert.Same(t, githubClient, UnwrapClient(githubClient))
+}
+
 func TestNegativeGitlabClient(t *testing.T) {
 	vcsClient, err := NewClientBuilder(vcsutils.GitLab).ApiEndpoint("https://bad^endpoint").Build()
 	assert.Nil(t, vcsClient)
@@ -0,0 +1,2679 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+var errGiteaCodeScanningNotSupported = errors.New("code scanning is not supported on Gitea")
+var errGiteaGetRepoEnvironmentInfoNotSupported = errors.New("get repository environment info is currently not supported on Gitea")
+var errGiteaCommitFilesNotSupported = errors.New("committing multiple files in a single commit is not supported on Gitea")
+var errGiteaRateLimitNotSupported = errors.New("Gitea doesn't report rate limits")
+var errGiteaInternalRepositoriesNotSupported = errors.New("internal repositories are not supported on Gitea")
+var errGiteaWebhookInsecureSSLNotSupported = errors.New("skipping the SSL verification of a single webhook is not supported on Gitea")
+var errGiteaRepositoryLanguageFilterNotSupported = errors.New("filtering repositories by language is not supported on Gitea")
+var errGiteaCodeSearchNotSupported = errors.New("code search is not supported on Gitea")
+var errGiteaBlameNotSupported = errors.New("blame is not supported by the Gitea API")
+var errGiteaPipelinesNotSupported = errors.New("triggering pipelines is not supported by the Gitea API")
+var errGiteaDeploymentsNotSupported = errors.New("environments and deployments are not supported on Gitea")
+var errGiteaTagProtectionNotSupported = errors.New("tag protection is currently not supported on Gitea")
+var errGiteaSecurityAlertsNotSupported = errors.New("security alerts are not supported on Gitea")
+var errGiteaCodeInsightsNotSupported = errors.New("Code Insights reports are not supported on Gitea")
+var errGiteaPullRequestThreadsNotSupported = errors.New("resolvable pull request threads are not supported by the Gitea API")
+
+// Pull requests whose title starts with one of these prefixes are work in progress, by Gitea's default settings
+var giteaDraftTitlePrefixes = []string{"WIP:", "[WIP]"}
+
+// GiteaClient API version 1
+type GiteaClient struct {
+	vcsInfo VcsInfo
+	logger  Log
+}
+
+// NewGiteaClient create a new GiteaClient
+func NewGiteaClient(vcsInfo VcsInfo, logger Log) (*GiteaClient, error) {
+	return &GiteaClient{vcsInfo: vcsInfo, logger: logger}, nil
+}
+
+func (client *GiteaClient) buildGiteaClient(ctx context.Context) (*gitea.Client, error) {
+	// An empty Gitea version skips the server version check, which requires an additional API call
+	return gitea.NewClient(client.vcsInfo.APIEndpoint,
+		gitea.SetToken(client.vcsInfo.Token),
+		gitea.SetContext(ctx),
+		gitea.SetHTTPClient(newHTTPClient(client.vcsInfo, client.logger)),
+		gitea.SetGiteaVersion(""))
+}
+
+// TestConnection on Gitea
+func (client *GiteaClient) TestConnection(ctx context.Context) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.GetMyUserInfo()
+	return err
+}
+
+// GetRateLimitInfo on Gitea
+func (client *GiteaClient) GetRateLimitInfo(ctx context.Context) (RateLimitInfo, error) {
+	return RateLimitInfo{}, errGiteaRateLimitNotSupported
+}
+
+// ListRepositories on Gitea
+func (client *GiteaClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	for nextPage := 1; nextPage > 0; {
+		repositories, response, err := giteaClient.ListMyRepos(gitea.ListReposOptions{ListOptions: gitea.ListOptions{Page: nextPage}})
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repositories {
+			owner := repo.Owner.UserName
+			results[owner] = append(results[owner], repo.Name)
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// ListRepositoriesWithOptions on Gitea
+func (client *GiteaClient) ListRepositoriesWithOptions(ctx context.Context, owner string, options ListRepositoriesOptions) ([]RepositoryDetails, error) {
+	if options.Visibility != nil && *options.Visibility == Internal {
+		return nil, errGiteaInternalRepositoriesNotSupported
+	}
+	if options.Language != "" {
+		return nil, errGiteaRepositoryLanguageFilterNotSupported
+	}
+	return listPageOrAll(ctx, client.listRepositoriesPager(owner, options), options.Page)
+}
+
+func (client *GiteaClient) listRepositoriesPager(owner string, options ListRepositoriesOptions) *Pager[RepositoryDetails] {
+	// The repositories are sorted by their update time, so the pages after a repository updated before UpdatedSince aren't fetched
+	query := url.Values{"exclusive": {"true"}, "sort": {"updated"}, "order": {"desc"}}
+	if options.Visibility != nil {
+		query.Set("is_private", strconv.FormatBool(*options.Visibility == Private))
+	}
+	if options.Archived != nil {
+		query.Set("archived", strconv.FormatBool(*options.Archived))
+	}
+	if options.PerPage > 0 {
+		query.Set("limit", strconv.Itoa(options.PerPage))
+	}
+	nextPage := firstPage(options.Page)
+	return newPager(func(ctx context.Context) ([]RepositoryDetails, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner})
+		if err != nil {
+			return nil, false, err
+		}
+		giteaClient, err := client.buildGiteaClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		// The repositories are searched by the ID of their owner, which is a user or an organization
+		if query.Get("uid") == "" {
+			ownerInfo, _, err := giteaClient.GetUserInfo(owner)
+			if err != nil {
+				return nil, false, err
+			}
+			query.Set("uid", strconv.FormatInt(ownerInfo.ID, 10))
+		}
+		query.Set("page", strconv.Itoa(nextPage))
+		client.logger.Debug("fetching repositories page", nextPage, "of", owner)
+		// The search options of the SDK don't encode the visibility and archived filters correctly, so the query is built here
+		repos, response, err := giteaClient.SearchRepos(gitea.SearchRepoOptions{RawQuery: query.Encode()})
+		if err != nil {
+			return nil, false, err
+		}
+		var results []RepositoryDetails
+		var details RepositoryDetails
+		for _, repo := range repos {
+			details = mapGiteaRepositoryToRepositoryDetails(repo)
+			if options.matches(details) {
+				results = append(results, details)
+			}
+		}
+		nextPage = response.NextPage
+		// The details are of the last repository in the page
+		if !options.UpdatedSince.IsZero() && details.Updated.Before(options.UpdatedSince) {
+			return results, false, nil
+		}
+		return results, nextPage > 0, nil
+	})
+}
+
+// ListBranches on Gitea
+func (client *GiteaClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	branches, _, err := giteaClient.ListRepoBranches(owner, repository, gitea.ListRepoBranchesOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		results = append(results, branch.Name)
+	}
+	return results, nil
+}
+
+// ListBranchesPager on Gitea
+func (client *GiteaClient) ListBranchesPager(owner, repository string, perPage int) *Pager[string] {
+	options := gitea.ListRepoBranchesOptions{ListOptions: gitea.ListOptions{Page: 1, PageSize: perPage}}
+	return newPager(func(ctx context.Context) ([]string, bool, error) {
+		giteaClient, err := client.buildGiteaClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		branches, response, err := giteaClient.ListRepoBranches(owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]string, 0, len(branches))
+		for _, branch := range branches {
+			results = append(results, branch.Name)
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// ListAllBranches on Gitea
+func (client *GiteaClient) ListAllBranches(ctx context.Context, owner, repository, prefix string) ([]BranchDetails, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []BranchDetails
+	for nextPage := 1; nextPage > 0; {
+		options := gitea.ListRepoBranchesOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: 50}}
+		branches, response, err := giteaClient.ListRepoBranches(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			if !strings.HasPrefix(branch.Name, prefix) {
+				continue
+			}
+			branchDetails := BranchDetails{Name: branch.Name}
+			if branch.Commit != nil {
+				branchDetails.CommitHash = branch.Commit.ID
+			}
+			results = append(results, branchDetails)
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// CreateBranch on Gitea
+func (client *GiteaClient) CreateBranch(ctx context.Context, owner, repository, branchName, fromRef string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":       owner,
+		"repository":  repository,
+		"branch name": branchName,
+		"from ref":    fromRef,
+	})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("creating branch", branchName, "from", fromRef)
+	// Gitea only supports creating a branch from another branch
+	_, _, err = giteaClient.CreateBranch(owner, repository, gitea.CreateBranchOption{BranchName: branchName, OldBranchName: fromRef})
+	return err
+}
+
+// DeleteBranch on Gitea
+func (client *GiteaClient) DeleteBranch(ctx context.Context, owner, repository, branchName string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch name": branchName})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("deleting branch", branchName)
+	deleted, _, err := giteaClient.DeleteRepoBranch(owner, repository, branchName)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("branch %s was not deleted", branchName)
+	}
+	return nil
+}
+
+// ListTags on Gitea
+func (client *GiteaClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []TagInfo
+	for nextPage := 1; nextPage > 0; {
+		options := gitea.ListRepoTagsOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: 50}}
+		tags, response, err := giteaClient.ListRepoTags(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagInfo := TagInfo{Name: tag.Name, Message: tag.Message}
+			if tag.Commit != nil {
+				tagInfo.CommitHash = tag.Commit.SHA
+			}
+			results = append(results, tagInfo)
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// CreateTag on Gitea
+func (client *GiteaClient) CreateTag(ctx context.Context, owner, repository, tagName, targetSha, message string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"tag name":   tagName,
+		"target sha": targetSha,
+	})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("creating tag", tagName, "on", targetSha)
+	// Gitea creates an annotated tag if the message isn't empty
+	_, _, err = giteaClient.CreateTag(owner, repository, gitea.CreateTagOption{TagName: tagName, Target: targetSha, Message: message})
+	return err
+}
+
+// CreateRelease on Gitea
+func (client *GiteaClient) CreateRelease(ctx context.Context, owner, repository string, release ReleaseInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tag name": release.TagName})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Gitea requires a release title
+	title := release.Name
+	if title == "" {
+		title = release.TagName
+	}
+	client.logger.Debug("creating release", release.TagName)
+	_, _, err = giteaClient.CreateRelease(owner, repository, gitea.CreateReleaseOption{
+		TagName: release.TagName,
+		Target:  release.Target,
+		Title:   title,
+		Note:    release.Description,
+	})
+	return err
+}
+
+// GetLatestRelease on Gitea
+func (client *GiteaClient) GetLatestRelease(ctx context.Context, owner, repository string) (*ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	release, response, err := giteaClient.GetLatestRelease(owner, repository)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ReleaseInfo{
+		TagName:     release.TagName,
+		Target:      release.Target,
+		Name:        release.Title,
+		Description: release.Note,
+		Url:         release.HTMLURL,
+	}, nil
+}
+
+// UploadReleaseAsset on Gitea
+func (client *GiteaClient) UploadReleaseAsset(ctx context.Context, owner, repository, tagName, assetPath string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"tag name":   tagName,
+		"asset path": assetPath,
+	})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	release, _, err := giteaClient.GetReleaseByTag(owner, repository, tagName)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(assetPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	client.logger.Debug("uploading release asset", assetPath, "to", tagName)
+	_, _, err = giteaClient.CreateReleaseAttachment(owner, repository, release.ID, file, filepath.Base(assetPath))
+	return err
+}
+
+// GetBranchProtection on Gitea
+func (client *GiteaClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error) {
+	protection, err := client.getBranchProtection(ctx, owner, repository, branch)
+	if err != nil || protection == nil {
+		return nil, err
+	}
+	branchProtection := &BranchProtection{
+		RequiredApprovingReviewCount: int(protection.RequiredApprovals),
+		RestrictPushes:               !protection.EnablePush || protection.EnablePushWhitelist,
+	}
+	if protection.EnableStatusCheck {
+		branchProtection.RequiredStatusChecks = protection.StatusCheckContexts
+	}
+	if protection.EnablePush && protection.EnablePushWhitelist {
+		branchProtection.AllowedPushUsers = protection.PushWhitelistUsernames
+	}
+	return branchProtection, nil
+}
+
+// SetBranchProtection on Gitea
+func (client *GiteaClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtection) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Gitea blocks all the pushes if pushing is disabled, and allows only the whitelisted users to push if the whitelist is enabled
+	enablePush := !protection.RestrictPushes || len(protection.AllowedPushUsers) > 0
+	enablePushWhitelist := protection.RestrictPushes && len(protection.AllowedPushUsers) > 0
+	enableStatusCheck := len(protection.RequiredStatusChecks) > 0
+	requiredApprovals := int64(protection.RequiredApprovingReviewCount)
+	_, response, err := giteaClient.GetBranchProtection(owner, repository, branch)
+	if err != nil {
+		if response == nil || response.StatusCode != http.StatusNotFound {
+			return err
+		}
+		client.logger.Debug("creating protection of branch", branch)
+		_, _, err = giteaClient.CreateBranchProtection(owner, repository, gitea.CreateBranchProtectionOption{
+			BranchName:             branch,
+			EnablePush:             enablePush,
+			EnablePushWhitelist:    enablePushWhitelist,
+			PushWhitelistUsernames: protection.AllowedPushUsers,
+			EnableStatusCheck:      enableStatusCheck,
+			StatusCheckContexts:    protection.RequiredStatusChecks,
+			RequiredApprovals:      requiredApprovals,
+		})
+		return err
+	}
+	client.logger.Debug("updating protection of branch", branch)
+	_, _, err = giteaClient.EditBranchProtection(owner, repository, branch, gitea.EditBranchProtectionOption{
+		EnablePush:             &enablePush,
+		EnablePushWhitelist:    &enablePushWhitelist,
+		PushWhitelistUsernames: protection.AllowedPushUsers,
+		EnableStatusCheck:      &enableStatusCheck,
+		StatusCheckContexts:    protection.RequiredStatusChecks,
+		RequiredApprovals:      &requiredApprovals,
+	})
+	return err
+}
+
+// GetRequiredChecks on Gitea
+func (client *GiteaClient) GetRequiredChecks(ctx context.Context, owner, repository, branch string) (RequiredChecksInfo, error) {
+	protection, err := client.getBranchProtection(ctx, owner, repository, branch)
+	if err != nil || protection == nil || !protection.EnableStatusCheck {
+		return RequiredChecksInfo{}, err
+	}
+	return RequiredChecksInfo{Titles: protection.StatusCheckContexts, RequireUpToDate: protection.BlockOnOutdatedBranch}, nil
+}
+
+// GetRequiredApprovals on Gitea
+func (client *GiteaClient) GetRequiredApprovals(ctx context.Context, owner, repository, branch string) (RequiredApprovalsInfo, error) {
+	protection, err := client.getBranchProtection(ctx, owner, repository, branch)
+	if err != nil || protection == nil || protection.RequiredApprovals == 0 {
+		return RequiredApprovalsInfo{}, err
+	}
+	requiredApprovals := RequiredApprovalsInfo{Count: int(protection.RequiredApprovals), DismissStaleApprovals: protection.DismissStaleApprovals}
+	if protection.EnableApprovalsWhitelist {
+		requiredApprovals.Approvers = protection.ApprovalsWhitelistUsernames
+	}
+	return requiredApprovals, nil
+}
+
+// GetTagProtection on Gitea
+func (client *GiteaClient) GetTagProtection(ctx context.Context, owner, repository, pattern string) (*TagProtection, error) {
+	return nil, errGiteaTagProtectionNotSupported
+}
+
+// SetTagProtection on Gitea
+func (client *GiteaClient) SetTagProtection(ctx context.Context, owner, repository, pattern string, protection TagProtection) error {
+	return errGiteaTagProtectionNotSupported
+}
+
+// getBranchProtection returns nil if the branch isn't protected
+func (client *GiteaClient) getBranchProtection(ctx context.Context, owner, repository, branch string) (*gitea.BranchProtection, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	protection, response, err := giteaClient.GetBranchProtection(owner, repository, branch)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return protection, nil
+}
+
+// AddSshKeyToRepository on Gitea
+func (client *GiteaClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"key name":   keyName,
+		"public key": publicKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateDeployKey(owner, repository, gitea.CreateKeyOption{
+		Title:    keyName,
+		Key:      publicKey,
+		ReadOnly: permission != ReadWrite,
+	})
+	return err
+}
+
+// ListDeployKeys on Gitea
+func (client *GiteaClient) ListDeployKeys(ctx context.Context, owner, repository string) ([]DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []DeployKeyInfo
+	for nextPage := 1; nextPage > 0; {
+		options := gitea.ListDeployKeysOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: 50}}
+		keys, response, err := giteaClient.ListDeployKeys(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			results = append(results, mapGiteaDeployKeyToDeployKeyInfo(key))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// AddDeployKey on Gitea
+func (client *GiteaClient) AddDeployKey(ctx context.Context, owner, repository, title, key string, readOnly bool) (DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title, "key": key})
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	deployKey, _, err := giteaClient.CreateDeployKey(owner, repository, gitea.CreateKeyOption{Title: title, Key: key, ReadOnly: readOnly})
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	return mapGiteaDeployKeyToDeployKeyInfo(deployKey), nil
+}
+
+// DeleteDeployKey on Gitea
+func (client *GiteaClient) DeleteDeployKey(ctx context.Context, owner, repository string, keyID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteDeployKey(owner, repository, int64(keyID))
+	return err
+}
+
+func mapGiteaDeployKeyToDeployKeyInfo(key *gitea.DeployKey) DeployKeyInfo {
+	return DeployKeyInfo{ID: int(key.ID), Title: key.Title, Key: key.Key, ReadOnly: key.ReadOnly}
+}
+
+// CreateWebhook on Gitea
+func (client *GiteaClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string,
+	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	token := vcsutils.CreateToken()
+	hook, _, err := giteaClient.CreateRepoHook(owner, repository, gitea.CreateHookOption{
+		Type:         gitea.HookTypeGitea,
+		Config:       createGiteaHookConfig(token, payloadURL),
+		Events:       getGiteaWebhookEvents(webhookEvents...),
+		BranchFilter: branch,
+		Active:       true,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return strconv.FormatInt(hook.ID, 10), token, nil
+}
+
+// CreateWebhookWithOptions on Gitea
+func (client *GiteaClient) CreateWebhookWithOptions(ctx context.Context, owner, repository string,
+	options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	if options.InsecureSSL {
+		return WebhookInfo{}, "", errGiteaWebhookInsecureSSLNotSupported
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	token := vcsutils.CreateToken()
+	hook, _, err := giteaClient.CreateRepoHook(owner, repository, createGiteaHookOption(token, options))
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	return mapGiteaHookToWebhookInfo(hook), token, nil
+}
+
+// CreateOrgWebhook on Gitea
+func (client *GiteaClient) CreateOrgWebhook(ctx context.Context, owner string, options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	if options.InsecureSSL {
+		return WebhookInfo{}, "", errGiteaWebhookInsecureSSLNotSupported
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	token := vcsutils.CreateToken()
+	hook, _, err := giteaClient.CreateOrgHook(owner, createGiteaHookOption(token, options))
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	return mapGiteaHookToWebhookInfo(hook), token, nil
+}
+
+// ListOrgWebhooks on Gitea
+func (client *GiteaClient) ListOrgWebhooks(ctx context.Context, owner string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []WebhookInfo
+	for nextPage := 1; nextPage > 0; {
+		options := gitea.ListHooksOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: 50}}
+		hooks, response, err := giteaClient.ListOrgHooks(owner, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			webhooks = append(webhooks, mapGiteaHookToWebhookInfo(hook))
+		}
+		nextPage = response.NextPage
+	}
+	return webhooks, nil
+}
+
+// DeleteOrgWebhook on Gitea
+func (client *GiteaClient) DeleteOrgWebhook(ctx context.Context, owner, webhookID string) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteOrgHook(owner, webhookIDInt64)
+	return err
+}
+
+// UpdateWebhook on Gitea
+func (client *GiteaClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token,
+	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	active := true
+	_, err = giteaClient.EditRepoHook(owner, repository, webhookIDInt64, gitea.EditHookOption{
+		Config:       createGiteaHookConfig(token, payloadURL),
+		Events:       getGiteaWebhookEvents(webhookEvents...),
+		BranchFilter: branch,
+		Active:       &active,
+	})
+	return err
+}
+
+// DeleteWebhook on Gitea
+func (client *GiteaClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteRepoHook(owner, repository, webhookIDInt64)
+	return err
+}
+
+// ListWebhooks on Gitea
+func (client *GiteaClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []WebhookInfo
+	for nextPage := 1; nextPage > 0; {
+		options := gitea.ListHooksOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: 50}}
+		hooks, response, err := giteaClient.ListRepoHooks(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			webhooks = append(webhooks, mapGiteaHookToWebhookInfo(hook))
+		}
+		nextPage = response.NextPage
+	}
+	return webhooks, nil
+}
+
+// SetWebhookActive on Gitea.
+// Gitea resets the events of a hook which are missing from the edit request, so they are sent again.
+func (client *GiteaClient) SetWebhookActive(ctx context.Context, owner, repository, webhookID string, active bool) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	hook, _, err := giteaClient.GetRepoHook(owner, repository, webhookIDInt64)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.EditRepoHook(owner, repository, webhookIDInt64, gitea.EditHookOption{
+		Events: hook.Events,
+		Active: &active,
+	})
+	return err
+}
+
+// SetCommitStatus on Gitea
+func (client *GiteaClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
+	title, description, detailsURL string) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateStatus(owner, repository, ref, gitea.CreateStatusOption{
+		State:       getGiteaCommitState(commitStatus),
+		TargetURL:   detailsURL,
+		Description: description,
+		Context:     title,
+	})
+	return err
+}
+
+// SetCommitStatuses on Gitea
+func (client *GiteaClient) SetCommitStatuses(ctx context.Context, owner, repository, ref string, statuses []CommitStatusInfo) error {
+	return setCommitStatusesOneByOne(ctx, client, owner, repository, ref, statuses)
+}
+
+// CreateCheckRun on Gitea. Gitea doesn't support check runs, so a commit status is set instead.
+func (client *GiteaClient) CreateCheckRun(ctx context.Context, owner, repository, ref string, checkRun CheckRunInfo) (int64, error) {
+	return 0, setCheckRunCommitStatus(ctx, client, owner, repository, ref, checkRun)
+}
+
+// UpdateCheckRun on Gitea. Gitea doesn't support check runs, so the commit status is set instead.
+func (client *GiteaClient) UpdateCheckRun(ctx context.Context, owner, repository, ref string, _ int64, checkRun CheckRunInfo) error {
+	return setCheckRunCommitStatus(ctx, client, owner, repository, ref, checkRun)
+}
+
+// CreateCodeInsightsReport on Gitea
+func (client *GiteaClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, sha string, report CodeInsightsReport) error {
+	return errGiteaCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on Gitea
+func (client *GiteaClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, sha, reportID string, annotations []CodeInsightsAnnotation) error {
+	return errGiteaCodeInsightsNotSupported
+}
+
+// TriggerPipeline on Gitea
+func (client *GiteaClient) TriggerPipeline(ctx context.Context, owner, repository, pipeline, ref string, inputs map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGiteaPipelinesNotSupported
+}
+
+// GetPipelineStatus on Gitea
+func (client *GiteaClient) GetPipelineStatus(ctx context.Context, owner, repository, runID string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGiteaPipelinesNotSupported
+}
+
+// ListCommitStatuses on Gitea
+func (client *GiteaClient) ListCommitStatuses(ctx context.Context, owner, repository, ref string) ([]CommitStatusInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	combinedStatus, _, err := giteaClient.GetCombinedStatus(owner, repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitStatusInfo
+	for _, status := range combinedStatus.Statuses {
+		results = append(results, CommitStatusInfo{
+			State:         getGiteaCommitStatus(status.State),
+			Title:         status.Context,
+			Description:   status.Description,
+			DetailsURL:    status.TargetURL,
+			CreatedAt:     status.Created,
+			LastUpdatedAt: status.Updated,
+		})
+	}
+	return results, nil
+}
+
+// DownloadRepository on Gitea
+func (client *GiteaClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	return client.DownloadRepositoryAtRef(ctx, owner, repository, branch, localPath, TarGz)
+}
+
+// DownloadRepositoryAtRef on Gitea
+func (client *GiteaClient) DownloadRepositoryAtRef(ctx context.Context, owner, repository, ref, localPath string, format ArchiveFormat) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	repo, _, err := giteaClient.GetRepo(owner, repository)
+	if err != nil {
+		return err
+	}
+	archive, err := client.GetRepositoryArchive(ctx, owner, repository, ref, format)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = archive.Close() }()
+	client.logger.Info(repository, "downloaded successfully, starting with repository extraction")
+	err = extractRepositoryArchive(archive, format, localPath, true, "")
+	if err != nil {
+		return err
+	}
+	client.logger.Info("extracted repository successfully")
+	return vcsutils.CreateDotGitFolderWithRemote(localPath, "origin", repo.CloneURL)
+}
+
+// GetRepositoryArchive on Gitea
+func (client *GiteaClient) GetRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat) (io.ReadCloser, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archiveType := gitea.TarGZArchive
+	if format == Zip {
+		archiveType = gitea.ZipArchive
+	}
+	archive, _, err := giteaClient.GetArchiveReader(owner, repository, ref, archiveType)
+	return archive, err
+}
+
+// DownloadRepositoryPath on Gitea
+func (client *GiteaClient) DownloadRepositoryPath(ctx context.Context, owner, repository, ref, path, localPath string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "path": path})
+	if err != nil {
+		return err
+	}
+	// Gitea has no archive of a single directory, so the files of the directory are extracted from the repository tarball
+	archive, err := client.GetRepositoryArchive(ctx, owner, repository, ref, TarGz)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = archive.Close() }()
+	client.logger.Info("extracting", path, "from", repository)
+	return extractRepositoryArchive(archive, TarGz, localPath, true, path)
+}
+
+// CreatePullRequest on Gitea
+func (client *GiteaClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) error {
+	return client.createPullRequest(ctx, owner, repository, sourceBranch, targetBranch, title, description)
+}
+
+// CreateDraftPullRequest on Gitea
+func (client *GiteaClient) CreateDraftPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) error {
+	// Gitea marks pull requests as work in progress by their title
+	return client.createPullRequest(ctx, owner, repository, sourceBranch, targetBranch, "WIP: "+title, description)
+}
+
+func (client *GiteaClient) createPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("creating new pull request:", title)
+	_, _, err = giteaClient.CreatePullRequest(owner, repository, gitea.CreatePullRequestOption{
+		Head:  sourceBranch,
+		Base:  targetBranch,
+		Title: title,
+		Body:  description,
+	})
+	return err
+}
+
+// MarkPullRequestReady on Gitea
+func (client *GiteaClient) MarkPullRequestReady(ctx context.Context, owner, repository string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest, _, err := giteaClient.GetPullRequest(owner, repository, int64(pullRequestID))
+	if err != nil {
+		return err
+	}
+	title, isDraft := trimDraftTitlePrefix(pullRequest.Title, giteaDraftTitlePrefixes)
+	if !isDraft {
+		return nil
+	}
+	client.logger.Debug("marking pull request as ready:", pullRequestID)
+	_, _, err = giteaClient.EditPullRequest(owner, repository, int64(pullRequestID), gitea.EditPullRequestOption{Title: title})
+	return err
+}
+
+// MergePullRequest on Gitea
+func (client *GiteaClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int,
+	mergeStrategy MergeStrategy, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("merging pull request:", pullRequestID)
+	merged, _, err := giteaClient.MergePullRequest(owner, repository, int64(pullRequestID), gitea.MergePullRequestOption{
+		Style:   getGiteaMergeStyle(mergeStrategy),
+		Message: commitMessage,
+	})
+	if err != nil {
+		return err
+	}
+	if !merged {
+		return fmt.Errorf("pull request %d was not merged", pullRequestID)
+	}
+	return nil
+}
+
+// EnablePullRequestAutoMerge on Gitea. The pull request is merged when its required status checks succeed.
+func (client *GiteaClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("enabling auto-merge of pull request:", pullRequestID)
+	// Gitea merges the pull request immediately if its status checks already succeeded
+	_, _, err = giteaClient.MergePullRequest(owner, repository, int64(pullRequestID), gitea.MergePullRequestOption{
+		Style:                  getGiteaMergeStyle(mergeStrategy),
+		MergeWhenChecksSucceed: true,
+	})
+	return err
+}
+
+// DisablePullRequestAutoMerge on Gitea
+func (client *GiteaClient) DisablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	// Canceling the scheduled merge isn't supported by the Gitea SDK, so the request is sent directly
+	u := fmt.Sprintf("%s/api/v1/repos/%s/%s/pulls/%d/merge", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/"),
+		url.PathEscape(owner), url.PathEscape(repository), pullRequestID)
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "token "+client.vcsInfo.Token)
+	client.logger.Debug("disabling auto-merge of pull request:", pullRequestID)
+	response, err := newHTTPClient(client.vcsInfo, client.logger).Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to disable the auto-merge of pull request %d, status: %s", pullRequestID, response.Status)
+	}
+	return nil
+}
+
+// UpdatePullRequest on Gitea
+func (client *GiteaClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranch string,
+	pullRequestID int, state *PullRequestState) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validatePullRequestState(state); err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	options := gitea.EditPullRequestOption{
+		Title: title,
+		Body:  body,
+		Base:  targetBranch,
+	}
+	if state != nil {
+		giteaState := gitea.StateOpen
+		if *state == PullRequestClosed {
+			giteaState = gitea.StateClosed
+		}
+		options.State = &giteaState
+	}
+	client.logger.Debug("updating pull request:", pullRequestID)
+	_, _, err = giteaClient.EditPullRequest(owner, repository, int64(pullRequestID), options)
+	return err
+}
+
+// ApprovePullRequest on Gitea
+func (client *GiteaClient) ApprovePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	return client.SubmitPullRequestReview(ctx, owner, repository, pullRequestID, ReviewApprove, "")
+}
+
+// SubmitPullRequestReview on Gitea
+func (client *GiteaClient) SubmitPullRequestReview(ctx context.Context, owner, repository string, pullRequestID int,
+	reviewEvent ReviewEvent, body string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewBody(reviewEvent, body); err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("submitting review on pull request:", pullRequestID)
+	_, _, err = giteaClient.CreatePullReview(owner, repository, int64(pullRequestID), gitea.CreatePullReviewOptions{
+		State: getGiteaReviewState(reviewEvent),
+		Body:  body,
+	})
+	return err
+}
+
+// AddPullRequestComment on Gitea
+func (client *GiteaClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	// In Gitea, pull requests are issues. Pull request comments are added to the issue.
+	_, _, err = giteaClient.CreateIssueComment(owner, repository, int64(pullRequestID), gitea.CreateIssueCommentOption{Body: content})
+	return err
+}
+
+// ListPullRequestComments on Gitea
+func (client *GiteaClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return []CommentInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return []CommentInfo{}, err
+	}
+	comments, _, err := giteaClient.ListIssueComments(owner, repository, int64(pullRequestID), gitea.ListIssueCommentOptions{})
+	if err != nil {
+		return []CommentInfo{}, err
+	}
+	return mapGiteaCommentsToCommentInfoList(comments), nil
+}
+
+// UpdatePullRequestComment on Gitea
+func (client *GiteaClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, _ int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Pull request comments are issue comments, which are identified by the repository only
+	_, _, err = giteaClient.EditIssueComment(owner, repository, commentID, gitea.EditIssueCommentOption{Body: content})
+	return err
+}
+
+// DeletePullRequestComment on Gitea
+func (client *GiteaClient) DeletePullRequestComment(ctx context.Context, owner, repository string, _ int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteIssueComment(owner, repository, commentID)
+	return err
+}
+
+// AddCommentReaction on Gitea
+func (client *GiteaClient) AddCommentReaction(ctx context.Context, owner, repository string, _ int, commentID int64, reaction Reaction) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "reaction": string(reaction)})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.PostIssueCommentReaction(owner, repository, commentID, string(reaction))
+	return err
+}
+
+// ListCommentReactions on Gitea
+func (client *GiteaClient) ListCommentReactions(ctx context.Context, owner, repository string, _ int, commentID int64) ([]ReactionInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reactions, _, err := giteaClient.GetIssueCommentReactions(owner, repository, commentID)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ReactionInfo, 0, len(reactions))
+	for _, reaction := range reactions {
+		info := ReactionInfo{Reaction: Reaction(reaction.Reaction)}
+		if reaction.User != nil {
+			info.User = reaction.User.UserName
+		}
+		results = append(results, info)
+	}
+	return results, nil
+}
+
+// AddPullRequestReviewComment on Gitea
+func (client *GiteaClient) AddPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int,
+	comment PullRequestReviewComment) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewComment(comment); err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Gitea doesn't support multi-line comments, so the comment is anchored to the last line of the range
+	_, _, err = giteaClient.CreatePullReview(owner, repository, int64(pullRequestID), gitea.CreatePullReviewOptions{
+		State: gitea.ReviewStateComment,
+		Comments: []gitea.CreatePullReviewComment{{
+			Path:       comment.Path,
+			Body:       comment.Content,
+			NewLineNum: int64(comment.Line),
+		}},
+	})
+	return err
+}
+
+// ListPullRequestReviewComments on Gitea
+func (client *GiteaClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewComment, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestReviewComment
+	for nextPage := 1; nextPage > 0; {
+		options := gitea.ListPullReviewsOptions{ListOptions: gitea.ListOptions{Page: nextPage}}
+		reviews, response, err := giteaClient.ListPullReviews(owner, repository, int64(pullRequestID), options)
+		if err != nil {
+			return nil, err
+		}
+		// Gitea returns the inline comments per review
+		for _, review := range reviews {
+			if review.CodeCommentsCount == 0 {
+				continue
+			}
+			comments, _, err := giteaClient.ListPullReviewComments(owner, repository, int64(pullRequestID), review.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, comment := range comments {
+				results = append(results, mapGiteaPullReviewCommentToReviewComment(comment))
+			}
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// CreatePullRequestThread on Gitea
+func (client *GiteaClient) CreatePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (string, error) {
+	return "", errGiteaPullRequestThreadsNotSupported
+}
+
+// ResolvePullRequestThread on Gitea
+func (client *GiteaClient) ResolvePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	return errGiteaPullRequestThreadsNotSupported
+}
+
+// ListOpenPullRequests on Gitea
+func (client *GiteaClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return []PullRequestInfo{}, err
+	}
+	client.logger.Debug("fetching open pull requests in", repository)
+	pullRequests, _, err := giteaClient.ListRepoPullRequests(owner, repository, gitea.ListPullRequestsOptions{State: gitea.StateOpen})
+	if err != nil {
+		return []PullRequestInfo{}, err
+	}
+	return mapGiteaPullRequestToPullRequestInfoList(pullRequests), nil
+}
+
+// ListOpenPullRequestsWithFilter on Gitea
+func (client *GiteaClient) ListOpenPullRequestsWithFilter(ctx context.Context, owner, repository string, filter PullRequestFilter) ([]PullRequestInfo, error) {
+	return listPageOrAll(ctx, client.ListOpenPullRequestsPager(owner, repository, filter), filter.Page)
+}
+
+// ListOpenPullRequestsPager on Gitea
+func (client *GiteaClient) ListOpenPullRequestsPager(owner, repository string, filter PullRequestFilter) *Pager[PullRequestInfo] {
+	options := gitea.ListPullRequestsOptions{
+		ListOptions: gitea.ListOptions{Page: firstPage(filter.Page), PageSize: filter.PerPage},
+		State:       gitea.StateOpen,
+	}
+	return newPager(func(ctx context.Context) ([]PullRequestInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{
+			"owner":      owner,
+			"repository": repository,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		giteaClient, err := client.buildGiteaClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching open pull requests page", options.Page, "in", repository)
+		pullRequests, response, err := giteaClient.ListRepoPullRequests(owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		// The Gitea API can't filter pull requests by author, branch, label name or update time
+		var results []PullRequestInfo
+		for _, pullRequest := range pullRequests {
+			pullRequestInfo := mapGiteaPullRequestToPullRequestInfo(pullRequest)
+			if filter.matches(pullRequestInfo, vcsutils.DefaultIfNotNil(pullRequest.Updated)) {
+				results = append(results, pullRequestInfo)
+			}
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// GetPullRequestByID on Gitea
+func (client *GiteaClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	client.logger.Debug("fetching pull request", pullRequestID, "in", repository)
+	pullRequest, _, err := giteaClient.GetPullRequest(owner, repository, int64(pullRequestID))
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapGiteaPullRequestToPullRequestInfo(pullRequest), nil
+}
+
+// GetPullRequestDetails on Gitea
+func (client *GiteaClient) GetPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestDetails, error) {
+	return getPullRequestDetails(ctx, client, owner, repository, pullRequestID, true)
+}
+
+// GetPullRequestMergeableState on Gitea
+func (client *GiteaClient) GetPullRequestMergeableState(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestMergeableState, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	pullRequest, _, err := giteaClient.GetPullRequest(owner, repository, int64(pullRequestID))
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	// Gitea computes the mergeable flag of open pull requests by test merging them into the target branch
+	state := PullRequestMergeableState{
+		HasConflicts: pullRequest.State == gitea.StateOpen && !pullRequest.Mergeable,
+		BehindTarget: pullRequest.MergeBase != "" && pullRequest.MergeBase != pullRequest.Base.Sha,
+	}
+	protection, err := client.GetBranchProtection(ctx, owner, repository, pullRequest.Base.Ref)
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	if protection != nil {
+		state.MissingStatusChecks, err = getMissingStatusChecks(ctx, client, owner, repository, pullRequest.Head.Sha, protection.RequiredStatusChecks)
+		if err != nil {
+			return PullRequestMergeableState{}, err
+		}
+		if protection.RequiredApprovingReviewCount > 0 {
+			approvals, err := countGiteaApprovals(giteaClient, owner, repository, pullRequestID)
+			if err != nil {
+				return PullRequestMergeableState{}, err
+			}
+			state.MissingApprovals = getMissingApprovals(protection.RequiredApprovingReviewCount, approvals)
+		}
+	}
+	state.Mergeable = pullRequest.Mergeable && state.isMergeable()
+	return state, nil
+}
+
+// countGiteaApprovals counts the official approvals of a pull request, which are the ones counted towards the required approvals
+func countGiteaApprovals(giteaClient *gitea.Client, owner, repository string, pullRequestID int) (int, error) {
+	approvals := 0
+	for nextPage := 1; nextPage > 0; {
+		options := gitea.ListPullReviewsOptions{ListOptions: gitea.ListOptions{Page: nextPage}}
+		reviews, response, err := giteaClient.ListPullReviews(owner, repository, int64(pullRequestID), options)
+		if err != nil {
+			return 0, err
+		}
+		for _, review := range reviews {
+			if review.State == gitea.ReviewStateApproved && review.Official && !review.Dismissed && !review.Stale {
+				approvals++
+			}
+		}
+		nextPage = response.NextPage
+	}
+	return approvals, nil
+}
+
+// ListPullRequestFiles on Gitea
+func (client *GiteaClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestFile
+	for nextPage := 1; nextPage > 0; {
+		files, response, err := giteaClient.ListPullRequestFiles(owner, repository, int64(pullRequestID), gitea.ListPullRequestFilesOptions{
+			ListOptions: gitea.ListOptions{Page: nextPage, PageSize: 50},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			results = append(results, PullRequestFile{
+				Path:         file.Filename,
+				PreviousPath: file.PreviousFilename,
+				Status:       getGiteaFileStatus(file.Status),
+				Additions:    file.Additions,
+				Deletions:    file.Deletions,
+			})
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetPullRequestDiff on Gitea
+func (client *GiteaClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return "", err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	diff, _, err := giteaClient.GetPullRequestDiff(owner, repository, int64(pullRequestID), gitea.PullRequestDiffOptions{})
+	return string(diff), err
+}
+
+// ListPullRequestCommits on Gitea
+func (client *GiteaClient) ListPullRequestCommits(ctx context.Context, owner, repository string, pullRequestID int) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitInfo
+	for nextPage := 1; nextPage > 0; {
+		commits, response, err := giteaClient.ListPullRequestCommits(owner, repository, int64(pullRequestID), gitea.ListPullRequestCommitsOptions{
+			ListOptions: gitea.ListOptions{Page: nextPage, PageSize: 50},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			results = append(results, mapGiteaCommitToCommitInfo(commit))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetDefaultReviewers on Gitea. Gitea has no default reviewers, so the code owners of the files changed by the source branch are returned.
+func (client *GiteaClient) GetDefaultReviewers(ctx context.Context, owner, repository, sourceBranch, targetBranch string) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "source branch": sourceBranch, "target branch": targetBranch})
+	if err != nil {
+		return nil, err
+	}
+	return getCodeOwnersReviewers(ctx, client, owner, repository, sourceBranch, targetBranch)
+}
+
+// GetLatestCommit on Gitea
+func (client *GiteaClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     branch,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	commits, _, err := giteaClient.ListRepoCommits(owner, repository, gitea.ListCommitOptions{
+		ListOptions: gitea.ListOptions{Page: 1, PageSize: 1},
+		SHA:         branch,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	if len(commits) > 0 {
+		return mapGiteaCommitToCommitInfo(commits[0]), nil
+	}
+	return CommitInfo{}, nil
+}
+
+// GetRepositoryInfo on Gitea
+func (client *GiteaClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	repo, _, err := giteaClient.GetRepo(owner, repository)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	info := mapGiteaRepositoryToRepositoryInfo(repo)
+	// Gitea allows up to 25 topics, so they are returned in a single page
+	info.Topics, _, err = giteaClient.ListRepoTopics(owner, repository, gitea.ListRepoTopicsOptions{ListOptions: gitea.ListOptions{Page: 1, PageSize: 50}})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return info, nil
+}
+
+// GetRepositoryLanguages on Gitea
+func (client *GiteaClient) GetRepositoryLanguages(ctx context.Context, owner, repository string) (map[string]float64, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languages, _, err := giteaClient.GetRepoLanguages(owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	return getLanguagePercentages(languages), nil
+}
+
+// CreateRepository on Gitea
+func (client *GiteaClient) CreateRepository(ctx context.Context, owner, name string, options CreateRepositoryOptions) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "name": name})
+	if err != nil {
+		return err
+	}
+	if err = validateCreateRepositoryOptions(options); err != nil {
+		return err
+	}
+	if options.Visibility != nil && *options.Visibility == Internal {
+		return errGiteaInternalRepositoriesNotSupported
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	createOptions := gitea.CreateRepoOption{
+		Name:          name,
+		Description:   options.Description,
+		Private:       options.isPrivate(),
+		AutoInit:      options.InitWithReadme,
+		DefaultBranch: options.DefaultBranch,
+	}
+	// Gitea creates the repositories of the authenticated user without an organization
+	user, _, err := giteaClient.GetMyUserInfo()
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(user.UserName, owner) {
+		_, _, err = giteaClient.CreateRepo(createOptions)
+	} else {
+		_, _, err = giteaClient.CreateOrgRepo(owner, createOptions)
+	}
+	return err
+}
+
+// DeleteRepository on Gitea
+func (client *GiteaClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteRepo(owner, repository)
+	return err
+}
+
+// ForkRepository on Gitea
+func (client *GiteaClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string, _ bool) (ForkInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	options := gitea.CreateForkOption{}
+	if targetOwner != "" {
+		options.Organization = &targetOwner
+	}
+	// Gitea returns the fork once its content is available
+	fork, _, err := giteaClient.CreateFork(owner, repository, options)
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	forkInfo := ForkInfo{
+		Repository:     fork.Name,
+		RepositoryInfo: mapGiteaRepositoryToRepositoryInfo(fork),
+	}
+	if fork.Owner != nil {
+		forkInfo.Owner = fork.Owner.UserName
+	}
+	return forkInfo, nil
+}
+
+// ListCollaborators on Gitea
+func (client *GiteaClient) ListCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []CollaboratorInfo
+	for nextPage := 1; nextPage > 0; {
+		options := gitea.ListCollaboratorsOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: 50}}
+		users, response, err := giteaClient.ListCollaborators(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		// Gitea doesn't return the permissions of the collaborators in the list
+		for _, user := range users {
+			permission, err := getGiteaCollaboratorPermission(giteaClient, owner, repository, user.UserName)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, CollaboratorInfo{Username: user.UserName, Permission: permission})
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// AddCollaborator on Gitea
+func (client *GiteaClient) AddCollaborator(ctx context.Context, owner, repository, username string, permission RepositoryPermission) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return err
+	}
+	if err = validateCollaboratorPermission(permission); err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	accessMode := getGiteaAccessMode(permission)
+	_, err = giteaClient.AddCollaborator(owner, repository, username, gitea.AddCollaboratorOption{Permission: &accessMode})
+	return err
+}
+
+// RemoveCollaborator on Gitea
+func (client *GiteaClient) RemoveCollaborator(ctx context.Context, owner, repository, username string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteCollaborator(owner, repository, username)
+	return err
+}
+
+// GetUserPermission on Gitea
+func (client *GiteaClient) GetUserPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return PermissionNone, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return PermissionNone, err
+	}
+	return getGiteaCollaboratorPermission(giteaClient, owner, repository, username)
+}
+
+func getGiteaCollaboratorPermission(giteaClient *gitea.Client, owner, repository, username string) (RepositoryPermission, error) {
+	permission, _, err := giteaClient.CollaboratorPermission(owner, repository, username)
+	if err != nil || permission == nil {
+		return PermissionNone, err
+	}
+	return mapGiteaAccessModeToRepositoryPermission(permission.Permission), nil
+}
+
+// GetCommitBySha on Gitea
+func (client *GiteaClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	commit, _, err := giteaClient.GetSingleCommit(owner, repository, sha)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapGiteaCommitToCommitInfo(commit), nil
+}
+
+// ListCommits on Gitea. Gitea doesn't filter the commits by author or date, so these filters are applied to each fetched page.
+func (client *GiteaClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	return listPageOrAll(ctx, client.listCommitsPager(owner, repository, options), options.Page)
+}
+
+func (client *GiteaClient) listCommitsPager(owner, repository string, options ListCommitsOptions) *Pager[CommitInfo] {
+	listOptions := gitea.ListCommitOptions{
+		ListOptions: gitea.ListOptions{Page: firstPage(options.Page), PageSize: options.PerPage},
+		SHA:         options.Branch,
+		Path:        options.Path,
+	}
+	return newPager(func(ctx context.Context) ([]CommitInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		giteaClient, err := client.buildGiteaClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching commits page", listOptions.Page, "in", repository)
+		commits, response, err := giteaClient.ListRepoCommits(owner, repository, listOptions)
+		if err != nil {
+			return nil, false, err
+		}
+		var results []CommitInfo
+		for _, commit := range commits {
+			commitInfo := mapGiteaCommitToCommitInfo(commit)
+			if options.matches(time.Unix(commitInfo.Timestamp, 0), getGiteaCommitAuthorIdentities(commit)...) {
+				results = append(results, commitInfo)
+			}
+		}
+		listOptions.Page = response.NextPage
+		return results, listOptions.Page > 0, nil
+	})
+}
+
+// GetCommit on Gitea. Gitea returns only the paths of the changed files, so they're reported as modified.
+func (client *GiteaClient) GetCommit(ctx context.Context, owner, repository, sha string) (CommitDetails, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha})
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	commit, _, err := giteaClient.GetSingleCommit(owner, repository, sha)
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	result := CommitDetails{CommitInfo: mapGiteaCommitToCommitInfo(commit)}
+	if commit.Stats != nil {
+		result.Additions, result.Deletions = commit.Stats.Additions, commit.Stats.Deletions
+	}
+	for _, file := range commit.Files {
+		result.Files = append(result.Files, PullRequestFile{Path: file.Filename, Status: FileModified})
+	}
+	return result, nil
+}
+
+// GetCommitVerification on Gitea. The signer isn't reported by the Gitea client.
+func (client *GiteaClient) GetCommitVerification(ctx context.Context, owner, repository, sha string) (CommitVerificationInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha})
+	if err != nil {
+		return CommitVerificationInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return CommitVerificationInfo{}, err
+	}
+	commit, _, err := giteaClient.GetSingleCommit(owner, repository, sha)
+	if err != nil {
+		return CommitVerificationInfo{}, err
+	}
+	if commit.RepoCommit == nil || commit.RepoCommit.Verification == nil {
+		return CommitVerificationInfo{}, nil
+	}
+	verification := commit.RepoCommit.Verification
+	return CommitVerificationInfo{
+		Signed:        verification.Signature != "",
+		Verified:      verification.Verified,
+		SignatureType: getSignatureType(verification.Signature),
+		Reason:        verification.Reason,
+	}, nil
+}
+
+// CompareCommits on Gitea. Gitea doesn't return the changed files of the comparison, so Files is left empty.
+func (client *GiteaClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	comparison, _, err := giteaClient.CompareCommits(owner, repository, base, head)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	// Gitea doesn't return the number of commits head is behind base, so the refs are compared the other way around as well
+	reverseComparison, _, err := giteaClient.CompareCommits(owner, repository, head, base)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	result := CommitsComparison{AheadBy: comparison.TotalCommits, BehindBy: reverseComparison.TotalCommits}
+	for _, commit := range comparison.Commits {
+		result.Commits = append(result.Commits, mapGiteaCommitToCommitInfo(commit))
+	}
+	return result, nil
+}
+
+// GetCommitsBetween on Gitea
+func (client *GiteaClient) GetCommitsBetween(ctx context.Context, owner, repository, fromSha, toSha string) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"fromSha":    fromSha,
+		"toSha":      toSha,
+	})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	comparison, _, err := giteaClient.CompareCommits(owner, repository, fromSha, toSha)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]CommitInfo, 0, len(comparison.Commits))
+	for _, commit := range comparison.Commits {
+		results = append(results, mapGiteaCommitToCommitInfo(commit))
+	}
+	return results, nil
+}
+
+// ListContributors on Gitea. The contributors are counted from the commits, since Gitea doesn't provide a contributors API.
+func (client *GiteaClient) ListContributors(ctx context.Context, owner, repository string) ([]ContributorInfo, error) {
+	return listContributorsFromCommits(ctx, client, owner, repository)
+}
+
+// GetCommitActivity on Gitea. The activity is counted from the commits, since Gitea doesn't provide commit statistics.
+func (client *GiteaClient) GetCommitActivity(ctx context.Context, owner, repository string, since time.Time) ([]CommitActivityInfo, error) {
+	return getCommitActivityFromCommits(ctx, client, owner, repository, since)
+}
+
+// CreateLabel on Gitea
+func (client *GiteaClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateLabel(owner, repository, gitea.CreateLabelOption{
+		Name:        labelInfo.Name,
+		Description: labelInfo.Description,
+		Color:       "#" + labelInfo.Color,
+	})
+	return err
+}
+
+// GetLabel on Gitea
+func (client *GiteaClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return nil, err
+	}
+	label, err := client.findLabel(ctx, owner, repository, name)
+	if err != nil || label == nil {
+		return nil, err
+	}
+	return mapGiteaLabelToLabelInfo(label), nil
+}
+
+// ListLabels on Gitea
+func (client *GiteaClient) ListLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	return client.ListLabelsPager(owner, repository, 100).All(ctx)
+}
+
+// ListLabelsPager on Gitea
+func (client *GiteaClient) ListLabelsPager(owner, repository string, perPage int) *Pager[LabelInfo] {
+	labelsPager := client.labelsPager(owner, repository, perPage)
+	return newPager(func(ctx context.Context) ([]LabelInfo, bool, error) {
+		labels, hasNext, err := labelsPager.fetchPage(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]LabelInfo, 0, len(labels))
+		for _, label := range labels {
+			results = append(results, *mapGiteaLabelToLabelInfo(label))
+		}
+		return results, hasNext, nil
+	})
+}
+
+// UpdateLabel on Gitea
+func (client *GiteaClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	// Gitea updates labels by their ID, so the label should be found first
+	label, err := client.findLabel(ctx, owner, repository, name)
+	if err != nil {
+		return err
+	}
+	if label == nil {
+		return fmt.Errorf("label %s: %w", name, ErrNotFound)
+	}
+	options := gitea.EditLabelOption{}
+	if labelInfo.Name != "" {
+		options.Name = &labelInfo.Name
+	}
+	if labelInfo.Description != "" {
+		options.Description = &labelInfo.Description
+	}
+	if labelInfo.Color != "" {
+		options.Color = gitea.OptionalString("#" + labelInfo.Color)
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.EditLabel(owner, repository, label.ID, options)
+	return err
+}
+
+// DeleteLabel on Gitea
+func (client *GiteaClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	// Gitea deletes labels by their ID, so the label should be found first
+	label, err := client.findLabel(ctx, owner, repository, name)
+	if err != nil {
+		return err
+	}
+	if label == nil {
+		return fmt.Errorf("label %s: %w", name, ErrNotFound)
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteLabel(owner, repository, label.ID)
+	return err
+}
+
+// labelsPager iterates over the labels of a repository, as returned by Gitea
+func (client *GiteaClient) labelsPager(owner, repository string, perPage int) *Pager[*gitea.Label] {
+	options := gitea.ListLabelsOptions{ListOptions: gitea.ListOptions{Page: 1, PageSize: perPage}}
+	return newPager(func(ctx context.Context) ([]*gitea.Label, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		giteaClient, err := client.buildGiteaClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		labels, response, err := giteaClient.ListRepoLabels(owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		options.Page = response.NextPage
+		return labels, options.Page > 0, nil
+	})
+}
+
+// findLabel returns the label with the given name, or nil if the repository has no such label
+func (client *GiteaClient) findLabel(ctx context.Context, owner, repository, name string) (*gitea.Label, error) {
+	labels, err := client.labelsPager(owner, repository, 100).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, label := range labels {
+		if label.Name == name {
+			return label, nil
+		}
+	}
+	return nil, nil
+}
+
+func mapGiteaLabelToLabelInfo(label *gitea.Label) *LabelInfo {
+	return &LabelInfo{
+		Name:        label.Name,
+		Description: label.Description,
+		Color:       strings.TrimPrefix(label.Color, "#"),
+	}
+}
+
+// ListPullRequestLabels on Gitea
+func (client *GiteaClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return []string{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return []string{}, err
+	}
+	labels, _, err := giteaClient.GetIssueLabels(owner, repository, int64(pullRequestID), gitea.ListLabelsOptions{})
+	if err != nil {
+		return []string{}, err
+	}
+	results := make([]string, 0, len(labels))
+	for _, label := range labels {
+		results = append(results, label.Name)
+	}
+	return results, nil
+}
+
+// UnlabelPullRequest on Gitea
+func (client *GiteaClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Gitea removes labels by their ID, so the label should be found first
+	labels, _, err := giteaClient.GetIssueLabels(owner, repository, int64(pullRequestID), gitea.ListLabelsOptions{})
+	if err != nil {
+		return err
+	}
+	for _, label := range labels {
+		if label.Name == name {
+			_, err = giteaClient.DeleteIssueLabel(owner, repository, int64(pullRequestID), label.ID)
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateIssue on Gitea
+func (client *GiteaClient) CreateIssue(ctx context.Context, owner, repository, title, body string, labels []string) (IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title})
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	options := gitea.CreateIssueOption{Title: title, Body: body}
+	if len(labels) > 0 {
+		// Gitea adds labels to issues by their ID
+		options.Labels, err = client.getLabelIDs(ctx, owner, repository, labels)
+		if err != nil {
+			return IssueInfo{}, err
+		}
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	issue, _, err := giteaClient.CreateIssue(owner, repository, options)
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	return mapGiteaIssueToIssueInfo(issue), nil
+}
+
+// AddIssueComment on Gitea
+func (client *GiteaClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateIssueComment(owner, repository, int64(issueID), gitea.CreateIssueCommentOption{Body: content})
+	return err
+}
+
+// ListIssues on Gitea
+func (client *GiteaClient) ListIssues(ctx context.Context, owner, repository string, filter IssueFilter) ([]IssueInfo, error) {
+	return listPageOrAll(ctx, client.issuesPager(owner, repository, filter), filter.Page)
+}
+
+func (client *GiteaClient) issuesPager(owner, repository string, filter IssueFilter) *Pager[IssueInfo] {
+	options := gitea.ListIssueOption{
+		ListOptions: gitea.ListOptions{Page: firstPage(filter.Page), PageSize: filter.PerPage},
+		State:       gitea.StateAll,
+		Type:        gitea.IssueTypeIssue,
+		CreatedBy:   filter.Author,
+	}
+	if filter.State != nil {
+		options.State = mapIssueStateToGiteaState(*filter.State)
+	}
+	if filter.Label != "" {
+		options.Labels = []string{filter.Label}
+	}
+	return newPager(func(ctx context.Context) ([]IssueInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		giteaClient, err := client.buildGiteaClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		issues, response, err := giteaClient.ListRepoIssues(owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]IssueInfo, 0, len(issues))
+		for _, issue := range issues {
+			results = append(results, mapGiteaIssueToIssueInfo(issue))
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// UpdateIssueState on Gitea
+func (client *GiteaClient) UpdateIssueState(ctx context.Context, owner, repository string, issueID int, state IssueState) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	giteaState := mapIssueStateToGiteaState(state)
+	_, _, err = giteaClient.EditIssue(owner, repository, int64(issueID), gitea.EditIssueOption{State: &giteaState})
+	return err
+}
+
+// getLabelIDs returns the IDs of the labels with the given names
+func (client *GiteaClient) getLabelIDs(ctx context.Context, owner, repository string, names []string) ([]int64, error) {
+	labels, err := client.labelsPager(owner, repository, 100).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labelIDs := make(map[string]int64, len(labels))
+	for _, label := range labels {
+		labelIDs[label.Name] = label.ID
+	}
+	results := make([]int64, 0, len(names))
+	for _, name := range names {
+		labelID, exists := labelIDs[name]
+		if !exists {
+			return nil, fmt.Errorf("label %s: %w", name, ErrNotFound)
+		}
+		results = append(results, labelID)
+	}
+	return results, nil
+}
+
+// UploadCodeScanning on Gitea
+func (client *GiteaClient) UploadCodeScanning(_ context.Context, _, _, _, _ string) (string, error) {
+	return "", errGiteaCodeScanningNotSupported
+}
+
+// UploadScanResults on Gitea
+func (client *GiteaClient) UploadScanResults(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	return "", errGiteaCodeScanningNotSupported
+}
+
+// ListSecurityAlerts on Gitea
+func (client *GiteaClient) ListSecurityAlerts(ctx context.Context, owner, repository string) ([]SecurityAlertInfo, error) {
+	return nil, errGiteaSecurityAlertsNotSupported
+}
+
+// DownloadFileFromRepo on Gitea
+func (client *GiteaClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	content, response, err := giteaClient.GetFile(owner, repository, branch, path)
+	if response == nil {
+		return nil, 0, err
+	}
+	if err != nil {
+		return nil, response.StatusCode, err
+	}
+	return content, response.StatusCode, nil
+}
+
+// GetFileContent on Gitea
+func (client *GiteaClient) GetFileContent(ctx context.Context, owner, repository, ref, path string) (FileContent, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"ref":        ref,
+		"path":       path,
+	})
+	if err != nil {
+		return FileContent{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return FileContent{}, err
+	}
+	contents, _, err := giteaClient.GetContents(owner, repository, ref, path)
+	if err != nil {
+		return FileContent{}, err
+	}
+	if contents.Type != "file" || contents.Content == nil {
+		return FileContent{}, fmt.Errorf("%s is not a file", path)
+	}
+	content, err := base64.StdEncoding.DecodeString(*contents.Content)
+	if err != nil {
+		return FileContent{}, err
+	}
+	return FileContent{Path: contents.Path, Content: content, Size: contents.Size, Sha: contents.SHA}, nil
+}
+
+// GetFileBlame on Gitea
+func (client *GiteaClient) GetFileBlame(_ context.Context, _, _, _, _ string) ([]BlameLine, error) {
+	return nil, errGiteaBlameNotSupported
+}
+
+// ListRepositoryTree on Gitea. Trees larger than the page size of the Gitea API are truncated.
+func (client *GiteaClient) ListRepositoryTree(ctx context.Context, owner, repository, ref string, recursive bool) ([]TreeEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree, _, err := giteaClient.GetTrees(owner, repository, ref, recursive)
+	if err != nil {
+		return nil, err
+	}
+	if tree.Truncated {
+		client.logger.Warn("the tree of", repository, "at", ref, "is truncated")
+	}
+	results := make([]TreeEntry, 0, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		results = append(results, TreeEntry{Path: entry.Path, Type: getGitTreeEntryType(entry.Type), Size: entry.Size})
+	}
+	return results, nil
+}
+
+// SearchCode on Gitea
+func (client *GiteaClient) SearchCode(ctx context.Context, scope CodeSearchScope, query string) ([]CodeSearchResult, error) {
+	return nil, errGiteaCodeSearchNotSupported
+}
+
+// CreateOrUpdateFile on Gitea
+func (client *GiteaClient) CreateOrUpdateFile(ctx context.Context, owner, repository, branch, path string, content []byte, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":          owner,
+		"repository":     repository,
+		"branch":         branch,
+		"path":           path,
+		"commit message": commitMessage,
+	})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	fileOptions := gitea.FileOptions{Message: commitMessage, BranchName: branch}
+	encodedContent := base64.StdEncoding.EncodeToString(content)
+	contents, response, err := giteaClient.GetContents(owner, repository, branch, path)
+	if err != nil {
+		if response == nil || response.StatusCode != http.StatusNotFound {
+			return err
+		}
+		client.logger.Debug("creating file", path, "on branch", branch)
+		_, _, err = giteaClient.CreateFile(owner, repository, path, gitea.CreateFileOptions{FileOptions: fileOptions, Content: encodedContent})
+		return err
+	}
+	// Updating an existing file requires the SHA of the blob being replaced
+	client.logger.Debug("updating file", path, "on branch", branch)
+	_, _, err = giteaClient.UpdateFile(owner, repository, path, gitea.UpdateFileOptions{FileOptions: fileOptions, SHA: contents.SHA, Content: encodedContent})
+	return err
+}
+
+// CommitFiles on Gitea
+func (client *GiteaClient) CommitFiles(_ context.Context, _, _, _, _ string, _ []FileChange) error {
+	return errGiteaCommitFilesNotSupported
+}
+
+// GetRepositoryEnvironmentInfo on Gitea
+func (client *GiteaClient) GetRepositoryEnvironmentInfo(_ context.Context, _, _, _ string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errGiteaGetRepoEnvironmentInfoNotSupported
+}
+
+// ListEnvironments on Gitea
+func (client *GiteaClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errGiteaDeploymentsNotSupported
+}
+
+// CreateDeployment on Gitea
+func (client *GiteaClient) CreateDeployment(ctx context.Context, owner, repository string, deployment DeploymentInfo) (int64, error) {
+	return 0, errGiteaDeploymentsNotSupported
+}
+
+// SetDeploymentStatus on Gitea
+func (client *GiteaClient) SetDeploymentStatus(ctx context.Context, owner, repository string, deploymentID int64, status DeploymentStatusInfo) error {
+	return errGiteaDeploymentsNotSupported
+}
+
+func createGiteaHookConfig(token, payloadURL string) map[string]string {
+	return map[string]string{
+		"url":          payloadURL,
+		"content_type": "json",
+		"secret":       token,
+	}
+}
+
+func createGiteaHookOption(token string, options WebhookOptions) gitea.CreateHookOption {
+	config := createGiteaHookConfig(token, options.PayloadURL)
+	config["content_type"] = string(options.contentType())
+	return gitea.CreateHookOption{
+		Type:         gitea.HookTypeGitea,
+		Config:       config,
+		Events:       getGiteaWebhookEvents(options.Events...),
+		BranchFilter: options.Branch,
+		Active:       true,
+	}
+}
+
+func mapGiteaHookToWebhookInfo(hook *gitea.Hook) WebhookInfo {
+	return WebhookInfo{
+		ID:          strconv.FormatInt(hook.ID, 10),
+		PayloadURL:  hook.Config["url"],
+		Events:      mapProviderWebhookEvents(hook.Events, getGiteaWebhookEvents),
+		Active:      hook.Active,
+		ContentType: WebhookContentType(hook.Config["content_type"]),
+	}
+}
+
+// Get varargs of webhook events and return a slice of Gitea webhook events
+func getGiteaWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []string {
+	events := make([]string, 0, len(webhookEvents))
+	for _, event := range webhookEvents {
+		switch event {
+		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.PrClosed:
+			events = append(events, "pull_request")
+		case vcsutils.Push:
+			events = append(events, "push")
+		}
+	}
+	return events
+}
+
+func getGiteaCommitState(commitState CommitStatus) gitea.StatusState {
+	switch commitState {
+	case Pass:
+		return gitea.StatusSuccess
+	case Fail:
+		return gitea.StatusFailure
+	case Error:
+		return gitea.StatusError
+	case InProgress:
+		return gitea.StatusPending
+	}
+	return ""
+}
+
+func getGiteaCommitStatus(state gitea.StatusState) CommitStatus {
+	switch state {
+	case gitea.StatusSuccess:
+		return Pass
+	case gitea.StatusFailure:
+		return Fail
+	case gitea.StatusPending:
+		return InProgress
+	}
+	return Error
+}
+
+func mapGiteaAccessModeToRepositoryPermission(accessMode gitea.AccessMode) RepositoryPermission {
+	switch accessMode {
+	case gitea.AccessModeOwner, gitea.AccessModeAdmin:
+		return PermissionAdmin
+	case gitea.AccessModeWrite:
+		return PermissionWrite
+	case gitea.AccessModeRead:
+		return PermissionRead
+	default:
+		return PermissionNone
+	}
+}
+
+func getGiteaAccessMode(permission RepositoryPermission) gitea.AccessMode {
+	switch permission {
+	case PermissionAdmin:
+		return gitea.AccessModeAdmin
+	case PermissionWrite:
+		return gitea.AccessModeWrite
+	default:
+		return gitea.AccessModeRead
+	}
+}
+
+// Gitea reports the size of a repository in kilobytes, and returns its topics in a separate API
+func mapGiteaRepositoryToRepositoryInfo(repo *gitea.Repository) RepositoryInfo {
+	return RepositoryInfo{
+		RepositoryVisibility: getGiteaRepositoryVisibility(repo),
+		CloneInfo:            CloneInfo{HTTP: repo.CloneURL, SSH: repo.SSHURL},
+		DefaultBranch:        repo.DefaultBranch,
+		Description:          repo.Description,
+		Archived:             repo.Archived,
+		Size:                 int64(repo.Size) * 1024,
+	}
+}
+
+func mapGiteaRepositoryToRepositoryDetails(repo *gitea.Repository) RepositoryDetails {
+	details := RepositoryDetails{
+		Repository:     repo.Name,
+		RepositoryInfo: mapGiteaRepositoryToRepositoryInfo(repo),
+		Updated:        repo.Updated,
+	}
+	if repo.Owner != nil {
+		details.Owner = repo.Owner.UserName
+	}
+	return details
+}
+
+func getGiteaRepositoryVisibility(repo *gitea.Repository) RepositoryVisibility {
+	if repo.Private {
+		return Private
+	}
+	return Public
+}
+
+func mapGiteaCommitToCommitInfo(commit *gitea.Commit) CommitInfo {
+	commitInfo := CommitInfo{Url: commit.HTMLURL}
+	if commit.CommitMeta != nil {
+		commitInfo.Hash = commit.SHA
+	}
+	if commit.RepoCommit != nil {
+		commitInfo.Message = commit.RepoCommit.Message
+		if commit.RepoCommit.Author != nil {
+			commitInfo.AuthorName = commit.RepoCommit.Author.Name
+		}
+		if commit.RepoCommit.Committer != nil {
+			commitInfo.CommitterName = commit.RepoCommit.Committer.Name
+			if committed, err := time.Parse(time.RFC3339, commit.RepoCommit.Committer.Date); err == nil {
+				commitInfo.Timestamp = committed.UTC().Unix()
+			}
+		}
+	}
+	for _, parent := range commit.Parents {
+		commitInfo.ParentHashes = append(commitInfo.ParentHashes, parent.SHA)
+	}
+	return commitInfo
+}
+
+// getGiteaCommitAuthorIdentities returns the name and email of the commit author, and the username of the matching Gitea user
+func getGiteaCommitAuthorIdentities(commit *gitea.Commit) []string {
+	var identities []string
+	if commit.RepoCommit != nil && commit.RepoCommit.Author != nil {
+		identities = append(identities, commit.RepoCommit.Author.Name, commit.RepoCommit.Author.Email)
+	}
+	if commit.Author != nil {
+		identities = append(identities, commit.Author.UserName)
+	}
+	return identities
+}
+
+func mapGiteaCommentsToCommentInfoList(comments []*gitea.Comment) (res []CommentInfo) {
+	for _, comment := range comments {
+		res = append(res, CommentInfo{
+			ID:      comment.ID,
+			Content: comment.Body,
+			Created: comment.Created,
+		})
+	}
+	return
+}
+
+func mapGiteaPullReviewCommentToReviewComment(comment *gitea.PullReviewComment) PullRequestReviewComment {
+	line := comment.LineNum
+	if line == 0 {
+		// Comments on removed lines are anchored to the old file version
+		line = comment.OldLineNum
+	}
+	return PullRequestReviewComment{
+		CommentInfo: CommentInfo{
+			ID:      comment.ID,
+			Content: comment.Body,
+			Created: comment.Created,
+		},
+		Path: comment.Path,
+		Line: int(line),
+	}
+}
+
+func mapGiteaPullRequestToPullRequestInfoList(pullRequests []*gitea.PullRequest) (res []PullRequestInfo) {
+	for _, pullRequest := range pullRequests {
+		res = append(res, PullRequestInfo{
+			ID:     pullRequest.Index,
+			Source: mapGiteaBranchInfo(pullRequest.Head),
+			Target: mapGiteaBranchInfo(pullRequest.Base),
+			Draft:  isGiteaDraftPullRequest(pullRequest),
+		})
+	}
+	return
+}
+
+func mapGiteaPullRequestToPullRequestInfo(pullRequest *gitea.PullRequest) PullRequestInfo {
+	pullRequestInfo := PullRequestInfo{
+		ID:     pullRequest.Index,
+		Source: mapGiteaBranchInfo(pullRequest.Head),
+		Target: mapGiteaBranchInfo(pullRequest.Base),
+		Title:  pullRequest.Title,
+		Body:   pullRequest.Body,
+		Draft:  isGiteaDraftPullRequest(pullRequest),
+	}
+	pullRequestInfo.Source.Owner = getGiteaBranchOwner(pullRequest.Head)
+	pullRequestInfo.Target.Owner = getGiteaBranchOwner(pullRequest.Base)
+	switch {
+	case pullRequest.HasMerged:
+		pullRequestInfo.State = PullRequestMerged
+	case pullRequest.State == gitea.StateClosed:
+		pullRequestInfo.State = PullRequestClosed
+	default:
+		// Gitea computes the mergeable flag for open pull requests only
+		pullRequestInfo.Mergeable = &pullRequest.Mergeable
+	}
+	if pullRequest.Poster != nil {
+		pullRequestInfo.Author = pullRequest.Poster.UserName
+	}
+	for _, label := range pullRequest.Labels {
+		pullRequestInfo.Labels = append(pullRequestInfo.Labels, label.Name)
+	}
+	return pullRequestInfo
+}
+
+func isGiteaDraftPullRequest(pullRequest *gitea.PullRequest) bool {
+	_, isDraft := trimDraftTitlePrefix(pullRequest.Title, giteaDraftTitlePrefixes)
+	return isDraft
+}
+
+func mapGiteaBranchInfo(branch *gitea.PRBranchInfo) BranchInfo {
+	if branch == nil {
+		return BranchInfo{}
+	}
+	branchInfo := BranchInfo{Name: branch.Ref}
+	if branch.Repository != nil {
+		branchInfo.Repository = branch.Repository.Name
+	}
+	return branchInfo
+}
+
+func getGiteaBranchOwner(branch *gitea.PRBranchInfo) string {
+	if branch == nil || branch.Repository == nil || branch.Repository.Owner == nil {
+		return ""
+	}
+	return branch.Repository.Owner.UserName
+}
+
+func getGiteaMergeStyle(mergeStrategy MergeStrategy) gitea.MergeStyle {
+	switch mergeStrategy {
+	case SquashMerge:
+		return gitea.MergeStyleSquash
+	case RebaseMerge:
+		return gitea.MergeStyleRebase
+	}
+	return gitea.MergeStyleMerge
+}
+
+func getGiteaReviewState(reviewEvent ReviewEvent) gitea.ReviewStateType {
+	switch reviewEvent {
+	case ReviewRequestChanges:
+		return gitea.ReviewStateRequestChanges
+	case ReviewComment:
+		return gitea.ReviewStateComment
+	}
+	return gitea.ReviewStateApproved
+}
+
+func getGiteaFileStatus(status string) FileStatus {
+	switch status {
+	case "added", "copied":
+		return FileAdded
+	case "deleted":
+		return FileDeleted
+	case "renamed":
+		return FileRenamed
+	}
+	return FileModified
+}
+
+func mapGiteaIssueToIssueInfo(issue *gitea.Issue) IssueInfo {
+	state := IssueOpen
+	if issue.State == gitea.StateClosed {
+		state = IssueClosed
+	}
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, label.Name)
+	}
+	issueInfo := IssueInfo{
+		ID:      issue.Index,
+		Title:   issue.Title,
+		Body:    issue.Body,
+		State:   state,
+		Labels:  labels,
+		URL:     issue.HTMLURL,
+		Created: issue.Created,
+	}
+	if issue.Poster != nil {
+		issueInfo.Author = issue.Poster.UserName
+	}
+	return issueInfo
+}
+
+func mapIssueStateToGiteaState(state IssueState) gitea.StateType {
+	if state == IssueClosed {
+		return gitea.StateClosed
+	}
+	return gitea.StateOpen
+}
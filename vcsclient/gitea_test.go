@@ -0,0 +1,2180 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+func TestGiteaClient_Connection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, gitea.User{}, "/api/v1/user", createGiteaHandler)
+	defer cleanUp()
+
+	err := client.TestConnection(ctx)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).TestConnection(ctx)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ConnectionWhenContextCancelled(t *testing.T) {
+	ctx := context.Background()
+	ctxWithCancel, cancel := context.WithCancel(ctx)
+	cancel()
+
+	client, cleanUp := createWaitingServerAndClient(t, vcsutils.Gitea, 0)
+	defer cleanUp()
+
+	err := client.TestConnection(ctxWithCancel)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGiteaClient_GetRateLimitInfo(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, "", "unsupportedTest", createGiteaHandler)
+	defer cleanUp()
+
+	_, err := client.GetRateLimitInfo(ctx)
+	assert.ErrorIs(t, err, errGiteaRateLimitNotSupported)
+}
+
+func TestGiteaClient_ListRepositories(t *testing.T) {
+	ctx := context.Background()
+	repositories := []gitea.Repository{
+		{Name: repo1, Owner: &gitea.User{UserName: owner}},
+		{Name: repo2, Owner: &gitea.User{UserName: owner}},
+		{Name: repo1, Owner: &gitea.User{UserName: username}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, repositories, "/api/v1/user/repos?limit=0&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	actualRepositories, err := client.ListRepositories(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{owner: {repo1, repo2}, username: {repo1}}, actualRepositories)
+
+	_, err = createBadGiteaClient(t).ListRepositories(ctx)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	const searchURI = "/api/v1/repos/search?archived=false&exclusive=true&is_private=true&limit=1&order=desc&page=%d&sort=updated&uid=3"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.RequestURI {
+		case "/api/v1/version":
+			_, err = w.Write([]byte(`{"version":"1.18.0"}`))
+		case "/api/v1/users/jfrog":
+			_, err = w.Write([]byte(`{"id":3,"login":"jfrog"}`))
+		case fmt.Sprintf(searchURI, 1):
+			w.Header().Set("Link", `<http://`+r.Host+fmt.Sprintf(searchURI, 2)+`>; rel="next"`)
+			_, err = w.Write([]byte(`{"ok":true,"data":[{"name":"repo-1","owner":{"login":"jfrog"},"private":true,"default_branch":"main","updated_at":"2023-03-01T00:00:00Z"}]}`))
+		case fmt.Sprintf(searchURI, 2):
+			_, err = w.Write([]byte(`{"ok":true,"data":[{"name":"repo-2","owner":{"login":"jfrog"},"private":true,"updated_at":"2023-02-01T00:00:00Z"}]}`))
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	private := Private
+	archived := false
+	result, err := client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{Visibility: &private, Archived: &archived, PerPage: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryDetails{
+		{
+			Owner:          owner,
+			Repository:     repo1,
+			RepositoryInfo: RepositoryInfo{RepositoryVisibility: Private, DefaultBranch: "main"},
+			Updated:        time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Owner:          owner,
+			Repository:     repo2,
+			RepositoryInfo: RepositoryInfo{RepositoryVisibility: Private},
+			Updated:        time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, result)
+
+	_, err = client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{Language: "Go"})
+	assert.ErrorIs(t, err, errGiteaRepositoryLanguageFilterNotSupported)
+	internal := Internal
+	_, err = client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{Visibility: &internal})
+	assert.ErrorIs(t, err, errGiteaInternalRepositoriesNotSupported)
+
+	_, err = createBadGiteaClient(t).ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{})
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListBranches(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, []gitea.Branch{{Name: branch1}, {Name: branch2}},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/branches?limit=0&page=1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	actualBranches, err := client.ListBranches(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{branch1, branch2}, actualBranches)
+
+	_, err = createBadGiteaClient(t).ListBranches(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListBranchesPager(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var branches []gitea.Branch
+		switch r.RequestURI {
+		case "/api/v1/repos/jfrog/repo-1/branches?limit=1&page=1":
+			w.Header().Add("Link", fmt.Sprintf("<%s/api/v1/repos/jfrog/repo-1/branches?limit=1&page=2>; rel=\"next\"", "http://"+r.Host))
+			branches = []gitea.Branch{{Name: branch1}}
+		case "/api/v1/repos/jfrog/repo-1/branches?limit=1&page=2":
+			branches = []gitea.Branch{{Name: branch2}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(branches)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	pager := buildClient(t, vcsutils.Gitea, false, server).ListBranchesPager(owner, repo1, 1)
+
+	branches, err := pager.All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{branch1, branch2}, branches)
+
+	_, err = createBadGiteaClient(t).ListBranchesPager(owner, repo1, 1).Next(ctx)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListAllBranches(t *testing.T) {
+	ctx := context.Background()
+	response := []gitea.Branch{{Name: branch1, Commit: &gitea.PayloadCommit{ID: "sha1"}}, {Name: "feature", Commit: &gitea.PayloadCommit{ID: "sha2"}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/branches?limit=50&page=1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	actualBranches, err := client.ListAllBranches(ctx, owner, repo1, "branch-")
+	assert.NoError(t, err)
+	assert.Equal(t, []BranchDetails{{Name: branch1, CommitHash: "sha1"}}, actualBranches)
+
+	_, err = createBadGiteaClient(t).ListAllBranches(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.Branch{Name: branch1},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/branches", repo1), http.StatusCreated,
+		[]byte(`{"new_branch_name":"branch-1","old_branch_name":"master"}`), http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateBranch(ctx, owner, repo1, branch1, "master")
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).CreateBranch(ctx, owner, repo1, branch1, "master")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, []byte{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/branches/%s", repo1, branch1), http.StatusNoContent,
+		[]byte{}, http.MethodDelete, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+
+	failingClient, failingCleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, nil,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/branches/%s", repo1, branch1), http.StatusForbidden, createGiteaHandler)
+	defer failingCleanUp()
+	err = failingClient.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListTags(t *testing.T) {
+	ctx := context.Background()
+	response := []gitea.Tag{{Name: "v1.0.0", Message: "Release 1.0.0", Commit: &gitea.CommitMeta{SHA: "sha1"}}, {Name: "v1.1.0", Commit: &gitea.CommitMeta{SHA: "sha2"}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/tags?limit=50&page=1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitHash: "sha1", Message: "Release 1.0.0"}, {Name: "v1.1.0", CommitHash: "sha2"}}, tags)
+
+	_, err = createBadGiteaClient(t).ListTags(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.Tag{Name: "v1.0.0"},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/tags", repo1), http.StatusCreated,
+		[]byte(`{"tag_name":"v1.0.0","message":"Release 1.0.0","target":"sha1"}`), http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateTag(ctx, owner, repo1, "v1.0.0", "sha1", "Release 1.0.0")
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).CreateTag(ctx, owner, repo1, "v1.0.0", "sha1", "Release 1.0.0")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateRelease(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.Release{TagName: "v1.0.0"},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/releases", repo1), http.StatusCreated,
+		[]byte(`{"tag_name":"v1.0.0","target_commitish":"master","name":"v1.0.0","body":"Bug fixes","draft":false,"prerelease":false}`),
+		http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	// The release is named after its tag if no name is provided
+	err := client.CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0", Target: "master", Description: "Bug fixes"})
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0"})
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetLatestRelease(t *testing.T) {
+	ctx := context.Background()
+	response := gitea.Release{TagName: "v1.0.0", Target: "master", Title: "Release 1.0.0", Note: "Bug fixes", HTMLURL: "https://gitea.example.com/jfrog/repo-1/releases/tag/v1.0.0"}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/releases/latest", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	release, err := client.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, &ReleaseInfo{
+		TagName:     "v1.0.0",
+		Target:      "master",
+		Name:        "Release 1.0.0",
+		Description: "Bug fixes",
+		Url:         "https://gitea.example.com/jfrog/repo-1/releases/tag/v1.0.0",
+	}, release)
+
+	noReleasesClient, noReleasesCleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, nil,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/releases/latest", repo1), http.StatusNotFound, createGiteaHandler)
+	defer noReleasesCleanUp()
+	release, err = noReleasesClient.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Nil(t, release)
+}
+
+func TestGiteaClient_UploadReleaseAsset(t *testing.T) {
+	ctx := context.Background()
+	assetPath := filepath.Join(t.TempDir(), "asset.zip")
+	require.NoError(t, os.WriteFile(assetPath, []byte("asset content"), 0600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/v1/repos/jfrog/repo-1/releases/tags/v1.0.0":
+			response = `{"id":1,"tag_name":"v1.0.0"}`
+		case "/api/v1/repos/jfrog/repo-1/releases/1/assets":
+			assert.Equal(t, http.MethodPost, r.Method)
+			file, header, err := r.FormFile("attachment")
+			assert.NoError(t, err)
+			content, err := io.ReadAll(file)
+			assert.NoError(t, err)
+			assert.Equal(t, "asset.zip", header.Filename)
+			assert.Equal(t, "asset content", string(content))
+			w.WriteHeader(http.StatusCreated)
+			response = `{"id":1,"name":"asset.zip"}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	err := buildClient(t, vcsutils.Gitea, false, server).UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", assetPath)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", assetPath)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	response := gitea.BranchProtection{
+		BranchName:             "master",
+		EnablePush:             true,
+		EnablePushWhitelist:    true,
+		PushWhitelistUsernames: []string{"frogger"},
+		EnableStatusCheck:      true,
+		StatusCheckContexts:    []string{"build"},
+		RequiredApprovals:      2,
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/branch_protections/master", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	protection, err := client.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, &BranchProtection{
+		RequiredApprovingReviewCount: 2,
+		RequiredStatusChecks:         []string{"build"},
+		RestrictPushes:               true,
+		AllowedPushUsers:             []string{"frogger"},
+	}, protection)
+
+	notProtectedClient, notProtectedCleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, nil,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/branch_protections/master", repo1), http.StatusNotFound, createGiteaHandler)
+	defer notProtectedCleanUp()
+	protection, err = notProtectedClient.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Nil(t, protection)
+}
+
+func TestGiteaClient_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	protected := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request gitea.CreateBranchProtectionOption
+		switch r.RequestURI {
+		case "/api/v1/repos/jfrog/repo-1/branch_protections/master":
+			if r.Method == http.MethodGet {
+				if !protected {
+					w.WriteHeader(http.StatusNotFound)
+				}
+				break
+			}
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.False(t, request.EnablePush)
+			assert.False(t, request.EnablePushWhitelist)
+		case "/api/v1/repos/jfrog/repo-1/branch_protections":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "master", request.BranchName)
+			assert.True(t, request.EnablePush)
+			assert.False(t, request.EnablePushWhitelist)
+			assert.Equal(t, []string{"build"}, request.StatusCheckContexts)
+			assert.Equal(t, int64(1), request.RequiredApprovals)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(`{"branch_name":"master"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err := client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{RequiredApprovingReviewCount: 1, RequiredStatusChecks: []string{"build"}})
+	assert.NoError(t, err)
+	// Blocking all the pushes to a branch that is already protected
+	protected = true
+	err = client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{RestrictPushes: true})
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{})
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetRequiredChecksAndApprovals(t *testing.T) {
+	ctx := context.Background()
+	response := gitea.BranchProtection{
+		BranchName:                  "master",
+		EnableStatusCheck:           true,
+		StatusCheckContexts:         []string{"build"},
+		BlockOnOutdatedBranch:       true,
+		RequiredApprovals:           2,
+		EnableApprovalsWhitelist:    true,
+		ApprovalsWhitelistUsernames: []string{"frogger"},
+		DismissStaleApprovals:       true,
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/branch_protections/master", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	requiredChecks, err := client.GetRequiredChecks(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredChecksInfo{Titles: []string{"build"}, RequireUpToDate: true}, requiredChecks)
+	requiredApprovals, err := client.GetRequiredApprovals(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredApprovalsInfo{Count: 2, Approvers: []string{"frogger"}, DismissStaleApprovals: true}, requiredApprovals)
+
+	notProtectedClient, notProtectedCleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, nil,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/branch_protections/master", repo1), http.StatusNotFound, createGiteaHandler)
+	defer notProtectedCleanUp()
+	requiredChecks, err = notProtectedClient.GetRequiredChecks(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredChecksInfo{}, requiredChecks)
+	requiredApprovals, err = notProtectedClient.GetRequiredApprovals(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredApprovalsInfo{}, requiredApprovals)
+}
+
+func TestGiteaClient_AddSshKeyToRepository(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"My deploy key","key":"ssh-rsa AAAA...","read_only":true}`)
+
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.DeployKey{ID: 1},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/keys", repo1), http.StatusCreated, expectedBody, http.MethodPost,
+		createGiteaWithBodyHandler)
+	defer closeServer()
+
+	err := client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", Read)
+	assert.NoError(t, err)
+}
+
+func TestGiteaClient_AddSshKeyToRepositoryReadWrite(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"My deploy key","key":"ssh-rsa AAAA...","read_only":false}`)
+
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.DeployKey{ID: 1},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/keys", repo1), http.StatusCreated, expectedBody, http.MethodPost,
+		createGiteaWithBodyHandler)
+	defer closeServer()
+
+	err := client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", ReadWrite)
+	assert.NoError(t, err)
+}
+
+func TestGiteaClient_ListDeployKeys(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":7,"title":"frogbot","key":"ssh-rsa AAAA...","read_only":true}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/keys?limit=50&page=1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	keys, err := client.ListDeployKeys(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []DeployKeyInfo{{ID: 7, Title: "frogbot", Key: "ssh-rsa AAAA...", ReadOnly: true}}, keys)
+
+	_, err = createBadGiteaClient(t).ListDeployKeys(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_AddDeployKey(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.CreateKeyOption{Title: "frogbot", Key: "ssh-rsa AAAA...", ReadOnly: true})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false,
+		[]byte(`{"id":7,"title":"frogbot","key":"ssh-rsa AAAA...","read_only":true}`), fmt.Sprintf("/api/v1/repos/jfrog/%s/keys", repo1),
+		http.StatusCreated, expectedBody, http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	key, err := client.AddDeployKey(ctx, owner, repo1, "frogbot", "ssh-rsa AAAA...", true)
+	require.NoError(t, err)
+	assert.Equal(t, DeployKeyInfo{ID: 7, Title: "frogbot", Key: "ssh-rsa AAAA...", ReadOnly: true}, key)
+
+	_, err = createBadGiteaClient(t).AddDeployKey(ctx, owner, repo1, "frogbot", "ssh-rsa AAAA...", true)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DeleteDeployKey(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, []byte{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/keys/7", repo1), http.StatusNoContent, []byte{}, http.MethodDelete, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteDeployKey(ctx, owner, repo1, 7)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).DeleteDeployKey(ctx, owner, repo1, 7)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int63()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, gitea.Hook{ID: id},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/hooks", repo1), http.StatusCreated, createGiteaHandler)
+	defer cleanUp()
+
+	actualID, token, err := client.CreateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com", vcsutils.Push)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, strconv.FormatInt(id, 10), actualID)
+
+	_, _, err = createBadGiteaClient(t).CreateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com", vcsutils.Push)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateWebhookWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id":1,"events":["push"],"active":true,"config":{"url":"https://jfrog.com","content_type":"form"}}`)
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/hooks", repo1), http.StatusCreated, createGiteaHandler)
+	defer cleanUp()
+
+	options := WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, ContentType: WebhookContentTypeForm}
+	webhook, token, err := client.CreateWebhookWithOptions(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeForm}, webhook)
+
+	options.InsecureSSL = true
+	_, _, err = client.CreateWebhookWithOptions(ctx, owner, repo1, options)
+	assert.ErrorIs(t, err, errGiteaWebhookInsecureSSLNotSupported)
+}
+
+func TestGiteaClient_UpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int63()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, gitea.Hook{ID: id},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/hooks/%d", repo1, id), createGiteaHandler)
+	defer cleanUp()
+
+	err := client.UpdateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com", token, strconv.FormatInt(id, 10),
+		vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).UpdateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com", token, strconv.FormatInt(id, 10),
+		vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DeleteWebhook(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int63()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/hooks/%d", repo1, id), createGiteaHandler)
+	defer cleanUp()
+
+	err := client.DeleteWebhook(ctx, owner, repo1, strconv.FormatInt(id, 10))
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).DeleteWebhook(ctx, owner, repo1, strconv.FormatInt(id, 10))
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":1,"events":["pull_request","push"],"active":true,"config":{"url":"https://jfrog.com/hook","content_type":"json"}},` +
+		`{"id":2,"events":["push"],"active":false,"config":{"url":"https://jfrog.com/push","content_type":"form"}}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/hooks?limit=50&page=1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{
+		{ID: "1", PayloadURL: "https://jfrog.com/hook", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.Push}, Active: true, ContentType: WebhookContentTypeJSON},
+		{ID: "2", PayloadURL: "https://jfrog.com/push", Events: []vcsutils.WebhookEvent{vcsutils.Push}, ContentType: WebhookContentTypeForm},
+	}, webhooks)
+
+	_, err = createBadGiteaClient(t).ListWebhooks(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_SetWebhookActive(t *testing.T) {
+	ctx := context.Background()
+	active := false
+	expectedBody, err := json.Marshal(gitea.EditHookOption{Events: []string{"pull_request", "push"}, Active: &active})
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/v1/version":
+			response = `{"version":"1.18.0"}`
+		case "/api/v1/repos/jfrog/repo-1/hooks/1":
+			if r.Method == http.MethodPatch {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+			}
+			response = `{"id":1,"events":["pull_request","push"],"active":true,"config":{"url":"https://jfrog.com/hook"}}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err = client.SetWebhookActive(ctx, owner, repo1, "1", false)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).SetWebhookActive(ctx, owner, repo1, "1", false)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id":1,"events":["push"],"active":true,"config":{"url":"https://jfrog.com","content_type":"json"}}`)
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, response, "/api/v1/orgs/jfrog/hooks",
+		http.StatusCreated, createGiteaHandler)
+	defer cleanUp()
+
+	webhook, token, err := client.CreateOrgWebhook(ctx, owner, WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON}, webhook)
+}
+
+func TestGiteaClient_ListOrgWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":1,"events":["push"],"active":false,"config":{"url":"https://jfrog.com","content_type":"json"}}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/orgs/jfrog/hooks?limit=50&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListOrgWebhooks(ctx, owner)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push},
+		ContentType: WebhookContentTypeJSON}}, webhooks)
+
+	_, err = createBadGiteaClient(t).ListOrgWebhooks(ctx, owner)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DeleteOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "/api/v1/orgs/jfrog/hooks/1", createGiteaHandler)
+	defer cleanUp()
+
+	err := client.DeleteOrgWebhook(ctx, owner, "1")
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).DeleteOrgWebhook(ctx, owner, "1")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	ref := "39e5418"
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, gitea.Status{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/statuses/%s", repo1, ref), http.StatusCreated, createGiteaHandler)
+	defer cleanUp()
+
+	err := client.SetCommitStatus(ctx, Error, owner, repo1, ref, "Commit status title", "Commit status description",
+		"https://httpbin.org/anything")
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).SetCommitStatus(ctx, Error, owner, repo1, ref, "Commit status title", "Commit status description",
+		"https://httpbin.org/anything")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListCommitStatuses(t *testing.T) {
+	ctx := context.Background()
+	ref := "39e5418"
+	response := []byte(`{"state":"pending","statuses":[
+		{"status":"success","context":"build","description":"Build passed","target_url":"https://ci.example.com/build","created_at":"2023-01-01T10:00:00Z","updated_at":"2023-01-01T10:05:00Z"},
+		{"status":"pending","context":"test","created_at":"2023-01-01T10:00:00Z","updated_at":"2023-01-01T10:00:00Z"}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/commits/%s/status", repo1, ref), createGiteaHandler)
+	defer cleanUp()
+
+	statuses, err := client.ListCommitStatuses(ctx, owner, repo1, ref)
+	require.NoError(t, err)
+	assert.Equal(t, []CommitStatusInfo{
+		{State: Pass, Title: "build", Description: "Build passed", DetailsURL: "https://ci.example.com/build",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 5, 0, 0, time.UTC)},
+		{State: InProgress, Title: "test",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)},
+	}, statuses)
+
+	_, err = createBadGiteaClient(t).ListCommitStatuses(ctx, owner, repo1, ref)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DownloadRepository(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "gitea", "hello-world-main.tar.gz"))
+	assert.NoError(t, err)
+	repositoryResponse, err := os.ReadFile(filepath.Join("testdata", "gitea", "repository_response.json"))
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+		switch r.RequestURI {
+		case fmt.Sprintf("/api/v1/repos/jfrog/%s", repo1):
+			_, err := w.Write(repositoryResponse)
+			assert.NoError(t, err)
+		case fmt.Sprintf("/api/v1/repos/jfrog/%s/archive/%s.tar.gz", repo1, branch1):
+			_, err := w.Write(repoFile)
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "unexpected request URI", r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err = client.DownloadRepository(ctx, owner, repo1, branch1, dir)
+	require.NoError(t, err)
+	fileinfo, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, fileinfo, 2)
+	assert.Equal(t, ".git", fileinfo[0].Name())
+	assert.Equal(t, "README.md", fileinfo[1].Name())
+
+	err = createBadGiteaClient(t).DownloadRepository(ctx, owner, repo1, branch1, dir)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetRepositoryArchive(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, []byte("archive content"),
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/archive/v1.0.0.zip", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	archive, err := client.GetRepositoryArchive(ctx, owner, repo1, "v1.0.0", Zip)
+	require.NoError(t, err)
+	content, err := io.ReadAll(archive)
+	assert.NoError(t, err)
+	assert.NoError(t, archive.Close())
+	assert.Equal(t, "archive content", string(content))
+
+	_, err = createBadGiteaClient(t).GetRepositoryArchive(ctx, owner, repo1, "v1.0.0", Zip)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DownloadFileFromRepo(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, []byte("Hello World!"),
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/raw/hello-world?ref=%s", repo1, branch1), createGiteaHandler)
+	defer cleanUp()
+
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-world")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "Hello World!", string(content))
+
+	_, _, err = createBadGiteaClient(t).DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-world")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DownloadFileFromRepoNotFound(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, []byte(`{"message":"file not found"}`),
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/raw/hello-world?ref=%s", repo1, branch1), http.StatusNotFound, createGiteaHandler)
+	defer cleanUp()
+
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-world")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.Nil(t, content)
+}
+
+func TestGiteaClient_GetFileContent(t *testing.T) {
+	ctx := context.Background()
+	content := "SGVsbG8gV29ybGQh"
+	response := gitea.ContentsResponse{Type: "file", Path: "go.mod", SHA: "sha1", Size: 12, Content: &content}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/contents/go.mod?ref=%s", repo1, branch1), createGiteaHandler)
+	defer cleanUp()
+
+	fileContent, err := client.GetFileContent(ctx, owner, repo1, branch1, "go.mod")
+	assert.NoError(t, err)
+	assert.Equal(t, FileContent{Path: "go.mod", Content: []byte("Hello World!"), Size: 12, Sha: "sha1"}, fileContent)
+
+	_, err = createBadGiteaClient(t).GetFileContent(ctx, owner, repo1, branch1, "go.mod")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListRepositoryTree(t *testing.T) {
+	ctx := context.Background()
+	response := gitea.GitTreeResponse{SHA: "sha1", Entries: []gitea.GitEntry{
+		{Path: "go.mod", Type: "blob", Size: 12},
+		{Path: "src", Type: "tree"},
+		{Path: "src/main.go", Type: "blob", Size: 120},
+	}}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/git/trees/%s?recursive=1", repo1, branch1), createGiteaHandler)
+	defer cleanUp()
+
+	tree, err := client.ListRepositoryTree(ctx, owner, repo1, branch1, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []TreeEntry{
+		{Path: "go.mod", Type: TreeEntryFile, Size: 12},
+		{Path: "src", Type: TreeEntryDirectory},
+		{Path: "src/main.go", Type: TreeEntryFile, Size: 120},
+	}, tree)
+
+	_, err = createBadGiteaClient(t).ListRepositoryTree(ctx, owner, repo1, branch1, true)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", createGiteaHandler)
+	defer cleanUp()
+	_, err := client.SearchCode(ctx, CodeSearchScope{Owner: owner}, "exec.Command")
+	assert.ErrorIs(t, err, errGiteaCodeSearchNotSupported)
+}
+
+func TestGiteaClient_CreateOrUpdateFile(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v1/repos/jfrog/repo-1/contents/go.mod?ref=branch-1":
+			response = []byte(`{"type":"file","path":"go.mod","sha":"sha1"}`)
+		case "GET /api/v1/repos/jfrog/repo-1/contents/new.txt?ref=branch-1":
+			w.WriteHeader(http.StatusNotFound)
+		case "PUT /api/v1/repos/jfrog/repo-1/contents/go.mod":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(b), `"sha":"sha1"`)
+			assert.Contains(t, string(b), `"content":"SGVsbG8gV29ybGQh"`)
+			response = []byte(`{}`)
+		case "POST /api/v1/repos/jfrog/repo-1/contents/new.txt":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(b), `"branch":"branch-1"`)
+			w.WriteHeader(http.StatusCreated)
+			response = []byte(`{}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err := client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "go.mod", []byte("Hello World!"), "Update go.mod")
+	assert.NoError(t, err)
+
+	err = client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "new.txt", []byte("Hello World!"), "Add new.txt")
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).CreateOrUpdateFile(ctx, owner, repo1, branch1, "go.mod", []byte("Hello World!"), "Update go.mod")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", createGiteaHandler)
+	defer cleanUp()
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", []FileChange{{Type: DeleteFile, Path: "go.sum"}})
+	assert.ErrorIs(t, err, errGiteaCommitFilesNotSupported)
+}
+
+func TestGiteaClient_CreatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, gitea.PullRequest{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls", repo1), http.StatusCreated, createGiteaHandler)
+	defer cleanUp()
+
+	err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateDraftPullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.CreatePullRequestOption{Head: branch1, Base: branch2, Title: "WIP: PR title", Body: "PR body"})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.PullRequest{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls", repo1), http.StatusCreated, expectedBody, http.MethodPost,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.CreateDraftPullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+}
+
+func TestGiteaClient_MarkPullRequestReady(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v1/repos/jfrog/repo-1/pulls/1":
+			response = []byte(`{"number":1,"title":"[wip] PR title"}`)
+		case "GET /api/v1/repos/jfrog/repo-1/pulls/2":
+			response = []byte(`{"number":2,"title":"PR title"}`)
+		case "PATCH /api/v1/repos/jfrog/repo-1/pulls/1":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(b), `"title":"PR title"`)
+			w.WriteHeader(http.StatusCreated)
+			response = []byte(`{"number":1,"title":"PR title"}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err := client.MarkPullRequestReady(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+
+	// Not a draft, no update is expected
+	err = client.MarkPullRequestReady(ctx, owner, repo1, 2)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).MarkPullRequestReady(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.MergePullRequestOption{Style: gitea.MergeStyleSquash, Message: "Merge commit message"})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, nil,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls/1/merge", repo1), http.StatusOK, expectedBody, http.MethodPost,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.MergePullRequest(ctx, owner, repo1, 1, SquashMerge, "Merge commit message")
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).MergePullRequest(ctx, owner, repo1, 1, SquashMerge, "Merge commit message")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_EnablePullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.MergePullRequestOption{Style: gitea.MergeStyleRebase, MergeWhenChecksSucceed: true})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, nil,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls/1/merge", repo1), http.StatusOK, expectedBody, http.MethodPost,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, RebaseMerge)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).EnablePullRequestAutoMerge(ctx, owner, repo1, 1, RebaseMerge)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DisablePullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, nil,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls/1/merge", repo1), http.StatusOK, []byte{}, http.MethodDelete,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DisablePullRequestAutoMerge(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+
+	notFoundClient, notFoundCleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, nil,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls/1/merge", repo1), http.StatusNotFound, createGiteaHandler)
+	defer notFoundCleanUp()
+	err = notFoundClient.DisablePullRequestAutoMerge(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_UpdatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	openState := gitea.StateOpen
+	expectedBody, err := json.Marshal(gitea.EditPullRequestOption{Title: "New title", Body: "New body", State: &openState})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.PullRequest{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls/1", repo1), http.StatusCreated, expectedBody, http.MethodPatch,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	open := PullRequestOpen
+	err = client.UpdatePullRequest(ctx, owner, repo1, "New title", "New body", "", 1, &open)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).UpdatePullRequest(ctx, owner, repo1, "New title", "", "", 1, nil)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_SubmitPullRequestReview(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.CreatePullReviewOptions{State: gitea.ReviewStateApproved, Body: "LGTM"})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.PullReview{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls/1/reviews", repo1), http.StatusOK, expectedBody, http.MethodPost,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.SubmitPullRequestReview(ctx, owner, repo1, 1, ReviewApprove, "LGTM")
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).ApprovePullRequest(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_AddPullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, gitea.Comment{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/1/comments", repo1), http.StatusCreated, createGiteaHandler)
+	defer cleanUp()
+
+	err := client.AddPullRequestComment(ctx, owner, repo1, "Comment content", 1)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).AddPullRequestComment(ctx, owner, repo1, "Comment content", 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/1/comments?limit=0&page=1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestComments(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	expectedCreated, err := time.Parse(time.RFC3339, "2023-03-20T09:56:03Z")
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, CommentInfo{
+		ID:      305,
+		Content: "Text of the comment\r\n",
+		Created: expectedCreated,
+	}, result[1])
+
+	_, err = createBadGiteaClient(t).ListPullRequestComments(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.EditIssueCommentOption{Body: "New content"})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.Comment{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/comments/305", repo1), http.StatusOK, expectedBody, http.MethodPatch,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "New content", 1, 305)
+	assert.NoError(t, err)
+
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "", 1, 305)
+	assert.Error(t, err)
+
+	err = createBadGiteaClient(t).UpdatePullRequestComment(ctx, owner, repo1, "New content", 1, 305)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DeletePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, []byte{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/comments/305", repo1), http.StatusNoContent, createGiteaHandler)
+	defer cleanUp()
+
+	err := client.DeletePullRequestComment(ctx, owner, repo1, 1, 305)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).DeletePullRequestComment(ctx, owner, repo1, 1, 305)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CommentReactions(t *testing.T) {
+	ctx := context.Background()
+	reactionsURI := fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/comments/305/reactions", repo1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v1/version":
+			response = []byte(`{"version":"1.18.0"}`)
+		case "POST " + reactionsURI:
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"content":"heart"}`, string(b))
+			w.WriteHeader(http.StatusCreated)
+			response = []byte(`{"content":"heart"}`)
+		case "GET " + reactionsURI:
+			response = []byte(`[{"content":"heart","user":{"login":"frogger"}}]`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err := client.AddCommentReaction(ctx, owner, repo1, 1, 305, ReactionHeart)
+	assert.NoError(t, err)
+
+	reactions, err := client.ListCommentReactions(ctx, owner, repo1, 1, 305)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReactionInfo{{Reaction: ReactionHeart, User: "frogger"}}, reactions)
+
+	_, err = createBadGiteaClient(t).ListCommentReactions(ctx, owner, repo1, 1, 305)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_AddPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.CreatePullReviewOptions{
+		State:    gitea.ReviewStateComment,
+		Comments: []gitea.CreatePullReviewComment{{Path: "main.go", Body: "Use a constant", NewLineNum: 5}},
+	})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.PullReview{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls/1/reviews", repo1), http.StatusOK, expectedBody, http.MethodPost,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	comment := PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go", Line: 5, StartLine: 3}
+	err = client.AddPullRequestReviewComment(ctx, owner, repo1, 1, comment)
+	assert.NoError(t, err)
+
+	err = client.AddPullRequestReviewComment(ctx, owner, repo1, 1, PullRequestReviewComment{Path: "main.go", Line: 5})
+	assert.Error(t, err)
+
+	err = createBadGiteaClient(t).AddPullRequestReviewComment(ctx, owner, repo1, 1, comment)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v1/repos/jfrog/repo-1/pulls/1/reviews?limit=0&page=1":
+			response = []byte(`[{"id":1,"comments_count":0},{"id":2,"comments_count":2}]`)
+		case "GET /api/v1/repos/jfrog/repo-1/pulls/1/reviews/2/comments":
+			response = []byte(`[{"id":10,"body":"Use a constant","path":"main.go","position":5,"created_at":"2023-03-20T09:56:03Z"},` +
+				`{"id":11,"body":"Removed","path":"go.mod","original_position":7,"created_at":"2023-03-20T09:56:03Z"}]`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	result, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	expectedCreated, err := time.Parse(time.RFC3339, "2023-03-20T09:56:03Z")
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewComment{
+		{CommentInfo: CommentInfo{ID: 10, Content: "Use a constant", Created: expectedCreated}, Path: "main.go", Line: 5},
+		{CommentInfo: CommentInfo{ID: 11, Content: "Removed", Created: expectedCreated}, Path: "go.mod", Line: 7},
+	}, result)
+
+	_, err = createBadGiteaClient(t).ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListOpenPullRequests(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls?limit=0&page=1&state=open", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequests(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, PullRequestInfo{
+		ID:     2,
+		Source: BranchInfo{Name: "test1", Repository: repo1},
+		Target: BranchInfo{Name: "master", Repository: repo1},
+	}, result[0])
+
+	_, err = createBadGiteaClient(t).ListOpenPullRequests(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListOpenPullRequestsWithFilter(t *testing.T) {
+	ctx := context.Background()
+	updated := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	older := updated.Add(-time.Hour)
+	head := &gitea.PRBranchInfo{Ref: "feature", Name: "feature"}
+	base := &gitea.PRBranchInfo{Ref: "main", Name: "main"}
+	response := []*gitea.PullRequest{
+		{Index: 1, Head: head, Base: base, Poster: &gitea.User{UserName: "frogger"}, Updated: &updated, Labels: []*gitea.Label{{Name: "bug"}}},
+		{Index: 2, Head: head, Base: base, Poster: &gitea.User{UserName: "frogger"}, Updated: &older, Labels: []*gitea.Label{{Name: "bug"}}},
+		{Index: 3, Head: head, Base: base, Poster: &gitea.User{UserName: "toad"}, Updated: &updated, Labels: []*gitea.Label{{Name: "bug"}}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		"/api/v1/repos/jfrog/repo-1/pulls?limit=10&page=2&state=open", createGiteaHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{
+		Author: "frogger", SourceBranch: "feature", TargetBranch: "main", Label: "bug", UpdatedSince: updated, Page: 2, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(1), result[0].ID)
+
+	_, err = createBadGiteaClient(t).ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{})
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	response := []*gitea.ChangedFile{
+		{Filename: "go.mod", Status: "changed", Additions: 2, Deletions: 1},
+		{Filename: "new.go", PreviousFilename: "old.go", Status: "renamed"},
+		{Filename: "main.go", Status: "added", Additions: 10},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		"/api/v1/repos/jfrog/repo-1/pulls/1/files?limit=50&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "go.mod", Status: FileModified, Additions: 2, Deletions: 1},
+		{Path: "new.go", PreviousPath: "old.go", Status: FileRenamed},
+		{Path: "main.go", Status: FileAdded, Additions: 10},
+	}, files)
+
+	_, err = createBadGiteaClient(t).ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	expectedDiff := "diff --git a/go.mod b/go.mod\n"
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, []byte(expectedDiff),
+		"/api/v1/repos/jfrog/repo-1/pulls/1.diff", createGiteaHandler)
+	defer cleanUp()
+
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDiff, diff)
+
+	_, err = createBadGiteaClient(t).GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListPullRequestCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "commit_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls/1/commits?limit=50&page=1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	commits, err := client.ListPullRequestCommits(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, CommitInfo{
+		Hash:          "ed899a2f4b50b4370feeea94676502b42383c746",
+		AuthorName:    "Example User",
+		CommitterName: "Administrator",
+		Url:           "https://gitea.example.com/jfrog/repo-1/commit/ed899a2f4b50b4370feeea94676502b42383c746",
+		Timestamp:     1679299910,
+		Message:       "Replace sanitize with escape once",
+		ParentHashes:  []string{"6104942438c14ec7bd21c6cd5bd995272b3faff6"},
+	}, commits[0])
+
+	_, err = createBadGiteaClient(t).ListPullRequestCommits(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetPullRequestByID(t *testing.T) {
+	ctx := context.Background()
+	response := &gitea.PullRequest{
+		Index:     1,
+		Title:     "Fix all the bugs",
+		Body:      "Pull request body",
+		State:     gitea.StateOpen,
+		Mergeable: true,
+		Poster:    &gitea.User{UserName: "example"},
+		Labels:    []*gitea.Label{{Name: "bug"}},
+		Head:      &gitea.PRBranchInfo{Ref: "feature", Repository: &gitea.Repository{Name: "fork-repo", Owner: &gitea.User{UserName: "forker"}}},
+		Base:      &gitea.PRBranchInfo{Ref: "master", Repository: &gitea.Repository{Name: repo1, Owner: &gitea.User{UserName: owner}}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/pulls/1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	pullRequest, err := client.GetPullRequestByID(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	mergeable := true
+	assert.Equal(t, PullRequestInfo{
+		ID:        1,
+		Source:    BranchInfo{Name: "feature", Repository: "fork-repo", Owner: "forker"},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		Title:     "Fix all the bugs",
+		Body:      "Pull request body",
+		State:     PullRequestOpen,
+		Author:    "example",
+		Labels:    []string{"bug"},
+		Mergeable: &mergeable,
+	}, pullRequest)
+
+	_, err = createBadGiteaClient(t).GetPullRequestByID(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetPullRequestMergeableState(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.RequestURI {
+		case "/api/v1/version":
+			_, err = w.Write([]byte(`{"version":"1.18.0"}`))
+		case "/api/v1/repos/jfrog/repo-1/pulls/1":
+			_, err = w.Write([]byte(`{"number":1,"state":"open","mergeable":false,"merge_base":"5dcb09b","base":{"ref":"master","sha":"7dcb09b"},"head":{"ref":"feature","sha":"6dcb09b"}}`))
+		case "/api/v1/repos/jfrog/repo-1/branch_protections/master":
+			_, err = w.Write([]byte(`{"enable_status_check":true,"status_check_contexts":["build"],"required_approvals":1}`))
+		case "/api/v1/repos/jfrog/repo-1/commits/6dcb09b/status":
+			_, err = w.Write([]byte(`{"statuses":[{"context":"build","status":"success"}]}`))
+		case "/api/v1/repos/jfrog/repo-1/pulls/1/reviews?limit=0&page=1":
+			_, err = w.Write([]byte(`[{"state":"APPROVED","official":true,"stale":true},{"state":"COMMENT"}]`))
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	state, err := client.GetPullRequestMergeableState(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestMergeableState{HasConflicts: true, BehindTarget: true, MissingApprovals: 1}, state)
+
+	_, err = createBadGiteaClient(t).GetPullRequestMergeableState(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetLatestCommit(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "commit_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/commits?limit=1&page=1&sha=master", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	result, err := client.GetLatestCommit(ctx, owner, repo1, "master")
+	require.NoError(t, err)
+	assert.Equal(t, CommitInfo{
+		Hash:          "ed899a2f4b50b4370feeea94676502b42383c746",
+		AuthorName:    "Example User",
+		CommitterName: "Administrator",
+		Url:           "https://gitea.example.com/jfrog/repo-1/commit/ed899a2f4b50b4370feeea94676502b42383c746",
+		Timestamp:     1679299910,
+		Message:       "Replace sanitize with escape once",
+		ParentHashes:  []string{"6104942438c14ec7bd21c6cd5bd995272b3faff6"},
+	}, result)
+
+	_, err = createBadGiteaClient(t).GetLatestCommit(ctx, owner, repo1, "master")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetLatestCommitNotFound(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"message": "repository does not exist"}`)
+
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/commits?limit=1&page=1&sha=master", repo1), http.StatusNotFound, createGiteaHandler)
+	defer cleanUp()
+
+	result, err := client.GetLatestCommit(ctx, owner, repo1, "master")
+	require.Error(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGiteaClient_GetRepositoryInfo(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "repository_response.json"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v1/version":
+			_, err = w.Write([]byte("{\"version\":\"1.18.0\"}"))
+		case fmt.Sprintf("/api/v1/repos/jfrog/%s", repo1):
+			_, err = w.Write(response)
+		case fmt.Sprintf("/api/v1/repos/jfrog/%s/topics?limit=50&page=1", repo1):
+			_, err = w.Write([]byte(`{"topics":["frogbot","security"]}`))
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	result, err := client.GetRepositoryInfo(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Private,
+			CloneInfo: CloneInfo{
+				HTTP: "https://gitea.example.com/jfrog/repo-1.git",
+				SSH:  "git@gitea.example.com:jfrog/repo-1.git",
+			},
+			DefaultBranch: "main",
+			Topics:        []string{"frogbot", "security"},
+		},
+		result,
+	)
+
+	_, err = createBadGiteaClient(t).GetRepositoryInfo(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetRepositoryLanguages(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, map[string]int64{"Go": 300, "Shell": 100},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/languages", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	languages, err := client.GetRepositoryLanguages(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"Go": 75, "Shell": 25}, languages)
+
+	_, err = createBadGiteaClient(t).GetRepositoryLanguages(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateRepository(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.CreateRepoOption{Name: repo1, Description: "Frogs", Private: true})
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+		switch r.RequestURI {
+		case "/api/v1/version":
+			_, err := w.Write([]byte(`{"version":"1.18.0"}`))
+			assert.NoError(t, err)
+		case "/api/v1/user":
+			_, err := w.Write([]byte(`{"login":"frogger"}`))
+			assert.NoError(t, err)
+		case "/api/v1/org/jfrog/repos":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedBody, body)
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"name":"repo-1"}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Description: "Frogs"})
+	assert.NoError(t, err)
+
+	internal := Internal
+	err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Visibility: &internal})
+	assert.ErrorIs(t, err, errGiteaInternalRepositoriesNotSupported)
+
+	err = createBadGiteaClient(t).CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{})
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_DeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, []byte{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s", repo1), http.StatusNoContent, []byte{}, http.MethodDelete, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).DeleteRepository(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "repository_response.json"))
+	require.NoError(t, err)
+	organization := "frogs"
+	expectedBody, err := json.Marshal(gitea.CreateForkOption{Organization: &organization})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/forks", repo1), http.StatusAccepted, expectedBody, http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	forkInfo, err := client.ForkRepository(ctx, owner, repo1, organization, true)
+	require.NoError(t, err)
+	assert.Equal(t, ForkInfo{
+		Owner:      owner,
+		Repository: repo1,
+		RepositoryInfo: RepositoryInfo{
+			RepositoryVisibility: Private,
+			CloneInfo:            CloneInfo{HTTP: "https://gitea.example.com/jfrog/repo-1.git", SSH: "git@gitea.example.com:jfrog/repo-1.git"},
+			DefaultBranch:        "main",
+		},
+	}, forkInfo)
+
+	_, err = createBadGiteaClient(t).ForkRepository(ctx, owner, repo1, "", false)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListCollaborators(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+		var response string
+		switch r.RequestURI {
+		case "/api/v1/version":
+			response = `{"version":"1.18.0"}`
+		case "/api/v1/repos/jfrog/repo-1/collaborators?limit=50&page=1":
+			response = `[{"login":"frogger"},{"login":"toad"}]`
+		case "/api/v1/repos/jfrog/repo-1/collaborators/frogger/permission":
+			response = `{"permission":"owner"}`
+		case "/api/v1/repos/jfrog/repo-1/collaborators/toad/permission":
+			response = `{"permission":"read"}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	collaborators, err := client.ListCollaborators(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "frogger", Permission: PermissionAdmin}, {Username: "toad", Permission: PermissionRead}}, collaborators)
+
+	_, err = createBadGiteaClient(t).ListCollaborators(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_AddCollaborator(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, []byte{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/collaborators/%s", repo1, username), http.StatusNoContent, []byte(`{"permission":"admin"}`),
+		http.MethodPut, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err := client.AddCollaborator(ctx, owner, repo1, username, PermissionAdmin)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).AddCollaborator(ctx, owner, repo1, username, PermissionAdmin)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_RemoveCollaborator(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, []byte{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/collaborators/%s", repo1, username), http.StatusNoContent, []byte{}, http.MethodDelete,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err := client.RemoveCollaborator(ctx, owner, repo1, username)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).RemoveCollaborator(ctx, owner, repo1, username)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetUserPermission(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, []byte(`{"permission":"write"}`),
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/collaborators/%s/permission", repo1, username), createGiteaHandler)
+	defer cleanUp()
+
+	permission, err := client.GetUserPermission(ctx, owner, repo1, username)
+	require.NoError(t, err)
+	assert.Equal(t, PermissionWrite, permission)
+
+	_, err = createBadGiteaClient(t).GetUserPermission(ctx, owner, repo1, username)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetCommitBySha(t *testing.T) {
+	ctx := context.Background()
+	sha := "ff4a54b88fbd387ac4d9e8cdeb54b049978e450a"
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "commit_single_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/git/commits/%s", repo1, sha), createGiteaHandler)
+	defer cleanUp()
+
+	result, err := client.GetCommitBySha(ctx, owner, repo1, sha)
+	require.NoError(t, err)
+	assert.Equal(t, CommitInfo{
+		Hash:          sha,
+		AuthorName:    "Example User",
+		CommitterName: "Administrator",
+		Url:           "https://gitea.example.com/jfrog/repo-1/commit/ff4a54b88fbd387ac4d9e8cdeb54b049978e450a",
+		Timestamp:     1679151388,
+		Message:       "Initial commit",
+		ParentHashes:  []string{"667fb1d7f3854da3ee036ba3ad711c87c8b37fbd"},
+	}, result)
+
+	_, err = createBadGiteaClient(t).GetCommitBySha(ctx, owner, repo1, sha)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	commitsURI := "/api/v1/repos/jfrog/repo-1/commits?limit=0&page=%d&path=README.md&sha=master"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/v1/version":
+			response = `{"version":"1.18.0"}`
+		case fmt.Sprintf(commitsURI, 1):
+			w.Header().Set("Link", `<http://`+r.Host+fmt.Sprintf(commitsURI, 2)+`>; rel="next"`)
+			response = `[{"sha":"sha1","commit":{"author":{"name":"Frogger","email":"frogger@example.com"},"committer":{"date":"2023-06-01T00:00:00Z"}}},` +
+				`{"sha":"sha2","commit":{"author":{"name":"Frogger"},"committer":{"date":"2023-01-01T00:00:00Z"}}}]`
+		case fmt.Sprintf(commitsURI, 2):
+			response = `[{"sha":"sha3","author":{"login":"frogger"},"commit":{"author":{"name":"Toad"},"committer":{"date":"2023-07-01T00:00:00Z"}},"parents":[{"sha":"sha4"}]}]`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	// The author and date filters are applied by the client
+	client := buildClient(t, vcsutils.Gitea, false, server)
+	options := ListCommitsOptions{Branch: "master", Path: "README.md", Author: "frogger", Since: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)}
+	result, err := client.ListCommits(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "sha1", result[0].Hash)
+	assert.Equal(t, "sha3", result[1].Hash)
+	assert.Equal(t, []string{"sha4"}, result[1].ParentHashes)
+
+	result, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master", Path: "README.md", Page: 1})
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	_, err = createBadGiteaClient(t).ListCommits(ctx, owner, repo1, options)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListContributors(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/v1/version":
+			response = `{"version":"1.18.0"}`
+		case "/api/v1/repos/jfrog/repo-1/commits?limit=0&page=1":
+			response = `[{"sha":"sha3","commit":{"author":{"name":"Toad"}}},{"sha":"sha2","commit":{"author":{"name":"Frogger"}}},` +
+				`{"sha":"sha1","commit":{"author":{"name":"Frogger"}}}]`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	// The contributors are counted from the commits
+	contributors, err := client.ListContributors(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []ContributorInfo{{Name: "Frogger", Commits: 2}, {Name: "Toad", Commits: 1}}, contributors)
+
+	_, err = createBadGiteaClient(t).ListContributors(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetCommit(t *testing.T) {
+	ctx := context.Background()
+	sha := "ff4a54b88fbd387ac4d9e8cdeb54b049978e450a"
+	response := []byte(`{"sha":"` + sha + `","commit":{"message":"Initial commit"},"parents":[{"sha":"sha0"}],` +
+		`"files":[{"filename":"README.md"}],"stats":{"total":3,"additions":2,"deletions":1}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/git/commits/%s", repo1, sha), createGiteaHandler)
+	defer cleanUp()
+
+	result, err := client.GetCommit(ctx, owner, repo1, sha)
+	require.NoError(t, err)
+	assert.Equal(t, sha, result.Hash)
+	assert.Equal(t, "Initial commit", result.Message)
+	assert.Equal(t, []string{"sha0"}, result.ParentHashes)
+	assert.Equal(t, 2, result.Additions)
+	assert.Equal(t, 1, result.Deletions)
+	assert.Equal(t, []PullRequestFile{{Path: "README.md", Status: FileModified}}, result.Files)
+
+	_, err = createBadGiteaClient(t).GetCommit(ctx, owner, repo1, sha)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetCommitVerification(t *testing.T) {
+	ctx := context.Background()
+	sha := "ff4a54b88fbd387ac4d9e8cdeb54b049978e450a"
+	response := []byte(`{"sha":"` + sha + `","commit":{"message":"Initial commit","verification":{"verified":true,"reason":"frogger / 4AEE18F83AFDEB23",` +
+		`"signature":"-----BEGIN PGP SIGNATURE-----\n\niQEz\n-----END PGP SIGNATURE-----\n"}}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/git/commits/%s", repo1, sha), createGiteaHandler)
+	defer cleanUp()
+
+	verification, err := client.GetCommitVerification(ctx, owner, repo1, sha)
+	require.NoError(t, err)
+	assert.Equal(t, CommitVerificationInfo{Signed: true, Verified: true, SignatureType: SignatureGPG, Reason: "frogger / 4AEE18F83AFDEB23"}, verification)
+
+	_, err = createBadGiteaClient(t).GetCommitVerification(ctx, owner, repo1, sha)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/v1/repos/jfrog/repo-1/compare/main...feature":
+			response = `{"total_commits":1,"commits":[{"sha":"sha1","html_url":"https://gitea.example.com/jfrog/repo-1/commit/sha1",` +
+				`"commit":{"message":"Add feature","author":{"name":"Example User"},"committer":{"name":"Administrator","date":"2023-03-18T14:56:28Z"}},` +
+				`"parents":[{"sha":"sha0"}]}]}`
+		case "/api/v1/repos/jfrog/repo-1/compare/feature...main":
+			response = `{"total_commits":2,"commits":[{"sha":"sha2"},{"sha":"sha3"}]}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	result, err := buildClient(t, vcsutils.Gitea, false, server).CompareCommits(ctx, owner, repo1, "main", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, CommitsComparison{
+		AheadBy:  1,
+		BehindBy: 2,
+		Commits: []CommitInfo{{
+			Hash:          "sha1",
+			AuthorName:    "Example User",
+			CommitterName: "Administrator",
+			Url:           "https://gitea.example.com/jfrog/repo-1/commit/sha1",
+			Timestamp:     1679151388,
+			Message:       "Add feature",
+			ParentHashes:  []string{"sha0"},
+		}},
+	}, result)
+
+	_, err = createBadGiteaClient(t).CompareCommits(ctx, owner, repo1, "main", "feature")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetCommitsBetween(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/repos/jfrog/repo-1/compare/sha0...sha2", r.RequestURI)
+		_, err := w.Write([]byte(`{"total_commits":2,"commits":[{"sha":"sha2","commit":{"message":"Second","author":{"name":"Example User"}}},{"sha":"sha1"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	commits, err := buildClient(t, vcsutils.Gitea, false, server).GetCommitsBetween(ctx, owner, repo1, "sha0", "sha2")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "sha2", commits[0].Hash)
+	assert.Equal(t, "Second", commits[0].Message)
+	assert.Equal(t, "Example User", commits[0].AuthorName)
+	assert.Equal(t, "sha1", commits[1].Hash)
+
+	_, err = createBadGiteaClient(t).GetCommitsBetween(ctx, owner, repo1, "sha0", "sha2")
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_getGiteaRepositoryVisibility(t *testing.T) {
+	assert.Equal(t, Public, getGiteaRepositoryVisibility(&gitea.Repository{Private: false}))
+	assert.Equal(t, Private, getGiteaRepositoryVisibility(&gitea.Repository{Private: true}))
+}
+
+func TestGiteaClient_getGiteaCommitState(t *testing.T) {
+	assert.Equal(t, gitea.StatusSuccess, getGiteaCommitState(Pass))
+	assert.Equal(t, gitea.StatusFailure, getGiteaCommitState(Fail))
+	assert.Equal(t, gitea.StatusError, getGiteaCommitState(Error))
+	assert.Equal(t, gitea.StatusPending, getGiteaCommitState(InProgress))
+	assert.Equal(t, gitea.StatusState(""), getGiteaCommitState(5))
+}
+
+func TestGiteaClient_CreateLabel(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.CreateLabelOption{Name: labelName, Color: "#001122", Description: "label-description"})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.Label{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/labels", repo1), http.StatusCreated, expectedBody, http.MethodPost,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.CreateLabel(ctx, owner, repo1, LabelInfo{
+		Name:        labelName,
+		Description: "label-description",
+		Color:       "001122",
+	})
+	assert.NoError(t, err)
+}
+
+func TestGiteaClient_GetLabel(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false,
+		[]gitea.Label{{Name: labelName, Description: "label-description", Color: "001122"}},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/labels?limit=100&page=1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	labelInfo, err := client.GetLabel(ctx, owner, repo1, labelName)
+	assert.NoError(t, err)
+	assert.Equal(t, &LabelInfo{Name: labelName, Description: "label-description", Color: "001122"}, labelInfo)
+
+	labelInfo, err = client.GetLabel(ctx, owner, repo1, "not-existed")
+	assert.NoError(t, err)
+	assert.Nil(t, labelInfo)
+
+	_, err = createBadGiteaClient(t).GetLabel(ctx, owner, repo1, labelName)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListPullRequestLabels(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, []gitea.Label{{Name: labelName}},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/1/labels?limit=0&page=0", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	labels, err := client.ListPullRequestLabels(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{labelName}, labels)
+
+	_, err = createBadGiteaClient(t).ListPullRequestLabels(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_UnlabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	labelID := rand.Int63()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/1/labels?limit=0&page=0", repo1), r.RequestURI)
+			response, err := json.Marshal([]gitea.Label{{ID: labelID, Name: labelName}})
+			assert.NoError(t, err)
+			_, err = w.Write(response)
+			assert.NoError(t, err)
+		case http.MethodDelete:
+			assert.Equal(t, fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/1/labels/%d", repo1, labelID), r.RequestURI)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err := client.UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListLabels(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false,
+		[]gitea.Label{{Name: labelName, Description: "label-description", Color: "#001122"}},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/labels?limit=100&page=1", repo1), createGiteaHandler)
+	defer cleanUp()
+
+	labels, err := client.ListLabels(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []LabelInfo{{Name: labelName, Description: "label-description", Color: "001122"}}, labels)
+
+	_, err = createBadGiteaClient(t).ListLabels(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_UpdateLabel(t *testing.T) {
+	ctx := context.Background()
+	labelID := rand.Int63()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodGet:
+			if r.RequestURI == "/api/v1/version" {
+				_, err := w.Write([]byte(`{"version":"1.18.0"}`))
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, fmt.Sprintf("/api/v1/repos/jfrog/%s/labels?limit=100&page=1", repo1), r.RequestURI)
+			response, err := json.Marshal([]gitea.Label{{ID: labelID, Name: labelName}})
+			assert.NoError(t, err)
+			_, err = w.Write(response)
+			assert.NoError(t, err)
+		case http.MethodPatch:
+			assert.Equal(t, fmt.Sprintf("/api/v1/repos/jfrog/%s/labels/%d", repo1, labelID), r.RequestURI)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"name":"new-name","color":"#ff0000","description":null}`, string(body))
+			_, err = w.Write([]byte("{}"))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request method "+r.Method)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err := client.UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: "new-name", Color: "ff0000"})
+	assert.NoError(t, err)
+
+	err = client.UpdateLabel(ctx, owner, repo1, "not-existed", LabelInfo{Name: "new-name"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGiteaClient_DeleteLabel(t *testing.T) {
+	ctx := context.Background()
+	labelID := rand.Int63()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodGet:
+			if r.RequestURI == "/api/v1/version" {
+				_, err := w.Write([]byte(`{"version":"1.18.0"}`))
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, fmt.Sprintf("/api/v1/repos/jfrog/%s/labels?limit=100&page=1", repo1), r.RequestURI)
+			response, err := json.Marshal([]gitea.Label{{ID: labelID, Name: labelName}})
+			assert.NoError(t, err)
+			_, err = w.Write(response)
+			assert.NoError(t, err)
+		case http.MethodDelete:
+			assert.Equal(t, fmt.Sprintf("/api/v1/repos/jfrog/%s/labels/%d", repo1, labelID), r.RequestURI)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "Unexpected request method "+r.Method)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	err := client.DeleteLabel(ctx, owner, repo1, labelName)
+	assert.NoError(t, err)
+
+	err = client.DeleteLabel(ctx, owner, repo1, "not-existed")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = createBadGiteaClient(t).DeleteLabel(ctx, owner, repo1, labelName)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreateIssue(t *testing.T) {
+	ctx := context.Background()
+	labelID := rand.Int63()
+	expectedBody, err := json.Marshal(gitea.CreateIssueOption{Title: "Vulnerable dependency", Body: "Upgrade lodash", Labels: []int64{labelID}})
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+		var response interface{}
+		switch r.RequestURI {
+		case "/api/v1/version":
+			response = map[string]string{"version": "1.18.0"}
+		case fmt.Sprintf("/api/v1/repos/jfrog/%s/labels?limit=100&page=1", repo1):
+			response = []gitea.Label{{ID: labelID, Name: "security"}}
+		case fmt.Sprintf("/api/v1/repos/jfrog/%s/issues", repo1):
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedBody, body)
+			w.WriteHeader(http.StatusCreated)
+			response = gitea.Issue{
+				Index:   3,
+				Title:   "Vulnerable dependency",
+				Body:    "Upgrade lodash",
+				State:   gitea.StateOpen,
+				Poster:  &gitea.User{UserName: username},
+				Labels:  []*gitea.Label{{ID: labelID, Name: "security"}},
+				HTMLURL: "https://gitea.com/jfrog/repo-1/issues/3",
+			}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		bytes, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(bytes)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.Gitea, false, server)
+
+	issue, err := client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "Upgrade lodash", []string{"security"})
+	assert.NoError(t, err)
+	assert.Equal(t, IssueInfo{
+		ID:     3,
+		Title:  "Vulnerable dependency",
+		Body:   "Upgrade lodash",
+		State:  IssueOpen,
+		Author: username,
+		Labels: []string{"security"},
+		URL:    "https://gitea.com/jfrog/repo-1/issues/3",
+	}, issue)
+
+	_, err = client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "", []string{"not-existed"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGiteaClient_AddIssueComment(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.CreateIssueCommentOption{Body: "Fixed in 1.2.3"})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.Comment{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/3/comments", repo1), http.StatusCreated, expectedBody, http.MethodPost,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.AddIssueComment(ctx, owner, repo1, "Fixed in 1.2.3", 3)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).AddIssueComment(ctx, owner, repo1, "Fixed in 1.2.3", 3)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListIssues(t *testing.T) {
+	ctx := context.Background()
+	response := []gitea.Issue{{Index: 1, Title: "Bug", State: gitea.StateClosed, Poster: &gitea.User{UserName: username}}}
+	closed := IssueClosed
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response,
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/issues?created_by=%s&labels=bug&limit=0&page=1&state=closed&type=issues", repo1, username),
+		createGiteaHandler)
+	defer cleanUp()
+
+	issues, err := client.ListIssues(ctx, owner, repo1, IssueFilter{State: &closed, Author: username, Label: "bug"})
+	assert.NoError(t, err)
+	assert.Equal(t, []IssueInfo{{ID: 1, Title: "Bug", State: IssueClosed, Author: username, Labels: []string{}}}, issues)
+
+	_, err = createBadGiteaClient(t).ListIssues(ctx, owner, repo1, IssueFilter{})
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_UpdateIssueState(t *testing.T) {
+	ctx := context.Background()
+	closed := gitea.StateClosed
+	expectedBody, err := json.Marshal(gitea.EditIssueOption{State: &closed})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.Issue{},
+		fmt.Sprintf("/api/v1/repos/jfrog/%s/issues/3", repo1), http.StatusCreated, expectedBody, http.MethodPatch,
+		createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.UpdateIssueState(ctx, owner, repo1, 3, IssueClosed)
+	assert.NoError(t, err)
+
+	err = createBadGiteaClient(t).UpdateIssueState(ctx, owner, repo1, 3, IssueClosed)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_UploadCodeScanning(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, "", "unsupportedTest", createGiteaHandler)
+	defer cleanUp()
+
+	_, err := client.UploadCodeScanning(ctx, owner, repo1, "", "1")
+	assert.ErrorIs(t, err, errGiteaCodeScanningNotSupported)
+}
+
+func TestGiteaClient_GetRepositoryEnvironmentInfo(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, "", "unsupportedTest", createGiteaHandler)
+	defer cleanUp()
+
+	_, err := client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
+	assert.ErrorIs(t, err, errGiteaGetRepoEnvironmentInfoNotSupported)
+}
+
+func TestGiteaClient_Deployments(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, "", "unsupportedTest", createGiteaHandler)
+	defer cleanUp()
+
+	_, err := client.ListEnvironments(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errGiteaDeploymentsNotSupported)
+	_, err = client.CreateDeployment(ctx, owner, repo1, DeploymentInfo{Environment: envName, Ref: branch1})
+	assert.ErrorIs(t, err, errGiteaDeploymentsNotSupported)
+	err = client.SetDeploymentStatus(ctx, owner, repo1, 1, DeploymentStatusInfo{State: Pass})
+	assert.ErrorIs(t, err, errGiteaDeploymentsNotSupported)
+}
+
+func TestGiteaClient_TagProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, "", "unsupportedTest", createGiteaHandler)
+	defer cleanUp()
+
+	_, err := client.GetTagProtection(ctx, owner, repo1, "v*")
+	assert.ErrorIs(t, err, errGiteaTagProtectionNotSupported)
+	err = client.SetTagProtection(ctx, owner, repo1, "v*", TagProtection{})
+	assert.ErrorIs(t, err, errGiteaTagProtectionNotSupported)
+}
+
+func TestGiteaClient_ListSecurityAlerts(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, "", "unsupportedTest", createGiteaHandler)
+	defer cleanUp()
+
+	_, err := client.ListSecurityAlerts(context.Background(), owner, repo1)
+	assert.ErrorIs(t, err, errGiteaSecurityAlertsNotSupported)
+}
+
+func TestGiteaClient_CodeInsights(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, "", "unsupportedTest", createGiteaHandler)
+	defer cleanUp()
+
+	err := client.CreateCodeInsightsReport(context.Background(), owner, repo1, "abc123", CodeInsightsReport{ID: "report"})
+	assert.ErrorIs(t, err, errGiteaCodeInsightsNotSupported)
+	err = client.AddCodeInsightsAnnotations(context.Background(), owner, repo1, "abc123", "report", nil)
+	assert.ErrorIs(t, err, errGiteaCodeInsightsNotSupported)
+}
+
+func TestGiteaClient_PullRequestThreads(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, "", "unsupportedTest", createGiteaHandler)
+	defer cleanUp()
+
+	_, err := client.CreatePullRequestThread(context.Background(), owner, repo1, 1, PullRequestReviewComment{CommentInfo: CommentInfo{Content: "comment"}})
+	assert.ErrorIs(t, err, errGiteaPullRequestThreadsNotSupported)
+}
+
+func createBadGiteaClient(t *testing.T) VcsClient {
+	client, err := NewClientBuilder(vcsutils.Gitea).ApiEndpoint("https://bad^endpoint").Build()
+	require.NoError(t, err)
+	return client
+}
+
+func createGiteaHandler(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, expectedURI, r.RequestURI)
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+		w.WriteHeader(expectedStatusCode)
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
+func createGiteaWithBodyHandler(t *testing.T, expectedURI string, response []byte, expectedRequestBody []byte,
+	expectedStatusCode int, expectedHttpMethod string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, expectedHttpMethod, r.Method)
+		assert.Equal(t, expectedURI, r.RequestURI)
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, expectedRequestBody, b)
+
+		w.WriteHeader(expectedStatusCode)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
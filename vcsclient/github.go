@@ -2,13 +2,18 @@ package vcsclient
 
 import (
 	"context"
+	stdbase64 "encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v45/github"
 	"github.com/grokify/mogo/encoding/base64"
@@ -17,15 +22,34 @@ import (
 	"golang.org/x/oauth2"
 )
 
+var errGitHubTagProtectionUsersNotSupported = errors.New("only the users with the maintain or admin role may create protected tags on GitHub, and allowing other users is not supported")
+var errGitHubCodeInsightsNotSupported = errors.New("Code Insights reports are not supported on GitHub, whose check runs report the results of tools")
+var errGitHubPullRequestThreadPathRequired = errors.New("review threads are anchored to the diff on GitHub, so the path of the commented file is required")
+
+// GitHub accepts up to 50 annotations of a check run in a request
+const gitHubMaxAnnotationsPerRequest = 50
+
+// The mode of the files that CommitFiles creates, which are regular files that aren't executable
+const gitHubRegularFileMode = "100644"
+
 // GitHubClient API version 3
 type GitHubClient struct {
-	vcsInfo VcsInfo
-	logger  Log
+	vcsInfo     VcsInfo
+	logger      Log
+	tokenSource oauth2.TokenSource
 }
 
 // NewGitHubClient create a new GitHubClient
 func NewGitHubClient(vcsInfo VcsInfo, logger Log) (*GitHubClient, error) {
-	return &GitHubClient{vcsInfo: vcsInfo, logger: logger}, nil
+	client := &GitHubClient{vcsInfo: vcsInfo, logger: logger, tokenSource: getStaticTokenSource(vcsInfo)}
+	if vcsInfo.GitHubApp != nil {
+		appTokenSource, err := newGitHubAppTokenSource(vcsInfo, logger)
+		if err != nil {
+			return nil, err
+		}
+		client.tokenSource = appTokenSource
+	}
+	return client, nil
 }
 
 // TestConnection on GitHub
@@ -38,12 +62,23 @@ func (client *GitHubClient) TestConnection(ctx context.Context) error {
 	return err
 }
 
-func (client *GitHubClient) buildGithubClient(ctx context.Context) (*github.Client, error) {
-	httpClient := &http.Client{}
-	if client.vcsInfo.Token != "" {
-		httpClient = oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: client.vcsInfo.Token}))
+// GetRateLimitInfo on GitHub
+func (client *GitHubClient) GetRateLimitInfo(ctx context.Context) (RateLimitInfo, error) {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return RateLimitInfo{}, err
+	}
+	rateLimits, _, err := ghClient.RateLimits(ctx)
+	if err != nil {
+		return RateLimitInfo{}, err
 	}
-	ghClient := github.NewClient(httpClient)
+	coreRate := rateLimits.GetCore()
+	return RateLimitInfo{Limit: coreRate.Limit, Remaining: coreRate.Remaining, Reset: coreRate.Reset.Time}, nil
+}
+
+// buildGithubClient builds a client whose installation access tokens, when authenticating as a GitHub App, are created within the context
+func (client *GitHubClient) buildGithubClient(ctx context.Context) (*github.Client, error) {
+	ghClient := github.NewClient(newTokenSourceHTTPClient(client.vcsInfo, client.logger, withTokenContext(ctx, client.tokenSource)))
 	if client.vcsInfo.APIEndpoint != "" {
 		baseURL, err := url.Parse(strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/") + "/")
 		if err != nil {
@@ -83,6 +118,65 @@ func (client *GitHubClient) AddSshKeyToRepository(ctx context.Context, owner, re
 	return err
 }
 
+// ListDeployKeys on GitHub
+func (client *GitHubClient) ListDeployKeys(ctx context.Context, owner, repository string) ([]DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []DeployKeyInfo
+	for nextPage := 1; nextPage > 0; {
+		keys, response, err := ghClient.Repositories.ListKeys(ctx, owner, repository, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			results = append(results, mapGitHubKeyToDeployKeyInfo(key))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// AddDeployKey on GitHub
+func (client *GitHubClient) AddDeployKey(ctx context.Context, owner, repository, title, key string, readOnly bool) (DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title, "key": key})
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	deployKey, _, err := ghClient.Repositories.CreateKey(ctx, owner, repository, &github.Key{Title: &title, Key: &key, ReadOnly: &readOnly})
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	return mapGitHubKeyToDeployKeyInfo(deployKey), nil
+}
+
+// DeleteDeployKey on GitHub
+func (client *GitHubClient) DeleteDeployKey(ctx context.Context, owner, repository string, keyID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = ghClient.Repositories.DeleteKey(ctx, owner, repository, int64(keyID))
+	return err
+}
+
+func mapGitHubKeyToDeployKeyInfo(key *github.Key) DeployKeyInfo {
+	return DeployKeyInfo{ID: int(key.GetID()), Title: key.GetTitle(), Key: key.GetKey(), ReadOnly: key.GetReadOnly()}
+}
+
 // ListRepositories on GitHub
 func (client *GitHubClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
 	ghClient, err := client.buildGithubClient(ctx)
@@ -106,162 +200,361 @@ func (client *GitHubClient) ListRepositories(ctx context.Context) (map[string][]
 	return results, nil
 }
 
-// ListBranches on GitHub
-func (client *GitHubClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
-	ghClient, err := client.buildGithubClient(ctx)
+// ListRepositoriesWithOptions on GitHub. Only the public repositories of users other than the authenticated user are returned.
+// GitHub doesn't filter the repositories by visibility, archived state or language, so these filters are applied to each fetched page.
+func (client *GitHubClient) ListRepositoriesWithOptions(ctx context.Context, owner string, options ListRepositoriesOptions) ([]RepositoryDetails, error) {
+	return listPageOrAll(ctx, client.listRepositoriesPager(owner, options), options.Page)
+}
+
+func (client *GitHubClient) listRepositoriesPager(owner string, options ListRepositoriesOptions) *Pager[RepositoryDetails] {
+	listOptions := github.ListOptions{Page: firstPage(options.Page), PerPage: options.PerPage}
+	// The repositories are sorted by their update time, so the pages after a repository updated before UpdatedSince aren't fetched
+	sort, direction := "", ""
+	if !options.UpdatedSince.IsZero() {
+		sort, direction = "updated", "desc"
+	}
+	var listRepositories gitHubListRepositoriesFunc
+	return newPager(func(ctx context.Context) ([]RepositoryDetails, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner})
+		if err != nil {
+			return nil, false, err
+		}
+		ghClient, err := client.buildGithubClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		if listRepositories == nil {
+			if listRepositories, err = getGitHubListRepositoriesFunc(ctx, ghClient, owner, sort, direction); err != nil {
+				return nil, false, err
+			}
+		}
+		client.logger.Debug("fetching repositories page", listOptions.Page, "of", owner)
+		repos, response, err := listRepositories(ctx, listOptions)
+		if err != nil {
+			return nil, false, err
+		}
+		var results []RepositoryDetails
+		for _, repo := range repos {
+			details := mapGitHubRepositoryToRepositoryDetails(repo)
+			if options.matches(details) {
+				results = append(results, details)
+			}
+		}
+		listOptions.Page = response.NextPage
+		if sort != "" && len(repos) > 0 && repos[len(repos)-1].GetUpdatedAt().Before(options.UpdatedSince) {
+			return results, false, nil
+		}
+		return results, listOptions.Page > 0, nil
+	})
+}
+
+type gitHubListRepositoriesFunc func(ctx context.Context, listOptions github.ListOptions) ([]*github.Repository, *github.Response, error)
+
+// getGitHubListRepositoriesFunc returns the function that lists the repositories of the owner by its type.
+// GitHub lists the private repositories of a user only to the user itself.
+func getGitHubListRepositoriesFunc(ctx context.Context, ghClient *github.Client, owner, sort, direction string) (gitHubListRepositoriesFunc, error) {
+	ownerInfo, _, err := ghClient.Users.Get(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
-	branches, _, err := ghClient.Repositories.ListBranches(ctx, owner, repository, nil)
+	if ownerInfo.GetType() == "Organization" {
+		return func(ctx context.Context, listOptions github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			return ghClient.Repositories.ListByOrg(ctx, owner, &github.RepositoryListByOrgOptions{Sort: sort, Direction: direction, ListOptions: listOptions})
+		}, nil
+	}
+	authenticatedUser, _, err := ghClient.Users.Get(ctx, "")
 	if err != nil {
-		return []string{}, err
+		return nil, err
 	}
+	user, listType, affiliation := owner, "owner", ""
+	if strings.EqualFold(authenticatedUser.GetLogin(), owner) {
+		user, listType, affiliation = "", "", "owner"
+	}
+	return func(ctx context.Context, listOptions github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return ghClient.Repositories.List(ctx, user, &github.RepositoryListOptions{
+			Type:        listType,
+			Affiliation: affiliation,
+			Sort:        sort,
+			Direction:   direction,
+			ListOptions: listOptions,
+		})
+	}, nil
+}
+
+// ListBranches on GitHub
+func (client *GitHubClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	// GitHub returns up to 30 branches by default, so all the pages are fetched
+	return client.ListBranchesPager(owner, repository, 100).All(ctx)
+}
+
+// ListBranchesPager on GitHub
+func (client *GitHubClient) ListBranchesPager(owner, repository string, perPage int) *Pager[string] {
+	options := &github.BranchListOptions{ListOptions: github.ListOptions{Page: 1, PerPage: perPage}}
+	return newPager(func(ctx context.Context) ([]string, bool, error) {
+		ghClient, err := client.buildGithubClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		branches, response, err := ghClient.Repositories.ListBranches(ctx, owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]string, 0, len(branches))
+		for _, branch := range branches {
+			results = append(results, branch.GetName())
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
 
-	results := make([]string, 0, len(branches))
-	for _, repo := range branches {
-		results = append(results, *repo.Name)
+// ListAllBranches on GitHub
+func (client *GitHubClient) ListAllBranches(ctx context.Context, owner, repository, prefix string) ([]BranchDetails, error) {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []BranchDetails
+	for nextPage := 1; nextPage > 0; {
+		options := &github.BranchListOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}}
+		branches, response, err := ghClient.Repositories.ListBranches(ctx, owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			if strings.HasPrefix(branch.GetName(), prefix) {
+				results = append(results, BranchDetails{Name: branch.GetName(), CommitHash: branch.GetCommit().GetSHA()})
+			}
+		}
+		nextPage = response.NextPage
 	}
 	return results, nil
 }
 
-// CreateWebhook on GitHub
-func (client *GitHubClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
-	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+// CreateBranch on GitHub
+func (client *GitHubClient) CreateBranch(ctx context.Context, owner, repository, branchName, fromRef string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":       owner,
+		"repository":  repository,
+		"branch name": branchName,
+		"from ref":    fromRef,
+	})
+	if err != nil {
+		return err
+	}
 	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
-		return "", "", err
+		return err
 	}
-	token := vcsutils.CreateToken()
-	hook := createGitHubHook(token, payloadURL, webhookEvents...)
-	responseHook, _, err := ghClient.Repositories.CreateHook(ctx, owner, repository, hook)
+	sha, _, err := ghClient.Repositories.GetCommitSHA1(ctx, owner, repository, fromRef, "")
 	if err != nil {
-		return "", "", err
+		return err
 	}
-	return strconv.FormatInt(*responseHook.ID, 10), token, err
+	client.logger.Debug("creating branch", branchName, "from", fromRef)
+	_, _, err = ghClient.Git.CreateRef(ctx, owner, repository, &github.Reference{
+		Ref:    github.String(vcsutils.AddBranchPrefix(branchName)),
+		Object: &github.GitObject{SHA: &sha},
+	})
+	return err
 }
 
-// UpdateWebhook on GitHub
-func (client *GitHubClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
-	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
-	ghClient, err := client.buildGithubClient(ctx)
+// DeleteBranch on GitHub
+func (client *GitHubClient) DeleteBranch(ctx context.Context, owner, repository, branchName string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch name": branchName})
 	if err != nil {
 		return err
 	}
-	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
 		return err
 	}
-	hook := createGitHubHook(token, payloadURL, webhookEvents...)
-	_, _, err = ghClient.Repositories.EditHook(ctx, owner, repository, webhookIDInt64, hook)
+	client.logger.Debug("deleting branch", branchName)
+	_, err = ghClient.Git.DeleteRef(ctx, owner, repository, "heads/"+branchName)
 	return err
 }
 
-// DeleteWebhook on GitHub
-func (client *GitHubClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+// ListTags on GitHub
+func (client *GitHubClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
 	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []TagInfo
+	for nextPage := 1; nextPage > 0; {
+		tags, response, err := ghClient.Repositories.ListTags(ctx, owner, repository, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			results = append(results, TagInfo{Name: tag.GetName(), CommitHash: tag.GetCommit().GetSHA()})
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// CreateTag on GitHub
+func (client *GitHubClient) CreateTag(ctx context.Context, owner, repository, tagName, targetSha, message string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"tag name":   tagName,
+		"target sha": targetSha,
+	})
 	if err != nil {
 		return err
 	}
-	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
 		return err
 	}
-	_, err = ghClient.Repositories.DeleteHook(ctx, owner, repository, webhookIDInt64)
+	client.logger.Debug("creating tag", tagName, "on", targetSha)
+	refSha := targetSha
+	if message != "" {
+		// An annotated tag is a tag object, which the tag reference points to
+		tag, _, err := ghClient.Git.CreateTag(ctx, owner, repository, &github.Tag{
+			Tag:     &tagName,
+			Message: &message,
+			Object:  &github.GitObject{Type: github.String("commit"), SHA: &targetSha},
+		})
+		if err != nil {
+			return err
+		}
+		refSha = tag.GetSHA()
+	}
+	_, _, err = ghClient.Git.CreateRef(ctx, owner, repository, &github.Reference{
+		Ref:    github.String("refs/tags/" + tagName),
+		Object: &github.GitObject{SHA: &refSha},
+	})
 	return err
 }
 
-// SetCommitStatus on GitHub
-func (client *GitHubClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
-	title, description, detailsURL string) error {
+// CreateRelease on GitHub
+func (client *GitHubClient) CreateRelease(ctx context.Context, owner, repository string, release ReleaseInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tag name": release.TagName})
+	if err != nil {
+		return err
+	}
 	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
 		return err
 	}
-	state := getGitHubCommitState(commitStatus)
-	status := &github.RepoStatus{
-		Context:     &title,
-		TargetURL:   &detailsURL,
-		State:       &state,
-		Description: &description,
+	client.logger.Debug("creating release", release.TagName)
+	repositoryRelease := &github.RepositoryRelease{TagName: &release.TagName, Name: &release.Name, Body: &release.Description}
+	if release.Target != "" {
+		repositoryRelease.TargetCommitish = &release.Target
 	}
-	_, _, err = ghClient.Repositories.CreateStatus(ctx, owner, repository, ref, status)
+	_, _, err = ghClient.Repositories.CreateRelease(ctx, owner, repository, repositoryRelease)
 	return err
 }
 
-// DownloadRepository on GitHub
-func (client *GitHubClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+// GetLatestRelease on GitHub
+func (client *GitHubClient) GetLatestRelease(ctx context.Context, owner, repository string) (*ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
 	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	client.logger.Debug("getting GitHub archive link to download")
-	baseURL, _, err := ghClient.Repositories.GetArchiveLink(ctx, owner, repository, github.Tarball,
-		&github.RepositoryContentGetOptions{Ref: branch}, true)
+	release, response, err := ghClient.Repositories.GetLatestRelease(ctx, owner, repository)
 	if err != nil {
-		return err
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
 	}
+	return &ReleaseInfo{
+		TagName:     release.GetTagName(),
+		Target:      release.GetTargetCommitish(),
+		Name:        release.GetName(),
+		Description: release.GetBody(),
+		Url:         release.GetHTMLURL(),
+	}, nil
+}
 
-	client.logger.Debug("received archive url:", baseURL.String())
-	httpClient := &http.Client{}
-	req, err := http.NewRequest("GET", baseURL.String(), nil)
+// UploadReleaseAsset on GitHub
+func (client *GitHubClient) UploadReleaseAsset(ctx context.Context, owner, repository, tagName, assetPath string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"tag name":   tagName,
+		"asset path": assetPath,
+	})
 	if err != nil {
 		return err
 	}
-	resp, err := httpClient.Do(req)
+	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
-	if err = vcsutils.CheckResponseStatusWithBody(resp, http.StatusOK); err != nil {
+	release, _, err := ghClient.Repositories.GetReleaseByTag(ctx, owner, repository, tagName)
+	if err != nil {
 		return err
 	}
-	client.logger.Info(repository, "downloaded successfully, starting with repository extraction")
-	err = vcsutils.Untar(localPath, resp.Body, true)
+	file, err := os.Open(assetPath)
 	if err != nil {
 		return err
 	}
-	client.logger.Info("extracted repository successfully")
-	return vcsutils.CreateDotGitFolderWithRemote(localPath, "origin",
-		fmt.Sprintf("https://github.com/%s/%s.git", owner, repository))
-}
-
-// CreatePullRequest on GitHub
-func (client *GitHubClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
-	title, description string) error {
-	ghClient, err := client.buildGithubClient(ctx)
+	defer func() {
+		_ = file.Close()
+	}()
+	stat, err := file.Stat()
 	if err != nil {
 		return err
 	}
-	head := owner + ":" + sourceBranch
-	client.logger.Debug("creating new pull request:", title)
-	_, _, err = ghClient.PullRequests.Create(ctx, owner, repository, &github.NewPullRequest{
-		Title: &title,
-		Body:  &description,
-		Head:  &head,
-		Base:  &targetBranch,
-	})
+	// The upload URL of the release is a URI template, which depends on the GitHub server
+	uploadURL := strings.Split(release.GetUploadURL(), "{")[0] + "?name=" + url.QueryEscape(filepath.Base(assetPath))
+	client.logger.Debug("uploading release asset", assetPath, "to", tagName)
+	request, err := ghClient.NewUploadRequest(uploadURL, file, stat.Size(), "application/octet-stream")
+	if err != nil {
+		return err
+	}
+	_, err = ghClient.Do(ctx, request, nil)
 	return err
 }
 
-// ListOpenPullRequests on GitHub
-func (client *GitHubClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+// GetBranchProtection on GitHub
+func (client *GitHubClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return nil, err
+	}
 	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	client.logger.Debug("fetching open pull requests in", repository)
-	pullRequests, _, err := ghClient.PullRequests.List(ctx, owner, repository, &github.PullRequestListOptions{
-		State: "open",
-	})
+	protection, _, err := ghClient.Repositories.GetBranchProtection(ctx, owner, repository, branch)
 	if err != nil {
-		return []PullRequestInfo{}, err
+		if errors.Is(err, github.ErrBranchNotProtected) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	return mapGitHubPullRequestToPullRequestInfoList(pullRequests)
+	branchProtection := &BranchProtection{}
+	if protection.RequiredPullRequestReviews != nil {
+		branchProtection.RequiredApprovingReviewCount = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+	}
+	if protection.RequiredStatusChecks != nil {
+		branchProtection.RequiredStatusChecks = protection.RequiredStatusChecks.Contexts
+	}
+	if protection.Restrictions != nil {
+		branchProtection.RestrictPushes = true
+		for _, user := range protection.Restrictions.Users {
+			branchProtection.AllowedPushUsers = append(branchProtection.AllowedPushUsers, user.GetLogin())
+		}
+	}
+	return branchProtection, nil
 }
 
-// AddPullRequestComment on GitHub
-func (client *GitHubClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+// SetBranchProtection on GitHub
+func (client *GitHubClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtection) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
 	if err != nil {
 		return err
 	}
@@ -269,29 +562,1288 @@ func (client *GitHubClient) AddPullRequestComment(ctx context.Context, owner, re
 	if err != nil {
 		return err
 	}
-	// We use the Issues API to add a regular comment. The PullRequests API adds a code review comment.
-	_, _, err = ghClient.Issues.CreateComment(ctx, owner, repository, pullRequestID, &github.IssueComment{
-		Body: &content,
-	})
+	protectionRequest := &github.ProtectionRequest{}
+	if protection.RequiredApprovingReviewCount > 0 {
+		protectionRequest.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: protection.RequiredApprovingReviewCount,
+		}
+	}
+	if len(protection.RequiredStatusChecks) > 0 {
+		protectionRequest.RequiredStatusChecks = &github.RequiredStatusChecks{Contexts: protection.RequiredStatusChecks}
+	}
+	if protection.RestrictPushes {
+		protectionRequest.Restrictions = &github.BranchRestrictionsRequest{
+			Users: append([]string{}, protection.AllowedPushUsers...),
+			Teams: []string{},
+		}
+	}
+	_, _, err = ghClient.Repositories.UpdateBranchProtection(ctx, owner, repository, branch, protectionRequest)
 	return err
 }
 
-// ListPullRequestComments on GitHub
-func (client *GitHubClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// GetRequiredChecks on GitHub
+func (client *GitHubClient) GetRequiredChecks(ctx context.Context, owner, repository, branch string) (RequiredChecksInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
 	if err != nil {
-		return []CommentInfo{}, err
+		return RequiredChecksInfo{}, err
 	}
 	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
-		return []CommentInfo{}, err
+		return RequiredChecksInfo{}, err
 	}
-	commentsList, _, err := ghClient.Issues.ListComments(ctx, owner, repository, pullRequestID, &github.IssueListCommentsOptions{})
+	checks, response, err := ghClient.Repositories.GetRequiredStatusChecks(ctx, owner, repository, branch)
 	if err != nil {
-		return []CommentInfo{}, err
+		// The branch isn't protected, or doesn't require status checks
+		if errors.Is(err, github.ErrBranchNotProtected) || (response != nil && response.StatusCode == http.StatusNotFound) {
+			return RequiredChecksInfo{}, nil
+		}
+		return RequiredChecksInfo{}, err
 	}
-	return mapGitHubCommentToCommentInfoList(commentsList)
-}
+	requiredChecks := RequiredChecksInfo{Titles: checks.Contexts, RequireUpToDate: checks.Strict}
+	if len(requiredChecks.Titles) == 0 {
+		for _, check := range checks.Checks {
+			requiredChecks.Titles = append(requiredChecks.Titles, check.Context)
+		}
+	}
+	return requiredChecks, nil
+}
+
+// GetRequiredApprovals on GitHub
+func (client *GitHubClient) GetRequiredApprovals(ctx context.Context, owner, repository, branch string) (RequiredApprovalsInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return RequiredApprovalsInfo{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return RequiredApprovalsInfo{}, err
+	}
+	reviews, response, err := ghClient.Repositories.GetPullRequestReviewEnforcement(ctx, owner, repository, branch)
+	if err != nil {
+		// The branch isn't protected, or doesn't require reviews
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return RequiredApprovalsInfo{}, nil
+		}
+		return RequiredApprovalsInfo{}, err
+	}
+	return RequiredApprovalsInfo{
+		Count:                 reviews.RequiredApprovingReviewCount,
+		RequireCodeOwners:     reviews.RequireCodeOwnerReviews,
+		DismissStaleApprovals: reviews.DismissStaleReviews,
+	}, nil
+}
+
+// GetTagProtection on GitHub
+func (client *GitHubClient) GetTagProtection(ctx context.Context, owner, repository, pattern string) (*TagProtection, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pattern": pattern})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := getGitHubTagProtectionRule(ctx, ghClient, owner, repository, pattern)
+	if err != nil || rule == nil {
+		return nil, err
+	}
+	return &TagProtection{}, nil
+}
+
+// SetTagProtection on GitHub. A tag protection rule of the pattern is created, unless it exists.
+func (client *GitHubClient) SetTagProtection(ctx context.Context, owner, repository, pattern string, protection TagProtection) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pattern": pattern})
+	if err != nil {
+		return err
+	}
+	if len(protection.AllowedUsers) > 0 {
+		return errGitHubTagProtectionUsersNotSupported
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	rule, err := getGitHubTagProtectionRule(ctx, ghClient, owner, repository, pattern)
+	if err != nil || rule != nil {
+		return err
+	}
+	client.logger.Debug("protecting tags", pattern)
+	// The tag protection API isn't supported by the GitHub client library
+	request, err := ghClient.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/tags/protection", owner, repository), gitHubTagProtectionRule{Pattern: pattern})
+	if err != nil {
+		return err
+	}
+	_, err = ghClient.Do(ctx, request, nil)
+	return err
+}
+
+// getGitHubTagProtectionRule returns nil if the pattern has no tag protection rule
+func getGitHubTagProtectionRule(ctx context.Context, ghClient *github.Client, owner, repository, pattern string) (*gitHubTagProtectionRule, error) {
+	request, err := ghClient.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/tags/protection", owner, repository), nil)
+	if err != nil {
+		return nil, err
+	}
+	var rules []gitHubTagProtectionRule
+	if _, err = ghClient.Do(ctx, request, &rules); err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if rules[i].Pattern == pattern {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+type gitHubTagProtectionRule struct {
+	ID      int64  `json:"id,omitempty"`
+	Pattern string `json:"pattern"`
+}
+
+// CreateWebhook on GitHub
+func (client *GitHubClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
+	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	token := vcsutils.CreateToken()
+	hook := createGitHubHook(token, payloadURL, webhookEvents...)
+	responseHook, _, err := ghClient.Repositories.CreateHook(ctx, owner, repository, hook)
+	if err != nil {
+		return "", "", err
+	}
+	return strconv.FormatInt(*responseHook.ID, 10), token, err
+}
+
+// CreateWebhookWithOptions on GitHub
+func (client *GitHubClient) CreateWebhookWithOptions(ctx context.Context, owner, repository string,
+	options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	token := vcsutils.CreateToken()
+	responseHook, _, err := ghClient.Repositories.CreateHook(ctx, owner, repository, createGitHubHookWithOptions(token, options))
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	return mapGitHubHookToWebhookInfo(responseHook), token, nil
+}
+
+// UpdateWebhook on GitHub
+func (client *GitHubClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
+	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	hook := createGitHubHook(token, payloadURL, webhookEvents...)
+	_, _, err = ghClient.Repositories.EditHook(ctx, owner, repository, webhookIDInt64, hook)
+	return err
+}
+
+// DeleteWebhook on GitHub
+func (client *GitHubClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = ghClient.Repositories.DeleteHook(ctx, owner, repository, webhookIDInt64)
+	return err
+}
+
+// ListWebhooks on GitHub
+func (client *GitHubClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []WebhookInfo
+	for nextPage := 1; nextPage > 0; {
+		hooks, response, err := ghClient.Repositories.ListHooks(ctx, owner, repository,
+			&github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			webhooks = append(webhooks, mapGitHubHookToWebhookInfo(hook))
+		}
+		nextPage = response.NextPage
+	}
+	return webhooks, nil
+}
+
+// SetWebhookActive on GitHub
+func (client *GitHubClient) SetWebhookActive(ctx context.Context, owner, repository, webhookID string, active bool) error {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, _, err = ghClient.Repositories.EditHook(ctx, owner, repository, webhookIDInt64, &github.Hook{Active: &active})
+	return err
+}
+
+// CreateOrgWebhook on GitHub
+func (client *GitHubClient) CreateOrgWebhook(ctx context.Context, owner string, options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	token := vcsutils.CreateToken()
+	responseHook, _, err := ghClient.Organizations.CreateHook(ctx, owner, createGitHubHookWithOptions(token, options))
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	return mapGitHubHookToWebhookInfo(responseHook), token, nil
+}
+
+// ListOrgWebhooks on GitHub
+func (client *GitHubClient) ListOrgWebhooks(ctx context.Context, owner string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []WebhookInfo
+	for nextPage := 1; nextPage > 0; {
+		hooks, response, err := ghClient.Organizations.ListHooks(ctx, owner, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			webhooks = append(webhooks, mapGitHubHookToWebhookInfo(hook))
+		}
+		nextPage = response.NextPage
+	}
+	return webhooks, nil
+}
+
+// DeleteOrgWebhook on GitHub
+func (client *GitHubClient) DeleteOrgWebhook(ctx context.Context, owner, webhookID string) error {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = ghClient.Organizations.DeleteHook(ctx, owner, webhookIDInt64)
+	return err
+}
+
+// SetCommitStatus on GitHub
+func (client *GitHubClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
+	title, description, detailsURL string) error {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	state := getGitHubCommitState(commitStatus)
+	status := &github.RepoStatus{
+		Context:     &title,
+		TargetURL:   &detailsURL,
+		State:       &state,
+		Description: &description,
+	}
+	_, _, err = ghClient.Repositories.CreateStatus(ctx, owner, repository, ref, status)
+	return err
+}
+
+// SetCommitStatuses on GitHub
+func (client *GitHubClient) SetCommitStatuses(ctx context.Context, owner, repository, ref string, statuses []CommitStatusInfo) error {
+	return setCommitStatusesOneByOne(ctx, client, owner, repository, ref, statuses)
+}
+
+// ListCommitStatuses on GitHub
+func (client *GitHubClient) ListCommitStatuses(ctx context.Context, owner, repository, ref string) ([]CommitStatusInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitStatusInfo
+	for nextPage := 1; nextPage > 0; {
+		combinedStatus, response, err := ghClient.Repositories.GetCombinedStatus(ctx, owner, repository, ref, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range combinedStatus.Statuses {
+			results = append(results, mapGitHubRepoStatusToCommitStatusInfo(status))
+		}
+		nextPage = response.NextPage
+	}
+	for nextPage := 1; nextPage > 0; {
+		checkRuns, response, err := ghClient.Checks.ListCheckRunsForRef(ctx, owner, repository, ref,
+			&github.ListCheckRunsOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+		if err != nil {
+			return nil, err
+		}
+		for _, checkRun := range checkRuns.CheckRuns {
+			results = append(results, mapGitHubCheckRunToCommitStatusInfo(checkRun))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// CreateCheckRun on GitHub. The annotations that exceed the limit of a request are added by updating the check run.
+func (client *GitHubClient) CreateCheckRun(ctx context.Context, owner, repository, ref string, checkRun CheckRunInfo) (int64, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref, "name": checkRun.Name})
+	if err != nil {
+		return 0, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return 0, err
+	}
+	annotations, remainingAnnotations := splitGitHubAnnotations(mapCheckRunAnnotationsToGitHub(checkRun.Annotations))
+	status, conclusion, completedAt := getGitHubCheckRunState(checkRun.State)
+	created, _, err := ghClient.Checks.CreateCheckRun(ctx, owner, repository, github.CreateCheckRunOptions{
+		Name:        checkRun.Name,
+		HeadSHA:     ref,
+		DetailsURL:  getOptionalGitHubString(checkRun.DetailsURL),
+		Status:      &status,
+		Conclusion:  conclusion,
+		CompletedAt: completedAt,
+		Output:      getGitHubCheckRunOutput(checkRun, annotations),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created.GetID(), client.addCheckRunAnnotations(ctx, ghClient, owner, repository, created.GetID(), checkRun, remainingAnnotations)
+}
+
+// UpdateCheckRun on GitHub
+func (client *GitHubClient) UpdateCheckRun(ctx context.Context, owner, repository, _ string, checkRunID int64, checkRun CheckRunInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": checkRun.Name})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	annotations, remainingAnnotations := splitGitHubAnnotations(mapCheckRunAnnotationsToGitHub(checkRun.Annotations))
+	status, conclusion, completedAt := getGitHubCheckRunState(checkRun.State)
+	_, _, err = ghClient.Checks.UpdateCheckRun(ctx, owner, repository, checkRunID, github.UpdateCheckRunOptions{
+		Name:        checkRun.Name,
+		DetailsURL:  getOptionalGitHubString(checkRun.DetailsURL),
+		Status:      &status,
+		Conclusion:  conclusion,
+		CompletedAt: completedAt,
+		Output:      getGitHubCheckRunOutput(checkRun, annotations),
+	})
+	if err != nil {
+		return err
+	}
+	return client.addCheckRunAnnotations(ctx, ghClient, owner, repository, checkRunID, checkRun, remainingAnnotations)
+}
+
+// CreateCodeInsightsReport on GitHub
+func (client *GitHubClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, sha string, report CodeInsightsReport) error {
+	return errGitHubCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on GitHub
+func (client *GitHubClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, sha, reportID string, annotations []CodeInsightsAnnotation) error {
+	return errGitHubCodeInsightsNotSupported
+}
+
+// TriggerPipeline on GitHub. The workflow is dispatched, and its run is polled for, since GitHub doesn't return it.
+func (client *GitHubClient) TriggerPipeline(ctx context.Context, owner, repository, pipeline, ref string, inputs map[string]string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pipeline": pipeline, "ref": ref})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	runsOptions := &github.ListWorkflowRunsOptions{Branch: ref, Event: "workflow_dispatch", ListOptions: github.ListOptions{PerPage: 1}}
+	// The run is identified as the first dispatched run that is newer than the latest run before the dispatch
+	previousRun, err := getLatestGitHubWorkflowRun(ctx, ghClient, owner, repository, pipeline, runsOptions)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	event := github.CreateWorkflowDispatchEventRequest{Ref: ref, Inputs: map[string]interface{}{}}
+	for name, value := range inputs {
+		event.Inputs[name] = value
+	}
+	if _, err = ghClient.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repository, pipeline, event); err != nil {
+		return PipelineInfo{}, err
+	}
+	var run *github.WorkflowRun
+	err = pollUntilReady(ctx, func() (bool, error) {
+		run, err = getLatestGitHubWorkflowRun(ctx, ghClient, owner, repository, pipeline, runsOptions)
+		return run != nil && run.GetID() > previousRun.GetID(), err
+	})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return mapGitHubWorkflowRunToPipelineInfo(run), nil
+}
+
+// GetPipelineStatus on GitHub
+func (client *GitHubClient) GetPipelineStatus(ctx context.Context, owner, repository, runID string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "run ID": runID})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	id, err := strconv.ParseInt(runID, 10, 64)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	run, _, err := ghClient.Actions.GetWorkflowRunByID(ctx, owner, repository, id)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return mapGitHubWorkflowRunToPipelineInfo(run), nil
+}
+
+// getLatestGitHubWorkflowRun returns nil if the workflow has no runs
+func getLatestGitHubWorkflowRun(ctx context.Context, ghClient *github.Client, owner, repository, workflow string, options *github.ListWorkflowRunsOptions) (*github.WorkflowRun, error) {
+	runs, _, err := ghClient.Actions.ListWorkflowRunsByFileName(ctx, owner, repository, workflow, options)
+	if err != nil || len(runs.WorkflowRuns) == 0 {
+		return nil, err
+	}
+	return runs.WorkflowRuns[0], nil
+}
+
+func mapGitHubWorkflowRunToPipelineInfo(run *github.WorkflowRun) PipelineInfo {
+	return PipelineInfo{
+		ID:     strconv.FormatInt(run.GetID(), 10),
+		Ref:    run.GetHeadBranch(),
+		State:  getGitHubRunStatus(run.GetStatus(), run.GetConclusion()),
+		WebURL: run.GetHTMLURL(),
+	}
+}
+
+// addCheckRunAnnotations adds the annotations to the check run, in batches of the maximum size of a request
+func (client *GitHubClient) addCheckRunAnnotations(ctx context.Context, ghClient *github.Client, owner, repository string, checkRunID int64,
+	checkRun CheckRunInfo, annotations []*github.CheckRunAnnotation) error {
+	for len(annotations) > 0 {
+		var batch []*github.CheckRunAnnotation
+		batch, annotations = splitGitHubAnnotations(annotations)
+		_, _, err := ghClient.Checks.UpdateCheckRun(ctx, owner, repository, checkRunID, github.UpdateCheckRunOptions{
+			Name:   checkRun.Name,
+			Output: getGitHubCheckRunOutput(checkRun, batch),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DownloadRepository on GitHub
+func (client *GitHubClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	return client.DownloadRepositoryAtRef(ctx, owner, repository, branch, localPath, TarGz)
+}
+
+// DownloadRepositoryAtRef on GitHub
+func (client *GitHubClient) DownloadRepositoryAtRef(ctx context.Context, owner, repository, ref, localPath string, format ArchiveFormat) error {
+	archive, err := client.GetRepositoryArchive(ctx, owner, repository, ref, format)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = archive.Close() }()
+	client.logger.Info(repository, "downloaded successfully, starting with repository extraction")
+	err = extractRepositoryArchive(archive, format, localPath, true, "")
+	if err != nil {
+		return err
+	}
+	client.logger.Info("extracted repository successfully")
+	return vcsutils.CreateDotGitFolderWithRemote(localPath, "origin",
+		fmt.Sprintf("https://github.com/%s/%s.git", owner, repository))
+}
+
+// GetRepositoryArchive on GitHub
+func (client *GitHubClient) GetRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat) (io.ReadCloser, error) {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archiveFormat := github.Tarball
+	if format == Zip {
+		archiveFormat = github.Zipball
+	}
+	client.logger.Debug("getting GitHub archive link to download")
+	baseURL, _, err := ghClient.Repositories.GetArchiveLink(ctx, owner, repository, archiveFormat,
+		&github.RepositoryContentGetOptions{Ref: ref}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	client.logger.Debug("received archive url:", baseURL.String())
+	httpClient := newHTTPClient(client.vcsInfo, client.logger)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = vcsutils.CheckResponseStatusWithBody(resp, http.StatusOK); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// DownloadRepositoryPath on GitHub
+func (client *GitHubClient) DownloadRepositoryPath(ctx context.Context, owner, repository, ref, path, localPath string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "path": path})
+	if err != nil {
+		return err
+	}
+	// GitHub has no archive of a single directory, so the files of the directory are extracted from the repository tarball
+	archive, err := client.GetRepositoryArchive(ctx, owner, repository, ref, TarGz)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = archive.Close() }()
+	client.logger.Info("extracting", path, "from", repository)
+	return extractRepositoryArchive(archive, TarGz, localPath, true, path)
+}
+
+// CreatePullRequest on GitHub
+func (client *GitHubClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) error {
+	return client.createPullRequest(ctx, owner, repository, sourceBranch, targetBranch, title, description, false)
+}
+
+// CreateDraftPullRequest on GitHub
+func (client *GitHubClient) CreateDraftPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) error {
+	return client.createPullRequest(ctx, owner, repository, sourceBranch, targetBranch, title, description, true)
+}
+
+func (client *GitHubClient) createPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string, draft bool) error {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	head := owner + ":" + sourceBranch
+	client.logger.Debug("creating new pull request:", title)
+	_, _, err = ghClient.PullRequests.Create(ctx, owner, repository, &github.NewPullRequest{
+		Title: &title,
+		Body:  &description,
+		Head:  &head,
+		Base:  &targetBranch,
+		Draft: &draft,
+	})
+	return err
+}
+
+// MarkPullRequestReady on GitHub
+func (client *GitHubClient) MarkPullRequestReady(ctx context.Context, owner, repository string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest, _, err := ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+	if err != nil || !pullRequest.GetDraft() {
+		return err
+	}
+	// The REST API can't mark a pull request as ready, so the GraphQL API is used
+	client.logger.Debug("marking pull request as ready for review:", pullRequestID)
+	return sendGitHubGraphQLRequest(ctx, ghClient, gitHubMarkReadyForReviewMutation, map[string]interface{}{"id": pullRequest.GetNodeID()}, nil)
+}
+
+// EnablePullRequestAutoMerge on GitHub. Auto-merge must be allowed in the repository settings.
+func (client *GitHubClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest, _, err := ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	// Auto-merge is available through the GraphQL API only
+	client.logger.Debug("enabling auto-merge of pull request:", pullRequestID)
+	return sendGitHubGraphQLRequest(ctx, ghClient, gitHubEnableAutoMergeMutation, map[string]interface{}{
+		"id":          pullRequest.GetNodeID(),
+		"mergeMethod": strings.ToUpper(getGitHubMergeMethod(mergeStrategy)),
+	}, nil)
+}
+
+// DisablePullRequestAutoMerge on GitHub
+func (client *GitHubClient) DisablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest, _, err := ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+	if err != nil || pullRequest.AutoMerge == nil {
+		return err
+	}
+	client.logger.Debug("disabling auto-merge of pull request:", pullRequestID)
+	return sendGitHubGraphQLRequest(ctx, ghClient, gitHubDisableAutoMergeMutation, map[string]interface{}{"id": pullRequest.GetNodeID()}, nil)
+}
+
+// sendGitHubGraphQLRequest sends a GraphQL query or mutation, for the features that the REST API doesn't support,
+// and decodes the data of the response into result, unless it's nil.
+// The GraphQL endpoint is resolved relative to the REST API URL, which is /api/v3/ on GitHub Enterprise.
+func sendGitHubGraphQLRequest(ctx context.Context, ghClient *github.Client, query string, variables map[string]interface{}, result interface{}) error {
+	request, err := ghClient.NewRequest(http.MethodPost, "../graphql", gitHubGraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+	var response gitHubGraphQLResponse
+	if _, err = ghClient.Do(ctx, request, &response); err != nil {
+		return err
+	}
+	// GraphQL errors are returned with a successful status code
+	if len(response.Errors) > 0 {
+		return errors.New(response.Errors[0].Message)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(response.Data, result)
+}
+
+// MergePullRequest on GitHub
+func (client *GitHubClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int,
+	mergeStrategy MergeStrategy, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("merging pull request:", pullRequestID)
+	mergeResult, _, err := ghClient.PullRequests.Merge(ctx, owner, repository, pullRequestID, commitMessage,
+		&github.PullRequestOptions{MergeMethod: getGitHubMergeMethod(mergeStrategy)})
+	if err != nil {
+		return err
+	}
+	if !mergeResult.GetMerged() {
+		return fmt.Errorf("pull request %d was not merged: %s", pullRequestID, mergeResult.GetMessage())
+	}
+	return nil
+}
+
+// UpdatePullRequest on GitHub
+func (client *GitHubClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranch string,
+	pullRequestID int, state *PullRequestState) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validatePullRequestState(state); err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest := &github.PullRequest{}
+	if title != "" {
+		pullRequest.Title = &title
+	}
+	if body != "" {
+		pullRequest.Body = &body
+	}
+	if targetBranch != "" {
+		pullRequest.Base = &github.PullRequestBranch{Ref: &targetBranch}
+	}
+	if state != nil {
+		pullRequest.State = github.String(getGitHubPullRequestState(*state))
+	}
+	client.logger.Debug("updating pull request:", pullRequestID)
+	_, _, err = ghClient.PullRequests.Edit(ctx, owner, repository, pullRequestID, pullRequest)
+	return err
+}
+
+// ApprovePullRequest on GitHub
+func (client *GitHubClient) ApprovePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	return client.SubmitPullRequestReview(ctx, owner, repository, pullRequestID, ReviewApprove, "")
+}
+
+// SubmitPullRequestReview on GitHub
+func (client *GitHubClient) SubmitPullRequestReview(ctx context.Context, owner, repository string, pullRequestID int,
+	reviewEvent ReviewEvent, body string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewBody(reviewEvent, body); err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	review := &github.PullRequestReviewRequest{Event: github.String(getGitHubReviewEvent(reviewEvent))}
+	if body != "" {
+		review.Body = &body
+	}
+	client.logger.Debug("submitting review on pull request:", pullRequestID)
+	_, _, err = ghClient.PullRequests.CreateReview(ctx, owner, repository, pullRequestID, review)
+	return err
+}
+
+// ListOpenPullRequests on GitHub
+func (client *GitHubClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Debug("fetching open pull requests in", repository)
+	pullRequests, _, err := ghClient.PullRequests.List(ctx, owner, repository, &github.PullRequestListOptions{
+		State: "open",
+	})
+	if err != nil {
+		return []PullRequestInfo{}, err
+	}
+	return mapGitHubPullRequestToPullRequestInfoList(pullRequests)
+}
+
+// ListOpenPullRequestsWithFilter on GitHub
+func (client *GitHubClient) ListOpenPullRequestsWithFilter(ctx context.Context, owner, repository string, filter PullRequestFilter) ([]PullRequestInfo, error) {
+	return listPageOrAll(ctx, client.ListOpenPullRequestsPager(owner, repository, filter), filter.Page)
+}
+
+// ListOpenPullRequestsPager on GitHub
+func (client *GitHubClient) ListOpenPullRequestsPager(owner, repository string, filter PullRequestFilter) *Pager[PullRequestInfo] {
+	options := &github.PullRequestListOptions{
+		State:       "open",
+		Base:        filter.TargetBranch,
+		ListOptions: github.ListOptions{Page: firstPage(filter.Page), PerPage: filter.PerPage},
+	}
+	if filter.SourceBranch != "" {
+		options.Head = owner + ":" + filter.SourceBranch
+	}
+	return newPager(func(ctx context.Context) ([]PullRequestInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{
+			"owner":      owner,
+			"repository": repository,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		ghClient, err := client.buildGithubClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching open pull requests page", options.Page, "in", repository)
+		pullRequests, response, err := ghClient.PullRequests.List(ctx, owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		var results []PullRequestInfo
+		for _, pullRequest := range pullRequests {
+			pullRequestInfo := mapGitHubPullRequestToPullRequestInfo(pullRequest)
+			if filter.matches(pullRequestInfo, pullRequest.GetUpdatedAt()) {
+				results = append(results, pullRequestInfo)
+			}
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// GetPullRequestByID on GitHub
+func (client *GitHubClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	client.logger.Debug("fetching pull request", pullRequestID, "in", repository)
+	pullRequest, _, err := ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapGitHubPullRequestToPullRequestInfo(pullRequest), nil
+}
+
+// GetPullRequestDetails on GitHub. If the client is built with UseGraphQL, the details are read using a single GraphQL query.
+// The query reads up to 100 labels, files, reviews and commit statuses. Those with more than 100 are listed using the REST API.
+func (client *GitHubClient) GetPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestDetails, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestDetails{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return PullRequestDetails{}, err
+	}
+	if !client.vcsInfo.UseGraphQL {
+		details, err := getPullRequestDetails(ctx, client, owner, repository, pullRequestID, true)
+		if err != nil {
+			return PullRequestDetails{}, err
+		}
+		details.Reviews, err = listGitHubPullRequestReviews(ctx, ghClient, owner, repository, pullRequestID)
+		return details, err
+	}
+	var result gitHubPullRequestDetailsResponse
+	err = sendGitHubGraphQLRequest(ctx, ghClient, gitHubPullRequestDetailsQuery, map[string]interface{}{
+		"owner": owner, "name": repository, "number": pullRequestID,
+	}, &result)
+	if err != nil {
+		return PullRequestDetails{}, err
+	}
+	if result.Repository == nil || result.Repository.PullRequest == nil {
+		return PullRequestDetails{}, fmt.Errorf("pull request %d wasn't found in %s/%s", pullRequestID, owner, repository)
+	}
+	pullRequest := result.Repository.PullRequest
+	details := mapGitHubGraphQLPullRequestToPullRequestDetails(pullRequest)
+	if pullRequest.Labels.PageInfo.HasNextPage {
+		if details.Labels, err = client.ListPullRequestLabels(ctx, owner, repository, pullRequestID); err != nil {
+			return PullRequestDetails{}, err
+		}
+	}
+	if pullRequest.Files.PageInfo.HasNextPage {
+		if details.Files, err = client.ListPullRequestFiles(ctx, owner, repository, pullRequestID); err != nil {
+			return PullRequestDetails{}, err
+		}
+	}
+	if pullRequest.Reviews.PageInfo.HasNextPage {
+		if details.Reviews, err = listGitHubPullRequestReviews(ctx, ghClient, owner, repository, pullRequestID); err != nil {
+			return PullRequestDetails{}, err
+		}
+	}
+	if pullRequest.hasMoreStatusContexts() {
+		if details.Statuses, err = client.ListCommitStatuses(ctx, owner, repository, details.HeadCommit.Hash); err != nil {
+			return PullRequestDetails{}, err
+		}
+	}
+	return details, nil
+}
+
+// listGitHubPullRequestReviews lists the submitted reviews of a pull request, skipping the pending and dismissed ones
+func listGitHubPullRequestReviews(ctx context.Context, ghClient *github.Client, owner, repository string, pullRequestID int) ([]PullRequestReviewInfo, error) {
+	var results []PullRequestReviewInfo
+	for nextPage := 1; nextPage > 0; {
+		reviews, response, err := ghClient.PullRequests.ListReviews(ctx, owner, repository, pullRequestID, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, review := range reviews {
+			if state, submitted := getGitHubReviewState(review.GetState()); submitted {
+				results = append(results, PullRequestReviewInfo{Reviewer: review.GetUser().GetLogin(), State: state})
+			}
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetPullRequestMergeableState on GitHub. Reading the required status checks and approvals requires admin access to the repository.
+func (client *GitHubClient) GetPullRequestMergeableState(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestMergeableState, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	pullRequest, _, err := ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	state := PullRequestMergeableState{HasConflicts: pullRequest.GetMergeableState() == "dirty"}
+	comparison, _, err := ghClient.Repositories.CompareCommits(ctx, owner, repository, pullRequest.GetBase().GetRef(), pullRequest.GetHead().GetSHA(), &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	state.BehindTarget = comparison.GetBehindBy() > 0
+	protection, err := client.GetBranchProtection(ctx, owner, repository, pullRequest.GetBase().GetRef())
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	if protection != nil {
+		state.MissingStatusChecks, err = getMissingStatusChecks(ctx, client, owner, repository, pullRequest.GetHead().GetSHA(), protection.RequiredStatusChecks)
+		if err != nil {
+			return PullRequestMergeableState{}, err
+		}
+		if protection.RequiredApprovingReviewCount > 0 {
+			approvals, err := countGitHubApprovals(ctx, ghClient, owner, repository, pullRequestID)
+			if err != nil {
+				return PullRequestMergeableState{}, err
+			}
+			state.MissingApprovals = getMissingApprovals(protection.RequiredApprovingReviewCount, approvals)
+		}
+	}
+	state.Mergeable = pullRequest.GetMergeable() && !pullRequest.GetDraft() && state.isMergeable()
+	return state, nil
+}
+
+// countGitHubApprovals counts the reviewers whose latest review approves the pull request
+func countGitHubApprovals(ctx context.Context, ghClient *github.Client, owner, repository string, pullRequestID int) (int, error) {
+	latestReviewStates := map[string]string{}
+	for nextPage := 1; nextPage > 0; {
+		reviews, response, err := ghClient.PullRequests.ListReviews(ctx, owner, repository, pullRequestID, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return 0, err
+		}
+		// Comments don't change the approval state of the reviewer
+		for _, review := range reviews {
+			if review.GetState() != "COMMENTED" {
+				latestReviewStates[review.GetUser().GetLogin()] = review.GetState()
+			}
+		}
+		nextPage = response.NextPage
+	}
+	approvals := 0
+	for _, reviewState := range latestReviewStates {
+		if reviewState == "APPROVED" {
+			approvals++
+		}
+	}
+	return approvals, nil
+}
+
+// ListPullRequestFiles on GitHub
+func (client *GitHubClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestFile
+	for nextPage := 1; nextPage > 0; {
+		files, response, err := ghClient.PullRequests.ListFiles(ctx, owner, repository, pullRequestID, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			results = append(results, mapGitHubCommitFileToPullRequestFile(file))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetPullRequestDiff on GitHub
+func (client *GitHubClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return "", err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	diff, _, err := ghClient.PullRequests.GetRaw(ctx, owner, repository, pullRequestID, github.RawOptions{Type: github.Diff})
+	return diff, err
+}
+
+// ListPullRequestCommits on GitHub
+func (client *GitHubClient) ListPullRequestCommits(ctx context.Context, owner, repository string, pullRequestID int) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitInfo
+	for nextPage := 1; nextPage > 0; {
+		commits, response, err := ghClient.PullRequests.ListCommits(ctx, owner, repository, pullRequestID, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			results = append(results, mapGitHubCommitToCommitInfo(commit))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetDefaultReviewers on GitHub. GitHub has no default reviewers, so the code owners of the files changed by the source branch are returned.
+func (client *GitHubClient) GetDefaultReviewers(ctx context.Context, owner, repository, sourceBranch, targetBranch string) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "source branch": sourceBranch, "target branch": targetBranch})
+	if err != nil {
+		return nil, err
+	}
+	return getCodeOwnersReviewers(ctx, client, owner, repository, sourceBranch, targetBranch)
+}
+
+// AddPullRequestComment on GitHub
+func (client *GitHubClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	// We use the Issues API to add a regular comment. The PullRequests API adds a code review comment.
+	_, _, err = ghClient.Issues.CreateComment(ctx, owner, repository, pullRequestID, &github.IssueComment{
+		Body: &content,
+	})
+	return err
+}
+
+// ListPullRequestComments on GitHub
+func (client *GitHubClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return []CommentInfo{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return []CommentInfo{}, err
+	}
+	commentsList, _, err := ghClient.Issues.ListComments(ctx, owner, repository, pullRequestID, &github.IssueListCommentsOptions{})
+	if err != nil {
+		return []CommentInfo{}, err
+	}
+	return mapGitHubCommentToCommentInfoList(commentsList)
+}
+
+// UpdatePullRequestComment on GitHub
+func (client *GitHubClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, _ int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Pull request comments are issue comments, which are identified by the repository only
+	_, _, err = ghClient.Issues.EditComment(ctx, owner, repository, commentID, &github.IssueComment{Body: &content})
+	return err
+}
+
+// DeletePullRequestComment on GitHub
+func (client *GitHubClient) DeletePullRequestComment(ctx context.Context, owner, repository string, _ int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = ghClient.Issues.DeleteComment(ctx, owner, repository, commentID)
+	return err
+}
+
+// AddCommentReaction on GitHub
+func (client *GitHubClient) AddCommentReaction(ctx context.Context, owner, repository string, _ int, commentID int64, reaction Reaction) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "reaction": string(reaction)})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = ghClient.Reactions.CreateIssueCommentReaction(ctx, owner, repository, commentID, string(reaction))
+	return err
+}
+
+// ListCommentReactions on GitHub
+func (client *GitHubClient) ListCommentReactions(ctx context.Context, owner, repository string, _ int, commentID int64) ([]ReactionInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []ReactionInfo
+	for nextPage := 1; nextPage > 0; {
+		reactions, response, err := ghClient.Reactions.ListIssueCommentReactions(ctx, owner, repository, commentID,
+			&github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, reaction := range reactions {
+			results = append(results, ReactionInfo{Reaction: Reaction(reaction.GetContent()), User: reaction.GetUser().GetLogin()})
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// AddPullRequestReviewComment on GitHub
+func (client *GitHubClient) AddPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int,
+	comment PullRequestReviewComment) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewComment(comment); err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Review comments are anchored to the latest commit of the pull request
+	pullRequest, _, err := ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	reviewComment := &github.PullRequestComment{
+		Body:     &comment.Content,
+		Path:     &comment.Path,
+		Line:     &comment.Line,
+		Side:     github.String("RIGHT"),
+		CommitID: pullRequest.GetHead().SHA,
+	}
+	if comment.StartLine > 0 && comment.StartLine < comment.Line {
+		reviewComment.StartLine = &comment.StartLine
+		reviewComment.StartSide = github.String("RIGHT")
+	}
+	client.logger.Debug("adding review comment on pull request:", pullRequestID)
+	_, _, err = ghClient.PullRequests.CreateComment(ctx, owner, repository, pullRequestID, reviewComment)
+	return err
+}
+
+// ListPullRequestReviewComments on GitHub
+func (client *GitHubClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewComment, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestReviewComment
+	for nextPage := 1; nextPage > 0; {
+		comments, response, err := ghClient.PullRequests.ListComments(ctx, owner, repository, pullRequestID,
+			&github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			results = append(results, mapGitHubPullRequestCommentToReviewComment(comment))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// CreatePullRequestThread on GitHub opens a review thread, and returns its node ID
+func (client *GitHubClient) CreatePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return "", err
+	}
+	if comment.Path == "" {
+		return "", errGitHubPullRequestThreadPathRequired
+	}
+	if err = validateReviewComment(comment); err != nil {
+		return "", err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	pullRequest, _, err := ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return "", err
+	}
+	variables := map[string]interface{}{"id": pullRequest.GetNodeID(), "body": comment.Content, "path": comment.Path, "line": comment.Line,
+		"startLine": nil, "startSide": nil}
+	if comment.StartLine > 0 && comment.StartLine < comment.Line {
+		variables["startLine"], variables["startSide"] = comment.StartLine, "RIGHT"
+	}
+	// The REST API can't return the ID of the thread of a comment, so the GraphQL API is used
+	var result gitHubAddReviewThreadResponse
+	client.logger.Debug("creating review thread on pull request:", pullRequestID)
+	if err = sendGitHubGraphQLRequest(ctx, ghClient, gitHubAddReviewThreadMutation, variables, &result); err != nil {
+		return "", err
+	}
+	return result.AddPullRequestReviewThread.Thread.ID, nil
+}
+
+// ResolvePullRequestThread on GitHub
+func (client *GitHubClient) ResolvePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "thread ID": threadID})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	mutation := gitHubResolveReviewThreadMutation
+	if !resolved {
+		mutation = gitHubUnresolveReviewThreadMutation
+	}
+	client.logger.Debug("updating review thread", threadID, "of pull request:", pullRequestID)
+	return sendGitHubGraphQLRequest(ctx, ghClient, mutation, map[string]interface{}{"id": threadID}, nil)
+}
 
 // GetLatestCommit on GitHub
 func (client *GitHubClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
@@ -301,48 +1853,243 @@ func (client *GitHubClient) GetLatestCommit(ctx context.Context, owner, reposito
 		"branch":     branch,
 	})
 	if err != nil {
-		return CommitInfo{}, err
+		return CommitInfo{}, err
+	}
+
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	listOptions := &github.CommitsListOptions{
+		SHA: branch,
+		ListOptions: github.ListOptions{
+			Page:    1,
+			PerPage: 1,
+		},
+	}
+	commits, _, err := ghClient.Repositories.ListCommits(ctx, owner, repository, listOptions)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	if len(commits) > 0 {
+		latestCommit := commits[0]
+		return mapGitHubCommitToCommitInfo(latestCommit), nil
+	}
+	return CommitInfo{}, nil
+}
+
+// GetRepositoryInfo on GitHub
+func (client *GitHubClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	repo, _, err := ghClient.Repositories.Get(ctx, owner, repository)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapGitHubRepositoryToRepositoryInfo(repo), nil
+}
+
+// GetRepositoryLanguages on GitHub
+func (client *GitHubClient) GetRepositoryLanguages(ctx context.Context, owner, repository string) (map[string]float64, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languages, _, err := ghClient.Repositories.ListLanguages(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	languageBytes := make(map[string]int64, len(languages))
+	for language, bytes := range languages {
+		languageBytes[language] = int64(bytes)
+	}
+	return getLanguagePercentages(languageBytes), nil
+}
+
+// CreateRepository on GitHub
+func (client *GitHubClient) CreateRepository(ctx context.Context, owner, name string, options CreateRepositoryOptions) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "name": name})
+	if err != nil {
+		return err
+	}
+	if err = validateCreateRepositoryOptions(options); err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	// GitHub creates the repositories of users without an organization
+	ownerInfo, _, err := ghClient.Users.Get(ctx, owner)
+	if err != nil {
+		return err
+	}
+	organization := ""
+	if ownerInfo.GetType() == "Organization" {
+		organization = owner
+	}
+	repo := &github.Repository{
+		Name:       &name,
+		Private:    github.Bool(options.isPrivate()),
+		Visibility: github.String(getGitHubVisibilityName(options.Visibility)),
+		AutoInit:   &options.InitWithReadme,
+	}
+	if options.Description != "" {
+		repo.Description = &options.Description
 	}
+	repo, _, err = ghClient.Repositories.Create(ctx, organization, repo)
+	if err != nil {
+		return err
+	}
+	if options.DefaultBranch == "" || options.DefaultBranch == repo.GetDefaultBranch() {
+		return nil
+	}
+	// GitHub names the branch of the initial commit by the settings of the owner, so the branch is renamed
+	_, _, err = ghClient.Repositories.RenameBranch(ctx, owner, name, repo.GetDefaultBranch(), options.DefaultBranch)
+	return err
+}
 
+// DeleteRepository on GitHub
+func (client *GitHubClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
 	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
-		return CommitInfo{}, err
+		return err
 	}
-	listOptions := &github.CommitsListOptions{
-		SHA: branch,
-		ListOptions: github.ListOptions{
-			Page:    1,
-			PerPage: 1,
-		},
+	_, err = ghClient.Repositories.Delete(ctx, owner, repository)
+	return err
+}
+
+// ForkRepository on GitHub
+func (client *GitHubClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string, waitUntilReady bool) (ForkInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return ForkInfo{}, err
 	}
-	commits, _, err := ghClient.Repositories.ListCommits(ctx, owner, repository, listOptions)
+	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
-		return CommitInfo{}, err
+		return ForkInfo{}, err
 	}
-	if len(commits) > 0 {
-		latestCommit := commits[0]
-		return mapGitHubCommitToCommitInfo(latestCommit), nil
+	// GitHub accepts the fork and copies the content of the repository in the background
+	fork, _, err := ghClient.Repositories.CreateFork(ctx, owner, repository, &github.RepositoryCreateForkOptions{Organization: targetOwner})
+	if _, accepted := err.(*github.AcceptedError); err != nil && !accepted {
+		return ForkInfo{}, err
 	}
-	return CommitInfo{}, nil
+	forkInfo := ForkInfo{
+		Owner:          fork.GetOwner().GetLogin(),
+		Repository:     fork.GetName(),
+		RepositoryInfo: mapGitHubRepositoryToRepositoryInfo(fork),
+	}
+	if !waitUntilReady {
+		return forkInfo, nil
+	}
+	err = pollUntilReady(ctx, func() (bool, error) {
+		_, response, err := ghClient.Repositories.GetBranch(ctx, forkInfo.Owner, forkInfo.Repository, fork.GetDefaultBranch(), false)
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	return forkInfo, err
 }
 
-// GetRepositoryInfo on GitHub
-func (client *GitHubClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+// ListCollaborators on GitHub
+func (client *GitHubClient) ListCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return RepositoryInfo{}, err
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []CollaboratorInfo
+	for nextPage := 1; nextPage > 0; {
+		options := &github.ListCollaboratorsOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}}
+		users, response, err := ghClient.Repositories.ListCollaborators(ctx, owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			results = append(results, CollaboratorInfo{Username: user.GetLogin(), Permission: mapGitHubPermissionsToRepositoryPermission(user.Permissions)})
+		}
+		nextPage = response.NextPage
 	}
+	return results, nil
+}
 
+// AddCollaborator on GitHub
+func (client *GitHubClient) AddCollaborator(ctx context.Context, owner, repository, username string, permission RepositoryPermission) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return err
+	}
+	if err = validateCollaboratorPermission(permission); err != nil {
+		return err
+	}
 	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
-		return RepositoryInfo{}, err
+		return err
 	}
+	// Users who aren't members of the organization are invited, and become collaborators once they accept the invitation
+	_, _, err = ghClient.Repositories.AddCollaborator(ctx, owner, repository, username,
+		&github.RepositoryAddCollaboratorOptions{Permission: getGitHubPermissionName(permission)})
+	return err
+}
 
-	repo, _, err := ghClient.Repositories.Get(ctx, owner, repository)
+// RemoveCollaborator on GitHub
+func (client *GitHubClient) RemoveCollaborator(ctx context.Context, owner, repository, username string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
 	if err != nil {
-		return RepositoryInfo{}, err
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = ghClient.Repositories.RemoveCollaborator(ctx, owner, repository, username)
+	return err
+}
+
+// GetUserPermission on GitHub
+func (client *GitHubClient) GetUserPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return PermissionNone, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return PermissionNone, err
+	}
+	permissionLevel, _, err := ghClient.Repositories.GetPermissionLevel(ctx, owner, repository, username)
+	if err != nil {
+		return PermissionNone, err
+	}
+	switch permissionLevel.GetPermission() {
+	case "admin":
+		return PermissionAdmin, nil
+	case "write":
+		return PermissionWrite, nil
+	case "read":
+		return PermissionRead, nil
+	default:
+		return PermissionNone, nil
 	}
-	return RepositoryInfo{RepositoryVisibility: getGitHubRepositoryVisibility(repo), CloneInfo: CloneInfo{HTTP: repo.GetCloneURL(), SSH: repo.GetSSHURL()}}, nil
 }
 
 // GetCommitBySha on GitHub
@@ -369,6 +2116,252 @@ func (client *GitHubClient) GetCommitBySha(ctx context.Context, owner, repositor
 	return mapGitHubCommitToCommitInfo(commit), nil
 }
 
+// ListCommits on GitHub
+func (client *GitHubClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	return listPageOrAll(ctx, client.listCommitsPager(owner, repository, options), options.Page)
+}
+
+func (client *GitHubClient) listCommitsPager(owner, repository string, options ListCommitsOptions) *Pager[CommitInfo] {
+	listOptions := &github.CommitsListOptions{
+		SHA:         options.Branch,
+		Path:        options.Path,
+		Author:      options.Author,
+		Since:       options.Since,
+		Until:       options.Until,
+		ListOptions: github.ListOptions{Page: firstPage(options.Page), PerPage: options.PerPage},
+	}
+	return newPager(func(ctx context.Context) ([]CommitInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		ghClient, err := client.buildGithubClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching commits page", listOptions.Page, "in", repository)
+		commits, response, err := ghClient.Repositories.ListCommits(ctx, owner, repository, listOptions)
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]CommitInfo, 0, len(commits))
+		for _, commit := range commits {
+			results = append(results, mapGitHubCommitToCommitInfo(commit))
+		}
+		listOptions.Page = response.NextPage
+		return results, listOptions.Page > 0, nil
+	})
+}
+
+// GetCommit on GitHub
+func (client *GitHubClient) GetCommit(ctx context.Context, owner, repository, sha string) (CommitDetails, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha})
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	var result CommitDetails
+	for nextPage := 1; nextPage > 0; {
+		commit, response, err := ghClient.Repositories.GetCommit(ctx, owner, repository, sha, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return CommitDetails{}, err
+		}
+		// The files are paginated, while the commit and its stats are returned with the first page
+		if nextPage == 1 {
+			result.CommitInfo = mapGitHubCommitToCommitInfo(commit)
+			result.Additions, result.Deletions = commit.GetStats().GetAdditions(), commit.GetStats().GetDeletions()
+		}
+		for _, file := range commit.Files {
+			result.Files = append(result.Files, mapGitHubCommitFileToPullRequestFile(file))
+		}
+		nextPage = response.NextPage
+	}
+	return result, nil
+}
+
+// GetCommitVerification on GitHub. The signature is fetched with GraphQL, which reports the signer, unlike the REST API.
+func (client *GitHubClient) GetCommitVerification(ctx context.Context, owner, repository, sha string) (CommitVerificationInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha})
+	if err != nil {
+		return CommitVerificationInfo{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return CommitVerificationInfo{}, err
+	}
+	var response gitHubCommitSignatureResponse
+	err = sendGitHubGraphQLRequest(ctx, ghClient, gitHubCommitSignatureQuery, map[string]interface{}{
+		"owner": owner,
+		"name":  repository,
+		"ref":   sha,
+	}, &response)
+	if err != nil {
+		return CommitVerificationInfo{}, err
+	}
+	if response.Repository == nil || response.Repository.Object == nil {
+		return CommitVerificationInfo{}, fmt.Errorf("commit %s: %w", sha, ErrNotFound)
+	}
+	signature := response.Repository.Object.Signature
+	if signature == nil {
+		return CommitVerificationInfo{Reason: "unsigned"}, nil
+	}
+	verification := CommitVerificationInfo{
+		Signed:      true,
+		Verified:    signature.State == "VALID",
+		Reason:      strings.ToLower(signature.State),
+		SignerEmail: signature.Email,
+	}
+	if signature.Signer != nil {
+		verification.SignerUsername = signature.Signer.Login
+		verification.SignerName = signature.Signer.Name
+	}
+	switch signature.Typename {
+	case "GpgSignature":
+		verification.SignatureType = SignatureGPG
+	case "SshSignature":
+		verification.SignatureType = SignatureSSH
+	case "SmimeSignature":
+		verification.SignatureType = SignatureX509
+	}
+	return verification, nil
+}
+
+// CompareCommits on GitHub
+func (client *GitHubClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	var result CommitsComparison
+	for nextPage := 1; nextPage > 0; {
+		comparison, response, err := ghClient.Repositories.CompareCommits(ctx, owner, repository, base, head, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return CommitsComparison{}, err
+		}
+		// The commits are paginated, while the counts and files are returned with the first page
+		if nextPage == 1 {
+			result.AheadBy, result.BehindBy = comparison.GetAheadBy(), comparison.GetBehindBy()
+			for _, file := range comparison.Files {
+				result.Files = append(result.Files, mapGitHubCommitFileToPullRequestFile(file))
+			}
+		}
+		for _, commit := range comparison.Commits {
+			result.Commits = append(result.Commits, mapGitHubCommitToCommitInfo(commit))
+		}
+		nextPage = response.NextPage
+	}
+	return result, nil
+}
+
+// GetCommitsBetween on GitHub
+func (client *GitHubClient) GetCommitsBetween(ctx context.Context, owner, repository, fromSha, toSha string) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"fromSha":    fromSha,
+		"toSha":      toSha,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitInfo
+	for nextPage := 1; nextPage > 0; {
+		comparison, response, err := ghClient.Repositories.CompareCommits(ctx, owner, repository, fromSha, toSha, &github.ListOptions{Page: nextPage, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range comparison.Commits {
+			results = append(results, mapGitHubCommitToCommitInfo(commit))
+		}
+		nextPage = response.NextPage
+	}
+	// The comparison lists the commits from the oldest one
+	reverseCommits(results)
+	return results, nil
+}
+
+// ListContributors on GitHub
+func (client *GitHubClient) ListContributors(ctx context.Context, owner, repository string) ([]ContributorInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []ContributorInfo
+	for nextPage := 1; nextPage > 0; {
+		contributors, response, err := ghClient.Repositories.ListContributors(ctx, owner, repository,
+			&github.ListContributorsOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+		if err != nil {
+			return nil, err
+		}
+		for _, contributor := range contributors {
+			results = append(results, ContributorInfo{Name: contributor.GetLogin(), Commits: contributor.GetContributions()})
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetCommitActivity on GitHub. The statistics are computed by GitHub on the first request, which is polled until they're ready.
+func (client *GitHubClient) GetCommitActivity(ctx context.Context, owner, repository string, since time.Time) ([]CommitActivityInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stats []*github.ContributorStats
+	err = pollUntilReady(ctx, func() (bool, error) {
+		stats, _, err = ghClient.Repositories.ListContributorsStats(ctx, owner, repository)
+		var acceptedErr *github.AcceptedError
+		if errors.As(err, &acceptedErr) {
+			client.logger.Debug("waiting for the commit activity of", repository, "to be computed")
+			return false, nil
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	weekStart := getWeekStart(since)
+	var activity []CommitActivityInfo
+	for _, authorStats := range stats {
+		for _, week := range authorStats.Weeks {
+			if week.GetCommits() == 0 || week.GetWeek().Before(weekStart) {
+				continue
+			}
+			activity = append(activity, CommitActivityInfo{
+				Author:  authorStats.GetAuthor().GetLogin(),
+				Week:    week.GetWeek().Unix(),
+				Commits: week.GetCommits(),
+			})
+		}
+	}
+	sortCommitActivity(activity)
+	return activity, nil
+}
+
 // CreateLabel on GitHub
 func (client *GitHubClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
@@ -380,41 +2373,113 @@ func (client *GitHubClient) CreateLabel(ctx context.Context, owner, repository s
 	if err != nil {
 		return err
 	}
-
-	_, _, err = ghClient.Issues.CreateLabel(ctx, owner, repository, &github.Label{
-		Name:        &labelInfo.Name,
-		Description: &labelInfo.Description,
-		Color:       &labelInfo.Color,
-	})
-
+
+	_, _, err = ghClient.Issues.CreateLabel(ctx, owner, repository, &github.Label{
+		Name:        &labelInfo.Name,
+		Description: &labelInfo.Description,
+		Color:       &labelInfo.Color,
+	})
+
+	return err
+}
+
+// GetLabel on GitHub
+func (client *GitHubClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	label, response, err := ghClient.Issues.GetLabel(ctx, owner, repository, name)
+	if err != nil {
+		if response.Response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &LabelInfo{
+		Name:        *label.Name,
+		Description: *label.Description,
+		Color:       *label.Color,
+	}, err
+}
+
+// ListLabels on GitHub
+func (client *GitHubClient) ListLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	return client.ListLabelsPager(owner, repository, 100).All(ctx)
+}
+
+// ListLabelsPager on GitHub
+func (client *GitHubClient) ListLabelsPager(owner, repository string, perPage int) *Pager[LabelInfo] {
+	options := &github.ListOptions{Page: 1, PerPage: perPage}
+	return newPager(func(ctx context.Context) ([]LabelInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		ghClient, err := client.buildGithubClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		labels, response, err := ghClient.Issues.ListLabels(ctx, owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]LabelInfo, 0, len(labels))
+		for _, label := range labels {
+			results = append(results, LabelInfo{
+				Name:        label.GetName(),
+				Description: label.GetDescription(),
+				Color:       label.GetColor(),
+			})
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// UpdateLabel on GitHub
+func (client *GitHubClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	label := &github.Label{}
+	if labelInfo.Name != "" {
+		label.Name = &labelInfo.Name
+	}
+	if labelInfo.Description != "" {
+		label.Description = &labelInfo.Description
+	}
+	if labelInfo.Color != "" {
+		label.Color = &labelInfo.Color
+	}
+	_, _, err = ghClient.Issues.EditLabel(ctx, owner, repository, name, label)
 	return err
 }
 
-// GetLabel on GitHub
-func (client *GitHubClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+// DeleteLabel on GitHub
+func (client *GitHubClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
 	if err != nil {
-		return nil, err
+		return err
 	}
-
 	ghClient, err := client.buildGithubClient(ctx)
 	if err != nil {
-		return nil, err
-	}
-
-	label, response, err := ghClient.Issues.GetLabel(ctx, owner, repository, name)
-	if err != nil {
-		if response.Response.StatusCode == http.StatusNotFound {
-			return nil, nil
-		}
-		return nil, err
+		return err
 	}
-
-	return &LabelInfo{
-		Name:        *label.Name,
-		Description: *label.Description,
-		Color:       *label.Color,
-	}, err
+	_, err = ghClient.Issues.DeleteLabel(ctx, owner, repository, name)
+	return err
 }
 
 // ListPullRequestLabels on GitHub
@@ -429,7 +2494,7 @@ func (client *GitHubClient) ListPullRequestLabels(ctx context.Context, owner, re
 	}
 
 	results := []string{}
-	for nextPage := 0; ; nextPage++ {
+	for nextPage := 1; nextPage > 0; {
 		options := &github.ListOptions{Page: nextPage}
 		labels, response, err := ghClient.Issues.ListLabelsByIssue(ctx, owner, repository, pullRequestID, options)
 		if err != nil {
@@ -438,9 +2503,7 @@ func (client *GitHubClient) ListPullRequestLabels(ctx context.Context, owner, re
 		for _, label := range labels {
 			results = append(results, *label.Name)
 		}
-		if nextPage+1 >= response.LastPage {
-			break
-		}
+		nextPage = response.NextPage
 	}
 	return results, nil
 }
@@ -460,6 +2523,99 @@ func (client *GitHubClient) UnlabelPullRequest(ctx context.Context, owner, repos
 	return err
 }
 
+// CreateIssue on GitHub
+func (client *GitHubClient) CreateIssue(ctx context.Context, owner, repository, title, body string, labels []string) (IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title})
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	issueRequest := &github.IssueRequest{Title: &title, Body: &body}
+	if len(labels) > 0 {
+		issueRequest.Labels = &labels
+	}
+	issue, _, err := ghClient.Issues.Create(ctx, owner, repository, issueRequest)
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	return mapGitHubIssueToIssueInfo(issue), nil
+}
+
+// AddIssueComment on GitHub
+func (client *GitHubClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = ghClient.Issues.CreateComment(ctx, owner, repository, issueID, &github.IssueComment{Body: &content})
+	return err
+}
+
+// ListIssues on GitHub
+func (client *GitHubClient) ListIssues(ctx context.Context, owner, repository string, filter IssueFilter) ([]IssueInfo, error) {
+	return listPageOrAll(ctx, client.issuesPager(owner, repository, filter), filter.Page)
+}
+
+func (client *GitHubClient) issuesPager(owner, repository string, filter IssueFilter) *Pager[IssueInfo] {
+	options := &github.IssueListByRepoOptions{
+		State:       "all",
+		Creator:     filter.Author,
+		ListOptions: github.ListOptions{Page: firstPage(filter.Page), PerPage: filter.PerPage},
+	}
+	if filter.State != nil {
+		options.State = mapIssueStateToGitHubState(*filter.State)
+	}
+	if filter.Label != "" {
+		options.Labels = []string{filter.Label}
+	}
+	return newPager(func(ctx context.Context) ([]IssueInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		ghClient, err := client.buildGithubClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		issues, response, err := ghClient.Issues.ListByRepo(ctx, owner, repository, options)
+		if err != nil {
+			return nil, false, err
+		}
+		var results []IssueInfo
+		for _, issue := range issues {
+			// GitHub lists the pull requests as issues too
+			if issue.IsPullRequest() {
+				continue
+			}
+			results = append(results, mapGitHubIssueToIssueInfo(issue))
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// UpdateIssueState on GitHub
+func (client *GitHubClient) UpdateIssueState(ctx context.Context, owner, repository string, issueID int, state IssueState) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	gitHubState := mapIssueStateToGitHubState(state)
+	_, _, err = ghClient.Issues.Edit(ctx, owner, repository, issueID, &github.IssueRequest{State: &gitHubState})
+	return err
+}
+
 // UploadCodeScanning to GitHub Security tab
 func (client *GitHubClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
 	packagedScan, err := packScanningResult(scanResults)
@@ -505,6 +2661,87 @@ func (client *GitHubClient) UploadCodeScanning(ctx context.Context, owner, repos
 	return "", nil
 }
 
+// UploadScanResults on GitHub uploads the scan results to code scanning
+func (client *GitHubClient) UploadScanResults(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	return client.UploadCodeScanning(ctx, owner, repository, branch, scanResults)
+}
+
+// ListSecurityAlerts on GitHub returns the open Dependabot and code scanning alerts.
+// The code scanning alerts are skipped if the repository has no code scanning analysis.
+func (client *GitHubClient) ListSecurityAlerts(ctx context.Context, owner, repository string) ([]SecurityAlertInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := client.listDependabotAlerts(ctx, ghClient, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	for nextPage := 1; nextPage > 0; {
+		codeScanningAlerts, response, err := ghClient.CodeScanning.ListAlertsForRepo(ctx, owner, repository,
+			&github.AlertListOptions{State: "open", ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+		if err != nil {
+			if response != nil && response.StatusCode == http.StatusNotFound {
+				break
+			}
+			return nil, err
+		}
+		for _, alert := range codeScanningAlerts {
+			alerts = append(alerts, mapGitHubCodeScanningAlertToSecurityAlertInfo(alert))
+		}
+		nextPage = response.NextPage
+	}
+	return alerts, nil
+}
+
+// The GitHub client doesn't support Dependabot alerts, so they are requested here, with cursor-based pagination
+func (client *GitHubClient) listDependabotAlerts(ctx context.Context, ghClient *github.Client, owner, repository string) ([]SecurityAlertInfo, error) {
+	var results []SecurityAlertInfo
+	for after := ""; ; {
+		path := fmt.Sprintf("repos/%s/%s/dependabot/alerts?state=open&per_page=100", owner, repository)
+		if after != "" {
+			path += "&after=" + url.QueryEscape(after)
+		}
+		request, err := ghClient.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		var alerts []gitHubDependabotAlert
+		response, err := ghClient.Do(ctx, request, &alerts)
+		if err != nil {
+			return nil, err
+		}
+		for _, alert := range alerts {
+			results = append(results, mapGitHubDependabotAlertToSecurityAlertInfo(alert))
+		}
+		if response.After == "" {
+			return results, nil
+		}
+		after = response.After
+	}
+}
+
+type gitHubDependabotAlert struct {
+	Number     int64  `json:"number"`
+	HTMLURL    string `json:"html_url"`
+	Dependency struct {
+		Package struct {
+			Name string `json:"name"`
+		} `json:"package"`
+		ManifestPath string `json:"manifest_path"`
+	} `json:"dependency"`
+	SecurityAdvisory struct {
+		GhsaID   string `json:"ghsa_id"`
+		CveID    string `json:"cve_id"`
+		Summary  string `json:"summary"`
+		Severity string `json:"severity"`
+	} `json:"security_advisory"`
+}
+
 // DownloadFileFromRepo on GitHub
 func (client *GitHubClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) (content []byte, statusCode int, err error) {
 	ghClient, err := client.buildGithubClient(ctx)
@@ -519,19 +2756,314 @@ func (client *GitHubClient) DownloadFileFromRepo(ctx context.Context, owner, rep
 				err = e
 			}
 		}
-	}()
-	if response != nil && response.StatusCode != http.StatusOK {
-		return nil, response.StatusCode, fmt.Errorf("expected %d status code while received %d status code with error:\n%s", http.StatusOK, response.StatusCode, err)
-	}
-	if err != nil {
-		return nil, 0, err
+	}()
+	if response != nil && response.StatusCode != http.StatusOK {
+		return nil, response.StatusCode, fmt.Errorf("expected %d status code while received %d status code with error:\n%s", http.StatusOK, response.StatusCode, err)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	content, err = io.ReadAll(body)
+	if err != nil {
+		return nil, response.StatusCode, err
+	}
+	return content, response.StatusCode, nil
+}
+
+// GetFileContent on GitHub
+func (client *GitHubClient) GetFileContent(ctx context.Context, owner, repository, ref, path string) (FileContent, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"ref":        ref,
+		"path":       path,
+	})
+	if err != nil {
+		return FileContent{}, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return FileContent{}, err
+	}
+	fileContent, _, _, err := ghClient.Repositories.GetContents(ctx, owner, repository, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		return FileContent{}, err
+	}
+	if fileContent == nil {
+		return FileContent{}, fmt.Errorf("%s is not a file", path)
+	}
+	result := FileContent{Path: fileContent.GetPath(), Size: int64(fileContent.GetSize()), Sha: fileContent.GetSHA()}
+	// The content of files larger than 1MB isn't included in the response, and should be fetched from the blobs API
+	if fileContent.GetEncoding() == "none" {
+		result.Content, _, err = ghClient.Git.GetBlobRaw(ctx, owner, repository, fileContent.GetSHA())
+		return result, err
+	}
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return FileContent{}, err
+	}
+	result.Content = []byte(content)
+	return result, nil
+}
+
+// GetFileBlame on GitHub. The REST API doesn't provide blame information, so the GraphQL API is used.
+func (client *GitHubClient) GetFileBlame(ctx context.Context, owner, repository, ref, path string) ([]BlameLine, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"ref":        ref,
+		"path":       path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var response gitHubBlameResponse
+	err = sendGitHubGraphQLRequest(ctx, ghClient, gitHubBlameQuery, map[string]interface{}{
+		"owner": owner,
+		"name":  repository,
+		"ref":   ref,
+		"path":  path,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Repository == nil || response.Repository.Object == nil {
+		return nil, fmt.Errorf("%s at %s: %w", path, ref, ErrNotFound)
+	}
+	var blame []BlameLine
+	for _, blameRange := range response.Repository.Object.Blame.Ranges {
+		for line := blameRange.StartingLine; line <= blameRange.EndingLine; line++ {
+			blame = append(blame, BlameLine{
+				Line:        line,
+				CommitHash:  blameRange.Commit.Oid,
+				AuthorName:  blameRange.Commit.Author.Name,
+				AuthorEmail: blameRange.Commit.Author.Email,
+				Timestamp:   blameRange.Commit.AuthoredDate.Unix(),
+			})
+		}
+	}
+	return blame, nil
+}
+
+// ListRepositoryTree on GitHub. Trees larger than the limits of the GitHub API are truncated.
+func (client *GitHubClient) ListRepositoryTree(ctx context.Context, owner, repository, ref string, recursive bool) ([]TreeEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree, _, err := ghClient.Git.GetTree(ctx, owner, repository, ref, recursive)
+	if err != nil {
+		return nil, err
+	}
+	if tree.GetTruncated() {
+		client.logger.Warn("the tree of", repository, "at", ref, "is truncated")
+	}
+	results := make([]TreeEntry, 0, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		results = append(results, TreeEntry{Path: entry.GetPath(), Type: getGitTreeEntryType(entry.GetType()), Size: int64(entry.GetSize())})
+	}
+	return results, nil
+}
+
+// SearchCode on GitHub
+func (client *GitHubClient) SearchCode(ctx context.Context, scope CodeSearchScope, query string) ([]CodeSearchResult, error) {
+	return listPageOrAll(ctx, client.searchCodePager(scope, query), scope.Page)
+}
+
+func (client *GitHubClient) searchCodePager(scope CodeSearchScope, query string) *Pager[CodeSearchResult] {
+	// The text matches contain the fragments of the matching files
+	searchOptions := &github.SearchOptions{TextMatch: true, ListOptions: github.ListOptions{Page: firstPage(scope.Page), PerPage: scope.PerPage}}
+	qualifiedQuery := fmt.Sprintf("%s user:%s", query, scope.Owner)
+	if scope.Repository != "" {
+		qualifiedQuery = fmt.Sprintf("%s repo:%s/%s", query, scope.Owner, scope.Repository)
+	}
+	return newPager(func(ctx context.Context) ([]CodeSearchResult, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": scope.Owner, "query": query})
+		if err != nil {
+			return nil, false, err
+		}
+		ghClient, err := client.buildGithubClient(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching code search results page", searchOptions.Page)
+		codeResults, response, err := ghClient.Search.Code(ctx, qualifiedQuery, searchOptions)
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]CodeSearchResult, 0, len(codeResults.CodeResults))
+		for _, codeResult := range codeResults.CodeResults {
+			results = append(results, mapGitHubCodeResultToCodeSearchResult(codeResult))
+		}
+		searchOptions.Page = response.NextPage
+		return results, searchOptions.Page > 0, nil
+	})
+}
+
+// CreateOrUpdateFile on GitHub
+func (client *GitHubClient) CreateOrUpdateFile(ctx context.Context, owner, repository, branch, path string, content []byte, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":          owner,
+		"repository":     repository,
+		"branch":         branch,
+		"path":           path,
+		"commit message": commitMessage,
+	})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	options := &github.RepositoryContentFileOptions{Message: &commitMessage, Content: content, Branch: &branch}
+	fileContent, _, response, err := ghClient.Repositories.GetContents(ctx, owner, repository, path, &github.RepositoryContentGetOptions{Ref: branch})
+	if err != nil {
+		if response == nil || response.StatusCode != http.StatusNotFound {
+			return err
+		}
+		client.logger.Debug("creating file", path, "on branch", branch)
+		_, _, err = ghClient.Repositories.CreateFile(ctx, owner, repository, path, options)
+		return err
+	}
+	if fileContent == nil {
+		return fmt.Errorf("%s is not a file", path)
+	}
+	// Updating an existing file requires the SHA of the blob being replaced
+	options.SHA = fileContent.SHA
+	client.logger.Debug("updating file", path, "on branch", branch)
+	_, _, err = ghClient.Repositories.UpdateFile(ctx, owner, repository, path, options)
+	return err
+}
+
+// CommitFiles on GitHub
+func (client *GitHubClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, changes []FileChange) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":          owner,
+		"repository":     repository,
+		"branch":         branch,
+		"commit message": commitMessage,
+	})
+	if err != nil {
+		return err
+	}
+	if err = validateFileChanges(changes); err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	branchRef, _, err := ghClient.Git.GetRef(ctx, owner, repository, "heads/"+branch)
+	if err != nil {
+		return err
+	}
+	parentCommit, _, err := ghClient.Git.GetCommit(ctx, owner, repository, branchRef.GetObject().GetSHA())
+	if err != nil {
+		return err
+	}
+	baseTreeSHA := parentCommit.GetTree().GetSHA()
+	modes, err := client.getUpdatedFileModes(ctx, ghClient, owner, repository, baseTreeSHA, changes)
+	if err != nil {
+		return err
+	}
+	entries := make([]*github.TreeEntry, 0, len(changes))
+	for _, change := range changes {
+		entry := &github.TreeEntry{Path: github.String(change.Path), Mode: github.String(gitHubRegularFileMode), Type: github.String("blob")}
+		if mode, exists := modes[change.Path]; exists {
+			entry.Mode = github.String(mode)
+		}
+		if change.Type != DeleteFile {
+			// Blobs are uploaded base64 encoded, to support binary content
+			blob, _, err := ghClient.Git.CreateBlob(ctx, owner, repository, &github.Blob{
+				Content:  github.String(stdbase64.StdEncoding.EncodeToString(change.Content)),
+				Encoding: github.String("base64"),
+			})
+			if err != nil {
+				return err
+			}
+			entry.SHA = blob.SHA
+		}
+		// A tree entry without a SHA deletes the file
+		entries = append(entries, entry)
+	}
+	tree, _, err := ghClient.Git.CreateTree(ctx, owner, repository, baseTreeSHA, entries)
+	if err != nil {
+		return err
+	}
+	commit, _, err := ghClient.Git.CreateCommit(ctx, owner, repository, &github.Commit{
+		Message: &commitMessage,
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: parentCommit.SHA}},
+	})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("updating branch", branch, "to commit", commit.GetSHA())
+	branchRef.Object.SHA = commit.SHA
+	_, _, err = ghClient.Git.UpdateRef(ctx, owner, repository, branchRef, false)
+	return err
+}
+
+// getUpdatedFileModes returns the modes of the updated files in the base tree, such as the mode of executable files,
+// so that CommitFiles doesn't change them. The trees of the directories are fetched one by one, since recursive trees may be truncated.
+func (client *GitHubClient) getUpdatedFileModes(ctx context.Context, ghClient *github.Client, owner, repository, baseTreeSHA string, changes []FileChange) (map[string]string, error) {
+	modes := make(map[string]string)
+	// The fetched trees by the paths of their directories, where the base tree is the root directory
+	trees := make(map[string]*github.Tree)
+	getTree := func(dir, sha string) (*github.Tree, error) {
+		if tree, exists := trees[dir]; exists {
+			return tree, nil
+		}
+		tree, _, err := ghClient.Git.GetTree(ctx, owner, repository, sha, false)
+		if err != nil {
+			return nil, err
+		}
+		trees[dir] = tree
+		return tree, nil
+	}
+	for _, change := range changes {
+		if change.Type != UpdateFile {
+			continue
+		}
+		tree, err := getTree("", baseTreeSHA)
+		if err != nil {
+			return nil, err
+		}
+		names := strings.Split(change.Path, "/")
+		for i, name := range names {
+			entry := findGitHubTreeEntry(tree, name)
+			if entry == nil {
+				break
+			}
+			if i == len(names)-1 {
+				modes[change.Path] = entry.GetMode()
+				break
+			}
+			if tree, err = getTree(strings.Join(names[:i+1], "/"), entry.GetSHA()); err != nil {
+				return nil, err
+			}
+		}
 	}
+	return modes, nil
+}
 
-	content, err = io.ReadAll(body)
-	if err != nil {
-		return nil, response.StatusCode, err
+func findGitHubTreeEntry(tree *github.Tree, name string) *github.TreeEntry {
+	for _, entry := range tree.Entries {
+		if entry.GetPath() == name {
+			return entry
+		}
 	}
-	return content, response.StatusCode, nil
+	return nil
 }
 
 // GetRepositoryEnvironmentInfo on GitHub
@@ -553,16 +3085,99 @@ func (client *GitHubClient) GetRepositoryEnvironmentInfo(ctx context.Context, ow
 		return RepositoryEnvironmentInfo{}, err
 	}
 
+	return mapGitHubEnvironmentToRepositoryEnvironmentInfo(environment)
+}
+
+// ListEnvironments on GitHub
+func (client *GitHubClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []RepositoryEnvironmentInfo
+	for nextPage := 1; nextPage > 0; {
+		environments, response, err := ghClient.Repositories.ListEnvironments(ctx, owner, repository,
+			&github.EnvironmentListOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range environments.Environments {
+			environmentInfo, err := mapGitHubEnvironmentToRepositoryEnvironmentInfo(environment)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, environmentInfo)
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// CreateDeployment on GitHub. The deployment is created regardless of the commit statuses of the ref, and without merging the default branch into it.
+func (client *GitHubClient) CreateDeployment(ctx context.Context, owner, repository string, deployment DeploymentInfo) (int64, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "environment": deployment.Environment, "ref": deployment.Ref})
+	if err != nil {
+		return 0, err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return 0, err
+	}
+	request := &github.DeploymentRequest{
+		Ref:              &deployment.Ref,
+		Environment:      &deployment.Environment,
+		AutoMerge:        github.Bool(false),
+		RequiredContexts: &[]string{},
+	}
+	if deployment.Description != "" {
+		request.Description = &deployment.Description
+	}
+	created, _, err := ghClient.Repositories.CreateDeployment(ctx, owner, repository, request)
+	if err != nil {
+		return 0, err
+	}
+	return created.GetID(), nil
+}
+
+// SetDeploymentStatus on GitHub
+func (client *GitHubClient) SetDeploymentStatus(ctx context.Context, owner, repository string, deploymentID int64, status DeploymentStatusInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	ghClient, err := client.buildGithubClient(ctx)
+	if err != nil {
+		return err
+	}
+	request := &github.DeploymentStatusRequest{State: github.String(getGitHubDeploymentState(status.State))}
+	if status.Description != "" {
+		request.Description = &status.Description
+	}
+	if status.LogURL != "" {
+		request.LogURL = &status.LogURL
+	}
+	if status.EnvironmentURL != "" {
+		request.EnvironmentURL = &status.EnvironmentURL
+	}
+	_, _, err = ghClient.Repositories.CreateDeploymentStatus(ctx, owner, repository, deploymentID, request)
+	return err
+}
+
+func mapGitHubEnvironmentToRepositoryEnvironmentInfo(environment *github.Environment) (RepositoryEnvironmentInfo, error) {
 	reviewers, err := extractGitHubEnvironmentReviewers(environment)
 	if err != nil {
 		return RepositoryEnvironmentInfo{}, err
 	}
 
 	return RepositoryEnvironmentInfo{
-		Name:      *environment.Name,
-		Url:       *environment.URL,
+		Name:      environment.GetName(),
+		Url:       environment.GetURL(),
 		Reviewers: reviewers,
-	}, err
+	}, nil
 }
 
 // Extract code reviewers from environment
@@ -595,28 +3210,147 @@ func createGitHubHook(token, payloadURL string, webhookEvents ...vcsutils.Webhoo
 	}
 }
 
+func createGitHubHookWithOptions(token string, options WebhookOptions) *github.Hook {
+	hook := createGitHubHook(token, options.PayloadURL, options.Events...)
+	hook.Config["content_type"] = string(options.contentType())
+	hook.Config["insecure_ssl"] = "0"
+	if options.InsecureSSL {
+		hook.Config["insecure_ssl"] = "1"
+	}
+	return hook
+}
+
+func mapGitHubHookToWebhookInfo(hook *github.Hook) WebhookInfo {
+	payloadURL, _ := hook.Config["url"].(string)
+	contentType, _ := hook.Config["content_type"].(string)
+	return WebhookInfo{
+		ID:          strconv.FormatInt(hook.GetID(), 10),
+		PayloadURL:  payloadURL,
+		Events:      mapProviderWebhookEvents(hook.Events, getGitHubWebhookEvents),
+		Active:      hook.GetActive(),
+		ContentType: WebhookContentType(contentType),
+		// GitHub returns insecure_ssl either as a string or as a number
+		InsecureSSL: fmt.Sprint(hook.Config["insecure_ssl"]) == "1",
+	}
+}
+
 // Get varargs of webhook events and return a slice of GitHub webhook events
 func getGitHubWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []string {
 	events := make([]string, 0, len(webhookEvents))
 	for _, event := range webhookEvents {
 		switch event {
-		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected:
+		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.PrClosed:
 			events = append(events, "pull_request")
 		case vcsutils.Push:
 			events = append(events, "push")
+		case vcsutils.ReleasePublished, vcsutils.ReleaseEdited:
+			events = append(events, "release")
+		case vcsutils.DeploymentCreated:
+			events = append(events, "deployment")
+		case vcsutils.DeploymentStatusUpdated:
+			events = append(events, "deployment_status")
+		case vcsutils.CIRunCompleted:
+			events = append(events, "workflow_run", "check_suite")
+		case vcsutils.BranchCreated:
+			events = append(events, "create")
+		case vcsutils.BranchDeleted:
+			events = append(events, "delete")
 		}
 	}
 	return events
 }
 
+// mapGitHubPermissionsToRepositoryPermission maps the roles of a collaborator to the highest permission they grant
+func mapGitHubPermissionsToRepositoryPermission(permissions map[string]bool) RepositoryPermission {
+	switch {
+	case permissions["admin"]:
+		return PermissionAdmin
+	case permissions["maintain"], permissions["push"]:
+		return PermissionWrite
+	case permissions["triage"], permissions["pull"]:
+		return PermissionRead
+	default:
+		return PermissionNone
+	}
+}
+
+func getGitHubPermissionName(permission RepositoryPermission) string {
+	switch permission {
+	case PermissionAdmin:
+		return "admin"
+	case PermissionWrite:
+		return "push"
+	default:
+		return "pull"
+	}
+}
+
+// GitHub reports the size of a repository in kilobytes
+func mapGitHubRepositoryToRepositoryInfo(repo *github.Repository) RepositoryInfo {
+	return RepositoryInfo{
+		RepositoryVisibility: getGitHubRepositoryVisibility(repo),
+		CloneInfo:            CloneInfo{HTTP: repo.GetCloneURL(), SSH: repo.GetSSHURL()},
+		DefaultBranch:        repo.GetDefaultBranch(),
+		Description:          repo.GetDescription(),
+		Archived:             repo.GetArchived(),
+		Topics:               repo.Topics,
+		Size:                 int64(repo.GetSize()) * 1024,
+	}
+}
+
+func mapGitHubCodeResultToCodeSearchResult(codeResult *github.CodeResult) CodeSearchResult {
+	result := CodeSearchResult{
+		Owner:      codeResult.GetRepository().GetOwner().GetLogin(),
+		Repository: codeResult.GetRepository().GetName(),
+		Path:       codeResult.GetPath(),
+	}
+	for _, textMatch := range codeResult.TextMatches {
+		// Matches of the file path are ignored
+		if textMatch.GetProperty() == "content" {
+			result.Fragments = append(result.Fragments, textMatch.GetFragment())
+		}
+	}
+	return result
+}
+
+func mapGitHubRepositoryToRepositoryDetails(repo *github.Repository) RepositoryDetails {
+	return RepositoryDetails{
+		Owner:          repo.GetOwner().GetLogin(),
+		Repository:     repo.GetName(),
+		RepositoryInfo: mapGitHubRepositoryToRepositoryInfo(repo),
+		Language:       repo.GetLanguage(),
+		Updated:        repo.GetUpdatedAt().Time,
+	}
+}
+
 func getGitHubRepositoryVisibility(repo *github.Repository) RepositoryVisibility {
-	switch *repo.Visibility {
+	switch repo.GetVisibility() {
 	case "public":
 		return Public
 	case "internal":
 		return Internal
-	default:
+	case "private":
 		return Private
+	default:
+		// The visibility isn't returned by older GitHub Enterprise versions
+		if repo.GetPrivate() {
+			return Private
+		}
+		return Public
+	}
+}
+
+func getGitHubVisibilityName(visibility *RepositoryVisibility) string {
+	if visibility == nil {
+		return "private"
+	}
+	switch *visibility {
+	case Public:
+		return "public"
+	case Internal:
+		return "internal"
+	default:
+		return "private"
 	}
 }
 
@@ -634,6 +3368,152 @@ func getGitHubCommitState(commitState CommitStatus) string {
 	return ""
 }
 
+// getGitHubDeploymentState returns the state of a deployment status, which is in progress rather than pending while deploying
+func getGitHubDeploymentState(state CommitStatus) string {
+	if state == InProgress {
+		return "in_progress"
+	}
+	return getGitHubCommitState(state)
+}
+
+// getGitHubCheckRunState returns the status of a check run, and its conclusion and completion time if it's completed
+func getGitHubCheckRunState(state CommitStatus) (string, *string, *github.Timestamp) {
+	var conclusion string
+	switch state {
+	case InProgress:
+		return "in_progress", nil, nil
+	case Pass:
+		conclusion = "success"
+	default:
+		conclusion = "failure"
+	}
+	return "completed", &conclusion, &github.Timestamp{Time: time.Now()}
+}
+
+func getGitHubAnnotationLevel(level AnnotationLevel) string {
+	switch level {
+	case AnnotationWarning:
+		return "warning"
+	case AnnotationFailure:
+		return "failure"
+	}
+	return "notice"
+}
+
+// getGitHubCheckRunOutput returns the output of the check run with the given annotations, or nil if there's no output.
+// The title of the output is required by GitHub, so the name of the check run is used if it has no title.
+func getGitHubCheckRunOutput(checkRun CheckRunInfo, annotations []*github.CheckRunAnnotation) *github.CheckRunOutput {
+	if checkRun.Title == "" && checkRun.Summary == "" && len(annotations) == 0 {
+		return nil
+	}
+	title := checkRun.Title
+	if title == "" {
+		title = checkRun.Name
+	}
+	return &github.CheckRunOutput{Title: &title, Summary: &checkRun.Summary, Annotations: annotations}
+}
+
+// getOptionalGitHubString returns a pointer to the value, or nil if it's empty, so it's omitted from the request
+func getOptionalGitHubString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// splitGitHubAnnotations splits the annotations to the annotations of the first request, and the remaining annotations
+func splitGitHubAnnotations(annotations []*github.CheckRunAnnotation) ([]*github.CheckRunAnnotation, []*github.CheckRunAnnotation) {
+	if len(annotations) <= gitHubMaxAnnotationsPerRequest {
+		return annotations, nil
+	}
+	return annotations[:gitHubMaxAnnotationsPerRequest], annotations[gitHubMaxAnnotationsPerRequest:]
+}
+
+func mapCheckRunAnnotationsToGitHub(annotations []CheckRunAnnotation) []*github.CheckRunAnnotation {
+	var results []*github.CheckRunAnnotation
+	for _, annotation := range annotations {
+		endLine := annotation.EndLine
+		if endLine == 0 {
+			endLine = annotation.StartLine
+		}
+		results = append(results, &github.CheckRunAnnotation{
+			Path:            github.String(annotation.Path),
+			StartLine:       github.Int(annotation.StartLine),
+			EndLine:         github.Int(endLine),
+			AnnotationLevel: github.String(getGitHubAnnotationLevel(annotation.Level)),
+			Title:           getOptionalGitHubString(annotation.Title),
+			Message:         github.String(annotation.Message),
+		})
+	}
+	return results
+}
+
+func getGitHubCommitStatus(state string) CommitStatus {
+	switch state {
+	case "success":
+		return Pass
+	case "failure":
+		return Fail
+	case "pending":
+		return InProgress
+	}
+	return Error
+}
+
+func getGitHubCheckRunStatus(checkRun *github.CheckRun) CommitStatus {
+	return getGitHubRunStatus(checkRun.GetStatus(), checkRun.GetConclusion())
+}
+
+// getGitHubRunStatus returns the status of a check run or a workflow run, which has a conclusion only once it's completed
+func getGitHubRunStatus(status, conclusion string) CommitStatus {
+	if status != "completed" {
+		return InProgress
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return Pass
+	case "failure", "cancelled", "timed_out", "action_required":
+		return Fail
+	}
+	return Error
+}
+
+func mapGitHubRepoStatusToCommitStatusInfo(status *github.RepoStatus) CommitStatusInfo {
+	return CommitStatusInfo{
+		State:         getGitHubCommitStatus(status.GetState()),
+		Title:         status.GetContext(),
+		Description:   status.GetDescription(),
+		DetailsURL:    status.GetTargetURL(),
+		CreatedAt:     status.GetCreatedAt(),
+		LastUpdatedAt: status.GetUpdatedAt(),
+	}
+}
+
+func mapGitHubCheckRunToCommitStatusInfo(checkRun *github.CheckRun) CommitStatusInfo {
+	status := CommitStatusInfo{
+		State:       getGitHubCheckRunStatus(checkRun),
+		Title:       checkRun.GetName(),
+		Description: checkRun.GetOutput().GetTitle(),
+		DetailsURL:  checkRun.GetDetailsURL(),
+		CreatedAt:   checkRun.GetStartedAt().Time,
+	}
+	status.LastUpdatedAt = status.CreatedAt
+	if checkRun.CompletedAt != nil {
+		status.LastUpdatedAt = checkRun.CompletedAt.Time
+	}
+	return status
+}
+
+func mapGitHubCommitFileToPullRequestFile(file *github.CommitFile) PullRequestFile {
+	return PullRequestFile{
+		Path:         file.GetFilename(),
+		PreviousPath: file.GetPreviousFilename(),
+		Status:       getGitHubFileStatus(file.GetStatus()),
+		Additions:    file.GetAdditions(),
+		Deletions:    file.GetDeletions(),
+	}
+}
+
 func mapGitHubCommitToCommitInfo(commit *github.RepositoryCommit) CommitInfo {
 	parents := make([]string, len(commit.Parents))
 	for i, c := range commit.Parents {
@@ -674,11 +3554,80 @@ func mapGitHubPullRequestToPullRequestInfoList(pullRequestList []*github.PullReq
 				Name:       *pullRequest.Base.Ref,
 				Repository: *pullRequest.Base.Repo.Name,
 			},
+			Draft: pullRequest.GetDraft(),
 		})
 	}
 	return
 }
 
+func mapGitHubPullRequestToPullRequestInfo(pullRequest *github.PullRequest) PullRequestInfo {
+	state := PullRequestOpen
+	if pullRequest.GetMerged() {
+		state = PullRequestMerged
+	} else if pullRequest.GetState() == "closed" {
+		state = PullRequestClosed
+	}
+	labels := make([]string, 0, len(pullRequest.Labels))
+	for _, label := range pullRequest.Labels {
+		labels = append(labels, label.GetName())
+	}
+	return PullRequestInfo{
+		ID:        int64(pullRequest.GetNumber()),
+		Source:    mapGitHubPullRequestBranch(pullRequest.GetHead()),
+		Target:    mapGitHubPullRequestBranch(pullRequest.GetBase()),
+		Title:     pullRequest.GetTitle(),
+		Body:      pullRequest.GetBody(),
+		State:     state,
+		Draft:     pullRequest.GetDraft(),
+		Author:    pullRequest.GetUser().GetLogin(),
+		Labels:    labels,
+		Mergeable: pullRequest.Mergeable,
+	}
+}
+
+func mapGitHubPullRequestBranch(branch *github.PullRequestBranch) BranchInfo {
+	return BranchInfo{
+		Name:       branch.GetRef(),
+		Repository: branch.GetRepo().GetName(),
+		Owner:      branch.GetRepo().GetOwner().GetLogin(),
+	}
+}
+
+func getGitHubPullRequestState(state PullRequestState) string {
+	if state == PullRequestClosed {
+		return "closed"
+	}
+	return "open"
+}
+
+func getGitHubReviewEvent(reviewEvent ReviewEvent) string {
+	switch reviewEvent {
+	case ReviewRequestChanges:
+		return "REQUEST_CHANGES"
+	case ReviewComment:
+		return "COMMENT"
+	}
+	return "APPROVE"
+}
+
+func mapGitHubPullRequestCommentToReviewComment(comment *github.PullRequestComment) PullRequestReviewComment {
+	reviewComment := PullRequestReviewComment{
+		CommentInfo: CommentInfo{
+			ID:      comment.GetID(),
+			Content: comment.GetBody(),
+			Created: comment.GetCreatedAt(),
+		},
+		Path:      comment.GetPath(),
+		Line:      comment.GetLine(),
+		StartLine: comment.GetStartLine(),
+	}
+	// Comments on lines that were changed by later commits are outdated, and keep their original lines only
+	if reviewComment.Line == 0 {
+		reviewComment.Line, reviewComment.StartLine = comment.GetOriginalLine(), comment.GetOriginalStartLine()
+	}
+	return reviewComment
+}
+
 func packScanningResult(data string) (string, error) {
 	compressedScan, err := base64.EncodeGzip([]byte(data), 6)
 	if err != nil {
@@ -691,3 +3640,467 @@ func packScanningResult(data string) (string, error) {
 type repositoryEnvironmentReviewer struct {
 	Login string `mapstructure:"login"`
 }
+
+const gitHubMarkReadyForReviewMutation = `mutation($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) { pullRequest { isDraft } }
+}`
+
+const gitHubEnableAutoMergeMutation = `mutation($id: ID!, $mergeMethod: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $mergeMethod}) { pullRequest { number } }
+}`
+
+const gitHubDisableAutoMergeMutation = `mutation($id: ID!) {
+  disablePullRequestAutoMerge(input: {pullRequestId: $id}) { pullRequest { number } }
+}`
+
+const gitHubAddReviewThreadMutation = `mutation($id: ID!, $body: String!, $path: String!, $line: Int!, $startLine: Int, $startSide: DiffSide) {
+  addPullRequestReviewThread(input: {pullRequestId: $id, body: $body, path: $path, line: $line, side: RIGHT, startLine: $startLine, startSide: $startSide}) {
+    thread { id }
+  }
+}`
+
+const gitHubResolveReviewThreadMutation = `mutation($id: ID!) {
+  resolveReviewThread(input: {threadId: $id}) { thread { isResolved } }
+}`
+
+const gitHubUnresolveReviewThreadMutation = `mutation($id: ID!) {
+  unresolveReviewThread(input: {threadId: $id}) { thread { isResolved } }
+}`
+
+// The REST states of the pull requests, files, commit statuses and check runs are the lowercase GraphQL states,
+// so the GraphQL response is mapped using the REST mappers
+const gitHubPullRequestDetailsQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      number title body state isDraft mergeable
+      author { login }
+      headRefName headRepository { name owner { login } }
+      baseRefName baseRepository { name owner { login } }
+      labels(first: 100) { pageInfo { hasNextPage } nodes { name } }
+      files(first: 100) { pageInfo { hasNextPage } nodes { path additions deletions changeType } }
+      reviews(first: 100) { pageInfo { hasNextPage } nodes { state author { login } } }
+      commits(last: 1) {
+        nodes {
+          commit {
+            oid message url committedDate author { name } committer { name } parents(first: 10) { nodes { oid } }
+            statusCheckRollup {
+              contexts(first: 100) {
+                pageInfo { hasNextPage }
+                nodes {
+                  __typename
+                  ... on StatusContext { context state description targetUrl createdAt }
+                  ... on CheckRun { name status conclusion title detailsUrl startedAt completedAt }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const gitHubBlameQuery = `query($owner: String!, $name: String!, $ref: String!, $path: String!) {
+  repository(owner: $owner, name: $name) {
+    object(expression: $ref) {
+      ... on Commit {
+        blame(path: $path) {
+          ranges { startingLine endingLine commit { oid authoredDate author { name email } } }
+        }
+      }
+    }
+  }
+}`
+
+const gitHubCommitSignatureQuery = `query($owner: String!, $name: String!, $ref: String!) {
+  repository(owner: $owner, name: $name) {
+    object(expression: $ref) {
+      ... on Commit {
+        signature { __typename state email signer { login name } }
+      }
+    }
+  }
+}`
+
+type gitHubAddReviewThreadResponse struct {
+	AddPullRequestReviewThread struct {
+		Thread struct {
+			ID string `json:"id"`
+		} `json:"thread"`
+	} `json:"addPullRequestReviewThread"`
+}
+
+type gitHubPullRequestDetailsResponse struct {
+	Repository *struct {
+		PullRequest *gitHubGraphQLPullRequest `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+type gitHubGraphQLPullRequest struct {
+	Number         int                    `json:"number"`
+	Title          string                 `json:"title"`
+	Body           string                 `json:"body"`
+	State          string                 `json:"state"`
+	IsDraft        bool                   `json:"isDraft"`
+	Mergeable      string                 `json:"mergeable"`
+	Author         *gitHubGraphQLActor    `json:"author"`
+	HeadRefName    string                 `json:"headRefName"`
+	HeadRepository *gitHubGraphQLRepo     `json:"headRepository"`
+	BaseRefName    string                 `json:"baseRefName"`
+	BaseRepository *gitHubGraphQLRepo     `json:"baseRepository"`
+	Labels         gitHubGraphQLLabels    `json:"labels"`
+	Files          gitHubGraphQLFileNodes `json:"files"`
+	Reviews        struct {
+		PageInfo gitHubGraphQLPageInfo `json:"pageInfo"`
+		Nodes    []struct {
+			State  string              `json:"state"`
+			Author *gitHubGraphQLActor `json:"author"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+	Commits struct {
+		Nodes []struct {
+			Commit gitHubGraphQLCommit `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// hasMoreStatusContexts checks whether the head commit has more commit statuses and check runs than the query read
+func (pullRequest *gitHubGraphQLPullRequest) hasMoreStatusContexts() bool {
+	if len(pullRequest.Commits.Nodes) == 0 {
+		return false
+	}
+	rollup := pullRequest.Commits.Nodes[0].Commit.StatusCheckRollup
+	return rollup != nil && rollup.Contexts.PageInfo.HasNextPage
+}
+
+type gitHubGraphQLPageInfo struct {
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+type gitHubGraphQLActor struct {
+	Login string `json:"login"`
+}
+
+type gitHubGraphQLRepo struct {
+	Name  string             `json:"name"`
+	Owner gitHubGraphQLActor `json:"owner"`
+}
+
+type gitHubGraphQLLabels struct {
+	PageInfo gitHubGraphQLPageInfo `json:"pageInfo"`
+	Nodes    []struct {
+		Name string `json:"name"`
+	} `json:"nodes"`
+}
+
+type gitHubGraphQLFileNodes struct {
+	PageInfo gitHubGraphQLPageInfo `json:"pageInfo"`
+	Nodes    []struct {
+		Path       string `json:"path"`
+		Additions  int    `json:"additions"`
+		Deletions  int    `json:"deletions"`
+		ChangeType string `json:"changeType"`
+	} `json:"nodes"`
+}
+
+type gitHubGraphQLCommit struct {
+	Oid           string    `json:"oid"`
+	Message       string    `json:"message"`
+	URL           string    `json:"url"`
+	CommittedDate time.Time `json:"committedDate"`
+	Author        struct {
+		Name string `json:"name"`
+	} `json:"author"`
+	Committer struct {
+		Name string `json:"name"`
+	} `json:"committer"`
+	Parents struct {
+		Nodes []struct {
+			Oid string `json:"oid"`
+		} `json:"nodes"`
+	} `json:"parents"`
+	StatusCheckRollup *struct {
+		Contexts struct {
+			PageInfo gitHubGraphQLPageInfo        `json:"pageInfo"`
+			Nodes    []gitHubGraphQLStatusContext `json:"nodes"`
+		} `json:"contexts"`
+	} `json:"statusCheckRollup"`
+}
+
+// gitHubGraphQLStatusContext is either a commit status or a check run, according to its type name
+type gitHubGraphQLStatusContext struct {
+	Typename string `json:"__typename"`
+	// Commit status fields
+	Context     string     `json:"context"`
+	State       string     `json:"state"`
+	Description string     `json:"description"`
+	TargetURL   string     `json:"targetUrl"`
+	CreatedAt   *time.Time `json:"createdAt"`
+	// Check run fields
+	Name        string            `json:"name"`
+	Status      string            `json:"status"`
+	Conclusion  string            `json:"conclusion"`
+	Title       string            `json:"title"`
+	DetailsURL  string            `json:"detailsUrl"`
+	StartedAt   *github.Timestamp `json:"startedAt"`
+	CompletedAt *github.Timestamp `json:"completedAt"`
+}
+
+type gitHubCommitSignatureResponse struct {
+	Repository *struct {
+		Object *struct {
+			Signature *struct {
+				Typename string `json:"__typename"`
+				State    string `json:"state"`
+				Email    string `json:"email"`
+				Signer   *struct {
+					Login string `json:"login"`
+					Name  string `json:"name"`
+				} `json:"signer"`
+			} `json:"signature"`
+		} `json:"object"`
+	} `json:"repository"`
+}
+
+type gitHubBlameResponse struct {
+	Repository *struct {
+		Object *struct {
+			Blame struct {
+				Ranges []gitHubBlameRange `json:"ranges"`
+			} `json:"blame"`
+		} `json:"object"`
+	} `json:"repository"`
+}
+
+type gitHubBlameRange struct {
+	StartingLine int `json:"startingLine"`
+	EndingLine   int `json:"endingLine"`
+	Commit       struct {
+		Oid          string    `json:"oid"`
+		AuthoredDate time.Time `json:"authoredDate"`
+		Author       struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+type gitHubGraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type gitHubGraphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func getGitHubMergeMethod(mergeStrategy MergeStrategy) string {
+	switch mergeStrategy {
+	case SquashMerge:
+		return "squash"
+	case RebaseMerge:
+		return "rebase"
+	}
+	return "merge"
+}
+
+func getGitHubFileStatus(status string) FileStatus {
+	switch status {
+	case "added", "copied":
+		return FileAdded
+	case "removed":
+		return FileDeleted
+	case "renamed":
+		return FileRenamed
+	}
+	return FileModified
+}
+
+func mapGitHubIssueToIssueInfo(issue *github.Issue) IssueInfo {
+	state := IssueOpen
+	if issue.GetState() == "closed" {
+		state = IssueClosed
+	}
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, label.GetName())
+	}
+	return IssueInfo{
+		ID:      int64(issue.GetNumber()),
+		Title:   issue.GetTitle(),
+		Body:    issue.GetBody(),
+		State:   state,
+		Author:  issue.GetUser().GetLogin(),
+		Labels:  labels,
+		URL:     issue.GetHTMLURL(),
+		Created: issue.GetCreatedAt(),
+	}
+}
+
+func mapIssueStateToGitHubState(state IssueState) string {
+	if state == IssueClosed {
+		return "closed"
+	}
+	return "open"
+}
+
+func mapGitHubDependabotAlertToSecurityAlertInfo(alert gitHubDependabotAlert) SecurityAlertInfo {
+	identifier := alert.SecurityAdvisory.CveID
+	if identifier == "" {
+		identifier = alert.SecurityAdvisory.GhsaID
+	}
+	return SecurityAlertInfo{
+		ID:         alert.Number,
+		Type:       DependencyAlert,
+		Severity:   getSecuritySeverity(alert.SecurityAdvisory.Severity),
+		Title:      alert.SecurityAdvisory.Summary,
+		Identifier: identifier,
+		Package:    alert.Dependency.Package.Name,
+		Path:       alert.Dependency.ManifestPath,
+		URL:        alert.HTMLURL,
+	}
+}
+
+func mapGitHubCodeScanningAlertToSecurityAlertInfo(alert *github.Alert) SecurityAlertInfo {
+	return SecurityAlertInfo{
+		ID:         int64(alert.GetNumber()),
+		Type:       CodeScanningAlert,
+		Severity:   getGitHubCodeScanningSeverity(alert.GetRule()),
+		Title:      alert.GetRule().GetDescription(),
+		Identifier: alert.GetRule().GetID(),
+		Path:       alert.GetMostRecentInstance().GetLocation().GetPath(),
+		URL:        alert.GetHTMLURL(),
+	}
+}
+
+// Security rules have a security severity, while the other rules only have the error, warning or note level of their results
+func getGitHubCodeScanningSeverity(rule *github.Rule) SecuritySeverity {
+	if rule.GetSecuritySeverityLevel() != "" {
+		return getSecuritySeverity(rule.GetSecuritySeverityLevel())
+	}
+	switch rule.GetSeverity() {
+	case "error":
+		return SeverityHigh
+	case "warning":
+		return SeverityMedium
+	case "note":
+		return SeverityInfo
+	}
+	return SeverityUnknown
+}
+
+func getGitHubReviewState(state string) (ReviewEvent, bool) {
+	switch state {
+	case "APPROVED":
+		return ReviewApprove, true
+	case "CHANGES_REQUESTED":
+		return ReviewRequestChanges, true
+	case "COMMENTED":
+		return ReviewComment, true
+	}
+	return ReviewComment, false
+}
+
+// The GraphQL change types of the files are the uppercase REST statuses, except for removed files
+var gitHubGraphQLFileStatuses = map[string]string{"DELETED": "removed"}
+
+func mapGitHubGraphQLPullRequestToPullRequestDetails(pullRequest *gitHubGraphQLPullRequest) PullRequestDetails {
+	restPullRequest := &github.PullRequest{
+		Number: github.Int(pullRequest.Number),
+		Title:  github.String(pullRequest.Title),
+		Body:   github.String(pullRequest.Body),
+		State:  github.String(strings.ToLower(pullRequest.State)),
+		Merged: github.Bool(pullRequest.State == "MERGED"),
+		Draft:  github.Bool(pullRequest.IsDraft),
+		Head:   mapGitHubGraphQLPullRequestBranch(pullRequest.HeadRefName, pullRequest.HeadRepository),
+		Base:   mapGitHubGraphQLPullRequestBranch(pullRequest.BaseRefName, pullRequest.BaseRepository),
+	}
+	if pullRequest.Mergeable != "UNKNOWN" {
+		restPullRequest.Mergeable = github.Bool(pullRequest.Mergeable == "MERGEABLE")
+	}
+	if pullRequest.Author != nil {
+		restPullRequest.User = &github.User{Login: github.String(pullRequest.Author.Login)}
+	}
+	for _, label := range pullRequest.Labels.Nodes {
+		restPullRequest.Labels = append(restPullRequest.Labels, &github.Label{Name: github.String(label.Name)})
+	}
+	details := PullRequestDetails{PullRequestInfo: mapGitHubPullRequestToPullRequestInfo(restPullRequest)}
+	for _, file := range pullRequest.Files.Nodes {
+		status, exists := gitHubGraphQLFileStatuses[file.ChangeType]
+		if !exists {
+			status = strings.ToLower(file.ChangeType)
+		}
+		details.Files = append(details.Files, mapGitHubCommitFileToPullRequestFile(&github.CommitFile{
+			Filename: github.String(file.Path), Status: github.String(status), Additions: github.Int(file.Additions), Deletions: github.Int(file.Deletions),
+		}))
+	}
+	for _, review := range pullRequest.Reviews.Nodes {
+		if state, submitted := getGitHubReviewState(review.State); submitted {
+			reviewInfo := PullRequestReviewInfo{State: state}
+			if review.Author != nil {
+				reviewInfo.Reviewer = review.Author.Login
+			}
+			details.Reviews = append(details.Reviews, reviewInfo)
+		}
+	}
+	if len(pullRequest.Commits.Nodes) > 0 {
+		details.HeadCommit, details.Statuses = mapGitHubGraphQLCommit(pullRequest.Commits.Nodes[0].Commit)
+	}
+	return details
+}
+
+// The head repository is nil if the fork of the pull request was deleted
+func mapGitHubGraphQLPullRequestBranch(ref string, repository *gitHubGraphQLRepo) *github.PullRequestBranch {
+	branch := &github.PullRequestBranch{Ref: github.String(ref)}
+	if repository != nil {
+		branch.Repo = &github.Repository{Name: github.String(repository.Name), Owner: &github.User{Login: github.String(repository.Owner.Login)}}
+	}
+	return branch
+}
+
+func mapGitHubGraphQLCommit(commit gitHubGraphQLCommit) (CommitInfo, []CommitStatusInfo) {
+	restCommit := &github.RepositoryCommit{
+		SHA: github.String(commit.Oid),
+		URL: github.String(commit.URL),
+		Commit: &github.Commit{
+			Message:   github.String(commit.Message),
+			Author:    &github.CommitAuthor{Name: github.String(commit.Author.Name)},
+			Committer: &github.CommitAuthor{Name: github.String(commit.Committer.Name), Date: &commit.CommittedDate},
+		},
+	}
+	for _, parent := range commit.Parents.Nodes {
+		restCommit.Parents = append(restCommit.Parents, &github.Commit{SHA: github.String(parent.Oid)})
+	}
+	var statuses []CommitStatusInfo
+	if commit.StatusCheckRollup != nil {
+		for _, statusContext := range commit.StatusCheckRollup.Contexts.Nodes {
+			statuses = append(statuses, mapGitHubGraphQLStatusContext(statusContext))
+		}
+	}
+	return mapGitHubCommitToCommitInfo(restCommit), statuses
+}
+
+func mapGitHubGraphQLStatusContext(statusContext gitHubGraphQLStatusContext) CommitStatusInfo {
+	if statusContext.Typename == "CheckRun" {
+		return mapGitHubCheckRunToCommitStatusInfo(&github.CheckRun{
+			Name:        github.String(statusContext.Name),
+			Status:      github.String(strings.ToLower(statusContext.Status)),
+			Conclusion:  github.String(strings.ToLower(statusContext.Conclusion)),
+			Output:      &github.CheckRunOutput{Title: github.String(statusContext.Title)},
+			DetailsURL:  github.String(statusContext.DetailsURL),
+			StartedAt:   statusContext.StartedAt,
+			CompletedAt: statusContext.CompletedAt,
+		})
+	}
+	return mapGitHubRepoStatusToCommitStatusInfo(&github.RepoStatus{
+		Context:     github.String(statusContext.Context),
+		State:       github.String(strings.ToLower(statusContext.State)),
+		Description: github.String(statusContext.Description),
+		TargetURL:   github.String(statusContext.TargetURL),
+		CreatedAt:   statusContext.CreatedAt,
+		UpdatedAt:   statusContext.CreatedAt,
+	})
+}
@@ -8,12 +8,14 @@ import (
 	"math"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -58,6 +60,20 @@ func TestGitHubClient_ConnectionWhenContextTimesOut(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestGitHubClient_GetRateLimitInfo(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"resources":{"core":{"limit":5000,"remaining":4990,"reset":1700000000}}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/rate_limit", createGitHubHandler)
+	defer cleanUp()
+
+	rateLimitInfo, err := client.GetRateLimitInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitInfo{Limit: 5000, Remaining: 4990, Reset: time.Unix(1700000000, 0)}, rateLimitInfo)
+
+	_, err = createBadGitHubClient(t).GetRateLimitInfo(ctx)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ListRepositories(t *testing.T) {
 	ctx := context.Background()
 	expectedRepo1 := github.Repository{Name: &repo1, Owner: &github.User{Login: &username}}
@@ -107,11 +123,103 @@ func TestGitHubClient_ListRepositoriesWithPagination(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.RequestURI {
+		case "/users/jfrog":
+			_, err := w.Write([]byte(`{"login":"jfrog","type":"Organization"}`))
+			assert.NoError(t, err)
+		case "/orgs/jfrog/repos?direction=desc&page=1&per_page=3&sort=updated":
+			w.Header().Set("Link", `<https://api.github.com/orgs/jfrog/repos?page=2>; rel="next"`)
+			_, err := w.Write([]byte(`[
+				{"name":"repo-1","owner":{"login":"jfrog"},"visibility":"public","language":"Go","default_branch":"main","updated_at":"2023-03-01T00:00:00Z"},
+				{"name":"repo-2","owner":{"login":"jfrog"},"visibility":"private","language":"Java","updated_at":"2023-02-01T00:00:00Z"},
+				{"name":"repo-3","owner":{"login":"jfrog"},"visibility":"public","language":"Go","updated_at":"2022-12-01T00:00:00Z"}
+			]`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	// The second page isn't fetched, since the last repository of the first page was updated before UpdatedSince
+	result, err := client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{
+		Language:     "go",
+		UpdatedSince: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		PerPage:      3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryDetails{{
+		Owner:          owner,
+		Repository:     repo1,
+		RepositoryInfo: RepositoryInfo{RepositoryVisibility: Public, DefaultBranch: "main"},
+		Language:       "Go",
+		Updated:        time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+	}}, result)
+
+	_, err = createBadGitHubClient(t).ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListRepositoriesWithOptionsOfAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/users/frogger":
+			_, err := w.Write([]byte(`{"login":"frogger","type":"User"}`))
+			assert.NoError(t, err)
+		case "/user":
+			_, err := w.Write([]byte(`{"login":"frogger","type":"User"}`))
+			assert.NoError(t, err)
+		case "/user/repos?affiliation=owner&page=2":
+			_, err := w.Write([]byte(`[
+				{"name":"repo-1","owner":{"login":"frogger"},"visibility":"private","archived":true},
+				{"name":"repo-2","owner":{"login":"frogger"},"visibility":"private"}
+			]`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	archived := false
+	result, err := client.ListRepositoriesWithOptions(ctx, username, ListRepositoriesOptions{Archived: &archived, Page: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryDetails{{
+		Owner:          username,
+		Repository:     repo2,
+		RepositoryInfo: RepositoryInfo{RepositoryVisibility: Private},
+	}}, result)
+}
+
 func TestGitHubClient_ListBranches(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []github.Branch{{Name: &branch1}, {Name: &branch2}}, fmt.Sprintf("/repos/jfrog/%s/branches", repo1), createGitHubHandler)
-	defer cleanUp()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var branches []github.Branch
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/branches?page=1&per_page=100":
+			w.Header().Add("Link", fmt.Sprintf("<%s/repos/jfrog/repo-1/branches?page=2&per_page=100>; rel=\"next\"", "http://"+r.Host))
+			branches = []github.Branch{{Name: &branch1}}
+		case "/repos/jfrog/repo-1/branches?page=2&per_page=100":
+			branches = []github.Branch{{Name: &branch2}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(branches)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
 
+	// All the pages are fetched
 	actualBranches, err := client.ListBranches(ctx, owner, repo1)
 	assert.NoError(t, err)
 	assert.ElementsMatch(t, actualBranches, []string{branch1, branch2})
@@ -120,6 +228,389 @@ func TestGitHubClient_ListBranches(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListBranchesPager(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var branches []github.Branch
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/branches?page=1&per_page=1":
+			w.Header().Add("Link", fmt.Sprintf("<%s/repos/jfrog/repo-1/branches?page=2&per_page=1>; rel=\"next\"", "http://"+r.Host))
+			branches = []github.Branch{{Name: &branch1}}
+		case "/repos/jfrog/repo-1/branches?page=2&per_page=1":
+			branches = []github.Branch{{Name: &branch2}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(branches)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	pager := buildClient(t, vcsutils.GitHub, false, server).ListBranchesPager(owner, repo1, 1)
+
+	page, err := pager.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{branch1}, page)
+	assert.True(t, pager.HasNext())
+	page, err = pager.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{branch2}, page)
+	assert.False(t, pager.HasNext())
+
+	_, err = createBadGitHubClient(t).ListBranchesPager(owner, repo1, 1).Next(ctx)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListAllBranches(t *testing.T) {
+	ctx := context.Background()
+	featureBranch := "feature"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var branches []github.Branch
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/branches?page=1&per_page=100":
+			w.Header().Add("Link", fmt.Sprintf("<%s/repos/jfrog/repo-1/branches?page=2&per_page=100>; rel=\"next\"", "http://"+r.Host))
+			branches = []github.Branch{{Name: &branch1, Commit: &github.RepositoryCommit{SHA: github.String("sha1")}}, {Name: &featureBranch}}
+		case "/repos/jfrog/repo-1/branches?page=2&per_page=100":
+			branches = []github.Branch{{Name: &branch2, Commit: &github.RepositoryCommit{SHA: github.String("sha2")}}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(branches)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	actualBranches, err := client.ListAllBranches(ctx, owner, repo1, "branch-")
+	assert.NoError(t, err)
+	assert.Equal(t, []BranchDetails{{Name: branch1, CommitHash: "sha1"}, {Name: branch2, CommitHash: "sha2"}}, actualBranches)
+
+	actualBranches, err = client.ListAllBranches(ctx, owner, repo1, "")
+	assert.NoError(t, err)
+	assert.Len(t, actualBranches, 3)
+
+	_, err = createBadGitHubClient(t).ListAllBranches(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateBranch(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/commits/master":
+			_, err := w.Write([]byte(sha))
+			assert.NoError(t, err)
+		case "/repos/jfrog/repo-1/git/refs":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"ref":"refs/heads/branch-1","sha":"`+sha+`"}`+"\n", string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"ref":"refs/heads/branch-1"}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.CreateBranch(ctx, owner, repo1, branch1, "master")
+	assert.NoError(t, err)
+
+	err = client.CreateBranch(ctx, owner, repo1, branch1, "")
+	assert.Error(t, err)
+
+	err = createBadGitHubClient(t).CreateBranch(ctx, owner, repo1, branch1, "master")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte{},
+		"/repos/jfrog/repo-1/git/refs/heads/branch-1", http.StatusNoContent, []byte{}, http.MethodDelete, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).DeleteBranch(ctx, owner, repo1, branch1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListTags(t *testing.T) {
+	ctx := context.Background()
+	response := []github.RepositoryTag{
+		{Name: github.String("v1.0.0"), Commit: &github.Commit{SHA: github.String("sha1")}},
+		{Name: github.String("v1.1.0"), Commit: &github.Commit{SHA: github.String("sha2")}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/tags?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitHash: "sha1"}, {Name: "v1.1.0", CommitHash: "sha2"}}, tags)
+
+	_, err = createBadGitHubClient(t).ListTags(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	tagSha := "940bd336248efae0f9ee5bc7b2d5c985887b16ac"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/git/tags":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, `{"tag":"v1.0.0","message":"Release 1.0.0","object":"`+sha+`","type":"commit"}`+"\n", string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"sha":"` + tagSha + `"}`))
+			assert.NoError(t, err)
+		case "/repos/jfrog/repo-1/git/refs":
+			assert.Equal(t, http.MethodPost, r.Method)
+			// The annotated tag points to the tag object, and the lightweight tag points to the commit
+			assert.Contains(t, []string{
+				`{"ref":"refs/tags/v1.0.0","sha":"` + tagSha + `"}` + "\n",
+				`{"ref":"refs/tags/v1.0.1","sha":"` + sha + `"}` + "\n",
+			}, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.CreateTag(ctx, owner, repo1, "v1.0.0", sha, "Release 1.0.0")
+	assert.NoError(t, err)
+	err = client.CreateTag(ctx, owner, repo1, "v1.0.1", sha, "")
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).CreateTag(ctx, owner, repo1, "v1.0.0", sha, "")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateRelease(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.RepositoryRelease{},
+		"/repos/jfrog/repo-1/releases", http.StatusCreated,
+		[]byte(`{"tag_name":"v1.0.0","target_commitish":"master","name":"Release 1.0.0","body":"Bug fixes"}`+"\n"), http.MethodPost,
+		createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0", Target: "master", Name: "Release 1.0.0", Description: "Bug fixes"})
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0"})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetLatestRelease(t *testing.T) {
+	ctx := context.Background()
+	response := github.RepositoryRelease{
+		TagName:         github.String("v1.0.0"),
+		TargetCommitish: github.String("master"),
+		Name:            github.String("Release 1.0.0"),
+		Body:            github.String("Bug fixes"),
+		HTMLURL:         github.String("https://github.com/jfrog/repo-1/releases/tag/v1.0.0"),
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/releases/latest", createGitHubHandler)
+	defer cleanUp()
+
+	release, err := client.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, &ReleaseInfo{
+		TagName:     "v1.0.0",
+		Target:      "master",
+		Name:        "Release 1.0.0",
+		Description: "Bug fixes",
+		Url:         "https://github.com/jfrog/repo-1/releases/tag/v1.0.0",
+	}, release)
+
+	noReleasesClient, noReleasesCleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false, nil,
+		"/repos/jfrog/repo-1/releases/latest", http.StatusNotFound, createGitHubHandler)
+	defer noReleasesCleanUp()
+	release, err = noReleasesClient.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Nil(t, release)
+
+	_, err = createBadGitHubClient(t).GetLatestRelease(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_UploadReleaseAsset(t *testing.T) {
+	ctx := context.Background()
+	assetPath := filepath.Join(t.TempDir(), "asset.zip")
+	require.NoError(t, os.WriteFile(assetPath, []byte("asset content"), 0600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/releases/tags/v1.0.0":
+			_, err := w.Write([]byte(`{"id":1,"upload_url":"http://` + r.Host + `/repos/jfrog/repo-1/releases/1/assets{?name,label}"}`))
+			assert.NoError(t, err)
+		case "/repos/jfrog/repo-1/releases/1/assets?name=asset.zip":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "asset content", string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"id":1,"name":"asset.zip"}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", assetPath)
+	assert.NoError(t, err)
+
+	err = client.UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", filepath.Join(t.TempDir(), "missing.zip"))
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	response := github.Protection{
+		RequiredStatusChecks:       &github.RequiredStatusChecks{Contexts: []string{"build", "test"}},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2},
+		Restrictions:               &github.BranchRestrictions{Users: []*github.User{{Login: github.String("frogger")}}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/branches/master/protection", createGitHubHandler)
+	defer cleanUp()
+
+	protection, err := client.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, &BranchProtection{
+		RequiredApprovingReviewCount: 2,
+		RequiredStatusChecks:         []string{"build", "test"},
+		RestrictPushes:               true,
+		AllowedPushUsers:             []string{"frogger"},
+	}, protection)
+
+	notProtectedClient, notProtectedCleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false,
+		github.ErrorResponse{Message: "Branch not protected"}, "/repos/jfrog/repo-1/branches/master/protection", http.StatusNotFound, createGitHubHandler)
+	defer notProtectedCleanUp()
+	protection, err = notProtectedClient.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Nil(t, protection)
+
+	_, err = createBadGitHubClient(t).GetBranchProtection(ctx, owner, repo1, "master")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := `{"required_status_checks":{"strict":false,"contexts":["build"]},` +
+		`"required_pull_request_reviews":{"dismiss_stale_reviews":false,"require_code_owner_reviews":false,"required_approving_review_count":1},` +
+		`"enforce_admins":false,"restrictions":{"users":["frogger"],"teams":[]}}` + "\n"
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.Protection{},
+		"/repos/jfrog/repo-1/branches/master/protection", http.StatusOK, []byte(expectedBody), http.MethodPut, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{
+		RequiredApprovingReviewCount: 1,
+		RequiredStatusChecks:         []string{"build"},
+		RestrictPushes:               true,
+		AllowedPushUsers:             []string{"frogger"},
+	})
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_TagProtection(t *testing.T) {
+	ctx := context.Background()
+	var created []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/tags/protection", r.RequestURI)
+		if r.Method == http.MethodPost {
+			var rule gitHubTagProtectionRule
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&rule))
+			created = append(created, rule.Pattern)
+		}
+		_, err := w.Write([]byte(`[{"id":1,"pattern":"v*"}]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	protection, err := client.GetTagProtection(ctx, owner, repo1, "v*")
+	require.NoError(t, err)
+	assert.Equal(t, &TagProtection{}, protection)
+	protection, err = client.GetTagProtection(ctx, owner, repo1, "release-*")
+	require.NoError(t, err)
+	assert.Nil(t, protection)
+
+	// A rule is created only if the pattern isn't protected
+	require.NoError(t, client.SetTagProtection(ctx, owner, repo1, "v*", TagProtection{}))
+	require.NoError(t, client.SetTagProtection(ctx, owner, repo1, "release-*", TagProtection{}))
+	assert.Equal(t, []string{"release-*"}, created)
+
+	err = client.SetTagProtection(ctx, owner, repo1, "v*", TagProtection{AllowedUsers: []string{"release-bot"}})
+	assert.ErrorIs(t, err, errGitHubTagProtectionUsersNotSupported)
+	_, err = createBadGitHubClient(t).GetTagProtection(ctx, owner, repo1, "v*")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetRequiredChecks(t *testing.T) {
+	ctx := context.Background()
+	response := github.RequiredStatusChecks{Strict: true, Checks: []*github.RequiredStatusCheck{{Context: "build"}, {Context: "frogbot"}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		"/repos/jfrog/repo-1/branches/master/protection/required_status_checks", createGitHubHandler)
+	defer cleanUp()
+
+	requiredChecks, err := client.GetRequiredChecks(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredChecksInfo{Titles: []string{"build", "frogbot"}, RequireUpToDate: true}, requiredChecks)
+
+	notProtectedClient, notProtectedCleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false,
+		github.ErrorResponse{Message: "Required status checks not enabled"}, "/repos/jfrog/repo-1/branches/master/protection/required_status_checks",
+		http.StatusNotFound, createGitHubHandler)
+	defer notProtectedCleanUp()
+	requiredChecks, err = notProtectedClient.GetRequiredChecks(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredChecksInfo{}, requiredChecks)
+
+	_, err = createBadGitHubClient(t).GetRequiredChecks(ctx, owner, repo1, "master")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetRequiredApprovals(t *testing.T) {
+	ctx := context.Background()
+	response := github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2, RequireCodeOwnerReviews: true, DismissStaleReviews: true}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		"/repos/jfrog/repo-1/branches/master/protection/required_pull_request_reviews", createGitHubHandler)
+	defer cleanUp()
+
+	requiredApprovals, err := client.GetRequiredApprovals(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredApprovalsInfo{Count: 2, RequireCodeOwners: true, DismissStaleApprovals: true}, requiredApprovals)
+
+	notProtectedClient, notProtectedCleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false,
+		github.ErrorResponse{Message: "Branch not protected"}, "/repos/jfrog/repo-1/branches/master/protection/required_pull_request_reviews",
+		http.StatusNotFound, createGitHubHandler)
+	defer notProtectedCleanUp()
+	requiredApprovals, err = notProtectedClient.GetRequiredApprovals(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredApprovalsInfo{}, requiredApprovals)
+
+	_, err = createBadGitHubClient(t).GetRequiredApprovals(ctx, owner, repo1, "master")
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int63()
@@ -135,6 +626,52 @@ func TestGitHubClient_CreateWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_CreateWebhookWithOptions(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/hooks", r.RequestURI)
+		assert.Equal(t, http.MethodPost, r.Method)
+		var hook github.Hook
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&hook))
+		assert.ElementsMatch(t, []string{"pull_request", "push"}, hook.Events)
+		assert.Equal(t, "form", hook.Config["content_type"])
+		assert.Equal(t, "1", hook.Config["insecure_ssl"])
+		assert.NotEmpty(t, hook.Config["secret"])
+		w.WriteHeader(http.StatusCreated)
+		_, err := w.Write([]byte(`{"id":1,"events":["pull_request","push"],"active":true,` +
+			`"config":{"url":"https://jfrog.com","content_type":"form","insecure_ssl":"1"}}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	options := WebhookOptions{
+		PayloadURL:  "https://jfrog.com",
+		Events:      []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.Push},
+		ContentType: WebhookContentTypeForm,
+		InsecureSSL: true,
+	}
+	webhook, token, err := client.CreateWebhookWithOptions(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{
+		ID:          "1",
+		PayloadURL:  "https://jfrog.com",
+		Events:      []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.Push},
+		Active:      true,
+		ContentType: WebhookContentTypeForm,
+		InsecureSSL: true,
+	}, webhook)
+
+	_, _, err = client.CreateWebhookWithOptions(ctx, owner, repo1, WebhookOptions{PayloadURL: "https://jfrog.com"})
+	assert.Error(t, err)
+	_, _, err = client.CreateWebhookWithOptions(ctx, owner, repo1, WebhookOptions{PayloadURL: "https://jfrog.com",
+		Events: []vcsutils.WebhookEvent{vcsutils.Push}, ContentType: "xml"})
+	assert.Error(t, err)
+	_, _, err = createBadGitHubClient(t).CreateWebhookWithOptions(ctx, owner, repo1, options)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_UpdateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int63()
@@ -163,95 +700,835 @@ func TestGitHubClient_DeleteWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":1,"events":["pull_request","push"],"active":true,"config":{"url":"https://jfrog.com/hook","content_type":"json"}},` +
+		`{"id":2,"events":["push"],"active":false,"config":{"url":"https://jfrog.com/push","content_type":"form","insecure_ssl":"1"}}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/jfrog/%s/hooks?page=1&per_page=100", repo1), createGitHubHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{
+		{ID: "1", PayloadURL: "https://jfrog.com/hook", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.Push}, Active: true, ContentType: WebhookContentTypeJSON},
+		{ID: "2", PayloadURL: "https://jfrog.com/push", Events: []vcsutils.WebhookEvent{vcsutils.Push}, ContentType: WebhookContentTypeForm, InsecureSSL: true},
+	}, webhooks)
+
+	_, err = createBadGitHubClient(t).ListWebhooks(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_SetWebhookActive(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte(`{"id":1,"active":false}`),
+		fmt.Sprintf("/repos/jfrog/%s/hooks/1", repo1), http.StatusOK, []byte("{\"active\":false}\n"), http.MethodPatch,
+		createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.SetWebhookActive(ctx, owner, repo1, "1", false)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).SetWebhookActive(ctx, owner, repo1, "1", false)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id":1,"events":["push"],"active":true,"config":{"url":"https://jfrog.com","content_type":"json","insecure_ssl":"0"}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/orgs/jfrog/hooks", createGitHubHandler)
+	defer cleanUp()
+
+	webhook, token, err := client.CreateOrgWebhook(ctx, owner, WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON}, webhook)
+
+	_, _, err = createBadGitHubClient(t).CreateOrgWebhook(ctx, owner, WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListOrgWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":1,"events":["pull_request"],"active":true,"config":{"url":"https://jfrog.com","content_type":"json"}}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/orgs/jfrog/hooks?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListOrgWebhooks(ctx, owner)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected},
+		Active: true, ContentType: WebhookContentTypeJSON}}, webhooks)
+
+	_, err = createBadGitHubClient(t).ListOrgWebhooks(ctx, owner)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DeleteOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "/orgs/jfrog/hooks/1", createGitHubHandler)
+	defer cleanUp()
+
+	err := client.DeleteOrgWebhook(ctx, owner, "1")
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).DeleteOrgWebhook(ctx, owner, "1")
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_CreateCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	ref := "39e5418"
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.RepoStatus{}, fmt.Sprintf("/repos/jfrog/%s/statuses/%s", repo1, ref), createGitHubHandler)
 	defer cleanUp()
 
-	err := client.SetCommitStatus(ctx, Error, owner, repo1, ref, "Commit status title", "Commit status description",
-		"https://httpbin.org/anything")
+	err := client.SetCommitStatus(ctx, Error, owner, repo1, ref, "Commit status title", "Commit status description",
+		"https://httpbin.org/anything")
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).SetCommitStatus(ctx, Error, owner, repo1, ref, "Commit status title", "Commit status description",
+		"https://httpbin.org/anything")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_SetCommitStatuses(t *testing.T) {
+	ctx := context.Background()
+	ref := "39e5418"
+	var titles []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("/repos/jfrog/%s/statuses/%s", repo1, ref), r.RequestURI)
+		var status github.RepoStatus
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+		titles = append(titles, status.GetContext())
+		if status.GetContext() == "lint" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := buildClient(t, vcsutils.GitHub, false, server)
+	err := client.SetCommitStatuses(ctx, owner, repo1, ref, []CommitStatusInfo{
+		{State: Pass, Title: "build"},
+		{State: Fail, Title: "test", Description: "2 tests failed", DetailsURL: "https://ci.example.com/test"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"build", "test"}, titles)
+
+	// The statuses after a failed status aren't set
+	titles = nil
+	err = client.SetCommitStatuses(ctx, owner, repo1, ref, []CommitStatusInfo{{State: Pass, Title: "lint"}, {State: Pass, Title: "build"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `failed to set the commit status "lint"`)
+	assert.Equal(t, []string{"lint"}, titles)
+}
+
+func TestGitHubClient_ListCommitStatuses(t *testing.T) {
+	ctx := context.Background()
+	ref := "39e5418"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case fmt.Sprintf("/repos/jfrog/%s/commits/%s/status?page=1&per_page=100", repo1, ref):
+			response = `{"state":"failure","statuses":[
+				{"state":"success","context":"build","description":"Build passed","target_url":"https://ci.example.com/build","created_at":"2023-01-01T10:00:00Z","updated_at":"2023-01-01T10:05:00Z"},
+				{"state":"failure","context":"test","description":"2 tests failed","target_url":"https://ci.example.com/test","created_at":"2023-01-01T10:00:00Z","updated_at":"2023-01-01T10:10:00Z"}]}`
+		case fmt.Sprintf("/repos/jfrog/%s/commits/%s/check-runs?page=1&per_page=100", repo1, ref):
+			response = `{"total_count":2,"check_runs":[
+				{"name":"lint","status":"completed","conclusion":"neutral","details_url":"https://ci.example.com/lint","started_at":"2023-01-01T10:00:00Z","completed_at":"2023-01-01T10:01:00Z","output":{"title":"No issues"}},
+				{"name":"scan","status":"in_progress","started_at":"2023-01-01T10:02:00Z"}]}`
+		default:
+			assert.Fail(t, "unexpected request URI", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	statuses, err := buildClient(t, vcsutils.GitHub, false, server).ListCommitStatuses(ctx, owner, repo1, ref)
+	require.NoError(t, err)
+	assert.Equal(t, []CommitStatusInfo{
+		{State: Pass, Title: "build", Description: "Build passed", DetailsURL: "https://ci.example.com/build",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 5, 0, 0, time.UTC)},
+		{State: Fail, Title: "test", Description: "2 tests failed", DetailsURL: "https://ci.example.com/test",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 10, 0, 0, time.UTC)},
+		{State: Pass, Title: "lint", Description: "No issues", DetailsURL: "https://ci.example.com/lint",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 1, 0, 0, time.UTC)},
+		{State: InProgress, Title: "scan",
+			CreatedAt: time.Date(2023, 1, 1, 10, 2, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 2, 0, 0, time.UTC)},
+	}, statuses)
+
+	_, err = createBadGitHubClient(t).ListCommitStatuses(ctx, owner, repo1, ref)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CheckRuns(t *testing.T) {
+	ctx := context.Background()
+	ref := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	var requests []github.CreateCheckRunOptions
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/repos/jfrog/repo-1/check-runs", r.RequestURI)
+		case http.MethodPatch:
+			assert.Equal(t, "/repos/jfrog/repo-1/check-runs/42", r.RequestURI)
+		}
+		var request github.CreateCheckRunOptions
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		requests = append(requests, request)
+		_, err := w.Write([]byte(`{"id":42}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	checkRun := CheckRunInfo{Name: "xray", State: Fail, Title: "2 vulnerabilities found", Summary: "## Results", DetailsURL: "https://acme.jfrog.io/ui/scans"}
+	for i := 1; i <= 120; i++ {
+		checkRun.Annotations = append(checkRun.Annotations, CheckRunAnnotation{Path: "go.mod", StartLine: i, Level: AnnotationFailure, Message: "vulnerable dependency"})
+	}
+	checkRunID, err := client.CreateCheckRun(ctx, owner, repo1, ref, checkRun)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), checkRunID)
+
+	// The annotations that exceed the limit of a request are added by updating the check run
+	assert.Equal(t, []string{http.MethodPost, http.MethodPatch, http.MethodPatch}, methods)
+	created := requests[0]
+	assert.Equal(t, "xray", created.Name)
+	assert.Equal(t, ref, created.HeadSHA)
+	assert.Equal(t, "https://acme.jfrog.io/ui/scans", created.GetDetailsURL())
+	assert.Equal(t, "completed", created.GetStatus())
+	assert.Equal(t, "failure", created.GetConclusion())
+	assert.NotNil(t, created.CompletedAt)
+	assert.Equal(t, "2 vulnerabilities found", created.Output.GetTitle())
+	assert.Equal(t, "## Results", created.Output.GetSummary())
+	require.Len(t, created.Output.Annotations, 50)
+	assert.Equal(t, &github.CheckRunAnnotation{
+		Path:            github.String("go.mod"),
+		StartLine:       github.Int(1),
+		EndLine:         github.Int(1),
+		AnnotationLevel: github.String("failure"),
+		Message:         github.String("vulnerable dependency"),
+	}, created.Output.Annotations[0])
+	assert.Len(t, requests[1].Output.Annotations, 50)
+	assert.Equal(t, 51, requests[1].Output.Annotations[0].GetStartLine())
+	assert.Len(t, requests[2].Output.Annotations, 20)
+	assert.Nil(t, requests[2].Status)
+
+	requests, methods = nil, nil
+	err = client.UpdateCheckRun(ctx, owner, repo1, ref, checkRunID, CheckRunInfo{Name: "xray", State: InProgress})
+	require.NoError(t, err)
+	assert.Equal(t, []string{http.MethodPatch}, methods)
+	assert.Equal(t, "in_progress", requests[0].GetStatus())
+	assert.Nil(t, requests[0].Conclusion)
+	assert.Nil(t, requests[0].Output)
+
+	_, err = client.CreateCheckRun(ctx, owner, repo1, ref, CheckRunInfo{State: Pass})
+	assert.Error(t, err)
+	_, err = createBadGitHubClient(t).CreateCheckRun(ctx, owner, repo1, ref, checkRun)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_Pipelines(t *testing.T) {
+	ctx := context.Background()
+	dispatched := false
+	var event github.CreateWorkflowDispatchEventRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/actions/workflows/build.yml/runs?branch=branch-1&event=workflow_dispatch&per_page=1":
+			response = `{"total_count":1,"workflow_runs":[{"id":42,"head_branch":"branch-1","status":"completed","conclusion":"success"}]}`
+			if dispatched {
+				response = `{"total_count":2,"workflow_runs":[{"id":43,"head_branch":"branch-1","status":"queued","html_url":"https://github.com/jfrog/repo-1/actions/runs/43"}]}`
+			}
+		case "/repos/jfrog/repo-1/actions/workflows/build.yml/dispatches":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+			dispatched = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case "/repos/jfrog/repo-1/actions/runs/43":
+			response = `{"id":43,"head_branch":"branch-1","status":"completed","conclusion":"failure","html_url":"https://github.com/jfrog/repo-1/actions/runs/43"}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	// The dispatched run is the first run that is newer than the latest run before the dispatch
+	run, err := client.TriggerPipeline(ctx, owner, repo1, "build.yml", branch1, map[string]string{"environment": "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, PipelineInfo{ID: "43", Ref: branch1, State: InProgress, WebURL: "https://github.com/jfrog/repo-1/actions/runs/43"}, run)
+	assert.Equal(t, branch1, event.Ref)
+	assert.Equal(t, map[string]interface{}{"environment": "staging"}, event.Inputs)
+
+	run, err = client.GetPipelineStatus(ctx, owner, repo1, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, Fail, run.State)
+
+	_, err = client.TriggerPipeline(ctx, owner, repo1, "", branch1, nil)
+	assert.Error(t, err)
+	_, err = client.GetPipelineStatus(ctx, owner, repo1, "not-a-number")
+	assert.Error(t, err)
+	_, err = createBadGitHubClient(t).GetPipelineStatus(ctx, owner, repo1, "43")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_getRepositoryVisibility(t *testing.T) {
+	visibility := "public"
+	assert.Equal(t, Public, getGitHubRepositoryVisibility(&github.Repository{Visibility: &visibility}))
+	visibility = "internal"
+	assert.Equal(t, Internal, getGitHubRepositoryVisibility(&github.Repository{Visibility: &visibility}))
+	visibility = "private"
+	assert.Equal(t, Private, getGitHubRepositoryVisibility(&github.Repository{Visibility: &visibility}))
+}
+
+func TestGitHubClient_getGitHubCommitState(t *testing.T) {
+	assert.Equal(t, "success", getGitHubCommitState(Pass))
+	assert.Equal(t, "failure", getGitHubCommitState(Fail))
+	assert.Equal(t, "error", getGitHubCommitState(Error))
+	assert.Equal(t, "pending", getGitHubCommitState(InProgress))
+	assert.Equal(t, "", getGitHubCommitState(5))
+}
+
+func TestGitHubClient_DownloadRepository(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false,
+		[]byte("https://github.com/octocat/Hello-World/archive/refs/heads/master.tar.gz"),
+		"/repos/jfrog/Hello-World/tarball/test", http.StatusFound, createGitHubHandler)
+	defer cleanUp()
+	assert.NoError(t, err)
+
+	err = client.DownloadRepository(ctx, owner, "Hello-World", "test", dir)
+	require.NoError(t, err)
+	fileinfo, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, fileinfo, 2)
+	assert.Equal(t, ".git", fileinfo[0].Name())
+	assert.Equal(t, "README", fileinfo[1].Name())
+
+	err = createBadGitHubClient(t).DownloadRepository(ctx, owner, "Hello-World", "test", dir)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DownloadRepositoryAtRef(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	archive := createZipArchive(t, "jfrog-repo-1-6dcb09b", map[string]string{"README.md": "Hello World!"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/zipball/" + sha:
+			w.Header().Set("Location", "http://"+r.Host+"/archive.zip")
+			w.WriteHeader(http.StatusFound)
+		case "/archive.zip":
+			_, err := w.Write(archive)
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	err := buildClient(t, vcsutils.GitHub, false, server).DownloadRepositoryAtRef(ctx, owner, repo1, sha, dir, Zip)
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello World!", string(content))
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+
+	err = createBadGitHubClient(t).DownloadRepositoryAtRef(ctx, owner, repo1, sha, dir, Zip)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DownloadRepositoryPath(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	archive := createTarGzArchive(t, "jfrog-repo-1-6dcb09b", map[string]string{
+		"README.md":           "Hello World!",
+		"services/a/main.go":  "package main",
+		"services/ab/main.go": "package main",
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/tarball/master":
+			w.Header().Set("Location", "http://"+r.Host+"/archive.tar.gz")
+			w.WriteHeader(http.StatusFound)
+		case "/archive.tar.gz":
+			_, err := w.Write(archive)
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	err := buildClient(t, vcsutils.GitHub, false, server).DownloadRepositoryPath(ctx, owner, repo1, "master", "services/a", dir)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "services", "a", "main.go"))
+	assert.NoFileExists(t, filepath.Join(dir, "README.md"))
+	assert.NoDirExists(t, filepath.Join(dir, "services", "ab"))
+
+	err = createBadGitHubClient(t).DownloadRepositoryPath(ctx, owner, repo1, "master", "services/a", dir)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DownloadFileFromRepository(t *testing.T) {
+	ctx := context.Background()
+	downloadURL := "https://jfrog.com"
+	name := "hello-world"
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, &[]github.RepositoryContent{{DownloadURL: &downloadURL, Name: &name}}, "/repos/jfrog/repo-1/contents/?ref=branch-1", createGitHubHandler)
+	defer cleanUp()
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-world")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.NotEmpty(t, content)
+
+	_, _, err = client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-bald")
+	assert.Error(t, err)
+
+	_, _, err = client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-bald")
+	assert.Error(t, err)
+
+	_, _, err = createBadGitHubClient(t).DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetFileContent(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var response []byte
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/contents/go.mod?ref=branch-1":
+			response = []byte(`{"type":"file","encoding":"base64","size":12,"path":"go.mod","sha":"sha1","content":"SGVsbG8gV29ybGQh"}`)
+		case "/repos/jfrog/repo-1/contents/large.json?ref=branch-1":
+			response = []byte(`{"type":"file","encoding":"none","size":12,"path":"large.json","sha":"sha2","content":""}`)
+		case "/repos/jfrog/repo-1/git/blobs/sha2":
+			response = []byte("Hello World!")
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	fileContent, err := client.GetFileContent(ctx, owner, repo1, branch1, "go.mod")
+	assert.NoError(t, err)
+	assert.Equal(t, FileContent{Path: "go.mod", Content: []byte("Hello World!"), Size: 12, Sha: "sha1"}, fileContent)
+
+	fileContent, err = client.GetFileContent(ctx, owner, repo1, branch1, "large.json")
+	assert.NoError(t, err)
+	assert.Equal(t, FileContent{Path: "large.json", Content: []byte("Hello World!"), Size: 12, Sha: "sha2"}, fileContent)
+
+	_, err = createBadGitHubClient(t).GetFileContent(ctx, owner, repo1, branch1, "go.mod")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetFileBlame(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /graphql", r.Method+" "+r.RequestURI)
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var request gitHubGraphQLRequest
+		assert.NoError(t, json.Unmarshal(b, &request))
+		assert.Contains(t, request.Query, "blame(path: $path)")
+		assert.Equal(t, map[string]interface{}{"owner": owner, "name": repo1, "ref": branch1, "path": "go.mod"}, request.Variables)
+		_, err = w.Write([]byte(`{"data":{"repository":{"object":{"blame":{"ranges":[
+			{"startingLine":1,"endingLine":2,"commit":{"oid":"sha1","authoredDate":"2023-03-18T14:56:28Z","author":{"name":"Frogger","email":"frogger@jfrog.com"}}},
+			{"startingLine":3,"endingLine":3,"commit":{"oid":"sha2","authoredDate":"2023-03-19T09:20:52Z","author":{"name":"Example User","email":"user@example.com"}}}
+		]}}}}}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	blame, err := client.GetFileBlame(ctx, owner, repo1, branch1, "go.mod")
+	assert.NoError(t, err)
+	assert.Equal(t, []BlameLine{
+		{Line: 1, CommitHash: "sha1", AuthorName: "Frogger", AuthorEmail: "frogger@jfrog.com", Timestamp: 1679151388},
+		{Line: 2, CommitHash: "sha1", AuthorName: "Frogger", AuthorEmail: "frogger@jfrog.com", Timestamp: 1679151388},
+		{Line: 3, CommitHash: "sha2", AuthorName: "Example User", AuthorEmail: "user@example.com", Timestamp: 1679217652},
+	}, blame)
+
+	_, err = client.GetFileBlame(ctx, owner, repo1, "", "go.mod")
+	assert.Error(t, err)
+
+	_, err = createBadGitHubClient(t).GetFileBlame(ctx, owner, repo1, branch1, "go.mod")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListRepositoryTree(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"sha":"sha1","truncated":false,"tree":[
+		{"path":"go.mod","type":"blob","size":12},
+		{"path":"src","type":"tree"},
+		{"path":"src/main.go","type":"blob","size":120},
+		{"path":"vendor/lib","type":"commit"}
+	]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/git/trees/branch-1?recursive=1", createGitHubHandler)
+	defer cleanUp()
+
+	tree, err := client.ListRepositoryTree(ctx, owner, repo1, branch1, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []TreeEntry{
+		{Path: "go.mod", Type: TreeEntryFile, Size: 12},
+		{Path: "src", Type: TreeEntryDirectory},
+		{Path: "src/main.go", Type: TreeEntryFile, Size: 120},
+		{Path: "vendor/lib", Type: TreeEntrySubmodule},
+	}, tree)
+
+	_, err = createBadGitHubClient(t).ListRepositoryTree(ctx, owner, repo1, branch1, true)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/search/code?page=1&q=exec.Command+repo%3Ajfrog%2Frepo-1":
+			assert.Contains(t, r.Header.Get("Accept"), "text-match")
+			w.Header().Set("Link", `<https://api.github.com/search/code?page=2>; rel="next"`)
+			_, err := w.Write([]byte(`{"total_count":2,"items":[{"path":"main.go","repository":{"name":"repo-1","owner":{"login":"jfrog"}},
+				"text_matches":[{"property":"path","fragment":"main.go"},{"property":"content","fragment":"cmd := exec.Command(name)"}]}]}`))
+			assert.NoError(t, err)
+		case "/search/code?page=2&q=exec.Command+repo%3Ajfrog%2Frepo-1":
+			_, err := w.Write([]byte(`{"total_count":2,"items":[{"path":"utils/run.go","repository":{"name":"repo-1","owner":{"login":"jfrog"}}}]}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	results, err := client.SearchCode(ctx, CodeSearchScope{Owner: owner, Repository: repo1}, "exec.Command")
+	require.NoError(t, err)
+	assert.Equal(t, []CodeSearchResult{
+		{Owner: owner, Repository: repo1, Path: "main.go", Fragments: []string{"cmd := exec.Command(name)"}},
+		{Owner: owner, Repository: repo1, Path: "utils/run.go"},
+	}, results)
+
+	_, err = createBadGitHubClient(t).SearchCode(ctx, CodeSearchScope{Owner: owner}, "exec.Command")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateOrUpdateFile(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/contents/go.mod?ref=branch-1":
+			response = []byte(`{"type":"file","encoding":"base64","size":12,"path":"go.mod","sha":"sha1","content":"SGVsbG8gV29ybGQh"}`)
+		case "GET /repos/jfrog/repo-1/contents/new.txt?ref=branch-1":
+			w.WriteHeader(http.StatusNotFound)
+		case "PUT /repos/jfrog/repo-1/contents/go.mod":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(b), `"sha":"sha1"`)
+			assert.Contains(t, string(b), `"content":"SGVsbG8gV29ybGQh"`)
+			response = []byte(`{}`)
+		case "PUT /repos/jfrog/repo-1/contents/new.txt":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NotContains(t, string(b), `"sha"`)
+			assert.Contains(t, string(b), `"branch":"branch-1"`)
+			w.WriteHeader(http.StatusCreated)
+			response = []byte(`{}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "go.mod", []byte("Hello World!"), "Update go.mod")
+	assert.NoError(t, err)
+
+	err = client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "new.txt", []byte("Hello World!"), "Add new.txt")
+	assert.NoError(t, err)
+
+	err = client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "new.txt", []byte("Hello World!"), "")
+	assert.Error(t, err)
+
+	err = createBadGitHubClient(t).CreateOrUpdateFile(ctx, owner, repo1, branch1, "go.mod", []byte("Hello World!"), "Update go.mod")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	changes := []FileChange{
+		{Type: UpdateFile, Path: "go.mod", Content: []byte("Hello World!")},
+		{Type: DeleteFile, Path: "go.sum"},
+		{Type: UpdateFile, Path: "scripts/build.sh", Content: []byte("Hello World!")},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/git/ref/heads/branch-1":
+			response = []byte(`{"ref":"refs/heads/branch-1","object":{"sha":"parent-sha","type":"commit"}}`)
+		case "GET /repos/jfrog/repo-1/git/commits/parent-sha":
+			response = []byte(`{"sha":"parent-sha","tree":{"sha":"base-tree-sha"}}`)
+		case "GET /repos/jfrog/repo-1/git/trees/base-tree-sha":
+			response = []byte(`{"sha":"base-tree-sha","tree":[` +
+				`{"path":"go.mod","mode":"100644","type":"blob","sha":"go-mod-sha"},` +
+				`{"path":"scripts","mode":"040000","type":"tree","sha":"scripts-tree-sha"}]}`)
+		case "GET /repos/jfrog/repo-1/git/trees/scripts-tree-sha":
+			response = []byte(`{"sha":"scripts-tree-sha","tree":[{"path":"build.sh","mode":"100755","type":"blob","sha":"build-sh-sha"}]}`)
+		case "POST /repos/jfrog/repo-1/git/blobs":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"content":"SGVsbG8gV29ybGQh","encoding":"base64"}`, string(b))
+			response = []byte(`{"sha":"blob-sha"}`)
+		case "POST /repos/jfrog/repo-1/git/trees":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"base_tree":"base-tree-sha","tree":[`+
+				`{"sha":"blob-sha","path":"go.mod","mode":"100644","type":"blob"},`+
+				`{"sha":null,"path":"go.sum","mode":"100644","type":"blob"},`+
+				// The executable file stays executable
+				`{"sha":"blob-sha","path":"scripts/build.sh","mode":"100755","type":"blob"}]}`, string(b))
+			response = []byte(`{"sha":"tree-sha"}`)
+		case "POST /repos/jfrog/repo-1/git/commits":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"message":"Update dependencies","tree":"tree-sha","parents":["parent-sha"]}`, string(b))
+			response = []byte(`{"sha":"commit-sha"}`)
+		case "PATCH /repos/jfrog/repo-1/git/refs/heads/branch-1":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"sha":"commit-sha","force":false}`, string(b))
+			response = []byte(`{"ref":"refs/heads/branch-1","object":{"sha":"commit-sha"}}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", changes)
+	assert.NoError(t, err)
+
+	err = client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", nil)
+	assert.Error(t, err)
+
+	err = createBadGitHubClient(t).CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", changes)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{}, "/repos/jfrog/repo-1/pulls", createGitHubHandler)
+	defer cleanUp()
+
+	err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateDraftPullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"PR title","head":"jfrog:branch-1","base":"branch-2","body":"PR body","draft":true}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{},
+		"/repos/jfrog/repo-1/pulls", http.StatusCreated, expectedBody, http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateDraftPullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).CreateDraftPullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_MarkPullRequestReady(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/pulls/1":
+			response = []byte(`{"number":1,"node_id":"PR_node1","draft":true}`)
+		case "GET /repos/jfrog/repo-1/pulls/2":
+			response = []byte(`{"number":2,"node_id":"PR_node2","draft":false}`)
+		case "GET /repos/jfrog/repo-1/pulls/3":
+			response = []byte(`{"number":3,"node_id":"PR_node3","draft":true}`)
+		case "POST /graphql":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var request gitHubGraphQLRequest
+			assert.NoError(t, json.Unmarshal(b, &request))
+			assert.Contains(t, request.Query, "markPullRequestReadyForReview")
+			switch request.Variables["id"] {
+			case "PR_node1":
+				response = []byte(`{"data":{"markPullRequestReadyForReview":{"pullRequest":{"isDraft":false}}}}`)
+			case "PR_node3":
+				response = []byte(`{"errors":[{"message":"Resource not accessible by integration"}]}`)
+			}
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.MarkPullRequestReady(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+
+	// Not a draft, no GraphQL request is expected
+	err = client.MarkPullRequestReady(ctx, owner, repo1, 2)
+	assert.NoError(t, err)
+
+	err = client.MarkPullRequestReady(ctx, owner, repo1, 3)
+	assert.EqualError(t, err, "Resource not accessible by integration")
+
+	err = createBadGitHubClient(t).MarkPullRequestReady(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"commit_message":"Merge commit message","merge_method":"squash"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.PullRequestMergeResult{Merged: github.Bool(true)},
+		"/repos/jfrog/repo-1/pulls/1/merge", http.StatusOK, expectedBody, http.MethodPut, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.MergePullRequest(ctx, owner, repo1, 1, SquashMerge, "Merge commit message")
 	assert.NoError(t, err)
 
-	err = createBadGitHubClient(t).SetCommitStatus(ctx, Error, owner, repo1, ref, "Commit status title", "Commit status description",
-		"https://httpbin.org/anything")
+	err = createBadGitHubClient(t).MergePullRequest(ctx, owner, repo1, 1, SquashMerge, "Merge commit message")
 	assert.Error(t, err)
 }
 
-func TestGitHubClient_getRepositoryVisibility(t *testing.T) {
-	visibility := "public"
-	assert.Equal(t, Public, getGitHubRepositoryVisibility(&github.Repository{Visibility: &visibility}))
-	visibility = "internal"
-	assert.Equal(t, Internal, getGitHubRepositoryVisibility(&github.Repository{Visibility: &visibility}))
-	visibility = "private"
-	assert.Equal(t, Private, getGitHubRepositoryVisibility(&github.Repository{Visibility: &visibility}))
-}
+func TestGitHubClient_MergePullRequestNotMerged(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false,
+		github.PullRequestMergeResult{Merged: github.Bool(false), Message: github.String("Pull Request is not mergeable")},
+		"/repos/jfrog/repo-1/pulls/1/merge", createGitHubHandler)
+	defer cleanUp()
 
-func TestGitHubClient_getGitHubCommitState(t *testing.T) {
-	assert.Equal(t, "success", getGitHubCommitState(Pass))
-	assert.Equal(t, "failure", getGitHubCommitState(Fail))
-	assert.Equal(t, "error", getGitHubCommitState(Error))
-	assert.Equal(t, "pending", getGitHubCommitState(InProgress))
-	assert.Equal(t, "", getGitHubCommitState(5))
+	err := client.MergePullRequest(ctx, owner, repo1, 1, MergeCommit, "")
+	assert.EqualError(t, err, "pull request 1 was not merged: Pull Request is not mergeable")
 }
 
-func TestGitHubClient_DownloadRepository(t *testing.T) {
+func TestGitHubClient_PullRequestAutoMerge(t *testing.T) {
 	ctx := context.Background()
-	dir, err := os.MkdirTemp("", "")
+	var mutations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/pulls/1":
+			response = []byte(`{"number":1,"node_id":"PR_node1","auto_merge":{"merge_method":"squash"}}`)
+		case "GET /repos/jfrog/repo-1/pulls/2":
+			response = []byte(`{"number":2,"node_id":"PR_node2"}`)
+		case "POST /graphql":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var request gitHubGraphQLRequest
+			assert.NoError(t, json.Unmarshal(b, &request))
+			assert.Equal(t, "PR_node1", request.Variables["id"])
+			if strings.Contains(request.Query, "enablePullRequestAutoMerge") {
+				assert.Equal(t, "SQUASH", request.Variables["mergeMethod"])
+				mutations = append(mutations, "enable")
+			} else {
+				assert.Contains(t, request.Query, "disablePullRequestAutoMerge")
+				mutations = append(mutations, "disable")
+			}
+			response = []byte(`{"data":{}}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, SquashMerge)
 	assert.NoError(t, err)
-	defer func() { _ = os.RemoveAll(dir) }()
 
-	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false,
-		[]byte("https://github.com/octocat/Hello-World/archive/refs/heads/master.tar.gz"),
-		"/repos/jfrog/Hello-World/tarball/test", http.StatusFound, createGitHubHandler)
-	defer cleanUp()
+	err = client.DisablePullRequestAutoMerge(ctx, owner, repo1, 1)
 	assert.NoError(t, err)
 
-	err = client.DownloadRepository(ctx, owner, "Hello-World", "test", dir)
-	require.NoError(t, err)
-	fileinfo, err := os.ReadDir(dir)
-	require.NoError(t, err)
-	assert.Len(t, fileinfo, 2)
-	assert.Equal(t, ".git", fileinfo[0].Name())
-	assert.Equal(t, "README", fileinfo[1].Name())
+	// Auto-merge isn't enabled, no GraphQL request is expected
+	err = client.DisablePullRequestAutoMerge(ctx, owner, repo1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"enable", "disable"}, mutations)
 
-	err = createBadGitHubClient(t).DownloadRepository(ctx, owner, "Hello-World", "test", dir)
+	err = createBadGitHubClient(t).EnablePullRequestAutoMerge(ctx, owner, repo1, 1, SquashMerge)
 	assert.Error(t, err)
 }
 
-func TestGitHubClient_DownloadFileFromRepository(t *testing.T) {
+func TestGitHubClient_UpdatePullRequest(t *testing.T) {
 	ctx := context.Background()
-	downloadURL := "https://jfrog.com"
-	name := "hello-world"
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, &[]github.RepositoryContent{{DownloadURL: &downloadURL, Name: &name}}, "/repos/jfrog/repo-1/contents/?ref=branch-1", createGitHubHandler)
+	expectedBody := []byte(`{"title":"New title","body":"New body","base":"dev"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{},
+		"/repos/jfrog/repo-1/pulls/1", http.StatusOK, expectedBody, http.MethodPatch, createGitHubWithBodyHandler)
 	defer cleanUp()
-	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-world")
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusOK, statusCode)
-	assert.NotEmpty(t, content)
 
-	_, _, err = client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-bald")
-	assert.Error(t, err)
+	err := client.UpdatePullRequest(ctx, owner, repo1, "New title", "New body", "dev", 1, nil)
+	assert.NoError(t, err)
 
-	_, _, err = client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-bald")
+	merged := PullRequestMerged
+	err = client.UpdatePullRequest(ctx, owner, repo1, "", "", "", 1, &merged)
 	assert.Error(t, err)
 
-	_, _, err = createBadGitHubClient(t).DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello")
+	closed := PullRequestClosed
+	err = createBadGitHubClient(t).UpdatePullRequest(ctx, owner, repo1, "", "", "", 1, &closed)
 	assert.Error(t, err)
 }
 
-func TestGitHubClient_CreatePullRequest(t *testing.T) {
+func TestGitHubClient_SubmitPullRequestReview(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{}, "/repos/jfrog/repo-1/pulls", createGitHubHandler)
+	expectedBody := []byte(`{"body":"Please fix","event":"REQUEST_CHANGES"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.PullRequestReview{},
+		"/repos/jfrog/repo-1/pulls/1/reviews", http.StatusOK, expectedBody, http.MethodPost, createGitHubWithBodyHandler)
 	defer cleanUp()
 
-	err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	err := client.SubmitPullRequestReview(ctx, owner, repo1, 1, ReviewRequestChanges, "Please fix")
 	assert.NoError(t, err)
 
-	err = createBadGitHubClient(t).CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	err = client.SubmitPullRequestReview(ctx, owner, repo1, 1, ReviewComment, "")
+	assert.Error(t, err)
+
+	err = createBadGitHubClient(t).ApprovePullRequest(ctx, owner, repo1, 1)
 	assert.Error(t, err)
 }
 
+func TestGetGitHubReviewEvent(t *testing.T) {
+	assert.Equal(t, "APPROVE", getGitHubReviewEvent(ReviewApprove))
+	assert.Equal(t, "REQUEST_CHANGES", getGitHubReviewEvent(ReviewRequestChanges))
+	assert.Equal(t, "COMMENT", getGitHubReviewEvent(ReviewComment))
+}
+
+func TestGetGitHubMergeMethod(t *testing.T) {
+	assert.Equal(t, "merge", getGitHubMergeMethod(MergeCommit))
+	assert.Equal(t, "squash", getGitHubMergeMethod(SquashMerge))
+	assert.Equal(t, "rebase", getGitHubMergeMethod(RebaseMerge))
+}
+
 func TestGitHubClient_AddPullRequestComment(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.IssueComment{}, "/repos/jfrog/repo-1/issues/1/comments", createGitHubHandler)
@@ -378,6 +1655,51 @@ func TestGitHubClient_AddSshKeyToRepositoryReadWrite(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGitHubClient_ListDeployKeys(t *testing.T) {
+	ctx := context.Background()
+	response := []github.Key{{ID: github.Int64(7), Title: github.String("frogbot"), Key: github.String("ssh-rsa AAAA..."), ReadOnly: github.Bool(true)}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/jfrog/%s/keys?page=1&per_page=100", repo1), createGitHubHandler)
+	defer cleanUp()
+
+	keys, err := client.ListDeployKeys(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []DeployKeyInfo{{ID: 7, Title: "frogbot", Key: "ssh-rsa AAAA...", ReadOnly: true}}, keys)
+
+	_, err = createBadGitHubClient(t).ListDeployKeys(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_AddDeployKey(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(github.Key{Key: github.String("ssh-rsa AAAA..."), Title: github.String("frogbot"), ReadOnly: github.Bool(false)})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false,
+		[]byte(`{"id":7,"key":"ssh-rsa AAAA...","title":"frogbot","read_only":false}`), fmt.Sprintf("/repos/jfrog/%s/keys", repo1),
+		http.StatusCreated, append(expectedBody, '\n'), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	key, err := client.AddDeployKey(ctx, owner, repo1, "frogbot", "ssh-rsa AAAA...", false)
+	require.NoError(t, err)
+	assert.Equal(t, DeployKeyInfo{ID: 7, Title: "frogbot", Key: "ssh-rsa AAAA..."}, key)
+
+	_, err = createBadGitHubClient(t).AddDeployKey(ctx, owner, repo1, "frogbot", "ssh-rsa AAAA...", false)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DeleteDeployKey(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte{},
+		fmt.Sprintf("/repos/jfrog/%s/keys/7", repo1), http.StatusNoContent, []byte{}, http.MethodDelete, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteDeployKey(ctx, owner, repo1, 7)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).DeleteDeployKey(ctx, owner, repo1, 7)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_GetCommitBySha(t *testing.T) {
 	ctx := context.Background()
 	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
@@ -405,6 +1727,213 @@ func TestGitHubClient_GetCommitBySha(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "commit_list_response.json"))
+	require.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/commits?author=frogger&page=2&path=README.md&per_page=10&sha=master&since=2011-04-01T00%%3A00%%3A00Z", owner, repo1),
+		createGitHubHandler)
+	defer cleanUp()
+
+	options := ListCommitsOptions{Branch: "master", Path: "README.md", Author: username, Since: time.Date(2011, 4, 1, 0, 0, 0, 0, time.UTC), Page: 2, PerPage: 10}
+	result, err := client.ListCommits(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", result[0].Hash)
+	assert.Equal(t, "Monalisa Octocat", result[0].AuthorName)
+	assert.Equal(t, "Fix all the bugs", result[0].Message)
+
+	_, err = createBadGitHubClient(t).ListCommits(ctx, owner, repo1, options)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetCommit(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "commit_single_response.json"))
+	require.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/commits/%s?page=1&per_page=100", owner, repo1, sha), createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.GetCommit(ctx, owner, repo1, sha)
+	require.NoError(t, err)
+	assert.Equal(t, sha, result.Hash)
+	assert.Equal(t, []string{"5dcb09b5b57875f334f61aebed695e2e4193db5e"}, result.ParentHashes)
+	assert.Equal(t, 104, result.Additions)
+	assert.Equal(t, 4, result.Deletions)
+	assert.Equal(t, []PullRequestFile{{Path: "file1.txt", Status: FileModified, Additions: 10, Deletions: 2}}, result.Files)
+
+	_, err = createBadGitHubClient(t).GetCommit(ctx, owner, repo1, sha)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetCommitVerification(t *testing.T) {
+	ctx := context.Background()
+	responses := map[string]string{
+		"sha1": `{"data":{"repository":{"object":{"signature":{"__typename":"SshSignature","state":"VALID","email":"frogger@jfrog.com","signer":{"login":"frogger","name":"Frogger"}}}}}}`,
+		"sha2": `{"data":{"repository":{"object":{"signature":{"__typename":"GpgSignature","state":"UNKNOWN_KEY","email":"user@example.com","signer":null}}}}}`,
+		"sha3": `{"data":{"repository":{"object":{"signature":null}}}}`,
+		"sha4": `{"data":{"repository":{"object":null}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /graphql", r.Method+" "+r.RequestURI)
+		var request gitHubGraphQLRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Contains(t, request.Query, "signature {")
+		_, err := w.Write([]byte(responses[request.Variables["ref"].(string)]))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	verification, err := client.GetCommitVerification(ctx, owner, repo1, "sha1")
+	require.NoError(t, err)
+	assert.Equal(t, CommitVerificationInfo{Signed: true, Verified: true, SignatureType: SignatureSSH, Reason: "valid",
+		SignerUsername: "frogger", SignerName: "Frogger", SignerEmail: "frogger@jfrog.com"}, verification)
+
+	verification, err = client.GetCommitVerification(ctx, owner, repo1, "sha2")
+	require.NoError(t, err)
+	assert.Equal(t, CommitVerificationInfo{Signed: true, SignatureType: SignatureGPG, Reason: "unknown_key", SignerEmail: "user@example.com"}, verification)
+
+	verification, err = client.GetCommitVerification(ctx, owner, repo1, "sha3")
+	require.NoError(t, err)
+	assert.False(t, verification.Signed)
+
+	_, err = client.GetCommitVerification(ctx, owner, repo1, "sha4")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = createBadGitHubClient(t).GetCommitVerification(ctx, owner, repo1, "sha1")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{
+		"ahead_by": 1,
+		"behind_by": 2,
+		"commits": [{
+			"sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			"html_url": "https://github.com/jfrog/repo-1/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			"commit": {
+				"message": "Add feature",
+				"author": {"name": "Monalisa Octocat", "date": "2011-04-14T16:00:49Z"},
+				"committer": {"name": "Joconde Octocat", "date": "2011-04-14T16:00:49Z"}
+			},
+			"parents": [{"sha": "5dcb09b5b57875f334f61aebed695e2e4193db5e"}]
+		}],
+		"files": [{"filename": "README.md", "status": "modified", "additions": 3, "deletions": 1, "changes": 4}]
+	}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/compare/main...feature?page=1&per_page=100", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.CompareCommits(ctx, owner, repo1, "main", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.AheadBy)
+	assert.Equal(t, 2, result.BehindBy)
+	require.Len(t, result.Commits, 1)
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", result.Commits[0].Hash)
+	assert.Equal(t, "Add feature", result.Commits[0].Message)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "README.md", result.Files[0].Path)
+	assert.Equal(t, 3, result.Files[0].Additions)
+
+	_, err = createBadGitHubClient(t).CompareCommits(ctx, owner, repo1, "main", "feature")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetCommitsBetween(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{
+		"ahead_by": 2,
+		"commits": [
+			{"sha": "sha1", "commit": {"message": "First", "author": {"name": "Monalisa Octocat"}}},
+			{"sha": "sha2", "commit": {"message": "Second", "author": {"name": "Monalisa Octocat"}}}
+		]
+	}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/compare/sha0...sha2?page=1&per_page=100", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	commits, err := client.GetCommitsBetween(ctx, owner, repo1, "sha0", "sha2")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	// The most recent commit is first
+	assert.Equal(t, "sha2", commits[0].Hash)
+	assert.Equal(t, "Second", commits[0].Message)
+	assert.Equal(t, "Monalisa Octocat", commits[0].AuthorName)
+	assert.Equal(t, "sha1", commits[1].Hash)
+
+	_, err = client.GetCommitsBetween(ctx, owner, repo1, "", "sha2")
+	assert.Error(t, err)
+
+	_, err = createBadGitHubClient(t).GetCommitsBetween(ctx, owner, repo1, "sha0", "sha2")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListContributors(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/contributors?page=1&per_page=100":
+			w.Header().Set("Link", `<https://api.github.com/repos/jfrog/repo-1/contributors?page=2&per_page=100>; rel="next"`)
+			response = `[{"login":"frogger","contributions":5}]`
+		case "/repos/jfrog/repo-1/contributors?page=2&per_page=100":
+			response = `[{"login":"toad","contributions":2}]`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	contributors, err := client.ListContributors(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []ContributorInfo{{Name: "frogger", Commits: 5}, {Name: "toad", Commits: 2}}, contributors)
+
+	_, err = createBadGitHubClient(t).ListContributors(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetCommitActivity(t *testing.T) {
+	ctx := context.Background()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/stats/contributors", r.RequestURI)
+		requests++
+		// The statistics are computed on the first request
+		if requests == 1 {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		_, err := w.Write([]byte(`[
+			{"author":{"login":"frogger"},"weeks":[{"w":1684627200,"c":4},{"w":1685232000,"c":2},{"w":1685836800,"c":0},{"w":1686441600,"c":1}]},
+			{"author":{"login":"toad"},"weeks":[{"w":1685232000,"c":1}]}
+		]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	activity, err := client.GetCommitActivity(ctx, owner, repo1, time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, []CommitActivityInfo{
+		{Author: "frogger", Week: 1685232000, Commits: 2},
+		{Author: "toad", Week: 1685232000, Commits: 1},
+		{Author: "frogger", Week: 1686441600, Commits: 1},
+	}, activity)
+
+	_, err = createBadGitHubClient(t).GetCommitActivity(ctx, owner, repo1, time.Time{})
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_GetCommitByWrongSha(t *testing.T) {
 	ctx := context.Background()
 	sha := "5dcb09b5b57875f334f61aebed695e2e4193db5e"
@@ -437,6 +1966,10 @@ func TestGitHubClient_GetRepositoryInfo(t *testing.T) {
 		RepositoryInfo{
 			RepositoryVisibility: Public,
 			CloneInfo:            CloneInfo{HTTP: "https://github.com/octocat/Hello-World.git", SSH: "git@github.com:octocat/Hello-World.git"},
+			DefaultBranch:        "master",
+			Description:          "This your first repo!",
+			Topics:               []string{"octocat", "atom", "electron", "api"},
+			Size:                 110592,
 		},
 		info,
 	)
@@ -445,6 +1978,187 @@ func TestGitHubClient_GetRepositoryInfo(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_GetRepositoryLanguages(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []byte(`{"Go":300,"Shell":100}`), "/repos/jfrog/repo-1/languages", createGitHubHandler)
+	defer cleanUp()
+
+	languages, err := client.GetRepositoryLanguages(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"Go": 75, "Shell": 25}, languages)
+
+	_, err = createBadGitHubClient(t).GetRepositoryLanguages(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateRepository(t *testing.T) {
+	ctx := context.Background()
+	renamed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		switch r.RequestURI {
+		case "/users/jfrog":
+			_, err := w.Write([]byte(`{"login":"jfrog","type":"Organization"}`))
+			assert.NoError(t, err)
+		case "/orgs/jfrog/repos":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"name":"repo-1","description":"Frogs","private":false,"visibility":"public","auto_init":true}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"name":"repo-1","default_branch":"main"}`))
+			assert.NoError(t, err)
+		case "/repos/jfrog/repo-1/branches/main/rename":
+			assert.Equal(t, http.MethodPost, r.Method)
+			renamed = true
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"name":"master"}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	public := Public
+	err := client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{
+		Description:    "Frogs",
+		Visibility:     &public,
+		DefaultBranch:  "master",
+		InitWithReadme: true,
+	})
+	assert.NoError(t, err)
+	assert.True(t, renamed)
+
+	err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{DefaultBranch: "master"})
+	assert.Error(t, err)
+
+	err = createBadGitHubClient(t).CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte{},
+		fmt.Sprintf("/repos/jfrog/%s", repo1), http.StatusNoContent, []byte{}, http.MethodDelete, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).DeleteRepository(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "repository_response.json"))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/forks?organization=octocat":
+			assert.Equal(t, http.MethodPost, r.Method)
+			w.WriteHeader(http.StatusAccepted)
+			_, err := w.Write(response)
+			assert.NoError(t, err)
+		case "/repos/octocat/Hello-World/branches/master":
+			_, err := w.Write([]byte(`{"name":"master"}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	forkInfo, err := client.ForkRepository(ctx, owner, repo1, "octocat", true)
+	require.NoError(t, err)
+	assert.Equal(t, ForkInfo{
+		Owner:      "octocat",
+		Repository: "Hello-World",
+		RepositoryInfo: RepositoryInfo{
+			RepositoryVisibility: Public,
+			CloneInfo:            CloneInfo{HTTP: "https://github.com/octocat/Hello-World.git", SSH: "git@github.com:octocat/Hello-World.git"},
+			DefaultBranch:        "master",
+			Description:          "This your first repo!",
+			Topics:               []string{"octocat", "atom", "electron", "api"},
+			Size:                 110592,
+		},
+	}, forkInfo)
+
+	_, err = createBadGitHubClient(t).ForkRepository(ctx, owner, repo1, "", false)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListCollaborators(t *testing.T) {
+	ctx := context.Background()
+	response := []github.User{
+		{Login: github.String("frogger"), Permissions: map[string]bool{"admin": true, "push": true, "pull": true}},
+		{Login: github.String("toad"), Permissions: map[string]bool{"maintain": true, "push": true, "pull": true}},
+		{Login: github.String("tadpole"), Permissions: map[string]bool{"triage": true, "pull": true}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/jfrog/%s/collaborators?page=1&per_page=100", repo1), createGitHubHandler)
+	defer cleanUp()
+
+	collaborators, err := client.ListCollaborators(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{
+		{Username: "frogger", Permission: PermissionAdmin},
+		{Username: "toad", Permission: PermissionWrite},
+		{Username: "tadpole", Permission: PermissionRead},
+	}, collaborators)
+
+	_, err = createBadGitHubClient(t).ListCollaborators(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_AddCollaborator(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte{},
+		fmt.Sprintf("/repos/jfrog/%s/collaborators/%s", repo1, username), http.StatusNoContent, []byte(`{"permission":"push"}`+"\n"),
+		http.MethodPut, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.AddCollaborator(ctx, owner, repo1, username, PermissionWrite)
+	assert.NoError(t, err)
+
+	err = client.AddCollaborator(ctx, owner, repo1, username, PermissionNone)
+	assert.EqualError(t, err, "validation failed: a collaborator must be granted read, write or admin permission")
+
+	err = createBadGitHubClient(t).AddCollaborator(ctx, owner, repo1, username, PermissionWrite)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_RemoveCollaborator(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte{},
+		fmt.Sprintf("/repos/jfrog/%s/collaborators/%s", repo1, username), http.StatusNoContent, []byte{}, http.MethodDelete,
+		createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.RemoveCollaborator(ctx, owner, repo1, username)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).RemoveCollaborator(ctx, owner, repo1, username)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetUserPermission(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []byte(`{"permission":"write"}`),
+		fmt.Sprintf("/repos/jfrog/%s/collaborators/%s/permission", repo1, username), createGitHubHandler)
+	defer cleanUp()
+
+	permission, err := client.GetUserPermission(ctx, owner, repo1, username)
+	require.NoError(t, err)
+	assert.Equal(t, PermissionWrite, permission)
+
+	_, err = createBadGitHubClient(t).GetUserPermission(ctx, owner, repo1, username)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_CreateLabel(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.Label{}, fmt.Sprintf("/repos/jfrog/%s/labels", repo1), createGitHubHandler)
@@ -496,7 +2210,7 @@ func TestGitGubClient_GetLabelNotExisted(t *testing.T) {
 
 func TestGitHubClient_ListPullRequestLabels(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []*github.Label{{Name: &labelName}}, "/repos/jfrog/repo-1/issues/1/labels", createGitHubHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []*github.Label{{Name: &labelName}}, "/repos/jfrog/repo-1/issues/1/labels?page=1", createGitHubHandler)
 	defer cleanUp()
 
 	labels, err := client.ListPullRequestLabels(ctx, owner, repo1, 1)
@@ -508,6 +2222,149 @@ func TestGitHubClient_ListPullRequestLabels(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListLabels(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var labels []*github.Label
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/labels?page=1&per_page=100":
+			w.Header().Set("Link", `<https://api.github.com/repos/jfrog/repo-1/labels?page=2&per_page=100>; rel="next"`)
+			labels = []*github.Label{{Name: github.String(labelName), Description: github.String("label-description"), Color: github.String("001122")}}
+		case "/repos/jfrog/repo-1/labels?page=2&per_page=100":
+			labels = []*github.Label{{Name: github.String("bug"), Color: github.String("ff0000")}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(labels)
+		require.NoError(t, err)
+		_, err = w.Write(response)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	labels, err := client.ListLabels(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []LabelInfo{
+		{Name: labelName, Description: "label-description", Color: "001122"},
+		{Name: "bug", Color: "ff0000"},
+	}, labels)
+
+	_, err = createBadGitHubClient(t).ListLabels(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_UpdateLabel(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"name":"new-name","color":"ff0000"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.Label{},
+		fmt.Sprintf("/repos/jfrog/%s/labels/%s", repo1, url.PathEscape(labelName)), http.StatusOK, expectedBody, http.MethodPatch,
+		createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: "new-name", Color: "ff0000"})
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: "new-name"})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DeleteLabel(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte{},
+		fmt.Sprintf("/repos/jfrog/%s/labels/%s", repo1, url.PathEscape(labelName)), http.StatusNoContent, []byte{}, http.MethodDelete,
+		createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteLabel(ctx, owner, repo1, labelName)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).DeleteLabel(ctx, owner, repo1, labelName)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateIssue(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"Vulnerable dependency","body":"Upgrade lodash","labels":["security"]}` + "\n")
+	response := github.Issue{
+		Number:  github.Int(3),
+		Title:   github.String("Vulnerable dependency"),
+		Body:    github.String("Upgrade lodash"),
+		State:   github.String("open"),
+		User:    &github.User{Login: github.String(username)},
+		Labels:  []*github.Label{{Name: github.String("security")}},
+		HTMLURL: github.String("https://github.com/jfrog/repo-1/issues/3"),
+	}
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/jfrog/%s/issues", repo1), http.StatusCreated, expectedBody, http.MethodPost,
+		createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	issue, err := client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "Upgrade lodash", []string{"security"})
+	assert.NoError(t, err)
+	assert.Equal(t, IssueInfo{
+		ID:     3,
+		Title:  "Vulnerable dependency",
+		Body:   "Upgrade lodash",
+		State:  IssueOpen,
+		Author: username,
+		Labels: []string{"security"},
+		URL:    "https://github.com/jfrog/repo-1/issues/3",
+	}, issue)
+
+	_, err = createBadGitHubClient(t).CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "", nil)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_AddIssueComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.IssueComment{},
+		fmt.Sprintf("/repos/jfrog/%s/issues/3/comments", repo1), http.StatusCreated, []byte(`{"body":"Fixed in 1.2.3"}`+"\n"),
+		http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.AddIssueComment(ctx, owner, repo1, "Fixed in 1.2.3", 3)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).AddIssueComment(ctx, owner, repo1, "Fixed in 1.2.3", 3)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListIssues(t *testing.T) {
+	ctx := context.Background()
+	response := []*github.Issue{
+		{Number: github.Int(1), Title: github.String("Bug"), State: github.String("closed"), User: &github.User{Login: github.String(username)}},
+		// Pull requests are listed as issues, and should be skipped
+		{Number: github.Int(2), Title: github.String("Fix"), PullRequestLinks: &github.PullRequestLinks{}},
+	}
+	closed := IssueClosed
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/jfrog/%s/issues?creator=%s&labels=bug&page=1&state=closed", repo1, username), createGitHubHandler)
+	defer cleanUp()
+
+	issues, err := client.ListIssues(ctx, owner, repo1, IssueFilter{State: &closed, Author: username, Label: "bug"})
+	assert.NoError(t, err)
+	assert.Equal(t, []IssueInfo{{ID: 1, Title: "Bug", State: IssueClosed, Author: username, Labels: []string{}}}, issues)
+
+	_, err = createBadGitHubClient(t).ListIssues(ctx, owner, repo1, IssueFilter{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_UpdateIssueState(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.Issue{},
+		fmt.Sprintf("/repos/jfrog/%s/issues/3", repo1), http.StatusOK, []byte(`{"state":"closed"}`+"\n"), http.MethodPatch,
+		createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdateIssueState(ctx, owner, repo1, 3, IssueClosed)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).UpdateIssueState(ctx, owner, repo1, 3, IssueClosed)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ListOpenPullRequests(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_requests_list_response.json"))
@@ -530,6 +2387,354 @@ func TestGitHubClient_ListOpenPullRequests(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListOpenPullRequestsWithFilter(t *testing.T) {
+	ctx := context.Background()
+	head := &github.PullRequestBranch{Ref: github.String("feature")}
+	base := &github.PullRequestBranch{Ref: github.String("main")}
+	response := []*github.PullRequest{
+		{Number: github.Int(1), Head: head, Base: base, User: &github.User{Login: github.String("frogger")},
+			Labels: []*github.Label{{Name: github.String("bug")}}},
+		{Number: github.Int(2), Head: head, Base: base, User: &github.User{Login: github.String("toad")},
+			Labels: []*github.Label{{Name: github.String("bug")}}},
+		{Number: github.Int(3), Head: head, Base: base, User: &github.User{Login: github.String("frogger")}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		"/repos/jfrog/repo-1/pulls?base=main&head=jfrog%3Afeature&page=2&per_page=10&state=open", createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{
+		Author: "frogger", SourceBranch: "feature", TargetBranch: "main", Label: "bug", Page: 2, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(1), result[0].ID)
+	assert.Equal(t, "frogger", result[0].Author)
+	assert.Equal(t, []string{"bug"}, result[0].Labels)
+	assert.Equal(t, "feature", result[0].Source.Name)
+
+	_, err = createBadGitHubClient(t).ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListOpenPullRequestsPager(t *testing.T) {
+	ctx := context.Background()
+	head := &github.PullRequestBranch{Ref: github.String("feature")}
+	base := &github.PullRequestBranch{Ref: github.String("main")}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pullRequests []*github.PullRequest
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/pulls?page=1&per_page=1&state=open":
+			w.Header().Add("Link", fmt.Sprintf("<%s/repos/jfrog/repo-1/pulls?page=2&per_page=1&state=open>; rel=\"next\"", "http://"+r.Host))
+			pullRequests = []*github.PullRequest{{Number: github.Int(1), Head: head, Base: base}}
+		case "/repos/jfrog/repo-1/pulls?page=2&per_page=1&state=open":
+			pullRequests = []*github.PullRequest{{Number: github.Int(2), Head: head, Base: base}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(pullRequests)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	pager := client.ListOpenPullRequestsPager(owner, repo1, PullRequestFilter{PerPage: 1})
+	page, err := pager.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, int64(1), page[0].ID)
+	assert.True(t, pager.HasNext())
+
+	pullRequests, err := pager.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, pullRequests, 1)
+	assert.Equal(t, int64(2), pullRequests[0].ID)
+
+	pullRequests, err = client.ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{PerPage: 1})
+	require.NoError(t, err)
+	assert.Len(t, pullRequests, 2)
+}
+
+func TestGitHubClient_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	response := []*github.CommitFile{
+		{Filename: github.String("go.mod"), Status: github.String("modified"), Additions: github.Int(2), Deletions: github.Int(1)},
+		{Filename: github.String("new.go"), PreviousFilename: github.String("old.go"), Status: github.String("renamed")},
+		{Filename: github.String("go.sum"), Status: github.String("removed"), Deletions: github.Int(10)},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		"/repos/jfrog/repo-1/pulls/1/files?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "go.mod", Status: FileModified, Additions: 2, Deletions: 1},
+		{Path: "new.go", PreviousPath: "old.go", Status: FileRenamed},
+		{Path: "go.sum", Status: FileDeleted, Deletions: 10},
+	}, files)
+
+	_, err = createBadGitHubClient(t).ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	expectedDiff := "diff --git a/go.mod b/go.mod\n"
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []byte(expectedDiff), "/repos/jfrog/repo-1/pulls/1", createGitHubHandler)
+	defer cleanUp()
+
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDiff, diff)
+
+	_, err = createBadGitHubClient(t).GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListPullRequestCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "commit_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		"/repos/jfrog/repo-1/pulls/1/commits?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	commits, err := client.ListPullRequestCommits(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []CommitInfo{{
+		Hash:          "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		AuthorName:    "Monalisa Octocat",
+		CommitterName: "Joconde Octocat",
+		Url:           "https://api.github.com/repos/octocat/Hello-World/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Timestamp:     1302796850,
+		Message:       "Fix all the bugs",
+		ParentHashes:  []string{"6dcb09b5b57875f334f61aebed695e2e4193db5e"},
+	}}, commits)
+
+	_, err = createBadGitHubClient(t).ListPullRequestCommits(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetPullRequestByID(t *testing.T) {
+	ctx := context.Background()
+	forkRepo := &github.Repository{Name: github.String("fork-repo"), Owner: &github.User{Login: github.String("forker")}}
+	targetRepo := &github.Repository{Name: github.String(repo1), Owner: &github.User{Login: github.String(owner)}}
+	response := &github.PullRequest{
+		Number:    github.Int(1),
+		Title:     github.String("Fix all the bugs"),
+		Body:      github.String("Pull request body"),
+		State:     github.String("closed"),
+		Merged:    github.Bool(true),
+		Draft:     github.Bool(false),
+		User:      &github.User{Login: github.String("octocat")},
+		Labels:    []*github.Label{{Name: github.String("bug")}},
+		Mergeable: github.Bool(true),
+		Head:      &github.PullRequestBranch{Ref: github.String("new-topic"), Repo: forkRepo},
+		Base:      &github.PullRequestBranch{Ref: github.String("master"), Repo: targetRepo},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		"/repos/jfrog/repo-1/pulls/1", createGitHubHandler)
+	defer cleanUp()
+
+	pullRequest, err := client.GetPullRequestByID(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestInfo{
+		ID:        1,
+		Source:    BranchInfo{Name: "new-topic", Repository: "fork-repo", Owner: "forker"},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		Title:     "Fix all the bugs",
+		Body:      "Pull request body",
+		State:     PullRequestMerged,
+		Author:    "octocat",
+		Labels:    []string{"bug"},
+		Mergeable: github.Bool(true),
+	}, pullRequest)
+
+	_, err = createBadGitHubClient(t).GetPullRequestByID(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetPullRequestDetails(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/pulls/1":
+			response = `{"number":1,"title":"Fix all the bugs","state":"open","user":{"login":"octocat"},"labels":[{"name":"bug"}],` +
+				`"head":{"ref":"feature","repo":{"name":"repo-1","owner":{"login":"jfrog"}}},"base":{"ref":"master","repo":{"name":"repo-1","owner":{"login":"jfrog"}}}}`
+		case "GET /repos/jfrog/repo-1/pulls/1/commits?page=1&per_page=100":
+			response = `[{"sha":"base","commit":{"message":"first"}},{"sha":"head","commit":{"message":"second"},"parents":[{"sha":"base"}]}]`
+		case "GET /repos/jfrog/repo-1/pulls/1/files?page=1&per_page=100":
+			response = `[{"filename":"README.md","status":"modified","additions":1,"deletions":2}]`
+		case "GET /repos/jfrog/repo-1/commits/head/status?page=1&per_page=100":
+			response = `{"statuses":[{"state":"success","context":"build"}]}`
+		case "GET /repos/jfrog/repo-1/commits/head/check-runs?page=1&per_page=100":
+			response = `{"total_count":0,"check_runs":[]}`
+		case "GET /repos/jfrog/repo-1/pulls/1/reviews?page=1&per_page=100":
+			response = `[{"state":"APPROVED","user":{"login":"reviewer"}},{"state":"PENDING","user":{"login":"pending"}}]`
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	details, err := client.GetPullRequestDetails(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix all the bugs", details.Title)
+	assert.Equal(t, PullRequestOpen, details.State)
+	assert.Equal(t, []string{"bug"}, details.Labels)
+	assert.Equal(t, "head", details.HeadCommit.Hash)
+	assert.Equal(t, []PullRequestFile{{Path: "README.md", Status: FileModified, Additions: 1, Deletions: 2}}, details.Files)
+	assert.Equal(t, []PullRequestReviewInfo{{Reviewer: "reviewer", State: ReviewApprove}}, details.Reviews)
+	require.Len(t, details.Statuses, 1)
+	assert.Equal(t, Pass, details.Statuses[0].State)
+
+	_, err = createBadGitHubClient(t).GetPullRequestDetails(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetPullRequestDetailsGraphQL(t *testing.T) {
+	ctx := context.Background()
+	var graphQLRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "POST /graphql":
+			atomic.AddInt32(&graphQLRequests, 1)
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var request gitHubGraphQLRequest
+			assert.NoError(t, json.Unmarshal(b, &request))
+			assert.Contains(t, request.Query, "pullRequest(number: $number)")
+			switch request.Variables["number"] {
+			case float64(1):
+				response = `{"data":{"repository":{"pullRequest":{"number":1,"title":"Fix all the bugs","state":"OPEN","mergeable":"MERGEABLE",
+					"author":{"login":"octocat"},"headRefName":"feature","headRepository":{"name":"fork-repo","owner":{"login":"forker"}},
+					"baseRefName":"master","baseRepository":{"name":"repo-1","owner":{"login":"jfrog"}},
+					"labels":{"nodes":[{"name":"bug"}]},
+					"files":{"pageInfo":{"hasNextPage":false},"nodes":[{"path":"old.go","additions":0,"deletions":3,"changeType":"DELETED"}]},
+					"reviews":{"nodes":[{"state":"CHANGES_REQUESTED","author":{"login":"reviewer"}},{"state":"DISMISSED","author":{"login":"dismissed"}}]},
+					"commits":{"nodes":[{"commit":{"oid":"head","message":"second","committedDate":"2023-01-02T03:04:05Z","author":{"name":"octocat"},
+						"committer":{"name":"octocat"},"parents":{"nodes":[{"oid":"base"}]},
+						"statusCheckRollup":{"contexts":{"nodes":[
+							{"__typename":"StatusContext","context":"build","state":"SUCCESS"},
+							{"__typename":"CheckRun","name":"scan","status":"COMPLETED","conclusion":"FAILURE"}]}}}}]}}}}}`
+			case float64(2):
+				response = `{"data":{"repository":{"pullRequest":{"number":2,
+					"labels":{"pageInfo":{"hasNextPage":true},"nodes":[{"name":"bug"}]},
+					"files":{"pageInfo":{"hasNextPage":true},"nodes":[]},
+					"reviews":{"pageInfo":{"hasNextPage":true},"nodes":[]},
+					"commits":{"nodes":[{"commit":{"oid":"head-2","statusCheckRollup":{"contexts":{"pageInfo":{"hasNextPage":true},"nodes":[]}}}}]}}}}}`
+			default:
+				response = `{"data":{"repository":{"pullRequest":null}}}`
+			}
+		case "GET /repos/jfrog/repo-1/pulls/2/files?page=1&per_page=100":
+			response = `[{"filename":"main.go","status":"added"}]`
+		case "GET /repos/jfrog/repo-1/issues/2/labels?page=1":
+			response = `[{"name":"bug"},{"name":"security"}]`
+		case "GET /repos/jfrog/repo-1/pulls/2/reviews?page=1&per_page=100":
+			response = `[{"state":"APPROVED","user":{"login":"reviewer"}}]`
+		case "GET /repos/jfrog/repo-1/commits/head-2/status?page=1&per_page=100":
+			response = `{"statuses":[{"state":"success","context":"build"}]}`
+		case "GET /repos/jfrog/repo-1/commits/head-2/check-runs?page=1&per_page=100":
+			response = `{"total_count":0,"check_runs":[]}`
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).UseGraphQL(true).Build()
+	require.NoError(t, err)
+
+	details, err := client.GetPullRequestDetails(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&graphQLRequests))
+	assert.Equal(t, PullRequestInfo{
+		ID:        1,
+		Source:    BranchInfo{Name: "feature", Repository: "fork-repo", Owner: "forker"},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		Title:     "Fix all the bugs",
+		State:     PullRequestOpen,
+		Author:    "octocat",
+		Labels:    []string{"bug"},
+		Mergeable: github.Bool(true),
+	}, details.PullRequestInfo)
+	assert.Equal(t, "head", details.HeadCommit.Hash)
+	assert.Equal(t, []string{"base"}, details.HeadCommit.ParentHashes)
+	assert.Equal(t, []PullRequestFile{{Path: "old.go", Status: FileDeleted, Deletions: 3}}, details.Files)
+	assert.Equal(t, []PullRequestReviewInfo{{Reviewer: "reviewer", State: ReviewRequestChanges}}, details.Reviews)
+	require.Len(t, details.Statuses, 2)
+	assert.Equal(t, Pass, details.Statuses[0].State)
+	assert.Equal(t, "build", details.Statuses[0].Title)
+	assert.Equal(t, Fail, details.Statuses[1].State)
+	assert.Equal(t, "scan", details.Statuses[1].Title)
+
+	// More than 100 labels, changed files, reviews and commit statuses are listed using the REST API
+	details, err = client.GetPullRequestDetails(ctx, owner, repo1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bug", "security"}, details.Labels)
+	assert.Equal(t, []PullRequestFile{{Path: "main.go", Status: FileAdded}}, details.Files)
+	assert.Equal(t, []PullRequestReviewInfo{{Reviewer: "reviewer", State: ReviewApprove}}, details.Reviews)
+	require.Len(t, details.Statuses, 1)
+	assert.Equal(t, "build", details.Statuses[0].Title)
+
+	_, err = client.GetPullRequestDetails(ctx, owner, repo1, 3)
+	assert.EqualError(t, err, "pull request 3 wasn't found in jfrog/repo-1")
+}
+
+func TestGitHubClient_GetPullRequestMergeableState(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/pulls/1":
+			response = `{"number":1,"mergeable":true,"mergeable_state":"blocked","base":{"ref":"master"},"head":{"ref":"feature","sha":"6dcb09b"}}`
+		case "/repos/jfrog/repo-1/compare/master...6dcb09b?per_page=1":
+			response = `{"ahead_by":1,"behind_by":2}`
+		case "/repos/jfrog/repo-1/branches/master/protection":
+			response = `{"required_status_checks":{"contexts":["build","scan"]},"required_pull_request_reviews":{"required_approving_review_count":2}}`
+		case "/repos/jfrog/repo-1/commits/6dcb09b/status?page=1&per_page=100":
+			response = `{"statuses":[{"context":"build","state":"success"},{"context":"scan","state":"pending"}]}`
+		case "/repos/jfrog/repo-1/commits/6dcb09b/check-runs?page=1&per_page=100":
+			response = `{"total_count":0,"check_runs":[]}`
+		case "/repos/jfrog/repo-1/pulls/1/reviews?page=1&per_page=100":
+			response = `[
+				{"user":{"login":"octocat"},"state":"CHANGES_REQUESTED"},
+				{"user":{"login":"octocat"},"state":"APPROVED"},
+				{"user":{"login":"octocat"},"state":"COMMENTED"},
+				{"user":{"login":"frogger"},"state":"APPROVED"},
+				{"user":{"login":"frogger"},"state":"DISMISSED"}
+			]`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+			return
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	state, err := client.GetPullRequestMergeableState(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestMergeableState{
+		BehindTarget:        true,
+		MissingStatusChecks: []string{"scan"},
+		MissingApprovals:    1,
+	}, state)
+
+	_, err = createBadGitHubClient(t).GetPullRequestMergeableState(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ListPullRequestComments(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_comments_list_response.json"))
@@ -553,6 +2758,169 @@ func TestGitHubClient_ListPullRequestComments(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.IssueComment{},
+		"/repos/jfrog/repo-1/issues/comments/10", http.StatusOK, []byte(`{"body":"New content"}`+"\n"), http.MethodPatch,
+		createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, "New content", 1, 10)
+	assert.NoError(t, err)
+
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "", 1, 10)
+	assert.Error(t, err)
+
+	err = createBadGitHubClient(t).UpdatePullRequestComment(ctx, owner, repo1, "New content", 1, 10)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DeletePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false, []byte{},
+		"/repos/jfrog/repo-1/issues/comments/10", http.StatusNoContent, createGitHubHandler)
+	defer cleanUp()
+
+	err := client.DeletePullRequestComment(ctx, owner, repo1, 1, 10)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).DeletePullRequestComment(ctx, owner, repo1, 1, 10)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CommentReactions(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "POST /repos/jfrog/repo-1/issues/comments/10/reactions":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"content":"+1"}`, string(b))
+			w.WriteHeader(http.StatusCreated)
+			response = []byte(`{"id":1,"content":"+1"}`)
+		case "GET /repos/jfrog/repo-1/issues/comments/10/reactions?page=1&per_page=100":
+			response = []byte(`[{"id":1,"content":"+1","user":{"login":"frogger"}},{"id":2,"content":"rocket","user":{"login":"octocat"}}]`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.AddCommentReaction(ctx, owner, repo1, 1, 10, ReactionThumbsUp)
+	assert.NoError(t, err)
+
+	reactions, err := client.ListCommentReactions(ctx, owner, repo1, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReactionInfo{{Reaction: ReactionThumbsUp, User: "frogger"}, {Reaction: ReactionRocket, User: "octocat"}}, reactions)
+
+	err = client.AddCommentReaction(ctx, owner, repo1, 1, 10, "")
+	assert.Error(t, err)
+
+	_, err = createBadGitHubClient(t).ListCommentReactions(ctx, owner, repo1, 1, 10)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_AddPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/pulls/1":
+			response = []byte(`{"number":1,"head":{"sha":"head-sha"}}`)
+		case "POST /repos/jfrog/repo-1/pulls/1/comments":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"body":"Use a constant","path":"main.go","commit_id":"head-sha",`+
+				`"start_line":3,"line":5,"start_side":"RIGHT","side":"RIGHT"}`, string(b))
+			w.WriteHeader(http.StatusCreated)
+			response = []byte(`{"id":1}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	comment := PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go", Line: 5, StartLine: 3}
+	err := client.AddPullRequestReviewComment(ctx, owner, repo1, 1, comment)
+	assert.NoError(t, err)
+
+	err = client.AddPullRequestReviewComment(ctx, owner, repo1, 1, PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go"})
+	assert.Error(t, err)
+
+	err = createBadGitHubClient(t).AddPullRequestReviewComment(ctx, owner, repo1, 1, comment)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":10,"body":"Use a constant","path":"main.go","start_line":3,"line":5,"created_at":"2011-04-14T16:00:49Z"},` +
+		`{"id":11,"body":"Outdated","path":"go.mod","original_line":7,"created_at":"2011-04-14T16:00:49Z"}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/pulls/1/comments?page=1&per_page=100", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	expectedCreated, err := time.Parse(time.RFC3339, "2011-04-14T16:00:49Z")
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewComment{
+		{CommentInfo: CommentInfo{ID: 10, Content: "Use a constant", Created: expectedCreated}, Path: "main.go", Line: 5, StartLine: 3},
+		{CommentInfo: CommentInfo{ID: 11, Content: "Outdated", Created: expectedCreated}, Path: "go.mod", Line: 7},
+	}, result)
+
+	_, err = createBadGitHubClient(t).ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_PullRequestThreads(t *testing.T) {
+	ctx := context.Background()
+	var mutations []gitHubGraphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/pulls/1":
+			response = `{"number":1,"node_id":"PR_kwDOA"}`
+		case "POST /graphql":
+			var request gitHubGraphQLRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			mutations = append(mutations, request)
+			response = `{"data":{"addPullRequestReviewThread":{"thread":{"id":"PRRT_kwDOA"}}}}`
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	threadID, err := client.CreatePullRequestThread(ctx, owner, repo1, 1,
+		PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go", Line: 5, StartLine: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "PRRT_kwDOA", threadID)
+	require.Len(t, mutations, 1)
+	assert.Contains(t, mutations[0].Query, "addPullRequestReviewThread")
+	assert.Equal(t, map[string]interface{}{"id": "PR_kwDOA", "body": "Use a constant", "path": "main.go", "line": float64(5),
+		"startLine": float64(3), "startSide": "RIGHT"}, mutations[0].Variables)
+
+	require.NoError(t, client.ResolvePullRequestThread(ctx, owner, repo1, 1, threadID, true))
+	require.NoError(t, client.ResolvePullRequestThread(ctx, owner, repo1, 1, threadID, false))
+	require.Len(t, mutations, 3)
+	assert.Contains(t, mutations[1].Query, "resolveReviewThread")
+	assert.Contains(t, mutations[2].Query, "unresolveReviewThread")
+	assert.Equal(t, map[string]interface{}{"id": "PRRT_kwDOA"}, mutations[2].Variables)
+
+	_, err = client.CreatePullRequestThread(ctx, owner, repo1, 1, PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Please update the changelog"}})
+	assert.ErrorIs(t, err, errGitHubPullRequestThreadPathRequired)
+}
+
 func TestGitHubClient_UnlabelPullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, &github.PullRequest{}, fmt.Sprintf("/repos/jfrog/repo-1/issues/1/labels/%s", url.PathEscape(labelName)), createGitHubHandler)
@@ -583,6 +2951,56 @@ func TestGitHubClient_UploadScanningAnalysis(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListSecurityAlerts(t *testing.T) {
+	ctx := context.Background()
+	dependabotAlertsURI := "/repos/jfrog/%s/dependabot/alerts?state=open&per_page=100"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case fmt.Sprintf(dependabotAlertsURI, repo1):
+			w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com%s&after=cursor1>; rel="next"`, r.RequestURI))
+			response = `[{"number":1,"html_url":"https://github.com/jfrog/repo-1/security/dependabot/1","dependency":{"package":{"name":"lodash"},"manifest_path":"package-lock.json"},` +
+				`"security_advisory":{"ghsa_id":"GHSA-p6mc-m468-83gw","cve_id":"CVE-2020-8203","summary":"Prototype Pollution in lodash","severity":"high"}}]`
+		case fmt.Sprintf(dependabotAlertsURI, repo1) + "&after=cursor1":
+			response = `[{"number":2,"dependency":{"package":{"name":"minimist"}},"security_advisory":{"ghsa_id":"GHSA-xvch-5gv4-984h","severity":"critical"}}]`
+		case "/repos/jfrog/repo-1/code-scanning/alerts?page=1&per_page=100&state=open":
+			response = `[{"number":3,"html_url":"https://github.com/jfrog/repo-1/security/code-scanning/3","rule":{"id":"go/sql-injection","severity":"error",` +
+				`"description":"Database query built from user-controlled sources"},"most_recent_instance":{"location":{"path":"main.go"}}},` +
+				`{"number":4,"rule":{"id":"go/unused-variable","severity":"note","description":"Unused variable"}}]`
+		case fmt.Sprintf(dependabotAlertsURI, repo2):
+			response = "[]"
+		case "/repos/jfrog/repo-2/code-scanning/alerts?page=1&per_page=100&state=open":
+			w.WriteHeader(http.StatusNotFound)
+			response = `{"message":"no analysis found"}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	alerts, err := client.ListSecurityAlerts(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []SecurityAlertInfo{
+		{ID: 1, Type: DependencyAlert, Severity: SeverityHigh, Title: "Prototype Pollution in lodash", Identifier: "CVE-2020-8203", Package: "lodash",
+			Path: "package-lock.json", URL: "https://github.com/jfrog/repo-1/security/dependabot/1"},
+		{ID: 2, Type: DependencyAlert, Severity: SeverityCritical, Identifier: "GHSA-xvch-5gv4-984h", Package: "minimist"},
+		{ID: 3, Type: CodeScanningAlert, Severity: SeverityHigh, Title: "Database query built from user-controlled sources", Identifier: "go/sql-injection",
+			Path: "main.go", URL: "https://github.com/jfrog/repo-1/security/code-scanning/3"},
+		{ID: 4, Type: CodeScanningAlert, Severity: SeverityInfo, Title: "Unused variable", Identifier: "go/unused-variable"},
+	}, alerts)
+
+	// Repositories without code scanning analyses have no code scanning alerts
+	alerts, err = client.ListSecurityAlerts(ctx, owner, repo2)
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+
+	_, err = createBadGitHubClient(t).ListSecurityAlerts(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_GetRepositoryEnvironmentInfo(t *testing.T) {
 	ctx := context.Background()
 
@@ -601,6 +3019,67 @@ func TestGitHubClient_GetRepositoryEnvironmentInfo(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_Deployments(t *testing.T) {
+	ctx := context.Background()
+	var deploymentRequest github.DeploymentRequest
+	var statusRequest github.DeploymentStatusRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/environments?page=1&per_page=100":
+			response = `{"total_count":2,"environments":[{"name":"staging","url":"https://api.github.com/repos/jfrog/repo-1/environments/staging"},
+				{"name":"production","url":"https://api.github.com/repos/jfrog/repo-1/environments/production",
+				"protection_rules":[{"type":"required_reviewers","reviewers":[{"type":"User","reviewer":{"login":"frogger"}}]}]}]}`
+		case "/repos/jfrog/repo-1/deployments":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&deploymentRequest))
+			response = `{"id":42}`
+		case "/repos/jfrog/repo-1/deployments/42/statuses":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&statusRequest))
+			response = `{"id":1}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	environments, err := client.ListEnvironments(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryEnvironmentInfo{
+		{Name: "staging", Url: "https://api.github.com/repos/jfrog/repo-1/environments/staging"},
+		{Name: "production", Url: "https://api.github.com/repos/jfrog/repo-1/environments/production", Reviewers: []string{"frogger"}},
+	}, environments)
+
+	deploymentID, err := client.CreateDeployment(ctx, owner, repo1, DeploymentInfo{Environment: "production", Ref: "v1.0.0", Description: "Release 1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), deploymentID)
+	assert.Equal(t, "production", deploymentRequest.GetEnvironment())
+	assert.Equal(t, "v1.0.0", deploymentRequest.GetRef())
+	assert.Equal(t, "Release 1.0.0", deploymentRequest.GetDescription())
+	assert.False(t, deploymentRequest.GetAutoMerge())
+	assert.Equal(t, []string{}, deploymentRequest.GetRequiredContexts())
+
+	err = client.SetDeploymentStatus(ctx, owner, repo1, deploymentID, DeploymentStatusInfo{State: InProgress, LogURL: "https://ci.example.com/deploy/1"})
+	require.NoError(t, err)
+	assert.Equal(t, "in_progress", statusRequest.GetState())
+	assert.Equal(t, "https://ci.example.com/deploy/1", statusRequest.GetLogURL())
+	assert.Nil(t, statusRequest.EnvironmentURL)
+
+	err = client.SetDeploymentStatus(ctx, owner, repo1, deploymentID, DeploymentStatusInfo{State: Pass, EnvironmentURL: "https://app.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "success", statusRequest.GetState())
+	assert.Equal(t, "https://app.example.com", statusRequest.GetEnvironmentURL())
+
+	_, err = client.CreateDeployment(ctx, owner, repo1, DeploymentInfo{Ref: "v1.0.0"})
+	assert.Error(t, err)
+	_, err = createBadGitHubClient(t).ListEnvironments(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ExtractGitHubEnvironmentReviewers(t *testing.T) {
 	reviewer1, reviewer2 := "reviewer-1", "reviewer-2"
 	environment := &github.Environment{
@@ -738,3 +3217,12 @@ func createGitHubSarifUploadHandler(t *testing.T, _ string, _ []byte, _ int) htt
 		}
 	}
 }
+
+func TestGitHubClient_CodeInsights(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.GitHub).Build()
+	require.NoError(t, err)
+	err = client.CreateCodeInsightsReport(context.Background(), owner, repo1, "abc123", CodeInsightsReport{ID: "report"})
+	assert.ErrorIs(t, err, errGitHubCodeInsightsNotSupported)
+	err = client.AddCodeInsightsAnnotations(context.Background(), owner, repo1, "abc123", "report", nil)
+	assert.ErrorIs(t, err, errGitHubCodeInsightsNotSupported)
+}
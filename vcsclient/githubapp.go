@@ -0,0 +1,193 @@
+package vcsclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"golang.org/x/oauth2"
+)
+
+const (
+	gitHubDefaultAPIEndpoint = "https://api.github.com"
+	// GitHub rejects app JWTs that expire in more than 10 minutes
+	gitHubAppJWTExpiration = 9 * time.Minute
+	// Installation tokens are refreshed when they expire in less than this margin, so requests don't fail with an expired token
+	gitHubAppTokenRefreshMargin = 5 * time.Minute
+	// The maximum time to create an installation token, so that a stalled GitHub API doesn't block the requests that need it
+	gitHubAppTokenTimeout = time.Minute
+)
+
+var errGitHubAppInvalidPrivateKey = errors.New("the private key of the GitHub App must be a PEM encoded RSA private key")
+
+// GitHubAppInfo is the details of a GitHub App installation, to authenticate as the app instead of using a token
+type GitHubAppInfo struct {
+	AppID          int64
+	InstallationID int64
+	// The PEM encoded private key of the app
+	PrivateKey []byte
+}
+
+// gitHubAppTokenSource mints installation access tokens of a GitHub App, and refreshes them before they expire
+type gitHubAppTokenSource struct {
+	appInfo     GitHubAppInfo
+	privateKey  *rsa.PrivateKey
+	apiEndpoint string
+	httpClient  *http.Client
+	mutex       sync.Mutex
+	token       *oauth2.Token
+}
+
+func newGitHubAppTokenSource(vcsInfo VcsInfo, logger Log) (*gitHubAppTokenSource, error) {
+	appInfo := *vcsInfo.GitHubApp
+	if appInfo.AppID == 0 || appInfo.InstallationID == 0 {
+		return nil, errors.New("the app ID and the installation ID of the GitHub App are required")
+	}
+	privateKey, err := parseRSAPrivateKey(appInfo.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	apiEndpoint := strings.TrimSuffix(vcsInfo.APIEndpoint, "/")
+	if apiEndpoint == "" {
+		apiEndpoint = gitHubDefaultAPIEndpoint
+	}
+	return &gitHubAppTokenSource{
+		appInfo:     appInfo,
+		privateKey:  privateKey,
+		apiEndpoint: apiEndpoint,
+		httpClient:  newHTTPClient(vcsInfo, logger),
+	}, nil
+}
+
+func parseRSAPrivateKey(pemKey []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, errGitHubAppInvalidPrivateKey
+	}
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errGitHubAppInvalidPrivateKey
+	}
+	rsaPrivateKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errGitHubAppInvalidPrivateKey
+	}
+	return rsaPrivateKey, nil
+}
+
+// Token returns the current installation access token, or mints a new one if it expires soon
+func (source *gitHubAppTokenSource) Token() (*oauth2.Token, error) {
+	return source.tokenWithContext(context.Background())
+}
+
+// tokenWithContext is like Token, but mints the installation access token within the context of the request that needs it
+func (source *gitHubAppTokenSource) tokenWithContext(ctx context.Context) (*oauth2.Token, error) {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	if source.token != nil && time.Until(source.token.Expiry) > gitHubAppTokenRefreshMargin {
+		return source.token, nil
+	}
+	token, err := source.createInstallationToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	source.token = token
+	return token, nil
+}
+
+func (source *gitHubAppTokenSource) createInstallationToken(ctx context.Context) (*oauth2.Token, error) {
+	appJWT, err := source.createJWT(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, gitHubAppTokenTimeout)
+	defer cancel()
+	tokenURL := fmt.Sprintf("%s/app/installations/%d/access_tokens", source.apiEndpoint, source.appInfo.InstallationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+appJWT)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	response, err := source.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusCreated); err != nil {
+		return nil, fmt.Errorf("failed to create an installation access token of the GitHub App: %w", err)
+	}
+	var installationToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err = json.NewDecoder(response.Body).Decode(&installationToken); err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{AccessToken: installationToken.Token, Expiry: installationToken.ExpiresAt}, nil
+}
+
+// contextTokenSource is a token source that creates its tokens within the context of the request that needs them
+type contextTokenSource interface {
+	tokenWithContext(ctx context.Context) (*oauth2.Token, error)
+}
+
+// boundTokenSource is an oauth2.TokenSource that creates the tokens of a contextTokenSource within a context
+type boundTokenSource struct {
+	ctx    context.Context
+	source contextTokenSource
+}
+
+func (source boundTokenSource) Token() (*oauth2.Token, error) {
+	return source.source.tokenWithContext(source.ctx)
+}
+
+// withTokenContext returns a token source that creates the tokens within the context, if the token source supports it
+func withTokenContext(ctx context.Context, tokenSource oauth2.TokenSource) oauth2.TokenSource {
+	if source, ok := tokenSource.(contextTokenSource); ok {
+		return boundTokenSource{ctx: ctx, source: source}
+	}
+	return tokenSource
+}
+
+// createJWT creates the JSON Web Token that authenticates as the app, signed by its private key
+func (source *gitHubAppTokenSource) createJWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	// The issue time is set in the past to allow for clock drift
+	claims, err := json.Marshal(map[string]int64{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(gitHubAppJWTExpiration).Unix(),
+		"iss": source.appInfo.AppID,
+	})
+	if err != nil {
+		return "", err
+	}
+	unsignedToken := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsignedToken))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, source.privateKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+	return unsignedToken + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
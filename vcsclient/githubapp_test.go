@@ -0,0 +1,144 @@
+package vcsclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGitHubAppID          int64 = 1234
+	testGitHubInstallationID int64 = 5678
+)
+
+func TestGitHubClient_GitHubApp(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+
+	tokenRequests := 0
+	tokenExpiration := time.Minute
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case fmt.Sprintf("/app/installations/%d/access_tokens", testGitHubInstallationID):
+			assert.Equal(t, http.MethodPost, r.Method)
+			assertGitHubAppJWT(t, &privateKey.PublicKey, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+			tokenRequests++
+			w.WriteHeader(http.StatusCreated)
+			_, err := fmt.Fprintf(w, `{"token":"installation-token-%d","expires_at":%q}`, tokenRequests, time.Now().Add(tokenExpiration).Format(time.RFC3339))
+			assert.NoError(t, err)
+		case "/zen":
+			assert.Equal(t, fmt.Sprintf("Bearer installation-token-%d", tokenRequests), r.Header.Get("Authorization"))
+		default:
+			assert.Fail(t, "unexpected request URI", r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).
+		GitHubApp(GitHubAppInfo{AppID: testGitHubAppID, InstallationID: testGitHubInstallationID, PrivateKey: pemKey}).Build()
+	require.NoError(t, err)
+
+	// Installation tokens that are about to expire are refreshed
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.Equal(t, 2, tokenRequests)
+
+	// Otherwise, the installation token is reused
+	tokenExpiration = time.Hour
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.Equal(t, 3, tokenRequests)
+
+	// PKCS8 private keys are supported
+	pkcs8Key, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	_, err = NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).
+		GitHubApp(GitHubAppInfo{AppID: testGitHubAppID, InstallationID: testGitHubInstallationID, PrivateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Key})}).Build()
+	assert.NoError(t, err)
+}
+
+func TestGitHubClient_GitHubAppErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+
+	_, err = NewClientBuilder(vcsutils.GitHub).GitHubApp(GitHubAppInfo{AppID: testGitHubAppID, InstallationID: testGitHubInstallationID, PrivateKey: []byte("invalid")}).Build()
+	assert.ErrorIs(t, err, errGitHubAppInvalidPrivateKey)
+	_, err = NewClientBuilder(vcsutils.GitHub).GitHubApp(GitHubAppInfo{AppID: testGitHubAppID, PrivateKey: pemKey}).Build()
+	assert.Error(t, err)
+
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).
+		GitHubApp(GitHubAppInfo{AppID: testGitHubAppID, InstallationID: testGitHubInstallationID, PrivateKey: pemKey}).Build()
+	require.NoError(t, err)
+	err = client.TestConnection(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create an installation access token of the GitHub App")
+}
+
+func TestGitHubClient_GitHubAppStalledTokenRequest(t *testing.T) {
+	// The installation access token request never gets a response
+	stalled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-stalled:
+		}
+	}))
+	defer server.Close()
+	defer close(stalled)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).
+		GitHubApp(GitHubAppInfo{AppID: testGitHubAppID, InstallationID: testGitHubInstallationID, PrivateKey: pemKey}).Build()
+	require.NoError(t, err)
+
+	// The token request is canceled with the context of the call
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = client.TestConnection(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
+func assertGitHubAppJWT(t *testing.T, publicKey *rsa.PublicKey, appJWT string) {
+	parts := strings.Split(appJWT, ".")
+	require.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature))
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]int64
+	require.NoError(t, json.Unmarshal(claimsJSON, &claims))
+	assert.Equal(t, testGitHubAppID, claims["iss"])
+	assert.Less(t, claims["iat"], time.Now().Unix())
+	assert.LessOrEqual(t, claims["exp"], time.Now().Add(10*time.Minute).Unix())
+}
@@ -1,19 +1,32 @@
 package vcsclient
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-retryablehttp"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/xanzy/go-gitlab"
+	"golang.org/x/oauth2"
 )
 
+// Similar to the default retries of the GitLab client, used if no retry policy is set
+var gitLabDefaultRetryPolicy = RetryPolicy{MaxAttempts: 6, InitialBackoff: 100 * time.Millisecond}
+
+var errGitLabWebhookDeactivationNotSupported = errors.New("deactivating webhooks is not supported on GitLab")
+var errGitLabWebhookFormContentTypeNotSupported = errors.New("webhook payloads are always sent as JSON on GitLab")
+var errGitLabGroupWebhookBranchNotSupported = errors.New("filtering the branches of group webhooks is not supported on GitLab")
+var errGitLabRepositoryLanguageFilterNotSupported = errors.New("filtering repositories by language is not supported on GitLab")
+
 // GitLabClient API version 4
 type GitLabClient struct {
 	glClient *gitlab.Client
@@ -22,13 +35,25 @@ type GitLabClient struct {
 
 // NewGitLabClient create a new GitLabClient
 func NewGitLabClient(vcsInfo VcsInfo, logger Log) (*GitLabClient, error) {
-	var client *gitlab.Client
-	var err error
+	var options []gitlab.ClientOptionFunc
 	if vcsInfo.APIEndpoint != "" {
-		client, err = gitlab.NewClient(vcsInfo.Token, gitlab.WithBaseURL(vcsInfo.APIEndpoint))
-	} else {
-		client, err = gitlab.NewClient(vcsInfo.Token)
+		options = append(options, gitlab.WithBaseURL(vcsInfo.APIEndpoint))
+	}
+	if vcsInfo.RetryPolicy.MaxAttempts < 2 {
+		vcsInfo.RetryPolicy = gitLabDefaultRetryPolicy
 	}
+	httpClient := newHTTPClient(vcsInfo, logger)
+	newClient, token := gitlab.NewClient, vcsInfo.Token
+	if vcsInfo.TokenSource != nil {
+		// The OAuth tokens are set by the HTTP client, which refreshes them when they expire
+		httpClient = newTokenSourceHTTPClient(vcsInfo, logger, oauth2.ReuseTokenSource(nil, vcsInfo.TokenSource))
+		newClient, token = gitlab.NewOAuthClient, ""
+	}
+	// The retries of the GitLab client are replaced by the retry policy
+	options = append(options,
+		gitlab.WithHTTPClient(httpClient),
+		gitlab.WithoutRetries())
+	client, err := newClient(token, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +70,15 @@ func (client *GitLabClient) TestConnection(ctx context.Context) error {
 	return err
 }
 
+// GetRateLimitInfo on GitLab. GitLab reports the rate limits in the headers of every response.
+func (client *GitLabClient) GetRateLimitInfo(ctx context.Context) (RateLimitInfo, error) {
+	_, response, err := client.glClient.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return RateLimitInfo{}, err
+	}
+	return getRateLimitInfo(response.Header), nil
+}
+
 // ListRepositories on GitLab
 func (client *GitLabClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
 	simple := true
@@ -67,6 +101,76 @@ func (client *GitLabClient) ListRepositories(ctx context.Context) (map[string][]
 	return results, nil
 }
 
+// ListRepositoriesWithOptions on GitLab. The projects of subgroups aren't returned.
+func (client *GitLabClient) ListRepositoriesWithOptions(ctx context.Context, owner string, options ListRepositoriesOptions) ([]RepositoryDetails, error) {
+	if options.Language != "" {
+		return nil, errGitLabRepositoryLanguageFilterNotSupported
+	}
+	return listPageOrAll(ctx, client.listRepositoriesPager(owner, options), options.Page)
+}
+
+func (client *GitLabClient) listRepositoriesPager(owner string, options ListRepositoriesOptions) *Pager[RepositoryDetails] {
+	listOptions := gitlab.ListOptions{Page: firstPage(options.Page), PerPage: options.PerPage}
+	var visibility *gitlab.VisibilityValue
+	if options.Visibility != nil {
+		visibility = gitlab.Visibility(getGitLabVisibilityValue(options.Visibility))
+	}
+	// The projects are sorted by their last activity, so the pages after a project updated before UpdatedSince aren't fetched
+	orderBy, sort := gitlab.String("last_activity_at"), gitlab.String("desc")
+	var namespaceKind string
+	return newPager(func(ctx context.Context) ([]RepositoryDetails, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner})
+		if err != nil {
+			return nil, false, err
+		}
+		// The projects of users and groups are listed by different APIs
+		if namespaceKind == "" {
+			namespace, _, err := client.glClient.Namespaces.GetNamespace(owner, gitlab.WithContext(ctx))
+			if err != nil {
+				return nil, false, err
+			}
+			namespaceKind = namespace.Kind
+		}
+		client.logger.Debug("fetching projects page", listOptions.Page, "of", owner)
+		var projects []*gitlab.Project
+		var response *gitlab.Response
+		if namespaceKind == "user" {
+			projects, response, err = client.glClient.Projects.ListUserProjects(owner, &gitlab.ListProjectsOptions{
+				ListOptions: listOptions,
+				Archived:    options.Archived,
+				Visibility:  visibility,
+				OrderBy:     orderBy,
+				Sort:        sort,
+			}, gitlab.WithContext(ctx))
+		} else {
+			projects, response, err = client.glClient.Groups.ListGroupProjects(owner, &gitlab.ListGroupProjectsOptions{
+				ListOptions: listOptions,
+				Archived:    options.Archived,
+				Visibility:  visibility,
+				OrderBy:     orderBy,
+				Sort:        sort,
+			}, gitlab.WithContext(ctx))
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		var results []RepositoryDetails
+		var details RepositoryDetails
+		for _, project := range projects {
+			details = mapGitLabProjectToRepositoryDetails(project)
+			if options.matches(details) {
+				results = append(results, details)
+			}
+		}
+		listOptions.Page = response.NextPage
+		// The details are of the last project in the page
+		if !options.UpdatedSince.IsZero() && details.Updated.Before(options.UpdatedSince) {
+			return results, false, nil
+		}
+		return results, listOptions.Page > 0, nil
+	})
+}
+
 // ListBranches on GitLab
 func (client *GitLabClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
 	branches, _, err := client.glClient.Branches.ListBranches(getProjectID(owner, repository), nil,
@@ -82,6 +186,423 @@ func (client *GitLabClient) ListBranches(ctx context.Context, owner, repository
 	return results, nil
 }
 
+// ListBranchesPager on GitLab
+func (client *GitLabClient) ListBranchesPager(owner, repository string, perPage int) *Pager[string] {
+	options := &gitlab.ListBranchesOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: perPage}}
+	return newPager(func(ctx context.Context) ([]string, bool, error) {
+		branches, response, err := client.glClient.Branches.ListBranches(getProjectID(owner, repository), options,
+			gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]string, 0, len(branches))
+		for _, branch := range branches {
+			results = append(results, branch.Name)
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// ListAllBranches on GitLab
+func (client *GitLabClient) ListAllBranches(ctx context.Context, owner, repository, prefix string) ([]BranchDetails, error) {
+	options := &gitlab.ListBranchesOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: 100}}
+	if prefix != "" {
+		// GitLab matches branches starting with the search term when it begins with '^'
+		options.Search = gitlab.String("^" + prefix)
+	}
+	var results []BranchDetails
+	for options.Page > 0 {
+		branches, response, err := client.glClient.Branches.ListBranches(getProjectID(owner, repository), options,
+			gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			branchDetails := BranchDetails{Name: branch.Name}
+			if branch.Commit != nil {
+				branchDetails.CommitHash = branch.Commit.ID
+			}
+			results = append(results, branchDetails)
+		}
+		options.Page = response.NextPage
+	}
+	return results, nil
+}
+
+// CreateBranch on GitLab
+func (client *GitLabClient) CreateBranch(ctx context.Context, owner, repository, branchName, fromRef string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":       owner,
+		"repository":  repository,
+		"branch name": branchName,
+		"from ref":    fromRef,
+	})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("creating branch", branchName, "from", fromRef)
+	_, _, err = client.glClient.Branches.CreateBranch(getProjectID(owner, repository), &gitlab.CreateBranchOptions{
+		Branch: &branchName,
+		Ref:    &fromRef,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// DeleteBranch on GitLab
+func (client *GitLabClient) DeleteBranch(ctx context.Context, owner, repository, branchName string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch name": branchName})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("deleting branch", branchName)
+	_, err = client.glClient.Branches.DeleteBranch(getProjectID(owner, repository), branchName, gitlab.WithContext(ctx))
+	return err
+}
+
+// ListTags on GitLab
+func (client *GitLabClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListTagsOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: 100}}
+	var results []TagInfo
+	for options.Page > 0 {
+		tags, response, err := client.glClient.Tags.ListTags(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagInfo := TagInfo{Name: tag.Name, Message: tag.Message}
+			if tag.Commit != nil {
+				tagInfo.CommitHash = tag.Commit.ID
+			}
+			results = append(results, tagInfo)
+		}
+		options.Page = response.NextPage
+	}
+	return results, nil
+}
+
+// CreateTag on GitLab
+func (client *GitLabClient) CreateTag(ctx context.Context, owner, repository, tagName, targetSha, message string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"tag name":   tagName,
+		"target sha": targetSha,
+	})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("creating tag", tagName, "on", targetSha)
+	// GitLab creates an annotated tag if the message isn't empty
+	_, _, err = client.glClient.Tags.CreateTag(getProjectID(owner, repository), &gitlab.CreateTagOptions{
+		TagName: &tagName,
+		Ref:     &targetSha,
+		Message: &message,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// CreateRelease on GitLab
+func (client *GitLabClient) CreateRelease(ctx context.Context, owner, repository string, release ReleaseInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tag name": release.TagName})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("creating release", release.TagName)
+	options := &gitlab.CreateReleaseOptions{Name: &release.Name, TagName: &release.TagName, Description: &release.Description}
+	if release.Target != "" {
+		options.Ref = &release.Target
+	}
+	_, _, err = client.glClient.Releases.CreateRelease(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	return err
+}
+
+// GetLatestRelease on GitLab
+func (client *GitLabClient) GetLatestRelease(ctx context.Context, owner, repository string) (*ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	// GitLab sorts the releases by their release date, starting from the latest release
+	releases, _, err := client.glClient.Releases.ListReleases(getProjectID(owner, repository),
+		&gitlab.ListReleasesOptions{Page: 1, PerPage: 1}, gitlab.WithContext(ctx))
+	if err != nil || len(releases) == 0 {
+		return nil, err
+	}
+	return &ReleaseInfo{
+		TagName:     releases[0].TagName,
+		Target:      releases[0].Commit.ID,
+		Name:        releases[0].Name,
+		Description: releases[0].Description,
+	}, nil
+}
+
+// UploadReleaseAsset on GitLab
+func (client *GitLabClient) UploadReleaseAsset(ctx context.Context, owner, repository, tagName, assetPath string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"tag name":   tagName,
+		"asset path": assetPath,
+	})
+	if err != nil {
+		return err
+	}
+	projectID := getProjectID(owner, repository)
+	client.logger.Debug("uploading release asset", assetPath, "to", tagName)
+	// GitLab releases link to their assets, so the file is uploaded to the project before it is linked to the release
+	projectFile, _, err := client.glClient.Projects.UploadFile(projectID, assetPath, gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	project, _, err := client.glClient.Projects.GetProject(projectID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	assetName, assetURL := filepath.Base(assetPath), project.WebURL+projectFile.URL
+	_, _, err = client.glClient.ReleaseLinks.CreateReleaseLink(projectID, tagName, &gitlab.CreateReleaseLinkOptions{
+		Name: &assetName,
+		URL:  &assetURL,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// GetBranchProtection on GitLab
+func (client *GitLabClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return nil, err
+	}
+	protectedBranch, response, err := client.glClient.ProtectedBranches.GetProtectedBranch(getProjectID(owner, repository), branch,
+		gitlab.WithContext(ctx))
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	branchProtection := &BranchProtection{RestrictPushes: true}
+	for _, accessLevel := range protectedBranch.PushAccessLevels {
+		if accessLevel.UserID == 0 {
+			// Pushes aren't restricted if all the developers may push
+			if accessLevel.GroupID == 0 && accessLevel.AccessLevel == gitlab.DeveloperPermissions {
+				branchProtection.RestrictPushes = false
+			}
+			continue
+		}
+		user, _, err := client.glClient.Users.GetUser(accessLevel.UserID, gitlab.GetUsersOptions{}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		branchProtection.AllowedPushUsers = append(branchProtection.AllowedPushUsers, user.Username)
+	}
+	if !branchProtection.RestrictPushes {
+		branchProtection.AllowedPushUsers = nil
+	}
+	return branchProtection, nil
+}
+
+// SetBranchProtection on GitLab
+func (client *GitLabClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtection) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	if protection.RequiredApprovingReviewCount > 0 || len(protection.RequiredStatusChecks) > 0 {
+		return errGitLabBranchProtectionChecksNotSupported
+	}
+	options := &gitlab.ProtectRepositoryBranchesOptions{Name: &branch}
+	if protection.RestrictPushes {
+		for _, username := range protection.AllowedPushUsers {
+			userID, err := client.getUserID(ctx, username)
+			if err != nil {
+				return err
+			}
+			options.AllowedToPush = append(options.AllowedToPush, &gitlab.BranchPermissionOptions{UserID: &userID})
+		}
+		if len(options.AllowedToPush) == 0 {
+			options.PushAccessLevel = gitlab.AccessLevel(gitlab.NoPermissions)
+		}
+	} else {
+		options.PushAccessLevel = gitlab.AccessLevel(gitlab.DeveloperPermissions)
+	}
+	// The protection of a protected branch can't be updated, so it is replaced
+	projectID := getProjectID(owner, repository)
+	response, err := client.glClient.ProtectedBranches.UnprotectRepositoryBranches(projectID, branch, gitlab.WithContext(ctx))
+	if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+		return err
+	}
+	client.logger.Debug("protecting branch", branch)
+	_, _, err = client.glClient.ProtectedBranches.ProtectRepositoryBranches(projectID, options, gitlab.WithContext(ctx))
+	return err
+}
+
+// GetRequiredChecks on GitLab. GitLab requires the whole pipeline to succeed, if the project is configured so, rather than named commit statuses.
+func (client *GitLabClient) GetRequiredChecks(ctx context.Context, owner, repository, branch string) (RequiredChecksInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return RequiredChecksInfo{}, err
+	}
+	project, _, err := client.glClient.Projects.GetProject(getProjectID(owner, repository), nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return RequiredChecksInfo{}, err
+	}
+	return RequiredChecksInfo{AllMustPass: project.OnlyAllowMergeIfPipelineSucceeds}, nil
+}
+
+// GetRequiredApprovals on GitLab. The approval rules that apply to the branch are combined.
+func (client *GitLabClient) GetRequiredApprovals(ctx context.Context, owner, repository, branch string) (RequiredApprovalsInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return RequiredApprovalsInfo{}, err
+	}
+	projectID := getProjectID(owner, repository)
+	rules, _, err := client.glClient.Projects.GetProjectApprovalRules(projectID, gitlab.WithContext(ctx))
+	if err != nil {
+		return RequiredApprovalsInfo{}, err
+	}
+	requiredApprovals := RequiredApprovalsInfo{}
+	for _, rule := range rules {
+		if rule.ApprovalsRequired == 0 || !isGitLabApprovalRuleOfBranch(rule, branch) {
+			continue
+		}
+		if rule.ApprovalsRequired > requiredApprovals.Count {
+			requiredApprovals.Count = rule.ApprovalsRequired
+		}
+		approvers := rule.EligibleApprovers
+		if len(approvers) == 0 {
+			approvers = rule.Users
+		}
+		for _, approver := range approvers {
+			requiredApprovals.Approvers = appendUniqueOwners(requiredApprovals.Approvers, approver.Username)
+		}
+	}
+	if requiredApprovals.Count == 0 {
+		return requiredApprovals, nil
+	}
+	configuration, _, err := client.glClient.Projects.GetApprovalConfiguration(projectID, gitlab.WithContext(ctx))
+	if err != nil {
+		return RequiredApprovalsInfo{}, err
+	}
+	requiredApprovals.DismissStaleApprovals = configuration.ResetApprovalsOnPush
+	protectedBranch, response, err := client.glClient.ProtectedBranches.GetProtectedBranch(projectID, branch, gitlab.WithContext(ctx))
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return requiredApprovals, nil
+		}
+		return RequiredApprovalsInfo{}, err
+	}
+	requiredApprovals.RequireCodeOwners = protectedBranch.CodeOwnerApprovalRequired
+	return requiredApprovals, nil
+}
+
+// GetTagProtection on GitLab
+func (client *GitLabClient) GetTagProtection(ctx context.Context, owner, repository, pattern string) (*TagProtection, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pattern": pattern})
+	if err != nil {
+		return nil, err
+	}
+	// The GitLab client doesn't support the users allowed to create protected tags, so the requests are built here
+	request, err := client.glClient.NewRequest(http.MethodGet, client.getProtectedTagsPath(owner, repository)+"/"+url.PathEscape(pattern), nil,
+		[]gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	var protectedTag gitLabProtectedTag
+	response, err := client.glClient.Do(request, &protectedTag)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	tagProtection := &TagProtection{}
+	for _, accessLevel := range protectedTag.CreateAccessLevels {
+		if accessLevel.UserID == 0 {
+			continue
+		}
+		user, _, err := client.glClient.Users.GetUser(accessLevel.UserID, gitlab.GetUsersOptions{}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		tagProtection.AllowedUsers = append(tagProtection.AllowedUsers, user.Username)
+	}
+	return tagProtection, nil
+}
+
+// SetTagProtection on GitLab
+func (client *GitLabClient) SetTagProtection(ctx context.Context, owner, repository, pattern string, protection TagProtection) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pattern": pattern})
+	if err != nil {
+		return err
+	}
+	options := gitLabProtectTagRequest{Name: pattern, CreateAccessLevel: gitlab.NoPermissions}
+	for _, username := range protection.AllowedUsers {
+		userID, err := client.getUserID(ctx, username)
+		if err != nil {
+			return err
+		}
+		options.AllowedToCreate = append(options.AllowedToCreate, gitLabProtectedTagAccessLevel{UserID: userID})
+	}
+	// The protection of a protected tag can't be updated, so it is replaced
+	response, err := client.glClient.ProtectedTags.UnprotectRepositoryTags(getProjectID(owner, repository), pattern, gitlab.WithContext(ctx))
+	if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+		return err
+	}
+	client.logger.Debug("protecting tags", pattern)
+	request, err := client.glClient.NewRequest(http.MethodPost, client.getProtectedTagsPath(owner, repository), options,
+		[]gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.Do(request, nil)
+	return err
+}
+
+func (client *GitLabClient) getProtectedTagsPath(owner, repository string) string {
+	return fmt.Sprintf("projects/%s/protected_tags", url.PathEscape(getProjectID(owner, repository)))
+}
+
+type gitLabCommitSignature struct {
+	SignatureType      string `json:"signature_type"`
+	VerificationStatus string `json:"verification_status"`
+	GPGKeyUserName     string `json:"gpg_key_user_name"`
+	GPGKeyUserEmail    string `json:"gpg_key_user_email"`
+	X509Certificate    struct {
+		Email string `json:"email"`
+	} `json:"x509_certificate"`
+}
+
+type gitLabProtectedTag struct {
+	Name               string                          `json:"name"`
+	CreateAccessLevels []gitLabProtectedTagAccessLevel `json:"create_access_levels"`
+}
+
+type gitLabProtectedTagAccessLevel struct {
+	UserID int `json:"user_id,omitempty"`
+}
+
+type gitLabProtectTagRequest struct {
+	Name              string                          `json:"name"`
+	CreateAccessLevel gitlab.AccessLevelValue         `json:"create_access_level"`
+	AllowedToCreate   []gitLabProtectedTagAccessLevel `json:"allowed_to_create,omitempty"`
+}
+
+func (client *GitLabClient) getUserID(ctx context.Context, username string) (int, error) {
+	users, _, err := client.glClient.Users.ListUsers(&gitlab.ListUsersOptions{Username: &username}, gitlab.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("user %s was not found", username)
+	}
+	return users[0].ID, nil
+}
+
 // AddSshKeyToRepository on GitLab
 func (client *GitLabClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
 	err := validateParametersNotBlank(map[string]string{
@@ -107,6 +628,55 @@ func (client *GitLabClient) AddSshKeyToRepository(ctx context.Context, owner, re
 	return err
 }
 
+// ListDeployKeys on GitLab
+func (client *GitLabClient) ListDeployKeys(ctx context.Context, owner, repository string) ([]DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListProjectDeployKeysOptions{Page: 1, PerPage: 100}
+	var results []DeployKeyInfo
+	for options.Page > 0 {
+		keys, response, err := client.glClient.DeployKeys.ListProjectDeployKeys(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			results = append(results, mapGitLabDeployKeyToDeployKeyInfo(key))
+		}
+		options.Page = response.NextPage
+	}
+	return results, nil
+}
+
+// AddDeployKey on GitLab
+func (client *GitLabClient) AddDeployKey(ctx context.Context, owner, repository, title, key string, readOnly bool) (DeployKeyInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title, "key": key})
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	options := &gitlab.AddDeployKeyOptions{Title: &title, Key: &key, CanPush: gitlab.Bool(!readOnly)}
+	deployKey, _, err := client.glClient.DeployKeys.AddDeployKey(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	if err != nil {
+		return DeployKeyInfo{}, err
+	}
+	return mapGitLabDeployKeyToDeployKeyInfo(deployKey), nil
+}
+
+// DeleteDeployKey on GitLab
+func (client *GitLabClient) DeleteDeployKey(ctx context.Context, owner, repository string, keyID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.DeployKeys.DeleteDeployKey(getProjectID(owner, repository), keyID, gitlab.WithContext(ctx))
+	return err
+}
+
+func mapGitLabDeployKeyToDeployKeyInfo(key *gitlab.DeployKey) DeployKeyInfo {
+	return DeployKeyInfo{ID: key.ID, Title: key.Title, Key: key.Key, ReadOnly: key.CanPush == nil || !*key.CanPush}
+}
+
 // CreateWebhook on GitLab
 func (client *GitLabClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string,
 	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
@@ -127,37 +697,158 @@ func (client *GitLabClient) CreateWebhook(ctx context.Context, owner, repository
 	return strconv.Itoa(response.ID), token, nil
 }
 
-// UpdateWebhook on GitLab
-func (client *GitLabClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token,
-	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
-	projectHook := createProjectHook(branch, payloadURL, webhookEvents...)
-	options := &gitlab.EditProjectHookOptions{
+// CreateWebhookWithOptions on GitLab
+func (client *GitLabClient) CreateWebhookWithOptions(ctx context.Context, owner, repository string,
+	options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	if options.contentType() != WebhookContentTypeJSON {
+		return WebhookInfo{}, "", errGitLabWebhookFormContentTypeNotSupported
+	}
+	token := vcsutils.CreateToken()
+	projectHook := createProjectHook(options.Branch, options.PayloadURL, options.Events...)
+	hookOptions := &gitlab.AddProjectHookOptions{
 		Token:                  &token,
 		URL:                    &projectHook.URL,
 		MergeRequestsEvents:    &projectHook.MergeRequestsEvents,
 		PushEvents:             &projectHook.PushEvents,
 		PushEventsBranchFilter: &projectHook.PushEventsBranchFilter,
+		EnableSSLVerification:  gitlab.Bool(!options.InsecureSSL),
 	}
-	intWebhook, err := strconv.Atoi(webhookID)
+	response, _, err := client.glClient.Projects.AddProjectHook(getProjectID(owner, repository), hookOptions,
+		gitlab.WithContext(ctx))
 	if err != nil {
-		return err
+		return WebhookInfo{}, "", err
 	}
-	_, _, err = client.glClient.Projects.EditProjectHook(getProjectID(owner, repository), intWebhook, options,
-		gitlab.WithContext(ctx))
-	return err
+	return mapGitLabProjectHookToWebhookInfo(response), token, nil
 }
 
-// DeleteWebhook on GitLab
-func (client *GitLabClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
-	intWebhook, err := strconv.Atoi(webhookID)
-	if err != nil {
-		return err
+// CreateOrgWebhook on GitLab
+func (client *GitLabClient) CreateOrgWebhook(ctx context.Context, owner string, options WebhookOptions) (WebhookInfo, string, error) {
+	if err := validateWebhookOptions(options); err != nil {
+		return WebhookInfo{}, "", err
+	}
+	if options.contentType() != WebhookContentTypeJSON {
+		return WebhookInfo{}, "", errGitLabWebhookFormContentTypeNotSupported
+	}
+	if options.Branch != "" {
+		return WebhookInfo{}, "", errGitLabGroupWebhookBranchNotSupported
+	}
+	token := vcsutils.CreateToken()
+	projectHook := createProjectHook("", options.PayloadURL, options.Events...)
+	hookOptions := &gitlab.AddGroupHookOptions{
+		Token:                 &token,
+		URL:                   &projectHook.URL,
+		MergeRequestsEvents:   &projectHook.MergeRequestsEvents,
+		PushEvents:            &projectHook.PushEvents,
+		EnableSSLVerification: gitlab.Bool(!options.InsecureSSL),
+	}
+	response, _, err := client.glClient.Groups.AddGroupHook(owner, hookOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return WebhookInfo{}, "", err
+	}
+	return mapGitLabGroupHookToWebhookInfo(response), token, nil
+}
+
+// ListOrgWebhooks on GitLab
+func (client *GitLabClient) ListOrgWebhooks(ctx context.Context, owner string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner})
+	if err != nil {
+		return nil, err
+	}
+	// The GitLab client doesn't page group hooks, so the request is built here
+	options := &gitlab.ListOptions{Page: 1, PerPage: 100}
+	var webhooks []WebhookInfo
+	for options.Page > 0 {
+		request, err := client.glClient.NewRequest(http.MethodGet, fmt.Sprintf("groups/%s/hooks", url.PathEscape(owner)), options,
+			[]gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+		if err != nil {
+			return nil, err
+		}
+		var hooks []*gitlab.GroupHook
+		response, err := client.glClient.Do(request, &hooks)
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			webhooks = append(webhooks, mapGitLabGroupHookToWebhookInfo(hook))
+		}
+		options.Page = response.NextPage
+	}
+	return webhooks, nil
+}
+
+// DeleteOrgWebhook on GitLab
+func (client *GitLabClient) DeleteOrgWebhook(ctx context.Context, owner, webhookID string) error {
+	intWebhook, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.Groups.DeleteGroupHook(owner, intWebhook, gitlab.WithContext(ctx))
+	return err
+}
+
+// UpdateWebhook on GitLab
+func (client *GitLabClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token,
+	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	projectHook := createProjectHook(branch, payloadURL, webhookEvents...)
+	options := &gitlab.EditProjectHookOptions{
+		Token:                  &token,
+		URL:                    &projectHook.URL,
+		MergeRequestsEvents:    &projectHook.MergeRequestsEvents,
+		PushEvents:             &projectHook.PushEvents,
+		PushEventsBranchFilter: &projectHook.PushEventsBranchFilter,
+	}
+	intWebhook, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Projects.EditProjectHook(getProjectID(owner, repository), intWebhook, options,
+		gitlab.WithContext(ctx))
+	return err
+}
+
+// DeleteWebhook on GitLab
+func (client *GitLabClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	intWebhook, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return err
 	}
 	_, err = client.glClient.Projects.DeleteProjectHook(getProjectID(owner, repository), intWebhook,
 		gitlab.WithContext(ctx))
 	return err
 }
 
+// ListWebhooks on GitLab
+func (client *GitLabClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListProjectHooksOptions{Page: 1, PerPage: 100}
+	var webhooks []WebhookInfo
+	for options.Page > 0 {
+		hooks, response, err := client.glClient.Projects.ListProjectHooks(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			webhooks = append(webhooks, mapGitLabProjectHookToWebhookInfo(hook))
+		}
+		options.Page = response.NextPage
+	}
+	return webhooks, nil
+}
+
+// SetWebhookActive on GitLab. Project hooks are always active, so only deactivating them fails.
+func (client *GitLabClient) SetWebhookActive(_ context.Context, _, _, _ string, active bool) error {
+	if !active {
+		return errGitLabWebhookDeactivationNotSupported
+	}
+	return nil
+}
+
 // SetCommitStatus on GitLab
 func (client *GitLabClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
 	title, description, detailsURL string) error {
@@ -173,20 +864,104 @@ func (client *GitLabClient) SetCommitStatus(ctx context.Context, commitStatus Co
 	return err
 }
 
+// SetCommitStatuses on GitLab
+func (client *GitLabClient) SetCommitStatuses(ctx context.Context, owner, repository, ref string, statuses []CommitStatusInfo) error {
+	return setCommitStatusesOneByOne(ctx, client, owner, repository, ref, statuses)
+}
+
+// CreateCheckRun on GitLab. GitLab doesn't support check runs, so a commit status is set instead.
+func (client *GitLabClient) CreateCheckRun(ctx context.Context, owner, repository, ref string, checkRun CheckRunInfo) (int64, error) {
+	return 0, setCheckRunCommitStatus(ctx, client, owner, repository, ref, checkRun)
+}
+
+// UpdateCheckRun on GitLab. GitLab doesn't support check runs, so the commit status is set instead.
+func (client *GitLabClient) UpdateCheckRun(ctx context.Context, owner, repository, ref string, _ int64, checkRun CheckRunInfo) error {
+	return setCheckRunCommitStatus(ctx, client, owner, repository, ref, checkRun)
+}
+
+// CreateCodeInsightsReport on GitLab
+func (client *GitLabClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, sha string, report CodeInsightsReport) error {
+	return errGitLabCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on GitLab
+func (client *GitLabClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, sha, reportID string, annotations []CodeInsightsAnnotation) error {
+	return errGitLabCodeInsightsNotSupported
+}
+
+// TriggerPipeline on GitLab. The pipeline of the repository is run, so the pipeline parameter is ignored.
+func (client *GitLabClient) TriggerPipeline(ctx context.Context, owner, repository, _, ref string, inputs map[string]string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	options := &gitlab.CreatePipelineOptions{Ref: &ref}
+	for _, name := range getSortedKeys(inputs) {
+		options.Variables = append(options.Variables, &gitlab.PipelineVariable{Key: name, Value: inputs[name], VariableType: "env_var"})
+	}
+	pipeline, _, err := client.glClient.Pipelines.CreatePipeline(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return mapGitLabPipelineToPipelineInfo(pipeline), nil
+}
+
+// GetPipelineStatus on GitLab
+func (client *GitLabClient) GetPipelineStatus(ctx context.Context, owner, repository, runID string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "run ID": runID})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	id, err := strconv.Atoi(runID)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	pipeline, _, err := client.glClient.Pipelines.GetPipeline(getProjectID(owner, repository), id, gitlab.WithContext(ctx))
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return mapGitLabPipelineToPipelineInfo(pipeline), nil
+}
+
+func mapGitLabPipelineToPipelineInfo(pipeline *gitlab.Pipeline) PipelineInfo {
+	return PipelineInfo{ID: strconv.Itoa(pipeline.ID), Ref: pipeline.Ref, State: getGitLabCommitStatus(pipeline.Status), WebURL: pipeline.WebURL}
+}
+
+// ListCommitStatuses on GitLab
+func (client *GitLabClient) ListCommitStatuses(ctx context.Context, owner, repository, ref string) ([]CommitStatusInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitStatusInfo
+	for nextPage := 1; nextPage > 0; {
+		statuses, response, err := client.glClient.Commits.GetCommitStatuses(getProjectID(owner, repository), ref,
+			&gitlab.GetCommitStatusesOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range statuses {
+			results = append(results, mapGitLabCommitStatusToCommitStatusInfo(status))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
 // DownloadRepository on GitLab
 func (client *GitLabClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
-	format := "tar.gz"
-	options := &gitlab.ArchiveOptions{
-		Format: &format,
-		SHA:    &branch,
-	}
-	response, _, err := client.glClient.Repositories.Archive(getProjectID(owner, repository), options,
-		gitlab.WithContext(ctx))
+	return client.DownloadRepositoryAtRef(ctx, owner, repository, branch, localPath, TarGz)
+}
+
+// DownloadRepositoryAtRef on GitLab
+func (client *GitLabClient) DownloadRepositoryAtRef(ctx context.Context, owner, repository, ref, localPath string, format ArchiveFormat) error {
+	archive, err := client.GetRepositoryArchive(ctx, owner, repository, ref, format)
 	if err != nil {
 		return err
 	}
+	defer func() { _ = archive.Close() }()
 	client.logger.Info(repository, "downloaded successfully, starting with repository extraction")
-	err = vcsutils.Untar(localPath, bytes.NewReader(response), true)
+	err = extractRepositoryArchive(archive, format, localPath, true, "")
 	if err != nil {
 		return err
 	}
@@ -194,8 +969,67 @@ func (client *GitLabClient) DownloadRepository(ctx context.Context, owner, repos
 	return nil
 }
 
+// GetRepositoryArchive on GitLab.
+// The archive is streamed by the GitLab library into a pipe, so request errors are returned when reading the archive.
+func (client *GitLabClient) GetRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat) (io.ReadCloser, error) {
+	return client.getRepositoryArchive(ctx, owner, repository, ref, format, ""), nil
+}
+
+// DownloadRepositoryPath on GitLab
+func (client *GitLabClient) DownloadRepositoryPath(ctx context.Context, owner, repository, ref, path, localPath string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "path": path})
+	if err != nil {
+		return err
+	}
+	archive := client.getRepositoryArchive(ctx, owner, repository, ref, TarGz, path)
+	defer func() { _ = archive.Close() }()
+	client.logger.Info("extracting", path, "from", repository)
+	// The archive contains only the files of the directory, which are filtered again in case the path parameter isn't supported by the GitLab server
+	return extractRepositoryArchive(archive, TarGz, localPath, true, path)
+}
+
+// getRepositoryArchive returns the archive of the repository, or of a single directory of the repository if the path isn't empty
+func (client *GitLabClient) getRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat, path string) io.ReadCloser {
+	archiveFormat := "tar.gz"
+	if format == Zip {
+		archiveFormat = "zip"
+	}
+	options := &gitlab.ArchiveOptions{
+		Format: &archiveFormat,
+		SHA:    &ref,
+	}
+	requestOptions := []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)}
+	if path != "" {
+		// The path parameter isn't supported by the GitLab library
+		requestOptions = append(requestOptions, func(req *retryablehttp.Request) error {
+			query := req.URL.Query()
+			query.Set("path", path)
+			req.URL.RawQuery = query.Encode()
+			return nil
+		})
+	}
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		_, err := client.glClient.Repositories.StreamArchive(getProjectID(owner, repository), pipeWriter, options, requestOptions...)
+		_ = pipeWriter.CloseWithError(err)
+	}()
+	return pipeReader
+}
+
 // CreatePullRequest on GitLab
 func (client *GitLabClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) error {
+	return client.createPullRequest(ctx, owner, repository, sourceBranch, targetBranch, title, description)
+}
+
+// CreateDraftPullRequest on GitLab
+func (client *GitLabClient) CreateDraftPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) error {
+	// GitLab marks merge requests as drafts by their title
+	return client.createPullRequest(ctx, owner, repository, sourceBranch, targetBranch, "Draft: "+title, description)
+}
+
+func (client *GitLabClient) createPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
 	title, description string) error {
 	options := &gitlab.CreateMergeRequestOptions{
 		Title:        &title,
@@ -209,6 +1043,144 @@ func (client *GitLabClient) CreatePullRequest(ctx context.Context, owner, reposi
 	return err
 }
 
+// MarkPullRequestReady on GitLab
+func (client *GitLabClient) MarkPullRequestReady(ctx context.Context, owner, repository string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestID, nil,
+		gitlab.WithContext(ctx))
+	if err != nil || !mergeRequest.WorkInProgress {
+		return err
+	}
+	title, _ := trimDraftTitlePrefix(mergeRequest.Title, gitLabDraftTitlePrefixes)
+	client.logger.Debug("marking merge request as ready:", pullRequestID)
+	_, _, err = client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID,
+		&gitlab.UpdateMergeRequestOptions{Title: &title}, gitlab.WithContext(ctx))
+	return err
+}
+
+// MergePullRequest on GitLab
+func (client *GitLabClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int,
+	mergeStrategy MergeStrategy, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	options := &gitlab.AcceptMergeRequestOptions{}
+	switch mergeStrategy {
+	case SquashMerge:
+		options.Squash = gitlab.Bool(true)
+		if commitMessage != "" {
+			options.SquashCommitMessage = &commitMessage
+		}
+	case RebaseMerge:
+		return errGitLabRebaseMergeNotSupported
+	default:
+		if commitMessage != "" {
+			options.MergeCommitMessage = &commitMessage
+		}
+	}
+	client.logger.Debug("merging merge request:", pullRequestID)
+	_, _, err = client.glClient.MergeRequests.AcceptMergeRequest(getProjectID(owner, repository), pullRequestID, options,
+		gitlab.WithContext(ctx))
+	return err
+}
+
+// EnablePullRequestAutoMerge on GitLab. The merge request is merged when its pipeline succeeds.
+func (client *GitLabClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	options := &gitlab.AcceptMergeRequestOptions{MergeWhenPipelineSucceeds: gitlab.Bool(true)}
+	switch mergeStrategy {
+	case SquashMerge:
+		options.Squash = gitlab.Bool(true)
+	case RebaseMerge:
+		return errGitLabRebaseMergeNotSupported
+	}
+	client.logger.Debug("enabling auto-merge of merge request:", pullRequestID)
+	_, _, err = client.glClient.MergeRequests.AcceptMergeRequest(getProjectID(owner, repository), pullRequestID, options,
+		gitlab.WithContext(ctx))
+	return err
+}
+
+// DisablePullRequestAutoMerge on GitLab
+func (client *GitLabClient) DisablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("disabling auto-merge of merge request:", pullRequestID)
+	_, _, err = client.glClient.MergeRequests.CancelMergeWhenPipelineSucceeds(getProjectID(owner, repository), pullRequestID,
+		gitlab.WithContext(ctx))
+	return err
+}
+
+// UpdatePullRequest on GitLab
+func (client *GitLabClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranch string,
+	pullRequestID int, state *PullRequestState) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validatePullRequestState(state); err != nil {
+		return err
+	}
+	options := &gitlab.UpdateMergeRequestOptions{}
+	if title != "" {
+		options.Title = &title
+	}
+	if body != "" {
+		options.Description = &body
+	}
+	if targetBranch != "" {
+		options.TargetBranch = &targetBranch
+	}
+	if state != nil {
+		options.StateEvent = gitlab.String(getGitLabMergeRequestStateEvent(*state))
+	}
+	client.logger.Debug("updating merge request:", pullRequestID)
+	_, _, err = client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID, options,
+		gitlab.WithContext(ctx))
+	return err
+}
+
+// ApprovePullRequest on GitLab
+func (client *GitLabClient) ApprovePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	return client.SubmitPullRequestReview(ctx, owner, repository, pullRequestID, ReviewApprove, "")
+}
+
+// SubmitPullRequestReview on GitLab
+func (client *GitLabClient) SubmitPullRequestReview(ctx context.Context, owner, repository string, pullRequestID int,
+	reviewEvent ReviewEvent, body string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewBody(reviewEvent, body); err != nil {
+		return err
+	}
+	switch reviewEvent {
+	case ReviewRequestChanges:
+		return errGitLabRequestChangesNotSupported
+	case ReviewApprove:
+		client.logger.Debug("approving merge request:", pullRequestID)
+		_, _, err = client.glClient.MergeRequestApprovals.ApproveMergeRequest(getProjectID(owner, repository), pullRequestID,
+			&gitlab.ApproveMergeRequestOptions{}, gitlab.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+	}
+	// GitLab approvals have no body, so the review body is added as a comment
+	if body != "" {
+		return client.AddPullRequestComment(ctx, owner, repository, body, pullRequestID)
+	}
+	return nil
+}
+
 // ListOpenPullRequests on GitLab
 func (client *GitLabClient) ListOpenPullRequests(ctx context.Context, _, repository string) ([]PullRequestInfo, error) {
 	openState := "open"
@@ -226,192 +1198,1481 @@ func (client *GitLabClient) ListOpenPullRequests(ctx context.Context, _, reposit
 	return mapGitLabMergeRequestToPullRequestInfoList(mergeRequests), nil
 }
 
-// AddPullRequestComment on GitLab
-func (client *GitLabClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
-	if err != nil {
-		return err
+// ListOpenPullRequestsWithFilter on GitLab
+func (client *GitLabClient) ListOpenPullRequestsWithFilter(ctx context.Context, owner, repository string, filter PullRequestFilter) ([]PullRequestInfo, error) {
+	return listPageOrAll(ctx, client.ListOpenPullRequestsPager(owner, repository, filter), filter.Page)
+}
+
+// ListOpenPullRequestsPager on GitLab
+func (client *GitLabClient) ListOpenPullRequestsPager(owner, repository string, filter PullRequestFilter) *Pager[PullRequestInfo] {
+	openedState := "opened"
+	options := &gitlab.ListProjectMergeRequestsOptions{
+		State:       &openedState,
+		ListOptions: gitlab.ListOptions{Page: firstPage(filter.Page), PerPage: filter.PerPage},
 	}
-	options := &gitlab.CreateMergeRequestNoteOptions{
-		Body: &content,
+	if filter.Author != "" {
+		options.AuthorUsername = &filter.Author
 	}
-	_, _, err = client.glClient.Notes.CreateMergeRequestNote(getProjectID(owner, repository), pullRequestID, options,
+	if filter.SourceBranch != "" {
+		options.SourceBranch = &filter.SourceBranch
+	}
+	if filter.TargetBranch != "" {
+		options.TargetBranch = &filter.TargetBranch
+	}
+	if filter.Label != "" {
+		options.Labels = gitlab.Labels{filter.Label}
+	}
+	if !filter.UpdatedSince.IsZero() {
+		options.UpdatedAfter = &filter.UpdatedSince
+	}
+	return newPager(func(ctx context.Context) ([]PullRequestInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching open merge requests page", options.Page, "in", repository)
+		mergeRequests, response, err := client.glClient.MergeRequests.ListProjectMergeRequests(getProjectID(owner, repository), options,
+			gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		var results []PullRequestInfo
+		for _, mergeRequest := range mergeRequests {
+			pullRequestInfo := mapGitLabMergeRequestToPullRequestInfo(mergeRequest)
+			pullRequestInfo.Target.Owner, pullRequestInfo.Target.Repository = owner, repository
+			results = append(results, pullRequestInfo)
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// GetPullRequestByID on GitLab
+func (client *GitLabClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	client.logger.Debug("fetching merge request", pullRequestID, "in", repository)
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestID, nil,
 		gitlab.WithContext(ctx))
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	pullRequestInfo := mapGitLabMergeRequestToPullRequestInfo(mergeRequest)
+	pullRequestInfo.Target.Owner, pullRequestInfo.Target.Repository = owner, repository
+	pullRequestInfo.Source.Owner, pullRequestInfo.Source.Repository = owner, repository
+	if mergeRequest.SourceProjectID != mergeRequest.TargetProjectID {
+		// The merge request was opened from a fork
+		sourceProject, _, err := client.glClient.Projects.GetProject(mergeRequest.SourceProjectID, nil, gitlab.WithContext(ctx))
+		if err != nil {
+			return PullRequestInfo{}, err
+		}
+		pullRequestInfo.Source.Repository = sourceProject.Path
+		if sourceProject.Namespace != nil {
+			pullRequestInfo.Source.Owner = sourceProject.Namespace.FullPath
+		}
+	}
+	return pullRequestInfo, nil
+}
 
-	return err
+// GetPullRequestDetails on GitLab
+func (client *GitLabClient) GetPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestDetails, error) {
+	return getPullRequestDetails(ctx, client, owner, repository, pullRequestID, true)
+}
+
+// GetPullRequestMergeableState on GitLab. GitLab requires a successful pipeline rather than named status checks, so MissingStatusChecks is left empty.
+func (client *GitLabClient) GetPullRequestMergeableState(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestMergeableState, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	projectID := getProjectID(owner, repository)
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(projectID, pullRequestID,
+		&gitlab.GetMergeRequestsOptions{IncludeDivergedCommitsCount: gitlab.Bool(true)}, gitlab.WithContext(ctx))
+	if err != nil {
+		return PullRequestMergeableState{}, err
+	}
+	state := PullRequestMergeableState{
+		HasConflicts: mergeRequest.HasConflicts,
+		BehindTarget: mergeRequest.DivergedCommitsCount > 0,
+	}
+	approvals, response, err := client.glClient.MergeRequestApprovals.GetConfiguration(projectID, pullRequestID, gitlab.WithContext(ctx))
+	// Merge request approvals may be unavailable on the GitLab instance, in which case no approvals are required
+	if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+		return PullRequestMergeableState{}, err
+	}
+	if approvals != nil {
+		state.MissingApprovals = approvals.ApprovalsLeft
+	}
+	state.Mergeable = mergeRequest.MergeStatus == "can_be_merged" && !mergeRequest.WorkInProgress && state.isMergeable()
+	return state, nil
+}
+
+// ListPullRequestFiles on GitLab
+func (client *GitLabClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequestChanges(getProjectID(owner, repository), pullRequestID, nil,
+		gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]PullRequestFile, 0, len(mergeRequest.Changes))
+	for _, change := range mergeRequest.Changes {
+		results = append(results, mapGitLabDiffToPullRequestFile(&gitlab.Diff{
+			Diff:        change.Diff,
+			NewPath:     change.NewPath,
+			OldPath:     change.OldPath,
+			NewFile:     change.NewFile,
+			RenamedFile: change.RenamedFile,
+			DeletedFile: change.DeletedFile,
+		}))
+	}
+	return results, nil
+}
+
+// GetPullRequestDiff on GitLab
+func (client *GitLabClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return "", err
+	}
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequestChanges(getProjectID(owner, repository), pullRequestID, nil,
+		gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	// GitLab returns the diff of each file without its header, so the unified diff is assembled here
+	var diff strings.Builder
+	for _, change := range mergeRequest.Changes {
+		oldPath, newPath := "a/"+change.OldPath, "b/"+change.NewPath
+		if change.NewFile {
+			oldPath = "/dev/null"
+		}
+		if change.DeletedFile {
+			newPath = "/dev/null"
+		}
+		diff.WriteString(fmt.Sprintf("diff --git a/%s b/%s\n--- %s\n+++ %s\n", change.OldPath, change.NewPath, oldPath, newPath))
+		diff.WriteString(change.Diff)
+		if change.Diff != "" && !strings.HasSuffix(change.Diff, "\n") {
+			diff.WriteString("\n")
+		}
+	}
+	return diff.String(), nil
+}
+
+// ListPullRequestCommits on GitLab
+func (client *GitLabClient) ListPullRequestCommits(ctx context.Context, owner, repository string, pullRequestID int) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitInfo
+	for nextPage := 1; nextPage > 0; {
+		commits, response, err := client.glClient.MergeRequests.GetMergeRequestCommits(getProjectID(owner, repository), pullRequestID,
+			&gitlab.GetMergeRequestCommitsOptions{Page: nextPage, PerPage: 100}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			results = append(results, mapGitLabCommitToCommitInfo(commit))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetDefaultReviewers on GitLab. The eligible approvers of the approval rules that apply to the target branch are returned.
+func (client *GitLabClient) GetDefaultReviewers(ctx context.Context, owner, repository, sourceBranch, targetBranch string) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "source branch": sourceBranch, "target branch": targetBranch})
+	if err != nil {
+		return nil, err
+	}
+	rules, _, err := client.glClient.Projects.GetProjectApprovalRules(getProjectID(owner, repository), gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var reviewers []string
+	for _, rule := range rules {
+		if !isGitLabApprovalRuleOfBranch(rule, targetBranch) {
+			continue
+		}
+		approvers := rule.EligibleApprovers
+		if len(approvers) == 0 {
+			approvers = rule.Users
+		}
+		for _, approver := range approvers {
+			reviewers = appendUniqueOwners(reviewers, approver.Username)
+		}
+	}
+	return reviewers, nil
+}
+
+// An approval rule without protected branches applies to all the branches, except for the rules of security reports, which
+// apply only when the report finds vulnerabilities. The names of protected branches may contain * wildcards, such as release/*.
+func isGitLabApprovalRuleOfBranch(rule *gitlab.ProjectApprovalRule, branch string) bool {
+	if rule.RuleType == "report_approver" {
+		return false
+	}
+	if len(rule.ProtectedBranches) == 0 {
+		return true
+	}
+	for _, protectedBranch := range rule.ProtectedBranches {
+		if matchesBranchPattern(protectedBranch.Name, branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddPullRequestComment on GitLab
+func (client *GitLabClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	options := &gitlab.CreateMergeRequestNoteOptions{
+		Body: &content,
+	}
+	_, _, err = client.glClient.Notes.CreateMergeRequestNote(getProjectID(owner, repository), pullRequestID, options,
+		gitlab.WithContext(ctx))
+
+	return err
+}
+
+// ListPullRequestComments on GitLab
+func (client *GitLabClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return []CommentInfo{}, err
+	}
+	commentsList, _, err := client.glClient.Notes.ListMergeRequestNotes(getProjectID(owner, repository), pullRequestID, &gitlab.ListMergeRequestNotesOptions{},
+		gitlab.WithContext(ctx))
+	if err != nil {
+		return []CommentInfo{}, err
+	}
+	return mapGitLabNotesToCommentInfoList(commentsList), nil
+}
+
+// UpdatePullRequestComment on GitLab
+func (client *GitLabClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Notes.UpdateMergeRequestNote(getProjectID(owner, repository), pullRequestID, int(commentID),
+		&gitlab.UpdateMergeRequestNoteOptions{Body: &content}, gitlab.WithContext(ctx))
+	return err
+}
+
+// DeletePullRequestComment on GitLab
+func (client *GitLabClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.Notes.DeleteMergeRequestNote(getProjectID(owner, repository), pullRequestID, int(commentID), gitlab.WithContext(ctx))
+	return err
+}
+
+// AddCommentReaction on GitLab awards the emoji of the reaction to the note
+func (client *GitLabClient) AddCommentReaction(ctx context.Context, owner, repository string, pullRequestID int, commentID int64, reaction Reaction) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "reaction": string(reaction)})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.AwardEmoji.CreateMergeRequestAwardEmojiOnNote(getProjectID(owner, repository), pullRequestID, int(commentID),
+		&gitlab.CreateAwardEmojiOptions{Name: getGitLabAwardEmojiName(reaction)}, gitlab.WithContext(ctx))
+	return err
+}
+
+// ListCommentReactions on GitLab
+func (client *GitLabClient) ListCommentReactions(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) ([]ReactionInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	var results []ReactionInfo
+	for nextPage := 1; nextPage > 0; {
+		awardEmoji, response, err := client.glClient.AwardEmoji.ListMergeRequestAwardEmojiOnNote(getProjectID(owner, repository), pullRequestID,
+			int(commentID), &gitlab.ListAwardEmojiOptions{Page: nextPage, PerPage: 100}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, emoji := range awardEmoji {
+			results = append(results, ReactionInfo{Reaction: getGitLabReaction(emoji.Name), User: emoji.User.Username})
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// AddPullRequestReviewComment on GitLab
+func (client *GitLabClient) AddPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int,
+	comment PullRequestReviewComment) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validateReviewComment(comment); err != nil {
+		return err
+	}
+	position, err := client.getDiffNotePosition(ctx, owner, repository, pullRequestID, comment)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("adding diff comment on merge request:", pullRequestID)
+	_, _, err = client.glClient.Discussions.CreateMergeRequestDiscussion(getProjectID(owner, repository), pullRequestID,
+		&gitlab.CreateMergeRequestDiscussionOptions{Body: &comment.Content, Position: position}, gitlab.WithContext(ctx))
+	return err
+}
+
+// getDiffNotePosition returns the position of a comment on lines of the merge request diff
+func (client *GitLabClient) getDiffNotePosition(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (*gitlab.NotePosition, error) {
+	// The position of a diff comment is relative to the diff versions of the merge request
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestID, nil,
+		gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	// Multi-line comments require GitLab's internal line codes, so the comment is anchored to the last line of the range
+	return &gitlab.NotePosition{
+		BaseSHA:      mergeRequest.DiffRefs.BaseSha,
+		StartSHA:     mergeRequest.DiffRefs.StartSha,
+		HeadSHA:      mergeRequest.DiffRefs.HeadSha,
+		PositionType: "text",
+		NewPath:      comment.Path,
+		OldPath:      comment.Path,
+		NewLine:      comment.Line,
+	}, nil
+}
+
+// CreatePullRequestThread on GitLab opens a discussion, and returns its ID
+func (client *GitLabClient) CreatePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": comment.Content})
+	if err != nil {
+		return "", err
+	}
+	options := &gitlab.CreateMergeRequestDiscussionOptions{Body: &comment.Content}
+	if comment.Path != "" {
+		if err = validateReviewComment(comment); err != nil {
+			return "", err
+		}
+		if options.Position, err = client.getDiffNotePosition(ctx, owner, repository, pullRequestID, comment); err != nil {
+			return "", err
+		}
+	}
+	client.logger.Debug("creating discussion on merge request:", pullRequestID)
+	discussion, _, err := client.glClient.Discussions.CreateMergeRequestDiscussion(getProjectID(owner, repository), pullRequestID, options,
+		gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return discussion.ID, nil
+}
+
+// ResolvePullRequestThread on GitLab
+func (client *GitLabClient) ResolvePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "thread ID": threadID})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("updating discussion", threadID, "of merge request:", pullRequestID)
+	_, _, err = client.glClient.Discussions.ResolveMergeRequestDiscussion(getProjectID(owner, repository), pullRequestID, threadID,
+		&gitlab.ResolveMergeRequestDiscussionOptions{Resolved: &resolved}, gitlab.WithContext(ctx))
+	return err
+}
+
+// ListPullRequestReviewComments on GitLab
+func (client *GitLabClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewComment, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestReviewComment
+	for nextPage := 1; nextPage > 0; {
+		discussions, response, err := client.glClient.Discussions.ListMergeRequestDiscussions(getProjectID(owner, repository), pullRequestID,
+			&gitlab.ListMergeRequestDiscussionsOptions{Page: nextPage, PerPage: 100}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, discussion := range discussions {
+			for _, note := range discussion.Notes {
+				if note.Position != nil {
+					results = append(results, mapGitLabNoteToReviewComment(note))
+				}
+			}
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetLatestCommit on GitLab
+func (client *GitLabClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     branch,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	listOptions := &gitlab.ListCommitsOptions{
+		RefName: &branch,
+		ListOptions: gitlab.ListOptions{
+			Page:    1,
+			PerPage: 1,
+		},
+	}
+
+	commits, _, err := client.glClient.Commits.ListCommits(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	if len(commits) > 0 {
+		latestCommit := commits[0]
+		return mapGitLabCommitToCommitInfo(latestCommit), nil
+	}
+	return CommitInfo{}, errors.New(`{"message":"404 Not Found"}`)
+}
+
+// GetRepositoryInfo on GitLab
+func (client *GitLabClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	project, _, err := client.glClient.Projects.GetProject(getProjectID(owner, repository),
+		&gitlab.GetProjectOptions{Statistics: gitlab.Bool(true)}, gitlab.WithContext(ctx))
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	return mapGitLabProjectToRepositoryInfo(project), nil
+}
+
+// GetRepositoryLanguages on GitLab
+func (client *GitLabClient) GetRepositoryLanguages(ctx context.Context, owner, repository string) (map[string]float64, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	languages, _, err := client.glClient.Projects.GetProjectLanguages(getProjectID(owner, repository), gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	results := map[string]float64{}
+	if languages != nil {
+		for language, percentage := range *languages {
+			results[language] = float64(percentage)
+		}
+	}
+	return results, nil
+}
+
+// CreateRepository on GitLab
+func (client *GitLabClient) CreateRepository(ctx context.Context, owner, name string, options CreateRepositoryOptions) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "name": name})
+	if err != nil {
+		return err
+	}
+	if err = validateCreateRepositoryOptions(options); err != nil {
+		return err
+	}
+	// GitLab creates projects in a namespace, which is a user or a group, by its ID
+	namespace, _, err := client.glClient.Namespaces.GetNamespace(owner, gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	createOptions := &gitlab.CreateProjectOptions{
+		Name:                 &name,
+		Path:                 &name,
+		NamespaceID:          &namespace.ID,
+		Visibility:           gitlab.Visibility(getGitLabVisibilityValue(options.Visibility)),
+		InitializeWithReadme: &options.InitWithReadme,
+	}
+	if options.Description != "" {
+		createOptions.Description = &options.Description
+	}
+	if options.DefaultBranch != "" {
+		createOptions.DefaultBranch = &options.DefaultBranch
+	}
+	_, _, err = client.glClient.Projects.CreateProject(createOptions, gitlab.WithContext(ctx))
+	return err
+}
+
+// DeleteRepository on GitLab
+func (client *GitLabClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.Projects.DeleteProject(getProjectID(owner, repository), gitlab.WithContext(ctx))
+	return err
+}
+
+// ForkRepository on GitLab
+func (client *GitLabClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string, waitUntilReady bool) (ForkInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	options := &gitlab.ForkProjectOptions{}
+	if targetOwner != "" {
+		options.Namespace = &targetOwner
+	}
+	fork, _, err := client.glClient.Projects.ForkProject(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	if err != nil {
+		return ForkInfo{}, err
+	}
+	forkInfo := ForkInfo{
+		Owner:          strings.TrimSuffix(fork.PathWithNamespace, "/"+fork.Path),
+		Repository:     fork.Path,
+		RepositoryInfo: mapGitLabProjectToRepositoryInfo(fork),
+	}
+	if !waitUntilReady {
+		return forkInfo, nil
+	}
+	// GitLab imports the content of the fork in the background
+	err = pollUntilReady(ctx, func() (bool, error) {
+		project, _, err := client.glClient.Projects.GetProject(fork.ID, nil, gitlab.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		switch project.ImportStatus {
+		case "failed":
+			return false, fmt.Errorf("failed to fork %s/%s: %s", owner, repository, project.ImportError)
+		case "scheduled", "started":
+			return false, nil
+		default:
+			return true, nil
+		}
+	})
+	return forkInfo, err
+}
+
+// ListCollaborators on GitLab
+func (client *GitLabClient) ListCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	// The members of the project include the members inherited from its groups
+	options := &gitlab.ListProjectMembersOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: 100}}
+	var results []CollaboratorInfo
+	for options.Page > 0 {
+		members, response, err := client.glClient.ProjectMembers.ListAllProjectMembers(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			results = append(results, CollaboratorInfo{Username: member.Username, Permission: mapGitLabAccessLevelToRepositoryPermission(member.AccessLevel)})
+		}
+		options.Page = response.NextPage
+	}
+	return results, nil
+}
+
+// AddCollaborator on GitLab
+func (client *GitLabClient) AddCollaborator(ctx context.Context, owner, repository, username string, permission RepositoryPermission) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return err
+	}
+	if err = validateCollaboratorPermission(permission); err != nil {
+		return err
+	}
+	userID, err := client.getUserID(ctx, username)
+	if err != nil {
+		return err
+	}
+	projectID := getProjectID(owner, repository)
+	accessLevel := getGitLabAccessLevel(permission)
+	_, response, err := client.glClient.ProjectMembers.AddProjectMember(projectID,
+		&gitlab.AddProjectMemberOptions{UserID: userID, AccessLevel: &accessLevel}, gitlab.WithContext(ctx))
+	if response != nil && response.StatusCode == http.StatusConflict {
+		// The user is already a member of the project
+		_, _, err = client.glClient.ProjectMembers.EditProjectMember(projectID, userID,
+			&gitlab.EditProjectMemberOptions{AccessLevel: &accessLevel}, gitlab.WithContext(ctx))
+	}
+	return err
+}
+
+// RemoveCollaborator on GitLab
+func (client *GitLabClient) RemoveCollaborator(ctx context.Context, owner, repository, username string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return err
+	}
+	userID, err := client.getUserID(ctx, username)
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.ProjectMembers.DeleteProjectMember(getProjectID(owner, repository), userID, gitlab.WithContext(ctx))
+	return err
+}
+
+// GetUserPermission on GitLab
+func (client *GitLabClient) GetUserPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return PermissionNone, err
+	}
+	userID, err := client.getUserID(ctx, username)
+	if err != nil {
+		return PermissionNone, err
+	}
+	member, response, err := client.glClient.ProjectMembers.GetInheritedProjectMember(getProjectID(owner, repository), userID, gitlab.WithContext(ctx))
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return PermissionNone, nil
+		}
+		return PermissionNone, err
+	}
+	return mapGitLabAccessLevelToRepositoryPermission(member.AccessLevel), nil
+}
+
+// GetCommitBySha on GitLab
+func (client *GitLabClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	commit, _, err := client.glClient.Commits.GetCommit(getProjectID(owner, repository), sha, gitlab.WithContext(ctx))
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapGitLabCommitToCommitInfo(commit), nil
+}
+
+// ListCommits on GitLab. GitLab doesn't filter the commits by author, so the author filter is applied to each fetched page.
+func (client *GitLabClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	return listPageOrAll(ctx, client.listCommitsPager(owner, repository, options), options.Page)
+}
+
+func (client *GitLabClient) listCommitsPager(owner, repository string, options ListCommitsOptions) *Pager[CommitInfo] {
+	listOptions := &gitlab.ListCommitsOptions{ListOptions: gitlab.ListOptions{Page: firstPage(options.Page), PerPage: options.PerPage}}
+	if options.Branch != "" {
+		listOptions.RefName = &options.Branch
+	}
+	if options.Path != "" {
+		listOptions.Path = &options.Path
+	}
+	if !options.Since.IsZero() {
+		listOptions.Since = &options.Since
+	}
+	if !options.Until.IsZero() {
+		listOptions.Until = &options.Until
+	}
+	return newPager(func(ctx context.Context) ([]CommitInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching commits page", listOptions.Page, "in", repository)
+		commits, response, err := client.glClient.Commits.ListCommits(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		var results []CommitInfo
+		for _, commit := range commits {
+			commitInfo := mapGitLabCommitToCommitInfo(commit)
+			if options.matches(time.Unix(commitInfo.Timestamp, 0), commit.AuthorName, commit.AuthorEmail) {
+				results = append(results, commitInfo)
+			}
+		}
+		listOptions.Page = response.NextPage
+		return results, listOptions.Page > 0, nil
+	})
+}
+
+// GetCommit on GitLab. GitLab doesn't return the number of added and deleted lines of each file.
+func (client *GitLabClient) GetCommit(ctx context.Context, owner, repository, sha string) (CommitDetails, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha})
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	commit, _, err := client.glClient.Commits.GetCommit(getProjectID(owner, repository), sha, gitlab.WithContext(ctx))
+	if err != nil {
+		return CommitDetails{}, err
+	}
+	result := CommitDetails{CommitInfo: mapGitLabCommitToCommitInfo(commit)}
+	if commit.Stats != nil {
+		result.Additions, result.Deletions = commit.Stats.Additions, commit.Stats.Deletions
+	}
+	for nextPage := 1; nextPage > 0; {
+		diffs, response, err := client.glClient.Commits.GetCommitDiff(getProjectID(owner, repository), sha,
+			&gitlab.GetCommitDiffOptions{Page: nextPage, PerPage: 100}, gitlab.WithContext(ctx))
+		if err != nil {
+			return CommitDetails{}, err
+		}
+		for _, diff := range diffs {
+			result.Files = append(result.Files, mapGitLabDiffToPullRequestFile(diff))
+		}
+		nextPage = response.NextPage
+	}
+	return result, nil
+}
+
+// GetCommitVerification on GitLab. GitLab reports the signer of GPG signatures only.
+func (client *GitLabClient) GetCommitVerification(ctx context.Context, owner, repository, sha string) (CommitVerificationInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha})
+	if err != nil {
+		return CommitVerificationInfo{}, err
+	}
+	// The GitLab client supports GPG signatures only, so the request is built here
+	request, err := client.glClient.NewRequest(http.MethodGet,
+		fmt.Sprintf("projects/%s/repository/commits/%s/signature", url.PathEscape(getProjectID(owner, repository)), url.PathEscape(sha)), nil,
+		[]gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return CommitVerificationInfo{}, err
+	}
+	var signature gitLabCommitSignature
+	response, err := client.glClient.Do(request, &signature)
+	if err != nil {
+		// Unsigned commits have no signature
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return CommitVerificationInfo{Reason: "unsigned"}, nil
+		}
+		return CommitVerificationInfo{}, err
+	}
+	verification := CommitVerificationInfo{
+		Signed:      true,
+		Verified:    signature.VerificationStatus == "verified",
+		Reason:      signature.VerificationStatus,
+		SignerName:  signature.GPGKeyUserName,
+		SignerEmail: signature.GPGKeyUserEmail,
+	}
+	switch signature.SignatureType {
+	case "PGP":
+		verification.SignatureType = SignatureGPG
+	case "SSH":
+		verification.SignatureType = SignatureSSH
+	case "X509":
+		verification.SignatureType = SignatureX509
+		verification.SignerEmail = signature.X509Certificate.Email
+	}
+	return verification, nil
+}
+
+// CompareCommits on GitLab
+func (client *GitLabClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	// GitLab compares the refs from their merge base, so the commits of head that aren't in base are returned
+	comparison, _, err := client.glClient.Repositories.Compare(getProjectID(owner, repository),
+		&gitlab.CompareOptions{From: &base, To: &head}, gitlab.WithContext(ctx))
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	// GitLab doesn't return the number of commits head is behind base, so the refs are compared the other way around as well
+	reverseComparison, _, err := client.glClient.Repositories.Compare(getProjectID(owner, repository),
+		&gitlab.CompareOptions{From: &head, To: &base}, gitlab.WithContext(ctx))
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	result := CommitsComparison{AheadBy: len(comparison.Commits), BehindBy: len(reverseComparison.Commits)}
+	for _, commit := range comparison.Commits {
+		result.Commits = append(result.Commits, mapGitLabCommitToCommitInfo(commit))
+	}
+	for _, diff := range comparison.Diffs {
+		result.Files = append(result.Files, mapGitLabDiffToPullRequestFile(diff))
+	}
+	return result, nil
+}
+
+// GetCommitsBetween on GitLab
+func (client *GitLabClient) GetCommitsBetween(ctx context.Context, owner, repository, fromSha, toSha string) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"fromSha":    fromSha,
+		"toSha":      toSha,
+	})
+	if err != nil {
+		return nil, err
+	}
+	comparison, _, err := client.glClient.Repositories.Compare(getProjectID(owner, repository),
+		&gitlab.CompareOptions{From: &fromSha, To: &toSha}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]CommitInfo, 0, len(comparison.Commits))
+	for _, commit := range comparison.Commits {
+		results = append(results, mapGitLabCommitToCommitInfo(commit))
+	}
+	// The comparison lists the commits from the oldest one
+	reverseCommits(results)
+	return results, nil
+}
+
+// ListContributors on GitLab
+func (client *GitLabClient) ListContributors(ctx context.Context, owner, repository string) ([]ContributorInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListContributorsOptions{
+		ListOptions: gitlab.ListOptions{Page: 1, PerPage: 100},
+		OrderBy:     gitlab.String("commits"),
+		Sort:        gitlab.String("desc"),
+	}
+	var results []ContributorInfo
+	for options.Page > 0 {
+		contributors, response, err := client.glClient.Repositories.Contributors(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, contributor := range contributors {
+			results = append(results, ContributorInfo{Name: contributor.Name, Email: contributor.Email, Commits: contributor.Commits})
+		}
+		options.Page = response.NextPage
+	}
+	return results, nil
+}
+
+// GetCommitActivity on GitLab. The activity is counted from the commits, since GitLab doesn't provide commit statistics.
+func (client *GitLabClient) GetCommitActivity(ctx context.Context, owner, repository string, since time.Time) ([]CommitActivityInfo, error) {
+	return getCommitActivityFromCommits(ctx, client, owner, repository, since)
+}
+
+// CreateLabel on GitLab
+func (client *GitLabClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.glClient.Labels.CreateLabel(getProjectID(owner, repository), &gitlab.CreateLabelOptions{
+		Name:        &labelInfo.Name,
+		Description: &labelInfo.Description,
+		Color:       &labelInfo.Color,
+	}, gitlab.WithContext(ctx))
+
+	return err
+}
+
+// GetLabel on GitLub
+func (client *GitLabClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := client.ListLabels(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, label := range labels {
+		if label.Name == name {
+			return &label, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ListLabels on GitLab
+func (client *GitLabClient) ListLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	return client.ListLabelsPager(owner, repository, 100).All(ctx)
+}
+
+// ListLabelsPager on GitLab
+func (client *GitLabClient) ListLabelsPager(owner, repository string, perPage int) *Pager[LabelInfo] {
+	options := &gitlab.ListLabelsOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: perPage}}
+	return newPager(func(ctx context.Context) ([]LabelInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		labels, response, err := client.glClient.Labels.ListLabels(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]LabelInfo, 0, len(labels))
+		for _, label := range labels {
+			results = append(results, LabelInfo{
+				Name:        label.Name,
+				Description: label.Description,
+				Color:       strings.TrimPrefix(label.Color, "#"),
+			})
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// UpdateLabel on GitLab
+func (client *GitLabClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	options := &gitlab.UpdateLabelOptions{Name: &name}
+	if labelInfo.Name != "" && labelInfo.Name != name {
+		options.NewName = &labelInfo.Name
+	}
+	if labelInfo.Description != "" {
+		options.Description = &labelInfo.Description
+	}
+	if labelInfo.Color != "" {
+		options.Color = gitlab.String("#" + labelInfo.Color)
+	}
+	_, _, err = client.glClient.Labels.UpdateLabel(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	return err
+}
+
+// DeleteLabel on GitLab
+func (client *GitLabClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.Labels.DeleteLabel(getProjectID(owner, repository), &gitlab.DeleteLabelOptions{Name: &name},
+		gitlab.WithContext(ctx))
+	return err
+}
+
+// ListPullRequestLabels on GitLab
+func (client *GitLabClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return []string{}, err
+	}
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestID,
+		&gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return []string{}, err
+	}
+
+	return mergeRequest.Labels, nil
+}
+
+// UnlabelPullRequest on GitLab
+func (client *GitLabClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID, &gitlab.UpdateMergeRequestOptions{
+		RemoveLabels: gitlab.Labels{name},
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// CreateIssue on GitLab
+func (client *GitLabClient) CreateIssue(ctx context.Context, owner, repository, title, body string, labels []string) (IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title})
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	issue, _, err := client.glClient.Issues.CreateIssue(getProjectID(owner, repository), &gitlab.CreateIssueOptions{
+		Title:       &title,
+		Description: &body,
+		Labels:      labels,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	return mapGitLabIssueToIssueInfo(issue), nil
+}
+
+// AddIssueComment on GitLab
+func (client *GitLabClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Notes.CreateIssueNote(getProjectID(owner, repository), issueID, &gitlab.CreateIssueNoteOptions{
+		Body: &content,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// ListIssues on GitLab
+func (client *GitLabClient) ListIssues(ctx context.Context, owner, repository string, filter IssueFilter) ([]IssueInfo, error) {
+	return listPageOrAll(ctx, client.issuesPager(owner, repository, filter), filter.Page)
+}
+
+func (client *GitLabClient) issuesPager(owner, repository string, filter IssueFilter) *Pager[IssueInfo] {
+	options := &gitlab.ListProjectIssuesOptions{
+		ListOptions: gitlab.ListOptions{Page: firstPage(filter.Page), PerPage: filter.PerPage},
+	}
+	if filter.State != nil {
+		state := "opened"
+		if *filter.State == IssueClosed {
+			state = "closed"
+		}
+		options.State = &state
+	}
+	if filter.Label != "" {
+		options.Labels = gitlab.Labels{filter.Label}
+	}
+	return newPager(func(ctx context.Context) ([]IssueInfo, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+		if err != nil {
+			return nil, false, err
+		}
+		issues, response, err := client.glClient.Issues.ListProjectIssues(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		var results []IssueInfo
+		for _, issue := range issues {
+			// GitLab filters the issues by the ID of their author, so the author's username is matched here
+			issueInfo := mapGitLabIssueToIssueInfo(issue)
+			if filter.matches(issueInfo) {
+				results = append(results, issueInfo)
+			}
+		}
+		options.Page = response.NextPage
+		return results, options.Page > 0, nil
+	})
+}
+
+// UpdateIssueState on GitLab
+func (client *GitLabClient) UpdateIssueState(ctx context.Context, owner, repository string, issueID int, state IssueState) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	stateEvent := "reopen"
+	if state == IssueClosed {
+		stateEvent = "close"
+	}
+	_, _, err = client.glClient.Issues.UpdateIssue(getProjectID(owner, repository), issueID, &gitlab.UpdateIssueOptions{
+		StateEvent: &stateEvent,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// UploadCodeScanning on GitLab
+func (client *GitLabClient) UploadCodeScanning(_ context.Context, _ string, _ string, _ string, _ string) (string, error) {
+	return "", errGitLabCodeScanningNotSupported
+}
+
+// UploadScanResults on GitLab comments a summary of the findings on the commit, since security reports can be uploaded by CI jobs only
+func (client *GitLabClient) UploadScanResults(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return "", err
+	}
+	results, err := parseSarif(scanResults)
+	if err != nil {
+		return "", err
+	}
+	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return "", err
+	}
+	// The GitLab client doesn't support commit discussions of commit SHAs, so the request is built here
+	path := fmt.Sprintf("projects/%s/repository/commits/%s/discussions", url.PathEscape(getProjectID(owner, repository)), commit.Hash)
+	request, err := client.glClient.NewRequest(http.MethodPost, path, &gitlab.CreateCommitDiscussionOptions{Body: gitlab.String(results.markdownSummary())},
+		[]gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return "", err
+	}
+	var discussion gitlab.Discussion
+	if _, err = client.glClient.Do(request, &discussion); err != nil {
+		return "", err
+	}
+	return discussion.ID, nil
+}
+
+// ListSecurityAlerts on GitLab returns the vulnerability findings of the latest pipeline of the default branch, except for the dismissed findings.
+// Dependency, container and cluster image scanning findings are dependency alerts, and the other findings are code scanning alerts.
+func (client *GitLabClient) ListSecurityAlerts(ctx context.Context, owner, repository string) ([]SecurityAlertInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	// The GitLab client doesn't support the vulnerability findings, so the request is built here
+	path := fmt.Sprintf("projects/%s/vulnerability_findings", url.PathEscape(getProjectID(owner, repository)))
+	var results []SecurityAlertInfo
+	for nextPage := 1; nextPage > 0; {
+		request, err := client.glClient.NewRequest(http.MethodGet, path, &gitlab.ListOptions{Page: nextPage, PerPage: 100},
+			[]gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+		if err != nil {
+			return nil, err
+		}
+		var findings []gitLabVulnerabilityFinding
+		response, err := client.glClient.Do(request, &findings)
+		if err != nil {
+			return nil, err
+		}
+		for _, finding := range findings {
+			results = append(results, mapGitLabVulnerabilityFindingToSecurityAlertInfo(finding))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+type gitLabVulnerabilityFinding struct {
+	ID          int64  `json:"id"`
+	ReportType  string `json:"report_type"`
+	Name        string `json:"name"`
+	Severity    string `json:"severity"`
+	Identifiers []struct {
+		ExternalType string `json:"external_type"`
+		ExternalID   string `json:"external_id"`
+	} `json:"identifiers"`
+	Location struct {
+		File       string `json:"file"`
+		Dependency struct {
+			Package struct {
+				Name string `json:"name"`
+			} `json:"package"`
+		} `json:"dependency"`
+	} `json:"location"`
+}
+
+func mapGitLabVulnerabilityFindingToSecurityAlertInfo(finding gitLabVulnerabilityFinding) SecurityAlertInfo {
+	alertType := CodeScanningAlert
+	switch finding.ReportType {
+	case "dependency_scanning", "container_scanning", "cluster_image_scanning":
+		alertType = DependencyAlert
+	}
+	// Prefer the CVE identifier, if the finding has one
+	var identifier string
+	for _, findingIdentifier := range finding.Identifiers {
+		if strings.EqualFold(findingIdentifier.ExternalType, "cve") {
+			identifier = findingIdentifier.ExternalID
+			break
+		}
+		if identifier == "" {
+			identifier = findingIdentifier.ExternalID
+		}
+	}
+	return SecurityAlertInfo{
+		ID:         finding.ID,
+		Type:       alertType,
+		Severity:   getSecuritySeverity(finding.Severity),
+		Title:      finding.Name,
+		Identifier: identifier,
+		Package:    finding.Location.Dependency.Package.Name,
+		Path:       finding.Location.File,
+	}
+}
+
+// GetRepositoryEnvironmentInfo on GitLab
+func (client *GitLabClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errGitLabGetRepoEnvironmentInfoNotSupported
 }
 
-// ListPullRequestComments on GitLab
-func (client *GitLabClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+// ListEnvironments on GitLab
+func (client *GitLabClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return []CommentInfo{}, err
+		return nil, err
 	}
-	commentsList, _, err := client.glClient.Notes.ListMergeRequestNotes(getProjectID(owner, repository), pullRequestID, &gitlab.ListMergeRequestNotesOptions{},
-		gitlab.WithContext(ctx))
-	if err != nil {
-		return []CommentInfo{}, err
+	var results []RepositoryEnvironmentInfo
+	for nextPage := 1; nextPage > 0; {
+		environments, response, err := client.glClient.Environments.ListEnvironments(getProjectID(owner, repository),
+			&gitlab.ListEnvironmentsOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range environments {
+			results = append(results, RepositoryEnvironmentInfo{Name: environment.Name, Url: environment.ExternalURL})
+		}
+		nextPage = response.NextPage
 	}
-	return mapGitLabNotesToCommentInfoList(commentsList), nil
+	return results, nil
 }
 
-// GetLatestCommit on GitLab
-func (client *GitLabClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
-	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"branch":     branch,
-	})
+// CreateDeployment on GitLab. The deployment is created in the running state, and the ref is resolved to its commit.
+func (client *GitLabClient) CreateDeployment(ctx context.Context, owner, repository string, deployment DeploymentInfo) (int64, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "environment": deployment.Environment, "ref": deployment.Ref})
 	if err != nil {
-		return CommitInfo{}, err
-	}
-
-	listOptions := &gitlab.ListCommitsOptions{
-		RefName: &branch,
-		ListOptions: gitlab.ListOptions{
-			Page:    1,
-			PerPage: 1,
-		},
+		return 0, err
 	}
-
-	commits, _, err := client.glClient.Commits.ListCommits(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+	projectID := getProjectID(owner, repository)
+	commit, _, err := client.glClient.Commits.GetCommit(projectID, deployment.Ref, gitlab.WithContext(ctx))
 	if err != nil {
-		return CommitInfo{}, err
+		return 0, err
 	}
-	if len(commits) > 0 {
-		latestCommit := commits[0]
-		return mapGitLabCommitToCommitInfo(latestCommit), nil
+	// GitLab requires to know whether the deployed ref is a tag
+	_, response, err := client.glClient.Tags.GetTag(projectID, deployment.Ref, gitlab.WithContext(ctx))
+	if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+		return 0, err
 	}
-	return CommitInfo{}, errors.New(`{"message":"404 Not Found"}`)
+	isTag := err == nil
+	created, _, err := client.glClient.Deployments.CreateProjectDeployment(projectID, &gitlab.CreateProjectDeploymentOptions{
+		Environment: &deployment.Environment,
+		Ref:         &deployment.Ref,
+		SHA:         &commit.ID,
+		Tag:         &isTag,
+		Status:      gitlab.DeploymentStatus(gitlab.DeploymentStatusRunning),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	return int64(created.ID), nil
 }
 
-// GetRepositoryInfo on GitLab
-func (client *GitLabClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+// SetDeploymentStatus on GitLab. Only the state of the deployment is set.
+func (client *GitLabClient) SetDeploymentStatus(ctx context.Context, owner, repository string, deploymentID int64, status DeploymentStatusInfo) error {
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return RepositoryInfo{}, err
+		return err
+	}
+	_, _, err = client.glClient.Deployments.UpdateProjectDeployment(getProjectID(owner, repository), int(deploymentID),
+		&gitlab.UpdateProjectDeploymentOptions{Status: gitlab.DeploymentStatus(gitlab.DeploymentStatusValue(getGitLabCommitState(status.State)))}, gitlab.WithContext(ctx))
+	return err
+}
+
+// DownloadFileFromRepo on GitLab
+func (client *GitLabClient) DownloadFileFromRepo(_ context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	file, response, err := client.glClient.RepositoryFiles.GetFile(getProjectID(owner, repository), path, &gitlab.GetFileOptions{Ref: &branch})
+	if response != nil && response.StatusCode != http.StatusOK {
+		return nil, response.StatusCode, fmt.Errorf("expected %d status code while received %d status code with error:\n%s", http.StatusOK, response.StatusCode, err)
+	}
+	if err != nil {
+		return nil, 0, err
 	}
 
-	project, _, err := client.glClient.Projects.GetProject(getProjectID(owner, repository), nil, gitlab.WithContext(ctx))
+	content, err := base64.StdEncoding.DecodeString(file.Content)
 	if err != nil {
-		return RepositoryInfo{}, err
+		return nil, response.StatusCode, err
 	}
 
-	return RepositoryInfo{RepositoryVisibility: getGitLabProjectVisibility(project), CloneInfo: CloneInfo{HTTP: project.HTTPURLToRepo, SSH: project.SSHURLToRepo}}, nil
+	return content, response.StatusCode, err
 }
 
-// GetCommitBySha on GitLab
-func (client *GitLabClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+// GetFileContent on GitLab
+func (client *GitLabClient) GetFileContent(ctx context.Context, owner, repository, ref, path string) (FileContent, error) {
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
-		"sha":        sha,
+		"ref":        ref,
+		"path":       path,
 	})
 	if err != nil {
-		return CommitInfo{}, err
+		return FileContent{}, err
 	}
-
-	commit, _, err := client.glClient.Commits.GetCommit(getProjectID(owner, repository), sha, gitlab.WithContext(ctx))
+	file, _, err := client.glClient.RepositoryFiles.GetFile(getProjectID(owner, repository), path, &gitlab.GetFileOptions{Ref: &ref},
+		gitlab.WithContext(ctx))
 	if err != nil {
-		return CommitInfo{}, err
+		return FileContent{}, err
 	}
-	return mapGitLabCommitToCommitInfo(commit), nil
-}
-
-// CreateLabel on GitLab
-func (client *GitLabClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
+	content, err := base64.StdEncoding.DecodeString(file.Content)
 	if err != nil {
-		return err
+		return FileContent{}, err
 	}
-
-	_, _, err = client.glClient.Labels.CreateLabel(getProjectID(owner, repository), &gitlab.CreateLabelOptions{
-		Name:        &labelInfo.Name,
-		Description: &labelInfo.Description,
-		Color:       &labelInfo.Color,
-	}, gitlab.WithContext(ctx))
-
-	return err
+	return FileContent{Path: file.FilePath, Content: content, Size: int64(file.Size), Sha: file.BlobID}, nil
 }
 
-// GetLabel on GitLub
-func (client *GitLabClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+// GetFileBlame on GitLab
+func (client *GitLabClient) GetFileBlame(ctx context.Context, owner, repository, ref, path string) ([]BlameLine, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"ref":        ref,
+		"path":       path,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	labels, _, err := client.glClient.Labels.ListLabels(getProjectID(owner, repository), &gitlab.ListLabelsOptions{}, gitlab.WithContext(ctx))
+	blameRanges, _, err := client.glClient.RepositoryFiles.GetFileBlame(getProjectID(owner, repository), path, &gitlab.GetFileBlameOptions{Ref: &ref},
+		gitlab.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
-
-	for _, label := range labels {
-		if label.Name == name {
-			return &LabelInfo{
-				Name:        label.Name,
-				Description: label.Description,
-				Color:       strings.TrimPrefix(label.Color, "#"),
-			}, err
+	var blame []BlameLine
+	for _, blameRange := range blameRanges {
+		var timestamp int64
+		if blameRange.Commit.AuthoredDate != nil {
+			timestamp = blameRange.Commit.AuthoredDate.Unix()
+		}
+		// The ranges are consecutive, so the line numbers are derived from the number of lines in each range
+		for range blameRange.Lines {
+			blame = append(blame, BlameLine{
+				Line:        len(blame) + 1,
+				CommitHash:  blameRange.Commit.ID,
+				AuthorName:  blameRange.Commit.AuthorName,
+				AuthorEmail: blameRange.Commit.AuthorEmail,
+				Timestamp:   timestamp,
+			})
 		}
 	}
-
-	return nil, nil
+	return blame, nil
 }
 
-// ListPullRequestLabels on GitLab
-func (client *GitLabClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// ListRepositoryTree on GitLab. GitLab doesn't provide the sizes of the files.
+func (client *GitLabClient) ListRepositoryTree(ctx context.Context, owner, repository, ref string, recursive bool) ([]TreeEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
 	if err != nil {
-		return []string{}, err
+		return nil, err
 	}
-	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestID,
-		&gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
-	if err != nil {
-		return []string{}, err
+	options := &gitlab.ListTreeOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: 100}, Ref: &ref, Recursive: &recursive}
+	var results []TreeEntry
+	for options.Page > 0 {
+		nodes, response, err := client.glClient.Repositories.ListTree(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			results = append(results, TreeEntry{Path: node.Path, Type: getGitTreeEntryType(node.Type)})
+		}
+		options.Page = response.NextPage
 	}
+	return results, nil
+}
 
-	return mergeRequest.Labels, nil
+// SearchCode on GitLab. The code of an owner is searched in the projects of the group, so the owner must be a group.
+func (client *GitLabClient) SearchCode(ctx context.Context, scope CodeSearchScope, query string) ([]CodeSearchResult, error) {
+	return listPageOrAll(ctx, client.searchCodePager(scope, query), scope.Page)
 }
 
-// UnlabelPullRequest on GitLab
-func (client *GitLabClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+func (client *GitLabClient) searchCodePager(scope CodeSearchScope, query string) *Pager[CodeSearchResult] {
+	searchOptions := &gitlab.SearchOptions{ListOptions: gitlab.ListOptions{Page: firstPage(scope.Page), PerPage: scope.PerPage}}
+	// The blobs found in a group contain only the IDs of their projects, so the paths of the projects are cached
+	projects := map[int]*gitlab.Project{}
+	return newPager(func(ctx context.Context) ([]CodeSearchResult, bool, error) {
+		err := validateParametersNotBlank(map[string]string{"owner": scope.Owner, "query": query})
+		if err != nil {
+			return nil, false, err
+		}
+		client.logger.Debug("fetching code search results page", searchOptions.Page)
+		var blobs []*gitlab.Blob
+		var response *gitlab.Response
+		if scope.Repository != "" {
+			blobs, response, err = client.glClient.Search.BlobsByProject(getProjectID(scope.Owner, scope.Repository), query, searchOptions, gitlab.WithContext(ctx))
+		} else {
+			blobs, response, err = client.glClient.Search.BlobsByGroup(scope.Owner, query, searchOptions, gitlab.WithContext(ctx))
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		results := make([]CodeSearchResult, 0, len(blobs))
+		for _, blob := range blobs {
+			result := CodeSearchResult{Owner: scope.Owner, Repository: scope.Repository, Path: blob.Filename, Fragments: []string{blob.Data}}
+			if scope.Repository == "" {
+				project, exists := projects[blob.ProjectID]
+				if !exists {
+					if project, _, err = client.glClient.Projects.GetProject(blob.ProjectID, nil, gitlab.WithContext(ctx)); err != nil {
+						return nil, false, err
+					}
+					projects[blob.ProjectID] = project
+				}
+				details := mapGitLabProjectToRepositoryDetails(project)
+				result.Owner, result.Repository = details.Owner, details.Repository
+			}
+			results = append(results, result)
+		}
+		searchOptions.Page = response.NextPage
+		return results, searchOptions.Page > 0, nil
+	})
+}
+
+// CreateOrUpdateFile on GitLab
+func (client *GitLabClient) CreateOrUpdateFile(ctx context.Context, owner, repository, branch, path string, content []byte, commitMessage string) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":          owner,
+		"repository":     repository,
+		"branch":         branch,
+		"path":           path,
+		"commit message": commitMessage,
+	})
 	if err != nil {
 		return err
 	}
-	_, _, err = client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID, &gitlab.UpdateMergeRequestOptions{
-		RemoveLabels: gitlab.Labels{name},
+	projectID := getProjectID(owner, repository)
+	encoding := "base64"
+	encodedContent := base64.StdEncoding.EncodeToString(content)
+	_, response, err := client.glClient.RepositoryFiles.GetFileMetaData(projectID, path, &gitlab.GetFileMetaDataOptions{Ref: &branch},
+		gitlab.WithContext(ctx))
+	if err != nil {
+		if response == nil || response.StatusCode != http.StatusNotFound {
+			return err
+		}
+		client.logger.Debug("creating file", path, "on branch", branch)
+		_, _, err = client.glClient.RepositoryFiles.CreateFile(projectID, path, &gitlab.CreateFileOptions{
+			Branch:        &branch,
+			Encoding:      &encoding,
+			Content:       &encodedContent,
+			CommitMessage: &commitMessage,
+		}, gitlab.WithContext(ctx))
+		return err
+	}
+	client.logger.Debug("updating file", path, "on branch", branch)
+	_, _, err = client.glClient.RepositoryFiles.UpdateFile(projectID, path, &gitlab.UpdateFileOptions{
+		Branch:        &branch,
+		Encoding:      &encoding,
+		Content:       &encodedContent,
+		CommitMessage: &commitMessage,
 	}, gitlab.WithContext(ctx))
 	return err
 }
 
-// UploadCodeScanning on GitLab
-func (client *GitLabClient) UploadCodeScanning(_ context.Context, _ string, _ string, _ string, _ string) (string, error) {
-	return "", errGitLabCodeScanningNotSupported
-}
-
-// GetRepositoryEnvironmentInfo on GitLab
-func (client *GitLabClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
-	return RepositoryEnvironmentInfo{}, errGitLabGetRepoEnvironmentInfoNotSupported
-}
-
-// DownloadFileFromRepo on GitLab
-func (client *GitLabClient) DownloadFileFromRepo(_ context.Context, owner, repository, branch, path string) ([]byte, int, error) {
-	file, response, err := client.glClient.RepositoryFiles.GetFile(getProjectID(owner, repository), path, &gitlab.GetFileOptions{Ref: &branch})
-	if response != nil && response.StatusCode != http.StatusOK {
-		return nil, response.StatusCode, fmt.Errorf("expected %d status code while received %d status code with error:\n%s", http.StatusOK, response.StatusCode, err)
-	}
+// CommitFiles on GitLab
+func (client *GitLabClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, changes []FileChange) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":          owner,
+		"repository":     repository,
+		"branch":         branch,
+		"commit message": commitMessage,
+	})
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
-
-	content, err := base64.StdEncoding.DecodeString(file.Content)
-	if err != nil {
-		return nil, response.StatusCode, err
+	if err = validateFileChanges(changes); err != nil {
+		return err
+	}
+	encoding := "base64"
+	actions := make([]*gitlab.CommitActionOptions, 0, len(changes))
+	for _, change := range changes {
+		action := &gitlab.CommitActionOptions{Action: getGitLabFileAction(change.Type), FilePath: gitlab.String(change.Path)}
+		if change.Type != DeleteFile {
+			action.Content = gitlab.String(base64.StdEncoding.EncodeToString(change.Content))
+			action.Encoding = &encoding
+		}
+		actions = append(actions, action)
 	}
+	client.logger.Debug("committing", len(changes), "files to branch", branch)
+	_, _, err = client.glClient.Commits.CreateCommit(getProjectID(owner, repository), &gitlab.CreateCommitOptions{
+		Branch:        &branch,
+		CommitMessage: &commitMessage,
+		Actions:       actions,
+	}, gitlab.WithContext(ctx))
+	return err
+}
 
-	return content, response.StatusCode, err
+func getGitLabFileAction(changeType FileChangeType) *gitlab.FileActionValue {
+	action := gitlab.FileCreate
+	switch changeType {
+	case UpdateFile:
+		action = gitlab.FileUpdate
+	case DeleteFile:
+		action = gitlab.FileDelete
+	}
+	return &action
 }
 
 func getProjectID(owner, project string) string {
@@ -422,7 +2683,7 @@ func createProjectHook(branch string, payloadURL string, webhookEvents ...vcsuti
 	options := &gitlab.ProjectHook{URL: payloadURL}
 	for _, webhookEvent := range webhookEvents {
 		switch webhookEvent {
-		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrRejected, vcsutils.PrMerged:
+		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrRejected, vcsutils.PrMerged, vcsutils.PrClosed:
 			options.MergeRequestsEvents = true
 		case vcsutils.Push:
 			options.PushEvents = true
@@ -432,6 +2693,95 @@ func createProjectHook(branch string, payloadURL string, webhookEvents ...vcsuti
 	return options
 }
 
+func mapGitLabProjectHookToWebhookInfo(hook *gitlab.ProjectHook) WebhookInfo {
+	return WebhookInfo{
+		ID:          strconv.Itoa(hook.ID),
+		PayloadURL:  hook.URL,
+		Events:      getGitLabHookWebhookEvents(hook.MergeRequestsEvents, hook.PushEvents),
+		Active:      true,
+		ContentType: WebhookContentTypeJSON,
+		InsecureSSL: !hook.EnableSSLVerification,
+	}
+}
+
+func mapGitLabGroupHookToWebhookInfo(hook *gitlab.GroupHook) WebhookInfo {
+	return WebhookInfo{
+		ID:          strconv.Itoa(hook.ID),
+		PayloadURL:  hook.URL,
+		Events:      getGitLabHookWebhookEvents(hook.MergeRequestsEvents, hook.PushEvents),
+		Active:      true,
+		ContentType: WebhookContentTypeJSON,
+		InsecureSSL: !hook.EnableSSLVerification,
+	}
+}
+
+// getGitLabHookWebhookEvents reverses the mapping of webhook events done by createProjectHook
+func getGitLabHookWebhookEvents(mergeRequestsEvents, pushEvents bool) []vcsutils.WebhookEvent {
+	var events []vcsutils.WebhookEvent
+	if mergeRequestsEvents {
+		events = append(events, vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected)
+	}
+	if pushEvents {
+		events = append(events, vcsutils.Push)
+	}
+	return events
+}
+
+// Guests can't access the code of a project, so they have no permission on the repository
+func mapGitLabAccessLevelToRepositoryPermission(accessLevel gitlab.AccessLevelValue) RepositoryPermission {
+	switch {
+	case accessLevel >= gitlab.MaintainerPermissions:
+		return PermissionAdmin
+	case accessLevel >= gitlab.DeveloperPermissions:
+		return PermissionWrite
+	case accessLevel >= gitlab.ReporterPermissions:
+		return PermissionRead
+	default:
+		return PermissionNone
+	}
+}
+
+func getGitLabAccessLevel(permission RepositoryPermission) gitlab.AccessLevelValue {
+	switch permission {
+	case PermissionAdmin:
+		return gitlab.MaintainerPermissions
+	case PermissionWrite:
+		return gitlab.DeveloperPermissions
+	default:
+		return gitlab.ReporterPermissions
+	}
+}
+
+// Statistics are returned only to project members with at least reporter access
+func mapGitLabProjectToRepositoryInfo(project *gitlab.Project) RepositoryInfo {
+	info := RepositoryInfo{
+		RepositoryVisibility: getGitLabProjectVisibility(project),
+		CloneInfo:            CloneInfo{HTTP: project.HTTPURLToRepo, SSH: project.SSHURLToRepo},
+		DefaultBranch:        project.DefaultBranch,
+		Description:          project.Description,
+		Archived:             project.Archived,
+		Topics:               project.Topics,
+	}
+	if project.Statistics != nil {
+		info.Size = project.Statistics.RepositorySize
+	}
+	return info
+}
+
+func mapGitLabProjectToRepositoryDetails(project *gitlab.Project) RepositoryDetails {
+	details := RepositoryDetails{
+		Repository:     project.Path,
+		RepositoryInfo: mapGitLabProjectToRepositoryInfo(project),
+	}
+	if project.Namespace != nil {
+		details.Owner = project.Namespace.FullPath
+	}
+	if project.LastActivityAt != nil {
+		details.Updated = *project.LastActivityAt
+	}
+	return details
+}
+
 func getGitLabProjectVisibility(project *gitlab.Project) RepositoryVisibility {
 	switch project.Visibility {
 	case gitlab.PublicVisibility:
@@ -443,6 +2793,20 @@ func getGitLabProjectVisibility(project *gitlab.Project) RepositoryVisibility {
 	}
 }
 
+func getGitLabVisibilityValue(visibility *RepositoryVisibility) gitlab.VisibilityValue {
+	if visibility == nil {
+		return gitlab.PrivateVisibility
+	}
+	switch *visibility {
+	case Public:
+		return gitlab.PublicVisibility
+	case Internal:
+		return gitlab.InternalVisibility
+	default:
+		return gitlab.PrivateVisibility
+	}
+}
+
 func getGitLabCommitState(commitState CommitStatus) string {
 	switch commitState {
 	case Pass:
@@ -457,13 +2821,68 @@ func getGitLabCommitState(commitState CommitStatus) string {
 	return ""
 }
 
+func getGitLabCommitStatus(state string) CommitStatus {
+	switch state {
+	case "success":
+		return Pass
+	case "failed":
+		return Fail
+	case "created", "waiting_for_resource", "preparing", "pending", "running", "scheduled", "manual":
+		return InProgress
+	}
+	return Error
+}
+
+func mapGitLabCommitStatusToCommitStatusInfo(status *gitlab.CommitStatus) CommitStatusInfo {
+	statusInfo := CommitStatusInfo{
+		State:       getGitLabCommitStatus(status.Status),
+		Title:       status.Name,
+		Description: status.Description,
+		DetailsURL:  status.TargetURL,
+	}
+	if status.CreatedAt != nil {
+		statusInfo.CreatedAt = *status.CreatedAt
+		statusInfo.LastUpdatedAt = *status.CreatedAt
+	}
+	if status.FinishedAt != nil {
+		statusInfo.LastUpdatedAt = *status.FinishedAt
+	} else if status.StartedAt != nil {
+		statusInfo.LastUpdatedAt = *status.StartedAt
+	}
+	return statusInfo
+}
+
+func mapGitLabDiffToPullRequestFile(diff *gitlab.Diff) PullRequestFile {
+	file := PullRequestFile{Path: diff.NewPath, Status: FileModified}
+	switch {
+	case diff.NewFile:
+		file.Status = FileAdded
+	case diff.DeletedFile:
+		file.Status = FileDeleted
+	case diff.RenamedFile:
+		file.Status, file.PreviousPath = FileRenamed, diff.OldPath
+	}
+	// GitLab doesn't return the line counts, so they are calculated from the diff
+	file.Additions, file.Deletions = countDiffLines(diff.Diff)
+	return file
+}
+
 func mapGitLabCommitToCommitInfo(commit *gitlab.Commit) CommitInfo {
+	// The committed date is missing in some of the responses, such as in older versions of the merge request commits API
+	commitDate := commit.CommittedDate
+	if commitDate == nil {
+		commitDate = commit.CreatedAt
+	}
+	var timestamp int64
+	if commitDate != nil {
+		timestamp = commitDate.UTC().Unix()
+	}
 	return CommitInfo{
 		Hash:          commit.ID,
 		AuthorName:    commit.AuthorName,
 		CommitterName: commit.CommitterName,
 		Url:           commit.WebURL,
-		Timestamp:     commit.CommittedDate.UTC().Unix(),
+		Timestamp:     timestamp,
 		Message:       commit.Message,
 		ParentHashes:  commit.ParentIDs,
 	}
@@ -486,7 +2905,125 @@ func mapGitLabMergeRequestToPullRequestInfoList(mergeRequests []*gitlab.MergeReq
 			ID:     int64(mergeRequest.IID),
 			Source: BranchInfo{Name: mergeRequest.SourceBranch},
 			Target: BranchInfo{Name: mergeRequest.TargetBranch},
+			Draft:  mergeRequest.WorkInProgress,
 		})
 	}
 	return
 }
+
+func mapGitLabMergeRequestToPullRequestInfo(mergeRequest *gitlab.MergeRequest) PullRequestInfo {
+	pullRequestInfo := PullRequestInfo{
+		ID:     int64(mergeRequest.IID),
+		Source: BranchInfo{Name: mergeRequest.SourceBranch},
+		Target: BranchInfo{Name: mergeRequest.TargetBranch},
+		Title:  mergeRequest.Title,
+		Body:   mergeRequest.Description,
+		State:  getGitLabMergeRequestState(mergeRequest.State),
+		Draft:  mergeRequest.WorkInProgress,
+		Labels: mergeRequest.Labels,
+	}
+	if mergeRequest.Author != nil {
+		pullRequestInfo.Author = mergeRequest.Author.Username
+	}
+	// The merge status is unknown until GitLab finishes checking the merge request
+	if mergeRequest.MergeStatus == "can_be_merged" || mergeRequest.MergeStatus == "cannot_be_merged" {
+		mergeable := mergeRequest.MergeStatus == "can_be_merged"
+		pullRequestInfo.Mergeable = &mergeable
+	}
+	return pullRequestInfo
+}
+
+func getGitLabMergeRequestState(state string) PullRequestState {
+	switch state {
+	case "merged":
+		return PullRequestMerged
+	case "closed", "locked":
+		return PullRequestClosed
+	}
+	return PullRequestOpen
+}
+
+func getGitLabMergeRequestStateEvent(state PullRequestState) string {
+	if state == PullRequestClosed {
+		return "close"
+	}
+	return "reopen"
+}
+
+func mapGitLabNoteToReviewComment(note *gitlab.Note) PullRequestReviewComment {
+	reviewComment := PullRequestReviewComment{
+		CommentInfo: CommentInfo{ID: int64(note.ID), Content: note.Body},
+		Path:        note.Position.NewPath,
+		Line:        note.Position.NewLine,
+	}
+	if note.CreatedAt != nil {
+		reviewComment.Created = *note.CreatedAt
+	}
+	// Comments on removed lines have an old line only
+	if reviewComment.Line == 0 {
+		reviewComment.Path, reviewComment.Line = note.Position.OldPath, note.Position.OldLine
+	}
+	if lineRange := note.Position.LineRange; lineRange != nil && lineRange.StartRange != nil && lineRange.StartRange.NewLine < reviewComment.Line {
+		reviewComment.StartLine = lineRange.StartRange.NewLine
+	}
+	return reviewComment
+}
+
+// countDiffLines counts the added and deleted lines in the hunks of a single file diff
+func countDiffLines(diff string) (additions, deletions int) {
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return
+}
+
+func mapGitLabIssueToIssueInfo(issue *gitlab.Issue) IssueInfo {
+	state := IssueOpen
+	if issue.State == "closed" {
+		state = IssueClosed
+	}
+	issueInfo := IssueInfo{
+		ID:     int64(issue.IID),
+		Title:  issue.Title,
+		Body:   issue.Description,
+		State:  state,
+		Labels: issue.Labels,
+		URL:    issue.WebURL,
+	}
+	if issue.Author != nil {
+		issueInfo.Author = issue.Author.Username
+	}
+	if issue.CreatedAt != nil {
+		issueInfo.Created = *issue.CreatedAt
+	}
+	return issueInfo
+}
+
+// The names of the GitLab award emoji of the reactions whose names differ
+var gitLabAwardEmojiNames = map[Reaction]string{
+	ReactionThumbsUp:   "thumbsup",
+	ReactionThumbsDown: "thumbsdown",
+	ReactionLaugh:      "laughing",
+	ReactionHooray:     "tada",
+}
+
+func getGitLabAwardEmojiName(reaction Reaction) string {
+	if name, exists := gitLabAwardEmojiNames[reaction]; exists {
+		return name
+	}
+	return string(reaction)
+}
+
+func getGitLabReaction(awardEmojiName string) Reaction {
+	for reaction, name := range gitLabAwardEmojiNames {
+		if name == awardEmojiName {
+			return reaction
+		}
+	}
+	return Reaction(awardEmojiName)
+}
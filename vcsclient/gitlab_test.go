@@ -8,11 +8,13 @@ import (
 	"math"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -55,6 +57,29 @@ func TestGitLabClient_ConnectionWhenContextTimesOut(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestGitLabClient_GetRateLimitInfo(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI != "/api/v4/" {
+			assert.Equal(t, "/api/v4/user", r.RequestURI)
+			w.Header().Set("RateLimit-Limit", "600")
+			w.Header().Set("RateLimit-Remaining", "598")
+			w.Header().Set("RateLimit-Reset", "1700000000")
+		}
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	rateLimitInfo, err := buildClient(t, vcsutils.GitLab, false, server).GetRateLimitInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitInfo{Limit: 600, Remaining: 598, Reset: time.Unix(1700000000, 0)}, rateLimitInfo)
+}
+
+func TestGitLabClient_TokenSource(t *testing.T) {
+	assertTokenSourceRefresh(t, vcsutils.GitLab, "[]")
+}
+
 func TestGitLabClient_ListRepositories(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "projects_response.json"))
@@ -73,6 +98,81 @@ func TestGitLabClient_ListRepositories(t *testing.T) {
 	}, actualRepositories)
 }
 
+func TestGitLabClient_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+			return
+		case "/api/v4/namespaces/jfrog":
+			_, err := w.Write([]byte(`{"id":5,"path":"jfrog","kind":"group"}`))
+			assert.NoError(t, err)
+		case "/api/v4/groups/jfrog/projects?archived=false&order_by=last_activity_at&page=1&per_page=2&sort=desc&visibility=private":
+			w.Header().Set("X-Next-Page", "2")
+			_, err := w.Write([]byte(`[
+				{"path":"repo-1","namespace":{"full_path":"jfrog"},"visibility":"private","default_branch":"main","last_activity_at":"2023-03-01T00:00:00Z"},
+				{"path":"repo-2","namespace":{"full_path":"jfrog"},"visibility":"private","last_activity_at":"2022-12-01T00:00:00Z"}
+			]`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		assert.Equal(t, token, r.Header.Get("Private-Token"))
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	// The second page isn't fetched, since the last project of the first page was updated before UpdatedSince
+	private := Private
+	archived := false
+	result, err := client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{
+		Visibility:   &private,
+		Archived:     &archived,
+		UpdatedSince: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		PerPage:      2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryDetails{{
+		Owner:          owner,
+		Repository:     repo1,
+		RepositoryInfo: RepositoryInfo{RepositoryVisibility: Private, DefaultBranch: "main"},
+		Updated:        time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+	}}, result)
+
+	_, err = client.ListRepositoriesWithOptions(ctx, owner, ListRepositoriesOptions{Language: "Go"})
+	assert.ErrorIs(t, err, errGitLabRepositoryLanguageFilterNotSupported)
+}
+
+func TestGitLabClient_ListRepositoriesWithOptionsOfUser(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+			return
+		case "/api/v4/namespaces/frogger":
+			_, err := w.Write([]byte(`{"id":6,"path":"frogger","kind":"user"}`))
+			assert.NoError(t, err)
+		case "/api/v4/users/frogger/projects?order_by=last_activity_at&page=1&sort=desc":
+			_, err := w.Write([]byte(`[{"path":"repo-1","namespace":{"full_path":"frogger"},"visibility":"public","archived":true}]`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	result, err := client.ListRepositoriesWithOptions(ctx, username, ListRepositoriesOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryDetails{{
+		Owner:          username,
+		Repository:     repo1,
+		RepositoryInfo: RepositoryInfo{RepositoryVisibility: Public, Archived: true},
+	}}, result)
+}
+
 func TestGitLabClient_ListBranches(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []gitlab.Branch{{Name: branch1}, {Name: branch2}}, fmt.Sprintf("/api/v4/projects/%s/repository/branches", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
@@ -83,6 +183,344 @@ func TestGitLabClient_ListBranches(t *testing.T) {
 	assert.ElementsMatch(t, actualRepositories, []string{branch1, branch2})
 }
 
+func TestGitLabClient_ListBranchesPager(t *testing.T) {
+	ctx := context.Background()
+	branchesURI := fmt.Sprintf("/api/v4/projects/%s/repository/branches", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var branches []gitlab.Branch
+		switch r.RequestURI {
+		case "/api/v4/":
+		case branchesURI + "?page=1&per_page=1":
+			w.Header().Add("X-Next-Page", "2")
+			branches = []gitlab.Branch{{Name: branch1}}
+		case branchesURI + "?page=2&per_page=1":
+			branches = []gitlab.Branch{{Name: branch2}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(branches)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	pager := buildClient(t, vcsutils.GitLab, false, server).ListBranchesPager(owner, repo1, 1)
+
+	branches, err := pager.All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{branch1, branch2}, branches)
+	assert.False(t, pager.HasNext())
+}
+
+func TestGitLabClient_ListAllBranches(t *testing.T) {
+	ctx := context.Background()
+	response := []gitlab.Branch{{Name: branch1, Commit: &gitlab.Commit{ID: "sha1"}}, {Name: branch2, Commit: &gitlab.Commit{ID: "sha2"}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/branches?page=1&per_page=100&search=%%5Ebranch-", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	actualBranches, err := client.ListAllBranches(ctx, owner, repo1, "branch-")
+	assert.NoError(t, err)
+	assert.Equal(t, []BranchDetails{{Name: branch1, CommitHash: "sha1"}, {Name: branch2, CommitHash: "sha2"}}, actualBranches)
+}
+
+func TestGitLabClient_CreateBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Branch{Name: branch1},
+		fmt.Sprintf("/api/v4/projects/%s/repository/branches", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		[]byte(`{"branch":"branch-1","ref":"master"}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateBranch(ctx, owner, repo1, branch1, "master")
+	assert.NoError(t, err)
+
+	err = client.CreateBranch(ctx, owner, repo1, "", "master")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_DeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, []byte{},
+		fmt.Sprintf("/api/v4/projects/%s/repository/branches/%s", url.PathEscape(owner+"/"+repo1), branch1), http.StatusNoContent,
+		[]byte{}, http.MethodDelete, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_ListTags(t *testing.T) {
+	ctx := context.Background()
+	response := []gitlab.Tag{{Name: "v1.0.0", Message: "Release 1.0.0", Commit: &gitlab.Commit{ID: "sha1"}}, {Name: "v1.1.0", Commit: &gitlab.Commit{ID: "sha2"}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/tags?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitHash: "sha1", Message: "Release 1.0.0"}, {Name: "v1.1.0", CommitHash: "sha2"}}, tags)
+}
+
+func TestGitLabClient_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Tag{Name: "v1.0.0"},
+		fmt.Sprintf("/api/v4/projects/%s/repository/tags", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		[]byte(`{"tag_name":"v1.0.0","ref":"sha1","message":"Release 1.0.0"}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateTag(ctx, owner, repo1, "v1.0.0", "sha1", "Release 1.0.0")
+	assert.NoError(t, err)
+
+	err = client.CreateTag(ctx, owner, repo1, "v1.0.0", "", "Release 1.0.0")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_CreateRelease(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Release{TagName: "v1.0.0"},
+		fmt.Sprintf("/api/v4/projects/%s/releases", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		[]byte(`{"name":"Release 1.0.0","tag_name":"v1.0.0","description":"Bug fixes","ref":"master"}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateRelease(ctx, owner, repo1, ReleaseInfo{TagName: "v1.0.0", Target: "master", Name: "Release 1.0.0", Description: "Bug fixes"})
+	assert.NoError(t, err)
+
+	err = client.CreateRelease(ctx, owner, repo1, ReleaseInfo{Name: "Release 1.0.0"})
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_GetLatestRelease(t *testing.T) {
+	ctx := context.Background()
+	response := []gitlab.Release{{TagName: "v1.0.0", Name: "Release 1.0.0", Description: "Bug fixes", Commit: gitlab.Commit{ID: "sha1"}}}
+	releasesURI := fmt.Sprintf("/api/v4/projects/%s/releases?page=1&per_page=1", url.PathEscape(owner+"/"+repo1))
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, releasesURI, createGitLabHandler)
+	defer cleanUp()
+
+	release, err := client.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, &ReleaseInfo{TagName: "v1.0.0", Target: "sha1", Name: "Release 1.0.0", Description: "Bug fixes"}, release)
+
+	noReleasesClient, noReleasesCleanUp := createServerAndClient(t, vcsutils.GitLab, false, []gitlab.Release{}, releasesURI, createGitLabHandler)
+	defer noReleasesCleanUp()
+	release, err = noReleasesClient.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Nil(t, release)
+}
+
+func TestGitLabClient_UploadReleaseAsset(t *testing.T) {
+	ctx := context.Background()
+	assetPath := filepath.Join(t.TempDir(), "asset.zip")
+	require.NoError(t, os.WriteFile(assetPath, []byte("asset content"), 0600))
+	projectURI := fmt.Sprintf("/api/v4/projects/%s", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{}
+		switch r.RequestURI {
+		case "/api/v4/":
+		case projectURI + "/uploads":
+			assert.Equal(t, http.MethodPost, r.Method)
+			response = gitlab.ProjectFile{URL: "/uploads/secret/asset.zip"}
+		case projectURI:
+			response = gitlab.Project{WebURL: "https://gitlab.example.com/jfrog/repo-1"}
+		case projectURI + "/releases/v1%2E0%2E0/assets/links":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"name":"asset.zip","url":"https://gitlab.example.com/jfrog/repo-1/uploads/secret/asset.zip"}`, string(body))
+			response = gitlab.ReleaseLink{Name: "asset.zip"}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		responseBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBody)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	err := buildClient(t, vcsutils.GitLab, false, server).UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", assetPath)
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	protectedBranchURI := fmt.Sprintf("/api/v4/projects/%s/protected_branches/master", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{}
+		switch r.RequestURI {
+		case "/api/v4/":
+		case protectedBranchURI:
+			response = gitlab.ProtectedBranch{Name: "master", PushAccessLevels: []*gitlab.BranchAccessDescription{
+				{AccessLevel: gitlab.MaintainerPermissions},
+				{UserID: 5},
+			}}
+		case "/api/v4/users/5":
+			response = gitlab.User{ID: 5, Username: "frogger"}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		responseBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBody)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	protection, err := buildClient(t, vcsutils.GitLab, false, server).GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, &BranchProtection{RestrictPushes: true, AllowedPushUsers: []string{"frogger"}}, protection)
+
+	unrestrictedClient, unrestrictedCleanUp := createServerAndClient(t, vcsutils.GitLab, false,
+		gitlab.ProtectedBranch{PushAccessLevels: []*gitlab.BranchAccessDescription{{AccessLevel: gitlab.DeveloperPermissions}}},
+		protectedBranchURI, createGitLabHandler)
+	defer unrestrictedCleanUp()
+	protection, err = unrestrictedClient.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, &BranchProtection{}, protection)
+
+	notProtectedClient, notProtectedCleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, nil,
+		protectedBranchURI, http.StatusNotFound, createGitLabHandler)
+	defer notProtectedCleanUp()
+	protection, err = notProtectedClient.GetBranchProtection(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Nil(t, protection)
+}
+
+func TestGitLabClient_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	protectedBranchesURI := fmt.Sprintf("/api/v4/projects/%s/protected_branches", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{}
+		switch r.RequestURI {
+		case "/api/v4/":
+		case protectedBranchesURI + "/master":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v4/users?username=frogger":
+			response = []gitlab.User{{ID: 5, Username: "frogger"}}
+		case protectedBranchesURI:
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"name":"master","allowed_to_push":[{"user_id":5}]}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			response = gitlab.ProtectedBranch{Name: "master"}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		responseBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBody)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	err := client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{RestrictPushes: true, AllowedPushUsers: []string{"frogger"}})
+	assert.NoError(t, err)
+
+	err = client.SetBranchProtection(ctx, owner, repo1, "master", BranchProtection{RequiredApprovingReviewCount: 1})
+	assert.ErrorIs(t, err, errGitLabBranchProtectionChecksNotSupported)
+}
+
+func TestGitLabClient_TagProtection(t *testing.T) {
+	ctx := context.Background()
+	protectedTagsURI := fmt.Sprintf("/api/v4/projects/%s/protected_tags", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{}
+		// The GitLab client escapes the wildcard of the pattern
+		switch strings.ReplaceAll(r.RequestURI, "%2A", "*") {
+		case "/api/v4/":
+		case protectedTagsURI + "/v*":
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			response = gitLabProtectedTag{Name: "v*", CreateAccessLevels: []gitLabProtectedTagAccessLevel{{}, {UserID: 5}}}
+		case protectedTagsURI + "/release-*":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v4/users/5":
+			response = gitlab.User{ID: 5, Username: "release-bot"}
+		case "/api/v4/users?username=release-bot":
+			response = []gitlab.User{{ID: 5, Username: "release-bot"}}
+		case protectedTagsURI:
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"name":"v*","create_access_level":0,"allowed_to_create":[{"user_id":5}]}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			response = gitLabProtectedTag{Name: "v*"}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		responseBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBody)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	protection, err := client.GetTagProtection(ctx, owner, repo1, "v*")
+	assert.NoError(t, err)
+	assert.Equal(t, &TagProtection{AllowedUsers: []string{"release-bot"}}, protection)
+	protection, err = client.GetTagProtection(ctx, owner, repo1, "release-*")
+	assert.NoError(t, err)
+	assert.Nil(t, protection)
+
+	err = client.SetTagProtection(ctx, owner, repo1, "v*", TagProtection{AllowedUsers: []string{"release-bot"}})
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_GetRequiredChecks(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, gitlab.Project{OnlyAllowMergeIfPipelineSucceeds: true},
+		fmt.Sprintf("/api/v4/projects/%s", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	requiredChecks, err := client.GetRequiredChecks(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredChecksInfo{AllMustPass: true}, requiredChecks)
+}
+
+func TestGitLabClient_GetRequiredApprovals(t *testing.T) {
+	ctx := context.Background()
+	projectURI := fmt.Sprintf("/api/v4/projects/%s", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{}
+		switch r.RequestURI {
+		case "/api/v4/":
+		case projectURI + "/approval_rules":
+			response = []*gitlab.ProjectApprovalRule{
+				{Name: "Maintainers", RuleType: "regular", ApprovalsRequired: 1, EligibleApprovers: []*gitlab.BasicUser{{Username: "frogger"}}},
+				{Name: "Releases", RuleType: "regular", ApprovalsRequired: 2, Users: []*gitlab.BasicUser{{Username: "releaser"}},
+					ProtectedBranches: []*gitlab.ProtectedBranch{{Name: "master"}}},
+				{Name: "Develop", RuleType: "regular", ApprovalsRequired: 3, Users: []*gitlab.BasicUser{{Username: "developer"}},
+					ProtectedBranches: []*gitlab.ProtectedBranch{{Name: "develop"}}},
+			}
+		case projectURI + "/approvals":
+			response = gitlab.ProjectApprovals{ResetApprovalsOnPush: true}
+		case projectURI + "/protected_branches/master":
+			response = gitlab.ProtectedBranch{Name: "master", CodeOwnerApprovalRequired: true}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		responseBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBody)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	requiredApprovals, err := client.GetRequiredApprovals(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RequiredApprovalsInfo{Count: 2, Approvers: []string{"frogger", "releaser"}, RequireCodeOwners: true, DismissStaleApprovals: true},
+		requiredApprovals)
+
+	_, err = client.GetRequiredApprovals(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
 func TestGitLabClient_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int()
@@ -96,6 +534,25 @@ func TestGitLabClient_CreateWebhook(t *testing.T) {
 	assert.Equal(t, actualID, strconv.Itoa(id))
 }
 
+func TestGitLabClient_CreateWebhookWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id":1,"url":"https://jfrog.com","push_events":true,"enable_ssl_verification":false}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/hooks", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	options := WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, InsecureSSL: true}
+	webhook, token, err := client.CreateWebhookWithOptions(ctx, owner, repo1, options)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON, InsecureSSL: true}, webhook)
+
+	options.ContentType = WebhookContentTypeForm
+	_, _, err = client.CreateWebhookWithOptions(ctx, owner, repo1, options)
+	assert.ErrorIs(t, err, errGitLabWebhookFormContentTypeNotSupported)
+}
+
 func TestGitLabClient_UpdateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int()
@@ -117,6 +574,70 @@ func TestGitLabClient_DeleteWebhook(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGitLabClient_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":1,"url":"https://jfrog.com/hook","push_events":true,"merge_requests_events":true,"enable_ssl_verification":true},` +
+		`{"id":2,"url":"https://jfrog.com/push","push_events":true}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/hooks?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{
+		{ID: "1", PayloadURL: "https://jfrog.com/hook", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.Push}, Active: true, ContentType: WebhookContentTypeJSON},
+		{ID: "2", PayloadURL: "https://jfrog.com/push", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true, ContentType: WebhookContentTypeJSON, InsecureSSL: true},
+	}, webhooks)
+}
+
+func TestGitLabClient_SetWebhookActive(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.GitLab).Build()
+	require.NoError(t, err)
+
+	assert.NoError(t, client.SetWebhookActive(ctx, owner, repo1, "1", true))
+	assert.ErrorIs(t, client.SetWebhookActive(ctx, owner, repo1, "1", false), errGitLabWebhookDeactivationNotSupported)
+}
+
+func TestGitLabClient_CreateOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id":1,"url":"https://jfrog.com","merge_requests_events":true,"enable_ssl_verification":true}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, "/api/v4/groups/jfrog/hooks", createGitLabHandler)
+	defer cleanUp()
+
+	options := WebhookOptions{PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened}}
+	webhook, token, err := client.CreateOrgWebhook(ctx, owner, options)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, WebhookInfo{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected},
+		Active: true, ContentType: WebhookContentTypeJSON}, webhook)
+
+	options.Branch = branch1
+	_, _, err = client.CreateOrgWebhook(ctx, owner, options)
+	assert.ErrorIs(t, err, errGitLabGroupWebhookBranchNotSupported)
+}
+
+func TestGitLabClient_ListOrgWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":1,"url":"https://jfrog.com","push_events":true,"enable_ssl_verification":true}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, "/api/v4/groups/jfrog/hooks?page=1&per_page=100", createGitLabHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListOrgWebhooks(ctx, owner)
+	require.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{{ID: "1", PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.Push}, Active: true,
+		ContentType: WebhookContentTypeJSON}}, webhooks)
+}
+
+func TestGitLabClient_DeleteOrgWebhook(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, "/api/v4/groups/jfrog/hooks/1", createGitLabHandler)
+	defer cleanUp()
+
+	err := client.DeleteOrgWebhook(ctx, owner, "1")
+	assert.NoError(t, err)
+}
+
 func TestGitLabClient_CreateCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
@@ -128,6 +649,104 @@ func TestGitLabClient_CreateCommitStatus(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGitLabClient_CheckRuns(t *testing.T) {
+	ctx := context.Background()
+	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
+	var statuses []gitlab.SetCommitStatusOptions
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/api/v4/" {
+			return
+		}
+		assert.Equal(t, fmt.Sprintf("/api/v4/projects/%s/statuses/%s", url.PathEscape(owner+"/"+repo1), ref), r.RequestURI)
+		var status gitlab.SetCommitStatusOptions
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+		statuses = append(statuses, status)
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	// GitLab doesn't support check runs, so commit statuses are set instead
+	checkRunID, err := client.CreateCheckRun(ctx, owner, repo1, ref, CheckRunInfo{Name: "xray", State: InProgress, Summary: "Scanning"})
+	require.NoError(t, err)
+	assert.Zero(t, checkRunID)
+	err = client.UpdateCheckRun(ctx, owner, repo1, ref, checkRunID, CheckRunInfo{Name: "xray", State: Pass, Title: "No vulnerabilities found", Summary: "## Results",
+		Annotations: []CheckRunAnnotation{{Path: "go.mod", StartLine: 1, Message: "ignored"}}})
+	require.NoError(t, err)
+
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "xray", *statuses[0].Name)
+	assert.Equal(t, gitlab.Running, statuses[0].State)
+	assert.Equal(t, "Scanning", *statuses[0].Description)
+	assert.Equal(t, gitlab.Success, statuses[1].State)
+	assert.Equal(t, "No vulnerabilities found", *statuses[1].Description)
+}
+
+func TestGitLabClient_Pipelines(t *testing.T) {
+	ctx := context.Background()
+	var options gitlab.CreatePipelineOptions
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/v4/":
+			return
+		case fmt.Sprintf("/api/v4/projects/%s/pipeline", url.PathEscape(owner+"/"+repo1)):
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			response = `{"id":42,"ref":"branch-1","status":"created","web_url":"https://gitlab.com/jfrog/repo-1/-/pipelines/42"}`
+		case fmt.Sprintf("/api/v4/projects/%s/pipelines/42", url.PathEscape(owner+"/"+repo1)):
+			response = `{"id":42,"ref":"branch-1","status":"success","web_url":"https://gitlab.com/jfrog/repo-1/-/pipelines/42"}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	run, err := client.TriggerPipeline(ctx, owner, repo1, "", branch1, map[string]string{"TARGET": "staging", "DEBUG": "true"})
+	require.NoError(t, err)
+	assert.Equal(t, PipelineInfo{ID: "42", Ref: branch1, State: InProgress, WebURL: "https://gitlab.com/jfrog/repo-1/-/pipelines/42"}, run)
+	assert.Equal(t, branch1, *options.Ref)
+	require.Len(t, options.Variables, 2)
+	assert.Equal(t, gitlab.PipelineVariable{Key: "DEBUG", Value: "true", VariableType: "env_var"}, *options.Variables[0])
+
+	run, err = client.GetPipelineStatus(ctx, owner, repo1, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, Pass, run.State)
+
+	_, err = client.GetPipelineStatus(ctx, owner, repo1, "not-a-number")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_ListCommitStatuses(t *testing.T) {
+	ctx := context.Background()
+	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
+	response := []byte(`[
+		{"status":"success","name":"build","description":"Build passed","target_url":"https://ci.example.com/build","created_at":"2023-01-01T10:00:00Z","started_at":"2023-01-01T10:01:00Z","finished_at":"2023-01-01T10:05:00Z"},
+		{"status":"running","name":"test","created_at":"2023-01-01T10:00:00Z","started_at":"2023-01-01T10:02:00Z"},
+		{"status":"canceled","name":"deploy","created_at":"2023-01-01T10:00:00Z"}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/commits/%s/statuses?page=1&per_page=100", url.PathEscape(owner+"/"+repo1), ref), createGitLabHandler)
+	defer cleanUp()
+
+	statuses, err := client.ListCommitStatuses(ctx, owner, repo1, ref)
+	require.NoError(t, err)
+	assert.Equal(t, []CommitStatusInfo{
+		{State: Pass, Title: "build", Description: "Build passed", DetailsURL: "https://ci.example.com/build",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 5, 0, 0, time.UTC)},
+		{State: InProgress, Title: "test",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 2, 0, 0, time.UTC)},
+		{State: Error, Title: "deploy",
+			CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), LastUpdatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)},
+	}, statuses)
+
+	_, err = client.ListCommitStatuses(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
 func TestGitLabClient_DownloadRepository(t *testing.T) {
 	ctx := context.Background()
 	dir, err := os.MkdirTemp("", "")
@@ -149,47 +768,372 @@ func TestGitLabClient_DownloadRepository(t *testing.T) {
 	assert.Equal(t, "README.md", fileinfo[0].Name())
 }
 
-func TestGitLabClient_DownloadFileFromRepo(t *testing.T) {
+func TestGitLabClient_DownloadRepositoryAtRef(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, gitlab.File{Content: "SGVsbG8gV29ybGQh"}, fmt.Sprintf("/api/v4/projects/%s/repository/files/hello-world?ref=branch-1", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	dir := t.TempDir()
+	ref := "v1.0.0"
+	archive := createZipArchive(t, "hello-world-v1.0.0", map[string]string{"README.md": "Hello World!"})
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, archive,
+		fmt.Sprintf("/api/v4/projects/%s/repository/archive.zip?sha=%s", url.PathEscape(owner+"/"+repo1), ref), createGitLabHandler)
 	defer cleanUp()
 
-	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-world")
-	assert.NoError(t, err)
-	assert.Equal(t, statusCode, http.StatusOK)
-	expected := "Hello World!"
-	assert.Equal(t, expected, string(content))
+	err := client.DownloadRepositoryAtRef(ctx, owner, repo1, ref, dir, Zip)
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello World!", string(content))
 }
 
-func TestGitLabClient_CreatePullRequest(t *testing.T) {
+func TestGitLabClient_GetRepositoryArchive(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{}, fmt.Sprintf("/api/v4/projects/%s/merge_requests", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	archiveURI := fmt.Sprintf("/api/v4/projects/%s/repository/archive.tar.gz?sha=%s", url.PathEscape(owner+"/"+repo1), branch1)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []byte("archive content"), archiveURI, createGitLabHandler)
 	defer cleanUp()
 
-	err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	archive, err := client.GetRepositoryArchive(ctx, owner, repo1, branch1, TarGz)
+	require.NoError(t, err)
+	content, err := io.ReadAll(archive)
 	assert.NoError(t, err)
+	assert.NoError(t, archive.Close())
+	assert.Equal(t, "archive content", string(content))
+
+	// Request errors are returned when reading the archive
+	notFoundClient, notFoundCleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, nil, archiveURI,
+		http.StatusNotFound, createGitLabHandler)
+	defer notFoundCleanUp()
+	archive, err = notFoundClient.GetRepositoryArchive(ctx, owner, repo1, branch1, TarGz)
+	require.NoError(t, err)
+	_, err = io.ReadAll(archive)
+	assert.Error(t, err)
+	assert.NoError(t, archive.Close())
 }
 
-func TestGitLabClient_AddPullRequestComment(t *testing.T) {
+func TestGitLabClient_DownloadRepositoryPath(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{}, fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	dir := t.TempDir()
+	archive := createTarGzArchive(t, "repo-1-master-services-a", map[string]string{"services/a/main.go": "package main"})
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, archive,
+		fmt.Sprintf("/api/v4/projects/%s/repository/archive.tar.gz?path=services%%2Fa&sha=master", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
 	defer cleanUp()
 
-	err := client.AddPullRequestComment(ctx, owner, repo1, "Comment content", 1)
-	assert.NoError(t, err)
+	err := client.DownloadRepositoryPath(ctx, owner, repo1, "master", "services/a", dir)
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "services", "a", "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main", string(content))
 }
 
-func TestGitLabClient_ListPullRequestComments(t *testing.T) {
+func TestGitLabClient_DownloadFileFromRepo(t *testing.T) {
 	ctx := context.Background()
-	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pull_request_comments_list_response.json"))
-	assert.NoError(t, err)
-
-	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
-		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, gitlab.File{Content: "SGVsbG8gV29ybGQh"}, fmt.Sprintf("/api/v4/projects/%s/repository/files/hello-world?ref=branch-1", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
 	defer cleanUp()
 
-	result, err := client.ListPullRequestComments(ctx, owner, repo1, 1)
-	require.NoError(t, err)
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-world")
+	assert.NoError(t, err)
+	assert.Equal(t, statusCode, http.StatusOK)
+	expected := "Hello World!"
+	assert.Equal(t, expected, string(content))
+}
+
+func TestGitLabClient_GetFileContent(t *testing.T) {
+	ctx := context.Background()
+	response := gitlab.File{FilePath: "go.mod", Content: "SGVsbG8gV29ybGQh", Size: 12, BlobID: "sha1"}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/files/go%%2Emod?ref=branch-1", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	fileContent, err := client.GetFileContent(ctx, owner, repo1, branch1, "go.mod")
+	assert.NoError(t, err)
+	assert.Equal(t, FileContent{Path: "go.mod", Content: []byte("Hello World!"), Size: 12, Sha: "sha1"}, fileContent)
+
+	_, err = client.GetFileContent(ctx, owner, repo1, "", "go.mod")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_GetFileBlame(t *testing.T) {
+	ctx := context.Background()
+	authored := time.Date(2023, time.March, 18, 14, 56, 28, 0, time.UTC)
+	response := []*gitlab.FileBlameRange{{Lines: []string{"module example", ""}}, {Lines: []string{"go 1.19"}}}
+	response[0].Commit.ID = "sha1"
+	response[0].Commit.AuthorName = "Frogger"
+	response[0].Commit.AuthorEmail = "frogger@jfrog.com"
+	response[0].Commit.AuthoredDate = &authored
+	response[1].Commit.ID = "sha2"
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/files/go%%2Emod/blame?ref=branch-1", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	blame, err := client.GetFileBlame(ctx, owner, repo1, branch1, "go.mod")
+	assert.NoError(t, err)
+	assert.Equal(t, []BlameLine{
+		{Line: 1, CommitHash: "sha1", AuthorName: "Frogger", AuthorEmail: "frogger@jfrog.com", Timestamp: authored.Unix()},
+		{Line: 2, CommitHash: "sha1", AuthorName: "Frogger", AuthorEmail: "frogger@jfrog.com", Timestamp: authored.Unix()},
+		{Line: 3, CommitHash: "sha2"},
+	}, blame)
+
+	_, err = client.GetFileBlame(ctx, owner, repo1, "", "go.mod")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_ListRepositoryTree(t *testing.T) {
+	ctx := context.Background()
+	response := []*gitlab.TreeNode{{Path: "go.mod", Type: "blob"}, {Path: "src", Type: "tree"}, {Path: "vendor/lib", Type: "commit"}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/tree?page=1&per_page=100&recursive=false&ref=branch-1", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	tree, err := client.ListRepositoryTree(ctx, owner, repo1, branch1, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []TreeEntry{{Path: "go.mod", Type: TreeEntryFile}, {Path: "src", Type: TreeEntryDirectory}, {Path: "vendor/lib", Type: TreeEntrySubmodule}}, tree)
+
+	_, err = client.ListRepositoryTree(ctx, owner, repo1, "", false)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	projectRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+			return
+		case "/api/v4/groups/jfrog/-/search?page=1&per_page=10&scope=blobs&search=exec.Command":
+			_, err := w.Write([]byte(`[
+				{"filename":"main.go","data":"cmd := exec.Command(name)","project_id":7},
+				{"filename":"utils/run.go","data":"exec.Command(\"ls\")","project_id":7}
+			]`))
+			assert.NoError(t, err)
+		case "/api/v4/projects/7":
+			projectRequests++
+			_, err := w.Write([]byte(`{"id":7,"path":"repo-1","namespace":{"full_path":"jfrog"}}`))
+			assert.NoError(t, err)
+		case "/api/v4/projects/jfrog%2Frepo-1/-/search?page=2&scope=blobs&search=exec.Command":
+			_, err := w.Write([]byte(`[{"filename":"main.go","data":"cmd := exec.Command(name)","project_id":7}]`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	results, err := client.SearchCode(ctx, CodeSearchScope{Owner: owner, PerPage: 10}, "exec.Command")
+	require.NoError(t, err)
+	assert.Equal(t, []CodeSearchResult{
+		{Owner: owner, Repository: repo1, Path: "main.go", Fragments: []string{"cmd := exec.Command(name)"}},
+		{Owner: owner, Repository: repo1, Path: "utils/run.go", Fragments: []string{`exec.Command("ls")`}},
+	}, results)
+	// The project of both results is fetched once
+	assert.Equal(t, 1, projectRequests)
+
+	results, err = client.SearchCode(ctx, CodeSearchScope{Owner: owner, Repository: repo1, Page: 2}, "exec.Command")
+	require.NoError(t, err)
+	assert.Equal(t, []CodeSearchResult{{Owner: owner, Repository: repo1, Path: "main.go", Fragments: []string{"cmd := exec.Command(name)"}}}, results)
+}
+
+func TestGitLabClient_CreateOrUpdateFile(t *testing.T) {
+	ctx := context.Background()
+	filesURI := fmt.Sprintf("/api/v4/projects/%s/repository/files/", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v4/":
+		case "HEAD " + filesURI + "go%2Emod?ref=branch-1":
+			w.Header().Set("X-Gitlab-Blob-Id", "sha1")
+		case "HEAD " + filesURI + "new%2Etxt?ref=branch-1":
+			w.WriteHeader(http.StatusNotFound)
+		case "PUT " + filesURI + "go%2Emod", "POST " + filesURI + "new%2Etxt":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(b), `"content":"SGVsbG8gV29ybGQh"`)
+			assert.Contains(t, string(b), `"encoding":"base64"`)
+			_, err = w.Write([]byte(`{}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	err := client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "go.mod", []byte("Hello World!"), "Update go.mod")
+	assert.NoError(t, err)
+
+	err = client.CreateOrUpdateFile(ctx, owner, repo1, branch1, "new.txt", []byte("Hello World!"), "Add new.txt")
+	assert.NoError(t, err)
+
+	err = client.CreateOrUpdateFile(ctx, owner, repo1, "", "new.txt", []byte("Hello World!"), "Add new.txt")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	changes := []FileChange{
+		{Type: AddFile, Path: "go.sum", Content: []byte("Hello World!")},
+		{Type: UpdateFile, Path: "go.mod", Content: []byte("Hello World!")},
+		{Type: DeleteFile, Path: "old.txt"},
+	}
+	expectedBody := []byte(`{"branch":"branch-1","commit_message":"Update dependencies","actions":[` +
+		`{"action":"create","file_path":"go.sum","content":"SGVsbG8gV29ybGQh","encoding":"base64"},` +
+		`{"action":"update","file_path":"go.mod","content":"SGVsbG8gV29ybGQh","encoding":"base64"},` +
+		`{"action":"delete","file_path":"old.txt"}]}`)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Commit{},
+		fmt.Sprintf("/api/v4/projects/%s/repository/commits", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		expectedBody, http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", changes)
+	assert.NoError(t, err)
+
+	err = client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", []FileChange{{Type: DeleteFile}})
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_CreatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{}, fmt.Sprintf("/api/v4/projects/%s/merge_requests", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_CreateDraftPullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"Draft: PR title","description":"PR body","source_branch":"branch-1","target_branch":"branch-2"}`)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{},
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		expectedBody, http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateDraftPullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_MarkPullRequestReady(t *testing.T) {
+	ctx := context.Background()
+	mergeRequestsURI := fmt.Sprintf("/api/v4/projects/%s/merge_requests/", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v4/":
+		case "GET " + mergeRequestsURI + "1":
+			response = []byte(`{"iid":1,"title":"[Draft] PR title","work_in_progress":true}`)
+		case "GET " + mergeRequestsURI + "2":
+			response = []byte(`{"iid":2,"title":"PR title","work_in_progress":false}`)
+		case "PUT " + mergeRequestsURI + "1":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"title":"PR title"}`, string(b))
+			response = []byte(`{"iid":1,"title":"PR title"}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	err := client.MarkPullRequestReady(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+
+	// Not a draft, no update is expected
+	err = client.MarkPullRequestReady(ctx, owner, repo1, 2)
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"squash_commit_message":"Merge commit message","squash":true}`)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{},
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/merge", url.PathEscape(owner+"/"+repo1)), http.StatusOK,
+		expectedBody, http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.MergePullRequest(ctx, owner, repo1, 1, SquashMerge, "Merge commit message")
+	assert.NoError(t, err)
+
+	err = client.MergePullRequest(ctx, owner, repo1, 1, RebaseMerge, "Merge commit message")
+	assert.ErrorIs(t, err, errGitLabRebaseMergeNotSupported)
+}
+
+func TestGitLabClient_EnablePullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"squash":true,"merge_when_pipeline_succeeds":true}`)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{},
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/merge", url.PathEscape(owner+"/"+repo1)), http.StatusOK,
+		expectedBody, http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, SquashMerge)
+	assert.NoError(t, err)
+
+	err = client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, RebaseMerge)
+	assert.ErrorIs(t, err, errGitLabRebaseMergeNotSupported)
+}
+
+func TestGitLabClient_DisablePullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{},
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/cancel_merge_when_pipeline_succeeds", url.PathEscape(owner+"/"+repo1)),
+		http.StatusOK, []byte{}, http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DisablePullRequestAutoMerge(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_UpdatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"New title","description":"New body","target_branch":"dev","state_event":"close"}`)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{},
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1", url.PathEscape(owner+"/"+repo1)), http.StatusOK,
+		expectedBody, http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	closed := PullRequestClosed
+	err := client.UpdatePullRequest(ctx, owner, repo1, "New title", "New body", "dev", 1, &closed)
+	assert.NoError(t, err)
+
+	merged := PullRequestMerged
+	err = client.UpdatePullRequest(ctx, owner, repo1, "", "", "", 1, &merged)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_ApprovePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequestApprovals{},
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/approve", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	err := client.ApprovePullRequest(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+
+	err = client.SubmitPullRequestReview(ctx, owner, repo1, 1, ReviewRequestChanges, "Please fix")
+	assert.ErrorIs(t, err, errGitLabRequestChangesNotSupported)
+}
+
+func TestGitLabClient_AddPullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{}, fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	err := client.AddPullRequestComment(ctx, owner, repo1, "Comment content", 1)
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_ListPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestComments(ctx, owner, repo1, 1)
+	require.NoError(t, err)
 	expectedCreated, err := time.Parse(time.RFC3339, "2013-10-02T09:56:03Z")
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
@@ -200,6 +1144,172 @@ func TestGitLabClient_ListPullRequestComments(t *testing.T) {
 	}, result[1])
 }
 
+func TestGitLabClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, &gitlab.Note{},
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes/305", url.PathEscape(owner+"/"+repo1)), http.StatusOK,
+		[]byte(`{"body":"New content"}`), http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, "New content", 1, 305)
+	assert.NoError(t, err)
+
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "", 1, 305)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_DeletePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, []byte{},
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes/305", url.PathEscape(owner+"/"+repo1)), http.StatusNoContent,
+		createGitLabHandler)
+	defer cleanUp()
+
+	err := client.DeletePullRequestComment(ctx, owner, repo1, 1, 305)
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_CommentReactions(t *testing.T) {
+	ctx := context.Background()
+	awardEmojiURI := fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes/305/award_emoji", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v4/":
+		case "POST " + awardEmojiURI:
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"name":"thumbsup"}`, string(b))
+			w.WriteHeader(http.StatusCreated)
+			response = []byte(`{"id":1,"name":"thumbsup"}`)
+		case "GET " + awardEmojiURI + "?page=1&per_page=100":
+			response = []byte(`[{"id":1,"name":"thumbsup","user":{"username":"frogger"}},{"id":2,"name":"smile","user":{"username":"octocat"}}]`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	err := client.AddCommentReaction(ctx, owner, repo1, 1, 305, ReactionThumbsUp)
+	assert.NoError(t, err)
+
+	reactions, err := client.ListCommentReactions(ctx, owner, repo1, 1, 305)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReactionInfo{{Reaction: ReactionThumbsUp, User: "frogger"}, {Reaction: "smile", User: "octocat"}}, reactions)
+}
+
+func TestGitLabClient_AddPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	mergeRequestURI := fmt.Sprintf("/api/v4/projects/%s/merge_requests/1", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v4/":
+		case "GET " + mergeRequestURI:
+			response = []byte(`{"iid":1,"diff_refs":{"base_sha":"base-sha","head_sha":"head-sha","start_sha":"start-sha"}}`)
+		case "POST " + mergeRequestURI + "/discussions":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"body":"Use a constant","position":{"base_sha":"base-sha","start_sha":"start-sha","head_sha":"head-sha",`+
+				`"position_type":"text","new_path":"main.go","old_path":"main.go","new_line":5,"line_range":null}}`, string(b))
+			w.WriteHeader(http.StatusCreated)
+			response = []byte(`{"id":"discussion-id"}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	comment := PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go", Line: 5, StartLine: 3}
+	err := client.AddPullRequestReviewComment(ctx, owner, repo1, 1, comment)
+	assert.NoError(t, err)
+
+	err = client.AddPullRequestReviewComment(ctx, owner, repo1, 1, PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Line: 5})
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_ListPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":"1","notes":[{"id":301,"body":"General comment","created_at":"2013-10-02T09:56:03Z"}]},` +
+		`{"id":"2","notes":[{"id":302,"body":"Use a constant","created_at":"2013-10-02T09:56:03Z","position":{"new_path":"main.go","old_path":"main.go","new_line":5,` +
+		`"line_range":{"start":{"new_line":3},"end":{"new_line":5}}}}]},` +
+		`{"id":"3","notes":[{"id":303,"body":"Removed","created_at":"2013-10-02T09:56:03Z","position":{"new_path":"go.mod","old_path":"go.mod","old_line":7}}]}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/discussions?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	expectedCreated, err := time.Parse(time.RFC3339, "2013-10-02T09:56:03Z")
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewComment{
+		{CommentInfo: CommentInfo{ID: 302, Content: "Use a constant", Created: expectedCreated}, Path: "main.go", Line: 5, StartLine: 3},
+		{CommentInfo: CommentInfo{ID: 303, Content: "Removed", Created: expectedCreated}, Path: "go.mod", Line: 7},
+	}, result)
+}
+
+func TestGitLabClient_PullRequestThreads(t *testing.T) {
+	ctx := context.Background()
+	mergeRequestURI := fmt.Sprintf("/api/v4/projects/%s/merge_requests/1", url.PathEscape(owner+"/"+repo1))
+	var resolvedRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v4/":
+		case "GET " + mergeRequestURI:
+			response = []byte(`{"iid":1,"diff_refs":{"base_sha":"base-sha","head_sha":"head-sha","start_sha":"start-sha"}}`)
+		case "POST " + mergeRequestURI + "/discussions":
+			var request gitlab.CreateMergeRequestDiscussionOptions
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			if request.Position == nil {
+				assert.Equal(t, "Please update the changelog", *request.Body)
+				response = []byte(`{"id":"general-discussion"}`)
+			} else {
+				assert.Equal(t, "main.go", request.Position.NewPath)
+				assert.Equal(t, 5, request.Position.NewLine)
+				assert.Equal(t, "head-sha", request.Position.HeadSHA)
+				response = []byte(`{"id":"diff-discussion"}`)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case "PUT " + mergeRequestURI + "/discussions/diff-discussion":
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			resolvedRequests = append(resolvedRequests, string(b))
+			response = []byte(`{"id":"diff-discussion"}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	threadID, err := client.CreatePullRequestThread(ctx, owner, repo1, 1,
+		PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Use a constant"}, Path: "main.go", Line: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "diff-discussion", threadID)
+
+	threadID, err = client.CreatePullRequestThread(ctx, owner, repo1, 1, PullRequestReviewComment{CommentInfo: CommentInfo{Content: "Please update the changelog"}})
+	require.NoError(t, err)
+	assert.Equal(t, "general-discussion", threadID)
+
+	_, err = client.CreatePullRequestThread(ctx, owner, repo1, 1, PullRequestReviewComment{})
+	assert.Error(t, err)
+
+	require.NoError(t, client.ResolvePullRequestThread(ctx, owner, repo1, 1, "diff-discussion", true))
+	require.NoError(t, client.ResolvePullRequestThread(ctx, owner, repo1, 1, "diff-discussion", false))
+	require.Len(t, resolvedRequests, 2)
+	assert.JSONEq(t, `{"resolved":true}`, resolvedRequests[0])
+	assert.JSONEq(t, `{"resolved":false}`, resolvedRequests[1])
+}
+
 func TestGitLabClient_ListOpenPullRequests(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pull_requests_list_response.json"))
@@ -219,6 +1329,197 @@ func TestGitLabClient_ListOpenPullRequests(t *testing.T) {
 	}, result[0]))
 }
 
+func TestGitLabClient_ListOpenPullRequestsWithFilter(t *testing.T) {
+	ctx := context.Background()
+	response := []*gitlab.MergeRequest{{IID: 1, SourceBranch: "feature", TargetBranch: "main", State: "opened",
+		Author: &gitlab.BasicUser{Username: "frogger"}, Labels: gitlab.Labels{"bug"}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests?author_username=frogger&labels=bug&page=1&per_page=5&source_branch=feature&state=opened&target_branch=main&updated_after=2023-01-02T00%%3A00%%3A00Z",
+			url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequestsWithFilter(ctx, owner, repo1, PullRequestFilter{
+		Author:       "frogger",
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		Label:        "bug",
+		UpdatedSince: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		Page:         1,
+		PerPage:      5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []PullRequestInfo{{
+		ID:     1,
+		Source: BranchInfo{Name: "feature"},
+		Target: BranchInfo{Name: "main", Repository: repo1, Owner: owner},
+		State:  PullRequestOpen,
+		Author: "frogger",
+		Labels: []string{"bug"},
+	}}, result)
+}
+
+func TestGitLabClient_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []byte(gitLabMergeRequestChangesResponse),
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/changes", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "go.mod", Status: FileModified, Additions: 1, Deletions: 1},
+		{Path: "new.go", PreviousPath: "old.go", Status: FileRenamed},
+		{Path: "go.sum", Status: FileDeleted, Deletions: 1},
+	}, files)
+
+	_, err = client.ListPullRequestFiles(ctx, owner, "", 1)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_GetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []byte(gitLabMergeRequestChangesResponse),
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/changes", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "diff --git a/go.mod b/go.mod\n--- a/go.mod\n+++ b/go.mod\n@@ -1 +1 @@\n-go 1.17\n+go 1.19\n"+
+		"diff --git a/old.go b/new.go\n--- a/old.go\n+++ b/new.go\n"+
+		"diff --git a/go.sum b/go.sum\n--- a/go.sum\n+++ /dev/null\n@@ -1 +0,0 @@\n-hash\n", diff)
+}
+
+func TestGitLabClient_ListPullRequestCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "commit_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/commits?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	commits, err := client.ListPullRequestCommits(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, CommitInfo{
+		Hash:          "ed899a2f4b50b4370feeea94676502b42383c746",
+		AuthorName:    "Example User",
+		CommitterName: "Administrator",
+		Url:           "https://gitlab.example.com/thedude/gitlab-foss/-/commit/ed899a2f4b50b4370feeea94676502b42383c746",
+		Timestamp:     1348131022,
+		Message:       "Replace sanitize with escape once",
+		ParentHashes:  []string{"6104942438c14ec7bd21c6cd5bd995272b3faff6"},
+	}, commits[0])
+
+	_, err = client.ListPullRequestCommits(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_GetDefaultReviewers(t *testing.T) {
+	ctx := context.Background()
+	response := []*gitlab.ProjectApprovalRule{
+		{Name: "All", RuleType: "any_approver"},
+		{Name: "Maintainers", RuleType: "regular", EligibleApprovers: []*gitlab.BasicUser{{Username: "frogger"}, {Username: "toad"}}},
+		{Name: "Releases", RuleType: "regular", Users: []*gitlab.BasicUser{{Username: "releaser"}},
+			ProtectedBranches: []*gitlab.ProtectedBranch{{Name: "release/*"}}},
+		{Name: "Develop", RuleType: "regular", Users: []*gitlab.BasicUser{{Username: "developer"}},
+			ProtectedBranches: []*gitlab.ProtectedBranch{{Name: "develop"}}},
+		{Name: "Vulnerability-Check", RuleType: "report_approver", EligibleApprovers: []*gitlab.BasicUser{{Username: "security"}}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/approval_rules", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	reviewers, err := client.GetDefaultReviewers(ctx, owner, repo1, branch1, "release/1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"frogger", "toad", "releaser"}, reviewers)
+
+	_, err = client.GetDefaultReviewers(ctx, owner, repo1, branch1, "")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_GetPullRequestByID(t *testing.T) {
+	ctx := context.Background()
+	response := `{"iid":1,"title":"Fix all the bugs","description":"Merge request body","state":"opened",
+"work_in_progress":true,"author":{"username":"example"},"labels":["bug"],"merge_status":"cannot_be_merged",
+"source_branch":"feature","target_branch":"master","source_project_id":3,"target_project_id":3}`
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []byte(response),
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	pullRequest, err := client.GetPullRequestByID(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	mergeable := false
+	assert.Equal(t, PullRequestInfo{
+		ID:        1,
+		Source:    BranchInfo{Name: "feature", Repository: repo1, Owner: owner},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		Title:     "Fix all the bugs",
+		Body:      "Merge request body",
+		State:     PullRequestOpen,
+		Draft:     true,
+		Author:    "example",
+		Labels:    []string{"bug"},
+		Mergeable: &mergeable,
+	}, pullRequest)
+
+	_, err = client.GetPullRequestByID(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_GetPullRequestMergeableState(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+			return
+		case "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1?include_diverged_commits_count=true":
+			_, err := w.Write([]byte(`{"iid":1,"merge_status":"can_be_merged","has_conflicts":false,"diverged_commits_count":3}`))
+			assert.NoError(t, err)
+		case "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1/approvals":
+			_, err := w.Write([]byte(`{"approvals_required":2,"approvals_left":1}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	state, err := client.GetPullRequestMergeableState(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestMergeableState{BehindTarget: true, MissingApprovals: 1}, state)
+}
+
+func TestGitLabClient_GetPullRequestMergeableStateWithoutApprovals(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+			return
+		case "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1?include_diverged_commits_count=true":
+			_, err := w.Write([]byte(`{"iid":1,"merge_status":"can_be_merged","has_conflicts":false,"diverged_commits_count":0}`))
+			assert.NoError(t, err)
+		case "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1/approvals":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	state, err := client.GetPullRequestMergeableState(ctx, owner, repo1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestMergeableState{Mergeable: true}, state)
+}
+
+const gitLabMergeRequestChangesResponse = `{"iid":1,"changes":[
+{"old_path":"go.mod","new_path":"go.mod","diff":"@@ -1 +1 @@\n-go 1.17\n+go 1.19\n"},
+{"old_path":"old.go","new_path":"new.go","renamed_file":true,"diff":""},
+{"old_path":"go.sum","new_path":"go.sum","deleted_file":true,"diff":"@@ -1 +0,0 @@\n-hash\n"}]}`
+
 func TestGitLabClient_GetLatestCommit(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "commit_list_response.json"))
@@ -254,92 +1555,362 @@ func TestGitLabClient_GetLatestCommitNotFound(t *testing.T) {
 			url.PathEscape(owner+"/"+repo1)), http.StatusNotFound, createGitLabHandler)
 	defer cleanUp()
 
-	result, err := client.GetLatestCommit(ctx, owner, repo1, "master")
-
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "404 Project Not Found")
-	assert.Empty(t, result)
+	result, err := client.GetLatestCommit(ctx, owner, repo1, "master")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404 Project Not Found")
+	assert.Empty(t, result)
+}
+
+func TestGitLabClient_GetLatestCommitUnknownBranch(t *testing.T) {
+	ctx := context.Background()
+
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, []byte("[]"),
+		fmt.Sprintf("/api/v4/projects/%s/repository/commits?page=1&per_page=1&ref_name=unknown",
+			url.PathEscape(owner+"/"+repo1)), http.StatusOK, createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.GetLatestCommit(ctx, owner, repo1, "unknown")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404 Not Found")
+	assert.Empty(t, result)
+}
+
+func TestGitLabClient_AddSshKeyToRepository(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{
+		"can_push": false,
+		"created_at": "2021-10-21T13:49:59.996Z",
+		"expires_at": null,
+		"id": 1,
+		"key": "ssh-rsa AAAA...",
+		"title": "My deploy key"
+	}`)
+	expectedBody := []byte(`{"title":"My deploy key","key":"ssh-rsa AAAA...","can_push":false}`)
+
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/deploy_keys", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		expectedBody, http.MethodPost, createGitLabWithBodyHandler)
+	defer closeServer()
+
+	err := client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", Read)
+
+	require.NoError(t, err)
+}
+
+func TestGitLabClient_AddSshKeyToRepositoryReadWrite(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{
+		"can_push": false,
+		"created_at": "2021-10-21T13:49:59.996Z",
+		"expires_at": null,
+		"id": 1,
+		"key": "ssh-rsa AAAA...",
+		"title": "My deploy key"
+	}`)
+	expectedBody := []byte(`{"title":"My deploy key","key":"ssh-rsa AAAA...","can_push":true}`)
+
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/deploy_keys", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		expectedBody, http.MethodPost, createGitLabWithBodyHandler)
+	defer closeServer()
+
+	err := client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", ReadWrite)
+
+	require.NoError(t, err)
+}
+
+func TestGitLabClient_ListDeployKeys(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"id":7,"title":"frogbot","key":"ssh-rsa AAAA...","can_push":true},{"id":8,"title":"ci","key":"ssh-rsa BBBB...","can_push":false}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/deploy_keys?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	keys, err := client.ListDeployKeys(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []DeployKeyInfo{
+		{ID: 7, Title: "frogbot", Key: "ssh-rsa AAAA..."},
+		{ID: 8, Title: "ci", Key: "ssh-rsa BBBB...", ReadOnly: true},
+	}, keys)
+}
+
+func TestGitLabClient_AddDeployKey(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitlab.AddDeployKeyOptions{Title: gitlab.String("frogbot"), Key: gitlab.String("ssh-rsa AAAA..."), CanPush: gitlab.Bool(false)})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false,
+		[]byte(`{"id":7,"title":"frogbot","key":"ssh-rsa AAAA...","can_push":false}`),
+		fmt.Sprintf("/api/v4/projects/%s/deploy_keys", url.PathEscape(owner+"/"+repo1)), http.StatusCreated, expectedBody, http.MethodPost,
+		createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	key, err := client.AddDeployKey(ctx, owner, repo1, "frogbot", "ssh-rsa AAAA...", true)
+	require.NoError(t, err)
+	assert.Equal(t, DeployKeyInfo{ID: 7, Title: "frogbot", Key: "ssh-rsa AAAA...", ReadOnly: true}, key)
+}
+
+func TestGitLabClient_DeleteDeployKey(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, []byte{},
+		fmt.Sprintf("/api/v4/projects/%s/deploy_keys/7", url.PathEscape(owner+"/"+repo1)), http.StatusNoContent, []byte{}, http.MethodDelete,
+		createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteDeployKey(ctx, owner, repo1, 7)
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_GetRepositoryInfo(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "repository_response.json"))
+	require.NoError(t, err)
+
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, response,
+		"/api/v4/projects/diaspora%2Fdiaspora-project-site?statistics=true", http.StatusOK, createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.GetRepositoryInfo(ctx, "diaspora", "diaspora-project-site")
+	require.NoError(t, err)
+	require.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Private,
+			CloneInfo: CloneInfo{
+				HTTP: "http://example.com/diaspora/diaspora-project-site.git",
+				SSH:  "git@example.com:diaspora/diaspora-project-site.git"},
+			DefaultBranch: "master",
+			Topics:        []string{"example", "disapora project"},
+			Size:          1038090,
+		},
+		result,
+	)
+}
+
+func TestGitLabClient_GetRepositoryLanguages(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, map[string]float32{"Go": 75, "Shell": 25},
+		fmt.Sprintf("/api/v4/projects/%s/languages", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	languages, err := client.GetRepositoryLanguages(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"Go": 75, "Shell": 25}, languages)
+
+	_, err = client.GetRepositoryLanguages(ctx, "", repo1)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_CreateRepository(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+			return
+		case "/api/v4/namespaces/jfrog":
+			_, err := w.Write([]byte(`{"id":5,"path":"jfrog"}`))
+			assert.NoError(t, err)
+		case "/api/v4/projects":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"name":"repo-1","path":"repo-1","namespace_id":5,"visibility":"internal","initialize_with_readme":true,"default_branch":"master"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"id":6}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		assert.Equal(t, token, r.Header.Get("Private-Token"))
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	internal := Internal
+	err := client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Visibility: &internal, DefaultBranch: "master", InitWithReadme: true})
+	assert.NoError(t, err)
+}
+
+func TestGitLabClient_DeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, []byte{},
+		fmt.Sprintf("/api/v4/projects/%s", url.PathEscape(owner+"/"+repo1)), http.StatusAccepted, []byte{}, http.MethodDelete,
+		createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
 }
 
-func TestGitLabClient_GetLatestCommitUnknownBranch(t *testing.T) {
+func TestGitLabClient_ForkRepository(t *testing.T) {
 	ctx := context.Background()
+	importStatus := "started"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+			return
+		case "/api/v4/projects/jfrog%2Frepo-1/fork":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"namespace":"frogs"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"id":6,"path":"repo-1","path_with_namespace":"frogs/repo-1","visibility":"private",` +
+				`"http_url_to_repo":"https://gitlab.com/frogs/repo-1.git","ssh_url_to_repo":"git@gitlab.com:frogs/repo-1.git","import_status":"scheduled"}`))
+			assert.NoError(t, err)
+		case "/api/v4/projects/6":
+			_, err := w.Write([]byte(fmt.Sprintf(`{"id":6,"import_status":"%s","import_error":"out of frogs"}`, importStatus)))
+			assert.NoError(t, err)
+			importStatus = "failed"
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
 
-	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, []byte("[]"),
-		fmt.Sprintf("/api/v4/projects/%s/repository/commits?page=1&per_page=1&ref_name=unknown",
-			url.PathEscape(owner+"/"+repo1)), http.StatusOK, createGitLabHandler)
-	defer cleanUp()
+	forkInfo, err := client.ForkRepository(ctx, owner, repo1, "frogs", false)
+	require.NoError(t, err)
+	assert.Equal(t, ForkInfo{
+		Owner:      "frogs",
+		Repository: repo1,
+		RepositoryInfo: RepositoryInfo{
+			RepositoryVisibility: Private,
+			CloneInfo:            CloneInfo{HTTP: "https://gitlab.com/frogs/repo-1.git", SSH: "git@gitlab.com:frogs/repo-1.git"},
+		},
+	}, forkInfo)
 
-	result, err := client.GetLatestCommit(ctx, owner, repo1, "unknown")
+	importStatus = "finished"
+	_, err = client.ForkRepository(ctx, owner, repo1, "frogs", true)
+	assert.NoError(t, err)
 
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "404 Not Found")
-	assert.Empty(t, result)
+	_, err = client.ForkRepository(ctx, owner, repo1, "frogs", true)
+	assert.EqualError(t, err, "failed to fork jfrog/repo-1: out of frogs")
 }
 
-func TestGitLabClient_AddSshKeyToRepository(t *testing.T) {
+func TestGitLabClient_ListCollaborators(t *testing.T) {
 	ctx := context.Background()
-	response := []byte(`{
-		"can_push": false,
-		"created_at": "2021-10-21T13:49:59.996Z",
-		"expires_at": null,
-		"id": 1,
-		"key": "ssh-rsa AAAA...",
-		"title": "My deploy key"
-	}`)
-	expectedBody := []byte(`{"title":"My deploy key","key":"ssh-rsa AAAA...","can_push":false}`)
-
-	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, response,
-		fmt.Sprintf("/api/v4/projects/%s/deploy_keys", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
-		expectedBody, http.MethodPost, createGitLabWithBodyHandler)
-	defer closeServer()
-
-	err := client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", Read)
+	response := []gitlab.ProjectMember{
+		{Username: "frogger", AccessLevel: gitlab.OwnerPermissions},
+		{Username: "toad", AccessLevel: gitlab.DeveloperPermissions},
+		{Username: "tadpole", AccessLevel: gitlab.ReporterPermissions},
+		{Username: "egg", AccessLevel: gitlab.GuestPermissions},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/members/all?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
 
+	collaborators, err := client.ListCollaborators(ctx, owner, repo1)
 	require.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{
+		{Username: "frogger", Permission: PermissionAdmin},
+		{Username: "toad", Permission: PermissionWrite},
+		{Username: "tadpole", Permission: PermissionRead},
+		{Username: "egg", Permission: PermissionNone},
+	}, collaborators)
 }
 
-func TestGitLabClient_AddSshKeyToRepositoryReadWrite(t *testing.T) {
+func TestGitLabClient_AddCollaborator(t *testing.T) {
 	ctx := context.Background()
-	response := []byte(`{
-		"can_push": false,
-		"created_at": "2021-10-21T13:49:59.996Z",
-		"expires_at": null,
-		"id": 1,
-		"key": "ssh-rsa AAAA...",
-		"title": "My deploy key"
-	}`)
-	expectedBody := []byte(`{"title":"My deploy key","key":"ssh-rsa AAAA...","can_push":true}`)
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+			return
+		case "/api/v4/users?username=frogger":
+			_, err := w.Write([]byte(`[{"id":3,"username":"frogger"}]`))
+			assert.NoError(t, err)
+		case "/api/v4/projects/jfrog%2Frepo-1/members":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"user_id":3,"access_level":30,"expires_at":null}`, string(body))
+			// The user is already a member
+			w.WriteHeader(http.StatusConflict)
+			_, err = w.Write([]byte(`{"message":"Member already exists"}`))
+			assert.NoError(t, err)
+		case "/api/v4/projects/jfrog%2Frepo-1/members/3":
+			assert.Equal(t, http.MethodPut, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"access_level":30,"expires_at":null}`, string(body))
+			_, err = w.Write([]byte(`{"id":3,"access_level":30}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		requests = append(requests, r.Method+" "+r.RequestURI)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
 
-	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, response,
-		fmt.Sprintf("/api/v4/projects/%s/deploy_keys", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
-		expectedBody, http.MethodPost, createGitLabWithBodyHandler)
-	defer closeServer()
+	err := client.AddCollaborator(ctx, owner, repo1, username, PermissionWrite)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /api/v4/users?username=frogger",
+		"POST /api/v4/projects/jfrog%2Frepo-1/members",
+		"PUT /api/v4/projects/jfrog%2Frepo-1/members/3",
+	}, requests)
+}
 
-	err := client.AddSshKeyToRepository(ctx, owner, repo1, "My deploy key", "ssh-rsa AAAA...", ReadWrite)
+func TestGitLabClient_RemoveCollaborator(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+		case "/api/v4/users?username=frogger":
+			_, err := w.Write([]byte(`[{"id":3,"username":"frogger"}]`))
+			assert.NoError(t, err)
+		case "/api/v4/projects/jfrog%2Frepo-1/members/3":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
 
-	require.NoError(t, err)
+	err := client.RemoveCollaborator(ctx, owner, repo1, username)
+	assert.NoError(t, err)
 }
 
-func TestGitLabClient_GetRepositoryInfo(t *testing.T) {
+func TestGitLabClient_GetUserPermission(t *testing.T) {
 	ctx := context.Background()
-	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "repository_response.json"))
-	require.NoError(t, err)
+	member := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+		case "/api/v4/users?username=frogger":
+			_, err := w.Write([]byte(`[{"id":3,"username":"frogger"}]`))
+			assert.NoError(t, err)
+		case "/api/v4/projects/jfrog%2Frepo-1/members/all/3":
+			if !member {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, err := w.Write([]byte(`{"id":3,"access_level":40}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
 
-	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, response,
-		"/api/v4/projects/diaspora%2Fdiaspora-project-site", http.StatusOK, createGitLabHandler)
-	defer cleanUp()
+	permission, err := client.GetUserPermission(ctx, owner, repo1, username)
+	require.NoError(t, err)
+	assert.Equal(t, PermissionAdmin, permission)
 
-	result, err := client.GetRepositoryInfo(ctx, "diaspora", "diaspora-project-site")
+	member = false
+	permission, err = client.GetUserPermission(ctx, owner, repo1, username)
 	require.NoError(t, err)
-	require.Equal(t,
-		RepositoryInfo{
-			RepositoryVisibility: Private,
-			CloneInfo: CloneInfo{
-				HTTP: "http://example.com/diaspora/diaspora-project-site.git",
-				SSH:  "git@example.com:diaspora/diaspora-project-site.git"},
-		},
-		result,
-	)
+	assert.Equal(t, PermissionNone, permission)
 }
 
 func TestGitLabClient_GetCommitBySha(t *testing.T) {
@@ -386,6 +1957,235 @@ func TestGitLabClient_GetCommitByShaNotFound(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestGitLabClient_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	commitsURI := fmt.Sprintf("/api/v4/projects/%s/repository/commits", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var commits []*gitlab.Commit
+		switch r.RequestURI {
+		case "/api/v4/":
+			return
+		case commitsURI + "?page=1&path=README.md&ref_name=master":
+			w.Header().Set("X-Next-Page", "2")
+			commits = []*gitlab.Commit{{ID: "sha1", AuthorName: "Frogger", AuthorEmail: "frogger@example.com"}, {ID: "sha2", AuthorName: "Toad"}}
+		case commitsURI + "?page=2&path=README.md&ref_name=master":
+			commits = []*gitlab.Commit{{ID: "sha3", AuthorName: "Frog", AuthorEmail: "frogger@example.com", ParentIDs: []string{"sha4"}}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(commits)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	// The author filter is applied by the client
+	client := buildClient(t, vcsutils.GitLab, false, server)
+	result, err := client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master", Path: "README.md", Author: "frogger@example.com"})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "sha1", result[0].Hash)
+	assert.Equal(t, "sha3", result[1].Hash)
+	assert.Equal(t, []string{"sha4"}, result[1].ParentHashes)
+
+	result, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master", Path: "README.md", Page: 2})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "sha3", result[0].Hash)
+}
+
+func TestGitLabClient_ListContributors(t *testing.T) {
+	ctx := context.Background()
+	response := []*gitlab.Contributor{{Name: "Frogger", Email: "frogger@example.com", Commits: 5}, {Name: "Toad", Email: "toad@example.com", Commits: 2}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/contributors?order_by=commits&page=1&per_page=100&sort=desc", url.PathEscape(owner+"/"+repo1)),
+		createGitLabHandler)
+	defer cleanUp()
+
+	contributors, err := client.ListContributors(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []ContributorInfo{{Name: "Frogger", Email: "frogger@example.com", Commits: 5}, {Name: "Toad", Email: "toad@example.com", Commits: 2}}, contributors)
+
+	_, err = client.ListContributors(ctx, "", repo1)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_GetCommitActivity(t *testing.T) {
+	ctx := context.Background()
+	commitsURI := fmt.Sprintf("/api/v4/projects/%s/repository/commits", url.PathEscape(owner+"/"+repo1))
+	committed := func(day int) *time.Time {
+		date := time.Date(2023, time.June, day, 12, 0, 0, 0, time.UTC)
+		return &date
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var commits []*gitlab.Commit
+		switch r.RequestURI {
+		case "/api/v4/":
+			return
+		// The commits are listed since the start of the week
+		case commitsURI + "?page=1&since=2023-05-28T00%3A00%3A00Z":
+			commits = []*gitlab.Commit{
+				{ID: "sha4", AuthorName: "Frogger", CommittedDate: committed(5)},
+				{ID: "sha3", AuthorName: "Toad", CommittedDate: committed(2)},
+				{ID: "sha2", AuthorName: "Frogger", CommittedDate: committed(1)},
+				{ID: "sha1", AuthorName: "Frogger", CommittedDate: committed(1)},
+			}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(commits)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	activity, err := client.GetCommitActivity(ctx, owner, repo1, time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, []CommitActivityInfo{
+		{Author: "Frogger", Week: 1685232000, Commits: 2},
+		{Author: "Toad", Week: 1685232000, Commits: 1},
+		{Author: "Frogger", Week: 1685836800, Commits: 1},
+	}, activity)
+}
+
+func TestGitLabClient_GetCommit(t *testing.T) {
+	ctx := context.Background()
+	sha := "ff4a54b88fbd387ac4d9e8cdeb54b049978e450a"
+	commitURI := fmt.Sprintf("/api/v4/projects/%s/repository/commits/%s", url.PathEscape(owner+"/"+repo1), sha)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{}
+		switch r.RequestURI {
+		case "/api/v4/":
+			return
+		case commitURI:
+			response = gitlab.Commit{ID: sha, ParentIDs: []string{"sha0"}, Stats: &gitlab.CommitStats{Additions: 5, Deletions: 2, Total: 7}}
+		case commitURI + "/diff?page=1&per_page=100":
+			response = []*gitlab.Diff{{OldPath: "README.md", NewPath: "README.md"}, {NewPath: "new.txt", NewFile: true}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		responseBytes, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBytes)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	result, err := buildClient(t, vcsutils.GitLab, false, server).GetCommit(ctx, owner, repo1, sha)
+	require.NoError(t, err)
+	assert.Equal(t, sha, result.Hash)
+	assert.Equal(t, []string{"sha0"}, result.ParentHashes)
+	assert.Equal(t, 5, result.Additions)
+	assert.Equal(t, 2, result.Deletions)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "README.md", Status: FileModified},
+		{Path: "new.txt", Status: FileAdded},
+	}, result.Files)
+}
+
+func TestGitLabClient_GetCommitVerification(t *testing.T) {
+	ctx := context.Background()
+	commitsURI := fmt.Sprintf("/api/v4/projects/%s/repository/commits", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/v4/":
+			return
+		case commitsURI + "/sha1/signature":
+			response = `{"signature_type":"PGP","verification_status":"verified","gpg_key_user_name":"Frogger","gpg_key_user_email":"frogger@jfrog.com"}`
+		case commitsURI + "/sha2/signature":
+			response = `{"signature_type":"X509","verification_status":"unverified","x509_certificate":{"email":"user@example.com"}}`
+		case commitsURI + "/sha3/signature":
+			w.WriteHeader(http.StatusNotFound)
+			response = `{"message":"404 Signature Not Found"}`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	verification, err := client.GetCommitVerification(ctx, owner, repo1, "sha1")
+	require.NoError(t, err)
+	assert.Equal(t, CommitVerificationInfo{Signed: true, Verified: true, SignatureType: SignatureGPG, Reason: "verified",
+		SignerName: "Frogger", SignerEmail: "frogger@jfrog.com"}, verification)
+
+	verification, err = client.GetCommitVerification(ctx, owner, repo1, "sha2")
+	require.NoError(t, err)
+	assert.Equal(t, CommitVerificationInfo{Signed: true, SignatureType: SignatureX509, Reason: "unverified", SignerEmail: "user@example.com"}, verification)
+
+	verification, err = client.GetCommitVerification(ctx, owner, repo1, "sha3")
+	require.NoError(t, err)
+	assert.Equal(t, CommitVerificationInfo{Reason: "unsigned"}, verification)
+}
+
+func TestGitLabClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	compareURI := fmt.Sprintf("/api/v4/projects/%s/repository/compare", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var comparison gitlab.Compare
+		switch r.RequestURI {
+		case "/api/v4/":
+		case compareURI + "?from=main&to=feature":
+			comparison = gitlab.Compare{
+				Commits: []*gitlab.Commit{{ID: "sha1", Message: "Add feature", ParentIDs: []string{"sha0"}}},
+				Diffs:   []*gitlab.Diff{{OldPath: "README.md", NewPath: "README.md"}, {OldPath: "a.txt", NewPath: "b.txt", RenamedFile: true}},
+			}
+		case compareURI + "?from=feature&to=main":
+			comparison = gitlab.Compare{Commits: []*gitlab.Commit{{ID: "sha2"}, {ID: "sha3"}}}
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		response, err := json.Marshal(comparison)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	result, err := buildClient(t, vcsutils.GitLab, false, server).CompareCommits(ctx, owner, repo1, "main", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.AheadBy)
+	assert.Equal(t, 2, result.BehindBy)
+	require.Len(t, result.Commits, 1)
+	assert.Equal(t, "sha1", result.Commits[0].Hash)
+	assert.Equal(t, []string{"sha0"}, result.Commits[0].ParentHashes)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "README.md", Status: FileModified},
+		{Path: "b.txt", PreviousPath: "a.txt", Status: FileRenamed},
+	}, result.Files)
+}
+
+func TestGitLabClient_GetCommitsBetween(t *testing.T) {
+	ctx := context.Background()
+	compareURI := fmt.Sprintf("/api/v4/projects/%s/repository/compare?from=sha0&to=sha2", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/api/v4/" {
+			return
+		}
+		assert.Equal(t, compareURI, r.RequestURI)
+		response, err := json.Marshal(gitlab.Compare{Commits: []*gitlab.Commit{{ID: "sha1", AuthorName: "Example User"}, {ID: "sha2", Message: "Second"}}})
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	commits, err := buildClient(t, vcsutils.GitLab, false, server).GetCommitsBetween(ctx, owner, repo1, "sha0", "sha2")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	// The most recent commit is first
+	assert.Equal(t, "sha2", commits[0].Hash)
+	assert.Equal(t, "Second", commits[0].Message)
+	assert.Equal(t, "sha1", commits[1].Hash)
+	assert.Equal(t, "Example User", commits[1].AuthorName)
+}
+
 func TestGitLabClient_getGitLabProjectVisibility(t *testing.T) {
 	assert.Equal(t, Public, getGitLabProjectVisibility(&gitlab.Project{Visibility: gitlab.PublicVisibility}))
 	assert.Equal(t, Internal, getGitLabProjectVisibility(&gitlab.Project{Visibility: gitlab.InternalVisibility}))
@@ -418,7 +2218,7 @@ func TestGitlabClient_GetLabel(t *testing.T) {
 	ctx := context.Background()
 	expectedLabel := gitlab.Label{Name: labelName, Description: "label-description", Color: "001122"}
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []gitlab.Label{expectedLabel},
-		fmt.Sprintf("/api/v4/projects/%s/labels", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+		fmt.Sprintf("/api/v4/projects/%s/labels?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
 	defer cleanUp()
 
 	labelInfo, err := client.GetLabel(ctx, owner, repo1, labelName)
@@ -454,6 +2254,117 @@ func TestGitlabClient_UnlabelPullRequest(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGitlabClient_ListLabels(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false,
+		[]gitlab.Label{{Name: labelName, Description: "label-description", Color: "#001122"}},
+		fmt.Sprintf("/api/v4/projects/%s/labels?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	labels, err := client.ListLabels(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []LabelInfo{{Name: labelName, Description: "label-description", Color: "001122"}}, labels)
+}
+
+func TestGitlabClient_UpdateLabel(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitlab.UpdateLabelOptions{Name: &labelName, NewName: gitlab.String("new-name"), Color: gitlab.String("#ff0000")})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Label{},
+		fmt.Sprintf("/api/v4/projects/%s/labels", url.PathEscape(owner+"/"+repo1)), http.StatusOK, expectedBody, http.MethodPut,
+		createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err = client.UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: "new-name", Color: "ff0000"})
+	assert.NoError(t, err)
+}
+
+func TestGitlabClient_DeleteLabel(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, []byte{},
+		fmt.Sprintf("/api/v4/projects/%s/labels?name=%s", url.PathEscape(owner+"/"+repo1), url.QueryEscape(labelName)),
+		http.StatusNoContent, []byte{}, http.MethodDelete, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteLabel(ctx, owner, repo1, labelName)
+	assert.NoError(t, err)
+}
+
+func TestGitlabClient_CreateIssue(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(&gitlab.CreateIssueOptions{
+		Title:       gitlab.String("Vulnerable dependency"),
+		Description: gitlab.String("Upgrade lodash"),
+		Labels:      gitlab.Labels{"security"},
+	})
+	require.NoError(t, err)
+	response := gitlab.Issue{
+		IID:    3,
+		Title:  "Vulnerable dependency",
+		State:  "opened",
+		Author: &gitlab.IssueAuthor{Username: username},
+		Labels: gitlab.Labels{"security"},
+		WebURL: "https://gitlab.com/jfrog/repo-1/-/issues/3",
+	}
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/issues", url.PathEscape(owner+"/"+repo1)), http.StatusCreated, expectedBody, http.MethodPost,
+		createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	issue, err := client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "Upgrade lodash", []string{"security"})
+	assert.NoError(t, err)
+	assert.Equal(t, IssueInfo{
+		ID:     3,
+		Title:  "Vulnerable dependency",
+		State:  IssueOpen,
+		Author: username,
+		Labels: []string{"security"},
+		URL:    "https://gitlab.com/jfrog/repo-1/-/issues/3",
+	}, issue)
+}
+
+func TestGitlabClient_AddIssueComment(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitlab.CreateIssueNoteOptions{Body: gitlab.String("Fixed in 1.2.3")})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Note{},
+		fmt.Sprintf("/api/v4/projects/%s/issues/3/notes", url.PathEscape(owner+"/"+repo1)), http.StatusCreated, expectedBody,
+		http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err = client.AddIssueComment(ctx, owner, repo1, "Fixed in 1.2.3", 3)
+	assert.NoError(t, err)
+}
+
+func TestGitlabClient_ListIssues(t *testing.T) {
+	ctx := context.Background()
+	response := []gitlab.Issue{
+		{IID: 1, Title: "Bug", State: "opened", Author: &gitlab.IssueAuthor{Username: username}, Labels: gitlab.Labels{"bug"}},
+		{IID: 2, Title: "Other bug", State: "opened", Author: &gitlab.IssueAuthor{Username: "other"}, Labels: gitlab.Labels{"bug"}},
+	}
+	opened := IssueOpen
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/issues?labels=bug&page=1&state=opened", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	issues, err := client.ListIssues(ctx, owner, repo1, IssueFilter{State: &opened, Author: username, Label: "bug"})
+	assert.NoError(t, err)
+	assert.Equal(t, []IssueInfo{{ID: 1, Title: "Bug", State: IssueOpen, Author: username, Labels: []string{"bug"}}}, issues)
+}
+
+func TestGitlabClient_UpdateIssueState(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitlab.UpdateIssueOptions{StateEvent: gitlab.String("close")})
+	require.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Issue{},
+		fmt.Sprintf("/api/v4/projects/%s/issues/3", url.PathEscape(owner+"/"+repo1)), http.StatusOK, expectedBody, http.MethodPut,
+		createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err = client.UpdateIssueState(ctx, owner, repo1, 3, IssueClosed)
+	assert.NoError(t, err)
+}
+
 func TestGitlabClient_UploadCodeScanning(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, true, "", "unsupportedTest", createGitLabHandler)
@@ -462,6 +2373,75 @@ func TestGitlabClient_UploadCodeScanning(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitLabClient_UploadScanResults(t *testing.T) {
+	ctx := context.Background()
+	commitsResponse, err := os.ReadFile(filepath.Join("testdata", "gitlab", "commit_list_response.json"))
+	require.NoError(t, err)
+	projectURI := "/api/v4/projects/" + url.PathEscape(owner+"/"+repo1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v4/":
+			return
+		case "GET " + projectURI + "/repository/commits?page=1&per_page=1&ref_name=master":
+			response = commitsResponse
+		case "POST " + projectURI + "/repository/commits/ed899a2f4b50b4370feeea94676502b42383c746/discussions":
+			var request gitlab.CreateCommitDiscussionOptions
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Contains(t, *request.Body, "### JFrog Xray results")
+			assert.Contains(t, *request.Body, "| Critical | Prototype Pollution in lodash | package.json:12 |")
+			w.WriteHeader(http.StatusCreated)
+			response = []byte(`{"id":"6a9c1750b37d513a43987b574953fceb50b03ce7","notes":[{"id":1}]}`)
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	discussionID, err := client.UploadScanResults(ctx, owner, repo1, "master", testSarifScanResults)
+	require.NoError(t, err)
+	assert.Equal(t, "6a9c1750b37d513a43987b574953fceb50b03ce7", discussionID)
+
+	_, err = client.UploadScanResults(ctx, owner, repo1, "master", "not a SARIF log")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_ListSecurityAlerts(t *testing.T) {
+	ctx := context.Background()
+	findingsURI := fmt.Sprintf("/api/v4/projects/%s/vulnerability_findings", url.PathEscape(owner+"/"+repo1))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/v4/":
+			return
+		case findingsURI + "?page=1&per_page=100":
+			w.Header().Set("X-Next-Page", "2")
+			response = `[{"id":1,"report_type":"dependency_scanning","name":"Prototype Pollution in lodash","severity":"High",` +
+				`"identifiers":[{"external_type":"gemnasium","external_id":"1a2b"},{"external_type":"cve","external_id":"CVE-2020-8203"}],` +
+				`"location":{"file":"package-lock.json","dependency":{"package":{"name":"lodash"}}}}]`
+		case findingsURI + "?page=2&per_page=100":
+			response = `[{"id":2,"report_type":"sast","name":"Improper neutralization of SQL commands","severity":"Medium",` +
+				`"identifiers":[{"external_type":"semgrep_id","external_id":"gosec.G201"}],"location":{"file":"main.go"}}]`
+		default:
+			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	alerts, err := client.ListSecurityAlerts(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []SecurityAlertInfo{
+		{ID: 1, Type: DependencyAlert, Severity: SeverityHigh, Title: "Prototype Pollution in lodash", Identifier: "CVE-2020-8203", Package: "lodash", Path: "package-lock.json"},
+		{ID: 2, Type: CodeScanningAlert, Severity: SeverityMedium, Title: "Improper neutralization of SQL commands", Identifier: "gosec.G201", Path: "main.go"},
+	}, alerts)
+}
+
 func TestGitlabClient_GetRepositoryEnvironmentInfo(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, true, "", "unsupportedTest", createGitLabHandler)
@@ -471,6 +2451,68 @@ func TestGitlabClient_GetRepositoryEnvironmentInfo(t *testing.T) {
 	assert.ErrorIs(t, err, errGitLabGetRepoEnvironmentInfoNotSupported)
 }
 
+func TestGitLabClient_Deployments(t *testing.T) {
+	ctx := context.Background()
+	projectPath := "/api/v4/projects/" + url.PathEscape(owner+"/"+repo1)
+	sha := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
+	var deploymentOptions gitlab.CreateProjectDeploymentOptions
+	var updateOptions gitlab.UpdateProjectDeploymentOptions
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/api/v4/":
+			return
+		case projectPath + "/environments?page=1&per_page=100":
+			response = `[{"id":1,"name":"staging","external_url":"https://staging.example.com"},{"id":2,"name":"production"}]`
+		case projectPath + "/repository/commits/v1.0.0":
+			response = `{"id":"` + sha + `"}`
+		case projectPath + "/repository/tags/v1.0.0":
+			response = `{"name":"v1.0.0"}`
+		case projectPath + "/repository/commits/" + branch1:
+			response = `{"id":"` + sha + `"}`
+		case projectPath + "/repository/tags/" + branch1:
+			w.WriteHeader(http.StatusNotFound)
+			response = `{"message":"404 Tag Not Found"}`
+		case projectPath + "/deployments":
+			assert.Equal(t, http.MethodPost, r.Method)
+			deploymentOptions = gitlab.CreateProjectDeploymentOptions{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&deploymentOptions))
+			response = `{"id":42}`
+		case projectPath + "/deployments/42":
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&updateOptions))
+			response = `{"id":42}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	environments, err := client.ListEnvironments(ctx, owner, repo1)
+	require.NoError(t, err)
+	assert.Equal(t, []RepositoryEnvironmentInfo{{Name: "staging", Url: "https://staging.example.com"}, {Name: "production"}}, environments)
+
+	deploymentID, err := client.CreateDeployment(ctx, owner, repo1, DeploymentInfo{Environment: "production", Ref: "v1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), deploymentID)
+	assert.Equal(t, "production", *deploymentOptions.Environment)
+	assert.Equal(t, sha, *deploymentOptions.SHA)
+	assert.True(t, *deploymentOptions.Tag)
+	assert.Equal(t, gitlab.DeploymentStatusRunning, *deploymentOptions.Status)
+
+	// A branch isn't a tag
+	_, err = client.CreateDeployment(ctx, owner, repo1, DeploymentInfo{Environment: "staging", Ref: branch1})
+	require.NoError(t, err)
+	assert.False(t, *deploymentOptions.Tag)
+
+	err = client.SetDeploymentStatus(ctx, owner, repo1, deploymentID, DeploymentStatusInfo{State: Error, Description: "Timed out"})
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.DeploymentStatusFailed, *updateOptions.Status)
+}
+
 func createGitLabHandler(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.RequestURI == "/api/v4/" {
@@ -571,3 +2613,12 @@ func createGitLabWithBodyHandler(t *testing.T, expectedURI string, response []by
 		assert.NoError(t, err)
 	}
 }
+
+func TestGitLabClient_CodeInsights(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.GitLab).Build()
+	require.NoError(t, err)
+	err = client.CreateCodeInsightsReport(context.Background(), owner, repo1, "abc123", CodeInsightsReport{ID: "report"})
+	assert.ErrorIs(t, err, errGitLabCodeInsightsNotSupported)
+	err = client.AddCodeInsightsAnnotations(context.Background(), owner, repo1, "abc123", "report", nil)
+	assert.ErrorIs(t, err, errGitLabCodeInsightsNotSupported)
+}
@@ -6,3 +6,10 @@ import (
 
 var errGitLabCodeScanningNotSupported = errors.New("code scanning is not supported on Gitlab")
 var errGitLabGetRepoEnvironmentInfoNotSupported = errors.New("get repository environment info is currently not supported on Bitbucket")
+var errGitLabRequestChangesNotSupported = errors.New("requesting changes on a merge request is not supported on GitLab")
+var errGitLabRebaseMergeNotSupported = errors.New("rebase merge strategy is not supported on GitLab, where the merge method is configured in the project settings")
+var errGitLabBranchProtectionChecksNotSupported = errors.New("required reviews and status checks are configured in the project merge request settings on GitLab, and aren't supported by branch protection")
+var errGitLabCodeInsightsNotSupported = errors.New("Code Insights reports are not supported on GitLab")
+
+// Merge requests whose title starts with one of these prefixes are drafts
+var gitLabDraftTitlePrefixes = []string{"Draft:", "[Draft]", "(Draft)", "WIP:", "[WIP]"}
@@ -0,0 +1,94 @@
+package vcsclient
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// newHTTPClient creates an HTTP client for the requests to the VCS provider.
+// The client is based on the HTTP client and transport of the VCS info, and retries requests according to the retry policy.
+func newHTTPClient(vcsInfo VcsInfo, logger Log) *http.Client {
+	return withRetries(newBaseHTTPClient(vcsInfo), vcsInfo, logger)
+}
+
+// newBearerTokenHTTPClient creates an HTTP client like newHTTPClient, which also authenticates the requests with the token of the VCS info
+func newBearerTokenHTTPClient(vcsInfo VcsInfo, logger Log) *http.Client {
+	return newTokenSourceHTTPClient(vcsInfo, logger, getStaticTokenSource(vcsInfo))
+}
+
+// newTokenSourceHTTPClient creates an HTTP client like newHTTPClient, which also authenticates the requests with the tokens of the token source, if not nil
+func newTokenSourceHTTPClient(vcsInfo VcsInfo, logger Log, tokenSource oauth2.TokenSource) *http.Client {
+	httpClient := newBaseHTTPClient(vcsInfo)
+	if tokenSource != nil {
+		httpClient.Transport = &oauth2.Transport{Source: tokenSource, Base: httpClient.Transport}
+	}
+	return withRetries(httpClient, vcsInfo, logger)
+}
+
+// getStaticTokenSource returns a token source of the token of the VCS info, or nil if there's no token
+func getStaticTokenSource(vcsInfo VcsInfo) oauth2.TokenSource {
+	if vcsInfo.Token == "" {
+		return nil
+	}
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: vcsInfo.Token})
+}
+
+// newBaseHTTPClient copies the HTTP client of the VCS info, so the transports added to the copy don't affect it.
+// The response cache is below the authentication, so that the cached responses are keyed by the authenticated user.
+// The rate limiter is below the retries and the response cache, so that it throttles every attempt, including the conditional requests.
+func newBaseHTTPClient(vcsInfo VcsInfo) *http.Client {
+	httpClient := &http.Client{}
+	if vcsInfo.HTTPClient != nil {
+		copiedClient := *vcsInfo.HTTPClient
+		httpClient = &copiedClient
+	}
+	if vcsInfo.Transport != nil {
+		httpClient.Transport = vcsInfo.Transport
+	}
+	if tlsConfig := getTLSConfig(vcsInfo); tlsConfig != nil {
+		httpClient.Transport = withTLSConfig(httpClient.Transport, tlsConfig)
+	}
+	if vcsInfo.RateLimiter != nil {
+		httpClient.Transport = withRateLimiter(httpClient.Transport, vcsInfo.RateLimiter)
+	}
+	if vcsInfo.ResponseCache != nil {
+		httpClient.Transport = withResponseCache(httpClient.Transport, vcsInfo.ResponseCache)
+	}
+	return httpClient
+}
+
+// getTLSConfig returns the TLS configuration of the VCS info, including its root CAs and InsecureSkipVerify, or nil if it has none
+func getTLSConfig(vcsInfo VcsInfo) *tls.Config {
+	if vcsInfo.TLSConfig == nil && vcsInfo.RootCAs == nil && !vcsInfo.InsecureSkipVerify {
+		return nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if vcsInfo.TLSConfig != nil {
+		tlsConfig = vcsInfo.TLSConfig.Clone()
+	}
+	if vcsInfo.RootCAs != nil {
+		tlsConfig.RootCAs = vcsInfo.RootCAs
+	}
+	if vcsInfo.InsecureSkipVerify {
+		// #nosec G402 -- explicitly requested by the user, for servers with self-signed certificates
+		tlsConfig.InsecureSkipVerify = true
+	}
+	return tlsConfig
+}
+
+// withTLSConfig returns a copy of the transport that uses the TLS configuration.
+// Custom transports that aren't *http.Transport are expected to configure TLS themselves, so they are returned as is.
+func withTLSConfig(transport http.RoundTripper, tlsConfig *tls.Config) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	httpTransport, ok := transport.(*http.Transport)
+	if !ok {
+		return transport
+	}
+	httpTransport = httpTransport.Clone()
+	httpTransport.TLSClientConfig = tlsConfig
+	return httpTransport
+}
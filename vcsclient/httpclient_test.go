@@ -0,0 +1,258 @@
+package vcsclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type countingTransport struct {
+	requests int
+}
+
+func (transport *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// testTokenSource returns a new OAuth token on each call, which expires after the expiration
+type testTokenSource struct {
+	tokens     int
+	expiration time.Duration
+}
+
+func (source *testTokenSource) Token() (*oauth2.Token, error) {
+	source.tokens++
+	return &oauth2.Token{AccessToken: fmt.Sprintf("oauth-token-%d", source.tokens), Expiry: time.Now().Add(source.expiration)}, nil
+}
+
+// assertTokenSourceRefresh checks that the client authenticates with the tokens of the token source, and refreshes them only when they expire
+func assertTokenSourceRefresh(t *testing.T, vcsProvider vcsutils.VcsProvider, responseBody string) {
+	// Tokens that expire in a second are considered expired
+	tokenSource := &testTokenSource{expiration: time.Second}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("Bearer oauth-token-%d", tokenSource.tokens), r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(responseBody))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Username(username).TokenSource(tokenSource).Build()
+	require.NoError(t, err)
+	assert.NoError(t, client.TestConnection(ctx))
+	tokens := tokenSource.tokens
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.Greater(t, tokenSource.tokens, tokens)
+
+	tokenSource.expiration = time.Hour
+	assert.NoError(t, client.TestConnection(ctx))
+	tokens = tokenSource.tokens
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.Equal(t, tokens, tokenSource.tokens)
+}
+
+func TestNewBaseHTTPClient(t *testing.T) {
+	assert.Equal(t, &http.Client{}, newBaseHTTPClient(VcsInfo{}))
+
+	customClient := &http.Client{Timeout: time.Minute}
+	httpClient := newBaseHTTPClient(VcsInfo{HTTPClient: customClient})
+	assert.Equal(t, customClient, httpClient)
+	assert.NotSame(t, customClient, httpClient)
+
+	transport := &countingTransport{}
+	httpClient = newBaseHTTPClient(VcsInfo{HTTPClient: customClient, Transport: transport})
+	assert.Equal(t, &http.Client{Timeout: time.Minute, Transport: transport}, httpClient)
+	assert.Nil(t, customClient.Transport)
+}
+
+func TestClientBuilder_Transport(t *testing.T) {
+	tests := []struct {
+		vcsProvider   vcsutils.VcsProvider
+		responseBody  string
+		authorization string
+	}{
+		{vcsutils.GitHub, "zen", "Bearer " + token},
+		{vcsutils.GitLab, "[]", ""},
+		{vcsutils.BitbucketServer, "{}", "Bearer " + token},
+		{vcsutils.BitbucketCloud, "{}", ""},
+		{vcsutils.Gitea, "{}", "token " + token},
+	}
+	for _, test := range tests {
+		t.Run(test.vcsProvider.String(), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.authorization != "" {
+					assert.Equal(t, test.authorization, r.Header.Get("Authorization"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+
+			transport := &countingTransport{}
+			client, err := NewClientBuilder(test.vcsProvider).ApiEndpoint(server.URL).Token(token).Transport(transport).Build()
+			require.NoError(t, err)
+			assert.NoError(t, client.TestConnection(context.Background()))
+			assert.NotZero(t, transport.requests)
+		})
+	}
+}
+
+func TestClientBuilder_HTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Timeout: 10 * time.Millisecond}
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).HTTPClient(httpClient).Build()
+	require.NoError(t, err)
+	err = client.TestConnection(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Client.Timeout exceeded")
+	assert.Nil(t, httpClient.Transport)
+
+	azureClient, err := NewAzureReposClient(VcsInfo{APIEndpoint: server.URL, HTTPClient: httpClient}, EmptyLogger{})
+	require.NoError(t, err)
+	require.NotNil(t, azureClient.connectionDetails.Timeout)
+	assert.Equal(t, httpClient.Timeout, *azureClient.connectionDetails.Timeout)
+}
+
+type recordingRequestLogger struct {
+	requests []RequestEvent
+	retries  []RetryEvent
+}
+
+func (logger *recordingRequestLogger) LogRequest(event RequestEvent) {
+	logger.requests = append(logger.requests, event)
+}
+
+func (logger *recordingRequestLogger) LogRetry(event RetryEvent) {
+	logger.retries = append(logger.retries, event)
+}
+
+func TestClientBuilder_RequestLogger(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	requestLogger := &recordingRequestLogger{}
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).
+		RetryPolicy(testRetryPolicy).RequestLogger(requestLogger).Build()
+	require.NoError(t, err)
+	require.NoError(t, client.TestConnection(context.Background()))
+
+	require.Len(t, requestLogger.requests, 2)
+	for i, expectedStatusCode := range []int{http.StatusServiceUnavailable, http.StatusOK} {
+		event := requestLogger.requests[i]
+		assert.Equal(t, http.MethodGet, event.Method)
+		assert.Equal(t, server.URL+"/zen", event.URL)
+		assert.Equal(t, i+1, event.Attempt)
+		assert.Equal(t, expectedStatusCode, event.StatusCode)
+		assert.Positive(t, event.Duration)
+		assert.NoError(t, event.Err)
+	}
+	require.Len(t, requestLogger.retries, 1)
+	assert.Equal(t, server.URL+"/zen", requestLogger.retries[0].URL)
+	assert.Equal(t, 2, requestLogger.retries[0].Attempt)
+	assert.Equal(t, testRetryPolicy.InitialBackoff, requestLogger.retries[0].Delay)
+
+	// Failed requests are reported with their error
+	server.Close()
+	requestLogger.requests = nil
+	assert.Error(t, client.TestConnection(context.Background()))
+	require.NotEmpty(t, requestLogger.requests)
+	assert.Zero(t, requestLogger.requests[0].StatusCode)
+	assert.Error(t, requestLogger.requests[0].Err)
+}
+
+func TestClientBuilder_TLS(t *testing.T) {
+	tests := []struct {
+		vcsProvider  vcsutils.VcsProvider
+		responseBody string
+	}{
+		{vcsutils.GitHub, "zen"},
+		{vcsutils.GitLab, "[]"},
+		{vcsutils.BitbucketServer, "{}"},
+		{vcsutils.BitbucketCloud, "{}"},
+		{vcsutils.Gitea, "{}"},
+	}
+	for _, test := range tests {
+		t.Run(test.vcsProvider.String(), func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+			rootCAs := x509.NewCertPool()
+			rootCAs.AddCert(server.Certificate())
+			caCertificates := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+			ctx := context.Background()
+			newBuilder := func() *ClientBuilder {
+				return NewClientBuilder(test.vcsProvider).ApiEndpoint(server.URL).Token(token)
+			}
+			// The certificate of the server isn't trusted by default
+			client, err := newBuilder().Build()
+			require.NoError(t, err)
+			assert.Error(t, client.TestConnection(ctx))
+
+			for _, builder := range []*ClientBuilder{
+				newBuilder().CACertificates(caCertificates),
+				newBuilder().RootCAs(rootCAs),
+				newBuilder().TLSConfig(&tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}),
+				newBuilder().InsecureSkipVerify(true),
+			} {
+				client, err = builder.Build()
+				require.NoError(t, err)
+				assert.NoError(t, client.TestConnection(ctx))
+			}
+		})
+	}
+
+	_, err := NewClientBuilder(vcsutils.GitHub).CACertificates([]byte("invalid")).Build()
+	assert.ErrorIs(t, err, errInvalidCACertificates)
+}
+
+func TestGetTLSConfig(t *testing.T) {
+	assert.Nil(t, getTLSConfig(VcsInfo{}))
+
+	// The TLS configuration of the VCS info isn't modified
+	tlsConfig := &tls.Config{ServerName: "example.com", MinVersion: tls.VersionTLS13}
+	rootCAs := x509.NewCertPool()
+	vcsInfo := VcsInfo{TLSConfig: tlsConfig, RootCAs: rootCAs, InsecureSkipVerify: true}
+	result := getTLSConfig(vcsInfo)
+	assert.Equal(t, "example.com", result.ServerName)
+	assert.Same(t, rootCAs, result.RootCAs)
+	assert.True(t, result.InsecureSkipVerify)
+	assert.Nil(t, tlsConfig.RootCAs)
+	assert.False(t, tlsConfig.InsecureSkipVerify)
+
+	// The Azure DevOps library creates its own HTTP clients, using the TLS configuration of the connection
+	azureClient, err := NewAzureReposClient(vcsInfo, EmptyLogger{})
+	require.NoError(t, err)
+	assert.Equal(t, result, azureClient.connectionDetails.TlsConfig)
+
+	// Custom transports are used as is
+	transport := &countingTransport{}
+	assert.Same(t, transport, newBaseHTTPClient(VcsInfo{Transport: transport, InsecureSkipVerify: true}).Transport)
+}
@@ -0,0 +1,732 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// instrumentedClient wraps the errors of a VcsClient with APIError, if enabled, and reports its calls to the Collector
+type instrumentedClient struct {
+	client        VcsClient
+	provider      vcsutils.VcsProvider
+	collector     Collector
+	wrapAPIErrors bool
+}
+
+// UnwrapClient returns the client of the VCS provider that was wrapped by ClientBuilder.Build, such as *GitHubClient,
+// when errors are wrapped with APIError or a collector is set. Other clients are returned as is.
+func UnwrapClient(client VcsClient) VcsClient {
+	if instrumented, ok := client.(*instrumentedClient); ok {
+		return instrumented.client
+	}
+	return client
+}
+
+// clientCall is a call of a VcsClient method
+type clientCall struct {
+	client    *instrumentedClient
+	operation string
+	start     time.Time
+	recorder  *responseRecorder
+}
+
+func (client *instrumentedClient) startCall(ctx context.Context, operation string) (context.Context, *clientCall) {
+	ctx, recorder := withResponseRecorder(ctx)
+	return ctx, &clientCall{client: client, operation: operation, start: time.Now(), recorder: recorder}
+}
+
+// end wraps the error returned by the call, if enabled, and reports the call to the Collector
+func (call *clientCall) end(err error) error {
+	wrappedErr := call.recorder.wrapError(err)
+	if call.client.wrapAPIErrors {
+		err = wrappedErr
+	}
+	if call.client.collector == nil {
+		return err
+	}
+	statusCode := call.recorder.lastStatusCode()
+	var apiErr *APIError
+	if statusCode == 0 && errors.As(wrappedErr, &apiErr) {
+		// The status of requests that aren't recorded is known from their errors
+		statusCode = apiErr.StatusCode
+	}
+	call.client.collector.CollectCall(CallMetrics{
+		Provider:   call.client.provider,
+		Operation:  call.operation,
+		StatusCode: statusCode,
+		Duration:   time.Since(call.start),
+		Err:        err,
+	})
+	return err
+}
+
+// instrumentPager instruments the fetching of each page as a call
+func instrumentPager[T any](client *instrumentedClient, operation string, pager *Pager[T]) *Pager[T] {
+	return newPager(func(ctx context.Context) ([]T, bool, error) {
+		ctx, call := client.startCall(ctx, operation)
+		page, hasNext, err := pager.fetchPage(ctx)
+		return page, hasNext, call.end(err)
+	})
+}
+
+func (client *instrumentedClient) TestConnection(ctx context.Context) error {
+	ctx, call := client.startCall(ctx, "TestConnection")
+	return call.end(client.client.TestConnection(ctx))
+}
+
+func (client *instrumentedClient) GetRateLimitInfo(ctx context.Context) (RateLimitInfo, error) {
+	ctx, call := client.startCall(ctx, "GetRateLimitInfo")
+	result, err := client.client.GetRateLimitInfo(ctx)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
+	ctx, call := client.startCall(ctx, "ListRepositories")
+	result, err := client.client.ListRepositories(ctx)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListRepositoriesWithOptions(ctx context.Context, owner string, options ListRepositoriesOptions) ([]RepositoryDetails, error) {
+	ctx, call := client.startCall(ctx, "ListRepositoriesWithOptions")
+	result, err := client.client.ListRepositoriesWithOptions(ctx, owner, options)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx, call := client.startCall(ctx, "ListBranches")
+	result, err := client.client.ListBranches(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListBranchesPager(owner, repository string, perPage int) *Pager[string] {
+	return instrumentPager(client, "ListBranchesPager", client.client.ListBranchesPager(owner, repository, perPage))
+}
+
+func (client *instrumentedClient) ListAllBranches(ctx context.Context, owner, repository, prefix string) ([]BranchDetails, error) {
+	ctx, call := client.startCall(ctx, "ListAllBranches")
+	result, err := client.client.ListAllBranches(ctx, owner, repository, prefix)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) CreateBranch(ctx context.Context, owner, repository, branchName, fromRef string) error {
+	ctx, call := client.startCall(ctx, "CreateBranch")
+	return call.end(client.client.CreateBranch(ctx, owner, repository, branchName, fromRef))
+}
+
+func (client *instrumentedClient) DeleteBranch(ctx context.Context, owner, repository, branchName string) error {
+	ctx, call := client.startCall(ctx, "DeleteBranch")
+	return call.end(client.client.DeleteBranch(ctx, owner, repository, branchName))
+}
+
+func (client *instrumentedClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	ctx, call := client.startCall(ctx, "ListTags")
+	result, err := client.client.ListTags(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) CreateTag(ctx context.Context, owner, repository, tagName, targetSha, message string) error {
+	ctx, call := client.startCall(ctx, "CreateTag")
+	return call.end(client.client.CreateTag(ctx, owner, repository, tagName, targetSha, message))
+}
+
+func (client *instrumentedClient) CreateRelease(ctx context.Context, owner, repository string, release ReleaseInfo) error {
+	ctx, call := client.startCall(ctx, "CreateRelease")
+	return call.end(client.client.CreateRelease(ctx, owner, repository, release))
+}
+
+func (client *instrumentedClient) GetLatestRelease(ctx context.Context, owner, repository string) (*ReleaseInfo, error) {
+	ctx, call := client.startCall(ctx, "GetLatestRelease")
+	result, err := client.client.GetLatestRelease(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) UploadReleaseAsset(ctx context.Context, owner, repository, tagName, assetPath string) error {
+	ctx, call := client.startCall(ctx, "UploadReleaseAsset")
+	return call.end(client.client.UploadReleaseAsset(ctx, owner, repository, tagName, assetPath))
+}
+
+func (client *instrumentedClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error) {
+	ctx, call := client.startCall(ctx, "GetBranchProtection")
+	result, err := client.client.GetBranchProtection(ctx, owner, repository, branch)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtection) error {
+	ctx, call := client.startCall(ctx, "SetBranchProtection")
+	return call.end(client.client.SetBranchProtection(ctx, owner, repository, branch, protection))
+}
+
+func (client *instrumentedClient) GetRequiredChecks(ctx context.Context, owner, repository, branch string) (RequiredChecksInfo, error) {
+	ctx, call := client.startCall(ctx, "GetRequiredChecks")
+	result, err := client.client.GetRequiredChecks(ctx, owner, repository, branch)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetRequiredApprovals(ctx context.Context, owner, repository, branch string) (RequiredApprovalsInfo, error) {
+	ctx, call := client.startCall(ctx, "GetRequiredApprovals")
+	result, err := client.client.GetRequiredApprovals(ctx, owner, repository, branch)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetTagProtection(ctx context.Context, owner, repository, pattern string) (*TagProtection, error) {
+	ctx, call := client.startCall(ctx, "GetTagProtection")
+	result, err := client.client.GetTagProtection(ctx, owner, repository, pattern)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) SetTagProtection(ctx context.Context, owner, repository, pattern string, protection TagProtection) error {
+	ctx, call := client.startCall(ctx, "SetTagProtection")
+	return call.end(client.client.SetTagProtection(ctx, owner, repository, pattern, protection))
+}
+
+func (client *instrumentedClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	ctx, call := client.startCall(ctx, "CreateWebhook")
+	result1, result2, err := client.client.CreateWebhook(ctx, owner, repository, branch, payloadURL, webhookEvents...)
+	return result1, result2, call.end(err)
+}
+
+func (client *instrumentedClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token, webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	ctx, call := client.startCall(ctx, "UpdateWebhook")
+	return call.end(client.client.UpdateWebhook(ctx, owner, repository, branch, payloadURL, token, webhookID, webhookEvents...))
+}
+
+func (client *instrumentedClient) CreateWebhookWithOptions(ctx context.Context, owner, repository string, options WebhookOptions) (WebhookInfo, string, error) {
+	ctx, call := client.startCall(ctx, "CreateWebhookWithOptions")
+	webhook, token, err := client.client.CreateWebhookWithOptions(ctx, owner, repository, options)
+	return webhook, token, call.end(err)
+}
+
+func (client *instrumentedClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	ctx, call := client.startCall(ctx, "DeleteWebhook")
+	return call.end(client.client.DeleteWebhook(ctx, owner, repository, webhookID))
+}
+
+func (client *instrumentedClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	ctx, call := client.startCall(ctx, "ListWebhooks")
+	webhooks, err := client.client.ListWebhooks(ctx, owner, repository)
+	return webhooks, call.end(err)
+}
+
+func (client *instrumentedClient) SetWebhookActive(ctx context.Context, owner, repository, webhookID string, active bool) error {
+	ctx, call := client.startCall(ctx, "SetWebhookActive")
+	return call.end(client.client.SetWebhookActive(ctx, owner, repository, webhookID, active))
+}
+
+func (client *instrumentedClient) CreateOrgWebhook(ctx context.Context, owner string, options WebhookOptions) (WebhookInfo, string, error) {
+	ctx, call := client.startCall(ctx, "CreateOrgWebhook")
+	webhook, token, err := client.client.CreateOrgWebhook(ctx, owner, options)
+	return webhook, token, call.end(err)
+}
+
+func (client *instrumentedClient) ListOrgWebhooks(ctx context.Context, owner string) ([]WebhookInfo, error) {
+	ctx, call := client.startCall(ctx, "ListOrgWebhooks")
+	webhooks, err := client.client.ListOrgWebhooks(ctx, owner)
+	return webhooks, call.end(err)
+}
+
+func (client *instrumentedClient) DeleteOrgWebhook(ctx context.Context, owner, webhookID string) error {
+	ctx, call := client.startCall(ctx, "DeleteOrgWebhook")
+	return call.end(client.client.DeleteOrgWebhook(ctx, owner, webhookID))
+}
+
+func (client *instrumentedClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error {
+	ctx, call := client.startCall(ctx, "SetCommitStatus")
+	return call.end(client.client.SetCommitStatus(ctx, commitStatus, owner, repository, ref, title, description, detailsURL))
+}
+
+func (client *instrumentedClient) SetCommitStatuses(ctx context.Context, owner, repository, ref string, statuses []CommitStatusInfo) error {
+	ctx, call := client.startCall(ctx, "SetCommitStatuses")
+	return call.end(client.client.SetCommitStatuses(ctx, owner, repository, ref, statuses))
+}
+
+func (client *instrumentedClient) CreateCheckRun(ctx context.Context, owner, repository, ref string, checkRun CheckRunInfo) (int64, error) {
+	ctx, call := client.startCall(ctx, "CreateCheckRun")
+	result, err := client.client.CreateCheckRun(ctx, owner, repository, ref, checkRun)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) UpdateCheckRun(ctx context.Context, owner, repository, ref string, checkRunID int64, checkRun CheckRunInfo) error {
+	ctx, call := client.startCall(ctx, "UpdateCheckRun")
+	return call.end(client.client.UpdateCheckRun(ctx, owner, repository, ref, checkRunID, checkRun))
+}
+
+func (client *instrumentedClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, sha string, report CodeInsightsReport) error {
+	ctx, call := client.startCall(ctx, "CreateCodeInsightsReport")
+	return call.end(client.client.CreateCodeInsightsReport(ctx, owner, repository, sha, report))
+}
+
+func (client *instrumentedClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, sha, reportID string, annotations []CodeInsightsAnnotation) error {
+	ctx, call := client.startCall(ctx, "AddCodeInsightsAnnotations")
+	return call.end(client.client.AddCodeInsightsAnnotations(ctx, owner, repository, sha, reportID, annotations))
+}
+
+func (client *instrumentedClient) TriggerPipeline(ctx context.Context, owner, repository, pipeline, ref string, inputs map[string]string) (PipelineInfo, error) {
+	ctx, call := client.startCall(ctx, "TriggerPipeline")
+	result, err := client.client.TriggerPipeline(ctx, owner, repository, pipeline, ref, inputs)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetPipelineStatus(ctx context.Context, owner, repository, runID string) (PipelineInfo, error) {
+	ctx, call := client.startCall(ctx, "GetPipelineStatus")
+	result, err := client.client.GetPipelineStatus(ctx, owner, repository, runID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListCommitStatuses(ctx context.Context, owner, repository, ref string) ([]CommitStatusInfo, error) {
+	ctx, call := client.startCall(ctx, "ListCommitStatuses")
+	result, err := client.client.ListCommitStatuses(ctx, owner, repository, ref)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	ctx, call := client.startCall(ctx, "DownloadRepository")
+	return call.end(client.client.DownloadRepository(ctx, owner, repository, branch, localPath))
+}
+
+func (client *instrumentedClient) DownloadRepositoryAtRef(ctx context.Context, owner, repository, ref, localPath string, format ArchiveFormat) error {
+	ctx, call := client.startCall(ctx, "DownloadRepositoryAtRef")
+	return call.end(client.client.DownloadRepositoryAtRef(ctx, owner, repository, ref, localPath, format))
+}
+
+func (client *instrumentedClient) GetRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat) (io.ReadCloser, error) {
+	ctx, call := client.startCall(ctx, "GetRepositoryArchive")
+	result, err := client.client.GetRepositoryArchive(ctx, owner, repository, ref, format)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) DownloadRepositoryPath(ctx context.Context, owner, repository, ref, path, localPath string) error {
+	ctx, call := client.startCall(ctx, "DownloadRepositoryPath")
+	return call.end(client.client.DownloadRepositoryPath(ctx, owner, repository, ref, path, localPath))
+}
+
+func (client *instrumentedClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error {
+	ctx, call := client.startCall(ctx, "CreatePullRequest")
+	return call.end(client.client.CreatePullRequest(ctx, owner, repository, sourceBranch, targetBranch, title, description))
+}
+
+func (client *instrumentedClient) CreateDraftPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error {
+	ctx, call := client.startCall(ctx, "CreateDraftPullRequest")
+	return call.end(client.client.CreateDraftPullRequest(ctx, owner, repository, sourceBranch, targetBranch, title, description))
+}
+
+func (client *instrumentedClient) MarkPullRequestReady(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx, call := client.startCall(ctx, "MarkPullRequestReady")
+	return call.end(client.client.MarkPullRequestReady(ctx, owner, repository, pullRequestID))
+}
+
+func (client *instrumentedClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy, commitMessage string) error {
+	ctx, call := client.startCall(ctx, "MergePullRequest")
+	return call.end(client.client.MergePullRequest(ctx, owner, repository, pullRequestID, mergeStrategy, commitMessage))
+}
+
+func (client *instrumentedClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy) error {
+	ctx, call := client.startCall(ctx, "EnablePullRequestAutoMerge")
+	return call.end(client.client.EnablePullRequestAutoMerge(ctx, owner, repository, pullRequestID, mergeStrategy))
+}
+
+func (client *instrumentedClient) DisablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx, call := client.startCall(ctx, "DisablePullRequestAutoMerge")
+	return call.end(client.client.DisablePullRequestAutoMerge(ctx, owner, repository, pullRequestID))
+}
+
+func (client *instrumentedClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranch string, pullRequestID int, state *PullRequestState) error {
+	ctx, call := client.startCall(ctx, "UpdatePullRequest")
+	return call.end(client.client.UpdatePullRequest(ctx, owner, repository, title, body, targetBranch, pullRequestID, state))
+}
+
+func (client *instrumentedClient) ApprovePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx, call := client.startCall(ctx, "ApprovePullRequest")
+	return call.end(client.client.ApprovePullRequest(ctx, owner, repository, pullRequestID))
+}
+
+func (client *instrumentedClient) SubmitPullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, reviewEvent ReviewEvent, body string) error {
+	ctx, call := client.startCall(ctx, "SubmitPullRequestReview")
+	return call.end(client.client.SubmitPullRequestReview(ctx, owner, repository, pullRequestID, reviewEvent, body))
+}
+
+func (client *instrumentedClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	ctx, call := client.startCall(ctx, "AddPullRequestComment")
+	return call.end(client.client.AddPullRequestComment(ctx, owner, repository, content, pullRequestID))
+}
+
+func (client *instrumentedClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	ctx, call := client.startCall(ctx, "ListPullRequestComments")
+	result, err := client.client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int, commentID int64) error {
+	ctx, call := client.startCall(ctx, "UpdatePullRequestComment")
+	return call.end(client.client.UpdatePullRequestComment(ctx, owner, repository, content, pullRequestID, commentID))
+}
+
+func (client *instrumentedClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) error {
+	ctx, call := client.startCall(ctx, "DeletePullRequestComment")
+	return call.end(client.client.DeletePullRequestComment(ctx, owner, repository, pullRequestID, commentID))
+}
+
+func (client *instrumentedClient) AddCommentReaction(ctx context.Context, owner, repository string, pullRequestID int, commentID int64, reaction Reaction) error {
+	ctx, call := client.startCall(ctx, "AddCommentReaction")
+	return call.end(client.client.AddCommentReaction(ctx, owner, repository, pullRequestID, commentID, reaction))
+}
+
+func (client *instrumentedClient) ListCommentReactions(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) ([]ReactionInfo, error) {
+	ctx, call := client.startCall(ctx, "ListCommentReactions")
+	result, err := client.client.ListCommentReactions(ctx, owner, repository, pullRequestID, commentID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) AddPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) error {
+	ctx, call := client.startCall(ctx, "AddPullRequestReviewComment")
+	return call.end(client.client.AddPullRequestReviewComment(ctx, owner, repository, pullRequestID, comment))
+}
+
+func (client *instrumentedClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewComment, error) {
+	ctx, call := client.startCall(ctx, "ListPullRequestReviewComments")
+	result, err := client.client.ListPullRequestReviewComments(ctx, owner, repository, pullRequestID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) CreatePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (string, error) {
+	ctx, call := client.startCall(ctx, "CreatePullRequestThread")
+	result, err := client.client.CreatePullRequestThread(ctx, owner, repository, pullRequestID, comment)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ResolvePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	ctx, call := client.startCall(ctx, "ResolvePullRequestThread")
+	return call.end(client.client.ResolvePullRequestThread(ctx, owner, repository, pullRequestID, threadID, resolved))
+}
+
+func (client *instrumentedClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	ctx, call := client.startCall(ctx, "ListOpenPullRequests")
+	result, err := client.client.ListOpenPullRequests(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListOpenPullRequestsWithFilter(ctx context.Context, owner, repository string, filter PullRequestFilter) ([]PullRequestInfo, error) {
+	ctx, call := client.startCall(ctx, "ListOpenPullRequestsWithFilter")
+	result, err := client.client.ListOpenPullRequestsWithFilter(ctx, owner, repository, filter)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListOpenPullRequestsPager(owner, repository string, filter PullRequestFilter) *Pager[PullRequestInfo] {
+	return instrumentPager(client, "ListOpenPullRequestsPager", client.client.ListOpenPullRequestsPager(owner, repository, filter))
+}
+
+func (client *instrumentedClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestInfo, error) {
+	ctx, call := client.startCall(ctx, "GetPullRequestByID")
+	result, err := client.client.GetPullRequestByID(ctx, owner, repository, pullRequestID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestDetails, error) {
+	ctx, call := client.startCall(ctx, "GetPullRequestDetails")
+	result, err := client.client.GetPullRequestDetails(ctx, owner, repository, pullRequestID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	ctx, call := client.startCall(ctx, "ListPullRequestFiles")
+	result, err := client.client.ListPullRequestFiles(ctx, owner, repository, pullRequestID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetPullRequestMergeableState(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestMergeableState, error) {
+	ctx, call := client.startCall(ctx, "GetPullRequestMergeableState")
+	result, err := client.client.GetPullRequestMergeableState(ctx, owner, repository, pullRequestID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	ctx, call := client.startCall(ctx, "GetPullRequestDiff")
+	result, err := client.client.GetPullRequestDiff(ctx, owner, repository, pullRequestID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListPullRequestCommits(ctx context.Context, owner, repository string, pullRequestID int) ([]CommitInfo, error) {
+	ctx, call := client.startCall(ctx, "ListPullRequestCommits")
+	result, err := client.client.ListPullRequestCommits(ctx, owner, repository, pullRequestID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetDefaultReviewers(ctx context.Context, owner, repository, sourceBranch, targetBranch string) ([]string, error) {
+	ctx, call := client.startCall(ctx, "GetDefaultReviewers")
+	result, err := client.client.GetDefaultReviewers(ctx, owner, repository, sourceBranch, targetBranch)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	ctx, call := client.startCall(ctx, "GetLatestCommit")
+	result, err := client.client.GetLatestCommit(ctx, owner, repository, branch)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
+	ctx, call := client.startCall(ctx, "AddSshKeyToRepository")
+	return call.end(client.client.AddSshKeyToRepository(ctx, owner, repository, keyName, publicKey, permission))
+}
+
+func (client *instrumentedClient) ListDeployKeys(ctx context.Context, owner, repository string) ([]DeployKeyInfo, error) {
+	ctx, call := client.startCall(ctx, "ListDeployKeys")
+	result, err := client.client.ListDeployKeys(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) AddDeployKey(ctx context.Context, owner, repository, title, key string, readOnly bool) (DeployKeyInfo, error) {
+	ctx, call := client.startCall(ctx, "AddDeployKey")
+	result, err := client.client.AddDeployKey(ctx, owner, repository, title, key, readOnly)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) DeleteDeployKey(ctx context.Context, owner, repository string, keyID int) error {
+	ctx, call := client.startCall(ctx, "DeleteDeployKey")
+	return call.end(client.client.DeleteDeployKey(ctx, owner, repository, keyID))
+}
+
+func (client *instrumentedClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	ctx, call := client.startCall(ctx, "GetRepositoryInfo")
+	result, err := client.client.GetRepositoryInfo(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetRepositoryLanguages(ctx context.Context, owner, repository string) (map[string]float64, error) {
+	ctx, call := client.startCall(ctx, "GetRepositoryLanguages")
+	result, err := client.client.GetRepositoryLanguages(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) CreateRepository(ctx context.Context, owner, name string, options CreateRepositoryOptions) error {
+	ctx, call := client.startCall(ctx, "CreateRepository")
+	return call.end(client.client.CreateRepository(ctx, owner, name, options))
+}
+
+func (client *instrumentedClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	ctx, call := client.startCall(ctx, "DeleteRepository")
+	return call.end(client.client.DeleteRepository(ctx, owner, repository))
+}
+
+func (client *instrumentedClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string, waitUntilReady bool) (ForkInfo, error) {
+	ctx, call := client.startCall(ctx, "ForkRepository")
+	forkInfo, err := client.client.ForkRepository(ctx, owner, repository, targetOwner, waitUntilReady)
+	return forkInfo, call.end(err)
+}
+
+func (client *instrumentedClient) ListCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	ctx, call := client.startCall(ctx, "ListCollaborators")
+	result, err := client.client.ListCollaborators(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) AddCollaborator(ctx context.Context, owner, repository, username string, permission RepositoryPermission) error {
+	ctx, call := client.startCall(ctx, "AddCollaborator")
+	return call.end(client.client.AddCollaborator(ctx, owner, repository, username, permission))
+}
+
+func (client *instrumentedClient) RemoveCollaborator(ctx context.Context, owner, repository, username string) error {
+	ctx, call := client.startCall(ctx, "RemoveCollaborator")
+	return call.end(client.client.RemoveCollaborator(ctx, owner, repository, username))
+}
+
+func (client *instrumentedClient) GetUserPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	ctx, call := client.startCall(ctx, "GetUserPermission")
+	result, err := client.client.GetUserPermission(ctx, owner, repository, username)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	ctx, call := client.startCall(ctx, "GetCommitBySha")
+	result, err := client.client.GetCommitBySha(ctx, owner, repository, sha)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	ctx, call := client.startCall(ctx, "ListCommits")
+	result, err := client.client.ListCommits(ctx, owner, repository, options)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetCommit(ctx context.Context, owner, repository, sha string) (CommitDetails, error) {
+	ctx, call := client.startCall(ctx, "GetCommit")
+	result, err := client.client.GetCommit(ctx, owner, repository, sha)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetCommitVerification(ctx context.Context, owner, repository, sha string) (CommitVerificationInfo, error) {
+	ctx, call := client.startCall(ctx, "GetCommitVerification")
+	result, err := client.client.GetCommitVerification(ctx, owner, repository, sha)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	ctx, call := client.startCall(ctx, "CompareCommits")
+	result, err := client.client.CompareCommits(ctx, owner, repository, base, head)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetCommitsBetween(ctx context.Context, owner, repository, fromSha, toSha string) ([]CommitInfo, error) {
+	ctx, call := client.startCall(ctx, "GetCommitsBetween")
+	result, err := client.client.GetCommitsBetween(ctx, owner, repository, fromSha, toSha)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListContributors(ctx context.Context, owner, repository string) ([]ContributorInfo, error) {
+	ctx, call := client.startCall(ctx, "ListContributors")
+	result, err := client.client.ListContributors(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetCommitActivity(ctx context.Context, owner, repository string, since time.Time) ([]CommitActivityInfo, error) {
+	ctx, call := client.startCall(ctx, "GetCommitActivity")
+	result, err := client.client.GetCommitActivity(ctx, owner, repository, since)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	ctx, call := client.startCall(ctx, "CreateLabel")
+	return call.end(client.client.CreateLabel(ctx, owner, repository, labelInfo))
+}
+
+func (client *instrumentedClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	ctx, call := client.startCall(ctx, "GetLabel")
+	result, err := client.client.GetLabel(ctx, owner, repository, name)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	ctx, call := client.startCall(ctx, "ListLabels")
+	result, err := client.client.ListLabels(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListLabelsPager(owner, repository string, perPage int) *Pager[LabelInfo] {
+	return instrumentPager(client, "ListLabelsPager", client.client.ListLabelsPager(owner, repository, perPage))
+}
+
+func (client *instrumentedClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	ctx, call := client.startCall(ctx, "UpdateLabel")
+	return call.end(client.client.UpdateLabel(ctx, owner, repository, name, labelInfo))
+}
+
+func (client *instrumentedClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	ctx, call := client.startCall(ctx, "DeleteLabel")
+	return call.end(client.client.DeleteLabel(ctx, owner, repository, name))
+}
+
+func (client *instrumentedClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	ctx, call := client.startCall(ctx, "ListPullRequestLabels")
+	result, err := client.client.ListPullRequestLabels(ctx, owner, repository, pullRequestID)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	ctx, call := client.startCall(ctx, "UnlabelPullRequest")
+	return call.end(client.client.UnlabelPullRequest(ctx, owner, repository, name, pullRequestID))
+}
+
+func (client *instrumentedClient) CreateIssue(ctx context.Context, owner, repository, title, body string, labels []string) (IssueInfo, error) {
+	ctx, call := client.startCall(ctx, "CreateIssue")
+	result, err := client.client.CreateIssue(ctx, owner, repository, title, body, labels)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	ctx, call := client.startCall(ctx, "AddIssueComment")
+	return call.end(client.client.AddIssueComment(ctx, owner, repository, content, issueID))
+}
+
+func (client *instrumentedClient) ListIssues(ctx context.Context, owner, repository string, filter IssueFilter) ([]IssueInfo, error) {
+	ctx, call := client.startCall(ctx, "ListIssues")
+	result, err := client.client.ListIssues(ctx, owner, repository, filter)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) UpdateIssueState(ctx context.Context, owner, repository string, issueID int, state IssueState) error {
+	ctx, call := client.startCall(ctx, "UpdateIssueState")
+	return call.end(client.client.UpdateIssueState(ctx, owner, repository, issueID, state))
+}
+
+func (client *instrumentedClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	ctx, call := client.startCall(ctx, "UploadCodeScanning")
+	result, err := client.client.UploadCodeScanning(ctx, owner, repository, branch, scanResults)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) UploadScanResults(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	ctx, call := client.startCall(ctx, "UploadScanResults")
+	result, err := client.client.UploadScanResults(ctx, owner, repository, branch, scanResults)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListSecurityAlerts(ctx context.Context, owner, repository string) ([]SecurityAlertInfo, error) {
+	ctx, call := client.startCall(ctx, "ListSecurityAlerts")
+	result, err := client.client.ListSecurityAlerts(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	ctx, call := client.startCall(ctx, "DownloadFileFromRepo")
+	result1, result2, err := client.client.DownloadFileFromRepo(ctx, owner, repository, branch, path)
+	return result1, result2, call.end(err)
+}
+
+func (client *instrumentedClient) GetFileContent(ctx context.Context, owner, repository, ref, path string) (FileContent, error) {
+	ctx, call := client.startCall(ctx, "GetFileContent")
+	result, err := client.client.GetFileContent(ctx, owner, repository, ref, path)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) GetFileBlame(ctx context.Context, owner, repository, ref, path string) ([]BlameLine, error) {
+	ctx, call := client.startCall(ctx, "GetFileBlame")
+	result, err := client.client.GetFileBlame(ctx, owner, repository, ref, path)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListRepositoryTree(ctx context.Context, owner, repository, ref string, recursive bool) ([]TreeEntry, error) {
+	ctx, call := client.startCall(ctx, "ListRepositoryTree")
+	result, err := client.client.ListRepositoryTree(ctx, owner, repository, ref, recursive)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) SearchCode(ctx context.Context, scope CodeSearchScope, query string) ([]CodeSearchResult, error) {
+	ctx, call := client.startCall(ctx, "SearchCode")
+	result, err := client.client.SearchCode(ctx, scope, query)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) CreateOrUpdateFile(ctx context.Context, owner, repository, branch, path string, content []byte, commitMessage string) error {
+	ctx, call := client.startCall(ctx, "CreateOrUpdateFile")
+	return call.end(client.client.CreateOrUpdateFile(ctx, owner, repository, branch, path, content, commitMessage))
+}
+
+func (client *instrumentedClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, changes []FileChange) error {
+	ctx, call := client.startCall(ctx, "CommitFiles")
+	return call.end(client.client.CommitFiles(ctx, owner, repository, branch, commitMessage, changes))
+}
+
+func (client *instrumentedClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	ctx, call := client.startCall(ctx, "GetRepositoryEnvironmentInfo")
+	result, err := client.client.GetRepositoryEnvironmentInfo(ctx, owner, repository, name)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
+	ctx, call := client.startCall(ctx, "ListEnvironments")
+	result, err := client.client.ListEnvironments(ctx, owner, repository)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) CreateDeployment(ctx context.Context, owner, repository string, deployment DeploymentInfo) (int64, error) {
+	ctx, call := client.startCall(ctx, "CreateDeployment")
+	result, err := client.client.CreateDeployment(ctx, owner, repository, deployment)
+	return result, call.end(err)
+}
+
+func (client *instrumentedClient) SetDeploymentStatus(ctx context.Context, owner, repository string, deploymentID int64, status DeploymentStatusInfo) error {
+	ctx, call := client.startCall(ctx, "SetDeploymentStatus")
+	return call.end(client.client.SetDeploymentStatus(ctx, owner, repository, deploymentID, status))
+}
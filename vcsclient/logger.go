@@ -1,5 +1,7 @@
 package vcsclient
 
+import "time"
+
 type Log interface {
 	Debug(a ...interface{})
 	Info(a ...interface{})
@@ -24,3 +26,37 @@ func (el EmptyLogger) Error(_ ...interface{}) {
 
 func (el EmptyLogger) Output(_ ...interface{}) {
 }
+
+// RequestLogger receives the events of the HTTP requests sent to the VCS provider
+type RequestLogger interface {
+	// LogRequest is called after each attempt of a request
+	LogRequest(event RequestEvent)
+	// LogRetry is called before a request is retried
+	LogRetry(event RetryEvent)
+}
+
+// RequestEvent describes an attempt of an HTTP request sent to the VCS provider
+type RequestEvent struct {
+	Method string
+	// The URL of the request, without the password
+	URL string
+	// The number of the attempt, starting from 1
+	Attempt int
+	// The status code of the response, or zero if no response was received
+	StatusCode int
+	// The time from sending the request until receiving the response headers
+	Duration time.Duration
+	// The error of the request, if no response was received
+	Err error
+}
+
+// RetryEvent describes a retry of an HTTP request sent to the VCS provider
+type RetryEvent struct {
+	Method string
+	// The URL of the request, without the password
+	URL string
+	// The number of the next attempt
+	Attempt int
+	// The delay before the next attempt
+	Delay time.Duration
+}
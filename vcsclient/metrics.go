@@ -0,0 +1,25 @@
+package vcsclient
+
+import (
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// Collector receives the metrics of the VcsClient method calls, for example to update Prometheus counters and histograms
+type Collector interface {
+	// CollectCall is called after each VcsClient method call, and after fetching each page of a Pager
+	CollectCall(metrics CallMetrics)
+}
+
+// CallMetrics are the metrics of a VcsClient method call
+type CallMetrics struct {
+	Provider vcsutils.VcsProvider
+	// The name of the VcsClient method, such as "ListBranches"
+	Operation string
+	// The status code of the last response received by the call, or zero if no response was received
+	StatusCode int
+	Duration   time.Duration
+	// The error returned by the call
+	Err error
+}
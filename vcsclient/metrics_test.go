@@ -0,0 +1,79 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingCollector struct {
+	calls []CallMetrics
+}
+
+func (collector *recordingCollector) CollectCall(metrics CallMetrics) {
+	collector.calls = append(collector.calls, metrics)
+}
+
+func TestClientBuilder_Collector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI != "/zen" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	collector := &recordingCollector{}
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).Collector(collector).Build()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, client.TestConnection(ctx))
+	_, err = client.GetLatestCommit(ctx, owner, repo1, "master")
+	// The errors aren't wrapped with APIError, unless enabled using WrapAPIErrors
+	var apiErr *APIError
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &apiErr))
+	_, err = client.ListBranchesPager(owner, repo1, 10).Next(ctx)
+	assert.Error(t, err)
+
+	require.Len(t, collector.calls, 3)
+	for i, expected := range []struct {
+		operation  string
+		statusCode int
+	}{
+		{"TestConnection", http.StatusOK},
+		{"GetLatestCommit", http.StatusNotFound},
+		{"ListBranchesPager", http.StatusNotFound},
+	} {
+		call := collector.calls[i]
+		assert.Equal(t, vcsutils.GitHub, call.Provider)
+		assert.Equal(t, expected.operation, call.Operation)
+		assert.Equal(t, expected.statusCode, call.StatusCode)
+		assert.Positive(t, call.Duration)
+		assert.Equal(t, expected.statusCode != http.StatusOK, call.Err != nil)
+	}
+}
+
+func TestClientBuilder_CollectorOfUnrecordedRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	collector := &recordingCollector{}
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).ApiEndpoint(server.URL).Username(username).Token(token).Collector(collector).WrapAPIErrors(true).Build()
+	require.NoError(t, err)
+	_, err = client.GetRepositoryInfo(context.Background(), owner, repo1)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.Len(t, collector.calls, 1)
+	assert.Equal(t, vcsutils.BitbucketCloud, collector.calls[0].Provider)
+	assert.Equal(t, "GetRepositoryInfo", collector.calls[0].Operation)
+	assert.Equal(t, http.StatusNotFound, collector.calls[0].StatusCode)
+}
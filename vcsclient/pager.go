@@ -0,0 +1,71 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoMorePages is returned by Pager.Next after the last page was fetched
+var ErrNoMorePages = errors.New("no more pages")
+
+// fetchPageFunc fetches the next page of a list operation, and reports whether more pages follow it
+type fetchPageFunc[T any] func(ctx context.Context) (page []T, hasNext bool, err error)
+
+// Pager iterates over the pages of a list operation.
+// Each page is fetched from the VCS provider only when Next is called, so callers can stop early without fetching the remaining pages.
+type Pager[T any] struct {
+	fetchPage fetchPageFunc[T]
+	done      bool
+}
+
+func newPager[T any](fetchPage fetchPageFunc[T]) *Pager[T] {
+	return &Pager[T]{fetchPage: fetchPage}
+}
+
+// HasNext checks whether there are more pages to fetch
+func (pager *Pager[T]) HasNext() bool {
+	return !pager.done
+}
+
+// Next fetches the next page. Returns ErrNoMorePages if all the pages were already fetched.
+// If fetching the page fails, calling Next again retries the same page.
+func (pager *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if pager.done {
+		return nil, ErrNoMorePages
+	}
+	page, hasNext, err := pager.fetchPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pager.done = !hasNext
+	return page, nil
+}
+
+// All fetches the remaining pages and returns their items
+func (pager *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var results []T
+	for pager.HasNext() {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, page...)
+	}
+	return results, nil
+}
+
+// listPageOrAll returns the requested 1-based page, or all the pages if the page is 0
+func listPageOrAll[T any](ctx context.Context, pager *Pager[T], page int) ([]T, error) {
+	if page > 0 {
+		return pager.Next(ctx)
+	}
+	return pager.All(ctx)
+}
+
+// firstPage returns the first page to fetch, which is the requested page or 1 when all the pages are requested
+func firstPage(page int) int {
+	if page > 0 {
+		return page
+	}
+	return 1
+}
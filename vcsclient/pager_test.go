@@ -0,0 +1,96 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPager(t *testing.T) {
+	ctx := context.Background()
+	pages := [][]int{{1, 2}, {3}}
+	fetchErr := errors.New("fetch failed")
+	fetchCalls := 0
+	pager := newPager(func(ctx context.Context) ([]int, bool, error) {
+		fetchCalls++
+		if fetchCalls == 2 {
+			return nil, false, fetchErr
+		}
+		page := pages[0]
+		pages = pages[1:]
+		return page, len(pages) > 0, nil
+	})
+
+	assert.True(t, pager.HasNext())
+	page, err := pager.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, page)
+
+	// A failed fetch keeps the pager at the same page
+	_, err = pager.Next(ctx)
+	assert.ErrorIs(t, err, fetchErr)
+	assert.True(t, pager.HasNext())
+
+	page, err = pager.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, page)
+	assert.False(t, pager.HasNext())
+
+	_, err = pager.Next(ctx)
+	assert.ErrorIs(t, err, ErrNoMorePages)
+	assert.Equal(t, 3, fetchCalls)
+}
+
+func TestPager_All(t *testing.T) {
+	ctx := context.Background()
+	nextPage := 1
+	pager := newPager(func(ctx context.Context) ([]int, bool, error) {
+		page := []int{nextPage * 10, nextPage*10 + 1}
+		nextPage++
+		return page, nextPage <= 3, nil
+	})
+
+	// Items of pages that were already fetched aren't returned
+	_, err := pager.Next(ctx)
+	require.NoError(t, err)
+	results, err := pager.All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int{20, 21, 30, 31}, results)
+
+	results, err = pager.All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	failingPager := newPager(func(ctx context.Context) ([]int, bool, error) {
+		return nil, false, errors.New("fetch failed")
+	})
+	_, err = failingPager.All(ctx)
+	assert.Error(t, err)
+}
+
+func TestListPageOrAll(t *testing.T) {
+	ctx := context.Background()
+	newTestPager := func() *Pager[int] {
+		nextPage := 1
+		return newPager(func(ctx context.Context) ([]int, bool, error) {
+			page := []int{nextPage}
+			nextPage++
+			return page, nextPage <= 3, nil
+		})
+	}
+
+	// The pagers of the clients start at the requested page, so only the first fetched page is returned
+	results, err := listPageOrAll(ctx, newTestPager(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, results)
+
+	results, err = listPageOrAll(ctx, newTestPager(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, results)
+
+	assert.Equal(t, 1, firstPage(0))
+	assert.Equal(t, 2, firstPage(2))
+}
@@ -0,0 +1,107 @@
+package vcsclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter throttles the requests sent to the VCS provider. *rate.Limiter of golang.org/x/time/rate implements it.
+// The same limiter can be shared by several clients, to throttle their requests together.
+type RateLimiter interface {
+	// Wait blocks until a request may be sent, or fails if the context is done first
+	Wait(ctx context.Context) error
+}
+
+// The default request rates and bursts, which stay below the rate limits and the abuse detection of the VCS providers.
+// The rates are in tokens per second, where each request consumes one token, except for the mutating requests on GitHub.
+var defaultRateLimits = map[vcsutils.VcsProvider]struct {
+	limit rate.Limit
+	burst int
+	// The tokens consumed by each mutating request, such as POST, PUT, PATCH and DELETE requests
+	mutatingRequestWeight int
+}{
+	// GitHub's secondary rate limits allow up to 900 points per minute, where GET requests cost 1 point and mutating requests cost 5,
+	// and up to 80 content-creating requests per minute. Mutating requests consume 5 tokens, so 6 tokens per second allow
+	// up to 360 points per minute, and up to 72 mutating requests per minute.
+	vcsutils.GitHub: {rate.Limit(6), 10, 5},
+	// GitLab.com allows up to 2000 requests per minute to authenticated users
+	vcsutils.GitLab: {rate.Limit(30), 30, 1},
+	// Bitbucket Data Center's token bucket refills 5 tokens per second, up to 60 tokens
+	vcsutils.BitbucketServer: {rate.Limit(5), 60, 1},
+	// Bitbucket cloud allows up to 1000 requests per hour to the repository endpoints
+	vcsutils.BitbucketCloud: {rate.Every(time.Hour / 1000), 10, 1},
+	vcsutils.AzureRepos:     {rate.Limit(10), 20, 1},
+	vcsutils.Gitea:          {rate.Limit(10), 20, 1},
+	// AWS CodeCommit throttles most of its operations above 10 requests per second
+	vcsutils.AWSCodeCommit: {rate.Limit(10), 10, 1},
+}
+
+// NewDefaultRateLimiter creates a rate limiter with the default request rate and burst of the VCS provider,
+// to be set using ClientBuilder.RateLimiter. On GitHub, mutating requests consume 5 times the tokens of GET requests,
+// like they cost 5 times the points of GitHub's secondary rate limits.
+func NewDefaultRateLimiter(vcsProvider vcsutils.VcsProvider) RateLimiter {
+	defaultRateLimit, exists := defaultRateLimits[vcsProvider]
+	if !exists {
+		return &weightedRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 10), mutatingRequestWeight: 1}
+	}
+	return &weightedRateLimiter{
+		Limiter:               rate.NewLimiter(defaultRateLimit.limit, defaultRateLimit.burst),
+		mutatingRequestWeight: defaultRateLimit.mutatingRequestWeight,
+	}
+}
+
+// requestRateLimiter is a RateLimiter that throttles each request according to its cost
+type requestRateLimiter interface {
+	// WaitRequest blocks until the request may be sent, or fails if its context is done first
+	WaitRequest(req *http.Request) error
+}
+
+// weightedRateLimiter is a rate limiter where mutating requests consume more tokens than the other requests
+type weightedRateLimiter struct {
+	*rate.Limiter
+	mutatingRequestWeight int
+}
+
+func (limiter *weightedRateLimiter) WaitRequest(req *http.Request) error {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return limiter.Wait(req.Context())
+	}
+	return limiter.WaitN(req.Context(), limiter.mutatingRequestWeight)
+}
+
+// rateLimitedTransport is an http.RoundTripper that waits for the RateLimiter before sending each request
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter RateLimiter
+}
+
+// withRateLimiter returns a transport that waits for the rate limiter before sending each request using the base transport
+func withRateLimiter(base http.RoundTripper, limiter RateLimiter) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitedTransport{base: base, limiter: limiter}
+}
+
+func (transport *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := transport.wait(req); err != nil {
+		// Round trippers must close the body of the request, even on errors
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	return transport.base.RoundTrip(req)
+}
+
+func (transport *rateLimitedTransport) wait(req *http.Request) error {
+	if limiter, ok := transport.limiter.(requestRateLimiter); ok {
+		return limiter.WaitRequest(req)
+	}
+	return transport.limiter.Wait(req.Context())
+}
@@ -0,0 +1,110 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// countingRateLimiter counts the requests it's consulted for, and fails them with its error, if set
+type countingRateLimiter struct {
+	waits int32
+	err   error
+}
+
+func (limiter *countingRateLimiter) Wait(_ context.Context) error {
+	atomic.AddInt32(&limiter.waits, 1)
+	return limiter.err
+}
+
+func TestClientBuilder_RateLimiter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&requests) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := w.Write([]byte("zen"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	// Retries are throttled as well
+	limiter := &countingRateLimiter{}
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).
+		RetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}).RateLimiter(limiter).Build()
+	require.NoError(t, err)
+	assert.NoError(t, client.TestConnection(context.Background()))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&limiter.waits))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	// Requests that the rate limiter doesn't allow aren't sent
+	limiter = &countingRateLimiter{err: errors.New("rate: Wait(n=1) would exceed context deadline")}
+	client, err = NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).RateLimiter(limiter).Build()
+	require.NoError(t, err)
+	err = client.TestConnection(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "would exceed context deadline")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	// One request per hour, so only the first request is allowed immediately
+	httpClient := &http.Client{Transport: withRateLimiter(nil, rate.NewLimiter(rate.Every(time.Hour), 1))}
+
+	response, err := httpClient.Get(server.URL)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	response, err = httpClient.Do(request)
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestNewDefaultRateLimiter(t *testing.T) {
+	for vcsProvider, defaultRateLimit := range defaultRateLimits {
+		limiter, ok := NewDefaultRateLimiter(vcsProvider).(*weightedRateLimiter)
+		require.True(t, ok)
+		assert.Greater(t, float64(limiter.Limit()), float64(0), vcsProvider.String())
+		// Mutating requests must fit in the burst, or they would never be allowed
+		assert.GreaterOrEqual(t, limiter.Burst(), defaultRateLimit.mutatingRequestWeight, vcsProvider.String())
+	}
+	limiter := NewDefaultRateLimiter(vcsutils.BitbucketServer).(*weightedRateLimiter)
+	assert.Equal(t, rate.Limit(5), limiter.Limit())
+	assert.Equal(t, 60, limiter.Burst())
+}
+
+func TestWeightedRateLimiter(t *testing.T) {
+	// GitHub's mutating requests consume 5 tokens of the burst of 10
+	limiter := NewDefaultRateLimiter(vcsutils.GitHub).(*weightedRateLimiter)
+	limiter.SetLimit(rate.Every(time.Hour))
+	post, err := http.NewRequest(http.MethodPost, "https://api.github.com/repos/jfrog/repo-1/statuses/sha", nil)
+	require.NoError(t, err)
+	require.NoError(t, limiter.WaitRequest(post))
+	get, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/jfrog/repo-1", nil)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, limiter.WaitRequest(get))
+	}
+
+	// No tokens are left, and the next one is an hour away
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, limiter.WaitRequest(get.WithContext(ctx)))
+}
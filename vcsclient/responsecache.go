@@ -0,0 +1,200 @@
+package vcsclient
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	// The maximum size of a response body that is cached, so that large archives and diffs don't fill the memory
+	maxCachedResponseBytes = 10 << 20
+	// The number of responses kept by NewMemoryResponseCache, when given no maximum
+	defaultResponseCacheEntries = 1000
+)
+
+// ResponseCache stores the responses of GET requests along with their validators, the ETag and Last-Modified headers.
+// The cached responses are revalidated using conditional requests. When the VCS provider responds with 304 Not Modified,
+// the cached response is returned instead, which doesn't count against the rate limits of GitHub.
+// Implementations must be safe for concurrent use.
+type ResponseCache interface {
+	// Get returns the response cached under the key, if exists
+	Get(key string) (CachedResponse, bool)
+	// Set caches the response under the key
+	Set(key string, response CachedResponse)
+}
+
+// CachedResponse is a response stored in a ResponseCache
+type CachedResponse struct {
+	StatusCode   int
+	Header       http.Header
+	Body         []byte
+	ETag         string
+	LastModified string
+}
+
+// memoryResponseCache is a ResponseCache that keeps the recently used responses in memory
+type memoryResponseCache struct {
+	maxEntries int
+	mutex      sync.Mutex
+	// The most recently used entries are at the front
+	entries *list.List
+	keys    map[string]*list.Element
+}
+
+type memoryResponseCacheEntry struct {
+	key      string
+	response CachedResponse
+}
+
+// NewMemoryResponseCache creates a ResponseCache that keeps up to maxEntries responses in memory, evicting the least recently used ones.
+// If maxEntries isn't positive, up to 1000 responses are kept.
+func NewMemoryResponseCache(maxEntries int) ResponseCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultResponseCacheEntries
+	}
+	return &memoryResponseCache{maxEntries: maxEntries, entries: list.New(), keys: make(map[string]*list.Element)}
+}
+
+func (cache *memoryResponseCache) Get(key string) (CachedResponse, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, exists := cache.keys[key]
+	if !exists {
+		return CachedResponse{}, false
+	}
+	cache.entries.MoveToFront(element)
+	return element.Value.(*memoryResponseCacheEntry).response, true
+}
+
+func (cache *memoryResponseCache) Set(key string, response CachedResponse) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, exists := cache.keys[key]; exists {
+		element.Value.(*memoryResponseCacheEntry).response = response
+		cache.entries.MoveToFront(element)
+		return
+	}
+	cache.keys[key] = cache.entries.PushFront(&memoryResponseCacheEntry{key: key, response: response})
+	if cache.entries.Len() > cache.maxEntries {
+		oldest := cache.entries.Back()
+		cache.entries.Remove(oldest)
+		delete(cache.keys, oldest.Value.(*memoryResponseCacheEntry).key)
+	}
+}
+
+// cachingTransport is an http.RoundTripper that sends conditional GET requests for the responses of the ResponseCache,
+// and returns the cached responses when they weren't modified
+type cachingTransport struct {
+	base  http.RoundTripper
+	cache ResponseCache
+}
+
+// withResponseCache returns a transport that caches the responses of the base transport in the response cache
+func withResponseCache(base http.RoundTripper, cache ResponseCache) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &cachingTransport{base: base, cache: cache}
+}
+
+func (transport *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Conditional requests of the caller are sent as is, since their 304 responses are expected
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return transport.base.RoundTrip(req)
+	}
+	key := getResponseCacheKey(req)
+	cached, exists := transport.cache.Get(key)
+	if exists {
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	response, err := transport.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if exists && response.StatusCode == http.StatusNotModified {
+		_ = response.Body.Close()
+		return newCachedHTTPResponse(req, response, cached), nil
+	}
+	return transport.cacheResponse(key, response)
+}
+
+// cacheResponse caches successful responses that have validators. The body of the response is read, and replaced by a copy.
+func (transport *cachingTransport) cacheResponse(key string, response *http.Response) (*http.Response, error) {
+	etag, lastModified := response.Header.Get("ETag"), response.Header.Get("Last-Modified")
+	if response.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return response, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxCachedResponseBytes+1))
+	if err != nil {
+		_ = response.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedResponseBytes {
+		// Too large to cache, the read part is returned along with the rest of the body
+		response.Body = &multiReadCloser{Reader: io.MultiReader(bytes.NewReader(body), response.Body), Closer: response.Body}
+		return response, nil
+	}
+	if err = response.Body.Close(); err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	transport.cache.Set(key, CachedResponse{
+		StatusCode:   response.StatusCode,
+		Header:       response.Header.Clone(),
+		Body:         body,
+		ETag:         etag,
+		LastModified: lastModified,
+	})
+	return response, nil
+}
+
+// newCachedHTTPResponse returns the cached response, with the headers of the 304 response, such as the rate limits, updated
+func newCachedHTTPResponse(req *http.Request, notModified *http.Response, cached CachedResponse) *http.Response {
+	header := cached.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	for name, values := range notModified.Header {
+		header[name] = values
+	}
+	header.Set("X-From-Cache", "1")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+		StatusCode:    cached.StatusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
+
+// getResponseCacheKey returns the cache key of the request. The responses depend on the requested media type and on the authenticated user,
+// so the Accept header and a hash of the Authorization header are part of the key.
+func getResponseCacheKey(req *http.Request) string {
+	key := req.URL.String() + "\n" + req.Header.Get("Accept")
+	if authorization := req.Header.Get("Authorization"); authorization != "" {
+		hash := sha256.Sum256([]byte(authorization))
+		key += "\n" + hex.EncodeToString(hash[:])
+	}
+	return key
+}
+
+type multiReadCloser struct {
+	io.Reader
+	io.Closer
+}
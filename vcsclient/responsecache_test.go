@@ -0,0 +1,111 @@
+package vcsclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createConditionalServer creates a server that responds with 304 to requests with the ETag of its body, and counts the full responses
+func createConditionalServer(t *testing.T, body string, fullResponses *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(fullResponses, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-RateLimit-Remaining", "5000")
+		_, err := w.Write([]byte(body))
+		assert.NoError(t, err)
+	}))
+}
+
+func TestCachingTransport(t *testing.T) {
+	var fullResponses int32
+	server := createConditionalServer(t, `["master"]`, &fullResponses)
+	defer server.Close()
+	httpClient := &http.Client{Transport: withResponseCache(nil, NewMemoryResponseCache(0))}
+
+	get := func(authorization string) *http.Response {
+		request, err := http.NewRequest(http.MethodGet, server.URL+"/branches", nil)
+		require.NoError(t, err)
+		request.Header.Set("Authorization", authorization)
+		response, err := httpClient.Do(request)
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, response.Body.Close())
+		}()
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, `["master"]`, string(body))
+		return response
+	}
+
+	response := get("Bearer token-1")
+	assert.Empty(t, response.Header.Get("X-From-Cache"))
+	response = get("Bearer token-1")
+	assert.Equal(t, "1", response.Header.Get("X-From-Cache"))
+	// The headers of the 304 response are up to date
+	assert.Equal(t, "4999", response.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fullResponses))
+
+	// Another user doesn't get the responses cached for the first one
+	get("Bearer token-2")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fullResponses))
+
+	// Requests that aren't GET aren't cached
+	response, err := httpClient.Post(server.URL+"/branches", "application/json", nil)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fullResponses))
+}
+
+func TestMemoryResponseCache(t *testing.T) {
+	cache := NewMemoryResponseCache(2)
+	cache.Set("a", CachedResponse{ETag: "a"})
+	cache.Set("b", CachedResponse{ETag: "b"})
+	// Using a makes b the least recently used
+	_, exists := cache.Get("a")
+	assert.True(t, exists)
+	cache.Set("c", CachedResponse{ETag: "c"})
+
+	_, exists = cache.Get("b")
+	assert.False(t, exists)
+	response, exists := cache.Get("a")
+	assert.True(t, exists)
+	assert.Equal(t, "a", response.ETag)
+	response, exists = cache.Get("c")
+	assert.True(t, exists)
+	assert.Equal(t, "c", response.ETag)
+
+	cache.Set("c", CachedResponse{ETag: "c2"})
+	response, _ = cache.Get("c")
+	assert.Equal(t, "c2", response.ETag)
+}
+
+func TestClientBuilder_ResponseCache(t *testing.T) {
+	var fullResponses int32
+	server := createConditionalServer(t, `[{"name":"master"}]`, &fullResponses)
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).ResponseCache(NewMemoryResponseCache(0)).Build()
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		branches, err := client.ListBranches(context.Background(), owner, repo1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"master"}, branches)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fullResponses))
+}
@@ -0,0 +1,274 @@
+package vcsclient
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	defaultRetryInitialBackoff = time.Second
+	defaultRetryMaxBackoff     = time.Minute
+	// The maximum number of bytes read from the body of a retried response, so the connection can be reused
+	maxDrainedResponseBytes = 4096
+)
+
+// RetryPolicy configures how requests that failed with a transient error are retried.
+// Transient errors are network errors, 5xx and 429 responses, and GitHub's rate limit responses.
+// Requests with non-idempotent methods, such as POST, are only retried when the server rejected them due to rate limiting.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// The maximum number of attempts of each request, including the first one. Requests aren't retried if lower than 2.
+	MaxAttempts int
+	// The delay before the first retry, which is doubled on each following retry. Defaults to 1 second.
+	InitialBackoff time.Duration
+	// The maximum delay between attempts. Defaults to 1 minute.
+	// Requests aren't retried if the server asks to wait longer, using the Retry-After or X-RateLimit-Reset headers.
+	MaxBackoff time.Duration
+	// The fraction of each delay that is randomized, between 0 and 1
+	Jitter float64
+}
+
+func (policy RetryPolicy) initialBackoff() time.Duration {
+	if policy.InitialBackoff > 0 {
+		return policy.InitialBackoff
+	}
+	return defaultRetryInitialBackoff
+}
+
+func (policy RetryPolicy) maxBackoff() time.Duration {
+	if policy.MaxBackoff > 0 {
+		return policy.MaxBackoff
+	}
+	return defaultRetryMaxBackoff
+}
+
+// backoff returns the delay before the given retry, starting from 1
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	delay := policy.initialBackoff()
+	for i := 1; i < retry && delay < policy.maxBackoff(); i++ {
+		delay *= 2
+	}
+	if delay > policy.maxBackoff() {
+		delay = policy.maxBackoff()
+	}
+	if policy.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * policy.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// RateLimitError is returned when a request was rejected due to rate limiting, after retrying it according to the retry policy
+type RateLimitError struct {
+	// The HTTP status code of the response
+	StatusCode int
+	// The rate limits reported in the response
+	RateLimit RateLimitInfo
+	// The delay requested by the server before sending more requests, or zero if it wasn't requested
+	RetryAfter time.Duration
+}
+
+func (err *RateLimitError) Error() string {
+	message := fmt.Sprintf("rate limit exceeded (status %d)", err.StatusCode)
+	if err.RetryAfter > 0 {
+		message += ", retry after " + err.RetryAfter.String()
+	}
+	if !err.RateLimit.Reset.IsZero() {
+		message += ", the rate limit resets at " + err.RateLimit.Reset.Format(time.RFC3339)
+	}
+	return message
+}
+
+// retryTransport is an http.RoundTripper that retries requests according to a RetryPolicy, and reports them to the RequestLogger
+type retryTransport struct {
+	base          http.RoundTripper
+	policy        RetryPolicy
+	logger        Log
+	requestLogger RequestLogger
+}
+
+// withRetries makes the HTTP client retry requests that failed with a transient error, according to the retry policy of the VCS info.
+// Requests that are still rejected due to rate limiting fail with a RateLimitError.
+func withRetries(httpClient *http.Client, vcsInfo VcsInfo, logger Log) *http.Client {
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient.Transport = &retryTransport{base: base, policy: vcsInfo.RetryPolicy, logger: logger, requestLogger: vcsInfo.RequestLogger}
+	return httpClient
+}
+
+func (transport *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := transport.roundTripAttempt(req, attempt)
+		delay, shouldRetry := transport.retryDelay(req, response, err, attempt)
+		if !shouldRetry || attempt >= transport.policy.MaxAttempts || !isRewindable(req) {
+			getResponseRecorder(req.Context()).record(response)
+			return checkRateLimit(response, err)
+		}
+		if response != nil {
+			_, _ = io.CopyN(io.Discard, response.Body, maxDrainedResponseBytes)
+			_ = response.Body.Close()
+		}
+		transport.logger.Debug("retrying", req.Method, req.URL.Redacted(), "in", delay.String())
+		if transport.requestLogger != nil {
+			transport.requestLogger.LogRetry(RetryEvent{Method: req.Method, URL: req.URL.Redacted(), Attempt: attempt + 1, Delay: delay})
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		if req, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (transport *retryTransport) roundTripAttempt(req *http.Request, attempt int) (*http.Response, error) {
+	start := time.Now()
+	response, err := transport.base.RoundTrip(req)
+	if transport.requestLogger != nil {
+		event := RequestEvent{Method: req.Method, URL: req.URL.Redacted(), Attempt: attempt, Duration: time.Since(start), Err: err}
+		if response != nil {
+			event.StatusCode = response.StatusCode
+		}
+		transport.requestLogger.LogRequest(event)
+	}
+	return response, err
+}
+
+// retryDelay returns the delay before retrying the request, and whether it should be retried at all
+func (transport *retryTransport) retryDelay(req *http.Request, response *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		return transport.policy.backoff(attempt), req.Context().Err() == nil && isIdempotent(req.Method) && !isCertificateError(err)
+	}
+	if isRateLimited(response) {
+		serverDelay, found := getServerRequestedDelay(response)
+		if !found {
+			return transport.policy.backoff(attempt), true
+		}
+		return serverDelay, serverDelay <= transport.policy.maxBackoff()
+	}
+	switch response.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !isIdempotent(req.Method) {
+			return 0, false
+		}
+		if serverDelay, found := getServerRequestedDelay(response); found {
+			return serverDelay, serverDelay <= transport.policy.maxBackoff()
+		}
+		return transport.policy.backoff(attempt), true
+	}
+	return 0, false
+}
+
+// isRateLimited checks whether the server rejected the request due to rate limiting.
+// Besides 429, GitHub responds with 403 when the primary or secondary rate limits are exceeded.
+func isRateLimited(response *http.Response) bool {
+	if response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return response.StatusCode == http.StatusForbidden &&
+		(response.Header.Get("Retry-After") != "" || response.Header.Get("X-RateLimit-Remaining") == "0")
+}
+
+// checkRateLimit fails with a RateLimitError if the request was rejected due to rate limiting
+func checkRateLimit(response *http.Response, err error) (*http.Response, error) {
+	if err != nil || !isRateLimited(response) {
+		return response, err
+	}
+	retryAfter, _ := getServerRequestedDelay(response)
+	rateLimitErr := &RateLimitError{StatusCode: response.StatusCode, RateLimit: getRateLimitInfo(response.Header), RetryAfter: retryAfter}
+	_ = vcsutils.DiscardResponseBody(response)
+	_ = response.Body.Close()
+	return nil, rateLimitErr
+}
+
+// getRateLimitInfo returns the rate limits reported in the headers of a response.
+// GitLab uses the RateLimit prefix, and the other VCS providers use the X-RateLimit prefix.
+func getRateLimitInfo(header http.Header) RateLimitInfo {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		limit, err := strconv.Atoi(header.Get(prefix + "Limit"))
+		if err != nil {
+			continue
+		}
+		remaining, err := strconv.Atoi(header.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+		info := RateLimitInfo{Limit: limit, Remaining: remaining}
+		if reset, err := strconv.ParseInt(header.Get(prefix+"Reset"), 10, 64); err == nil {
+			info.Reset = time.Unix(reset, 0)
+		}
+		return info
+	}
+	return RateLimitInfo{}
+}
+
+// getServerRequestedDelay returns the delay requested by the Retry-After header, or by GitHub's X-RateLimit-Reset header
+func getServerRequestedDelay(response *http.Response) (time.Duration, bool) {
+	if retryAfter := response.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return nonNegative(time.Duration(seconds) * time.Second), true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return nonNegative(time.Until(date)), true
+		}
+	}
+	if response.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(response.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return nonNegative(time.Until(time.Unix(reset, 0))), true
+		}
+	}
+	return 0, false
+}
+
+func nonNegative(delay time.Duration) time.Duration {
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// isCertificateError checks whether the certificate of the server couldn't be verified, which fails the retries as well
+func isCertificateError(err error) bool {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRewindable checks whether the body of the request can be sent again
+func isRewindable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retryReq.Body = body
+	}
+	return retryReq, nil
+}
@@ -0,0 +1,211 @@
+package vcsclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.backoff(1))
+	assert.Equal(t, 2*time.Second, policy.backoff(2))
+	assert.Equal(t, 4*time.Second, policy.backoff(3))
+	assert.Equal(t, 5*time.Second, policy.backoff(4))
+	assert.Equal(t, 5*time.Second, policy.backoff(100))
+
+	assert.Equal(t, defaultRetryInitialBackoff, RetryPolicy{}.backoff(1))
+	assert.Equal(t, defaultRetryMaxBackoff, RetryPolicy{}.backoff(100))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		delay := policy.backoff(2)
+		assert.LessOrEqual(t, delay, 2*time.Second)
+		assert.GreaterOrEqual(t, delay, time.Second)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		failure          func(w http.ResponseWriter)
+		expectedAttempts int
+	}{
+		{"serverError", http.MethodGet, func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) }, 3},
+		{"tooManyRequests", http.MethodGet, func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, 3},
+		{"retryAfterDate", http.MethodGet, func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, 3},
+		{"gitHubSecondaryRateLimit", http.MethodGet, func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+		}, 3},
+		{"gitHubRateLimit", http.MethodGet, func(w http.ResponseWriter) {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+		}, 3},
+		{"rateLimitedPost", http.MethodPost, func(w http.ResponseWriter) { w.WriteHeader(http.StatusTooManyRequests) }, 3},
+		{"serverErrorPost", http.MethodPost, func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) }, 1},
+		{"forbidden", http.MethodGet, func(w http.ResponseWriter) { w.WriteHeader(http.StatusForbidden) }, 1},
+		{"notFound", http.MethodGet, func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) }, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "request body", string(body))
+				if attempts < 3 {
+					test.failure(w)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			httpClient := withRetries(&http.Client{}, VcsInfo{RetryPolicy: testRetryPolicy}, EmptyLogger{})
+			req, err := http.NewRequestWithContext(context.Background(), test.method, server.URL, strings.NewReader("request body"))
+			require.NoError(t, err)
+			response, err := httpClient.Do(req)
+			require.NoError(t, err)
+			assert.NoError(t, response.Body.Close())
+			assert.Equal(t, test.expectedAttempts, attempts)
+			if test.expectedAttempts == 3 {
+				assert.Equal(t, http.StatusOK, response.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_MaxAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	response, err := withRetries(&http.Client{}, VcsInfo{RetryPolicy: testRetryPolicy}, EmptyLogger{}).Get(server.URL)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+	assert.Equal(t, testRetryPolicy.MaxAttempts, attempts)
+
+	// Retries are disabled by default
+	attempts = 0
+	response, err = withRetries(&http.Client{}, VcsInfo{}, EmptyLogger{}).Get(server.URL)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryTransport_ContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = withRetries(&http.Client{}, VcsInfo{RetryPolicy: policy}, EmptyLogger{}).Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryPolicyOfClients(t *testing.T) {
+	tests := []struct {
+		vcsProvider      vcsutils.VcsProvider
+		responseBody     string
+		expectedAttempts int
+	}{
+		{vcsutils.GitHub, "zen", 2},
+		// The GitLab client sends an additional request to configure its rate limiter
+		{vcsutils.GitLab, "[]", 3},
+		{vcsutils.BitbucketServer, "{}", 2},
+		{vcsutils.BitbucketCloud, "{}", 2},
+		{vcsutils.Gitea, "{}", 2},
+	}
+	for _, test := range tests {
+		t.Run(test.vcsProvider.String(), func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+
+			client, err := NewClientBuilder(test.vcsProvider).ApiEndpoint(server.URL).Token(token).RetryPolicy(testRetryPolicy).Build()
+			require.NoError(t, err)
+			assert.NoError(t, client.TestConnection(context.Background()))
+			assert.Equal(t, test.expectedAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryTransport_RateLimitError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "3600")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).RetryPolicy(testRetryPolicy).Build()
+	require.NoError(t, err)
+	err = client.TestConnection(context.Background())
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, http.StatusForbidden, rateLimitErr.StatusCode)
+	assert.Equal(t, time.Hour, rateLimitErr.RetryAfter)
+	assert.Equal(t, RateLimitInfo{Limit: 5000, Remaining: 0, Reset: time.Unix(1700000000, 0)}, rateLimitErr.RateLimit)
+	assert.Contains(t, err.Error(), "rate limit exceeded (status 403), retry after 1h0m0s")
+	// The requested delay exceeds the maximum backoff
+	assert.Equal(t, 1, attempts)
+}
+
+func TestGetRateLimitInfo(t *testing.T) {
+	header := http.Header{}
+	assert.Equal(t, RateLimitInfo{}, getRateLimitInfo(header))
+
+	header.Set("RateLimit-Limit", "600")
+	assert.Equal(t, RateLimitInfo{}, getRateLimitInfo(header))
+
+	header.Set("RateLimit-Remaining", "10")
+	assert.Equal(t, RateLimitInfo{Limit: 600, Remaining: 10}, getRateLimitInfo(header))
+
+	header.Set("X-RateLimit-Limit", "5000")
+	header.Set("X-RateLimit-Remaining", "20")
+	header.Set("X-RateLimit-Reset", "1700000000")
+	assert.Equal(t, RateLimitInfo{Limit: 5000, Remaining: 20, Reset: time.Unix(1700000000, 0)}, getRateLimitInfo(header))
+}
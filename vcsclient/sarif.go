@@ -0,0 +1,201 @@
+package vcsclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The ID of the scan results reports, when the SARIF log doesn't name its tool
+const defaultScanResultsReportID = "scan-results"
+
+// sarifLog is the part of a SARIF log that is needed to report its results on VCS providers without code scanning
+type sarifLog struct {
+	Runs []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name  string      `json:"name"`
+			Rules []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	Properties       struct {
+		SecuritySeverity string `json:"security-severity"`
+	} `json:"properties"`
+}
+
+type sarifResult struct {
+	RuleID    string       `json:"ruleId"`
+	Level     string       `json:"level"`
+	Message   sarifMessage `json:"message"`
+	Locations []struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region struct {
+				StartLine int `json:"startLine"`
+				EndLine   int `json:"endLine"`
+			} `json:"region"`
+		} `json:"physicalLocation"`
+	} `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// scanResults are the findings of a SARIF log, flattened across its runs
+type scanResults struct {
+	// The names of the tools that ran the scans, separated by commas
+	Tool     string
+	Findings []scanFinding
+}
+
+type scanFinding struct {
+	RuleID    string
+	Title     string
+	Message   string
+	Severity  SecuritySeverity
+	Path      string
+	StartLine int
+	EndLine   int
+}
+
+// parseSarif parses the results of a SARIF log
+func parseSarif(content string) (scanResults, error) {
+	var log sarifLog
+	if err := json.Unmarshal([]byte(content), &log); err != nil {
+		return scanResults{}, fmt.Errorf("failed to parse the SARIF scan results: %w", err)
+	}
+	var results scanResults
+	var tools []string
+	for _, run := range log.Runs {
+		if name := run.Tool.Driver.Name; name != "" && !containsFold(tools, name) {
+			tools = append(tools, name)
+		}
+		rules := make(map[string]sarifRule, len(run.Tool.Driver.Rules))
+		for _, rule := range run.Tool.Driver.Rules {
+			rules[rule.ID] = rule
+		}
+		for _, result := range run.Results {
+			results.Findings = append(results.Findings, mapSarifResultToScanFinding(result, rules[result.RuleID]))
+		}
+	}
+	results.Tool = strings.Join(tools, ", ")
+	return results, nil
+}
+
+func mapSarifResultToScanFinding(result sarifResult, rule sarifRule) scanFinding {
+	finding := scanFinding{
+		RuleID:   result.RuleID,
+		Title:    rule.ShortDescription.Text,
+		Message:  result.Message.Text,
+		Severity: getSarifSeverity(result.Level, rule.Properties.SecuritySeverity),
+	}
+	if finding.Title == "" {
+		finding.Title = result.RuleID
+	}
+	if len(result.Locations) > 0 {
+		location := result.Locations[0].PhysicalLocation
+		finding.Path = strings.TrimPrefix(location.ArtifactLocation.URI, "file://")
+		finding.StartLine = location.Region.StartLine
+		finding.EndLine = location.Region.EndLine
+		if finding.EndLine < finding.StartLine {
+			finding.EndLine = finding.StartLine
+		}
+	}
+	return finding
+}
+
+// getSarifSeverity returns the severity of a result, according to the CVSS score of its rule if the rule is a security rule,
+// or according to the error, warning or note level of the result otherwise
+func getSarifSeverity(level, securitySeverity string) SecuritySeverity {
+	if score, err := strconv.ParseFloat(securitySeverity, 64); err == nil {
+		switch {
+		case score >= 9:
+			return SeverityCritical
+		case score >= 7:
+			return SeverityHigh
+		case score >= 4:
+			return SeverityMedium
+		case score > 0:
+			return SeverityLow
+		}
+		return SeverityInfo
+	}
+	switch level {
+	case "error":
+		return SeverityHigh
+	case "note", "none":
+		return SeverityInfo
+	}
+	// The default level of SARIF results is warning
+	return SeverityMedium
+}
+
+// reportID returns an ID of the reports of the scan results, which is derived from the names of their tools,
+// so that reporting the results of the same tools again replaces the previous report
+func (results scanResults) reportID() string {
+	id := strings.Trim(nonAlphanumericRegexp.ReplaceAllString(strings.ToLower(results.Tool), "-"), "-")
+	if id == "" {
+		return defaultScanResultsReportID
+	}
+	return id
+}
+
+// markdownSummary returns a Markdown table of the findings, for VCS providers that report the scan results as comments
+func (results scanResults) markdownSummary() string {
+	var summary strings.Builder
+	tool := results.Tool
+	if tool == "" {
+		tool = "Scan"
+	}
+	summary.WriteString(fmt.Sprintf("### %s results\n\n", tool))
+	if len(results.Findings) == 0 {
+		summary.WriteString("No issues found.\n")
+		return summary.String()
+	}
+	summary.WriteString(fmt.Sprintf("%d issues found.\n\n| Severity | Rule | Location | Message |\n| --- | --- | --- | --- |\n", len(results.Findings)))
+	for _, finding := range results.Findings {
+		location := finding.Path
+		if finding.StartLine > 0 {
+			location = fmt.Sprintf("%s:%d", location, finding.StartLine)
+		}
+		summary.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", getSecuritySeverityName(finding.Severity), escapeMarkdownTableCell(finding.Title),
+			escapeMarkdownTableCell(location), escapeMarkdownTableCell(finding.Message)))
+	}
+	return summary.String()
+}
+
+func getSecuritySeverityName(severity SecuritySeverity) string {
+	switch severity {
+	case SeverityCritical:
+		return "Critical"
+	case SeverityHigh:
+		return "High"
+	case SeverityMedium:
+		return "Medium"
+	case SeverityLow:
+		return "Low"
+	case SeverityInfo:
+		return "Info"
+	}
+	return "Unknown"
+}
+
+func escapeMarkdownTableCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ").Replace(text)
+}
+
+var nonAlphanumericRegexp = regexp.MustCompile(`[^a-z0-9]+`)
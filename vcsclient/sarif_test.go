@@ -0,0 +1,83 @@
+package vcsclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSarifScanResults = `{
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "JFrog Xray",
+          "rules": [
+            {"id": "CVE-2020-8203", "shortDescription": {"text": "Prototype Pollution in lodash"}, "properties": {"security-severity": "9.1"}},
+            {"id": "go/unused-variable", "shortDescription": null}
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "CVE-2020-8203",
+          "message": {"text": "lodash 4.17.15. Fixed in Versions: [4.17.19]"},
+          "locations": [{"physicalLocation": {"artifactLocation": {"uri": "file://package.json"}, "region": {"startLine": 12}}}]
+        },
+        {
+          "ruleId": "go/unused-variable",
+          "level": "note",
+          "message": {"text": "Unused variable | x"},
+          "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}, "region": {"startLine": 3, "endLine": 5}}}]
+        }
+      ]
+    }
+  ]
+}`
+
+var testSarifFindings = []scanFinding{
+	{RuleID: "CVE-2020-8203", Title: "Prototype Pollution in lodash", Message: "lodash 4.17.15. Fixed in Versions: [4.17.19]", Severity: SeverityCritical,
+		Path: "package.json", StartLine: 12, EndLine: 12},
+	{RuleID: "go/unused-variable", Title: "go/unused-variable", Message: "Unused variable | x", Severity: SeverityInfo, Path: "main.go", StartLine: 3, EndLine: 5},
+}
+
+func TestParseSarif(t *testing.T) {
+	results, err := parseSarif(testSarifScanResults)
+	require.NoError(t, err)
+	assert.Equal(t, scanResults{Tool: "JFrog Xray", Findings: testSarifFindings}, results)
+	assert.Equal(t, "jfrog-xray", results.reportID())
+	assert.Equal(t, "### JFrog Xray results\n\n2 issues found.\n\n| Severity | Rule | Location | Message |\n| --- | --- | --- | --- |\n"+
+		"| Critical | Prototype Pollution in lodash | package.json:12 | lodash 4.17.15. Fixed in Versions: [4.17.19] |\n"+
+		"| Info | go/unused-variable | main.go:3 | Unused variable \\| x |\n", results.markdownSummary())
+
+	results, err = parseSarif(`{"runs":[]}`)
+	require.NoError(t, err)
+	assert.Equal(t, defaultScanResultsReportID, results.reportID())
+	assert.Equal(t, "### Scan results\n\nNo issues found.\n", results.markdownSummary())
+
+	_, err = parseSarif("not a SARIF log")
+	assert.Error(t, err)
+}
+
+func TestGetSarifSeverity(t *testing.T) {
+	tests := []struct {
+		level            string
+		securitySeverity string
+		expected         SecuritySeverity
+	}{
+		{securitySeverity: "9.8", expected: SeverityCritical},
+		{level: "note", securitySeverity: "7", expected: SeverityHigh},
+		{securitySeverity: "5.5", expected: SeverityMedium},
+		{securitySeverity: "1", expected: SeverityLow},
+		{securitySeverity: "0", expected: SeverityInfo},
+		{level: "error", expected: SeverityHigh},
+		{level: "warning", expected: SeverityMedium},
+		{expected: SeverityMedium},
+		{level: "note", expected: SeverityInfo},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, getSarifSeverity(tt.level, tt.securitySeverity), tt.level+" "+tt.securitySeverity)
+	}
+}
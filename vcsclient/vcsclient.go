@@ -2,11 +2,19 @@ package vcsclient
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jfrog/froggit-go/vcsutils"
+	"golang.org/x/oauth2"
 )
 
 // CommitStatus the status of the commit in the VCS
@@ -33,6 +41,20 @@ const (
 	ReadWrite
 )
 
+// RepositoryPermission the access level of a user to a repository
+type RepositoryPermission int
+
+const (
+	// PermissionNone means the user can't access the repository
+	PermissionNone RepositoryPermission = iota
+	// PermissionRead allows cloning the repository
+	PermissionRead
+	// PermissionWrite allows pushing to the repository
+	PermissionWrite
+	// PermissionAdmin allows managing the repository and its collaborators
+	PermissionAdmin
+)
+
 // RepositoryVisibility the visibility level of the repository
 type RepositoryVisibility int
 
@@ -45,6 +67,92 @@ const (
 	Private
 )
 
+// ReviewEvent the action performed by a pull request review
+type ReviewEvent int
+
+const (
+	// ReviewApprove approves the pull request
+	ReviewApprove ReviewEvent = iota
+	// ReviewRequestChanges requests changes to the pull request before it can be merged
+	ReviewRequestChanges
+	// ReviewComment submits general feedback without approving the pull request or requesting changes
+	ReviewComment
+)
+
+// MergeStrategy the method used to merge a pull request into the target branch
+type MergeStrategy int
+
+const (
+	// MergeCommit adds all commits of the source branch to the target branch using a merge commit
+	MergeCommit MergeStrategy = iota
+	// SquashMerge squashes all commits of the source branch into a single commit on the target branch
+	SquashMerge
+	// RebaseMerge rebases the commits of the source branch onto the target branch, without a merge commit
+	RebaseMerge
+)
+
+// ArchiveFormat the format of a downloaded repository archive
+type ArchiveFormat int
+
+const (
+	// TarGz is a gzip compressed tarball
+	TarGz ArchiveFormat = iota
+	// Zip is a zip archive
+	Zip
+)
+
+// FileChangeType the type of change made to a file in a commit
+type FileChangeType int
+
+const (
+	// AddFile creates a new file
+	AddFile FileChangeType = iota
+	// UpdateFile replaces the content of an existing file
+	UpdateFile
+	// DeleteFile removes an existing file
+	DeleteFile
+)
+
+// FileStatus the status of a file changed in a pull request
+type FileStatus int
+
+const (
+	// FileAdded means that the file was added
+	FileAdded FileStatus = iota
+	// FileModified means that the content of the file was modified
+	FileModified
+	// FileDeleted means that the file was deleted
+	FileDeleted
+	// FileRenamed means that the file was moved or renamed, and possibly modified
+	FileRenamed
+)
+
+// TreeEntryType the type of an entry in a repository tree
+type TreeEntryType int
+
+const (
+	// TreeEntryFile is a file, including symbolic links
+	TreeEntryFile TreeEntryType = iota
+	// TreeEntryDirectory is a directory
+	TreeEntryDirectory
+	// TreeEntrySubmodule is a git submodule
+	TreeEntrySubmodule
+)
+
+// SignatureType the type of the signature of a commit
+type SignatureType int
+
+const (
+	// SignatureUnknown means that the commit isn't signed, or that the VCS provider doesn't report the type of its signature
+	SignatureUnknown SignatureType = iota
+	// SignatureGPG is an OpenPGP signature
+	SignatureGPG
+	// SignatureSSH is a signature of an SSH key
+	SignatureSSH
+	// SignatureX509 is an S/MIME signature of an X.509 certificate
+	SignatureX509
+)
+
 // VcsInfo is the connection details of the VcsClient to communicate with the server
 type VcsInfo struct {
 	APIEndpoint string
@@ -52,6 +160,33 @@ type VcsInfo struct {
 	Token       string
 	// Project name is relevant for Azure Repos
 	Project string
+	// Region is relevant for AWS CodeCommit
+	Region string
+	// RetryPolicy configures the retries of requests that failed with a transient error
+	RetryPolicy RetryPolicy
+	// HTTPClient is the base of the HTTP clients that send the requests, if set
+	HTTPClient *http.Client
+	// Transport overrides the transport of the HTTP clients that send the requests, if set
+	Transport http.RoundTripper
+	// RequestLogger receives the events of the HTTP requests, if set
+	RequestLogger RequestLogger
+	// GitHubApp is relevant for GitHub, to authenticate as a GitHub App installation instead of using the token
+	GitHubApp *GitHubAppInfo
+	// TokenSource is relevant for GitLab and Bitbucket cloud, to authenticate with OAuth tokens that are refreshed when they expire, instead of using the token
+	TokenSource oauth2.TokenSource
+	// TLSConfig is the TLS configuration of the connections to the VCS provider, if set
+	TLSConfig *tls.Config
+	// RootCAs are the certificate authorities trusted when connecting to the VCS provider, such as the private CA of a self-hosted server.
+	// Overrides the root CAs of the TLS configuration, if set.
+	RootCAs *x509.CertPool
+	// InsecureSkipVerify disables the verification of the certificate of the VCS provider. Use only for testing.
+	InsecureSkipVerify bool
+	// UseGraphQL is relevant for GitHub, to read the details of pull requests using a single GraphQL query instead of several REST requests
+	UseGraphQL bool
+	// ResponseCache caches the responses of GET requests, which are revalidated using conditional requests, if set
+	ResponseCache ResponseCache
+	// RateLimiter is consulted before sending each request, to throttle the requests, if set
+	RateLimiter RateLimiter
 }
 
 // RepositoryEnvironmentInfo is the environment details configured for a repository
@@ -61,19 +196,215 @@ type RepositoryEnvironmentInfo struct {
 	Reviewers []string
 }
 
+// DeploymentInfo is a deployment of a ref to an environment
+type DeploymentInfo struct {
+	// The name of the environment, such as production. On GitLab, the environment is created if it doesn't exist.
+	Environment string
+	// The branch, tag or commit SHA that is deployed
+	Ref string
+	// The description of the deployment. Not supported on GitLab
+	Description string
+}
+
+// DeploymentStatusInfo is the status of a deployment.
+// On GitLab, only the state of the deployment is set.
+type DeploymentStatusInfo struct {
+	// One of Pass, Fail, Error, or InProgress
+	State CommitStatus
+	// A short description of the status
+	Description string
+	// The URL of the deployment logs
+	LogURL string
+	// The URL of the deployed environment, such as the URL of the deployed application
+	EnvironmentURL string
+}
+
+// SecurityAlertType is the kind of scan that raised a security alert
+type SecurityAlertType int
+
+const (
+	// DependencyAlert is a vulnerable dependency, such as a Dependabot alert or a GitLab dependency or container scanning finding
+	DependencyAlert SecurityAlertType = iota
+	// CodeScanningAlert is a vulnerability in the code of the repository, such as a code scanning alert or a GitLab SAST finding
+	CodeScanningAlert
+)
+
+// SecuritySeverity is the normalized severity of a security alert
+type SecuritySeverity int
+
+const (
+	SeverityUnknown SecuritySeverity = iota
+	SeverityInfo
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+// SecurityAlertInfo is an open security alert of a repository
+type SecurityAlertInfo struct {
+	ID       int64
+	Type     SecurityAlertType
+	Severity SecuritySeverity
+	Title    string
+	// The CVE, GHSA or rule ID of the alert
+	Identifier string
+	// The vulnerable package. Empty for code scanning alerts
+	Package string
+	// The path of the vulnerable file or manifest
+	Path string
+	// The web page of the alert. Empty if the VCS provider doesn't provide it
+	URL string
+}
+
+// getSecuritySeverity returns the severity of a critical, high, medium, low or info severity name, case-insensitive
+func getSecuritySeverity(severity string) SecuritySeverity {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return SeverityCritical
+	case "high":
+		return SeverityHigh
+	case "medium", "moderate":
+		return SeverityMedium
+	case "low":
+		return SeverityLow
+	case "info":
+		return SeverityInfo
+	}
+	return SeverityUnknown
+}
+
+// RateLimitInfo is the rate limit status of the authenticated user.
+// All the fields are zero if the VCS provider doesn't report its rate limits.
+type RateLimitInfo struct {
+	// The maximum number of requests in the current rate limit window
+	Limit int
+	// The number of requests remaining in the current rate limit window
+	Remaining int
+	// The time at which the current rate limit window resets
+	Reset time.Time
+}
+
 // VcsClient is a base class of all Vcs clients - GitHub, GitLab, Bitbucket server and cloud clients
 type VcsClient interface {
 	// TestConnection Returns nil if connection and authorization established successfully
 	TestConnection(ctx context.Context) error
 
+	// GetRateLimitInfo Returns the rate limit status of the authenticated user
+	GetRateLimitInfo(ctx context.Context) (RateLimitInfo, error)
+
 	// ListRepositories Returns a map between all accessible owners to their list of repositories
 	ListRepositories(ctx context.Context) (map[string][]string, error)
 
+	// ListRepositoriesWithOptions Lists the repositories of an owner, following pagination unless a page is requested
+	// owner   - User, organization, group, workspace or project
+	// options - Filters and pagination of the repositories
+	ListRepositoriesWithOptions(ctx context.Context, owner string, options ListRepositoriesOptions) ([]RepositoryDetails, error)
+
 	// ListBranches Lists all branches under the input repository
 	// owner      - User or organization
 	// repository - VCS repository name
 	ListBranches(ctx context.Context, owner, repository string) ([]string, error)
 
+	// ListBranchesPager Iterates over the branches of a repository, page by page
+	// owner      - User or organization
+	// repository - VCS repository name
+	// perPage    - The maximum number of branches per page. If 0, the VCS provider's default is used
+	ListBranchesPager(owner, repository string, perPage int) *Pager[string]
+
+	// ListAllBranches Lists all branches under the input repository, following pagination, along with their head commit
+	// owner      - User or organization
+	// repository - VCS repository name
+	// prefix     - If not empty, only branches whose name starts with the prefix are returned
+	ListAllBranches(ctx context.Context, owner, repository, prefix string) ([]BranchDetails, error)
+
+	// CreateBranch Creates a new branch
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branchName - The name of the new branch
+	// fromRef    - The branch name or commit SHA to create the new branch from
+	CreateBranch(ctx context.Context, owner, repository, branchName, fromRef string) error
+
+	// DeleteBranch Deletes a branch
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branchName - The name of the branch to delete
+	DeleteBranch(ctx context.Context, owner, repository, branchName string) error
+
+	// ListTags Lists all tags under the input repository, following pagination
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error)
+
+	// CreateTag Creates a new tag. If a message is provided, an annotated tag is created
+	// owner      - User or organization
+	// repository - VCS repository name
+	// tagName    - The name of the new tag
+	// targetSha  - The SHA-1 hash of the commit to tag
+	// message    - The message of the annotated tag. If empty, a lightweight tag is created
+	CreateTag(ctx context.Context, owner, repository, tagName, targetSha, message string) error
+
+	// CreateRelease Creates a new release.
+	// Bitbucket has no releases, so an annotated tag with the release name and description as its message is created instead.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// release    - The release to create
+	CreateRelease(ctx context.Context, owner, repository string, release ReleaseInfo) error
+
+	// GetLatestRelease Gets the latest release of a repository. Returns nil if the repository has no releases.
+	// On Bitbucket, the most recently created tag is returned.
+	// owner      - User or organization
+	// repository - VCS repository name
+	GetLatestRelease(ctx context.Context, owner, repository string) (*ReleaseInfo, error)
+
+	// UploadReleaseAsset Uploads a file as an asset of a release.
+	// On Bitbucket Cloud, the file is uploaded to the repository downloads.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// tagName    - The tag name of the release
+	// assetPath  - The path to the file to upload. The asset is named after the file
+	UploadReleaseAsset(ctx context.Context, owner, repository, tagName, assetPath string) error
+
+	// GetBranchProtection Gets the protection rules of a branch
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The name of the branch
+	// Returns nil if the branch isn't protected
+	GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error)
+
+	// SetBranchProtection Protects a branch, replacing its existing protection rules
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The name of the branch
+	// protection - The protection rules to apply
+	SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtection) error
+
+	// GetRequiredChecks Gets the commit statuses that must pass before a pull request can be merged into a branch
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The name of the target branch
+	GetRequiredChecks(ctx context.Context, owner, repository, branch string) (RequiredChecksInfo, error)
+
+	// GetRequiredApprovals Gets the approvals that a pull request needs before it can be merged into a branch
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The name of the target branch
+	GetRequiredApprovals(ctx context.Context, owner, repository, branch string) (RequiredApprovalsInfo, error)
+
+	// GetTagProtection Gets the protection rules of the tags that match a pattern
+	// owner      - User or organization
+	// repository - VCS repository name
+	// pattern    - The name of the tag, or a wildcard pattern such as v*
+	// Returns nil if the tags of the pattern aren't protected
+	GetTagProtection(ctx context.Context, owner, repository, pattern string) (*TagProtection, error)
+
+	// SetTagProtection Protects the tags that match a pattern, replacing the existing protection rules of the pattern
+	// owner      - User or organization
+	// repository - VCS repository name
+	// pattern    - The name of the tag, or a wildcard pattern such as v*
+	// protection - The protection rules to apply
+	SetTagProtection(ctx context.Context, owner, repository, pattern string, protection TagProtection) error
+
 	// CreateWebhook Creates a webhook
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -93,12 +424,47 @@ type VcsClient interface {
 	// webhookEvents - The event type
 	UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token, webhookID string, webhookEvents ...vcsutils.WebhookEvent) error
 
+	// CreateWebhookWithOptions Creates a webhook with the given delivery options
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// options      - The payload URL, events and delivery options of the webhook
+	// Return the created webhook, its token and an error, if occurred
+	CreateWebhookWithOptions(ctx context.Context, owner, repository string, options WebhookOptions) (WebhookInfo, string, error)
+
 	// DeleteWebhook Deletes a webhook
 	// owner        - User or organization
 	// repository   - VCS repository name
 	// webhookID    - The webhook ID returned from a previous CreateWebhook command
 	DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error
 
+	// ListWebhooks Returns the webhooks of a repository
+	// owner        - User or organization
+	// repository   - VCS repository name
+	ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error)
+
+	// SetWebhookActive Activates or deactivates a webhook without changing its configuration
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// webhookID    - The webhook ID returned from a previous CreateWebhook command
+	// active       - Whether the webhook should be delivered
+	SetWebhookActive(ctx context.Context, owner, repository, webhookID string, active bool) error
+
+	// CreateOrgWebhook Creates a webhook for all the repositories of an organization.
+	// Bitbucket server webhooks are created on the project and Bitbucket cloud webhooks on the workspace.
+	// owner        - Organization, group, project or workspace
+	// options      - The payload URL, events and delivery options of the webhook
+	// Return the created webhook, its token and an error, if occurred
+	CreateOrgWebhook(ctx context.Context, owner string, options WebhookOptions) (WebhookInfo, string, error)
+
+	// ListOrgWebhooks Returns the webhooks of an organization
+	// owner        - Organization, group, project or workspace
+	ListOrgWebhooks(ctx context.Context, owner string) ([]WebhookInfo, error)
+
+	// DeleteOrgWebhook Deletes a webhook of an organization
+	// owner        - Organization, group, project or workspace
+	// webhookID    - The webhook ID returned from a previous CreateOrgWebhook command
+	DeleteOrgWebhook(ctx context.Context, owner, webhookID string) error
+
 	// SetCommitStatus Sets commit status
 	// commitStatus - One of Pass, Fail, Error, or InProgress
 	// owner        - User or organization
@@ -109,6 +475,69 @@ type VcsClient interface {
 	// detailsUrl   - The URL for component status link
 	SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error
 
+	// SetCommitStatuses Sets several commit statuses on a commit, stopping at the first failure.
+	// The VCS providers don't support setting several statuses in one request, so the statuses are set one by one.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - SHA, a branch name, or a tag name.
+	// statuses   - The statuses to set, identified by their title
+	SetCommitStatuses(ctx context.Context, owner, repository, ref string, statuses []CommitStatusInfo) error
+
+	// ListCommitStatuses Lists the latest status of each title on a commit. On GitHub, the results of check runs are included.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - SHA, a branch name, or a tag name. On Bitbucket, only a SHA is supported.
+	ListCommitStatuses(ctx context.Context, owner, repository, ref string) ([]CommitStatusInfo, error)
+
+	// CreateCheckRun Creates a check run on a commit, and returns its ID.
+	// On VCS providers other than GitHub, a commit status is set instead, titled by the name of the check run, and the returned ID is 0.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - The SHA of the commit
+	// checkRun   - The details of the check run
+	CreateCheckRun(ctx context.Context, owner, repository, ref string, checkRun CheckRunInfo) (int64, error)
+
+	// UpdateCheckRun Updates a check run created by CreateCheckRun. The annotations are added to the existing annotations of the check run.
+	// On VCS providers other than GitHub, the commit status of the check run is set instead.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - The SHA of the commit
+	// checkRunID - The ID returned by CreateCheckRun
+	// checkRun   - The details of the check run
+	UpdateCheckRun(ctx context.Context, owner, repository, ref string, checkRunID int64, checkRun CheckRunInfo) error
+
+	// CreateCodeInsightsReport Creates a Code Insights report on a commit, which appears on the commit and its pull requests.
+	// A report with the ID of an existing report of the commit replaces it. Supported on Bitbucket only.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// sha        - The SHA of the commit
+	// report     - The details of the report
+	CreateCodeInsightsReport(ctx context.Context, owner, repository, sha string, report CodeInsightsReport) error
+
+	// AddCodeInsightsAnnotations Adds annotations of specific lines of files to a Code Insights report, which appear inline in pull requests.
+	// Supported on Bitbucket only.
+	// owner       - User or organization
+	// repository  - VCS repository name
+	// sha         - The SHA of the commit
+	// reportID    - The ID of the report
+	// annotations - The annotations to add
+	AddCodeInsightsAnnotations(ctx context.Context, owner, repository, sha, reportID string, annotations []CodeInsightsAnnotation) error
+
+	// TriggerPipeline Runs a CI pipeline on a branch or a tag, such as a GitHub workflow or a GitLab pipeline
+	// owner      - User or organization
+	// repository - VCS repository name
+	// pipeline   - The workflow file name or ID on GitHub, the pipeline ID on Azure Repos, or the name of a custom pipeline on Bitbucket Cloud.
+	//              Ignored on GitLab, which runs the pipeline of the repository
+	// ref        - The branch or tag to run the pipeline on. On Bitbucket Cloud, only a branch is supported.
+	// inputs     - The inputs of the GitHub workflow, or the variables of the pipeline
+	TriggerPipeline(ctx context.Context, owner, repository, pipeline, ref string, inputs map[string]string) (PipelineInfo, error)
+
+	// GetPipelineStatus Gets the state of a pipeline run
+	// owner      - User or organization
+	// repository - VCS repository name
+	// runID      - The ID of the pipeline run, as returned by TriggerPipeline
+	GetPipelineStatus(ctx context.Context, owner, repository, runID string) (PipelineInfo, error)
+
 	// DownloadRepository Downloads and extracts a VCS repository
 	// owner      - User or organization
 	// repository - VCS repository name
@@ -116,6 +545,31 @@ type VcsClient interface {
 	// localPath  - Local file system path
 	DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error
 
+	// DownloadRepositoryAtRef Downloads and extracts a VCS repository at a branch, a tag or a commit
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - SHA, a branch name, or a tag name
+	// localPath  - Local file system path
+	// format     - The format of the downloaded archive. Azure Repos supports Zip only
+	DownloadRepositoryAtRef(ctx context.Context, owner, repository, ref, localPath string, format ArchiveFormat) error
+
+	// GetRepositoryArchive Gets an archive of a VCS repository at a branch, a tag or a commit, as a stream.
+	// The archive isn't loaded into memory, so it can be piped into extraction or storage. The caller must close the returned reader.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - SHA, a branch name, or a tag name
+	// format     - The format of the archive. Azure Repos supports Zip only
+	GetRepositoryArchive(ctx context.Context, owner, repository, ref string, format ArchiveFormat) (io.ReadCloser, error)
+
+	// DownloadRepositoryPath Downloads and extracts a single directory of a VCS repository at a branch, a tag or a commit.
+	// The directory is extracted under the local path at its path in the repository. No .git folder is created.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - SHA, a branch name, or a tag name
+	// path       - The path of the directory in the repository
+	// localPath  - Local file system path
+	DownloadRepositoryPath(ctx context.Context, owner, repository, ref, path, localPath string) error
+
 	// CreatePullRequest Creates a pull request between 2 different branches in the same repository
 	// owner        - User or organization
 	// repository   - VCS repository name
@@ -125,6 +579,66 @@ type VcsClient interface {
 	// description  - Pull request description
 	CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error
 
+	// CreateDraftPullRequest Creates a draft pull request between 2 different branches in the same repository
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// sourceBranch - Source branch
+	// targetBranch - Target branch
+	// title        - Pull request title
+	// description  - Pull request description
+	CreateDraftPullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error
+
+	// MarkPullRequestReady Marks a draft pull request as ready for review. Does nothing if the pull request isn't a draft
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	MarkPullRequestReady(ctx context.Context, owner, repository string, pullRequestID int) error
+
+	// MergePullRequest Merges a pull request into its target branch
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	// mergeStrategy - One of MergeCommit, SquashMerge or RebaseMerge
+	// commitMessage - The merge or squash commit message. If empty, the provider's default message is used
+	MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy, commitMessage string) error
+
+	// EnablePullRequestAutoMerge Merges a pull request automatically once its required checks and reviews pass
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	// mergeStrategy - One of MergeCommit, SquashMerge or RebaseMerge
+	EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeStrategy MergeStrategy) error
+
+	// DisablePullRequestAutoMerge Cancels the automatic merge of a pull request
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	DisablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int) error
+
+	// UpdatePullRequest Updates the details of an existing pull request. Empty values are left unchanged
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// title         - The new pull request title
+	// body          - The new pull request description
+	// targetBranch  - The new target branch
+	// pullRequestID - Pull request ID
+	// state         - PullRequestOpen to reopen or PullRequestClosed to close the pull request. Nil to keep the current state
+	UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranch string, pullRequestID int, state *PullRequestState) error
+
+	// ApprovePullRequest Approves a pull request as the authenticated user
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	ApprovePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error
+
+	// SubmitPullRequestReview Submits a review on a pull request as the authenticated user
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	// reviewEvent   - One of ReviewApprove, ReviewRequestChanges or ReviewComment
+	// body          - The review comment. Required for ReviewComment
+	SubmitPullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, reviewEvent ReviewEvent, body string) error
+
 	// AddPullRequestComment Adds a new comment on the requested pull request
 	// owner          - User or organization
 	// repository     - VCS repository name
@@ -138,11 +652,128 @@ type VcsClient interface {
 	// pullRequestID  - Pull request ID
 	ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error)
 
+	// UpdatePullRequestComment Replaces the content of a pull request comment
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// content        - The new comment content
+	// pullRequestID  - Pull request ID
+	// commentID      - Comment ID, as returned by ListPullRequestComments
+	UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int, commentID int64) error
+
+	// DeletePullRequestComment Deletes a pull request comment
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// commentID      - Comment ID, as returned by ListPullRequestComments
+	DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) error
+
+	// AddCommentReaction Adds a reaction of the authenticated user to a pull request comment
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// commentID      - Comment ID, as returned by ListPullRequestComments
+	// reaction       - The reaction, such as ReactionThumbsUp or ReactionRocket
+	AddCommentReaction(ctx context.Context, owner, repository string, pullRequestID int, commentID int64, reaction Reaction) error
+
+	// ListCommentReactions Lists the reactions to a pull request comment
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// commentID      - Comment ID, as returned by ListPullRequestComments
+	ListCommentReactions(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) ([]ReactionInfo, error)
+
+	// AddPullRequestReviewComment Adds a new comment on a file line, or a range of lines, in the pull request diff
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// comment        - The comment content, file path and lines
+	AddPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) error
+
+	// ListPullRequestReviewComments Gets all comments anchored to file lines in the pull request diff
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewComment, error)
+
+	// CreatePullRequestThread Opens a resolvable thread on a pull request, and returns its ID.
+	// On GitLab, unresolved threads block merging if the project requires all threads to be resolved.
+	// Supported on GitHub, where the thread is a review thread, and on GitLab, where it's a discussion.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	// comment       - The first comment of the thread. Without a path, the thread isn't anchored to the diff, which is supported on GitLab only
+	CreatePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestReviewComment) (string, error)
+
+	// ResolvePullRequestThread Resolves or reopens a thread created by CreatePullRequestThread
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	// threadID      - The ID returned by CreatePullRequestThread
+	// resolved      - True to resolve the thread, false to reopen it
+	ResolvePullRequestThread(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error
+
 	// ListOpenPullRequests Gets all open pull requests ids.
 	// owner          - User or organization
 	// repository     - VCS repository name
 	ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error)
 
+	// ListOpenPullRequestsWithFilter Gets the open pull requests matching the filter, with all the details returned by GetPullRequestByID.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// filter         - Filters and pagination of the returned pull requests
+	ListOpenPullRequestsWithFilter(ctx context.Context, owner, repository string, filter PullRequestFilter) ([]PullRequestInfo, error)
+
+	// ListOpenPullRequestsPager Iterates over the open pull requests matching the filter, page by page, starting at the filter's page
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// filter         - Filters and pagination of the returned pull requests
+	ListOpenPullRequestsPager(owner, repository string, filter PullRequestFilter) *Pager[PullRequestInfo]
+
+	// GetPullRequestByID Gets the details of a pull request
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestInfo, error)
+
+	// GetPullRequestDetails Gets a pull request, along with its head commit, changed files, reviews and commit statuses.
+	// On GitHub, the details are read using a single GraphQL query if the client is built with UseGraphQL.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	GetPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestDetails, error)
+
+	// ListPullRequestFiles Gets all files changed in a pull request
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error)
+
+	// GetPullRequestMergeableState Gets whether a pull request can be merged, and what prevents it from being merged
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	GetPullRequestMergeableState(ctx context.Context, owner, repository string, pullRequestID int) (PullRequestMergeableState, error)
+
+	// GetPullRequestDiff Gets the unified diff of a pull request
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error)
+
+	// ListPullRequestCommits Gets all commits of a pull request
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	ListPullRequestCommits(ctx context.Context, owner, repository string, pullRequestID int) ([]CommitInfo, error)
+
+	// GetDefaultReviewers Gets the reviewers that the VCS provider adds by default to a pull request from the source branch to the target branch,
+	// such as the default reviewers of Bitbucket, the approval rules of GitLab or the code owners on GitHub and Gitea
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// sourceBranch - The branch the pull request merges from
+	// targetBranch - The branch the pull request merges into
+	GetDefaultReviewers(ctx context.Context, owner, repository, sourceBranch, targetBranch string) ([]string, error)
+
 	// GetLatestCommit Gets the most recent commit of a branch
 	// owner      - User or organization
 	// repository - VCS repository name
@@ -157,21 +788,132 @@ type VcsClient interface {
 	// permission - Access permission of the key: read or readWrite
 	AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error
 
-	// GetRepositoryInfo Returns information about repository.
+	// ListDeployKeys Returns the deploy keys of a repository
 	// owner      - User or organization
 	// repository - VCS repository name
-	GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error)
+	ListDeployKeys(ctx context.Context, owner, repository string) ([]DeployKeyInfo, error)
 
-	// GetCommitBySha Gets the commit by its SHA
+	// AddDeployKey Adds a deploy key to a repository, and returns the added key
 	// owner      - User or organization
 	// repository - VCS repository name
-	// sha        - The commit hash
-	GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error)
+	// title      - Name of the key
+	// key        - SSH public key
+	// readOnly   - If false, the key can also push to the repository. Bitbucket cloud supports read-only deploy keys only
+	AddDeployKey(ctx context.Context, owner, repository, title, key string, readOnly bool) (DeployKeyInfo, error)
 
-	// CreateLabel Creates a label in repository
+	// DeleteDeployKey Deletes a deploy key from a repository
 	// owner      - User or organization
 	// repository - VCS repository name
-	// labelInfo  - The label info
+	// keyID      - The ID of the deploy key
+	DeleteDeployKey(ctx context.Context, owner, repository string, keyID int) error
+
+	// GetRepositoryInfo Returns information about repository.
+	// owner      - User or organization
+	// repository - VCS repository name
+	GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error)
+
+	// GetRepositoryLanguages Gets the share of each language in the code of a repository, as a percentage
+	// owner      - User or organization
+	// repository - VCS repository name
+	GetRepositoryLanguages(ctx context.Context, owner, repository string) (map[string]float64, error)
+
+	// CreateRepository Creates a new repository
+	// owner   - User or organization. On Bitbucket server, the project key. Ignored on Azure Repos, where the repository is created in the project of the client
+	// name    - The name of the new repository
+	// options - The settings of the new repository
+	CreateRepository(ctx context.Context, owner, name string, options CreateRepositoryOptions) error
+
+	// DeleteRepository Deletes a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	DeleteRepository(ctx context.Context, owner, repository string) error
+
+	// ForkRepository Forks a repository and returns the details of the fork
+	// owner          - User or organization of the forked repository
+	// repository     - VCS repository name
+	// targetOwner    - Organization to fork the repository into. On Bitbucket server, the project key. If empty, the repository is forked into the account of the authenticated user
+	// waitUntilReady - Wait until the content of the fork is available, or until the context is done
+	ForkRepository(ctx context.Context, owner, repository, targetOwner string, waitUntilReady bool) (ForkInfo, error)
+
+	// ListCollaborators Returns the users with access to a repository, and their permissions
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error)
+
+	// AddCollaborator Grants a user access to a repository. If the user is already a collaborator, the permission is updated
+	// owner      - User or organization
+	// repository - VCS repository name
+	// username   - The user to add. On Bitbucket cloud, the account ID of the user
+	// permission - Read, write or admin permission
+	AddCollaborator(ctx context.Context, owner, repository, username string, permission RepositoryPermission) error
+
+	// RemoveCollaborator Revokes the access of a user to a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// username   - The user to remove. On Bitbucket cloud, the account ID of the user
+	RemoveCollaborator(ctx context.Context, owner, repository, username string) error
+
+	// GetUserPermission Returns the permission of a user on a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// username   - The user. On Bitbucket cloud, the account ID of the user
+	GetUserPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error)
+
+	// GetCommitBySha Gets the commit by its SHA
+	// owner      - User or organization
+	// repository - VCS repository name
+	// sha        - The commit hash
+	GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error)
+
+	// ListCommits Gets the commits of a repository, starting from the most recent one
+	// owner      - User or organization
+	// repository - VCS repository name
+	// options    - Filters and pagination of the commits
+	ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error)
+
+	// GetCommit Gets the commit by its SHA, including the files it changed
+	// owner      - User or organization
+	// repository - VCS repository name
+	// sha        - The commit hash
+	GetCommit(ctx context.Context, owner, repository, sha string) (CommitDetails, error)
+
+	// GetCommitVerification Gets whether a commit is signed, and whether the VCS provider verified its signature
+	// owner      - User or organization
+	// repository - VCS repository name
+	// sha        - The commit hash
+	GetCommitVerification(ctx context.Context, owner, repository, sha string) (CommitVerificationInfo, error)
+
+	// CompareCommits Gets the commits and files that head introduces since its common ancestor with base
+	// owner      - User or organization
+	// repository - VCS repository name
+	// base       - The commit, branch or tag to compare against
+	// head       - The commit, branch or tag to compare
+	CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error)
+
+	// GetCommitsBetween Gets the commits reachable from toSha that aren't reachable from fromSha, starting from the most recent one.
+	// Unlike CompareCommits, the changed files aren't fetched, which suits listing the commits of a push event.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// fromSha    - The commit the range starts after, such as the BeforeCommit of a push webhook
+	// toSha      - The last commit of the range, such as the Commit of a push webhook
+	GetCommitsBetween(ctx context.Context, owner, repository, fromSha, toSha string) ([]CommitInfo, error)
+
+	// ListContributors Gets the authors of the commits of the default branch, starting from the one with the most commits.
+	// On providers without a contributors API, the contributors are counted from the whole commit history.
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListContributors(ctx context.Context, owner, repository string) ([]ContributorInfo, error)
+
+	// GetCommitActivity Gets the number of commits of each author per week on the default branch, ordered by week
+	// owner      - User or organization
+	// repository - VCS repository name
+	// since      - Only the weeks that end after this time are returned. If zero, the whole history is returned
+	GetCommitActivity(ctx context.Context, owner, repository string, since time.Time) ([]CommitActivityInfo, error)
+
+	// CreateLabel Creates a label in repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// labelInfo  - The label info
 	CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error
 
 	// GetLabel Gets a label related to a repository. Returns (nil, nil) if label doesn't exist.
@@ -180,6 +922,30 @@ type VcsClient interface {
 	// name       - Label name
 	GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error)
 
+	// ListLabels Gets all the labels of a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error)
+
+	// ListLabelsPager Returns a pager over the labels of a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// perPage    - The maximum number of labels per page. If 0, the VCS provider's default is used
+	ListLabelsPager(owner, repository string, perPage int) *Pager[LabelInfo]
+
+	// UpdateLabel Renames a label, or changes its description or color
+	// owner      - User or organization
+	// repository - VCS repository name
+	// name       - The current label name
+	// labelInfo  - The new label info. Empty fields are left unchanged
+	UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error
+
+	// DeleteLabel Deletes a label from a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// name       - Label name
+	DeleteLabel(ctx context.Context, owner, repository, name string) error
+
 	// ListPullRequestLabels Gets all labels assigned to a pull request.
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -193,6 +959,34 @@ type VcsClient interface {
 	// pullRequestID - Pull request ID
 	UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error
 
+	// CreateIssue Creates an issue
+	// owner      - User or organization
+	// repository - VCS repository name
+	// title      - Issue title
+	// body       - Issue description
+	// labels     - The names of existing labels to add to the issue
+	CreateIssue(ctx context.Context, owner, repository, title, body string, labels []string) (IssueInfo, error)
+
+	// AddIssueComment Adds a comment to an issue
+	// owner      - User or organization
+	// repository - VCS repository name
+	// content    - The content of the comment
+	// issueID    - Issue ID
+	AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error
+
+	// ListIssues Lists the issues of a repository that pass the filter, without pull requests
+	// owner      - User or organization
+	// repository - VCS repository name
+	// filter     - Narrows down the returned issues. If a page is requested, only that page is returned
+	ListIssues(ctx context.Context, owner, repository string, filter IssueFilter) ([]IssueInfo, error)
+
+	// UpdateIssueState Closes or reopens an issue
+	// owner      - User or organization
+	// repository - VCS repository name
+	// issueID    - Issue ID
+	// state      - The new state of the issue
+	UpdateIssueState(ctx context.Context, owner, repository string, issueID int, state IssueState) error
+
 	// UploadCodeScanning Upload Scanning Analysis uploads a scanning analysis file to the relevant git provider
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -200,6 +994,22 @@ type VcsClient interface {
 	// scan  		 - Code scanning analysis
 	UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error)
 
+	// UploadScanResults Uploads SARIF scan results of the latest commit of a branch, and returns the ID of the upload.
+	// On GitHub, the results are uploaded to code scanning, like UploadCodeScanning. On Bitbucket, they are reported as a Code Insights report,
+	// with an annotation of each finding, and the ID of the report is returned. On GitLab, a summary of the findings is commented on the commit,
+	// and the ID of the discussion of the comment is returned.
+	// owner       - User or organization
+	// repository  - VCS repository name
+	// branch      - The name of the branch
+	// scanResults - The scan results, in the SARIF format
+	UploadScanResults(ctx context.Context, owner, repository, branch, scanResults string) (string, error)
+
+	// ListSecurityAlerts returns the open security alerts of a repository, such as GitHub Dependabot and code scanning alerts,
+	// and GitLab vulnerability findings
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListSecurityAlerts(ctx context.Context, owner, repository string) ([]SecurityAlertInfo, error)
+
 	// DownloadFileFromRepo Downloads a file from path in a repository
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -207,8 +1017,69 @@ type VcsClient interface {
 	// path  		 - The path to the requested file
 	DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error)
 
+	// GetFileContent Gets the content and metadata of a single file in a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - Branch name, tag or commit SHA
+	// path       - The path to the requested file
+	GetFileContent(ctx context.Context, owner, repository, ref, path string) (FileContent, error)
+
+	// GetFileBlame Gets the commit that last changed each line of a file, ordered by line number
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - Branch name, tag or commit SHA
+	// path       - The path to the file
+	GetFileBlame(ctx context.Context, owner, repository, ref, path string) ([]BlameLine, error)
+
+	// ListRepositoryTree Gets the files, directories and submodules of a repository, without downloading its content
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - Branch name, tag or commit SHA
+	// recursive  - If true, the whole tree is returned. Otherwise, only the entries of the root directory are returned
+	ListRepositoryTree(ctx context.Context, owner, repository, ref string, recursive bool) ([]TreeEntry, error)
+
+	// SearchCode Searches the code of a repository, or of all the repositories of an owner, in their default branches
+	// scope - The owner and repository to search in, and the pagination of the results
+	// query - The text to search for
+	SearchCode(ctx context.Context, scope CodeSearchScope, query string) ([]CodeSearchResult, error)
+
+	// CreateOrUpdateFile Creates a new file or updates an existing one on a branch, in a new commit
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// branch        - The branch to commit to
+	// path          - The path to the file in the repository
+	// content       - The new content of the file
+	// commitMessage - The commit message
+	CreateOrUpdateFile(ctx context.Context, owner, repository, branch, path string, content []byte, commitMessage string) error
+
+	// CommitFiles Creates a single commit on a branch, containing multiple file additions, updates and deletions
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// branch        - The branch to commit to
+	// commitMessage - The commit message
+	// changes       - The file changes to include in the commit
+	CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, changes []FileChange) error
+
 	// GetRepositoryEnvironmentInfo Gets the environment info configured for a repository
 	GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error)
+
+	// ListEnvironments Lists the deployment environments of a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error)
+
+	// CreateDeployment Records a deployment of a ref to an environment, and returns the ID of the deployment
+	// owner      - User or organization
+	// repository - VCS repository name
+	// deployment - The details of the deployment
+	CreateDeployment(ctx context.Context, owner, repository string, deployment DeploymentInfo) (int64, error)
+
+	// SetDeploymentStatus Sets the status of a deployment, such as when it starts, succeeds or fails
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// deploymentID - The ID returned by CreateDeployment
+	// status       - The status of the deployment
+	SetDeploymentStatus(ctx context.Context, owner, repository string, deploymentID int64, status DeploymentStatusInfo) error
 }
 
 // CommitInfo contains the details of a commit
@@ -229,27 +1100,704 @@ type CommitInfo struct {
 	ParentHashes []string
 }
 
+// ContributorInfo is an author of commits in a repository
+type ContributorInfo struct {
+	// The username on GitHub, and the author name on the other VCS providers
+	Name string
+	// The author email. Provided by GitLab only
+	Email string
+	// The number of commits authored
+	Commits int
+}
+
+// CommitActivityInfo is the number of commits an author made during a single week
+type CommitActivityInfo struct {
+	// The username on GitHub, and the author name on the other VCS providers
+	Author string
+	// The start of the week, on Sunday at midnight UTC, in seconds from epoch
+	Week    int64
+	Commits int
+}
+
+// CommitStatusInfo contains the details of a commit status
+type CommitStatusInfo struct {
+	// One of Pass, Fail, Error, or InProgress
+	State CommitStatus
+	// The title of the commit status, which identifies it on the commit
+	Title       string
+	Description string
+	// The URL for component status link
+	DetailsURL string
+	// Zero if not reported by the VCS provider
+	CreatedAt time.Time
+	// Zero if not reported by the VCS provider
+	LastUpdatedAt time.Time
+}
+
+// AnnotationLevel is the severity of a check run annotation
+type AnnotationLevel int
+
+const (
+	// AnnotationNotice is an informational annotation
+	AnnotationNotice AnnotationLevel = iota
+	// AnnotationWarning is an annotation of an issue that doesn't fail the check
+	AnnotationWarning
+	// AnnotationFailure is an annotation of an issue that fails the check
+	AnnotationFailure
+)
+
+// CheckRunInfo contains the details of a check run, which reports the results of a tool such as a scanner
+type CheckRunInfo struct {
+	// The name of the check run, which identifies it on the commit
+	Name string
+	// One of Pass, Fail, Error, or InProgress. The check run is completed unless InProgress.
+	State CommitStatus
+	// The title of the results
+	Title string
+	// The summary of the results, in Markdown
+	Summary string
+	// The URL of the full details of the results
+	DetailsURL string
+	// Annotations of specific lines of files. Supported only on GitHub.
+	Annotations []CheckRunAnnotation
+}
+
+// CheckRunAnnotation is an annotation of lines in a file, reported by a check run
+type CheckRunAnnotation struct {
+	// The path of the annotated file, relative to the repository root
+	Path      string
+	StartLine int
+	// Equals StartLine if the annotation is of a single line
+	EndLine int
+	Level   AnnotationLevel
+	Title   string
+	Message string
+}
+
+// CodeInsightsReport is a Bitbucket Code Insights report, which reports the results of a tool such as a scanner on a commit
+type CodeInsightsReport struct {
+	// The ID of the report, which identifies it on the commit
+	ID    string
+	Title string
+	// The summary of the results
+	Details string
+	// The name of the tool that created the report
+	Reporter string
+	// The URL of the full details of the results
+	Link string
+	// One of Pass, Fail or InProgress. Bitbucket server reports have no result while InProgress.
+	Result CommitStatus
+}
+
+// CodeInsightsAnnotationType is the kind of issue that a Code Insights annotation reports
+type CodeInsightsAnnotationType int
+
+const (
+	CodeInsightsVulnerability CodeInsightsAnnotationType = iota
+	CodeInsightsCodeSmell
+	CodeInsightsBug
+)
+
+// CodeInsightsAnnotation is an annotation of a line in a file, reported by a Code Insights report
+type CodeInsightsAnnotation struct {
+	// The ID of the annotation, which identifies it in the report
+	ExternalID string
+	Type       CodeInsightsAnnotationType
+	Severity   SecuritySeverity
+	// The path of the annotated file, relative to the repository root. Empty if the annotation is of the whole report
+	Path string
+	// Zero if the annotation is of the whole file
+	Line    int
+	Message string
+	// Additional details of the annotation. On Bitbucket server, they are appended to the message
+	Details string
+	// The URL of the full details of the annotation
+	Link string
+}
+
+// PipelineInfo contains the details of a pipeline run, such as a GitHub workflow run or a GitLab pipeline
+type PipelineInfo struct {
+	// The ID of the run, which is passed to GetPipelineStatus
+	ID string
+	// The branch or tag the pipeline runs on
+	Ref string
+	// One of Pass, Fail, Error, or InProgress. The run is completed unless InProgress.
+	State CommitStatus
+	// The URL of the run in the VCS provider UI. Empty if not provided by the VCS provider
+	WebURL string
+}
+
 type CommentInfo struct {
 	ID      int64
 	Content string
 	Created time.Time
 }
 
+// Reaction is an emoji reaction to a comment. The values are the reactions of GitHub.
+// On GitLab, the reactions are award emoji, and award emoji without a matching reaction are listed by their names.
+type Reaction string
+
+const (
+	ReactionThumbsUp   Reaction = "+1"
+	ReactionThumbsDown Reaction = "-1"
+	ReactionLaugh      Reaction = "laugh"
+	ReactionConfused   Reaction = "confused"
+	ReactionHeart      Reaction = "heart"
+	ReactionHooray     Reaction = "hooray"
+	ReactionRocket     Reaction = "rocket"
+	ReactionEyes       Reaction = "eyes"
+)
+
+// ReactionInfo is a reaction of a user to a comment
+type ReactionInfo struct {
+	Reaction Reaction
+	// The username of the user who reacted
+	User string
+}
+
+// PullRequestReviewComment is a pull request comment anchored to a file line, or a range of lines, in the pull request diff
+type PullRequestReviewComment struct {
+	CommentInfo
+	// The path of the commented file, relative to the repository root
+	Path string
+	// The commented line in the new version of the file. For a range of lines, the last line of the range
+	Line int
+	// The first line of a commented range of lines. Zero for a single line comment
+	StartLine int
+}
+
+// PullRequestState is the state of a pull request
+type PullRequestState int
+
+const (
+	PullRequestOpen PullRequestState = iota
+	PullRequestClosed
+	PullRequestMerged
+)
+
 type PullRequestInfo struct {
 	ID     int64
 	Source BranchInfo
 	Target BranchInfo
+	Draft  bool
+	// The following fields are populated by GetPullRequestByID and ListOpenPullRequestsWithFilter only
+	Title  string
+	Body   string
+	State  PullRequestState
+	Author string
+	Labels []string
+	// Whether the pull request can be merged without conflicts. Nil if the VCS provider didn't compute it
+	Mergeable *bool
+}
+
+// PullRequestDetails is a pull request, along with the details that most VCS providers return in separate requests
+type PullRequestDetails struct {
+	PullRequestInfo
+	// The head commit of the source branch
+	HeadCommit CommitInfo
+	Files      []PullRequestFile
+	// The submitted reviews, from the oldest to the most recent one. Reported on GitHub only
+	Reviews []PullRequestReviewInfo
+	// The commit statuses and check runs of the head commit. Not reported on AWS CodeCommit
+	Statuses []CommitStatusInfo
+}
+
+// PullRequestReviewInfo is a review submitted on a pull request
+type PullRequestReviewInfo struct {
+	Reviewer string
+	// One of ReviewApprove, ReviewRequestChanges or ReviewComment
+	State ReviewEvent
+}
+
+// PullRequestMergeableState describes whether a pull request can be merged, and what prevents it from being merged
+type PullRequestMergeableState struct {
+	// Whether the pull request can be merged now. False while the VCS provider computes it
+	Mergeable bool
+	// Whether the source branch conflicts with the target branch
+	HasConflicts bool
+	// Whether the target branch has commits that the source branch doesn't have. Not reported on Bitbucket and Azure Repos
+	BehindTarget bool
+	// The required status checks that didn't pass on the head commit of the pull request. Reported on GitHub and Gitea only
+	MissingStatusChecks []string
+	// The number of approvals still required to merge the pull request. Not reported on Bitbucket Server and Azure Repos
+	MissingApprovals int
+}
+
+// isMergeable returns true if no required status checks or approvals are missing, and the pull request has no conflicts
+func (state PullRequestMergeableState) isMergeable() bool {
+	return !state.HasConflicts && len(state.MissingStatusChecks) == 0 && state.MissingApprovals == 0
+}
+
+// getMissingApprovals returns the number of approvals still required, given the number of approvals the pull request has
+func getMissingApprovals(requiredApprovals, approvals int) int {
+	if approvals >= requiredApprovals {
+		return 0
+	}
+	return requiredApprovals - approvals
+}
+
+// PullRequestFilter narrows down the pull requests returned by ListOpenPullRequestsWithFilter. Empty fields are ignored.
+// Filters that the VCS provider's API doesn't support are applied to each fetched page,
+// so a page may contain fewer pull requests than PerPage.
+type PullRequestFilter struct {
+	// The username of the pull request author
+	Author       string
+	SourceBranch string
+	TargetBranch string
+	Label        string
+	// Only pull requests updated at or after this time are returned
+	UpdatedSince time.Time
+	Page         int
+	// The maximum number of pull requests per page. If 0, the VCS provider's default is used
+	PerPage int
+}
+
+// matches checks that a pull request, last updated at the given time, passes the filter
+func (filter PullRequestFilter) matches(pullRequest PullRequestInfo, updated time.Time) bool {
+	if filter.Author != "" && !strings.EqualFold(filter.Author, pullRequest.Author) {
+		return false
+	}
+	if filter.SourceBranch != "" && filter.SourceBranch != pullRequest.Source.Name {
+		return false
+	}
+	if filter.TargetBranch != "" && filter.TargetBranch != pullRequest.Target.Name {
+		return false
+	}
+	if filter.Label != "" && !containsLabel(pullRequest.Labels, filter.Label) {
+		return false
+	}
+	return filter.UpdatedSince.IsZero() || !updated.Before(filter.UpdatedSince)
+}
+
+func containsLabel(labels []string, label string) bool {
+	for _, currentLabel := range labels {
+		if currentLabel == label {
+			return true
+		}
+	}
+	return false
+}
+
+// IssueState is the state of an issue
+type IssueState int
+
+const (
+	IssueOpen IssueState = iota
+	IssueClosed
+)
+
+type IssueInfo struct {
+	// The issue number in the repository
+	ID      int64
+	Title   string
+	Body    string
+	State   IssueState
+	Author  string
+	Labels  []string
+	URL     string
+	Created time.Time
+}
+
+// IssueFilter narrows down the issues returned by ListIssues. Empty fields are ignored.
+// Filters that the VCS provider's API doesn't support are applied to each fetched page,
+// so a page may contain fewer issues than PerPage.
+type IssueFilter struct {
+	// If nil, both open and closed issues are returned
+	State *IssueState
+	// The username of the issue author
+	Author string
+	Label  string
+	Page   int
+	// The maximum number of issues per page. If 0, the VCS provider's default is used
+	PerPage int
+}
+
+// matches checks that an issue passes the filter
+func (filter IssueFilter) matches(issue IssueInfo) bool {
+	if filter.State != nil && *filter.State != issue.State {
+		return false
+	}
+	if filter.Author != "" && !strings.EqualFold(filter.Author, issue.Author) {
+		return false
+	}
+	return filter.Label == "" || containsLabel(issue.Labels, filter.Label)
 }
 
 type BranchInfo struct {
 	Name       string
 	Repository string
+	// The user or organization that owns the repository. Populated by GetPullRequestByID only
+	Owner string
+}
+
+// ListCommitsOptions narrows down the commits returned by ListCommits. Empty fields are ignored.
+// Filters that the VCS provider doesn't support are applied to each fetched page, so a page may contain fewer commits than PerPage.
+type ListCommitsOptions struct {
+	// The branch to list the commits of. If empty, the default branch is used
+	Branch string
+	// Only commits that changed this file or directory are returned
+	Path string
+	// The name or email of the commit author. GitHub matches the username or email only
+	Author string
+	// Only commits committed at or after this time are returned
+	Since time.Time
+	// Only commits committed at or before this time are returned
+	Until time.Time
+	Page  int
+	// The maximum number of commits per page. If 0, the VCS provider's default is used
+	PerPage int
+}
+
+// matches checks that a commit, committed at the given time by an author with the given names and emails, passes the options
+func (options ListCommitsOptions) matches(committed time.Time, authorIdentities ...string) bool {
+	if options.Author != "" && !containsFold(authorIdentities, options.Author) {
+		return false
+	}
+	if !options.Since.IsZero() && committed.Before(options.Since) {
+		return false
+	}
+	return options.Until.IsZero() || !committed.After(options.Until)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, currentValue := range values {
+		if strings.EqualFold(currentValue, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesBranchPattern checks whether a branch matches a branch pattern of a VCS provider, in which * matches any characters
+func matchesBranchPattern(pattern, branch string) bool {
+	expression := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	return regexp.MustCompile(expression).MatchString(branch)
+}
+
+// getSortedKeys returns the keys of a map in alphabetical order, so that requests built from the map are deterministic
+func getSortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CommitDetails contains the details of a commit, and the files it changed compared to its first parent
+type CommitDetails struct {
+	CommitInfo
+	// The total number of added and deleted lines. Zero if not provided by the VCS provider
+	Additions int
+	Deletions int
+	Files     []PullRequestFile
+}
+
+// CommitVerificationInfo describes the signature of a commit, and whether the VCS provider verified it
+type CommitVerificationInfo struct {
+	// True if the commit is signed, whether or not its signature is verified
+	Signed bool
+	// True if the VCS provider verified the signature with a key or a certificate of the signer
+	Verified bool
+	// The type of the signature. SignatureUnknown if the commit isn't signed
+	SignatureType SignatureType
+	// The verification status reported by the VCS provider, such as unknown_key, which explains why a signature isn't verified
+	Reason string
+	// The username of the signer on the VCS provider. Empty if the VCS provider doesn't report it
+	SignerUsername string
+	// The name of the signer. Empty if the VCS provider doesn't report it
+	SignerName string
+	// The email of the signer. Empty if the VCS provider doesn't report it
+	SignerEmail string
+}
+
+// getSignatureType returns the type of an ASCII armored signature
+func getSignatureType(signature string) SignatureType {
+	switch {
+	case strings.Contains(signature, "BEGIN PGP SIGNATURE"):
+		return SignatureGPG
+	case strings.Contains(signature, "BEGIN SSH SIGNATURE"):
+		return SignatureSSH
+	case strings.Contains(signature, "BEGIN SIGNED MESSAGE"), strings.Contains(signature, "BEGIN PKCS7"):
+		return SignatureX509
+	}
+	return SignatureUnknown
+}
+
+// CommitsComparison contains the differences between two commits
+type CommitsComparison struct {
+	// The number of commits in head that aren't in base
+	AheadBy int
+	// The number of commits in base that aren't in head
+	BehindBy int
+	// The commits in head that aren't in base
+	Commits []CommitInfo
+	// The files changed in head since its common ancestor with base
+	Files []PullRequestFile
+}
+
+// PullRequestFile contains the details of a file changed in a pull request or in a commits comparison
+type PullRequestFile struct {
+	Path string
+	// The path of the file before it was renamed. Empty if the file wasn't renamed
+	PreviousPath string
+	Status       FileStatus
+	// The number of added and deleted lines. Zero if not provided by the VCS provider
+	Additions int
+	Deletions int
+}
+
+// BranchDetails contains a branch name and the commit its head points to
+type BranchDetails struct {
+	Name string
+	// The SHA-1 hash of the head commit
+	CommitHash string
+}
+
+// TagInfo contains a tag name and the commit it points to
+type TagInfo struct {
+	Name string
+	// The SHA-1 hash of the tagged commit
+	CommitHash string
+	// The message of an annotated tag. Empty for lightweight tags, or if not provided by the VCS provider
+	Message string
+}
+
+// ReleaseInfo contains the details of a release
+type ReleaseInfo struct {
+	// The name of the tag the release points to
+	TagName string
+	// The branch name or commit SHA to create the tag from, if it doesn't exist. Used by CreateRelease only
+	Target      string
+	Name        string
+	Description string
+	// The URL of the release page. Empty if not provided by the VCS provider
+	Url string
+}
+
+// BranchProtection contains the protection rules of a branch
+type BranchProtection struct {
+	// The number of approving reviews required to merge a pull request. Zero if reviews aren't required
+	RequiredApprovingReviewCount int
+	// The names of the status checks that must pass before merging a pull request
+	RequiredStatusChecks []string
+	// If true, only the users in AllowedPushUsers may push to the branch
+	RestrictPushes bool
+	// The usernames allowed to push to the branch, if pushes are restricted. Empty means no one may push.
+	// On Bitbucket Cloud, the users are identified by their UUID
+	AllowedPushUsers []string
+}
+
+// TagProtection contains the protection rules of the tags that match a pattern
+type TagProtection struct {
+	// The usernames allowed to create, move and delete the matching tags. Empty means no one may, except for the administrators on some VCS providers.
+	// Not supported on GitHub, where only the users with the maintain or admin role may create protected tags.
+	AllowedUsers []string
+}
+
+// RequiredChecksInfo describes the commit statuses that must pass before a pull request can be merged into a branch.
+// All the fields are zero if the branch doesn't require any commit status.
+type RequiredChecksInfo struct {
+	// The titles of the commit statuses and check runs that must pass, which can be waited for with WaitForCommitStatus
+	Titles []string
+	// If true, all the commit statuses of the head commit must pass, such as when the VCS provider requires the pipeline or the builds to succeed without naming them
+	AllMustPass bool
+	// If true, the source branch must be up to date with the target branch
+	RequireUpToDate bool
+}
+
+// RequiredApprovalsInfo describes the approvals that a pull request needs before it can be merged into a branch.
+// All the fields are zero if the branch doesn't require approvals.
+type RequiredApprovalsInfo struct {
+	// The number of approvals required. On GitLab, the number of approvals of the approval rule that requires the most approvals
+	Count int
+	// The users whose approvals are counted. Empty if any user with write permissions may approve
+	Approvers []string
+	// If true, the code owners of the changed files must approve
+	RequireCodeOwners bool
+	// If true, the approvals are dismissed when new commits are pushed
+	DismissStaleApprovals bool
+}
+
+// FileContent contains the content of a single file in a repository, along with its metadata
+type FileContent struct {
+	Path    string
+	Content []byte
+	// The file size in bytes
+	Size int64
+	// The SHA-1 hash of the file blob. Empty if not provided by the VCS provider
+	Sha string
+}
+
+// TreeEntry is a file, directory or submodule in a repository tree
+type TreeEntry struct {
+	// The path from the root of the repository
+	Path string
+	Type TreeEntryType
+	// The file size in bytes. Zero for directories and submodules, and when not provided by the VCS provider
+	Size int64
+}
+
+// BlameLine is a line of a file, along with the commit that last changed it
+type BlameLine struct {
+	// The 1-based line number
+	Line int
+	// The SHA-1 hash of the commit that last changed the line
+	CommitHash  string
+	AuthorName  string
+	AuthorEmail string
+	// The author date of the commit, in seconds from epoch
+	Timestamp int64
+}
+
+// CodeSearchScope is where SearchCode searches, and the pagination of its results
+type CodeSearchScope struct {
+	// User, organization, group, workspace or project
+	Owner string
+	// If empty, all the repositories of the owner are searched
+	Repository string
+	Page       int
+	// The maximum number of results per page. If 0, the VCS provider's default is used
+	PerPage int
+}
+
+// CodeSearchResult is a file that matches a code search
+type CodeSearchResult struct {
+	// The user, organization or project that owns the repository
+	Owner      string
+	Repository string
+	Path       string
+	// The parts of the file content that match the query
+	Fragments []string
+}
+
+// FileChange is a single file change in a commit
+type FileChange struct {
+	Type FileChangeType
+	// The path to the file in the repository
+	Path string
+	// The new content of the file. Ignored when deleting a file
+	Content []byte
 }
 
 // RepositoryInfo contains general information about repository.
 type RepositoryInfo struct {
 	CloneInfo            CloneInfo
 	RepositoryVisibility RepositoryVisibility
+	// Empty if the repository has no branches
+	DefaultBranch string
+	Description   string
+	Archived      bool
+	// Topics aren't supported on Bitbucket
+	Topics []string
+	// The size of the repository in bytes, zero if the provider doesn't report it
+	Size int64
+}
+
+// ForkInfo is the details of a forked repository
+type ForkInfo struct {
+	// The user, organization or project that owns the fork
+	Owner string
+	// The name of the fork
+	Repository     string
+	RepositoryInfo RepositoryInfo
+}
+
+// RepositoryDetails is a repository returned by ListRepositoriesWithOptions
+type RepositoryDetails struct {
+	// The user, organization or project that owns the repository
+	Owner          string
+	Repository     string
+	RepositoryInfo RepositoryInfo
+	// The primary language of the repository. Empty if not reported by the VCS provider
+	Language string
+	// The time the repository was last updated. Zero if not reported by the VCS provider
+	Updated time.Time
+}
+
+// ListRepositoriesOptions narrows down the repositories returned by ListRepositoriesWithOptions. Empty fields are ignored.
+// Filters that the VCS provider's API doesn't support are applied to each fetched page,
+// so a page may contain fewer repositories than PerPage.
+type ListRepositoriesOptions struct {
+	// If nil, repositories of any visibility are returned
+	Visibility *RepositoryVisibility
+	// If nil, both archived and unarchived repositories are returned
+	Archived *bool
+	// The primary language of the repositories, case-insensitive. Supported on GitHub and Bitbucket Cloud only
+	Language string
+	// Only repositories updated at or after this time are returned. Not supported on Bitbucket Server
+	UpdatedSince time.Time
+	Page         int
+	// The maximum number of repositories per page. If 0, the VCS provider's default is used
+	PerPage int
+}
+
+// matches checks that a repository passes the options
+func (options ListRepositoriesOptions) matches(repository RepositoryDetails) bool {
+	if options.Visibility != nil && *options.Visibility != repository.RepositoryInfo.RepositoryVisibility {
+		return false
+	}
+	if options.Archived != nil && *options.Archived != repository.RepositoryInfo.Archived {
+		return false
+	}
+	if options.Language != "" && !strings.EqualFold(options.Language, repository.Language) {
+		return false
+	}
+	return options.UpdatedSince.IsZero() || !repository.Updated.Before(options.UpdatedSince)
+}
+
+// DeployKeyInfo is an SSH key with access to a single repository
+type DeployKeyInfo struct {
+	ID       int
+	Title    string
+	Key      string
+	ReadOnly bool
+}
+
+// WebhookContentType is the format of the webhook payload
+type WebhookContentType string
+
+const (
+	// WebhookContentTypeJSON delivers the payload as the request body
+	WebhookContentTypeJSON WebhookContentType = "json"
+	// WebhookContentTypeForm delivers the payload as a form parameter
+	WebhookContentTypeForm WebhookContentType = "form"
+)
+
+// WebhookInfo is the configuration of a repository webhook
+type WebhookInfo struct {
+	ID          string
+	PayloadURL  string
+	Events      []vcsutils.WebhookEvent
+	Active      bool
+	ContentType WebhookContentType
+	InsecureSSL bool
+}
+
+// WebhookOptions are the options of a new webhook
+type WebhookOptions struct {
+	PayloadURL string
+	Events     []vcsutils.WebhookEvent
+	// Filters the branches of push events, supported only on GitLab and Gitea
+	Branch string
+	// Defaults to WebhookContentTypeJSON
+	ContentType WebhookContentType
+	// Deliver the payload without verifying the SSL certificate of the payload URL
+	InsecureSSL bool
+}
+
+func (options WebhookOptions) contentType() WebhookContentType {
+	if options.ContentType == "" {
+		return WebhookContentTypeJSON
+	}
+	return options.ContentType
+}
+
+// CollaboratorInfo is a user with access to a repository
+type CollaboratorInfo struct {
+	// On Bitbucket cloud, the account ID of the user
+	Username   string
+	Permission RepositoryPermission
 }
 
 // CloneInfo contains URLs that can be used to clone the repository.
@@ -260,6 +1808,23 @@ type CloneInfo struct {
 	SSH string
 }
 
+// CreateRepositoryOptions are the settings of a repository created by CreateRepository.
+// Settings that the VCS provider doesn't support fail the creation, unless they are left empty.
+type CreateRepositoryOptions struct {
+	Description string
+	// If nil, the repository is private. On Azure Repos, repositories have the visibility of their project, so it must be nil
+	Visibility *RepositoryVisibility
+	// The name of the branch of the initial commit. Requires InitWithReadme. If empty, the VCS provider's default is used
+	DefaultBranch string
+	// Whether to initialize the repository with a commit that adds a README file
+	InitWithReadme bool
+}
+
+// isPrivate checks whether the repository should be created as private
+func (options CreateRepositoryOptions) isPrivate() bool {
+	return options.Visibility == nil || *options.Visibility == Private
+}
+
 // LabelInfo contains a label information
 type LabelInfo struct {
 	Name        string
@@ -268,6 +1833,201 @@ type LabelInfo struct {
 	Color string
 }
 
+// extractRepositoryArchive extracts a repository archive of the given format to the local path.
+// If dirPath isn't empty, only the files of this directory are extracted.
+func extractRepositoryArchive(archive io.Reader, format ArchiveFormat, localPath string, shouldRemoveBaseDir bool, dirPath string) error {
+	if format != Zip {
+		return vcsutils.UntarDirectory(localPath, archive, shouldRemoveBaseDir, dirPath)
+	}
+	// Zip archives can't be read sequentially
+	content, err := io.ReadAll(archive)
+	if err != nil {
+		return err
+	}
+	return vcsutils.UnzipDirectory(localPath, content, shouldRemoveBaseDir, dirPath)
+}
+
+// getPullRequestDetails reads the details of a pull request using separate requests.
+// The commit statuses are listed only if listStatuses is true, for VCS providers that support them.
+func getPullRequestDetails(ctx context.Context, client VcsClient, owner, repository string, pullRequestID int, listStatuses bool) (PullRequestDetails, error) {
+	pullRequest, err := client.GetPullRequestByID(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return PullRequestDetails{}, err
+	}
+	commits, err := client.ListPullRequestCommits(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return PullRequestDetails{}, err
+	}
+	details := PullRequestDetails{PullRequestInfo: pullRequest, HeadCommit: getHeadCommit(commits)}
+	if details.Files, err = client.ListPullRequestFiles(ctx, owner, repository, pullRequestID); err != nil {
+		return PullRequestDetails{}, err
+	}
+	if listStatuses && details.HeadCommit.Hash != "" {
+		if details.Statuses, err = client.ListCommitStatuses(ctx, owner, repository, details.HeadCommit.Hash); err != nil {
+			return PullRequestDetails{}, err
+		}
+	}
+	return details, nil
+}
+
+// getHeadCommit returns the head commit of a pull request, which isn't the parent of any of its other commits.
+// The VCS providers list the commits in different orders, so the order isn't relied on.
+// If the parents of the commits aren't reported, the most recent commit is returned.
+func getHeadCommit(commits []CommitInfo) CommitInfo {
+	parents := make(map[string]bool)
+	for _, commit := range commits {
+		for _, parent := range commit.ParentHashes {
+			parents[parent] = true
+		}
+	}
+	var head CommitInfo
+	for _, commit := range commits {
+		if !parents[commit.Hash] && (head.Hash == "" || commit.Timestamp > head.Timestamp) {
+			head = commit
+		}
+	}
+	return head
+}
+
+// setCommitStatusesOneByOne sets the commit statuses using SetCommitStatus, stopping at the first failure
+func setCommitStatusesOneByOne(ctx context.Context, client VcsClient, owner, repository, ref string, statuses []CommitStatusInfo) error {
+	for _, status := range statuses {
+		if err := client.SetCommitStatus(ctx, status.State, owner, repository, ref, status.Title, status.Description, status.DetailsURL); err != nil {
+			return fmt.Errorf("failed to set the commit status %q: %w", status.Title, err)
+		}
+	}
+	return nil
+}
+
+// setCheckRunCommitStatus sets a commit status instead of a check run, for VCS providers that don't support check runs.
+// The title of the check run, or its summary if it has no title, is used as the description of the commit status.
+func setCheckRunCommitStatus(ctx context.Context, client VcsClient, owner, repository, ref string, checkRun CheckRunInfo) error {
+	description := checkRun.Title
+	if description == "" {
+		description = checkRun.Summary
+	}
+	return client.SetCommitStatus(ctx, checkRun.State, owner, repository, ref, checkRun.Name, description, checkRun.DetailsURL)
+}
+
+// reverseCommits reverses the commits in place, for VCS providers that list them from the oldest one
+func reverseCommits(commits []CommitInfo) {
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+}
+
+// getLanguagePercentages converts the number of bytes of each language to its share of the code, as a percentage
+func getLanguagePercentages(languageBytes map[string]int64) map[string]float64 {
+	var total int64
+	for _, bytes := range languageBytes {
+		total += bytes
+	}
+	percentages := make(map[string]float64, len(languageBytes))
+	for language, bytes := range languageBytes {
+		if total > 0 {
+			percentages[language] = float64(bytes) * 100 / float64(total)
+		}
+	}
+	return percentages
+}
+
+// getGitTreeEntryType maps the type of a git tree object to the type of the entry
+func getGitTreeEntryType(objectType string) TreeEntryType {
+	switch objectType {
+	case "tree":
+		return TreeEntryDirectory
+	case "commit":
+		return TreeEntrySubmodule
+	}
+	return TreeEntryFile
+}
+
+// walkRepositoryTree lists the root directory of a repository, and its subdirectories if recursive is true,
+// for the VCS providers that list a single directory in each request. listDirectory gets the entries of a directory,
+// whose path is empty for the root directory.
+func walkRepositoryTree(ctx context.Context, recursive bool, listDirectory func(ctx context.Context, path string) ([]TreeEntry, error)) ([]TreeEntry, error) {
+	var tree []TreeEntry
+	directories := []string{""}
+	for len(directories) > 0 {
+		entries, err := listDirectory(ctx, directories[0])
+		if err != nil {
+			return nil, err
+		}
+		directories = directories[1:]
+		for _, entry := range entries {
+			tree = append(tree, entry)
+			if recursive && entry.Type == TreeEntryDirectory {
+				directories = append(directories, entry.Path)
+			}
+		}
+	}
+	return tree, nil
+}
+
+// listContributorsFromCommits counts the contributors of the default branch from its commits,
+// for the VCS providers that don't have a contributors API
+func listContributorsFromCommits(ctx context.Context, client VcsClient, owner, repository string) ([]ContributorInfo, error) {
+	commits, err := client.ListCommits(ctx, owner, repository, ListCommitsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var contributors []ContributorInfo
+	contributorIndexes := map[string]int{}
+	for _, commit := range commits {
+		index, exists := contributorIndexes[commit.AuthorName]
+		if !exists {
+			index = len(contributors)
+			contributorIndexes[commit.AuthorName] = index
+			contributors = append(contributors, ContributorInfo{Name: commit.AuthorName})
+		}
+		contributors[index].Commits++
+	}
+	sort.SliceStable(contributors, func(i, j int) bool { return contributors[i].Commits > contributors[j].Commits })
+	return contributors, nil
+}
+
+// getCommitActivityFromCommits counts the weekly commits of each author of the default branch,
+// for the VCS providers that don't have a commit activity API
+func getCommitActivityFromCommits(ctx context.Context, client VcsClient, owner, repository string, since time.Time) ([]CommitActivityInfo, error) {
+	commits, err := client.ListCommits(ctx, owner, repository, ListCommitsOptions{Since: getWeekStart(since)})
+	if err != nil {
+		return nil, err
+	}
+	var activity []CommitActivityInfo
+	activityIndexes := map[CommitActivityInfo]int{}
+	for _, commit := range commits {
+		key := CommitActivityInfo{Author: commit.AuthorName, Week: getWeekStart(time.Unix(commit.Timestamp, 0)).Unix()}
+		index, exists := activityIndexes[key]
+		if !exists {
+			index = len(activity)
+			activityIndexes[key] = index
+			activity = append(activity, key)
+		}
+		activity[index].Commits++
+	}
+	sortCommitActivity(activity)
+	return activity, nil
+}
+
+// getWeekStart returns the start of the week of t, on Sunday at midnight UTC. The zero time is returned as is.
+func getWeekStart(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day()-int(t.Weekday()), 0, 0, 0, 0, time.UTC)
+}
+
+// sortCommitActivity orders the commit activity by week, and the authors of each week by name
+func sortCommitActivity(activity []CommitActivityInfo) {
+	sort.Slice(activity, func(i, j int) bool {
+		if activity[i].Week != activity[j].Week {
+			return activity[i].Week < activity[j].Week
+		}
+		return activity[i].Author < activity[j].Author
+	})
+}
+
 func validateParametersNotBlank(paramNameValueMap map[string]string) error {
 	errorMessages := make([]string, 0)
 	for k, v := range paramNameValueMap {
@@ -280,3 +2040,128 @@ func validateParametersNotBlank(paramNameValueMap map[string]string) error {
 	}
 	return nil
 }
+
+// readyPollInterval is the time to wait between checks of a resource that isn't ready yet, such as a new fork
+const readyPollInterval = 2 * time.Second
+
+// pollUntilReady calls isReady until the resource is ready, isReady fails or the context is done
+func pollUntilReady(ctx context.Context, isReady func() (bool, error)) error {
+	for {
+		ready, err := isReady()
+		if err != nil || ready {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(readyPollInterval):
+		}
+	}
+}
+
+var allWebhookEvents = []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected,
+	vcsutils.PrCommentCreated, vcsutils.PrReviewed, vcsutils.Push, vcsutils.TagPushed, vcsutils.TagRemoved,
+	vcsutils.RepoForked, vcsutils.RepoUpdated, vcsutils.CommitStatusUpdated, vcsutils.ReleasePublished, vcsutils.ReleaseEdited,
+	vcsutils.DeploymentCreated, vcsutils.DeploymentStatusUpdated, vcsutils.CIRunCompleted,
+	vcsutils.BranchCreated, vcsutils.BranchDeleted}
+
+// mapProviderWebhookEvents returns the webhook events whose provider events are all subscribed by a webhook.
+// getProviderEvents is the provider's mapping of webhook events, used when creating a webhook.
+func mapProviderWebhookEvents(providerEvents []string, getProviderEvents func(...vcsutils.WebhookEvent) []string) []vcsutils.WebhookEvent {
+	subscribedEvents := make(map[string]bool, len(providerEvents))
+	for _, providerEvent := range providerEvents {
+		subscribedEvents[providerEvent] = true
+	}
+	var webhookEvents []vcsutils.WebhookEvent
+	for _, webhookEvent := range allWebhookEvents {
+		required := getProviderEvents(webhookEvent)
+		subscribed := len(required) > 0
+		for _, providerEvent := range required {
+			subscribed = subscribed && subscribedEvents[providerEvent]
+		}
+		if subscribed {
+			webhookEvents = append(webhookEvents, webhookEvent)
+		}
+	}
+	return webhookEvents
+}
+
+// validateWebhookOptions makes sure a webhook can be created with the given options
+func validateWebhookOptions(options WebhookOptions) error {
+	if err := validateParametersNotBlank(map[string]string{"payloadURL": options.PayloadURL}); err != nil {
+		return err
+	}
+	if len(options.Events) == 0 {
+		return errors.New("validation failed: a webhook must subscribe to at least one event")
+	}
+	if contentType := options.contentType(); contentType != WebhookContentTypeJSON && contentType != WebhookContentTypeForm {
+		return fmt.Errorf("validation failed: unknown webhook content type %q", contentType)
+	}
+	return nil
+}
+
+// validateCollaboratorPermission makes sure a collaborator is granted access to the repository
+func validateCollaboratorPermission(permission RepositoryPermission) error {
+	if permission < PermissionRead || permission > PermissionAdmin {
+		return errors.New("validation failed: a collaborator must be granted read, write or admin permission")
+	}
+	return nil
+}
+
+// validateCreateRepositoryOptions makes sure the default branch of a new repository can be set
+func validateCreateRepositoryOptions(options CreateRepositoryOptions) error {
+	if options.DefaultBranch != "" && !options.InitWithReadme {
+		return errors.New("validation failed: a default branch requires initializing the repository with a README")
+	}
+	return nil
+}
+
+// validatePullRequestState makes sure the pull request isn't updated to a state that can't be set directly
+func validatePullRequestState(state *PullRequestState) error {
+	if state != nil && *state == PullRequestMerged {
+		return errors.New("validation failed: a pull request can't be updated to the merged state, use MergePullRequest instead")
+	}
+	return nil
+}
+
+// trimDraftTitlePrefix removes a draft prefix from a pull request title, ignoring case.
+// Returns false if the title doesn't start with one of the prefixes.
+func trimDraftTitlePrefix(title string, prefixes []string) (string, bool) {
+	for _, prefix := range prefixes {
+		if len(title) >= len(prefix) && strings.EqualFold(title[:len(prefix)], prefix) {
+			return strings.TrimSpace(title[len(prefix):]), true
+		}
+	}
+	return title, false
+}
+
+// validateReviewBody makes sure a comment review has a body
+func validateReviewBody(reviewEvent ReviewEvent, body string) error {
+	if reviewEvent == ReviewComment && strings.TrimSpace(body) == "" {
+		return errors.New("validation failed: a body is required for a comment review")
+	}
+	return nil
+}
+
+func validateReviewComment(comment PullRequestReviewComment) error {
+	err := validateParametersNotBlank(map[string]string{"content": comment.Content, "path": comment.Path})
+	if err != nil {
+		return err
+	}
+	if comment.Line <= 0 || comment.StartLine < 0 || comment.StartLine > comment.Line {
+		return fmt.Errorf("validation failed: invalid comment lines %d-%d", comment.StartLine, comment.Line)
+	}
+	return nil
+}
+
+func validateFileChanges(changes []FileChange) error {
+	if len(changes) == 0 {
+		return errors.New("validation failed: at least one file change is required")
+	}
+	for _, change := range changes {
+		if strings.TrimSpace(change.Path) == "" {
+			return errors.New("validation failed: file change path is missing")
+		}
+	}
+	return nil
+}
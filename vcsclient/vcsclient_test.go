@@ -0,0 +1,14 @@
+package vcsclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHeadCommit(t *testing.T) {
+	assert.Equal(t, "second", getHeadCommit([]CommitInfo{{Hash: "first", Timestamp: 1}, {Hash: "second", Timestamp: 2}}).Hash)
+	assert.Equal(t, "second", getHeadCommit([]CommitInfo{
+		{Hash: "second", Timestamp: 1, ParentHashes: []string{"first"}}, {Hash: "first", Timestamp: 2}}).Hash)
+	assert.Empty(t, getHeadCommit(nil).Hash)
+}
@@ -16,6 +16,10 @@ const (
 	BitbucketCloud
 	// AzureRepos VCS provider
 	AzureRepos
+	// Gitea VCS provider
+	Gitea
+	// AWSCodeCommit VCS provider
+	AWSCodeCommit
 )
 
 // String representation of the VcsProvider
@@ -31,6 +35,10 @@ func (v VcsProvider) String() string {
 		return "Bitbucket Cloud"
 	case AzureRepos:
 		return "Azure Repos"
+	case Gitea:
+		return "Gitea"
+	case AWSCodeCommit:
+		return "AWS CodeCommit"
 	default:
 		return ""
 	}
@@ -40,8 +48,10 @@ func (v VcsProvider) String() string {
 type WebhookEvent string
 
 const (
-	// PrRejected the pull request is rejected
+	// PrRejected the pull request is declined on Bitbucket or abandoned on Azure Repos
 	PrRejected WebhookEvent = "PrRejected"
+	// PrClosed the pull request is closed without being merged on GitHub, GitLab, Gitea or AWS CodeCommit
+	PrClosed WebhookEvent = "PrClosed"
 	// PrEdited the pull request is edited
 	PrEdited WebhookEvent = "PrEdited"
 	// PrMerged the pull request is merged
@@ -50,4 +60,33 @@ const (
 	PrOpened WebhookEvent = "PrOpened"
 	// Push a commit is pushed to the source branch
 	Push WebhookEvent = "Push"
+	// TagPushed a new tag is pushed
+	TagPushed WebhookEvent = "TagPushed"
+	// TagRemoved a tag is removed
+	TagRemoved WebhookEvent = "TagRemoved"
+	// PrCommentCreated a comment is added to a pull request
+	PrCommentCreated WebhookEvent = "PrCommentCreated"
+	// PrReviewed a review is submitted to a pull request or an approval is withdrawn
+	PrReviewed WebhookEvent = "PrReviewed"
+	// RepoForked the repository is forked
+	RepoForked WebhookEvent = "RepoForked"
+	// RepoUpdated the repository details, such as its name or description, are updated
+	RepoUpdated WebhookEvent = "RepoUpdated"
+	// CommitStatusUpdated a commit status is created or updated
+	CommitStatusUpdated WebhookEvent = "CommitStatusUpdated"
+	// ReleasePublished a release is published
+	ReleasePublished WebhookEvent = "ReleasePublished"
+	// ReleaseEdited a release is edited
+	ReleaseEdited WebhookEvent = "ReleaseEdited"
+	// DeploymentCreated a deployment is created
+	DeploymentCreated WebhookEvent = "DeploymentCreated"
+	// DeploymentStatusUpdated the status of a deployment is updated
+	DeploymentStatusUpdated WebhookEvent = "DeploymentStatusUpdated"
+	// CIRunCompleted a CI workflow run or check suite is completed
+	CIRunCompleted WebhookEvent = "CIRunCompleted"
+	// BranchCreated a new branch is created. Pushes which create or delete a branch are reported as branch events,
+	// except on GitHub, which sends dedicated create and delete events.
+	BranchCreated WebhookEvent = "BranchCreated"
+	// BranchDeleted a branch is deleted
+	BranchDeleted WebhookEvent = "BranchDeleted"
 )
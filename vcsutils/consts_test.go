@@ -12,5 +12,7 @@ func TestVcsProviderString(t *testing.T) {
 	assert.Equal(t, "Bitbucket Server", BitbucketServer.String())
 	assert.Equal(t, "Bitbucket Cloud", BitbucketCloud.String())
 	assert.Equal(t, "Azure Repos", AzureRepos.String())
-	assert.Equal(t, "", (VcsProvider(5)).String())
+	assert.Equal(t, "Gitea", Gitea.String())
+	assert.Equal(t, "AWS CodeCommit", AWSCodeCommit.String())
+	assert.Equal(t, "", (VcsProvider(7)).String())
 }
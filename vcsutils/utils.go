@@ -27,6 +27,15 @@ func CreateToken() string {
 // reader              - Reader for the tar.gz file
 // shouldRemoveBaseDir - True if should remove the base directory
 func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err error) {
+	return UntarDirectory(destDir, reader, shouldRemoveBaseDir, "")
+}
+
+// UntarDirectory untars the files of a single directory in a file to the given destination. The path of the files in the archive is kept.
+// destDir             - Destination folder
+// reader              - Reader for the tar.gz file
+// shouldRemoveBaseDir - True if should remove the base directory
+// dirPath             - The path of the directory in the archive, after removing the base directory. All the files are extracted if empty
+func UntarDirectory(destDir string, reader io.Reader, shouldRemoveBaseDir bool, dirPath string) (err error) {
 	gzr, err := gzip.NewReader(reader)
 	if err != nil {
 		return err
@@ -58,7 +67,7 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 		if shouldRemoveBaseDir {
 			filePath = removeBaseDir(filePath)
 		}
-		if filePath == "" {
+		if filePath == "" || !isInDirectory(filePath, dirPath) {
 			continue
 		}
 
@@ -79,8 +88,11 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 				}
 			}
 
-		// If it's a file create it
+		// If it's a file create it, along with its parent directories that may have been filtered out
 		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+				return err
+			}
 			targetFile, err := os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode))
 			if err != nil {
 				return err
@@ -109,6 +121,16 @@ func makeDirIfMissing(destDir string) error {
 	return err
 }
 
+// isInDirectory checks whether a relative path is the directory itself or is inside it. Any path is inside an empty directory path.
+func isInDirectory(relativePath, dirPath string) bool {
+	dirPath = strings.Trim(filepath.ToSlash(dirPath), "/")
+	if dirPath == "" {
+		return true
+	}
+	relativePath = filepath.ToSlash(filepath.Clean(relativePath))
+	return relativePath == dirPath || strings.HasPrefix(relativePath, dirPath+"/")
+}
+
 // Remove the left component of the relative path
 func removeBaseDir(relativePath string) string {
 	parts := strings.Split(filepath.Clean(relativePath), string(os.PathSeparator))
@@ -172,19 +194,43 @@ func AddBranchPrefix(branch string) string {
 
 // Unzip a file to dest path
 func Unzip(zipFileContent []byte, destinationToUnzip string) (err error) {
+	return UnzipArchive(destinationToUnzip, zipFileContent, false)
+}
+
+// UnzipArchive unzips a file to the given destination
+// destDir             - Destination folder
+// zipFileContent      - The content of the zip file
+// shouldRemoveBaseDir - True if should remove the base directory
+func UnzipArchive(destDir string, zipFileContent []byte, shouldRemoveBaseDir bool) (err error) {
+	return UnzipDirectory(destDir, zipFileContent, shouldRemoveBaseDir, "")
+}
+
+// UnzipDirectory unzips the files of a single directory in a file to the given destination. The path of the files in the archive is kept.
+// destDir             - Destination folder
+// zipFileContent      - The content of the zip file
+// shouldRemoveBaseDir - True if should remove the base directory
+// dirPath             - The path of the directory in the archive, after removing the base directory. All the files are extracted if empty
+func UnzipDirectory(destDir string, zipFileContent []byte, shouldRemoveBaseDir bool, dirPath string) (err error) {
 	zf, err := zip.NewReader(bytes.NewReader(zipFileContent), int64(len(zipFileContent)))
 	if err != nil {
 		return err
 	}
 	// Get the absolute destination path
-	destinationToUnzip, err = filepath.Abs(destinationToUnzip)
+	destDir, err = filepath.Abs(destDir)
 	if err != nil {
 		return err
 	}
 
 	// Iterate over zip files inside the archive and unzip each of them
 	for _, f := range zf.File {
-		err = unzipFile(f, destinationToUnzip)
+		filePath := f.Name
+		if shouldRemoveBaseDir {
+			filePath = removeBaseDir(filePath)
+		}
+		if filePath == "" || !isInDirectory(filePath, dirPath) {
+			continue
+		}
+		err = unzipFile(f, filePath, destDir)
 		if err != nil {
 			return err
 		}
@@ -193,9 +239,9 @@ func Unzip(zipFileContent []byte, destinationToUnzip string) (err error) {
 	return nil
 }
 
-func unzipFile(f *zip.File, destination string) (err error) {
+func unzipFile(f *zip.File, filePath, destination string) (err error) {
 	// Check if file paths are not vulnerable to Zip Slip
-	fullFilePath, err := sanitizeExtractionPath(f.Name, destination)
+	fullFilePath, err := sanitizeExtractionPath(filePath, destination)
 	if err != nil {
 		return err
 	}
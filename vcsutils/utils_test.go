@@ -1,14 +1,18 @@
 package vcsutils
 
 import (
+	"archive/zip"
+	"bytes"
 	"github.com/go-git/go-git/v5"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUntar(t *testing.T) {
@@ -37,6 +41,23 @@ func TestUntarRemoveBaseDir(t *testing.T) {
 	assert.Equal(t, "b", fileinfo[0].Name())
 }
 
+func TestUntarDirectory(t *testing.T) {
+	destDir, tarball := openTarball(t)
+	defer tarball.Close()
+
+	err := UntarDirectory(destDir, tarball, true, "b/c")
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(destDir, "b", "c", "file"))
+
+	otherDestDir, otherTarball := openTarball(t)
+	defer otherTarball.Close()
+	err = UntarDirectory(otherDestDir, otherTarball, true, "d")
+	assert.NoError(t, err)
+	fileinfo, err := os.ReadDir(otherDestDir)
+	assert.NoError(t, err)
+	assert.Empty(t, fileinfo)
+}
+
 func TestUntarError(t *testing.T) {
 	err := Untar("", io.MultiReader(), false)
 	assert.Error(t, err)
@@ -136,6 +157,65 @@ func TestUnzip(t *testing.T) {
 	assert.Equal(t, "README.md", fileinfo[0].Name())
 }
 
+func TestUnzipArchive(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	for _, name := range []string{"repo-1-sha/", "repo-1-sha/README.md", "repo-1-sha/dir/a.txt"} {
+		fileWriter, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		if !strings.HasSuffix(name, "/") {
+			_, err = fileWriter.Write([]byte(name))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, zipWriter.Close())
+
+	destDir := t.TempDir()
+	err := UnzipArchive(destDir, buf.Bytes(), true)
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(destDir, "README.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "repo-1-sha/README.md", string(content))
+	assert.FileExists(t, filepath.Join(destDir, "dir", "a.txt"))
+	assert.NoDirExists(t, filepath.Join(destDir, "repo-1-sha"))
+}
+
+func TestUnzipDirectory(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	for _, name := range []string{"repo-1-sha/README.md", "repo-1-sha/services/a/main.go", "repo-1-sha/services/ab/main.go"} {
+		_, err := zipWriter.Create(name)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	destDir := t.TempDir()
+	err := UnzipDirectory(destDir, buf.Bytes(), true, "/services/a/")
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(destDir, "services", "a", "main.go"))
+	assert.NoFileExists(t, filepath.Join(destDir, "README.md"))
+	assert.NoDirExists(t, filepath.Join(destDir, "services", "ab"))
+}
+
+func TestIsInDirectory(t *testing.T) {
+	tests := []struct {
+		relativePath string
+		dirPath      string
+		expected     bool
+	}{
+		{relativePath: "README.md", dirPath: "", expected: true},
+		{relativePath: "services/a", dirPath: "services/a", expected: true},
+		{relativePath: "services/a/main.go", dirPath: "/services/a/", expected: true},
+		{relativePath: "services/ab/main.go", dirPath: "services/a", expected: false},
+		{relativePath: "services", dirPath: "services/a", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.relativePath+"_"+test.dirPath, func(t *testing.T) {
+			assert.Equal(t, test.expected, isInDirectory(test.relativePath, test.dirPath))
+		})
+	}
+}
+
 func TestAddBranchPrefix(t *testing.T) {
 	branch := "sampleBranch"
 	branchWithPrefix := AddBranchPrefix(branch)
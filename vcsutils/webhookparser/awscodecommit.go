@@ -0,0 +1,427 @@
+package webhookparser
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	// Sent by SNS with each HTTP delivery, used to detect AWS CodeCommit notifications
+	snsMessageTypeHeader = "X-Amz-Sns-Message-Type"
+
+	snsNotification             = "Notification"
+	snsSubscriptionConfirmation = "SubscriptionConfirmation"
+
+	codeCommitRepositoryStateChange  = "CodeCommit Repository State Change"
+	codeCommitPullRequestStateChange = "CodeCommit Pull Request State Change"
+	codeCommitCommentOnPullRequest   = "CodeCommit Comment on Pull Request"
+)
+
+// SNS hosts the certificates which sign its messages on the SNS endpoints of the AWS regions
+var snsCertificateHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// The signing certificates are rotated rarely, so they are cached by their URL
+var snsCertificates sync.Map
+
+// fetchSNSCertificate downloads the certificate that signed an SNS message
+var fetchSNSCertificate = func(certificateURL string) (*x509.Certificate, error) {
+	if certificate, exists := snsCertificates.Load(certificateURL); exists {
+		return certificate.(*x509.Certificate), nil
+	}
+	parsedURL, err := url.Parse(certificateURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsedURL.Scheme != "https" || !snsCertificateHostPattern.MatchString(parsedURL.Host) {
+		return nil, fmt.Errorf("the signing certificate URL %s isn't an SNS URL", certificateURL)
+	}
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	response, err := httpClient.Get(certificateURL)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download the signing certificate %s: %s", certificateURL, response.Status)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(body)
+	if block == nil {
+		return nil, fmt.Errorf("the signing certificate %s isn't PEM encoded", certificateURL)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	snsCertificates.Store(certificateURL, certificate)
+	return certificate, nil
+}
+
+// SNSSubscriptionConfirmationError is returned for the request SNS sends to confirm a new subscription of the webhook endpoint.
+// Notifications are delivered only after the subscription is confirmed by visiting the SubscribeURL.
+// It matches ErrUnsupportedEvent, so the request is acknowledged by handlers which ignore unsupported events.
+type SNSSubscriptionConfirmationError struct {
+	// The topic the endpoint is subscribed to
+	TopicArn string
+	// Visit this URL to confirm the subscription
+	SubscribeURL string
+}
+
+func (err *SNSSubscriptionConfirmationError) Error() string {
+	return fmt.Sprintf("the subscription to the SNS topic %s must be confirmed by visiting %s", err.TopicArn, err.SubscribeURL)
+}
+
+func (err *SNSSubscriptionConfirmationError) Is(target error) bool {
+	return target == ErrUnsupportedEvent
+}
+
+// AWSCodeCommitWebhook represents an incoming AWS CodeCommit notification.
+// The notifications of repository triggers and EventBridge rules are delivered to the webhook endpoint by an SNS topic subscription.
+// EventBridge events sent directly by an API destination are supported as well.
+type AWSCodeCommitWebhook struct {
+	request *http.Request
+}
+
+// NewAWSCodeCommitWebhook create a new AWSCodeCommitWebhook instance
+func NewAWSCodeCommitWebhook(request *http.Request) *AWSCodeCommitWebhook {
+	return &AWSCodeCommitWebhook{
+		request: request,
+	}
+}
+
+func (webhook *AWSCodeCommitWebhook) validatePayload(token []byte) ([]byte, error) {
+	// SNS can't add headers to its deliveries, so the token is expected in the query of the subscribed endpoint URL
+	keys, tokenParamsExist := webhook.request.URL.Query()["token"]
+	if len(token) > 0 || tokenParamsExist {
+		if !tokenParamsExist || keys[0] != string(token) {
+			return nil, errors.New("token mismatch")
+		}
+	}
+	payload := new(bytes.Buffer)
+	if _, err := payload.ReadFrom(webhook.request.Body); err != nil {
+		return nil, err
+	}
+	if webhook.request.Header.Get(snsMessageTypeHeader) == "" {
+		return payload.Bytes(), nil
+	}
+	message := &snsMessage{}
+	if err := json.Unmarshal(payload.Bytes(), message); err != nil {
+		return nil, err
+	}
+	if err := message.verifySignature(); err != nil {
+		return nil, err
+	}
+	return payload.Bytes(), nil
+}
+
+func (webhook *AWSCodeCommitWebhook) parseIncomingWebhook(payload []byte) (*WebhookInfo, error) {
+	var deliveryID string
+	if messageType := webhook.request.Header.Get(snsMessageTypeHeader); messageType != "" {
+		message := &snsMessage{}
+		if err := json.Unmarshal(payload, message); err != nil {
+			return nil, err
+		}
+		switch message.Type {
+		case snsNotification:
+			payload, deliveryID = []byte(message.Message), message.MessageID
+		case snsSubscriptionConfirmation:
+			return nil, &SNSSubscriptionConfirmationError{TopicArn: message.TopicArn, SubscribeURL: message.SubscribeURL}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, message.Type)
+		}
+	}
+	codeCommitWebHook := &awsCodeCommitWebHook{}
+	if err := json.Unmarshal(payload, codeCommitWebHook); err != nil {
+		return nil, err
+	}
+	webhookInfo, err := webhook.parseEvent(codeCommitWebHook)
+	if err != nil {
+		return nil, err
+	}
+	if deliveryID == "" {
+		deliveryID = codeCommitWebHook.ID
+	}
+	webhookInfo.DeliveryID = deliveryID
+	return webhookInfo, nil
+}
+
+func (webhook *AWSCodeCommitWebhook) parseEvent(codeCommitWebHook *awsCodeCommitWebHook) (*WebhookInfo, error) {
+	// Repository triggers send records, while EventBridge rules send a single event
+	if len(codeCommitWebHook.Records) > 0 {
+		return webhook.parseTriggerEvent(codeCommitWebHook.Records)
+	}
+	var webhookInfo *WebhookInfo
+	detail := codeCommitWebHook.Detail
+	switch codeCommitWebHook.DetailType {
+	case codeCommitRepositoryStateChange:
+		webhookInfo = webhook.parseReferenceEvent(codeCommitWebHook)
+	case codeCommitPullRequestStateChange:
+		switch {
+		case detail.Event == "pullRequestCreated":
+			webhookInfo = webhook.parsePrEvents(codeCommitWebHook, vcsutils.PrOpened)
+		case detail.Event == "pullRequestSourceBranchUpdated":
+			webhookInfo = webhook.parsePrEvents(codeCommitWebHook, vcsutils.PrEdited)
+		case detail.Event == "pullRequestMergeStatusUpdated" && detail.IsMerged == "True":
+			webhookInfo = webhook.parsePrEvents(codeCommitWebHook, vcsutils.PrMerged)
+		case detail.Event == "pullRequestStatusChanged" && detail.PullRequestStatus == "Open":
+			webhookInfo = webhook.parsePrEvents(codeCommitWebHook, vcsutils.PrOpened)
+		case detail.Event == "pullRequestStatusChanged" && detail.IsMerged != "True":
+			webhookInfo = webhook.parsePrEvents(codeCommitWebHook, vcsutils.PrClosed)
+		}
+	case codeCommitCommentOnPullRequest:
+		if detail.Event == "commentOnPullRequestCreated" {
+			webhookInfo = webhook.parsePrCommentEvent(codeCommitWebHook)
+		}
+	}
+	if webhookInfo == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrUnsupportedEvent, codeCommitWebHook.DetailType, detail.Event)
+	}
+	webhookInfo.RawEvent = codeCommitWebHook.DetailType
+	return webhookInfo, nil
+}
+
+func (webhook *AWSCodeCommitWebhook) parseTriggerEvent(records []awsCodeCommitRecord) (*WebhookInfo, error) {
+	var changes []WebhookInfo
+	for _, record := range records {
+		if record.EventName != "ReferenceChanges" {
+			continue
+		}
+		eventTime, err := time.Parse("2006-01-02T15:04:05.000-0700", record.EventTime)
+		if err != nil {
+			return nil, err
+		}
+		repositoryDetails := getCodeCommitRepositoryDetails(record.EventSourceARN)
+		for _, reference := range record.CodeCommit.References {
+			changes = append(changes, webhook.parseTriggerReference(repositoryDetails, reference, eventTime))
+		}
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, records[0].EventName)
+	}
+	// The first change is reflected in the top-level fields, all changes are available in the Changes field
+	webhookInfo := changes[0]
+	webhookInfo.Changes = changes
+	webhookInfo.RawEvent = records[0].EventName
+	return &webhookInfo, nil
+}
+
+func (webhook *AWSCodeCommitWebhook) parseTriggerReference(repositoryDetails WebHookInfoRepoDetails, reference awsCodeCommitReference, eventTime time.Time) WebhookInfo {
+	webhookInfo := WebhookInfo{
+		TargetRepositoryDetails: repositoryDetails,
+		Timestamp:               eventTime.UTC().Unix(),
+		Time:                    eventTime,
+	}
+	if strings.HasPrefix(reference.Ref, tagPrefix) {
+		webhookInfo.Event = vcsutils.TagPushed
+		if reference.Deleted {
+			webhookInfo.Event = vcsutils.TagRemoved
+		}
+		webhookInfo.Tag = &WebhookInfoTag{Name: strings.TrimPrefix(reference.Ref, tagPrefix), Hash: reference.Commit}
+		return webhookInfo
+	}
+	webhookInfo.TargetBranch = strings.TrimPrefix(reference.Ref, "refs/heads/")
+	// Triggers send a single commit, which is the deleted head of deleted branches
+	switch {
+	case reference.Created:
+		webhookInfo.Event, webhookInfo.Commit = vcsutils.BranchCreated, reference.Commit
+	case reference.Deleted:
+		webhookInfo.Event, webhookInfo.BeforeCommit = vcsutils.BranchDeleted, reference.Commit
+	default:
+		webhookInfo.Event, webhookInfo.Commit = vcsutils.Push, reference.Commit
+	}
+	return webhookInfo
+}
+
+func (webhook *AWSCodeCommitWebhook) parseReferenceEvent(codeCommitWebHook *awsCodeCommitWebHook) *WebhookInfo {
+	detail := codeCommitWebHook.Detail
+	webhookInfo := &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{Name: detail.RepositoryName, Owner: codeCommitWebHook.Account},
+		Timestamp:               codeCommitWebHook.Time.UTC().Unix(),
+		Time:                    codeCommitWebHook.Time,
+	}
+	if detail.ReferenceType == "tag" {
+		webhookEvent, hash := vcsutils.TagPushed, detail.CommitID
+		if detail.Event == "referenceDeleted" {
+			webhookEvent, hash = vcsutils.TagRemoved, detail.OldCommitID
+		}
+		webhookInfo.Event = webhookEvent
+		webhookInfo.Tag = &WebhookInfoTag{Name: detail.ReferenceName, Hash: hash}
+		return webhookInfo
+	}
+	webhookInfo.TargetBranch = detail.ReferenceName
+	webhookInfo.Event = getBranchEvent(detail.OldCommitID, detail.CommitID)
+	webhookInfo.BeforeCommit = getCommitSha(detail.OldCommitID)
+	webhookInfo.Commit = getCommitSha(detail.CommitID)
+	return webhookInfo
+}
+
+func (webhook *AWSCodeCommitWebhook) parsePrEvents(codeCommitWebHook *awsCodeCommitWebHook, event vcsutils.WebhookEvent) *WebhookInfo {
+	detail := codeCommitWebHook.Detail
+	// Pull requests are opened between branches of the same repository
+	repositoryDetails := WebHookInfoRepoDetails{Owner: codeCommitWebHook.Account}
+	if len(detail.RepositoryNames) > 0 {
+		repositoryDetails.Name = detail.RepositoryNames[0]
+	}
+	pullRequestID, _ := strconv.Atoi(detail.PullRequestID)
+	return &WebhookInfo{
+		PullRequestId:           pullRequestID,
+		TargetRepositoryDetails: repositoryDetails,
+		TargetBranch:            strings.TrimPrefix(detail.DestinationReference, "refs/heads/"),
+		SourceRepositoryDetails: repositoryDetails,
+		SourceBranch:            strings.TrimPrefix(detail.SourceReference, "refs/heads/"),
+		Timestamp:               codeCommitWebHook.Time.UTC().Unix(),
+		Time:                    codeCommitWebHook.Time,
+		Event:                   event,
+		PullRequest: &WebhookInfoPullRequest{
+			Title:  detail.Title,
+			Author: WebhookInfoUser{Username: getCodeCommitUsername(detail.Author)},
+		},
+	}
+}
+
+func (webhook *AWSCodeCommitWebhook) parsePrCommentEvent(codeCommitWebHook *awsCodeCommitWebHook) *WebhookInfo {
+	detail := codeCommitWebHook.Detail
+	pullRequestID, _ := strconv.Atoi(detail.PullRequestID)
+	return &WebhookInfo{
+		PullRequestId:           pullRequestID,
+		TargetRepositoryDetails: WebHookInfoRepoDetails{Name: detail.RepositoryName, Owner: codeCommitWebHook.Account},
+		Timestamp:               codeCommitWebHook.Time.UTC().Unix(),
+		Time:                    codeCommitWebHook.Time,
+		Event:                   vcsutils.PrCommentCreated,
+		// The event has neither the content of the comment nor a numeric comment ID
+		Comment: &WebhookInfoComment{Author: WebhookInfoUser{Username: getCodeCommitUsername(detail.CallerUserArn)}},
+	}
+}
+
+// Get the repository name and the ID of the AWS account that owns it from an ARN such as arn:aws:codecommit:us-east-1:123456789012:repo
+func getCodeCommitRepositoryDetails(repositoryArn string) WebHookInfoRepoDetails {
+	arnParts := strings.Split(repositoryArn, ":")
+	if len(arnParts) < 6 {
+		return WebHookInfoRepoDetails{}
+	}
+	return WebHookInfoRepoDetails{Name: arnParts[5], Owner: arnParts[4]}
+}
+
+// Get the name of the IAM user or of the role session from an ARN such as arn:aws:iam::123456789012:user/yahavi
+func getCodeCommitUsername(userArn string) string {
+	return userArn[strings.LastIndex(userArn, "/")+1:]
+}
+
+type snsMessage struct {
+	Type             string `json:"Type,omitempty"`
+	MessageID        string `json:"MessageId,omitempty"`
+	Token            string `json:"Token,omitempty"`
+	TopicArn         string `json:"TopicArn,omitempty"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message,omitempty"`
+	Timestamp        string `json:"Timestamp,omitempty"`
+	SignatureVersion string `json:"SignatureVersion,omitempty"`
+	Signature        string `json:"Signature,omitempty"`
+	SigningCertURL   string `json:"SigningCertURL,omitempty"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+}
+
+// Verify the signature of the message with the certificate of SNS, as described in https://docs.aws.amazon.com/sns/latest/dg/sns-verify-signature-of-message.html
+func (message *snsMessage) verifySignature() error {
+	var algorithm x509.SignatureAlgorithm
+	switch message.SignatureVersion {
+	case "1":
+		algorithm = x509.SHA1WithRSA
+	case "2":
+		algorithm = x509.SHA256WithRSA
+	default:
+		return fmt.Errorf("unsupported SNS signature version: %s", message.SignatureVersion)
+	}
+	signature, err := base64.StdEncoding.DecodeString(message.Signature)
+	if err != nil {
+		return err
+	}
+	certificate, err := fetchSNSCertificate(message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	if err = certificate.CheckSignature(algorithm, []byte(message.stringToSign()), signature); err != nil {
+		return fmt.Errorf("payload signature mismatch: %w", err)
+	}
+	return nil
+}
+
+// The signed string lists the names and values of the message fields in alphabetical order, each in its own line
+func (message *snsMessage) stringToSign() string {
+	fields := []string{"Message", message.Message, "MessageId", message.MessageID}
+	if message.Type == snsNotification {
+		if message.Subject != "" {
+			fields = append(fields, "Subject", message.Subject)
+		}
+		fields = append(fields, "Timestamp", message.Timestamp)
+	} else {
+		fields = append(fields, "SubscribeURL", message.SubscribeURL, "Timestamp", message.Timestamp, "Token", message.Token)
+	}
+	fields = append(fields, "TopicArn", message.TopicArn, "Type", message.Type)
+	return strings.Join(fields, "\n") + "\n"
+}
+
+type awsCodeCommitWebHook struct {
+	// Repository triggers
+	Records []awsCodeCommitRecord `json:"Records,omitempty"`
+	// EventBridge events
+	ID         string                    `json:"id,omitempty"`
+	DetailType string                    `json:"detail-type,omitempty"`
+	Account    string                    `json:"account,omitempty"`
+	Time       time.Time                 `json:"time,omitempty"`
+	Detail     awsCodeCommitEventDetails `json:"detail,omitempty"`
+}
+
+type awsCodeCommitRecord struct {
+	EventName      string `json:"eventName,omitempty"`
+	EventSourceARN string `json:"eventSourceARN,omitempty"`
+	EventTime      string `json:"eventTime,omitempty"`
+	CodeCommit     struct {
+		References []awsCodeCommitReference `json:"references,omitempty"`
+	} `json:"codecommit,omitempty"`
+}
+
+type awsCodeCommitReference struct {
+	Commit  string `json:"commit,omitempty"`
+	Ref     string `json:"ref,omitempty"`
+	Created bool   `json:"created,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+type awsCodeCommitEventDetails struct {
+	Event          string `json:"event,omitempty"`
+	RepositoryName string `json:"repositoryName,omitempty"`
+	// Repository events
+	ReferenceType string `json:"referenceType,omitempty"`
+	ReferenceName string `json:"referenceName,omitempty"`
+	CommitID      string `json:"commitId,omitempty"`
+	OldCommitID   string `json:"oldCommitId,omitempty"`
+	// Pull request events
+	RepositoryNames      []string `json:"repositoryNames,omitempty"`
+	PullRequestID        string   `json:"pullRequestId,omitempty"`
+	PullRequestStatus    string   `json:"pullRequestStatus,omitempty"`
+	IsMerged             string   `json:"isMerged,omitempty"`
+	Title                string   `json:"title,omitempty"`
+	SourceReference      string   `json:"sourceReference,omitempty"`
+	DestinationReference string   `json:"destinationReference,omitempty"`
+	Author               string   `json:"author,omitempty"`
+	CallerUserArn        string   `json:"callerUserArn,omitempty"`
+}
@@ -0,0 +1,189 @@
+package webhookparser
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	awsCodeCommitExpectedAccount  = "123456789012"
+	awsCodeCommitExpectedHash     = "5c4ef1049f1d27deadbeeff313e0730018be182b"
+	awsCodeCommitSigningCertURL   = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-froggit.pem"
+	awsCodeCommitExpectedTopicArn = "arn:aws:sns:us-east-1:123456789012:froggit"
+)
+
+func TestAWSCodeCommitParseIncomingTriggerWebhook(t *testing.T) {
+	actual := parseAWSCodeCommitPayload(t, "triggerpayload.json")
+
+	expectedTime := time.Date(2023, time.March, 19, 9, 20, 52, 0, time.UTC)
+	repositoryDetails := WebHookInfoRepoDetails{Name: expectedRepoName, Owner: awsCodeCommitExpectedAccount}
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Equal(t, repositoryDetails, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedTime.Unix(), actual.Timestamp)
+	assert.Equal(t, "ReferenceChanges", actual.RawEvent)
+	require.Len(t, actual.Changes, 3)
+	assert.Equal(t, vcsutils.TagPushed, actual.Changes[1].Event)
+	assert.Equal(t, &WebhookInfoTag{Name: expectedTag, Hash: awsCodeCommitExpectedHash}, actual.Changes[1].Tag)
+	assert.Equal(t, vcsutils.BranchDeleted, actual.Changes[2].Event)
+	assert.Equal(t, expectedSourceBranch, actual.Changes[2].TargetBranch)
+	assert.Equal(t, "0d7c53ed21b5c1c1f5c2dc4e9d7e6a1b2c3d4e5f", actual.Changes[2].BeforeCommit)
+	assert.Empty(t, actual.Changes[2].Commit)
+	assert.Equal(t, awsCodeCommitExpectedHash, actual.Commit)
+}
+
+func TestAWSCodeCommitParseIncomingReferenceWebhook(t *testing.T) {
+	actual := parseAWSCodeCommitPayload(t, "pushpayload.json")
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: awsCodeCommitExpectedAccount}, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, time.Date(2023, time.March, 19, 9, 20, 52, 0, time.UTC).Unix(), actual.Timestamp)
+	assert.Equal(t, "3b1b0c2e-6f3a-4f5e-9c8d-7a6b5c4d3e2f", actual.DeliveryID)
+	assert.Equal(t, codeCommitRepositoryStateChange, actual.RawEvent)
+	assert.Equal(t, "0d7c53ed21b5c1c1f5c2dc4e9d7e6a1b2c3d4e5f", actual.BeforeCommit)
+	assert.Equal(t, awsCodeCommitExpectedHash, actual.Commit)
+
+	actual = parseAWSCodeCommitPayload(t, "tagremovepayload.json")
+	assert.Equal(t, vcsutils.TagRemoved, actual.Event)
+	assert.Equal(t, &WebhookInfoTag{Name: expectedTag, Hash: awsCodeCommitExpectedHash}, actual.Tag)
+}
+
+func TestAWSCodeCommitParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		payloadFilename   string
+		expectedEventType vcsutils.WebhookEvent
+	}{
+		{payloadFilename: "prcreatepayload.json", expectedEventType: vcsutils.PrOpened},
+		{payloadFilename: "prupdatepayload.json", expectedEventType: vcsutils.PrEdited},
+		{payloadFilename: "prmergepayload.json", expectedEventType: vcsutils.PrMerged},
+		{payloadFilename: "prclosepayload.json", expectedEventType: vcsutils.PrClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.payloadFilename, func(t *testing.T) {
+			actual := parseAWSCodeCommitPayload(t, tt.payloadFilename)
+			repositoryDetails := WebHookInfoRepoDetails{Name: expectedRepoName, Owner: awsCodeCommitExpectedAccount}
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, 1, actual.PullRequestId)
+			assert.Equal(t, repositoryDetails, actual.TargetRepositoryDetails)
+			assert.Equal(t, repositoryDetails, actual.SourceRepositoryDetails)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+			assert.Equal(t, &WebhookInfoPullRequest{Title: "Update README.md", Author: WebhookInfoUser{Username: expectedOwner}}, actual.PullRequest)
+			assert.Equal(t, codeCommitPullRequestStateChange, actual.RawEvent)
+		})
+	}
+}
+
+func TestAWSCodeCommitParseIncomingPrCommentWebhook(t *testing.T) {
+	actual := parseAWSCodeCommitPayload(t, "prcommentpayload.json")
+	assert.Equal(t, vcsutils.PrCommentCreated, actual.Event)
+	assert.Equal(t, 1, actual.PullRequestId)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: awsCodeCommitExpectedAccount}, actual.TargetRepositoryDetails)
+	assert.Equal(t, &WebhookInfoComment{Author: WebhookInfoUser{Username: expectedOwner}}, actual.Comment)
+}
+
+func TestAWSCodeCommitParseIncomingSNSWebhook(t *testing.T) {
+	privateKey := mockSNSCertificate(t)
+	payload, err := os.ReadFile(filepath.Join("testdata", "awscodecommit", "pushpayload.json"))
+	require.NoError(t, err)
+	parse := func(message snsMessage, query string) (*WebhookInfo, error) {
+		body, err := json.Marshal(message)
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", "https://127.0.0.1"+query, bytes.NewReader(body))
+		request.Header.Set(snsMessageTypeHeader, message.Type)
+		return ParseIncomingWebhook(vcsutils.AWSCodeCommit, token, request)
+	}
+
+	notification := signSNSMessage(t, privateKey, snsMessage{Type: snsNotification, MessageID: "message1", Message: string(payload)})
+	actual, err := parse(notification, "?token="+string(token))
+	require.NoError(t, err)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, "message1", actual.DeliveryID)
+
+	// Token mismatch
+	_, err = parse(notification, "?token=wrong")
+	assert.Error(t, err)
+
+	// Signature mismatch
+	tampered := notification
+	tampered.Message = "{}"
+	_, err = parse(tampered, "?token="+string(token))
+	assert.Error(t, err)
+
+	confirmation := signSNSMessage(t, privateKey, snsMessage{Type: snsSubscriptionConfirmation, MessageID: "message2", Token: "confirmation-token",
+		SubscribeURL: "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"})
+	_, err = parse(confirmation, "?token="+string(token))
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+	var confirmationErr *SNSSubscriptionConfirmationError
+	require.ErrorAs(t, err, &confirmationErr)
+	assert.Equal(t, confirmation.SubscribeURL, confirmationErr.SubscribeURL)
+	assert.Equal(t, awsCodeCommitExpectedTopicArn, confirmationErr.TopicArn)
+}
+
+func TestFetchSNSCertificateRejectsForeignHosts(t *testing.T) {
+	_, err := fetchSNSCertificate("https://example.com/SimpleNotificationService.pem")
+	assert.Error(t, err)
+	_, err = fetchSNSCertificate("http://sns.us-east-1.amazonaws.com/SimpleNotificationService.pem")
+	assert.Error(t, err)
+}
+
+func parseAWSCodeCommitPayload(t *testing.T, payloadFilename string) *WebhookInfo {
+	reader, err := os.Open(filepath.Join("testdata", "awscodecommit", payloadFilename))
+	require.NoError(t, err)
+	defer close(reader)
+
+	request := httptest.NewRequest("POST", "https://127.0.0.1?token="+string(token), reader)
+	actual, err := ParseIncomingWebhook(vcsutils.AWSCodeCommit, token, request)
+	require.NoError(t, err)
+	return actual
+}
+
+// Replace the SNS signing certificate with a self-signed certificate, and return its private key
+func mockSNSCertificate(t *testing.T) *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "sns.amazonaws.com"}, NotAfter: time.Now().Add(time.Hour)}
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(certificateBytes)
+	require.NoError(t, err)
+
+	originalFetch := fetchSNSCertificate
+	fetchSNSCertificate = func(certificateURL string) (*x509.Certificate, error) {
+		assert.Equal(t, awsCodeCommitSigningCertURL, certificateURL)
+		return certificate, nil
+	}
+	t.Cleanup(func() { fetchSNSCertificate = originalFetch })
+	return privateKey
+}
+
+func signSNSMessage(t *testing.T, privateKey *rsa.PrivateKey, message snsMessage) snsMessage {
+	message.TopicArn = awsCodeCommitExpectedTopicArn
+	message.Timestamp = "2023-03-19T09:20:53.000Z"
+	message.SignatureVersion = "2"
+	message.SigningCertURL = awsCodeCommitSigningCertURL
+	digest := sha256.Sum256([]byte(message.stringToSign()))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	message.Signature = base64.StdEncoding.EncodeToString(signature)
+	return message
+}
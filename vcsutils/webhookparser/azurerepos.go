@@ -0,0 +1,282 @@
+package webhookparser
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// AzureReposWebhook represents an incoming webhook on Azure Repos
+type AzureReposWebhook struct {
+	request *http.Request
+}
+
+// NewAzureReposWebhook create a new AzureReposWebhook instance
+func NewAzureReposWebhook(request *http.Request) *AzureReposWebhook {
+	return &AzureReposWebhook{
+		request: request,
+	}
+}
+
+func (webhook *AzureReposWebhook) validatePayload(token []byte) ([]byte, error) {
+	// Azure Repos service hooks authenticate using the basic authentication configured in the subscription.
+	// The token is expected to be the password, while the username is ignored.
+	_, password, basicAuthExist := webhook.request.BasicAuth()
+	if len(token) > 0 && !basicAuthExist {
+		return nil, errors.New("basic authentication is missing, although a token is expected")
+	}
+	if basicAuthExist && subtle.ConstantTimeCompare([]byte(password), token) != 1 {
+		return nil, errors.New("token mismatch")
+	}
+
+	payload := new(bytes.Buffer)
+	if _, err := payload.ReadFrom(webhook.request.Body); err != nil {
+		return nil, err
+	}
+	return payload.Bytes(), nil
+}
+
+func (webhook *AzureReposWebhook) parseIncomingWebhook(payload []byte) (*WebhookInfo, error) {
+	azureReposWebHook := &azureReposWebHook{}
+	err := json.Unmarshal(payload, azureReposWebHook)
+	if err != nil {
+		return nil, err
+	}
+
+	switch azureReposWebHook.EventType {
+	case "git.push":
+		return webhook.parsePushEvent(azureReposWebHook), nil
+	case "git.pullrequest.created":
+		return webhook.parsePrEvents(azureReposWebHook, azureReposWebHook.Resource.azureReposPullRequest, vcsutils.PrOpened), nil
+	case "git.pullrequest.updated":
+		if azureReposWebHook.Resource.Status == "abandoned" {
+			return webhook.parsePrEvents(azureReposWebHook, azureReposWebHook.Resource.azureReposPullRequest, vcsutils.PrRejected), nil
+		}
+		return webhook.parsePrEvents(azureReposWebHook, azureReposWebHook.Resource.azureReposPullRequest, vcsutils.PrEdited), nil
+	case "git.pullrequest.merged":
+		// The merged event is sent on every merge attempt, including failed ones
+		if azureReposWebHook.Resource.MergeStatus == "succeeded" {
+			return webhook.parsePrEvents(azureReposWebHook, azureReposWebHook.Resource.azureReposPullRequest, vcsutils.PrMerged), nil
+		}
+		return nil, fmt.Errorf("%w: %s with merge status %q", ErrUnsupportedEvent, azureReposWebHook.EventType, azureReposWebHook.Resource.MergeStatus)
+	case "ms.vss-code.git-pullrequest-comment-event":
+		return webhook.parsePrCommentEvent(azureReposWebHook)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, azureReposWebHook.EventType)
+}
+
+func (webhook *AzureReposWebhook) parsePushEvent(azureReposWebHook *azureReposWebHook) *WebhookInfo {
+	repositoryDetails := webhook.getRepositoryDetails(azureReposWebHook.Resource.Repository)
+	eventTime := azureReposWebHook.CreatedDate
+	changes := make([]WebhookInfo, 0, len(azureReposWebHook.Resource.RefUpdates))
+	for _, refUpdate := range azureReposWebHook.Resource.RefUpdates {
+		changes = append(changes, webhook.parseRefUpdate(repositoryDetails, refUpdate, eventTime))
+	}
+	if len(changes) == 0 {
+		return &WebhookInfo{TargetRepositoryDetails: repositoryDetails, Timestamp: eventTime.UTC().Unix(), Time: eventTime, Event: vcsutils.Push,
+			DeliveryID: azureReposWebHook.ID, RawEvent: azureReposWebHook.EventType}
+	}
+	webhookInfo := changes[0]
+	webhookInfo.Changes = changes
+	webhookInfo.Commits = webhook.parseCommits(azureReposWebHook.Resource.Commits)
+	webhookInfo.DeliveryID = azureReposWebHook.ID
+	webhookInfo.RawEvent = azureReposWebHook.EventType
+	return &webhookInfo
+}
+
+// The commits of a push are listed once for all its ref updates, from the most recent one, and without the changed files
+func (webhook *AzureReposWebhook) parseCommits(commits []azureReposCommit) []WebHookInfoCommit {
+	var webhookCommits []WebHookInfoCommit
+	for _, commit := range commits {
+		webhookCommits = append(webhookCommits, WebHookInfoCommit{
+			Hash:      commit.CommitID,
+			Message:   commit.Comment,
+			Author:    WebhookInfoUser{DisplayName: commit.Author.Name, Email: commit.Author.Email},
+			Timestamp: commit.Author.Date.UTC().Unix(),
+		})
+	}
+	return webhookCommits
+}
+
+func (webhook *AzureReposWebhook) parseRefUpdate(repositoryDetails WebHookInfoRepoDetails, refUpdate azureReposRefUpdate, eventTime time.Time) WebhookInfo {
+	if strings.HasPrefix(refUpdate.Name, tagPrefix) {
+		webhookEvent, hash := vcsutils.TagPushed, refUpdate.NewObjectID
+		// On ref removal, Azure Repos sends a new object ID consisting of zeros
+		if strings.Trim(refUpdate.NewObjectID, "0") == "" {
+			webhookEvent, hash = vcsutils.TagRemoved, refUpdate.OldObjectID
+		}
+		return WebhookInfo{
+			TargetRepositoryDetails: repositoryDetails,
+			Timestamp:               eventTime.UTC().Unix(),
+			Time:                    eventTime,
+			Event:                   webhookEvent,
+			Tag: &WebhookInfoTag{
+				Name: strings.TrimPrefix(refUpdate.Name, tagPrefix),
+				Hash: hash,
+			},
+		}
+	}
+	return WebhookInfo{
+		TargetRepositoryDetails: repositoryDetails,
+		TargetBranch:            strings.TrimPrefix(refUpdate.Name, "refs/heads/"),
+		Timestamp:               eventTime.UTC().Unix(),
+		Time:                    eventTime,
+		Event:                   getBranchEvent(refUpdate.OldObjectID, refUpdate.NewObjectID),
+		BeforeCommit:            getCommitSha(refUpdate.OldObjectID),
+		Commit:                  getCommitSha(refUpdate.NewObjectID),
+	}
+}
+
+func (webhook *AzureReposWebhook) parsePrEvents(azureReposWebHook *azureReposWebHook, pullRequest azureReposPullRequest, event vcsutils.WebhookEvent) *WebhookInfo {
+	sourceRepository := pullRequest.Repository
+	if pullRequest.ForkSource != nil {
+		sourceRepository = pullRequest.ForkSource.Repository
+	}
+	webhookInfo := &WebhookInfo{
+		PullRequestId:           pullRequest.PullRequestID,
+		TargetRepositoryDetails: webhook.getRepositoryDetails(pullRequest.Repository),
+		TargetBranch:            strings.TrimPrefix(pullRequest.TargetRefName, "refs/heads/"),
+		SourceRepositoryDetails: webhook.getRepositoryDetails(sourceRepository),
+		SourceBranch:            strings.TrimPrefix(pullRequest.SourceRefName, "refs/heads/"),
+		Timestamp:               azureReposWebHook.CreatedDate.UTC().Unix(),
+		Time:                    azureReposWebHook.CreatedDate,
+		Event:                   event,
+		DeliveryID:              azureReposWebHook.ID,
+		RawEvent:                azureReposWebHook.EventType,
+		PullRequest: &WebhookInfoPullRequest{
+			Title:     pullRequest.Title,
+			Body:      pullRequest.Description,
+			Author:    pullRequest.CreatedBy.toWebhookInfoUser(),
+			Draft:     pullRequest.IsDraft,
+			CreatedAt: pullRequest.CreationDate,
+			Labels:    webhook.getLabelNames(pullRequest.Labels),
+		},
+	}
+	if event == vcsutils.PrMerged {
+		webhookInfo.PullRequest.MergeCommitHash = pullRequest.LastMergeCommit.CommitID
+	}
+	return webhookInfo
+}
+
+// The comment event payload contains the comment, and the pull request it was added to
+func (webhook *AzureReposWebhook) parsePrCommentEvent(azureReposWebHook *azureReposWebHook) (*WebhookInfo, error) {
+	pullRequest, comment := azureReposWebHook.Resource.PullRequest, azureReposWebHook.Resource.Comment
+	if pullRequest == nil || comment == nil {
+		return nil, fmt.Errorf("%w: %s without a pull request comment", ErrUnsupportedEvent, azureReposWebHook.EventType)
+	}
+	webhookInfo := webhook.parsePrEvents(azureReposWebHook, *pullRequest, vcsutils.PrCommentCreated)
+	webhookInfo.Comment = &WebhookInfoComment{
+		ID:     comment.ID,
+		Body:   comment.Content,
+		Author: comment.Author.toWebhookInfoUser(),
+	}
+	return webhookInfo, nil
+}
+
+func (webhook *AzureReposWebhook) getLabelNames(labels []azureReposLabel) []string {
+	var labelNames []string
+	for _, label := range labels {
+		labelNames = append(labelNames, label.Name)
+	}
+	return labelNames
+}
+
+// In Azure Repos, repositories are grouped by projects. The project is used as the owner of the repository.
+func (webhook *AzureReposWebhook) getRepositoryDetails(repository azureReposRepository) WebHookInfoRepoDetails {
+	return WebHookInfoRepoDetails{
+		Name:  repository.Name,
+		Owner: repository.Project.Name,
+	}
+}
+
+type azureReposWebHook struct {
+	ID          string    `json:"id,omitempty"` // Event ID
+	EventType   string    `json:"eventType,omitempty"`
+	CreatedDate time.Time `json:"createdDate,omitempty"` // Timestamp
+	Resource    struct {
+		// Push events
+		RefUpdates []azureReposRefUpdate `json:"refUpdates,omitempty"`
+		Commits    []azureReposCommit    `json:"commits,omitempty"`
+		// Pull request events. The repository is also set on push events
+		azureReposPullRequest
+		// Pull request comment events
+		Comment     *azureReposComment     `json:"comment,omitempty"`
+		PullRequest *azureReposPullRequest `json:"pullRequest,omitempty"`
+	} `json:"resource,omitempty"`
+}
+
+type azureReposPullRequest struct {
+	PullRequestID int                `json:"pullRequestId,omitempty"`
+	Title         string             `json:"title,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	IsDraft       bool               `json:"isDraft,omitempty"`
+	CreationDate  time.Time          `json:"creationDate,omitempty"`
+	CreatedBy     azureReposIdentity `json:"createdBy,omitempty"`
+	Labels        []azureReposLabel  `json:"labels,omitempty"`
+	Status        string             `json:"status,omitempty"`      // active, abandoned or completed
+	MergeStatus   string             `json:"mergeStatus,omitempty"` // succeeded, conflicts, failure, etc.
+	// The last merge commit, which is the test merge commit until the pull request is completed
+	LastMergeCommit struct {
+		CommitID string `json:"commitId,omitempty"`
+	} `json:"lastMergeCommit,omitempty"`
+	SourceRefName string `json:"sourceRefName,omitempty"`
+	TargetRefName string `json:"targetRefName,omitempty"`
+	ForkSource    *struct {
+		Repository azureReposRepository `json:"repository,omitempty"`
+	} `json:"forkSource,omitempty"`
+	Repository azureReposRepository `json:"repository,omitempty"`
+}
+
+type azureReposIdentity struct {
+	UniqueName  string `json:"uniqueName,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	ImageURL    string `json:"imageUrl,omitempty"`
+}
+
+func (identity azureReposIdentity) toWebhookInfoUser() WebhookInfoUser {
+	return WebhookInfoUser{
+		Username:    identity.UniqueName,
+		DisplayName: identity.DisplayName,
+		AvatarURL:   identity.ImageURL,
+	}
+}
+
+type azureReposComment struct {
+	ID      int64              `json:"id,omitempty"`
+	Content string             `json:"content,omitempty"`
+	Author  azureReposIdentity `json:"author,omitempty"`
+}
+
+type azureReposCommit struct {
+	CommitID string `json:"commitId,omitempty"`
+	Comment  string `json:"comment,omitempty"` // The commit message
+	Author   struct {
+		Name  string    `json:"name,omitempty"`
+		Email string    `json:"email,omitempty"`
+		Date  time.Time `json:"date,omitempty"`
+	} `json:"author,omitempty"`
+}
+
+type azureReposRefUpdate struct {
+	Name        string `json:"name,omitempty"` // Ref name, such as refs/heads/main
+	OldObjectID string `json:"oldObjectId,omitempty"`
+	NewObjectID string `json:"newObjectId,omitempty"`
+}
+
+type azureReposRepository struct {
+	Name    string `json:"name,omitempty"`
+	Project struct {
+		Name string `json:"name,omitempty"`
+	} `json:"project,omitempty"`
+}
+
+type azureReposLabel struct {
+	Name string `json:"name,omitempty"`
+}
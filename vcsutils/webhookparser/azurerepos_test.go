@@ -0,0 +1,206 @@
+package webhookparser
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	azureReposPushExpectedTime      = int64(1679217652)
+	azureReposPrCreateExpectedTime  = int64(1679220923)
+	azureReposPrUpdateExpectedTime  = int64(1679221211)
+	azureReposPrMergeExpectedTime   = int64(1679221542)
+	azureReposPrAbandonExpectedTime = int64(1679221805)
+	azureReposPrCommentExpectedTime = int64(1679221212)
+	azureReposExpectedPrID          = 1
+)
+
+func TestAzureReposParseIncomingPushWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "azurerepos", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.SetBasicAuth("froggit", string(token))
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.AzureRepos, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, azureReposPushExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Empty(t, actual.ChangedFiles)
+	assert.Equal(t, "aad331d8d3b131fa9ae03cf5e53965b51942618a", actual.BeforeCommit)
+	assert.Equal(t, "33b55f7cb7e7e245323987634f960cf4a6e6bc74", actual.Commit)
+	assert.Len(t, actual.Changes, 1)
+	assert.Equal(t, []WebHookInfoCommit{{
+		Hash:      "33b55f7cb7e7e245323987634f960cf4a6e6bc74",
+		Message:   "Update README.md",
+		Author:    WebhookInfoUser{DisplayName: "Yahav Itzhak", Email: "yahavi@example.com"},
+		Timestamp: 1679217648,
+	}}, actual.Commits)
+}
+
+func TestAzureReposParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		name                    string
+		payloadFilename         string
+		expectedTime            int64
+		expectedEventType       vcsutils.WebhookEvent
+		expectedMergeCommitHash string
+		expectedLabels          []string
+	}{
+		{
+			name:              "create",
+			payloadFilename:   "prcreatepayload.json",
+			expectedTime:      azureReposPrCreateExpectedTime,
+			expectedEventType: vcsutils.PrOpened,
+		},
+		{
+			name:              "update",
+			payloadFilename:   "prupdatepayload.json",
+			expectedTime:      azureReposPrUpdateExpectedTime,
+			expectedEventType: vcsutils.PrEdited,
+			expectedLabels:    []string{"security"},
+		},
+		{
+			name:                    "merge",
+			payloadFilename:         "prmergepayload.json",
+			expectedTime:            azureReposPrMergeExpectedTime,
+			expectedEventType:       vcsutils.PrMerged,
+			expectedMergeCommitHash: "6a1d0c32e5b6f4c9a8d7e2f1b0c3a4d5e6f7a8b9",
+		},
+		{
+			name:              "abandon",
+			payloadFilename:   "prabandonpayload.json",
+			expectedTime:      azureReposPrAbandonExpectedTime,
+			expectedEventType: vcsutils.PrRejected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "azurerepos", tt.payloadFilename))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.SetBasicAuth("froggit", string(token))
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.AzureRepos, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, azureReposExpectedPrID, actual.PullRequestId)
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.Equal(t, expectedRepoName, actual.SourceRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.SourceRepositoryDetails.Owner)
+			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, "03c164c2-8912-4d5e-8009-3707d5f83734", actual.DeliveryID)
+			assert.Contains(t, actual.RawEvent, "git.pullrequest.")
+			assert.Equal(t, &WebhookInfoPullRequest{
+				Title: "Update README.md",
+				Body:  "Update README.md",
+				Author: WebhookInfoUser{
+					Username:    "yahavi@example.com",
+					DisplayName: "Yahav Itzhak",
+					AvatarURL:   "https://dev.azure.com/yahavi/_api/_common/identityImage?id=54d125f7-69f7-4191-904f-c5b96b6261c8",
+				},
+				Labels:          tt.expectedLabels,
+				CreatedAt:       time.Date(2023, time.March, 19, 10, 15, 21, 234567800, time.UTC),
+				MergeCommitHash: tt.expectedMergeCommitHash,
+			}, actual.PullRequest)
+		})
+	}
+}
+
+func TestAzureReposParseIncomingPrCommentWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "azurerepos", "prcommentpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.SetBasicAuth("froggit", string(token))
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.AzureRepos, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, azureReposExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+	assert.Equal(t, azureReposPrCommentExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.PrCommentCreated, actual.Event)
+	assert.Equal(t, "ms.vss-code.git-pullrequest-comment-event", actual.RawEvent)
+	assert.Equal(t, "Update README.md", actual.PullRequest.Title)
+	assert.Equal(t, &WebhookInfoComment{
+		ID:   2,
+		Body: "This is my comment",
+		Author: WebhookInfoUser{
+			Username:    "yahavi@example.com",
+			DisplayName: "Yahav Itzhak",
+			AvatarURL:   "https://dev.azure.com/yahavi/_api/_common/identityImage?id=54d125f7-69f7-4191-904f-c5b96b6261c8",
+		},
+	}, actual.Comment)
+}
+
+func TestAzureReposParseIncomingWebhookError(t *testing.T) {
+	request := &http.Request{Header: http.Header{}}
+	request.SetBasicAuth("froggit", "a")
+	_, err := ParseIncomingWebhook(vcsutils.AzureRepos, token, request)
+	assert.Error(t, err)
+
+	webhook := AzureReposWebhook{}
+	_, err = webhook.parseIncomingWebhook([]byte{})
+	assert.Error(t, err)
+}
+
+func TestAzureReposParseIncomingUnsupportedWebhook(t *testing.T) {
+	webhook := AzureReposWebhook{}
+	_, err := webhook.parseIncomingWebhook([]byte(`{"eventType":"git.pullrequest.merged","resource":{"mergeStatus":"conflicts"}}`))
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+
+	_, err = webhook.parseIncomingWebhook([]byte(`{"eventType":"ms.vss-code.git-pullrequest-comment-event"}`))
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestAzureReposPayloadMismatchToken(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "azurerepos", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.SetBasicAuth("froggit", "wrong-token")
+
+	// Parse webhook
+	_, err = ParseIncomingWebhook(vcsutils.AzureRepos, token, request)
+	assert.EqualError(t, err, "token mismatch")
+
+	// A service hook subscription without basic authentication
+	_, err = ParseIncomingWebhook(vcsutils.AzureRepos, token, httptest.NewRequest("POST", "https://127.0.0.1", nil))
+	assert.EqualError(t, err, "basic authentication is missing, although a token is expected")
+}
@@ -2,15 +2,21 @@ package webhookparser
 
 import (
 	"bytes"
+	"crypto/hmac"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/mail"
 	"strings"
 	"time"
 
 	"github.com/jfrog/froggit-go/vcsutils"
 )
 
+// Sent by Bitbucket Cloud only, used to tell it apart from Bitbucket Server
+const bitbucketCloudHookUUIDHeader = "X-Hook-UUID"
+
 // BitbucketCloudWebhook represents an incoming webhook on Bitbucket cloud
 type BitbucketCloudWebhook struct {
 	request *http.Request
@@ -24,9 +30,15 @@ func NewBitbucketCloudWebhookWebhook(request *http.Request) *BitbucketCloudWebho
 }
 
 func (webhook *BitbucketCloudWebhook) validatePayload(token []byte) ([]byte, error) {
+	// When a secret is configured, Bitbucket Cloud signs the payload and sends the signature in the X-Hub-Signature header.
+	// The signature isn't verified if the token is empty.
+	if expectedSignature := webhook.request.Header.Get(sha256Signature); expectedSignature != "" && len(token) > 0 {
+		return webhook.validatePayloadSignature(token, expectedSignature)
+	}
+
 	keys, tokenParamsExist := webhook.request.URL.Query()["token"]
 	if len(token) > 0 || tokenParamsExist {
-		if keys[0] != string(token) {
+		if !tokenParamsExist || keys[0] != string(token) {
 			return nil, errors.New("token mismatch")
 		}
 	}
@@ -37,6 +49,18 @@ func (webhook *BitbucketCloudWebhook) validatePayload(token []byte) ([]byte, err
 	return payload.Bytes(), nil
 }
 
+func (webhook *BitbucketCloudWebhook) validatePayloadSignature(token []byte, expectedSignature string) ([]byte, error) {
+	payload := new(bytes.Buffer)
+	if _, err := payload.ReadFrom(webhook.request.Body); err != nil {
+		return nil, err
+	}
+	actualSignature := calculatePayloadSignature(payload.Bytes(), token)
+	if !hmac.Equal([]byte(expectedSignature), []byte("sha256="+actualSignature)) {
+		return nil, errors.New("payload signature mismatch")
+	}
+	return payload.Bytes(), nil
+}
+
 func (webhook *BitbucketCloudWebhook) parseIncomingWebhook(payload []byte) (*WebhookInfo, error) {
 	bitbucketCloudWebHook := &bitbucketCloudWebHook{}
 	err := json.Unmarshal(payload, bitbucketCloudWebHook)
@@ -56,16 +80,107 @@ func (webhook *BitbucketCloudWebhook) parseIncomingWebhook(payload []byte) (*Web
 		return webhook.parsePrEvents(bitbucketCloudWebHook, vcsutils.PrMerged), nil
 	case "pullrequest:rejected":
 		return webhook.parsePrEvents(bitbucketCloudWebHook, vcsutils.PrRejected), nil
+	case "pullrequest:comment_created":
+		return webhook.parsePrCommentEvent(bitbucketCloudWebHook), nil
+	case "pullrequest:approved":
+		return webhook.parsePrReviewEvent(bitbucketCloudWebHook.Approval, ReviewApproved, bitbucketCloudWebHook), nil
+	case "pullrequest:unapproved":
+		return webhook.parsePrReviewEvent(bitbucketCloudWebHook.Approval, ReviewUnapproved, bitbucketCloudWebHook), nil
+	case "pullrequest:changes_request_created":
+		return webhook.parsePrReviewEvent(bitbucketCloudWebHook.ChangesRequest, ReviewChangesRequested, bitbucketCloudWebHook), nil
+	case "repo:fork":
+		return webhook.parseForkEvent(bitbucketCloudWebHook), nil
+	case "repo:updated":
+		return webhook.parseRepoUpdatedEvent(bitbucketCloudWebHook), nil
+	case "repo:commit_status_created", "repo:commit_status_updated":
+		return webhook.parseCommitStatusEvent(bitbucketCloudWebHook), nil
 	}
-	return nil, nil
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, event)
 }
 
 func (webhook *BitbucketCloudWebhook) parsePushEvent(bitbucketCloudWebHook *bitbucketCloudWebHook) *WebhookInfo {
-	return &WebhookInfo{
-		TargetRepositoryDetails: webhook.parseRepoFullName(bitbucketCloudWebHook.Repository.FullName),
-		TargetBranch:            bitbucketCloudWebHook.Push.Changes[0].New.Name,
-		Timestamp:               bitbucketCloudWebHook.Push.Changes[0].New.Target.Date.UTC().Unix(),
-		Event:                   vcsutils.Push,
+	repositoryDetails := webhook.parseRepoFullName(bitbucketCloudWebHook.Repository.FullName)
+	changes := make([]WebhookInfo, 0, len(bitbucketCloudWebHook.Push.Changes))
+	for _, change := range bitbucketCloudWebHook.Push.Changes {
+		changes = append(changes, webhook.parsePushChange(repositoryDetails, change))
+	}
+	if len(changes) == 0 {
+		return &WebhookInfo{TargetRepositoryDetails: repositoryDetails, Event: vcsutils.Push}
+	}
+	// The first change is reflected in the top-level fields, all changes are available in the Changes field
+	webhookInfo := changes[0]
+	webhookInfo.Changes = changes
+	return &webhookInfo
+}
+
+func (webhook *BitbucketCloudWebhook) parsePushChange(repositoryDetails WebHookInfoRepoDetails, change bitbucketCloudPushChange) WebhookInfo {
+	if change.New.Type == "tag" || change.Old.Type == "tag" {
+		return webhook.parseTagChange(repositoryDetails, change)
+	}
+	// A created branch has no "old" state, and a deleted branch has no "new" state
+	if change.New.Type == "" {
+		return WebhookInfo{
+			TargetRepositoryDetails: repositoryDetails,
+			TargetBranch:            change.Old.Name,
+			Timestamp:               change.Old.Target.Date.UTC().Unix(),
+			Time:                    change.Old.Target.Date,
+			Event:                   vcsutils.BranchDeleted,
+			BeforeCommit:            change.Old.Target.Hash,
+		}
+	}
+	webhookEvent := vcsutils.Push
+	if change.Old.Type == "" {
+		webhookEvent = vcsutils.BranchCreated
+	}
+	return WebhookInfo{
+		TargetRepositoryDetails: repositoryDetails,
+		TargetBranch:            change.New.Name,
+		Timestamp:               change.New.Target.Date.UTC().Unix(),
+		Time:                    change.New.Target.Date,
+		Event:                   webhookEvent,
+		ForcePush:               change.Forced,
+		BeforeCommit:            change.Old.Target.Hash,
+		Commit:                  change.New.Target.Hash,
+		Commits:                 webhook.parseCommits(change),
+	}
+}
+
+func (webhook *BitbucketCloudWebhook) parseCommits(change bitbucketCloudPushChange) []WebHookInfoCommit {
+	var commits []WebHookInfoCommit
+	for _, commit := range change.Commits {
+		author := webhook.parseUser(commit.Author.User)
+		// The raw author is formatted as "Name <email>"
+		if address, err := mail.ParseAddress(commit.Author.Raw); err == nil {
+			author.Email = address.Address
+			if author.DisplayName == "" {
+				author.DisplayName = address.Name
+			}
+		}
+		commits = append(commits, WebHookInfoCommit{
+			Hash:      commit.Hash,
+			Message:   commit.Message,
+			Author:    author,
+			Timestamp: commit.Date.UTC().Unix(),
+		})
+	}
+	return commits
+}
+
+func (webhook *BitbucketCloudWebhook) parseTagChange(repositoryDetails WebHookInfoRepoDetails, change bitbucketCloudPushChange) WebhookInfo {
+	// A removed tag has no "new" state, so the details are taken from the "old" one
+	webhookEvent, tag := vcsutils.TagPushed, change.New
+	if tag.Type == "" {
+		webhookEvent, tag = vcsutils.TagRemoved, change.Old
+	}
+	return WebhookInfo{
+		TargetRepositoryDetails: repositoryDetails,
+		Timestamp:               tag.Target.Date.UTC().Unix(),
+		Time:                    tag.Target.Date,
+		Event:                   webhookEvent,
+		Tag: &WebhookInfoTag{
+			Name: tag.Name,
+			Hash: tag.Target.Hash,
+		},
 	}
 }
 
@@ -77,7 +192,87 @@ func (webhook *BitbucketCloudWebhook) parsePrEvents(bitbucketCloudWebHook *bitbu
 		SourceRepositoryDetails: webhook.parseRepoFullName(bitbucketCloudWebHook.PullRequest.Source.Repository.FullName),
 		SourceBranch:            bitbucketCloudWebHook.PullRequest.Source.Branch.Name,
 		Timestamp:               bitbucketCloudWebHook.PullRequest.UpdatedOn.UTC().Unix(),
+		Time:                    bitbucketCloudWebHook.PullRequest.UpdatedOn,
 		Event:                   event,
+		PullRequest: &WebhookInfoPullRequest{
+			Title:           bitbucketCloudWebHook.PullRequest.Title,
+			Body:            bitbucketCloudWebHook.PullRequest.Description,
+			Author:          webhook.parseUser(bitbucketCloudWebHook.PullRequest.Author),
+			Draft:           bitbucketCloudWebHook.PullRequest.Draft,
+			CreatedAt:       bitbucketCloudWebHook.PullRequest.CreatedOn,
+			UpdatedAt:       bitbucketCloudWebHook.PullRequest.UpdatedOn,
+			MergeCommitHash: bitbucketCloudWebHook.PullRequest.MergeCommit.Hash,
+		},
+	}
+}
+
+func (webhook *BitbucketCloudWebhook) parsePrCommentEvent(bitbucketCloudWebHook *bitbucketCloudWebHook) *WebhookInfo {
+	webhookInfo := webhook.parsePrEvents(bitbucketCloudWebHook, vcsutils.PrCommentCreated)
+	comment := bitbucketCloudWebHook.Comment
+	webhookInfo.Timestamp, webhookInfo.Time = comment.CreatedOn.UTC().Unix(), comment.CreatedOn
+	webhookInfo.Comment = &WebhookInfoComment{
+		ID:     comment.ID,
+		Body:   comment.Content.Raw,
+		Author: webhook.parseUser(comment.User),
+	}
+	return webhookInfo
+}
+
+func (webhook *BitbucketCloudWebhook) parsePrReviewEvent(review bitbucketCloudReview, reviewState ReviewState, bitbucketCloudWebHook *bitbucketCloudWebHook) *WebhookInfo {
+	webhookInfo := webhook.parsePrEvents(bitbucketCloudWebHook, vcsutils.PrReviewed)
+	webhookInfo.Timestamp, webhookInfo.Time = review.Date.UTC().Unix(), review.Date
+	webhookInfo.Review = &WebhookInfoReview{
+		State:    reviewState,
+		Reviewer: webhook.parseUser(review.User),
+	}
+	return webhookInfo
+}
+
+func (webhook *BitbucketCloudWebhook) parseForkEvent(bitbucketCloudWebHook *bitbucketCloudWebHook) *WebhookInfo {
+	fork := webhook.parseRepoFullName(bitbucketCloudWebHook.Fork.FullName)
+	return &WebhookInfo{
+		TargetRepositoryDetails: webhook.parseRepoFullName(bitbucketCloudWebHook.Repository.FullName),
+		Timestamp:               bitbucketCloudWebHook.Fork.CreatedOn.UTC().Unix(),
+		Time:                    bitbucketCloudWebHook.Fork.CreatedOn,
+		Event:                   vcsutils.RepoForked,
+		Fork:                    &fork,
+	}
+}
+
+func (webhook *BitbucketCloudWebhook) parseRepoUpdatedEvent(bitbucketCloudWebHook *bitbucketCloudWebHook) *WebhookInfo {
+	// The repository details are the updated ones
+	return &WebhookInfo{
+		TargetRepositoryDetails: webhook.parseRepoFullName(bitbucketCloudWebHook.Repository.FullName),
+		Timestamp:               bitbucketCloudWebHook.Repository.UpdatedOn.UTC().Unix(),
+		Time:                    bitbucketCloudWebHook.Repository.UpdatedOn,
+		Event:                   vcsutils.RepoUpdated,
+	}
+}
+
+func (webhook *BitbucketCloudWebhook) parseCommitStatusEvent(bitbucketCloudWebHook *bitbucketCloudWebHook) *WebhookInfo {
+	commitStatus := bitbucketCloudWebHook.CommitStatus
+	return &WebhookInfo{
+		TargetRepositoryDetails: webhook.parseRepoFullName(bitbucketCloudWebHook.Repository.FullName),
+		TargetBranch:            commitStatus.RefName,
+		Timestamp:               commitStatus.UpdatedOn.UTC().Unix(),
+		Time:                    commitStatus.UpdatedOn,
+		Event:                   vcsutils.CommitStatusUpdated,
+		CommitStatus: &WebhookInfoCommitStatus{
+			Key:         commitStatus.Key,
+			Name:        commitStatus.Name,
+			Description: commitStatus.Description,
+			State:       commitStatus.State,
+			URL:         commitStatus.URL,
+			Hash:        commitStatus.Commit.Hash,
+		},
+	}
+}
+
+func (webhook *BitbucketCloudWebhook) parseUser(user bitbucketCloudUser) WebhookInfoUser {
+	return WebhookInfoUser{
+		Username:    user.Nickname,
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.Links.Avatar.Href,
 	}
 }
 
@@ -93,26 +288,81 @@ func (webhook *BitbucketCloudWebhook) parseRepoFullName(fullName string) WebHook
 
 type bitbucketCloudWebHook struct {
 	Push struct {
-		Changes []struct {
-			New struct {
-				Name   string `json:"name,omitempty"` // Branch name
-				Target struct {
-					Date time.Time `json:"date,omitempty"` // Timestamp
-				} `json:"target,omitempty"`
-			} `json:"new,omitempty"`
-		} `json:"changes,omitempty"`
+		Changes []bitbucketCloudPushChange `json:"changes,omitempty"`
 	} `json:"push,omitempty"`
 	PullRequest struct {
 		ID          int                                  `json:"id,omitempty"`
+		Title       string                               `json:"title,omitempty"`
+		Description string                               `json:"description,omitempty"`
+		Author      bitbucketCloudUser                   `json:"author,omitempty"`
+		Draft       bool                                 `json:"draft,omitempty"`
 		Source      struct{ bitbucketCloudPrRepository } `json:"source,omitempty"`
 		Destination struct{ bitbucketCloudPrRepository } `json:"destination,omitempty"`
+		CreatedOn   time.Time                            `json:"created_on,omitempty"`
 		UpdatedOn   time.Time                            `json:"updated_on,omitempty"` // Timestamp
+		// Set when the pull request is merged
+		MergeCommit struct {
+			Hash string `json:"hash,omitempty"`
+		} `json:"merge_commit,omitempty"`
 	} `json:"pullrequest,omitempty"`
-	Repository bitbucketCloudRepository `json:"repository,omitempty"`
+	Comment struct {
+		ID      int64 `json:"id,omitempty"`
+		Content struct {
+			Raw string `json:"raw,omitempty"`
+		} `json:"content,omitempty"`
+		User      bitbucketCloudUser `json:"user,omitempty"`
+		CreatedOn time.Time          `json:"created_on,omitempty"` // Timestamp
+	} `json:"comment,omitempty"`
+	// Pull request review events
+	Approval       bitbucketCloudReview     `json:"approval,omitempty"`
+	ChangesRequest bitbucketCloudReview     `json:"changes_request,omitempty"`
+	Repository     bitbucketCloudRepository `json:"repository,omitempty"`
+	// Repository fork events
+	Fork bitbucketCloudRepository `json:"fork,omitempty"`
+	// Commit status events
+	CommitStatus struct {
+		Key         string `json:"key,omitempty"`
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+		State       string `json:"state,omitempty"` // SUCCESSFUL, FAILED, INPROGRESS or STOPPED
+		URL         string `json:"url,omitempty"`
+		RefName     string `json:"refname,omitempty"` // Branch name, if the status was reported for a branch
+		Commit      struct {
+			Hash string `json:"hash,omitempty"` // Commit SHA
+		} `json:"commit,omitempty"`
+		UpdatedOn time.Time `json:"updated_on,omitempty"` // Timestamp
+	} `json:"commit_status,omitempty"`
+}
+
+type bitbucketCloudPushChange struct {
+	New    bitbucketCloudRef `json:"new,omitempty"`
+	Old    bitbucketCloudRef `json:"old,omitempty"`
+	Forced bool              `json:"forced,omitempty"`
+	// Up to 5 of the pushed commits, from the newest to the oldest
+	Commits []struct {
+		Hash    string    `json:"hash,omitempty"`
+		Message string    `json:"message,omitempty"`
+		Date    time.Time `json:"date,omitempty"`
+		Author  struct {
+			Raw  string             `json:"raw,omitempty"`
+			User bitbucketCloudUser `json:"user,omitempty"`
+		} `json:"author,omitempty"`
+	} `json:"commits,omitempty"`
+}
+
+type bitbucketCloudRef struct {
+	Type   string `json:"type,omitempty"` // branch or tag
+	Name   string `json:"name,omitempty"` // Branch or tag name
+	Target struct {
+		Hash string    `json:"hash,omitempty"` // Commit SHA
+		Date time.Time `json:"date,omitempty"` // Timestamp
+	} `json:"target,omitempty"`
 }
 
 type bitbucketCloudRepository struct {
-	FullName string `json:"full_name,omitempty"` // Repository full name
+	FullName  string    `json:"full_name,omitempty"` // Repository full name
+	CreatedOn time.Time `json:"created_on,omitempty"`
+	UpdatedOn time.Time `json:"updated_on,omitempty"`
 }
 
 type bitbucketCloudPrRepository struct {
@@ -121,3 +371,18 @@ type bitbucketCloudPrRepository struct {
 		Name string `json:"name,omitempty"` // Branch name
 	} `json:"branch,omitempty"`
 }
+
+type bitbucketCloudReview struct {
+	Date time.Time          `json:"date,omitempty"` // Timestamp
+	User bitbucketCloudUser `json:"user,omitempty"`
+}
+
+type bitbucketCloudUser struct {
+	Nickname    string `json:"nickname,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Links       struct {
+		Avatar struct {
+			Href string `json:"href,omitempty"`
+		} `json:"avatar,omitempty"`
+	} `json:"links,omitempty"`
+}
@@ -15,12 +15,19 @@ import (
 )
 
 const (
-	bitbucketCloudPushExpectedTime     = int64(1630824565)
-	bitbucketCloudPrCreateExpectedTime = int64(1630831665)
-	bitbucketCloudPrUpdateExpectedTime = int64(1630844170)
-	bitbucketCloudPrMergeExpectedTime  = int64(1638783257)
-	bitbucketCloudPrCloseExpectedTime  = int64(1638784487)
-	bitbucketCloudExpectedPrID         = 2
+	bitbucketCloudPushExpectedTime       = int64(1630824565)
+	bitbucketCloudPrCreateExpectedTime   = int64(1630831665)
+	bitbucketCloudPrUpdateExpectedTime   = int64(1630844170)
+	bitbucketCloudPrMergeExpectedTime    = int64(1638783257)
+	bitbucketCloudPrCloseExpectedTime    = int64(1638784487)
+	bitbucketCloudPrCommentExpectedTime  = int64(1647261680)
+	bitbucketCloudPrApprovedExpectedTime = int64(1647262327)
+	bitbucketCloudForkExpectedTime       = int64(1647261910)
+	bitbucketCloudRepoUpdateExpectedTime = int64(1647262951)
+	bitbucketCloudStatusExpectedTime     = int64(1647263565)
+	bitbucketCloudExpectedPrID           = 2
+	bitbucketCloudPushSha256             = "d1551f1c74419c562040bb8777e40728e6ced906fb2edd981e24a2dab80f9e54"
+	bitbucketCloudExpectedTagHash        = "fa8c303777d0006fa99b843b830ad1ed18a6928e"
 )
 
 func TestBitbucketCloudParseIncomingPushWebhook(t *testing.T) {
@@ -42,15 +49,137 @@ func TestBitbucketCloudParseIncomingPushWebhook(t *testing.T) {
 	assert.Equal(t, expectedBranch, actual.TargetBranch)
 	assert.Equal(t, bitbucketCloudPushExpectedTime, actual.Timestamp)
 	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.False(t, actual.ForcePush)
+	assert.Equal(t, "a2b4032ae25e08844b894e413d80ee75b4c1995b", actual.BeforeCommit)
+	assert.Equal(t, "fa8c303777d0006fa99b843b830ad1ed18a6928e", actual.Commit)
+	assert.Equal(t, []WebHookInfoCommit{{
+		Hash:    "fa8c303777d0006fa99b843b830ad1ed18a6928e",
+		Message: "README.md edited online with Bitbucket",
+		Author: WebhookInfoUser{
+			Username:    expectedOwner,
+			DisplayName: "Yahav Itzhak",
+			AvatarURL:   "https://secure.gravatar.com/avatar/9680da1674e22a1de17acb19bb233ebf?d=https%3A%2F%2Favatar-management--avatars.us-west-2.prod.public.atl-paas.net%2Finitials%2FYI-5.png",
+			Email:       "yahavitz@gmail.com",
+		},
+		Timestamp: bitbucketCloudPushExpectedTime,
+	}}, actual.Commits)
 }
 
-func TestBitbucketCloudParseIncomingPrWebhook(t *testing.T) {
+func TestBitbucketCloudParseIncomingPushWebhookWithSignature(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(EventHeaderKey, "repo:push")
+	request.Header.Add(sha256Signature, "sha256="+bitbucketCloudPushSha256)
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, bitbucketCloudPushExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+}
+
+func TestBitbucketCloudParseIncomingPushWebhookWithSignatureWithoutToken(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(EventHeaderKey, "repo:push")
+	request.Header.Add(sha256Signature, "sha256="+bitbucketCloudPushSha256)
+
+	// Parse webhook without a token, so the signature isn't verified
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, nil, request)
+	require.NoError(t, err)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+}
+
+func TestBitbucketCloudParseIncomingMultipleChangesPushWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "multipushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1?token="+string(token), reader)
+	request.Header.Add(EventHeaderKey, "repo:push")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.True(t, actual.ForcePush)
+	require.Len(t, actual.Changes, 2)
+	assert.Equal(t, expectedBranch, actual.Changes[0].TargetBranch)
+	assert.Equal(t, vcsutils.Push, actual.Changes[0].Event)
+	assert.Equal(t, expectedRepoName, actual.Changes[1].TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.Changes[1].TargetRepositoryDetails.Owner)
+	assert.Equal(t, bitbucketCloudPushExpectedTime, actual.Changes[1].Timestamp)
+	assert.Equal(t, vcsutils.TagPushed, actual.Changes[1].Event)
+	assert.Equal(t, &WebhookInfoTag{Name: expectedTag, Hash: bitbucketCloudExpectedTagHash}, actual.Changes[1].Tag)
+}
+
+func TestBitbucketCloudParseIncomingTagWebhook(t *testing.T) {
 	tests := []struct {
 		name              string
 		payloadFilename   string
-		eventHeader       string
-		expectedTime      int64
 		expectedEventType vcsutils.WebhookEvent
+	}{
+		{
+			name:              "push",
+			payloadFilename:   "tagpushpayload.json",
+			expectedEventType: vcsutils.TagPushed,
+		},
+		{
+			name:              "delete",
+			payloadFilename:   "tagdeletepayload.json",
+			expectedEventType: vcsutils.TagRemoved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", tt.payloadFilename))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1?token="+string(token), reader)
+			request.Header.Add(EventHeaderKey, "repo:push")
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, bitbucketCloudPushExpectedTime, actual.Timestamp)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, &WebhookInfoTag{Name: expectedTag, Hash: bitbucketCloudExpectedTagHash}, actual.Tag)
+		})
+	}
+}
+
+func TestBitbucketCloudParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		name                    string
+		payloadFilename         string
+		eventHeader             string
+		expectedTime            int64
+		expectedEventType       vcsutils.WebhookEvent
+		expectedMergeCommitHash string
 	}{
 		{
 			name:              "create",
@@ -67,11 +196,12 @@ func TestBitbucketCloudParseIncomingPrWebhook(t *testing.T) {
 			expectedEventType: vcsutils.PrEdited,
 		},
 		{
-			name:              "merge",
-			payloadFilename:   "prmergepayload.json",
-			eventHeader:       "pullrequest:fulfilled",
-			expectedTime:      bitbucketCloudPrMergeExpectedTime,
-			expectedEventType: vcsutils.PrMerged,
+			name:                    "merge",
+			payloadFilename:         "prmergepayload.json",
+			eventHeader:             "pullrequest:fulfilled",
+			expectedTime:            bitbucketCloudPrMergeExpectedTime,
+			expectedEventType:       vcsutils.PrMerged,
+			expectedMergeCommitHash: "97c75a94ddc7",
 		},
 		{
 			name:              "close",
@@ -105,6 +235,13 @@ func TestBitbucketCloudParseIncomingPrWebhook(t *testing.T) {
 			assert.Equal(t, expectedOwner, actual.SourceRepositoryDetails.Owner)
 			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
 			assert.Equal(t, tt.expectedEventType, actual.Event)
+			require.NotNil(t, actual.PullRequest)
+			assert.Equal(t, tt.expectedMergeCommitHash, actual.PullRequest.MergeCommitHash)
+			assert.Equal(t, "Dev", actual.PullRequest.Title)
+			assert.Contains(t, actual.PullRequest.Body, "README.md edited online with Bitbucket")
+			assert.Equal(t, expectedOwner, actual.PullRequest.Author.Username)
+			assert.Equal(t, "Yahav Itzhak", actual.PullRequest.Author.DisplayName)
+			assert.Contains(t, actual.PullRequest.Author.AvatarURL, "https://secure.gravatar.com/avatar/")
 		})
 	}
 }
@@ -131,3 +268,189 @@ func TestBitbucketCloudPayloadMismatchToken(t *testing.T) {
 	_, err = ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
 	assert.EqualError(t, err, "token mismatch")
 }
+
+func TestBitbucketCloudPayloadMismatchSignature(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(EventHeaderKey, "repo:push")
+	request.Header.Add(sha256Signature, "sha256=wrongsignature")
+
+	// Parse webhook
+	_, err = ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+	assert.EqualError(t, err, "payload signature mismatch")
+}
+
+func TestBitbucketCloudPayloadMissingToken(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(EventHeaderKey, "repo:push")
+
+	// Parse webhook
+	_, err = ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+	assert.EqualError(t, err, "token mismatch")
+}
+
+func TestBitbucketCloudParseIncomingPrCommentWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "prcommentpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1?token="+string(token), reader)
+	request.Header.Add(EventHeaderKey, "pullrequest:comment_created")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, bitbucketCloudExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+	assert.Equal(t, bitbucketCloudPrCommentExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.PrCommentCreated, actual.Event)
+	require.NotNil(t, actual.Comment)
+	assert.Equal(t, int64(307115543), actual.Comment.ID)
+	assert.Equal(t, "/frogbot scan", actual.Comment.Body)
+	assert.Equal(t, expectedOwner, actual.Comment.Author.Username)
+	assert.Equal(t, "Yahav Itzhak", actual.Comment.Author.DisplayName)
+}
+
+func TestBitbucketCloudParseIncomingPrApprovalWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "prapprovedpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1?token="+string(token), reader)
+	request.Header.Add(EventHeaderKey, "pullrequest:approved")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, bitbucketCloudExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+	assert.Equal(t, bitbucketCloudPrApprovedExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.PrReviewed, actual.Event)
+	require.NotNil(t, actual.Review)
+	assert.Equal(t, ReviewApproved, actual.Review.State)
+	assert.Equal(t, expectedOwner, actual.Review.Reviewer.Username)
+	assert.Equal(t, "Yahav Itzhak", actual.Review.Reviewer.DisplayName)
+}
+
+func TestBitbucketCloudParseIncomingForkWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "forkpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1?token="+string(token), reader)
+	request.Header.Add(EventHeaderKey, "repo:fork")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, vcsutils.RepoForked, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+	assert.Equal(t, &WebHookInfoRepoDetails{Name: expectedRepoName, Owner: "forker"}, actual.Fork)
+	assert.Equal(t, bitbucketCloudForkExpectedTime, actual.Timestamp)
+}
+
+func TestBitbucketCloudParseIncomingRepoUpdatedWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "repoupdatedpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1?token="+string(token), reader)
+	request.Header.Add(EventHeaderKey, "repo:updated")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, vcsutils.RepoUpdated, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+	assert.Equal(t, bitbucketCloudRepoUpdateExpectedTime, actual.Timestamp)
+}
+
+func TestBitbucketCloudParseIncomingCommitStatusWebhook(t *testing.T) {
+	for _, event := range []string{"repo:commit_status_created", "repo:commit_status_updated"} {
+		t.Run(event, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "commitstatuspayload.json"))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1?token="+string(token), reader)
+			request.Header.Add(EventHeaderKey, event)
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, vcsutils.CommitStatusUpdated, actual.Event)
+			assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, bitbucketCloudStatusExpectedTime, actual.Timestamp)
+			assert.Equal(t, &WebhookInfoCommitStatus{
+				Key:         "froggit-build",
+				Name:        "Build #12",
+				Description: "The build passed",
+				State:       "SUCCESSFUL",
+				URL:         "https://ci.example.com/builds/12",
+				Hash:        bitbucketCloudExpectedTagHash,
+			}, actual.CommitStatus)
+		})
+	}
+}
+
+func TestBitbucketCloudParseIncomingUnsupportedWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1?token="+string(token), reader)
+	request.Header.Add(EventHeaderKey, "issue:created")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketCloud, token, request)
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestBitbucketCloudParsePushChangeBranchEvents(t *testing.T) {
+	webhook := &BitbucketCloudWebhook{}
+	branch := bitbucketCloudRef{Type: "branch", Name: expectedBranch}
+
+	actual := webhook.parsePushChange(WebHookInfoRepoDetails{}, bitbucketCloudPushChange{New: branch, Old: branch})
+	assert.Equal(t, vcsutils.Push, actual.Event)
+
+	actual = webhook.parsePushChange(WebHookInfoRepoDetails{}, bitbucketCloudPushChange{New: branch})
+	assert.Equal(t, vcsutils.BranchCreated, actual.Event)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+
+	actual = webhook.parsePushChange(WebHookInfoRepoDetails{}, bitbucketCloudPushChange{Old: branch})
+	assert.Equal(t, vcsutils.BranchDeleted, actual.Event)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -65,8 +66,16 @@ func (webhook *BitbucketServerWebhook) parseIncomingWebhook(payload []byte) (*We
 		return webhook.parsePrEvents(bitbucketServerWebHook, vcsutils.PrMerged)
 	case "pr:declined", "pr:deleted":
 		return webhook.parsePrEvents(bitbucketServerWebHook, vcsutils.PrRejected)
+	case "pr:comment:added":
+		return webhook.parsePrCommentEvent(bitbucketServerWebHook)
+	case "pr:reviewer:approved":
+		return webhook.parsePrReviewEvent(bitbucketServerWebHook, ReviewApproved)
+	case "pr:reviewer:unapproved":
+		return webhook.parsePrReviewEvent(bitbucketServerWebHook, ReviewUnapproved)
+	case "pr:reviewer:needs_work":
+		return webhook.parsePrReviewEvent(bitbucketServerWebHook, ReviewChangesRequested)
 	}
-	return nil, nil
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, event)
 }
 
 func calculatePayloadSignature(payload []byte, token []byte) string {
@@ -75,18 +84,50 @@ func calculatePayloadSignature(payload []byte, token []byte) string {
 	return hex.EncodeToString(hmacHash.Sum(nil))
 }
 
-func (webhook *BitbucketServerWebhook) parsePushEvent(bitbucketCloudWebHook *bitbucketServerWebHook) (*WebhookInfo, error) {
-	eventTime, err := time.Parse("2006-01-02T15:04:05-0700", bitbucketCloudWebHook.Date)
+func (webhook *BitbucketServerWebhook) parsePushEvent(bitbucketServerWebHook *bitbucketServerWebHook) (*WebhookInfo, error) {
+	eventTime, err := time.Parse("2006-01-02T15:04:05-0700", bitbucketServerWebHook.Date)
 	if err != nil {
 		return nil, err
 	}
-	repository := bitbucketCloudWebHook.Repository
-	return &WebhookInfo{
-		TargetRepositoryDetails: webhook.getRepositoryDetails(repository),
-		TargetBranch:            strings.TrimPrefix(bitbucketCloudWebHook.Changes[0].RefID, "refs/heads/"),
+	repositoryDetails := webhook.getRepositoryDetails(bitbucketServerWebHook.Repository)
+	changes := make([]WebhookInfo, 0, len(bitbucketServerWebHook.Changes))
+	for _, change := range bitbucketServerWebHook.Changes {
+		changes = append(changes, webhook.parsePushChange(repositoryDetails, change, eventTime))
+	}
+	if len(changes) == 0 {
+		return &WebhookInfo{TargetRepositoryDetails: repositoryDetails, Timestamp: eventTime.UTC().Unix(), Time: eventTime, Event: vcsutils.Push}, nil
+	}
+	webhookInfo := changes[0]
+	webhookInfo.Changes = changes
+	return &webhookInfo, nil
+}
+
+func (webhook *BitbucketServerWebhook) parsePushChange(repositoryDetails WebHookInfoRepoDetails, change bitbucketServerRefChange, eventTime time.Time) WebhookInfo {
+	if change.Ref.Type == "TAG" {
+		webhookEvent, hash := vcsutils.TagPushed, change.ToHash
+		if change.Type == "DELETE" {
+			webhookEvent, hash = vcsutils.TagRemoved, change.FromHash
+		}
+		return WebhookInfo{
+			TargetRepositoryDetails: repositoryDetails,
+			Timestamp:               eventTime.UTC().Unix(),
+			Time:                    eventTime,
+			Event:                   webhookEvent,
+			Tag: &WebhookInfoTag{
+				Name: strings.TrimPrefix(change.RefID, tagPrefix),
+				Hash: hash,
+			},
+		}
+	}
+	return WebhookInfo{
+		TargetRepositoryDetails: repositoryDetails,
+		TargetBranch:            strings.TrimPrefix(change.RefID, "refs/heads/"),
 		Timestamp:               eventTime.UTC().Unix(),
-		Event:                   vcsutils.Push,
-	}, nil
+		Time:                    eventTime,
+		Event:                   getBranchEvent(change.FromHash, change.ToHash),
+		BeforeCommit:            getCommitSha(change.FromHash),
+		Commit:                  getCommitSha(change.ToHash),
+	}
 }
 
 func (webhook *BitbucketServerWebhook) getRepositoryDetails(repository bitbucketv1.Repository) WebHookInfoRepoDetails {
@@ -108,16 +149,101 @@ func (webhook *BitbucketServerWebhook) parsePrEvents(bitbucketCloudWebHook *bitb
 		SourceRepositoryDetails: webhook.getRepositoryDetails(bitbucketCloudWebHook.PullRequest.FromRef.Repository),
 		SourceBranch:            strings.TrimPrefix(bitbucketCloudWebHook.PullRequest.FromRef.ID, "refs/heads/"),
 		Timestamp:               eventTime.UTC().Unix(),
+		Time:                    eventTime,
 		Event:                   event,
+		PullRequest:             webhook.parsePullRequest(bitbucketCloudWebHook.PullRequest),
 	}, nil
 }
 
+func (webhook *BitbucketServerWebhook) parsePullRequest(pullRequest bitbucketServerPullRequest) *WebhookInfoPullRequest {
+	// The payload times are milliseconds from epoch, without a time zone
+	webhookInfoPullRequest := &WebhookInfoPullRequest{
+		Title:           pullRequest.Title,
+		Body:            pullRequest.Description,
+		CreatedAt:       time.UnixMilli(pullRequest.CreatedDate).UTC(),
+		UpdatedAt:       time.UnixMilli(pullRequest.UpdatedDate).UTC(),
+		MergeCommitHash: pullRequest.Properties.MergeCommit.ID,
+	}
+	if pullRequest.Author != nil {
+		webhookInfoPullRequest.Author = WebhookInfoUser{
+			Username:    pullRequest.Author.User.Name,
+			DisplayName: pullRequest.Author.User.DisplayName,
+		}
+	}
+	return webhookInfoPullRequest
+}
+
+func (webhook *BitbucketServerWebhook) parsePrCommentEvent(bitbucketServerWebHook *bitbucketServerWebHook) (*WebhookInfo, error) {
+	webhookInfo, err := webhook.parsePrEvents(bitbucketServerWebHook, vcsutils.PrCommentCreated)
+	if err != nil {
+		return nil, err
+	}
+	comment := bitbucketServerWebHook.Comment
+	webhookInfo.Comment = &WebhookInfoComment{
+		ID:   comment.ID,
+		Body: comment.Text,
+		Author: WebhookInfoUser{
+			Username:    comment.Author.Name,
+			DisplayName: comment.Author.DisplayName,
+		},
+	}
+	return webhookInfo, nil
+}
+
+func (webhook *BitbucketServerWebhook) parsePrReviewEvent(bitbucketServerWebHook *bitbucketServerWebHook, reviewState ReviewState) (*WebhookInfo, error) {
+	webhookInfo, err := webhook.parsePrEvents(bitbucketServerWebHook, vcsutils.PrReviewed)
+	if err != nil {
+		return nil, err
+	}
+	reviewer := bitbucketServerWebHook.Participant.User
+	webhookInfo.Review = &WebhookInfoReview{
+		State: reviewState,
+		Reviewer: WebhookInfoUser{
+			Username:    reviewer.Name,
+			DisplayName: reviewer.DisplayName,
+		},
+	}
+	return webhookInfo, nil
+}
+
 type bitbucketServerWebHook struct {
-	EventKey    string                  `json:"eventKey,omitempty"`
-	Date        string                  `json:"date,omitempty"` // Timestamp
-	Repository  bitbucketv1.Repository  `json:"repository,omitempty"`
-	PullRequest bitbucketv1.PullRequest `json:"pullRequest,omitempty"`
-	Changes     []struct {
-		RefID string `json:"refId,omitempty"`
-	} `json:"changes,omitempty"`
+	EventKey    string                     `json:"eventKey,omitempty"`
+	Date        string                     `json:"date,omitempty"` // Timestamp
+	Repository  bitbucketv1.Repository     `json:"repository,omitempty"`
+	PullRequest bitbucketServerPullRequest `json:"pullRequest,omitempty"`
+	Changes     []bitbucketServerRefChange `json:"changes,omitempty"`
+	Comment     struct {
+		ID     int64               `json:"id,omitempty"`
+		Text   string              `json:"text,omitempty"`
+		Author bitbucketServerUser `json:"author,omitempty"`
+	} `json:"comment,omitempty"`
+	Participant struct {
+		User bitbucketServerUser `json:"user,omitempty"`
+	} `json:"participant,omitempty"`
+}
+
+type bitbucketServerPullRequest struct {
+	bitbucketv1.PullRequest
+	// Replaces the properties of bitbucketv1.PullRequest, which don't include the merge commit
+	Properties struct {
+		// Set when the pull request is merged
+		MergeCommit struct {
+			ID string `json:"id,omitempty"`
+		} `json:"mergeCommit,omitempty"`
+	} `json:"properties,omitempty"`
+}
+
+type bitbucketServerRefChange struct {
+	Ref struct {
+		Type string `json:"type,omitempty"` // BRANCH or TAG
+	} `json:"ref,omitempty"`
+	RefID    string `json:"refId,omitempty"`
+	FromHash string `json:"fromHash,omitempty"`
+	ToHash   string `json:"toHash,omitempty"`
+	Type     string `json:"type,omitempty"` // ADD, UPDATE or DELETE
+}
+
+type bitbucketServerUser struct {
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
 }
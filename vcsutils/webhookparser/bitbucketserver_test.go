@@ -35,7 +35,19 @@ const (
 	bitbucketServerPrDeleteExpectedTime = int64(1638794581)
 	bitbucketServerPrDeletedSha256      = "b0ccbd0f97ca030aa469cfa559f7051732c33fc63e7e3a8b5b8e2d157af71806"
 
+	bitbucketServerPrCommentExpectedTime = int64(1630999872)
+	bitbucketServerPrCommentSha256       = "719cbd4ba291377b23d26129f99e07dba0db99c8fb382213a8e3851947fb8200"
+
+	bitbucketServerPrApprovedExpectedTime = int64(1631178944)
+	bitbucketServerPrApprovedSha256       = "57bf00ec049b4b9b299272ac3e5a4cf15e7c03ee7d7097201b89056b90cefd1c"
+
 	bitbucketServerExpectedPrID = 3
+
+	bitbucketServerTagPushSha256   = "c43858ed920e2face5069ffffbc3e814f7b6ab1cd7bf8957664cdf42528a1506"
+	bitbucketServerTagDeleteSha256 = "df1affdad9e0594abecabc2f76c8027064c829ad4ad935c6b247e16a14c5c916"
+	bitbucketServerExpectedTagHash = "929d3054cf60e11a38672966f948bb5d95f48f0e"
+
+	bitbucketServerMultiPushSha256 = "3ae19ad66f329982dfb628ff1300fe2637bcf27e067555bc9614f69a32155103"
 )
 
 func TestBitbucketServerParseIncomingPushWebhook(t *testing.T) {
@@ -57,17 +69,92 @@ func TestBitbucketServerParseIncomingPushWebhook(t *testing.T) {
 	assert.Equal(t, formatOwnerForBitbucketServer(expectedOwner), actual.TargetRepositoryDetails.Owner)
 	assert.Equal(t, expectedBranch, actual.TargetBranch)
 	assert.Equal(t, bitbucketServerPushExpectedTime, actual.Timestamp)
-	assert.Equal(t, vcsutils.Push, actual.Event)
+	// The payload adds the branch
+	assert.Equal(t, vcsutils.BranchCreated, actual.Event)
+	assert.Empty(t, actual.BeforeCommit)
+	assert.Equal(t, "929d3054cf60e11a38672966f948bb5d95f48f0e", actual.Commit)
 }
 
-func TestBitbucketServerParseIncomingPrWebhook(t *testing.T) {
+func TestBitbucketServerParseIncomingMultipleChangesPushWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketserver", "multipushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(EventHeaderKey, "repo:refs_changed")
+	request.Header.Add(sha256Signature, "sha256="+bitbucketServerMultiPushSha256)
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketServer, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, vcsutils.BranchCreated, actual.Event)
+	require.Len(t, actual.Changes, 3)
+	assert.Equal(t, expectedBranch, actual.Changes[0].TargetBranch)
+	assert.Equal(t, expectedSourceBranch, actual.Changes[1].TargetBranch)
+	assert.Equal(t, vcsutils.Push, actual.Changes[1].Event)
+	assert.Equal(t, bitbucketServerPushExpectedTime, actual.Changes[1].Timestamp)
+	assert.Equal(t, vcsutils.TagPushed, actual.Changes[2].Event)
+	assert.Equal(t, &WebhookInfoTag{Name: expectedTag, Hash: bitbucketServerExpectedTagHash}, actual.Changes[2].Tag)
+}
+
+func TestBitbucketServerParseIncomingTagWebhook(t *testing.T) {
 	tests := []struct {
 		name              string
 		payloadFilename   string
-		eventHeader       string
 		payloadSha        string
-		expectedTime      int64
 		expectedEventType vcsutils.WebhookEvent
+	}{
+		{
+			name:              "push",
+			payloadFilename:   "tagpushpayload.json",
+			payloadSha:        bitbucketServerTagPushSha256,
+			expectedEventType: vcsutils.TagPushed,
+		},
+		{
+			name:              "delete",
+			payloadFilename:   "tagdeletepayload.json",
+			payloadSha:        bitbucketServerTagDeleteSha256,
+			expectedEventType: vcsutils.TagRemoved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "bitbucketserver", tt.payloadFilename))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.Header.Add(EventHeaderKey, "repo:refs_changed")
+			request.Header.Add(sha256Signature, "sha256="+tt.payloadSha)
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.BitbucketServer, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, formatOwnerForBitbucketServer(expectedOwner), actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, bitbucketServerPushExpectedTime, actual.Timestamp)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, &WebhookInfoTag{Name: expectedTag, Hash: bitbucketServerExpectedTagHash}, actual.Tag)
+		})
+	}
+}
+
+func TestBitbucketServerParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		name                    string
+		payloadFilename         string
+		eventHeader             string
+		payloadSha              string
+		expectedTime            int64
+		expectedEventType       vcsutils.WebhookEvent
+		expectedMergeCommitHash string
 	}{
 		{
 			name:              "create",
@@ -86,12 +173,13 @@ func TestBitbucketServerParseIncomingPrWebhook(t *testing.T) {
 			expectedEventType: vcsutils.PrEdited,
 		},
 		{
-			name:              "merge",
-			payloadFilename:   "prmergepayload.json",
-			eventHeader:       "pr:merged",
-			payloadSha:        bitbucketServerPrMergedSha256,
-			expectedTime:      bitbucketServerPrMergeExpectedTime,
-			expectedEventType: vcsutils.PrMerged,
+			name:                    "merge",
+			payloadFilename:         "prmergepayload.json",
+			eventHeader:             "pr:merged",
+			payloadSha:              bitbucketServerPrMergedSha256,
+			expectedTime:            bitbucketServerPrMergeExpectedTime,
+			expectedEventType:       vcsutils.PrMerged,
+			expectedMergeCommitHash: "7e48f426f0a6e47c5b5e862c31be6ca965f82c9c",
 		},
 		{
 			name:              "decline",
@@ -135,6 +223,16 @@ func TestBitbucketServerParseIncomingPrWebhook(t *testing.T) {
 			assert.Equal(t, formatOwnerForBitbucketServer(expectedOwner), actual.SourceRepositoryDetails.Owner)
 			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
 			assert.Equal(t, tt.expectedEventType, actual.Event)
+			require.NotNil(t, actual.PullRequest)
+			assert.Equal(t, bitbucketServerPrCreateExpectedTime, actual.PullRequest.CreatedAt.Unix())
+			assert.Equal(t, tt.expectedTime, actual.PullRequest.UpdatedAt.Unix())
+			assert.Equal(t, &WebhookInfoPullRequest{
+				Title:           "Update README.md",
+				Author:          WebhookInfoUser{Username: expectedOwner, DisplayName: "Yahav Itzhak"},
+				CreatedAt:       actual.PullRequest.CreatedAt,
+				UpdatedAt:       actual.PullRequest.UpdatedAt,
+				MergeCommitHash: tt.expectedMergeCommitHash,
+			}, actual.PullRequest)
 		})
 	}
 }
@@ -172,3 +270,60 @@ func TestBitbucketServerPayloadMismatchSignature(t *testing.T) {
 func formatOwnerForBitbucketServer(owner string) string {
 	return fmt.Sprintf("~%s", strings.ToUpper(owner))
 }
+
+func TestBitbucketServerParseIncomingPrCommentWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketserver", "prcommentpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(EventHeaderKey, "pr:comment:added")
+	request.Header.Add(sha256Signature, "sha256="+bitbucketServerPrCommentSha256)
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketServer, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, bitbucketServerExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, formatOwnerForBitbucketServer(expectedOwner), actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+	assert.Equal(t, bitbucketServerPrCommentExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.PrCommentCreated, actual.Event)
+	require.NotNil(t, actual.Comment)
+	assert.Equal(t, int64(52), actual.Comment.ID)
+	assert.Equal(t, "/frogbot scan", actual.Comment.Body)
+	assert.Equal(t, expectedOwner, actual.Comment.Author.Username)
+	assert.Equal(t, "Yahav Itzhak", actual.Comment.Author.DisplayName)
+}
+
+func TestBitbucketServerParseIncomingPrApprovalWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketserver", "prapprovedpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(EventHeaderKey, "pr:reviewer:approved")
+	request.Header.Add(sha256Signature, "sha256="+bitbucketServerPrApprovedSha256)
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.BitbucketServer, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, bitbucketServerExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, formatOwnerForBitbucketServer(expectedOwner), actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+	assert.Equal(t, bitbucketServerPrApprovedExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.PrReviewed, actual.Event)
+	require.NotNil(t, actual.Review)
+	assert.Equal(t, ReviewApproved, actual.Review.State)
+	assert.Equal(t, expectedOwner, actual.Review.Reviewer.Username)
+	assert.Equal(t, "Yahav Itzhak", actual.Review.Reviewer.DisplayName)
+}
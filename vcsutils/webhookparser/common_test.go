@@ -1,7 +1,18 @@
 package webhookparser
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -9,6 +20,7 @@ const (
 	expectedRepoName     = "hello-world"
 	expectedBranch       = "main"
 	expectedSourceBranch = "dev"
+	expectedTag          = "v1.0.0"
 )
 
 var token = []byte("abc123")
@@ -16,3 +28,220 @@ var token = []byte("abc123")
 func close(closer io.Closer) {
 	_ = closer.Close()
 }
+
+func TestGetChangedFiles(t *testing.T) {
+	assert.Empty(t, getChangedFiles())
+	assert.Equal(t, []string{"README.md", "a/b.go", "c.txt"},
+		getChangedFiles([]string{"README.md"}, []string{"a/b.go", "README.md"}, nil, []string{"c.txt", "a/b.go"}))
+}
+
+func TestGetMissingItems(t *testing.T) {
+	assert.Empty(t, getMissingItems(nil, []string{"bug"}))
+	assert.Empty(t, getMissingItems([]string{"bug"}, []string{"bug", "security"}))
+	assert.Equal(t, []string{"security", "docs"}, getMissingItems([]string{"bug", "security", "docs"}, []string{"bug"}))
+}
+
+func TestGetBranchEvent(t *testing.T) {
+	zeros := "0000000000000000000000000000000000000000"
+	assert.Equal(t, vcsutils.Push, getBranchEvent("a82aa1b065b4fa17db4b7a055109044be377ddf7", "9d497bd67a395a8063774f200338769ccbcee916"))
+	assert.Equal(t, vcsutils.BranchCreated, getBranchEvent(zeros, "9d497bd67a395a8063774f200338769ccbcee916"))
+	assert.Equal(t, vcsutils.BranchCreated, getBranchEvent("", "9d497bd67a395a8063774f200338769ccbcee916"))
+	assert.Equal(t, vcsutils.BranchDeleted, getBranchEvent("a82aa1b065b4fa17db4b7a055109044be377ddf7", zeros))
+}
+
+func TestParseIncomingWebhookWithOptionsMaxPayloadSize(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pushpayload.json"))
+	require.NoError(t, err)
+	payloadSize := int64(len(payload))
+
+	tests := []struct {
+		name           string
+		maxPayloadSize int64
+		contentLength  int64
+		expectedErr    error
+	}{
+		{name: "default", contentLength: payloadSize},
+		{name: "exactSize", maxPayloadSize: payloadSize, contentLength: payloadSize},
+		{name: "contentLengthTooLarge", maxPayloadSize: payloadSize - 1, contentLength: payloadSize, expectedErr: ErrPayloadTooLarge},
+		// Chunked requests have no content length, so the payload size is checked while reading it
+		{name: "bodyTooLarge", maxPayloadSize: payloadSize - 1, contentLength: -1, expectedErr: ErrPayloadTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "https://127.0.0.1", bytes.NewReader(payload))
+			request.ContentLength = tt.contentLength
+			request.Header.Add(gitLabKeyHeader, string(token))
+			request.Header.Add(gitLabEventHeader, "Push Hook")
+
+			actual, err := ParseIncomingWebhookWithOptions(vcsutils.GitLab, token, request, ParseOptions{MaxPayloadSize: tt.maxPayloadSize})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, vcsutils.Push, actual.Event)
+		})
+	}
+}
+
+type testDeduplicationStore struct {
+	deliveryIDs map[string]bool
+	err         error
+}
+
+func (store *testDeduplicationStore) Seen(_ context.Context, deliveryID string) (bool, error) {
+	if store.err != nil {
+		return false, store.err
+	}
+	seen := store.deliveryIDs[deliveryID]
+	store.deliveryIDs[deliveryID] = true
+	return seen, nil
+}
+
+func TestParseIncomingWebhookWithOptionsDeduplicationStore(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pushpayload.json"))
+	require.NoError(t, err)
+	parse := func(store DeduplicationStore, deliveryID string) (*WebhookInfo, error) {
+		request := httptest.NewRequest("POST", "https://127.0.0.1", bytes.NewReader(payload))
+		request.Header.Add(gitLabKeyHeader, string(token))
+		request.Header.Add(gitLabEventHeader, "Push Hook")
+		if deliveryID != "" {
+			request.Header.Add("X-Gitlab-Event-UUID", deliveryID)
+		}
+		return ParseIncomingWebhookWithOptions(vcsutils.GitLab, token, request, ParseOptions{DeduplicationStore: store})
+	}
+
+	store := &testDeduplicationStore{deliveryIDs: map[string]bool{}}
+	actual, err := parse(store, "delivery1")
+	require.NoError(t, err)
+	assert.Equal(t, "delivery1", actual.DeliveryID)
+
+	// Redelivery
+	_, err = parse(store, "delivery1")
+	assert.ErrorIs(t, err, ErrDuplicateDelivery)
+
+	// Another delivery
+	_, err = parse(store, "delivery2")
+	assert.NoError(t, err)
+
+	// Deliveries without an ID can't be deduplicated
+	for i := 0; i < 2; i++ {
+		actual, err = parse(store, "")
+		require.NoError(t, err)
+		assert.Empty(t, actual.DeliveryID)
+	}
+	assert.Len(t, store.deliveryIDs, 2)
+
+	// Store error
+	_, err = parse(&testDeduplicationStore{err: errors.New("store error")}, "delivery1")
+	assert.EqualError(t, err, "store error")
+}
+
+func TestParseIncomingWebhookWithOptionsRawPayload(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pushpayload.json"))
+	require.NoError(t, err)
+	parse := func(options ParseOptions) *WebhookInfo {
+		request := httptest.NewRequest("POST", "https://127.0.0.1", bytes.NewReader(payload))
+		request.Header.Add(gitLabKeyHeader, string(token))
+		request.Header.Add(gitLabEventHeader, "Push Hook")
+		actual, err := ParseIncomingWebhookWithOptions(vcsutils.GitLab, token, request, options)
+		require.NoError(t, err)
+		return actual
+	}
+
+	actual := parse(ParseOptions{})
+	assert.Equal(t, "Push Hook", actual.RawEvent)
+	assert.Nil(t, actual.RawPayload)
+
+	actual = parse(ParseOptions{IncludeRawPayload: true})
+	assert.Equal(t, "Push Hook", actual.RawEvent)
+	assert.JSONEq(t, string(payload), string(actual.RawPayload))
+}
+
+func TestParseIncomingWebhookWithOptionsEvents(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pushpayload.json"))
+	require.NoError(t, err)
+	parse := func(token []byte, events ...vcsutils.WebhookEvent) (*WebhookInfo, error) {
+		request := httptest.NewRequest("POST", "https://127.0.0.1", bytes.NewReader(payload))
+		request.Header.Add(gitLabKeyHeader, string(token))
+		request.Header.Add(gitLabEventHeader, "Push Hook")
+		return ParseIncomingWebhookWithOptions(vcsutils.GitLab, token, request, ParseOptions{Events: events})
+	}
+
+	actual, err := parse(token, vcsutils.PrOpened, vcsutils.Push)
+	require.NoError(t, err)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+
+	// Filtered before the payload is validated
+	_, err = parse([]byte("wrong"), vcsutils.PrOpened)
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+
+	// Push Hook may be parsed into a branch creation, so the event is filtered after parsing
+	_, err = parse(token, vcsutils.BranchCreated)
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestParseIncomingWebhookWithOptionsBranchPushEvents(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "github", "pushbranchcreatepayload"))
+	require.NoError(t, err)
+	parse := func(events ...vcsutils.WebhookEvent) (*WebhookInfo, error) {
+		request := httptest.NewRequest("POST", "https://127.0.0.1", bytes.NewReader(payload))
+		request.Header.Add("content-type", "application/x-www-form-urlencoded")
+		request.Header.Add(githubSha256Header, "sha256="+githubPushBranchCreateSha256)
+		request.Header.Add(githubEventHeader, "push")
+		return ParseIncomingWebhookWithOptions(vcsutils.GitHub, token, request, ParseOptions{Events: events})
+	}
+
+	// The push creates a branch, so it's allowed by BranchCreated
+	actual, err := parse(vcsutils.BranchCreated)
+	require.NoError(t, err)
+	assert.Equal(t, vcsutils.BranchCreated, actual.Event)
+
+	// The push may be parsed into Push, so it's filtered only after parsing
+	_, err = parse(vcsutils.Push)
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestIsRawEventAllowed(t *testing.T) {
+	assert.True(t, isRawEventAllowed(vcsutils.GitHub, "push", []vcsutils.WebhookEvent{vcsutils.TagPushed}))
+	assert.True(t, isRawEventAllowed(vcsutils.GitHub, "push", []vcsutils.WebhookEvent{vcsutils.BranchDeleted}))
+	assert.False(t, isRawEventAllowed(vcsutils.GitHub, "push", []vcsutils.WebhookEvent{vcsutils.PrOpened}))
+	assert.False(t, isRawEventAllowed(vcsutils.BitbucketCloud, "pullrequest:fulfilled", []vcsutils.WebhookEvent{vcsutils.PrOpened}))
+	// Unknown raw events and Azure Repos events are left to the parser
+	assert.True(t, isRawEventAllowed(vcsutils.GitHub, "star", []vcsutils.WebhookEvent{vcsutils.PrOpened}))
+	assert.True(t, isRawEventAllowed(vcsutils.AzureRepos, "", []vcsutils.WebhookEvent{vcsutils.PrOpened}))
+}
+
+func TestParsePayload(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "github", "pushpayload"))
+	require.NoError(t, err)
+	headers := map[string]string{
+		"content-type":        "application/x-www-form-urlencoded",
+		"x-hub-signature-256": "sha256=" + githubPushSha256,
+		"x-github-event":      "push",
+		"x-github-delivery":   "delivery1",
+	}
+
+	actual, err := ParsePayload(vcsutils.GitHub, headers, payload, token)
+	require.NoError(t, err)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, "delivery1", actual.DeliveryID)
+
+	// Token mismatch
+	_, err = ParsePayload(vcsutils.GitHub, headers, payload, []byte("wrong"))
+	assert.Error(t, err)
+}
+
+func TestPayloadSizeLimiter(t *testing.T) {
+	limiter := &payloadSizeLimiter{ReadCloser: io.NopCloser(bytes.NewReader([]byte("12345"))), remaining: 5}
+	content, err := io.ReadAll(limiter)
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", string(content))
+
+	limiter = &payloadSizeLimiter{ReadCloser: io.NopCloser(bytes.NewReader([]byte("123456"))), remaining: 5}
+	content, err = io.ReadAll(limiter)
+	assert.ErrorIs(t, err, ErrPayloadTooLarge)
+	assert.Equal(t, "12345", string(content))
+}
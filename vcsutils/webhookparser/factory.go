@@ -1,11 +1,137 @@
 package webhookparser
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/google/go-github/v45/github"
 	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/xanzy/go-gitlab"
 )
 
+// Event key prefixes of Bitbucket Server, which has no header of its own to tell it apart from Bitbucket Cloud
+var bitbucketServerEventKeyPrefixes = []string{"pr:", "repo:refs_changed", "repo:modified", "repo:forked", "repo:comment:", "mirror:"}
+
+// The headers of the delivery ID sent by each VCS provider, from the most to the least preferred
+var deliveryIDHeaders = map[vcsutils.VcsProvider][]string{
+	vcsutils.GitHub:          {"X-GitHub-Delivery"},
+	vcsutils.Gitea:           {"X-Gitea-Delivery"},
+	vcsutils.GitLab:          {"Idempotency-Key", "X-Gitlab-Event-UUID"},
+	vcsutils.BitbucketCloud:  {"X-Request-UUID"},
+	vcsutils.BitbucketServer: {"X-Request-Id"},
+}
+
+// The headers of the event name sent by each VCS provider
+var eventHeaders = map[vcsutils.VcsProvider]string{
+	vcsutils.GitHub:          github.EventTypeHeader,
+	vcsutils.Gitea:           giteaEventHeader,
+	vcsutils.GitLab:          "X-Gitlab-Event",
+	vcsutils.BitbucketCloud:  EventHeaderKey,
+	vcsutils.BitbucketServer: EventHeaderKey,
+}
+
+var (
+	pushEvents        = []vcsutils.WebhookEvent{vcsutils.Push, vcsutils.TagPushed, vcsutils.TagRemoved, vcsutils.BranchCreated, vcsutils.BranchDeleted}
+	pullRequestEvents = []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrClosed}
+)
+
+// The webhook events each raw event of a VCS provider may be parsed into, used to filter events before parsing their payload.
+// Azure Repos and AWS CodeCommit send the event name in the payload, so their events are filtered after parsing.
+var rawEventWebhookEvents = map[vcsutils.VcsProvider]map[string][]vcsutils.WebhookEvent{
+	vcsutils.GitHub: {
+		"push":                        {vcsutils.Push, vcsutils.BranchCreated, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved},
+		"pull_request":                pullRequestEvents,
+		"issue_comment":               {vcsutils.PrCommentCreated},
+		"pull_request_review_comment": {vcsutils.PrCommentCreated},
+		"pull_request_review":         {vcsutils.PrReviewed},
+		"release":                     {vcsutils.ReleasePublished, vcsutils.ReleaseEdited},
+		"deployment":                  {vcsutils.DeploymentCreated},
+		"deployment_status":           {vcsutils.DeploymentStatusUpdated},
+		"workflow_run":                {vcsutils.CIRunCompleted},
+		"check_suite":                 {vcsutils.CIRunCompleted},
+		"create":                      {vcsutils.BranchCreated},
+		"delete":                      {vcsutils.BranchDeleted},
+	},
+	vcsutils.GitLab: {
+		"Push Hook":          {vcsutils.Push, vcsutils.BranchCreated, vcsutils.BranchDeleted},
+		"Tag Push Hook":      {vcsutils.TagPushed, vcsutils.TagRemoved},
+		"Merge Request Hook": append([]vcsutils.WebhookEvent{vcsutils.PrReviewed}, pullRequestEvents...),
+		"Note Hook":          {vcsutils.PrCommentCreated},
+	},
+	vcsutils.Gitea: {
+		"push":         pushEvents,
+		"pull_request": pullRequestEvents,
+	},
+	vcsutils.BitbucketCloud: {
+		"repo:push":                           pushEvents,
+		"pullrequest:created":                 {vcsutils.PrOpened},
+		"pullrequest:updated":                 {vcsutils.PrEdited},
+		"pullrequest:fulfilled":               {vcsutils.PrMerged},
+		"pullrequest:rejected":                {vcsutils.PrRejected},
+		"pullrequest:comment_created":         {vcsutils.PrCommentCreated},
+		"pullrequest:approved":                {vcsutils.PrReviewed},
+		"pullrequest:unapproved":              {vcsutils.PrReviewed},
+		"pullrequest:changes_request_created": {vcsutils.PrReviewed},
+		"repo:fork":                           {vcsutils.RepoForked},
+		"repo:updated":                        {vcsutils.RepoUpdated},
+		"repo:commit_status_created":          {vcsutils.CommitStatusUpdated},
+		"repo:commit_status_updated":          {vcsutils.CommitStatusUpdated},
+	},
+	vcsutils.BitbucketServer: {
+		"repo:refs_changed":      pushEvents,
+		"pr:opened":              {vcsutils.PrOpened},
+		"pr:from_ref_updated":    {vcsutils.PrEdited},
+		"pr:merged":              {vcsutils.PrMerged},
+		"pr:declined":            {vcsutils.PrRejected},
+		"pr:deleted":             {vcsutils.PrRejected},
+		"pr:comment:added":       {vcsutils.PrCommentCreated},
+		"pr:reviewer:approved":   {vcsutils.PrReviewed},
+		"pr:reviewer:unapproved": {vcsutils.PrReviewed},
+		"pr:reviewer:needs_work": {vcsutils.PrReviewed},
+	},
+}
+
+// Return false if none of the webhook events the raw event may be parsed into is allowed.
+// Raw events of Azure Repos and AWS CodeCommit, and unknown raw events, are left to the parser.
+func isRawEventAllowed(provider vcsutils.VcsProvider, rawEvent string, allowedEvents []vcsutils.WebhookEvent) bool {
+	webhookEvents, exists := rawEventWebhookEvents[provider][rawEvent]
+	if !exists {
+		return true
+	}
+	for _, webhookEvent := range webhookEvents {
+		if isEventAllowed(webhookEvent, allowedEvents) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEventAllowed(webhookEvent vcsutils.WebhookEvent, allowedEvents []vcsutils.WebhookEvent) bool {
+	for _, allowedEvent := range allowedEvents {
+		if webhookEvent == allowedEvent {
+			return true
+		}
+	}
+	return false
+}
+
+func getRawEvent(provider vcsutils.VcsProvider, header http.Header) string {
+	if eventHeader, exists := eventHeaders[provider]; exists {
+		return header.Get(eventHeader)
+	}
+	return ""
+}
+
+func getDeliveryID(provider vcsutils.VcsProvider, header http.Header) string {
+	for _, deliveryIDHeader := range deliveryIDHeaders[provider] {
+		if deliveryID := header.Get(deliveryIDHeader); deliveryID != "" {
+			return deliveryID
+		}
+	}
+	return ""
+}
+
 func createWebhookParser(provider vcsutils.VcsProvider, request *http.Request) WebhookParser {
 	switch provider {
 	case vcsutils.GitHub:
@@ -16,6 +142,40 @@ func createWebhookParser(provider vcsutils.VcsProvider, request *http.Request) W
 		return NewBitbucketServerWebhookWebhook(request)
 	case vcsutils.GitLab:
 		return NewGitLabWebhook(request)
+	case vcsutils.AzureRepos:
+		return NewAzureReposWebhook(request)
+	case vcsutils.Gitea:
+		return NewGiteaWebhook(request)
+	case vcsutils.AWSCodeCommit:
+		return NewAWSCodeCommitWebhook(request)
 	}
 	return nil
 }
+
+// DetectProvider detects the VCS provider of an incoming webhook request from its headers.
+// Azure Repos service hooks and AWS CodeCommit events sent directly by EventBridge have no identifying header, and therefore can't be detected.
+func DetectProvider(request *http.Request) (vcsutils.VcsProvider, error) {
+	header := request.Header
+	switch {
+	// Gitea sends the GitHub event header as well, so it must be checked first
+	case header.Get(giteaEventHeader) != "":
+		return vcsutils.Gitea, nil
+	case header.Get(github.EventTypeHeader) != "":
+		return vcsutils.GitHub, nil
+	case gitlab.WebhookEventType(request) != "":
+		return vcsutils.GitLab, nil
+	case header.Get(snsMessageTypeHeader) != "":
+		return vcsutils.AWSCodeCommit, nil
+	case header.Get(bitbucketCloudHookUUIDHeader) != "":
+		return vcsutils.BitbucketCloud, nil
+	case header.Get(EventHeaderKey) != "":
+		eventKey := header.Get(EventHeaderKey)
+		for _, prefix := range bitbucketServerEventKeyPrefixes {
+			if strings.HasPrefix(eventKey, prefix) {
+				return vcsutils.BitbucketServer, nil
+			}
+		}
+		return vcsutils.BitbucketCloud, nil
+	}
+	return -1, errors.New("couldn't detect the VCS provider from the webhook request headers")
+}
@@ -1,10 +1,15 @@
 package webhookparser
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateWebhookParser(t *testing.T) {
@@ -12,5 +17,111 @@ func TestCreateWebhookParser(t *testing.T) {
 	assert.IsType(t, &GitLabWebhook{}, createWebhookParser(vcsutils.GitLab, nil))
 	assert.IsType(t, &BitbucketServerWebhook{}, createWebhookParser(vcsutils.BitbucketServer, nil))
 	assert.IsType(t, &BitbucketCloudWebhook{}, createWebhookParser(vcsutils.BitbucketCloud, nil))
-	assert.Nil(t, createWebhookParser(5, nil))
+	assert.IsType(t, &AzureReposWebhook{}, createWebhookParser(vcsutils.AzureRepos, nil))
+	assert.IsType(t, &GiteaWebhook{}, createWebhookParser(vcsutils.Gitea, nil))
+	assert.IsType(t, &AWSCodeCommitWebhook{}, createWebhookParser(vcsutils.AWSCodeCommit, nil))
+	assert.Nil(t, createWebhookParser(7, nil))
+}
+
+func TestGetDeliveryID(t *testing.T) {
+	tests := []struct {
+		name               string
+		provider           vcsutils.VcsProvider
+		headers            map[string]string
+		expectedDeliveryID string
+	}{
+		{name: "github", provider: vcsutils.GitHub, headers: map[string]string{"X-GitHub-Delivery": "id1"}, expectedDeliveryID: "id1"},
+		{name: "gitea", provider: vcsutils.Gitea, headers: map[string]string{"X-Gitea-Delivery": "id1"}, expectedDeliveryID: "id1"},
+		{name: "gitlabEventUUID", provider: vcsutils.GitLab, headers: map[string]string{"X-Gitlab-Event-UUID": "id1"}, expectedDeliveryID: "id1"},
+		{name: "gitlabIdempotencyKey", provider: vcsutils.GitLab, headers: map[string]string{"X-Gitlab-Event-UUID": "id1", "Idempotency-Key": "id2"}, expectedDeliveryID: "id2"},
+		{name: "bitbucketCloud", provider: vcsutils.BitbucketCloud, headers: map[string]string{"X-Request-UUID": "id1"}, expectedDeliveryID: "id1"},
+		{name: "bitbucketServer", provider: vcsutils.BitbucketServer, headers: map[string]string{"X-Request-Id": "id1"}, expectedDeliveryID: "id1"},
+		{name: "azureRepos", provider: vcsutils.AzureRepos, headers: map[string]string{"X-Request-Id": "id1"}},
+		{name: "missing", provider: vcsutils.GitHub, headers: map[string]string{"X-Gitea-Delivery": "id1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tt.headers {
+				header.Set(key, value)
+			}
+			assert.Equal(t, tt.expectedDeliveryID, getDeliveryID(tt.provider, header))
+		})
+	}
+}
+
+func TestDetectProvider(t *testing.T) {
+	tests := []struct {
+		name             string
+		headers          map[string]string
+		expectedProvider vcsutils.VcsProvider
+	}{
+		{name: "github", headers: map[string]string{"X-GitHub-Event": "push"}, expectedProvider: vcsutils.GitHub},
+		{name: "gitlab", headers: map[string]string{"X-Gitlab-Event": "Push Hook"}, expectedProvider: vcsutils.GitLab},
+		{name: "gitea", headers: map[string]string{"X-Gitea-Event": "push", "X-GitHub-Event": "push"}, expectedProvider: vcsutils.Gitea},
+		{name: "bitbucket cloud", headers: map[string]string{EventHeaderKey: "repo:push", "X-Hook-UUID": "1234"}, expectedProvider: vcsutils.BitbucketCloud},
+		{name: "bitbucket cloud without hook uuid", headers: map[string]string{EventHeaderKey: "pullrequest:created"}, expectedProvider: vcsutils.BitbucketCloud},
+		{name: "bitbucket server push", headers: map[string]string{EventHeaderKey: "repo:refs_changed"}, expectedProvider: vcsutils.BitbucketServer},
+		{name: "bitbucket server pr", headers: map[string]string{EventHeaderKey: "pr:opened"}, expectedProvider: vcsutils.BitbucketServer},
+		{name: "aws codecommit", headers: map[string]string{"x-amz-sns-message-type": "Notification"}, expectedProvider: vcsutils.AWSCodeCommit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &http.Request{Header: http.Header{}}
+			for key, value := range tt.headers {
+				request.Header.Add(key, value)
+			}
+			provider, err := DetectProvider(request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedProvider, provider)
+		})
+	}
+
+	_, err := DetectProvider(&http.Request{Header: http.Header{}})
+	assert.Error(t, err)
+}
+
+func TestParseIncomingWebhookAutoDetect(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "gitlab", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(gitLabKeyHeader, string(token))
+	request.Header.Add(gitLabEventHeader, "Push Hook")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhookAutoDetect(token, request)
+	require.NoError(t, err)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+
+	_, err = ParseIncomingWebhookAutoDetect(token, &http.Request{Header: http.Header{}})
+	assert.Error(t, err)
+}
+
+func TestGetRawEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		provider      vcsutils.VcsProvider
+		headers       map[string]string
+		expectedEvent string
+	}{
+		{name: "github", provider: vcsutils.GitHub, headers: map[string]string{"X-GitHub-Event": "pull_request"}, expectedEvent: "pull_request"},
+		{name: "gitea", provider: vcsutils.Gitea, headers: map[string]string{"X-GitHub-Event": "push", "X-Gitea-Event": "pull_request"}, expectedEvent: "pull_request"},
+		{name: "gitlab", provider: vcsutils.GitLab, headers: map[string]string{"X-Gitlab-Event": "Merge Request Hook"}, expectedEvent: "Merge Request Hook"},
+		{name: "bitbucketCloud", provider: vcsutils.BitbucketCloud, headers: map[string]string{EventHeaderKey: "pullrequest:fulfilled"}, expectedEvent: "pullrequest:fulfilled"},
+		{name: "bitbucketServer", provider: vcsutils.BitbucketServer, headers: map[string]string{EventHeaderKey: "pr:merged"}, expectedEvent: "pr:merged"},
+		{name: "azureRepos", provider: vcsutils.AzureRepos, headers: map[string]string{EventHeaderKey: "git.push"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tt.headers {
+				header.Set(key, value)
+			}
+			assert.Equal(t, tt.expectedEvent, getRawEvent(tt.provider, header))
+		})
+	}
 }
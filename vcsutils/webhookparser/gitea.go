@@ -0,0 +1,249 @@
+package webhookparser
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	giteaSignatureHeader = "X-Gitea-Signature"
+	giteaEventHeader     = "X-Gitea-Event"
+)
+
+// GiteaWebhook represents an incoming webhook on Gitea
+type GiteaWebhook struct {
+	request *http.Request
+}
+
+// NewGiteaWebhook create a new GiteaWebhook instance
+func NewGiteaWebhook(request *http.Request) *GiteaWebhook {
+	return &GiteaWebhook{
+		request: request,
+	}
+}
+
+func (webhook *GiteaWebhook) validatePayload(token []byte) ([]byte, error) {
+	payload := new(bytes.Buffer)
+	if _, err := payload.ReadFrom(webhook.request.Body); err != nil {
+		return nil, err
+	}
+
+	// Unlike GitHub and Bitbucket, Gitea sends the plain hex signature, without the "sha256=" prefix
+	expectedSignature := webhook.request.Header.Get(giteaSignatureHeader)
+	if len(token) > 0 || len(expectedSignature) > 0 {
+		if !hmac.Equal([]byte(expectedSignature), []byte(calculatePayloadSignature(payload.Bytes(), token))) {
+			return nil, errors.New("payload signature mismatch")
+		}
+	}
+	return payload.Bytes(), nil
+}
+
+func (webhook *GiteaWebhook) parseIncomingWebhook(payload []byte) (*WebhookInfo, error) {
+	giteaWebHook := &giteaWebHook{}
+	err := json.Unmarshal(payload, giteaWebHook)
+	if err != nil {
+		return nil, err
+	}
+
+	event := webhook.request.Header.Get(giteaEventHeader)
+	switch event {
+	case "push":
+		return webhook.parsePushEvent(giteaWebHook), nil
+	case "pull_request":
+		return webhook.parsePrEvents(giteaWebHook)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, event)
+}
+
+func (webhook *GiteaWebhook) parsePushEvent(giteaWebHook *giteaWebHook) *WebhookInfo {
+	var timestamp int64
+	var eventTime time.Time
+	if giteaWebHook.HeadCommit != nil {
+		eventTime = giteaWebHook.HeadCommit.Timestamp
+		timestamp = eventTime.UTC().Unix()
+	}
+	if strings.HasPrefix(giteaWebHook.Ref, tagPrefix) {
+		webhookEvent, hash := vcsutils.TagPushed, giteaWebHook.After
+		if strings.Trim(giteaWebHook.After, "0") == "" {
+			webhookEvent, hash = vcsutils.TagRemoved, giteaWebHook.Before
+		}
+		return &WebhookInfo{
+			TargetRepositoryDetails: webhook.getRepositoryDetails(giteaWebHook.Repository),
+			Timestamp:               timestamp,
+			Time:                    eventTime,
+			Event:                   webhookEvent,
+			Tag: &WebhookInfoTag{
+				Name: strings.TrimPrefix(giteaWebHook.Ref, tagPrefix),
+				Hash: hash,
+			},
+		}
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: webhook.getRepositoryDetails(giteaWebHook.Repository),
+		TargetBranch:            strings.TrimPrefix(giteaWebHook.Ref, "refs/heads/"),
+		Timestamp:               timestamp,
+		Time:                    eventTime,
+		Event:                   getBranchEvent(giteaWebHook.Before, giteaWebHook.After),
+		ChangedFiles:            webhook.getChangedFiles(giteaWebHook),
+		BeforeCommit:            getCommitSha(giteaWebHook.Before),
+		Commit:                  getCommitSha(giteaWebHook.After),
+		Commits:                 webhook.parseCommits(giteaWebHook),
+	}
+}
+
+func (webhook *GiteaWebhook) parseCommits(giteaWebHook *giteaWebHook) []WebHookInfoCommit {
+	var commits []WebHookInfoCommit
+	for _, commit := range giteaWebHook.Commits {
+		commits = append(commits, WebHookInfoCommit{
+			Hash:    commit.ID,
+			Message: commit.Message,
+			Author: WebhookInfoUser{
+				Username:    commit.Author.Username,
+				DisplayName: commit.Author.Name,
+				Email:       commit.Author.Email,
+			},
+			Timestamp: commit.Timestamp.UTC().Unix(),
+			Added:     commit.Added,
+			Modified:  commit.Modified,
+			Removed:   commit.Removed,
+		})
+	}
+	return commits
+}
+
+func (webhook *GiteaWebhook) getChangedFiles(giteaWebHook *giteaWebHook) []string {
+	var fileLists [][]string
+	for _, commit := range giteaWebHook.Commits {
+		fileLists = append(fileLists, commit.Added, commit.Modified, commit.Removed)
+	}
+	return getChangedFiles(fileLists...)
+}
+
+func (webhook *GiteaWebhook) parsePrEvents(giteaWebHook *giteaWebHook) (*WebhookInfo, error) {
+	var webhookEvent vcsutils.WebhookEvent
+	switch giteaWebHook.Action {
+	case "opened", "reopened":
+		webhookEvent = vcsutils.PrOpened
+	case "synchronized", "edited":
+		webhookEvent = vcsutils.PrEdited
+	case "closed":
+		if giteaWebHook.PullRequest.Merged {
+			webhookEvent = vcsutils.PrMerged
+		} else {
+			webhookEvent = vcsutils.PrClosed
+		}
+	default:
+		// Action is not supported
+		return nil, fmt.Errorf("%w: pull_request action %q", ErrUnsupportedEvent, giteaWebHook.Action)
+	}
+	pullRequest := giteaWebHook.PullRequest
+	return &WebhookInfo{
+		PullRequestId:           pullRequest.Number,
+		TargetRepositoryDetails: webhook.getRepositoryDetails(pullRequest.Base.Repository),
+		TargetBranch:            pullRequest.Base.Ref,
+		SourceRepositoryDetails: webhook.getRepositoryDetails(pullRequest.Head.Repository),
+		SourceBranch:            pullRequest.Head.Ref,
+		Timestamp:               pullRequest.UpdatedAt.UTC().Unix(),
+		Time:                    pullRequest.UpdatedAt,
+		Event:                   webhookEvent,
+		PullRequest: &WebhookInfoPullRequest{
+			Title: pullRequest.Title,
+			Body:  pullRequest.Body,
+			Author: WebhookInfoUser{
+				Username:    pullRequest.User.Login,
+				DisplayName: pullRequest.User.FullName,
+				AvatarURL:   pullRequest.User.AvatarURL,
+			},
+			Labels:          webhook.getLabelNames(pullRequest.Labels),
+			CreatedAt:       pullRequest.CreatedAt,
+			UpdatedAt:       pullRequest.UpdatedAt,
+			MergeCommitHash: pullRequest.MergeCommitSHA,
+		},
+	}, nil
+}
+
+func (webhook *GiteaWebhook) getLabelNames(labels []giteaLabel) []string {
+	var labelNames []string
+	for _, label := range labels {
+		labelNames = append(labelNames, label.Name)
+	}
+	return labelNames
+}
+
+func (webhook *GiteaWebhook) getRepositoryDetails(repository giteaRepository) WebHookInfoRepoDetails {
+	return WebHookInfoRepoDetails{
+		Name:  repository.Name,
+		Owner: repository.Owner.Login,
+	}
+}
+
+type giteaWebHook struct {
+	// Push events
+	Ref        string `json:"ref,omitempty"`
+	Before     string `json:"before,omitempty"`
+	After      string `json:"after,omitempty"`
+	HeadCommit *struct {
+		Timestamp time.Time `json:"timestamp,omitempty"`
+	} `json:"head_commit,omitempty"`
+	Commits []struct {
+		ID        string    `json:"id,omitempty"`
+		Message   string    `json:"message,omitempty"`
+		Timestamp time.Time `json:"timestamp,omitempty"`
+		Author    struct {
+			Name     string `json:"name,omitempty"`
+			Email    string `json:"email,omitempty"`
+			Username string `json:"username,omitempty"`
+		} `json:"author,omitempty"`
+		Added    []string `json:"added,omitempty"`
+		Modified []string `json:"modified,omitempty"`
+		Removed  []string `json:"removed,omitempty"`
+	} `json:"commits,omitempty"`
+	// Pull request events
+	Action      string `json:"action,omitempty"`
+	PullRequest struct {
+		Number int          `json:"number,omitempty"`
+		Title  string       `json:"title,omitempty"`
+		Body   string       `json:"body,omitempty"`
+		User   giteaUser    `json:"user,omitempty"`
+		Labels []giteaLabel `json:"labels,omitempty"`
+		Merged bool         `json:"merged,omitempty"`
+		// Set when the pull request is merged
+		MergeCommitSHA string          `json:"merge_commit_sha,omitempty"`
+		CreatedAt      time.Time       `json:"created_at,omitempty"`
+		UpdatedAt      time.Time       `json:"updated_at,omitempty"` // Timestamp
+		Base           giteaBranchInfo `json:"base,omitempty"`
+		Head           giteaBranchInfo `json:"head,omitempty"`
+	} `json:"pull_request,omitempty"`
+	// Push and pull request events
+	Repository giteaRepository `json:"repository,omitempty"`
+}
+
+type giteaBranchInfo struct {
+	Ref        string          `json:"ref,omitempty"` // Branch name
+	Repository giteaRepository `json:"repo,omitempty"`
+}
+
+type giteaRepository struct {
+	Name  string `json:"name,omitempty"`
+	Owner struct {
+		Login string `json:"login,omitempty"`
+	} `json:"owner,omitempty"`
+}
+
+type giteaUser struct {
+	Login     string `json:"login,omitempty"`
+	FullName  string `json:"full_name,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+type giteaLabel struct {
+	Name string `json:"name,omitempty"`
+}
@@ -0,0 +1,183 @@
+package webhookparser
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	giteaPushSha256           = "d2bf2fd28fe6f3642e239d0c4eb7389811905825edaa341d7f153a81823efe04"
+	giteaPushExpectedTime     = int64(1679299910)
+	giteaPrOpenSha256         = "7412c3483b803004f5f4d0c758b0297b0098837a285bddabf488fe7abc6fb353"
+	giteaPrOpenExpectedTime   = int64(1679300411)
+	giteaPrUpdateSha256       = "3d1f5ac863fd3c4f5df276e74455febef829321e783b6adbaafc184db8732693"
+	giteaPrUpdateExpectedTime = int64(1679301102)
+	giteaPrMergeSha256        = "18ae24f0a47bb06ffac79333383b3782795360c1fcb484b3e8b0cd87e9b7df1c"
+	giteaPrMergeExpectedTime  = int64(1679301903)
+	giteaPrCloseSha256        = "b7cdcb5879c46726a243b22602faceafe5c70862deecfa36d365bfaa62fe9ccf"
+	giteaPrCloseExpectedTime  = int64(1679302227)
+	giteaExpectedPrID         = 1
+)
+
+func TestGiteaParseIncomingPushWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "gitea", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(giteaSignatureHeader, giteaPushSha256)
+	request.Header.Add(giteaEventHeader, "push")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.Gitea, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, giteaPushExpectedTime, actual.Timestamp)
+	assert.Equal(t, "2023-03-20T10:11:50+02:00", actual.Time.Format(time.RFC3339))
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Equal(t, []string{"README.md"}, actual.ChangedFiles)
+	assert.Equal(t, "a1e8f5bb3d4c2d7e9b0f6c5a4d3e2f1a0b9c8d7e", actual.BeforeCommit)
+	assert.Equal(t, "f5b2bb8e6d2a8ba0e4e03bc9a8b6fb8c1f0d5e3a", actual.Commit)
+	assert.Equal(t, []WebHookInfoCommit{{
+		Hash:      "f5b2bb8e6d2a8ba0e4e03bc9a8b6fb8c1f0d5e3a",
+		Message:   "Update README.md\n",
+		Author:    WebhookInfoUser{Username: expectedOwner, DisplayName: "Yahav Itzhak", Email: "yahavi@example.com"},
+		Timestamp: giteaPushExpectedTime,
+		Added:     []string{},
+		Modified:  []string{"README.md"},
+		Removed:   []string{},
+	}}, actual.Commits)
+}
+
+func TestGiteaParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		name                    string
+		payloadFilename         string
+		sha256                  string
+		expectedTime            int64
+		expectedEventType       vcsutils.WebhookEvent
+		expectedMergeCommitHash string
+		expectedLabels          []string
+	}{
+		{
+			name:              "open",
+			payloadFilename:   "propenpayload.json",
+			sha256:            giteaPrOpenSha256,
+			expectedTime:      giteaPrOpenExpectedTime,
+			expectedEventType: vcsutils.PrOpened,
+		},
+		{
+			name:              "update",
+			payloadFilename:   "prupdatepayload.json",
+			sha256:            giteaPrUpdateSha256,
+			expectedTime:      giteaPrUpdateExpectedTime,
+			expectedEventType: vcsutils.PrEdited,
+			expectedLabels:    []string{"security"},
+		},
+		{
+			name:                    "merge",
+			payloadFilename:         "prmergepayload.json",
+			sha256:                  giteaPrMergeSha256,
+			expectedTime:            giteaPrMergeExpectedTime,
+			expectedEventType:       vcsutils.PrMerged,
+			expectedMergeCommitHash: "b7c3a1d2e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9",
+		},
+		{
+			name:              "close",
+			payloadFilename:   "prclosepayload.json",
+			sha256:            giteaPrCloseSha256,
+			expectedTime:      giteaPrCloseExpectedTime,
+			expectedEventType: vcsutils.PrClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "gitea", tt.payloadFilename))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.Header.Add(giteaSignatureHeader, tt.sha256)
+			request.Header.Add(giteaEventHeader, "pull_request")
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.Gitea, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, giteaExpectedPrID, actual.PullRequestId)
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.Equal(t, expectedRepoName, actual.SourceRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.SourceRepositoryDetails.Owner)
+			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, &WebhookInfoPullRequest{
+				Title:           "Update README.md",
+				Author:          WebhookInfoUser{Username: expectedOwner, DisplayName: "Yahav Itzhak", AvatarURL: "https://gitea.example.com/avatars/1"},
+				Labels:          tt.expectedLabels,
+				CreatedAt:       time.Date(2023, time.March, 20, 8, 20, 11, 0, time.UTC),
+				UpdatedAt:       time.Unix(tt.expectedTime, 0).UTC(),
+				MergeCommitHash: tt.expectedMergeCommitHash,
+			}, actual.PullRequest)
+		})
+	}
+}
+
+func TestGiteaParseIncomingWebhookError(t *testing.T) {
+	_, err := ParseIncomingWebhook(vcsutils.Gitea, token, &http.Request{Body: io.NopCloser(io.MultiReader())})
+	require.Error(t, err)
+
+	webhook := GiteaWebhook{}
+	_, err = webhook.parseIncomingWebhook([]byte{})
+	assert.Error(t, err)
+}
+
+func TestGiteaPayloadMismatchSignature(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "gitea", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(giteaSignatureHeader, "a")
+	request.Header.Add(giteaEventHeader, "push")
+
+	// Parse webhook
+	_, err = ParseIncomingWebhook(vcsutils.Gitea, token, request)
+	assert.EqualError(t, err, "payload signature mismatch")
+}
+
+func TestGiteaParseIncomingUnsupportedWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "gitea", "pushpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(giteaSignatureHeader, giteaPushSha256)
+	request.Header.Add(giteaEventHeader, "create")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.Gitea, token, request)
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
@@ -1,7 +1,10 @@
 package webhookparser
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"mime"
 	"net/http"
 	"strings"
 
@@ -27,13 +30,36 @@ func (webhook *GitHubWebhook) validatePayload(token []byte) ([]byte, error) {
 		return nil, errors.New(github.SHA256SignatureHeader + " header is missing")
 	}
 
-	payload, err := github.ValidatePayload(webhook.request, token)
+	body := new(bytes.Buffer)
+	if _, err := body.ReadFrom(webhook.request.Body); err != nil {
+		return nil, err
+	}
+	contentType, err := webhook.getContentType(body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	// For form-encoded payloads, the JSON payload is extracted from the "payload" form field
+	signature := webhook.request.Header.Get(github.SHA256SignatureHeader)
+	payload, err := github.ValidatePayloadFromBody(contentType, bytes.NewReader(body.Bytes()), signature, token)
 	if err != nil {
 		return nil, err
 	}
 	return payload, nil
 }
 
+// GitHub hooks send either JSON or form-encoded payloads, according to their configured content type.
+// If the Content-Type header is missing, the content type is detected from the body.
+func (webhook *GitHubWebhook) getContentType(body []byte) (string, error) {
+	if contentTypeHeader := webhook.request.Header.Get("Content-Type"); contentTypeHeader != "" {
+		contentType, _, err := mime.ParseMediaType(contentTypeHeader)
+		return contentType, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		return "application/json", nil
+	}
+	return "application/x-www-form-urlencoded", nil
+}
+
 func (webhook *GitHubWebhook) parseIncomingWebhook(payload []byte) (*WebhookInfo, error) {
 	event, err := github.ParseWebHook(github.WebHookType(webhook.request), payload)
 	if err != nil {
@@ -41,39 +67,149 @@ func (webhook *GitHubWebhook) parseIncomingWebhook(payload []byte) (*WebhookInfo
 	}
 	switch event := event.(type) {
 	case *github.PushEvent:
+		if strings.HasPrefix(event.GetRef(), tagPrefix) {
+			return webhook.parseTagEvent(event), nil
+		}
 		return webhook.parsePushEvent(event), nil
 	case *github.PullRequestEvent:
-		return webhook.parsePrEvents(event), nil
+		return webhook.parsePrEvents(event)
+	case *github.IssueCommentEvent:
+		return webhook.parseIssueCommentEvent(event)
+	case *github.PullRequestReviewCommentEvent:
+		return webhook.parsePrReviewCommentEvent(event)
+	case *github.PullRequestReviewEvent:
+		return webhook.parsePrReviewEvent(event)
+	case *github.ReleaseEvent:
+		return webhook.parseReleaseEvent(event)
+	case *github.DeploymentEvent:
+		return webhook.parseDeploymentEvent(event), nil
+	case *github.DeploymentStatusEvent:
+		return webhook.parseDeploymentStatusEvent(event), nil
+	case *github.WorkflowRunEvent:
+		return webhook.parseWorkflowRunEvent(event)
+	case *github.CheckSuiteEvent:
+		return webhook.parseCheckSuiteEvent(event)
+	case *github.CreateEvent:
+		return webhook.parseBranchEvent(event.GetRepo(), event.GetRefType(), event.GetRef(), vcsutils.BranchCreated)
+	case *github.DeleteEvent:
+		return webhook.parseBranchEvent(event.GetRepo(), event.GetRefType(), event.GetRef(), vcsutils.BranchDeleted)
 	}
-	return nil, nil
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, github.WebHookType(webhook.request))
 }
 
+// Like on GitLab and Bitbucket, pushes that create or delete a branch are reported as BranchCreated and BranchDeleted
 func (webhook *GitHubWebhook) parsePushEvent(event *github.PushEvent) *WebhookInfo {
+	webhookEvent := vcsutils.Push
+	switch {
+	case event.GetCreated():
+		webhookEvent = vcsutils.BranchCreated
+	case event.GetDeleted():
+		webhookEvent = vcsutils.BranchDeleted
+	}
+	// Pushes that delete a branch have no head commit
+	pushTime := event.GetRepo().GetPushedAt().Time
+	if event.GetHeadCommit() != nil {
+		pushTime = event.GetHeadCommit().GetTimestamp().Time
+	}
 	return &WebhookInfo{
 		TargetRepositoryDetails: WebHookInfoRepoDetails{
 			Name:  *event.GetRepo().Name,
 			Owner: *event.GetRepo().Owner.Login,
 		},
 		TargetBranch: strings.TrimPrefix(event.GetRef(), "refs/heads/"),
-		Timestamp:    event.GetHeadCommit().GetTimestamp().UTC().Unix(),
-		Event:        vcsutils.Push,
+		Timestamp:    pushTime.UTC().Unix(),
+		Time:         pushTime,
+		Event:        webhookEvent,
+		ChangedFiles: webhook.getChangedFiles(event),
+		ForcePush:    event.GetForced(),
+		BeforeCommit: getCommitSha(event.GetBefore()),
+		Commit:       getCommitSha(event.GetAfter()),
+		Commits:      webhook.parseCommits(event),
 	}
 }
 
-func (webhook *GitHubWebhook) parsePrEvents(event *github.PullRequestEvent) *WebhookInfo {
+func (webhook *GitHubWebhook) parseCommits(event *github.PushEvent) []WebHookInfoCommit {
+	var commits []WebHookInfoCommit
+	for _, commit := range event.Commits {
+		commits = append(commits, WebHookInfoCommit{
+			Hash:    commit.GetID(),
+			Message: commit.GetMessage(),
+			Author: WebhookInfoUser{
+				Username:    commit.GetAuthor().GetLogin(),
+				DisplayName: commit.GetAuthor().GetName(),
+				Email:       commit.GetAuthor().GetEmail(),
+			},
+			Timestamp: commit.GetTimestamp().UTC().Unix(),
+			Added:     commit.Added,
+			Modified:  commit.Modified,
+			Removed:   commit.Removed,
+		})
+	}
+	return commits
+}
+
+func (webhook *GitHubWebhook) getChangedFiles(event *github.PushEvent) []string {
+	var fileLists [][]string
+	for _, commit := range event.Commits {
+		fileLists = append(fileLists, commit.Added, commit.Modified, commit.Removed)
+	}
+	return getChangedFiles(fileLists...)
+}
+
+func (webhook *GitHubWebhook) parseTagEvent(event *github.PushEvent) *WebhookInfo {
+	webhookEvent, hash := vcsutils.TagPushed, event.GetAfter()
+	if event.GetDeleted() {
+		webhookEvent, hash = vcsutils.TagRemoved, event.GetBefore()
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		// Deleted tags have no head commit, so the push time is used instead
+		Timestamp: event.GetRepo().GetPushedAt().UTC().Unix(),
+		Time:      event.GetRepo().GetPushedAt().Time,
+		Event:     webhookEvent,
+		Tag: &WebhookInfoTag{
+			Name: strings.TrimPrefix(event.GetRef(), tagPrefix),
+			Hash: hash,
+		},
+	}
+}
+
+// Branches created and deleted by a push are also sent as push events, which are parsed into BranchCreated and BranchDeleted as well.
+// So a webhook subscribed to both the create and the push events reports each branch creation twice, and likewise for deletions.
+// Tags are handled by parseTagEvent.
+func (webhook *GitHubWebhook) parseBranchEvent(repository *github.Repository, refType, ref string, webhookEvent vcsutils.WebhookEvent) (*WebhookInfo, error) {
+	if refType != "branch" {
+		return nil, fmt.Errorf("%w: %s of %s", ErrUnsupportedEvent, webhookEvent, refType)
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  repository.GetName(),
+			Owner: repository.GetOwner().GetLogin(),
+		},
+		TargetBranch: ref,
+		Timestamp:    repository.GetPushedAt().UTC().Unix(),
+		Time:         repository.GetPushedAt().Time,
+		Event:        webhookEvent,
+	}, nil
+}
+
+func (webhook *GitHubWebhook) parsePrEvents(event *github.PullRequestEvent) (*WebhookInfo, error) {
 	var webhookEvent vcsutils.WebhookEvent
 	switch event.GetAction() {
 	case "opened", "reopened":
 		webhookEvent = vcsutils.PrOpened
-	case "synchronize", "edited":
+	case "synchronize", "edited", "labeled", "unlabeled":
 		webhookEvent = vcsutils.PrEdited
 	case "closed":
 		webhookEvent = webhook.resolveClosedEventType(event)
 	default:
 		// Action is not supported
-		return nil
+		return nil, fmt.Errorf("%w: pull_request action %q", ErrUnsupportedEvent, event.GetAction())
 	}
-	return &WebhookInfo{
+	webhookInfo := &WebhookInfo{
 		PullRequestId: event.GetPullRequest().GetNumber(),
 		TargetRepositoryDetails: WebHookInfoRepoDetails{
 			Name:  *event.GetPullRequest().GetBase().GetRepo().Name,
@@ -86,7 +222,278 @@ func (webhook *GitHubWebhook) parsePrEvents(event *github.PullRequestEvent) *Web
 		},
 		SourceBranch: event.GetPullRequest().GetHead().GetRef(),
 		Timestamp:    event.GetPullRequest().GetUpdatedAt().UTC().Unix(),
+		Time:         event.GetPullRequest().GetUpdatedAt(),
+		Event:        webhookEvent,
+		PullRequest:  webhook.parsePullRequest(event.GetPullRequest()),
+	}
+	// GitHub sets the merge commit SHA of open pull requests to their test merge commit
+	if webhookEvent == vcsutils.PrMerged {
+		webhookInfo.PullRequest.MergeCommitHash = event.GetPullRequest().GetMergeCommitSHA()
+	}
+	switch event.GetAction() {
+	case "labeled":
+		webhookInfo.PullRequest.AddedLabels = []string{event.GetLabel().GetName()}
+	case "unlabeled":
+		webhookInfo.PullRequest.RemovedLabels = []string{event.GetLabel().GetName()}
+	}
+	return webhookInfo, nil
+}
+
+// Pull requests are issues in GitHub, so general pull request comments are sent as issue comments
+func (webhook *GitHubWebhook) parseIssueCommentEvent(event *github.IssueCommentEvent) (*WebhookInfo, error) {
+	if !event.GetIssue().IsPullRequest() {
+		return nil, fmt.Errorf("%w: issue_comment on an issue which is not a pull request", ErrUnsupportedEvent)
+	}
+	if event.GetAction() != "created" {
+		// Edited and deleted comments are not supported
+		return nil, fmt.Errorf("%w: issue_comment action %q", ErrUnsupportedEvent, event.GetAction())
+	}
+	return &WebhookInfo{
+		PullRequestId: event.GetIssue().GetNumber(),
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		Timestamp: event.GetComment().GetCreatedAt().UTC().Unix(),
+		Time:      event.GetComment().GetCreatedAt(),
+		Event:     vcsutils.PrCommentCreated,
+		Comment: &WebhookInfoComment{
+			ID:     event.GetComment().GetID(),
+			Body:   event.GetComment().GetBody(),
+			Author: webhook.parseUser(event.GetComment().GetUser()),
+		},
+	}, nil
+}
+
+// Review comments are comments on a specific line in the diff of the pull request
+func (webhook *GitHubWebhook) parsePrReviewCommentEvent(event *github.PullRequestReviewCommentEvent) (*WebhookInfo, error) {
+	if event.GetAction() != "created" {
+		// Edited and deleted comments are not supported
+		return nil, fmt.Errorf("%w: pull_request_review_comment action %q", ErrUnsupportedEvent, event.GetAction())
+	}
+	return &WebhookInfo{
+		PullRequestId: event.GetPullRequest().GetNumber(),
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetPullRequest().GetBase().GetRepo().GetName(),
+			Owner: event.GetPullRequest().GetBase().GetRepo().GetOwner().GetLogin(),
+		},
+		TargetBranch: event.GetPullRequest().GetBase().GetRef(),
+		SourceRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetPullRequest().GetHead().GetRepo().GetName(),
+			Owner: event.GetPullRequest().GetHead().GetRepo().GetOwner().GetLogin(),
+		},
+		SourceBranch: event.GetPullRequest().GetHead().GetRef(),
+		Timestamp:    event.GetComment().GetCreatedAt().UTC().Unix(),
+		Time:         event.GetComment().GetCreatedAt(),
+		Event:        vcsutils.PrCommentCreated,
+		PullRequest:  webhook.parsePullRequest(event.GetPullRequest()),
+		Comment: &WebhookInfoComment{
+			ID:     event.GetComment().GetID(),
+			Body:   event.GetComment().GetBody(),
+			Author: webhook.parseUser(event.GetComment().GetUser()),
+		},
+	}, nil
+}
+
+func (webhook *GitHubWebhook) parsePrReviewEvent(event *github.PullRequestReviewEvent) (*WebhookInfo, error) {
+	var reviewState ReviewState
+	switch {
+	case event.GetAction() == "dismissed":
+		reviewState = ReviewUnapproved
+	case event.GetAction() != "submitted":
+		// Edited reviews are not supported
+		return nil, fmt.Errorf("%w: pull_request_review action %q", ErrUnsupportedEvent, event.GetAction())
+	case strings.EqualFold(event.GetReview().GetState(), "approved"):
+		reviewState = ReviewApproved
+	case strings.EqualFold(event.GetReview().GetState(), "changes_requested"):
+		reviewState = ReviewChangesRequested
+	case strings.EqualFold(event.GetReview().GetState(), "commented"):
+		reviewState = ReviewCommented
+	default:
+		return nil, fmt.Errorf("%w: pull_request_review state %q", ErrUnsupportedEvent, event.GetReview().GetState())
+	}
+	return &WebhookInfo{
+		PullRequestId: event.GetPullRequest().GetNumber(),
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetPullRequest().GetBase().GetRepo().GetName(),
+			Owner: event.GetPullRequest().GetBase().GetRepo().GetOwner().GetLogin(),
+		},
+		TargetBranch: event.GetPullRequest().GetBase().GetRef(),
+		SourceRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetPullRequest().GetHead().GetRepo().GetName(),
+			Owner: event.GetPullRequest().GetHead().GetRepo().GetOwner().GetLogin(),
+		},
+		SourceBranch: event.GetPullRequest().GetHead().GetRef(),
+		Timestamp:    event.GetReview().GetSubmittedAt().UTC().Unix(),
+		Time:         event.GetReview().GetSubmittedAt(),
+		Event:        vcsutils.PrReviewed,
+		PullRequest:  webhook.parsePullRequest(event.GetPullRequest()),
+		Review: &WebhookInfoReview{
+			State:    reviewState,
+			Body:     event.GetReview().GetBody(),
+			Reviewer: webhook.parseUser(event.GetReview().GetUser()),
+		},
+	}, nil
+}
+
+func (webhook *GitHubWebhook) parseReleaseEvent(event *github.ReleaseEvent) (*WebhookInfo, error) {
+	var webhookEvent vcsutils.WebhookEvent
+	switch event.GetAction() {
+	case "published":
+		webhookEvent = vcsutils.ReleasePublished
+	case "edited":
+		webhookEvent = vcsutils.ReleaseEdited
+	default:
+		return nil, fmt.Errorf("%w: release action %q", ErrUnsupportedEvent, event.GetAction())
+	}
+	release := event.GetRelease()
+	// Draft releases are not published yet, so their creation time is used instead
+	releaseTime := release.GetPublishedAt().Time
+	if releaseTime.IsZero() {
+		releaseTime = release.GetCreatedAt().Time
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		TargetBranch: release.GetTargetCommitish(),
+		Timestamp:    releaseTime.UTC().Unix(),
+		Time:         releaseTime,
 		Event:        webhookEvent,
+		Release: &WebhookInfoRelease{
+			ID:         release.GetID(),
+			TagName:    release.GetTagName(),
+			Name:       release.GetName(),
+			Body:       release.GetBody(),
+			Prerelease: release.GetPrerelease(),
+			Author:     webhook.parseUser(release.GetAuthor()),
+		},
+	}, nil
+}
+
+func (webhook *GitHubWebhook) parseDeploymentEvent(event *github.DeploymentEvent) *WebhookInfo {
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		Timestamp:  event.GetDeployment().GetCreatedAt().UTC().Unix(),
+		Time:       event.GetDeployment().GetCreatedAt().Time,
+		Event:      vcsutils.DeploymentCreated,
+		Deployment: webhook.parseDeployment(event.GetDeployment()),
+	}
+}
+
+func (webhook *GitHubWebhook) parseDeploymentStatusEvent(event *github.DeploymentStatusEvent) *WebhookInfo {
+	deploymentStatus := event.GetDeploymentStatus()
+	deployment := webhook.parseDeployment(event.GetDeployment())
+	deployment.State = deploymentStatus.GetState()
+	deployment.EnvironmentURL = deploymentStatus.GetEnvironmentURL()
+	if deploymentStatus.GetDescription() != "" {
+		deployment.Description = deploymentStatus.GetDescription()
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		Timestamp:  deploymentStatus.GetUpdatedAt().UTC().Unix(),
+		Time:       deploymentStatus.GetUpdatedAt().Time,
+		Event:      vcsutils.DeploymentStatusUpdated,
+		Deployment: deployment,
+	}
+}
+
+func (webhook *GitHubWebhook) parseDeployment(deployment *github.Deployment) *WebhookInfoDeployment {
+	return &WebhookInfoDeployment{
+		ID:          deployment.GetID(),
+		Environment: deployment.GetEnvironment(),
+		Ref:         deployment.GetRef(),
+		Hash:        deployment.GetSHA(),
+		Description: deployment.GetDescription(),
+		Creator:     webhook.parseUser(deployment.GetCreator()),
+	}
+}
+
+func (webhook *GitHubWebhook) parseWorkflowRunEvent(event *github.WorkflowRunEvent) (*WebhookInfo, error) {
+	if event.GetAction() != "completed" {
+		// Requested and in progress runs are not supported
+		return nil, fmt.Errorf("%w: workflow_run action %q", ErrUnsupportedEvent, event.GetAction())
+	}
+	workflowRun := event.GetWorkflowRun()
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		TargetBranch: workflowRun.GetHeadBranch(),
+		Timestamp:    workflowRun.GetUpdatedAt().UTC().Unix(),
+		Time:         workflowRun.GetUpdatedAt().Time,
+		Event:        vcsutils.CIRunCompleted,
+		CIRun: &WebhookInfoCIRun{
+			ID:         workflowRun.GetID(),
+			Name:       workflowRun.GetName(),
+			Conclusion: workflowRun.GetConclusion(),
+			Hash:       workflowRun.GetHeadSHA(),
+			URL:        workflowRun.GetHTMLURL(),
+		},
+	}, nil
+}
+
+func (webhook *GitHubWebhook) parseCheckSuiteEvent(event *github.CheckSuiteEvent) (*WebhookInfo, error) {
+	if event.GetAction() != "completed" {
+		// Requested and rerequested check suites are not supported
+		return nil, fmt.Errorf("%w: check_suite action %q", ErrUnsupportedEvent, event.GetAction())
+	}
+	checkSuite := event.GetCheckSuite()
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		TargetBranch: checkSuite.GetHeadBranch(),
+		Timestamp:    checkSuite.GetUpdatedAt().UTC().Unix(),
+		Time:         checkSuite.GetUpdatedAt().Time,
+		Event:        vcsutils.CIRunCompleted,
+		CIRun: &WebhookInfoCIRun{
+			ID:         checkSuite.GetID(),
+			Name:       checkSuite.GetApp().GetName(),
+			Conclusion: checkSuite.GetConclusion(),
+			Hash:       checkSuite.GetHeadSHA(),
+			URL:        checkSuite.GetURL(),
+		},
+	}, nil
+}
+
+func (webhook *GitHubWebhook) parsePullRequest(pullRequest *github.PullRequest) *WebhookInfoPullRequest {
+	return &WebhookInfoPullRequest{
+		Title:     pullRequest.GetTitle(),
+		Body:      pullRequest.GetBody(),
+		Author:    webhook.parseUser(pullRequest.GetUser()),
+		Draft:     pullRequest.GetDraft(),
+		Labels:    webhook.getLabelNames(pullRequest),
+		CreatedAt: pullRequest.GetCreatedAt(),
+		UpdatedAt: pullRequest.GetUpdatedAt(),
+	}
+}
+
+func (webhook *GitHubWebhook) getLabelNames(pullRequest *github.PullRequest) []string {
+	if pullRequest == nil {
+		return nil
+	}
+	var labelNames []string
+	for _, label := range pullRequest.Labels {
+		labelNames = append(labelNames, label.GetName())
+	}
+	return labelNames
+}
+
+func (webhook *GitHubWebhook) parseUser(user *github.User) WebhookInfoUser {
+	return WebhookInfoUser{
+		Username:    user.GetLogin(),
+		DisplayName: user.GetName(),
+		AvatarURL:   user.GetAvatarURL(),
 	}
 }
 
@@ -94,5 +501,5 @@ func (webhook *GitHubWebhook) resolveClosedEventType(event *github.PullRequestEv
 	if event.GetPullRequest().GetMerged() {
 		return vcsutils.PrMerged
 	}
-	return vcsutils.PrRejected
+	return vcsutils.PrClosed
 }
@@ -1,13 +1,17 @@
 package webhookparser
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-github/v45/github"
 	"github.com/stretchr/testify/require"
 
 	"github.com/jfrog/froggit-go/vcsutils"
@@ -21,6 +25,11 @@ const (
 	// Push event
 	githubPushSha256       = "687737b6d39345e557be42058da1ad57dbd5f54baeb30044751e50d396cc2116"
 	githubPushExpectedTime = int64(1630416256)
+	githubForcePushSha256  = "02bac05722ce426dec37d24ac85dffa18a776ecb9c3a6e8f318d625b5fc9ace8"
+	// Push events that create and delete a branch
+	githubPushBranchCreateSha256 = "1357bdfa24fc83a24d47f5663e2a4c4667004407780ed3acc5af26b336c758de"
+	githubPushBranchDeleteSha256 = "fa7d176bed2be1364f825556c65a76d47090629885cf2e7e789b8aededd0d299"
+	githubPushBranchDeleteTime   = int64(1630416400)
 	// Pull request create event
 	githubPrOpenSha256         = "48b9f23bfeb95dd8a1067b590f753599dbd12732c8d5217431ec70132cee8c1c"
 	githubPrOpenExpectedTime   = int64(1630666350)
@@ -31,6 +40,9 @@ const (
 	githubPrSyncExpectedTime = int64(1630666481)
 	githubPrEditSha256       = "b1ee2dfd35d9eac32374e4a0aa6bbee1752c0d19640295bc29a793971999be29"
 	githubPrEditExpectedTime = int64(1638802767)
+	// Pull request label events
+	githubPrLabeledSha256   = "40c5f4fd3b1f9ff12f8dd849558e695d11ecdc19067b3e97900e339eb437082c"
+	githubPrUnlabeledSha256 = "7ef11069158eb520aebae9eba102e05471980eaf06876e9c61531cc2334be185"
 	// Pull request close event
 	githubPrCloseSha256       = "51cddb70352880cfd2a8ba2b55d3e5ed827b8ca528a6dc31e346a5b4d3485496"
 	githubPrCloseExpectedTime = int64(1638804604)
@@ -38,6 +50,36 @@ const (
 	githubPrMergeSha256       = "f94088bf7c34740ed9f9c3752f30e786527fbe5f5c9726d4526d9c92b5a7c208"
 	githubPrMergeExpectedTime = int64(1638805994)
 	gitHubExpectedPrID        = 2
+	// Tag events
+	githubTagPushSha256   = "93e8833d46ca9a8f15ea6b8fe28ceec138bee9963d0b6c1b33e6fceea7d0439f"
+	githubTagDeleteSha256 = "3f35552f6c58a1a5a7e718aa521adf8a3a240bbd8a93ecf1d988484dbf77e287"
+	githubExpectedTagHash = "9d497bd67a395a8063774f200338769ccbcee916"
+	// Pull request comment events
+	githubIssueCommentSha256          = "062976f9ccb785a1b5537779c9e90155bc340e61a5e266063ba9b161def5dc97"
+	githubIssueCommentExpectedTime    = int64(1630669212)
+	githubPrReviewCommentSha256       = "c5d8e2dc831d6ff5c2f70896f2aaa749c7e3d65ac293264ba5a1c0cbfc7423a3"
+	githubPrReviewCommentExpectedTime = int64(1630669351)
+	// Pull request review event
+	githubPrReviewSha256       = "c6c7683b9cef026fff19bb0102631fb8aa09414ad663d390e065b0395953605f"
+	githubPrReviewExpectedTime = int64(1630669521)
+	// Release events
+	githubReleasePublishedSha256 = "564ebde4d0e72dc7d8376b164d44dda79ba657f7dfce21c8a604d75f1c24d478"
+	githubReleaseEditedSha256    = "be1cbda5f827f55ff4f063332a9af91d35e29f0cb7a887df8e1d02f5d63a555b"
+	githubReleaseExpectedTime    = int64(1645434902)
+	githubExpectedReleaseID      = int64(60238462)
+	// Deployment events
+	githubDeploymentSha256             = "1ab60a07307c5c0c8d316b75c11dd696f4cd6e6d2d53570e4597e018c085845a"
+	githubDeploymentExpectedTime       = int64(1645437731)
+	githubDeploymentStatusSha256       = "d1c562251a9b347e97f8e093447bb33a293a4e325bb6269f8e6bf426f3dde39f"
+	githubDeploymentStatusExpectedTime = int64(1645437937)
+	githubExpectedDeploymentID         = int64(512348561)
+	// CI run events
+	githubWorkflowRunSha256 = "327b3e872a4f57de21fa4979d1ac8843a5e97a5d3e47a3b78ad97de4e5d320ef"
+	githubCheckSuiteSha256  = "cdd1568f1c38a13e40b87a72e0afa7f2c72567ce0414c0224ac2f79e165baa92"
+	// Branch events
+	githubBranchCreateSha256 = "b2eadb01e03f86df2971334595238e0076062cdb47ac97608584c7944c8df0f6"
+	githubBranchDeleteSha256 = "b8b7748f7644b8fd53a3b58aeb0d78fca0fdbda577630eb944ee7c9cc1436640"
+	githubBranchExpectedTime = int64(1645518612)
 )
 
 func TestGitHubParseIncomingPushWebhook(t *testing.T) {
@@ -60,16 +102,161 @@ func TestGitHubParseIncomingPushWebhook(t *testing.T) {
 	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
 	assert.Equal(t, expectedBranch, actual.TargetBranch)
 	assert.Equal(t, githubPushExpectedTime, actual.Timestamp)
+	assert.Equal(t, "2021-08-31T16:24:16+03:00", actual.Time.Format(time.RFC3339))
 	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Equal(t, []string{"README.md"}, actual.ChangedFiles)
+	assert.False(t, actual.ForcePush)
+	assert.Equal(t, "a82aa1b065b4fa17db4b7a055109044be377ddf7", actual.BeforeCommit)
+	assert.Equal(t, "9d497bd67a395a8063774f200338769ccbcee916", actual.Commit)
+	assert.Equal(t, []WebHookInfoCommit{{
+		Hash:      githubExpectedTagHash,
+		Message:   "Update README.md",
+		Author:    WebhookInfoUser{Username: expectedOwner, DisplayName: "Yahav Itzhak", Email: "yahavi@users.noreply.github.com"},
+		Timestamp: githubPushExpectedTime,
+		Added:     []string{},
+		Modified:  []string{"README.md"},
+		Removed:   []string{},
+	}}, actual.Commits)
 }
 
-func TestGithubParseIncomingPrWebhook(t *testing.T) {
+func TestGitHubParseIncomingForcePushWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "github", "forcepushpayload"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add("content-type", "application/x-www-form-urlencoded")
+	request.Header.Add(githubSha256Header, "sha256="+githubForcePushSha256)
+	request.Header.Add(githubEventHeader, "push")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.True(t, actual.ForcePush)
+}
+
+func TestGitHubParseIncomingBranchPushWebhook(t *testing.T) {
+	tests := []struct {
+		name                 string
+		payloadFilename      string
+		sha256               string
+		expectedEvent        vcsutils.WebhookEvent
+		expectedTime         int64
+		expectedBeforeCommit string
+		expectedCommit       string
+	}{
+		{
+			name:            "create",
+			payloadFilename: "pushbranchcreatepayload",
+			sha256:          githubPushBranchCreateSha256,
+			expectedEvent:   vcsutils.BranchCreated,
+			// The time of the head commit
+			expectedTime:   githubPushExpectedTime,
+			expectedCommit: "9d497bd67a395a8063774f200338769ccbcee916",
+		},
+		{
+			name:            "delete",
+			payloadFilename: "pushbranchdeletepayload",
+			sha256:          githubPushBranchDeleteSha256,
+			expectedEvent:   vcsutils.BranchDeleted,
+			// There's no head commit, so the push time of the repository is used
+			expectedTime:         githubPushBranchDeleteTime,
+			expectedBeforeCommit: "9d497bd67a395a8063774f200338769ccbcee916",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "github", tt.payloadFilename))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.Header.Add("content-type", "application/x-www-form-urlencoded")
+			request.Header.Add(githubSha256Header, "sha256="+tt.sha256)
+			request.Header.Add(githubEventHeader, "push")
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, "dev", actual.TargetBranch)
+			assert.Equal(t, tt.expectedEvent, actual.Event)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.Equal(t, tt.expectedTime, actual.Time.Unix())
+			assert.Equal(t, tt.expectedBeforeCommit, actual.BeforeCommit)
+			assert.Equal(t, tt.expectedCommit, actual.Commit)
+			assert.Empty(t, actual.Commits)
+		})
+	}
+}
+
+func TestGitHubParseIncomingTagWebhook(t *testing.T) {
 	tests := []struct {
 		name              string
 		payloadFilename   string
 		payloadSha        string
-		expectedTime      int64
 		expectedEventType vcsutils.WebhookEvent
+	}{
+		{
+			name:              "push",
+			payloadFilename:   "tagpushpayload",
+			payloadSha:        githubTagPushSha256,
+			expectedEventType: vcsutils.TagPushed,
+		},
+		{
+			name:              "delete",
+			payloadFilename:   "tagdeletepayload",
+			payloadSha:        githubTagDeleteSha256,
+			expectedEventType: vcsutils.TagRemoved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "github", tt.payloadFilename))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.Header.Add("content-type", "application/x-www-form-urlencoded")
+			request.Header.Add(githubSha256Header, "sha256="+tt.payloadSha)
+			request.Header.Add(githubEventHeader, "push")
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Empty(t, actual.TargetBranch)
+			assert.Equal(t, githubPushExpectedTime, actual.Timestamp)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, &WebhookInfoTag{Name: expectedTag, Hash: githubExpectedTagHash}, actual.Tag)
+		})
+	}
+}
+
+func TestGithubParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		name                    string
+		payloadFilename         string
+		payloadSha              string
+		expectedTime            int64
+		expectedEventType       vcsutils.WebhookEvent
+		expectedMergeCommitHash string
+		expectedLabels          []string
+		expectedAddedLabels     []string
+		expectedRemovedLabels   []string
 	}{
 		{
 			name:              "open",
@@ -99,19 +286,38 @@ func TestGithubParseIncomingPrWebhook(t *testing.T) {
 			expectedTime:      githubPrEditExpectedTime,
 			expectedEventType: vcsutils.PrEdited,
 		},
+		{
+			name:                "labeled",
+			payloadFilename:     "prlabeledpayload",
+			payloadSha:          githubPrLabeledSha256,
+			expectedTime:        githubPrEditExpectedTime,
+			expectedEventType:   vcsutils.PrEdited,
+			expectedLabels:      []string{"bug", "security"},
+			expectedAddedLabels: []string{"security"},
+		},
+		{
+			name:                  "unlabeled",
+			payloadFilename:       "prunlabeledpayload",
+			payloadSha:            githubPrUnlabeledSha256,
+			expectedTime:          githubPrEditExpectedTime,
+			expectedEventType:     vcsutils.PrEdited,
+			expectedLabels:        []string{"bug"},
+			expectedRemovedLabels: []string{"security"},
+		},
 		{
 			name:              "close",
 			payloadFilename:   "prclosepayload",
 			payloadSha:        githubPrCloseSha256,
 			expectedTime:      githubPrCloseExpectedTime,
-			expectedEventType: vcsutils.PrRejected,
+			expectedEventType: vcsutils.PrClosed,
 		},
 		{
-			name:              "merge",
-			payloadFilename:   "prmergepayload",
-			payloadSha:        githubPrMergeSha256,
-			expectedTime:      githubPrMergeExpectedTime,
-			expectedEventType: vcsutils.PrMerged,
+			name:                    "merge",
+			payloadFilename:         "prmergepayload",
+			payloadSha:              githubPrMergeSha256,
+			expectedTime:            githubPrMergeExpectedTime,
+			expectedEventType:       vcsutils.PrMerged,
+			expectedMergeCommitHash: "8d6dff8a6ed3ed8d83558b9f60ceb5e4a9226c76",
 		},
 	}
 	for _, tt := range tests {
@@ -140,6 +346,18 @@ func TestGithubParseIncomingPrWebhook(t *testing.T) {
 			assert.Equal(t, expectedOwner, actual.SourceRepositoryDetails.Owner)
 			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
 			assert.Equal(t, tt.expectedEventType, actual.Event)
+			require.NotNil(t, actual.PullRequest)
+			assert.Equal(t, tt.expectedMergeCommitHash, actual.PullRequest.MergeCommitHash)
+			assert.Contains(t, actual.PullRequest.Title, "README.md")
+			assert.Equal(t, expectedOwner, actual.PullRequest.Author.Username)
+			assert.Equal(t, "https://avatars.githubusercontent.com/u/11367982?v=4", actual.PullRequest.Author.AvatarURL)
+			assert.False(t, actual.PullRequest.Draft)
+			assert.Equal(t, githubPrOpenExpectedTime, actual.PullRequest.CreatedAt.Unix())
+			assert.Equal(t, tt.expectedTime, actual.PullRequest.UpdatedAt.Unix())
+			assert.Equal(t, tt.expectedTime, actual.Time.Unix())
+			assert.Equal(t, tt.expectedLabels, actual.PullRequest.Labels)
+			assert.Equal(t, tt.expectedAddedLabels, actual.PullRequest.AddedLabels)
+			assert.Equal(t, tt.expectedRemovedLabels, actual.PullRequest.RemovedLabels)
 		})
 	}
 }
@@ -155,7 +373,76 @@ func TestGitHubParseIncomingWebhookError(t *testing.T) {
 
 func TestGitHubParsePrEventsError(t *testing.T) {
 	webhook := GitHubWebhook{}
-	assert.Nil(t, webhook.parsePrEvents(nil))
+	webhookInfo, err := webhook.parsePrEvents(nil)
+	assert.Nil(t, webhookInfo)
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestGitHubParseIncomingUnsupportedWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "github", "pushpayload"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add("content-type", "application/x-www-form-urlencoded")
+	request.Header.Add(githubSha256Header, "sha256="+githubPushSha256)
+	request.Header.Add(githubEventHeader, "ping")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+	assert.Nil(t, actual)
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestGitHubParseIncomingWebhookContentTypes(t *testing.T) {
+	formPayload, err := os.ReadFile(filepath.Join("testdata", "github", "pushpayload"))
+	require.NoError(t, err)
+	form, err := url.ParseQuery(string(formPayload))
+	require.NoError(t, err)
+	jsonPayload := []byte(form.Get("payload"))
+
+	tests := []struct {
+		name        string
+		contentType string
+		payload     []byte
+	}{
+		{name: "form", contentType: "application/x-www-form-urlencoded", payload: formPayload},
+		{name: "json", contentType: "application/json", payload: jsonPayload},
+		{name: "jsonWithCharset", contentType: "application/json; charset=utf-8", payload: jsonPayload},
+		{name: "formWithoutContentType", payload: formPayload},
+		{name: "jsonWithoutContentType", payload: jsonPayload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", bytes.NewReader(tt.payload))
+			if tt.contentType != "" {
+				request.Header.Add("content-type", tt.contentType)
+			}
+			request.Header.Add(githubSha256Header, "sha256="+calculatePayloadSignature(tt.payload, token))
+			request.Header.Add(githubEventHeader, "push")
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, githubPushExpectedTime, actual.Timestamp)
+			assert.Equal(t, vcsutils.Push, actual.Event)
+		})
+	}
+}
+
+func TestGitHubParseIncomingWebhookUnsupportedContentType(t *testing.T) {
+	request := httptest.NewRequest("POST", "https://127.0.0.1", strings.NewReader("payload"))
+	request.Header.Add("content-type", "text/plain")
+	request.Header.Add(githubEventHeader, "push")
+
+	_, err := ParseIncomingWebhook(vcsutils.GitHub, nil, request)
+	assert.EqualError(t, err, "webhook request has unsupported Content-Type \"text/plain\"")
 }
 
 func TestGitHubPayloadMismatchSignature(t *testing.T) {
@@ -173,3 +460,349 @@ func TestGitHubPayloadMismatchSignature(t *testing.T) {
 	_, err = ParseIncomingWebhook(vcsutils.GitHub, token, request)
 	assert.True(t, strings.HasPrefix(err.Error(), "error decoding signature"), "error was: "+err.Error())
 }
+
+func TestGitHubParseIncomingPrCommentWebhook(t *testing.T) {
+	tests := []struct {
+		name                 string
+		payloadFilename      string
+		payloadSha           string
+		eventHeader          string
+		expectedTime         int64
+		expectedCommentID    int64
+		expectedCommentBody  string
+		expectedTargetBranch string
+	}{
+		{
+			name:                "issue comment",
+			payloadFilename:     "issuecommentpayload",
+			payloadSha:          githubIssueCommentSha256,
+			eventHeader:         "issue_comment",
+			expectedTime:        githubIssueCommentExpectedTime,
+			expectedCommentID:   912312476,
+			expectedCommentBody: "/frogbot scan",
+		},
+		{
+			name:                 "review comment",
+			payloadFilename:      "prreviewcommentpayload",
+			payloadSha:           githubPrReviewCommentSha256,
+			eventHeader:          "pull_request_review_comment",
+			expectedTime:         githubPrReviewCommentExpectedTime,
+			expectedCommentID:    701592362,
+			expectedCommentBody:  "Please fix the title",
+			expectedTargetBranch: expectedBranch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "github", tt.payloadFilename))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.Header.Add("content-type", "application/x-www-form-urlencoded")
+			request.Header.Add(githubSha256Header, "sha256="+tt.payloadSha)
+			request.Header.Add(githubEventHeader, tt.eventHeader)
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, gitHubExpectedPrID, actual.PullRequestId)
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, tt.expectedTargetBranch, actual.TargetBranch)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.Equal(t, vcsutils.PrCommentCreated, actual.Event)
+			require.NotNil(t, actual.Comment)
+			assert.Equal(t, tt.expectedCommentID, actual.Comment.ID)
+			assert.Equal(t, tt.expectedCommentBody, actual.Comment.Body)
+			assert.Equal(t, expectedOwner, actual.Comment.Author.Username)
+		})
+	}
+}
+
+func TestGitHubParseIssueCommentEventNotPr(t *testing.T) {
+	webhook := GitHubWebhook{}
+	action := "created"
+	_, err := webhook.parseIssueCommentEvent(&github.IssueCommentEvent{Action: &action, Issue: &github.Issue{}})
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestGitHubParseIncomingPrReviewWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "github", "prreviewpayload"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add("content-type", "application/x-www-form-urlencoded")
+	request.Header.Add(githubSha256Header, "sha256="+githubPrReviewSha256)
+	request.Header.Add(githubEventHeader, "pull_request_review")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, gitHubExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+	assert.Equal(t, githubPrReviewExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.PrReviewed, actual.Event)
+	require.NotNil(t, actual.Review)
+	assert.Equal(t, ReviewApproved, actual.Review.State)
+	assert.Equal(t, "Looks good", actual.Review.Body)
+	assert.Equal(t, expectedOwner, actual.Review.Reviewer.Username)
+}
+
+func TestGitHubParsePrReviewEventStates(t *testing.T) {
+	webhook := GitHubWebhook{}
+	tests := []struct {
+		action        string
+		state         string
+		expectedState ReviewState
+	}{
+		{action: "submitted", state: "approved", expectedState: ReviewApproved},
+		{action: "submitted", state: "changes_requested", expectedState: ReviewChangesRequested},
+		{action: "submitted", state: "commented", expectedState: ReviewCommented},
+		{action: "dismissed", state: "dismissed", expectedState: ReviewUnapproved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			action, state := tt.action, tt.state
+			actual, err := webhook.parsePrReviewEvent(&github.PullRequestReviewEvent{Action: &action, Review: &github.PullRequestReview{State: &state}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedState, actual.Review.State)
+		})
+	}
+	action := "edited"
+	_, err := webhook.parsePrReviewEvent(&github.PullRequestReviewEvent{Action: &action})
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestGitHubParseIncomingReleaseWebhook(t *testing.T) {
+	tests := []struct {
+		name          string
+		payloadFile   string
+		sha256        string
+		expectedEvent vcsutils.WebhookEvent
+		expectedBody  string
+	}{
+		{name: "published", payloadFile: "releasepublishedpayload", sha256: githubReleasePublishedSha256, expectedEvent: vcsutils.ReleasePublished,
+			expectedBody: "## What's Changed\r\n* Update README.md"},
+		{name: "edited", payloadFile: "releaseeditedpayload", sha256: githubReleaseEditedSha256, expectedEvent: vcsutils.ReleaseEdited,
+			expectedBody: "## What's Changed\r\n* Update README.md\r\n* Fix typo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "github", tt.payloadFile))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.Header.Add("content-type", "application/x-www-form-urlencoded")
+			request.Header.Add(githubSha256Header, "sha256="+tt.sha256)
+			request.Header.Add(githubEventHeader, "release")
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, tt.expectedEvent, actual.Event)
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, githubReleaseExpectedTime, actual.Timestamp)
+			require.NotNil(t, actual.Release)
+			assert.Equal(t, githubExpectedReleaseID, actual.Release.ID)
+			assert.Equal(t, expectedTag, actual.Release.TagName)
+			assert.Equal(t, "Release 1.0.0", actual.Release.Name)
+			assert.Equal(t, tt.expectedBody, actual.Release.Body)
+			assert.False(t, actual.Release.Prerelease)
+			assert.Equal(t, expectedOwner, actual.Release.Author.Username)
+		})
+	}
+}
+
+func TestGitHubParseReleaseEventUnsupportedAction(t *testing.T) {
+	_, err := (&GitHubWebhook{}).parseReleaseEvent(&github.ReleaseEvent{Action: github.String("deleted")})
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestGitHubParseIncomingDeploymentWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "github", "deploymentpayload"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add("content-type", "application/x-www-form-urlencoded")
+	request.Header.Add(githubSha256Header, "sha256="+githubDeploymentSha256)
+	request.Header.Add(githubEventHeader, "deployment")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, vcsutils.DeploymentCreated, actual.Event)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, githubDeploymentExpectedTime, actual.Timestamp)
+	require.NotNil(t, actual.Deployment)
+	assert.Equal(t, githubExpectedDeploymentID, actual.Deployment.ID)
+	assert.Equal(t, "production", actual.Deployment.Environment)
+	assert.Equal(t, expectedTag, actual.Deployment.Ref)
+	assert.Equal(t, githubExpectedTagHash, actual.Deployment.Hash)
+	assert.Equal(t, "Deploy v1.0.0", actual.Deployment.Description)
+	assert.Empty(t, actual.Deployment.State)
+	assert.Equal(t, expectedOwner, actual.Deployment.Creator.Username)
+}
+
+func TestGitHubParseIncomingDeploymentStatusWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "github", "deploymentstatuspayload"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add("content-type", "application/x-www-form-urlencoded")
+	request.Header.Add(githubSha256Header, "sha256="+githubDeploymentStatusSha256)
+	request.Header.Add(githubEventHeader, "deployment_status")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, vcsutils.DeploymentStatusUpdated, actual.Event)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, githubDeploymentStatusExpectedTime, actual.Timestamp)
+	require.NotNil(t, actual.Deployment)
+	assert.Equal(t, githubExpectedDeploymentID, actual.Deployment.ID)
+	assert.Equal(t, "production", actual.Deployment.Environment)
+	assert.Equal(t, "success", actual.Deployment.State)
+	assert.Equal(t, "Deployment finished successfully", actual.Deployment.Description)
+	assert.Equal(t, "https://hello-world.example.com", actual.Deployment.EnvironmentURL)
+}
+
+func TestGitHubParseIncomingCIRunWebhook(t *testing.T) {
+	tests := []struct {
+		name          string
+		event         string
+		payloadFile   string
+		sha256        string
+		expectedTime  int64
+		expectedCIRun WebhookInfoCIRun
+	}{
+		{name: "workflowRun", event: "workflow_run", payloadFile: "workflowrunpayload", sha256: githubWorkflowRunSha256, expectedTime: 1645441422,
+			expectedCIRun: WebhookInfoCIRun{ID: 1876543210, Name: "CI", Conclusion: "success", Hash: githubExpectedTagHash,
+				URL: "https://github.com/yahavi/hello-world/actions/runs/1876543210"}},
+		{name: "checkSuite", event: "check_suite", payloadFile: "checksuitepayload", sha256: githubCheckSuiteSha256, expectedTime: 1645441510,
+			expectedCIRun: WebhookInfoCIRun{ID: 5234567890, Name: "GitHub Actions", Conclusion: "failure", Hash: githubExpectedTagHash,
+				URL: "https://api.github.com/repos/yahavi/hello-world/check-suites/5234567890"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "github", tt.payloadFile))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.Header.Add("content-type", "application/x-www-form-urlencoded")
+			request.Header.Add(githubSha256Header, "sha256="+tt.sha256)
+			request.Header.Add(githubEventHeader, tt.event)
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, vcsutils.CIRunCompleted, actual.Event)
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.Equal(t, &tt.expectedCIRun, actual.CIRun)
+		})
+	}
+}
+
+func TestGitHubParseCIRunEventsUnsupportedAction(t *testing.T) {
+	webhook := &GitHubWebhook{}
+	_, err := webhook.parseWorkflowRunEvent(&github.WorkflowRunEvent{Action: github.String("requested")})
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+	_, err = webhook.parseCheckSuiteEvent(&github.CheckSuiteEvent{Action: github.String("rerequested")})
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
+
+func TestGitHubParseIncomingBranchWebhook(t *testing.T) {
+	tests := []struct {
+		event         string
+		payloadFile   string
+		sha256        string
+		expectedEvent vcsutils.WebhookEvent
+	}{
+		{event: "create", payloadFile: "branchcreatepayload", sha256: githubBranchCreateSha256, expectedEvent: vcsutils.BranchCreated},
+		{event: "delete", payloadFile: "branchdeletepayload", sha256: githubBranchDeleteSha256, expectedEvent: vcsutils.BranchDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.event, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "github", tt.payloadFile))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.Header.Add("content-type", "application/x-www-form-urlencoded")
+			request.Header.Add(githubSha256Header, "sha256="+tt.sha256)
+			request.Header.Add(githubEventHeader, tt.event)
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, tt.expectedEvent, actual.Event)
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, expectedSourceBranch, actual.TargetBranch)
+			assert.Equal(t, githubBranchExpectedTime, actual.Timestamp)
+		})
+	}
+}
+
+func TestGitHubParseIncomingBranchWebhookTwice(t *testing.T) {
+	// A branch creation is sent both as a create event and as a push event, and both are parsed into BranchCreated
+	parse := func(event, payloadFile, sha256 string) *WebhookInfo {
+		reader, err := os.Open(filepath.Join("testdata", "github", payloadFile))
+		require.NoError(t, err)
+		defer close(reader)
+		request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+		request.Header.Add("content-type", "application/x-www-form-urlencoded")
+		request.Header.Add(githubSha256Header, "sha256="+sha256)
+		request.Header.Add(githubEventHeader, event)
+		actual, err := ParseIncomingWebhook(vcsutils.GitHub, token, request)
+		require.NoError(t, err)
+		return actual
+	}
+
+	fromCreate := parse("create", "branchcreatepayload", githubBranchCreateSha256)
+	fromPush := parse("push", "pushbranchcreatepayload", githubPushBranchCreateSha256)
+	assert.Equal(t, vcsutils.BranchCreated, fromCreate.Event)
+	assert.Equal(t, fromCreate.Event, fromPush.Event)
+	assert.Equal(t, fromCreate.TargetRepositoryDetails, fromPush.TargetRepositoryDetails)
+	assert.Equal(t, fromCreate.TargetBranch, fromPush.TargetBranch)
+}
+
+func TestGitHubParseTagCreateEventUnsupported(t *testing.T) {
+	_, err := (&GitHubWebhook{}).parseBranchEvent(&github.Repository{}, "tag", expectedTag, vcsutils.BranchCreated)
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+}
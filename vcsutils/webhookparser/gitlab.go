@@ -3,6 +3,7 @@ package webhookparser
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -11,7 +12,10 @@ import (
 	"github.com/xanzy/go-gitlab"
 )
 
-const gitLabKeyHeader = "X-GitLab-Token"
+const (
+	gitLabKeyHeader  = "X-GitLab-Token"
+	gitLabTimeLayout = "2006-01-02 15:04:05 MST"
+)
 
 // GitLabWebhook represents an incoming webhook on GitLab
 type GitLabWebhook struct {
@@ -49,33 +53,106 @@ func (webhook *GitLabWebhook) parseIncomingWebhook(payload []byte) (*WebhookInfo
 		return webhook.parsePushEvent(event), nil
 	case *gitlab.MergeEvent:
 		return webhook.parsePrEvents(event)
+	case *gitlab.TagEvent:
+		return webhook.parseTagEvent(event), nil
+	case *gitlab.MergeCommentEvent:
+		return webhook.parsePrCommentEvent(event)
 	}
-	return nil, nil
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, gitlab.WebhookEventType(webhook.request))
 }
 
 func (webhook *GitLabWebhook) parsePushEvent(event *gitlab.PushEvent) *WebhookInfo {
 	var localTimestamp int64
+	var eventTime time.Time
 	if len(event.Commits) > 0 {
-		localTimestamp = event.Commits[0].Timestamp.Local().Unix()
+		eventTime = *event.Commits[0].Timestamp
+		localTimestamp = eventTime.Local().Unix()
 	}
 	return &WebhookInfo{
 		TargetRepositoryDetails: webhook.parseRepoDetails(event.Project.PathWithNamespace),
 		TargetBranch:            strings.TrimPrefix(event.Ref, "refs/heads/"),
 		Timestamp:               localTimestamp,
-		Event:                   vcsutils.Push,
+		Time:                    eventTime,
+		Event:                   getBranchEvent(event.Before, event.After),
+		ChangedFiles:            webhook.getChangedFiles(event),
+		ForcePush:               webhook.isForcePush(event),
+		BeforeCommit:            getCommitSha(event.Before),
+		Commit:                  getCommitSha(event.After),
+		Commits:                 webhook.parseCommits(event),
+	}
+}
+
+func (webhook *GitLabWebhook) parseCommits(event *gitlab.PushEvent) []WebHookInfoCommit {
+	var commits []WebHookInfoCommit
+	for _, commit := range event.Commits {
+		webhookInfoCommit := WebHookInfoCommit{
+			Hash:    commit.ID,
+			Message: commit.Message,
+			Author: WebhookInfoUser{
+				DisplayName: commit.Author.Name,
+				Email:       commit.Author.Email,
+			},
+			Added:    commit.Added,
+			Modified: commit.Modified,
+			Removed:  commit.Removed,
+		}
+		if commit.Timestamp != nil {
+			webhookInfoCommit.Timestamp = commit.Timestamp.UTC().Unix()
+		}
+		commits = append(commits, webhookInfoCommit)
+	}
+	return commits
+}
+
+// GitLab doesn't flag force pushes. A push of an existing branch which adds no commits moves the branch back, which requires a force push.
+func (webhook *GitLabWebhook) isForcePush(event *gitlab.PushEvent) bool {
+	return getBranchEvent(event.Before, event.After) == vcsutils.Push && event.Before != event.After && event.TotalCommitsCount == 0
+}
+
+func (webhook *GitLabWebhook) getChangedFiles(event *gitlab.PushEvent) []string {
+	var fileLists [][]string
+	for _, commit := range event.Commits {
+		fileLists = append(fileLists, commit.Added, commit.Modified, commit.Removed)
+	}
+	return getChangedFiles(fileLists...)
+}
+
+func (webhook *GitLabWebhook) parseTagEvent(event *gitlab.TagEvent) *WebhookInfo {
+	var localTimestamp int64
+	var eventTime time.Time
+	if len(event.Commits) > 0 {
+		eventTime = *event.Commits[0].Timestamp
+		localTimestamp = eventTime.Local().Unix()
+	}
+	webhookEvent, hash := vcsutils.TagPushed, event.CheckoutSHA
+	// On tag removal, GitLab sends an "after" commit consisting of zeros
+	if strings.Trim(event.After, "0") == "" {
+		webhookEvent, hash = vcsutils.TagRemoved, event.Before
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: webhook.parseRepoDetails(event.Project.PathWithNamespace),
+		Timestamp:               localTimestamp,
+		Time:                    eventTime,
+		Event:                   webhookEvent,
+		Tag: &WebhookInfoTag{
+			Name: strings.TrimPrefix(event.Ref, tagPrefix),
+			Hash: hash,
+		},
 	}
 }
 
+// Projects of subgroups are nested in several namespaces, such as "group/subgroup/project", so the owner is the full namespace
 func (webhook *GitLabWebhook) parseRepoDetails(pathWithNamespace string) WebHookInfoRepoDetails {
-	split := strings.Split(pathWithNamespace, "/")
-	return WebHookInfoRepoDetails{
-		Name:  split[1],
-		Owner: split[0],
+	details := WebHookInfoRepoDetails{Name: pathWithNamespace}
+	if separatorIndex := strings.LastIndex(pathWithNamespace, "/"); separatorIndex >= 0 {
+		details.Owner, details.Name = pathWithNamespace[:separatorIndex], pathWithNamespace[separatorIndex+1:]
 	}
+	return details
 }
 
 func (webhook *GitLabWebhook) parsePrEvents(event *gitlab.MergeEvent) (*WebhookInfo, error) {
 	var webhookEvent vcsutils.WebhookEvent
+	var reviewState ReviewState
 	switch event.ObjectAttributes.Action {
 	case "open", "reopen":
 		webhookEvent = vcsutils.PrOpened
@@ -84,22 +161,120 @@ func (webhook *GitLabWebhook) parsePrEvents(event *gitlab.MergeEvent) (*WebhookI
 	case "merge":
 		webhookEvent = vcsutils.PrMerged
 	case "close":
-		webhookEvent = vcsutils.PrRejected
+		webhookEvent = vcsutils.PrClosed
+	case "approved", "approval":
+		webhookEvent, reviewState = vcsutils.PrReviewed, ReviewApproved
+	case "unapproved", "unapproval":
+		webhookEvent, reviewState = vcsutils.PrReviewed, ReviewUnapproved
 	default:
 		//Action is not supported
-		return nil, nil
+		return nil, fmt.Errorf("%w: merge request action %q", ErrUnsupportedEvent, event.ObjectAttributes.Action)
 	}
-	eventTime, err := time.Parse("2006-01-02 15:04:05 MST", event.ObjectAttributes.UpdatedAt)
+	eventTime, err := time.Parse(gitLabTimeLayout, event.ObjectAttributes.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
-	return &WebhookInfo{
+	createdAt, err := time.Parse(gitLabTimeLayout, event.ObjectAttributes.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	webhookInfo := &WebhookInfo{
 		PullRequestId:           event.ObjectAttributes.IID,
 		SourceRepositoryDetails: webhook.parseRepoDetails(event.ObjectAttributes.Source.PathWithNamespace),
 		SourceBranch:            event.ObjectAttributes.SourceBranch,
 		TargetRepositoryDetails: webhook.parseRepoDetails(event.ObjectAttributes.Target.PathWithNamespace),
 		TargetBranch:            event.ObjectAttributes.TargetBranch,
 		Timestamp:               eventTime.UTC().Unix(),
+		Time:                    eventTime,
 		Event:                   webhookEvent,
-	}, nil
+		PullRequest: &WebhookInfoPullRequest{
+			Title:           event.ObjectAttributes.Title,
+			Body:            event.ObjectAttributes.Description,
+			Draft:           event.ObjectAttributes.WorkInProgress,
+			Labels:          webhook.getLabelNames(event.Labels),
+			CreatedAt:       createdAt,
+			UpdatedAt:       eventTime,
+			MergeCommitHash: event.ObjectAttributes.MergeCommitSHA,
+		},
+	}
+	if webhookEvent == vcsutils.PrEdited {
+		webhookInfo.PullRequest.AddedLabels, webhookInfo.PullRequest.RemovedLabels = webhook.getLabelChanges(event)
+	}
+	// The payload identifies the author by ID only, but the user who opened the merge request is its author
+	if event.ObjectAttributes.Action == "open" && event.User != nil {
+		webhookInfo.PullRequest.Author = webhook.parseUser(event.User)
+	}
+	if webhookEvent == vcsutils.PrReviewed {
+		// In approval events, the user who triggered the event is the reviewer
+		webhookInfo.Review = &WebhookInfoReview{State: reviewState}
+		if event.User != nil {
+			webhookInfo.Review.Reviewer = webhook.parseUser(event.User)
+		}
+	}
+	return webhookInfo, nil
+}
+
+func (webhook *GitLabWebhook) parsePrCommentEvent(event *gitlab.MergeCommentEvent) (*WebhookInfo, error) {
+	eventTime, err := time.Parse(gitLabTimeLayout, event.ObjectAttributes.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, err := time.Parse(gitLabTimeLayout, event.MergeRequest.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := time.Parse(gitLabTimeLayout, event.MergeRequest.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	webhookInfo := &WebhookInfo{
+		PullRequestId:           event.MergeRequest.IID,
+		SourceBranch:            event.MergeRequest.SourceBranch,
+		TargetRepositoryDetails: webhook.parseRepoDetails(event.Project.PathWithNamespace),
+		TargetBranch:            event.MergeRequest.TargetBranch,
+		Timestamp:               eventTime.UTC().Unix(),
+		Time:                    eventTime,
+		Event:                   vcsutils.PrCommentCreated,
+		Comment: &WebhookInfoComment{
+			ID:   int64(event.ObjectAttributes.ID),
+			Body: event.ObjectAttributes.Note,
+		},
+		PullRequest: &WebhookInfoPullRequest{
+			Title:     event.MergeRequest.Title,
+			Body:      event.MergeRequest.Description,
+			Draft:     event.MergeRequest.WorkInProgress,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		},
+	}
+	if event.MergeRequest.Source != nil {
+		webhookInfo.SourceRepositoryDetails = webhook.parseRepoDetails(event.MergeRequest.Source.PathWithNamespace)
+	}
+	if event.User != nil {
+		webhookInfo.Comment.Author = webhook.parseUser(event.User)
+	}
+	return webhookInfo, nil
+}
+
+func (webhook *GitLabWebhook) getLabelNames(labels []*gitlab.Label) []string {
+	var labelNames []string
+	for _, label := range labels {
+		labelNames = append(labelNames, label.Name)
+	}
+	return labelNames
+}
+
+// Compare the previous and current labels listed in the changes of an update event
+func (webhook *GitLabWebhook) getLabelChanges(event *gitlab.MergeEvent) (addedLabels, removedLabels []string) {
+	previousLabels := webhook.getLabelNames(event.Changes.Labels.Previous)
+	currentLabels := webhook.getLabelNames(event.Changes.Labels.Current)
+	return getMissingItems(currentLabels, previousLabels), getMissingItems(previousLabels, currentLabels)
+}
+
+func (webhook *GitLabWebhook) parseUser(user *gitlab.EventUser) WebhookInfoUser {
+	return WebhookInfoUser{
+		Username:    user.Username,
+		DisplayName: user.Name,
+		AvatarURL:   user.AvatarURL,
+	}
 }
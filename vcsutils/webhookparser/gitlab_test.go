@@ -15,14 +15,17 @@ import (
 )
 
 const (
-	gitLabEventHeader          = "X-GitLab-Event"
-	gitlabPushExpectedTime     = int64(1630306883)
-	gitlabPrOpenExpectedTime   = int64(1631202047)
-	gitlabPrReopenExpectedTime = int64(1638865856)
-	gitlabPrUpdateExpectedTime = int64(1631202266)
-	gitlabPrCloseExpectedTime  = int64(1638864453)
-	gitlabPrMergeExpectedTime  = int64(1638866119)
-	gitlabExpectedPrID         = 1
+	gitLabEventHeader            = "X-GitLab-Event"
+	gitlabPushExpectedTime       = int64(1630306883)
+	gitlabPrOpenExpectedTime     = int64(1631202047)
+	gitlabPrReopenExpectedTime   = int64(1638865856)
+	gitlabPrUpdateExpectedTime   = int64(1631202266)
+	gitlabPrCloseExpectedTime    = int64(1638864453)
+	gitlabPrMergeExpectedTime    = int64(1638866119)
+	gitlabPrCommentExpectedTime  = int64(1630922553)
+	gitlabPrApprovedExpectedTime = int64(1631202734)
+	gitlabExpectedPrID           = 1
+	gitlabExpectedTagHash        = "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc"
 )
 
 func TestGitLabParseIncomingPushWebhook(t *testing.T) {
@@ -45,14 +48,104 @@ func TestGitLabParseIncomingPushWebhook(t *testing.T) {
 	assert.Equal(t, expectedBranch, actual.TargetBranch)
 	assert.Equal(t, gitlabPushExpectedTime, actual.Timestamp)
 	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Equal(t, []string{"README.md"}, actual.ChangedFiles)
+	assert.False(t, actual.ForcePush)
+	assert.Equal(t, "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc", actual.BeforeCommit)
+	assert.Equal(t, "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc", actual.Commit)
+	assert.Equal(t, []WebHookInfoCommit{{
+		Hash:      "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc",
+		Message:   "Initial commit",
+		Author:    WebhookInfoUser{DisplayName: "Yahav Itzhak", Email: "yahavitz@gmail.com"},
+		Timestamp: gitlabPushExpectedTime,
+		Added:     []string{"README.md"},
+		Modified:  []string{},
+		Removed:   []string{},
+	}}, actual.Commits)
 }
 
-func TestGitLabParseIncomingPrWebhook(t *testing.T) {
+func TestGitLabIsForcePush(t *testing.T) {
+	const before, after = "a82aa1b065b4fa17db4b7a055109044be377ddf7", "9d497bd67a395a8063774f200338769ccbcee916"
+	const emptyHash = "0000000000000000000000000000000000000000"
+	tests := []struct {
+		name              string
+		before            string
+		after             string
+		totalCommitsCount int
+		expected          bool
+	}{
+		{name: "push commits", before: before, after: after, totalCommitsCount: 1, expected: false},
+		{name: "move branch back", before: before, after: after, totalCommitsCount: 0, expected: true},
+		{name: "create branch", before: emptyHash, after: after, totalCommitsCount: 0, expected: false},
+		{name: "delete branch", before: before, after: emptyHash, totalCommitsCount: 0, expected: false},
+	}
+	webhook := &GitLabWebhook{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &gitlab.PushEvent{Before: tt.before, After: tt.after, TotalCommitsCount: tt.totalCommitsCount}
+			assert.Equal(t, tt.expected, webhook.isForcePush(event))
+		})
+	}
+}
+
+func TestGitLabParseRepoDetails(t *testing.T) {
+	webhook := &GitLabWebhook{}
+	assert.Equal(t, WebHookInfoRepoDetails{Owner: "yahavi", Name: "hello-world"}, webhook.parseRepoDetails("yahavi/hello-world"))
+	assert.Equal(t, WebHookInfoRepoDetails{Owner: "jfrog/security", Name: "hello-world"}, webhook.parseRepoDetails("jfrog/security/hello-world"))
+}
+
+func TestGitLabParseIncomingTagWebhook(t *testing.T) {
 	tests := []struct {
 		name              string
 		payloadFilename   string
 		expectedTime      int64
 		expectedEventType vcsutils.WebhookEvent
+	}{
+		{
+			name:              "push",
+			payloadFilename:   "tagpushpayload.json",
+			expectedTime:      gitlabPushExpectedTime,
+			expectedEventType: vcsutils.TagPushed,
+		},
+		{
+			name:              "delete",
+			payloadFilename:   "tagdeletepayload.json",
+			expectedEventType: vcsutils.TagRemoved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "gitlab", tt.payloadFilename))
+			require.NoError(t, err)
+			defer close(reader)
+
+			// Create request
+			request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+			request.Header.Add(gitLabKeyHeader, string(token))
+			request.Header.Add(gitLabEventHeader, "Tag Push Hook")
+
+			// Parse webhook
+			actual, err := ParseIncomingWebhook(vcsutils.GitLab, token, request)
+			require.NoError(t, err)
+
+			// Check values
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, &WebhookInfoTag{Name: expectedTag, Hash: gitlabExpectedTagHash}, actual.Tag)
+		})
+	}
+}
+
+func TestGitLabParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		name                    string
+		payloadFilename         string
+		expectedTime            int64
+		expectedEventType       vcsutils.WebhookEvent
+		expectedMergeCommitHash string
+		expectedLabels          []string
+		expectedAddedLabels     []string
 	}{
 		{
 			name:              "open",
@@ -67,22 +160,25 @@ func TestGitLabParseIncomingPrWebhook(t *testing.T) {
 			expectedEventType: vcsutils.PrOpened,
 		},
 		{
-			name:              "update",
-			payloadFilename:   "prupdatepayload.json",
-			expectedTime:      gitlabPrUpdateExpectedTime,
-			expectedEventType: vcsutils.PrEdited,
+			name:                "update",
+			payloadFilename:     "prupdatepayload.json",
+			expectedTime:        gitlabPrUpdateExpectedTime,
+			expectedEventType:   vcsutils.PrEdited,
+			expectedLabels:      []string{"security"},
+			expectedAddedLabels: []string{"security"},
 		},
 		{
 			name:              "close",
 			payloadFilename:   "prclosepayload.json",
 			expectedTime:      gitlabPrCloseExpectedTime,
-			expectedEventType: vcsutils.PrRejected,
+			expectedEventType: vcsutils.PrClosed,
 		},
 		{
-			name:              "merge",
-			payloadFilename:   "prmergepayload.json",
-			expectedTime:      gitlabPrMergeExpectedTime,
-			expectedEventType: vcsutils.PrMerged,
+			name:                    "merge",
+			payloadFilename:         "prmergepayload.json",
+			expectedTime:            gitlabPrMergeExpectedTime,
+			expectedEventType:       vcsutils.PrMerged,
+			expectedMergeCommitHash: "115b4dcd1147ffb55743160075c7127e80fb8f51",
 		},
 	}
 	for _, tt := range tests {
@@ -110,6 +206,21 @@ func TestGitLabParseIncomingPrWebhook(t *testing.T) {
 			assert.Equal(t, expectedOwner, actual.SourceRepositoryDetails.Owner)
 			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
 			assert.Equal(t, tt.expectedEventType, actual.Event)
+			require.NotNil(t, actual.PullRequest)
+			assert.Equal(t, tt.expectedMergeCommitHash, actual.PullRequest.MergeCommitHash)
+			assert.Equal(t, "Update README.md", actual.PullRequest.Title)
+			assert.False(t, actual.PullRequest.Draft)
+			assert.Equal(t, gitlabPrOpenExpectedTime, actual.PullRequest.CreatedAt.Unix())
+			assert.Equal(t, tt.expectedTime, actual.PullRequest.UpdatedAt.Unix())
+			assert.Equal(t, tt.expectedTime, actual.Time.Unix())
+			assert.Equal(t, tt.expectedLabels, actual.PullRequest.Labels)
+			assert.Equal(t, tt.expectedAddedLabels, actual.PullRequest.AddedLabels)
+			assert.Empty(t, actual.PullRequest.RemovedLabels)
+			if tt.name == "open" {
+				assert.Equal(t, expectedOwner, actual.PullRequest.Author.Username)
+			} else {
+				assert.Empty(t, actual.PullRequest.Author.Username)
+			}
 		})
 	}
 }
@@ -125,8 +236,9 @@ func TestGitLabParseIncomingWebhookError(t *testing.T) {
 
 func TestGitLabParsePrEventsError(t *testing.T) {
 	webhook := GitLabWebhook{}
-	webhookInfo, _ := webhook.parsePrEvents(&gitlab.MergeEvent{})
+	webhookInfo, err := webhook.parsePrEvents(&gitlab.MergeEvent{})
 	assert.Nil(t, webhookInfo)
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
 }
 
 func TestGitLabPayloadMismatchSignature(t *testing.T) {
@@ -143,3 +255,62 @@ func TestGitLabPayloadMismatchSignature(t *testing.T) {
 	_, err = ParseIncomingWebhook(vcsutils.GitLab, token, request)
 	assert.EqualError(t, err, "token mismatch")
 }
+
+func TestGitLabParseIncomingPrCommentWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "gitlab", "prcommentpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(gitLabKeyHeader, string(token))
+	request.Header.Add(gitLabEventHeader, "Note Hook")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.GitLab, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, gitlabExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedRepoName, actual.SourceRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.SourceRepositoryDetails.Owner)
+	assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+	assert.Equal(t, gitlabPrCommentExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.PrCommentCreated, actual.Event)
+	require.NotNil(t, actual.Comment)
+	assert.Equal(t, int64(712045681), actual.Comment.ID)
+	assert.Equal(t, "/frogbot scan", actual.Comment.Body)
+	assert.Equal(t, expectedOwner, actual.Comment.Author.Username)
+	assert.Equal(t, "Yahav Itzhak", actual.Comment.Author.DisplayName)
+}
+
+func TestGitLabParseIncomingPrApprovalWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "gitlab", "prapprovedpayload.json"))
+	require.NoError(t, err)
+	defer close(reader)
+
+	// Create request
+	request := httptest.NewRequest("POST", "https://127.0.0.1", reader)
+	request.Header.Add(gitLabKeyHeader, string(token))
+	request.Header.Add(gitLabEventHeader, "Merge Request Hook")
+
+	// Parse webhook
+	actual, err := ParseIncomingWebhook(vcsutils.GitLab, token, request)
+	require.NoError(t, err)
+
+	// Check values
+	assert.Equal(t, gitlabExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+	assert.Equal(t, gitlabPrApprovedExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.PrReviewed, actual.Event)
+	require.NotNil(t, actual.Review)
+	assert.Equal(t, ReviewApproved, actual.Review.State)
+	assert.Equal(t, expectedOwner, actual.Review.Reviewer.Username)
+	assert.Equal(t, "Yahav Itzhak", actual.Review.Reviewer.DisplayName)
+}
@@ -0,0 +1,52 @@
+package webhookparser
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// Handler returns an http.Handler, which validates and parses incoming webhooks of the VCS provider,
+// and invokes the callback asynchronously with the parsed WebhookInfo.
+// provider - The VCS provider
+// token    - Token to authenticate incoming webhooks. If empty, signature will not be verified.
+// callback - Invoked in a new goroutine for each parsed webhook
+//
+// The handler responds with:
+// 202 Accepted           - The webhook was parsed, and the callback was invoked
+// 200 OK                 - The event is not handled by the parser, and is ignored, so the VCS provider doesn't retry it
+// 400 Bad Request        - The payload couldn't be parsed
+// 401 Unauthorized       - The token or signature validation failed
+// 405 Method Not Allowed - The request method is not POST
+// 413 Payload Too Large  - The payload exceeds DefaultMaxPayloadSize
+func Handler(provider vcsutils.VcsProvider, token []byte, callback func(webhookInfo *WebhookInfo)) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			writer.Header().Set("Allow", http.MethodPost)
+			http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		webhookInfo, err := ParseIncomingWebhook(provider, token, request)
+		if err != nil {
+			http.Error(writer, err.Error(), getHandlerErrorStatus(err))
+			return
+		}
+		go callback(webhookInfo)
+		writer.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func getHandlerErrorStatus(err error) int {
+	var validationError *payloadValidationError
+	switch {
+	case errors.Is(err, ErrUnsupportedEvent):
+		return http.StatusOK
+	case errors.Is(err, ErrPayloadTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.As(err, &validationError):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusBadRequest
+	}
+}
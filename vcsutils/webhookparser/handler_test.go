@@ -0,0 +1,67 @@
+package webhookparser
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pushpayload.json"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		method         string
+		token          string
+		event          string
+		payload        []byte
+		contentLength  int64
+		expectedStatus int
+	}{
+		{name: "accepted", method: http.MethodPost, token: string(token), event: "Push Hook", payload: payload, expectedStatus: http.StatusAccepted},
+		{name: "unsupportedEvent", method: http.MethodPost, token: string(token), event: "Issue Hook", payload: payload, expectedStatus: http.StatusOK},
+		{name: "tokenMismatch", method: http.MethodPost, token: "wrong", event: "Push Hook", payload: payload, expectedStatus: http.StatusUnauthorized},
+		{name: "invalidPayload", method: http.MethodPost, token: string(token), event: "Push Hook", payload: []byte("{"), expectedStatus: http.StatusBadRequest},
+		{name: "payloadTooLarge", method: http.MethodPost, token: string(token), event: "Push Hook", payload: payload, contentLength: DefaultMaxPayloadSize + 1, expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "methodNotAllowed", method: http.MethodGet, expectedStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookInfos := make(chan *WebhookInfo, 1)
+			handler := Handler(vcsutils.GitLab, token, func(webhookInfo *WebhookInfo) {
+				webhookInfos <- webhookInfo
+			})
+
+			request := httptest.NewRequest(tt.method, "https://127.0.0.1", bytes.NewReader(tt.payload))
+			if tt.contentLength != 0 {
+				request.ContentLength = tt.contentLength
+			}
+			request.Header.Add(gitLabKeyHeader, tt.token)
+			request.Header.Add(gitLabEventHeader, tt.event)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusAccepted {
+				assert.Empty(t, webhookInfos)
+				return
+			}
+			select {
+			case webhookInfo := <-webhookInfos:
+				assert.Equal(t, vcsutils.Push, webhookInfo.Event)
+				assert.Equal(t, expectedBranch, webhookInfo.TargetBranch)
+			case <-time.After(5 * time.Second):
+				assert.Fail(t, "The callback wasn't invoked")
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package webhookparser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsclient"
+)
+
+// DefaultWebhookContextTTL is the time a fetched WebhookContext is cached, when NewWebhookContextFetcher is given no TTL
+const DefaultWebhookContextTTL = time.Minute
+
+var errNoWebhookContext = errors.New("the webhook refers to neither a pull request nor a commit")
+
+// WebhookContext is the pull request or the commit that a webhook refers to, along with the details that the webhook payload doesn't include
+type WebhookContext struct {
+	// The repository of the pull request or of the commit, as expected by the VcsClient methods
+	Owner      string
+	Repository string
+	// The pull request, for webhooks of pull request events, comments and reviews. Nil for the other webhooks
+	PullRequest *vcsclient.PullRequestInfo
+	// The commit of the webhook, or the head commit of the pull request
+	Commit vcsclient.CommitInfo
+	// The files changed by the pull request, or by the pushed commits. Empty for pushes which created a branch
+	Files []vcsclient.PullRequestFile
+	// The labels of the pull request
+	Labels []string
+	// The commit statuses and check runs of Commit
+	Statuses []vcsclient.CommitStatusInfo
+}
+
+// WebhookContextFetcher fetches the WebhookContext of incoming webhooks using a VcsClient.
+// The fetched contexts are cached for a while, so that handling several webhooks of the same pull request or commit,
+// such as the changes of a single push or the retries of a delivery, doesn't fetch them again.
+// It is safe for concurrent use.
+type WebhookContextFetcher struct {
+	client vcsclient.VcsClient
+	ttl    time.Duration
+	mutex  sync.Mutex
+	cache  map[string]cachedWebhookContext
+}
+
+type cachedWebhookContext struct {
+	context *WebhookContext
+	expiry  time.Time
+}
+
+// NewWebhookContextFetcher creates a WebhookContextFetcher.
+// client - A VcsClient of the VCS provider which sent the webhooks
+// ttl    - The time a fetched WebhookContext is cached. If 0, DefaultWebhookContextTTL is used
+func NewWebhookContextFetcher(client vcsclient.VcsClient, ttl time.Duration) *WebhookContextFetcher {
+	if ttl <= 0 {
+		ttl = DefaultWebhookContextTTL
+	}
+	return &WebhookContextFetcher{client: client, ttl: ttl, cache: make(map[string]cachedWebhookContext)}
+}
+
+// Fetch returns the WebhookContext of the pull request of the webhook, if it has one, or of its commit otherwise.
+// The repository is the target repository of the webhook, where the pull request is opened.
+// The commit of tag, commit status, deployment and CI run events is used when the webhook has no pushed commit.
+func (fetcher *WebhookContextFetcher) Fetch(ctx context.Context, webhookInfo *WebhookInfo) (*WebhookContext, error) {
+	owner, repository := webhookInfo.TargetRepositoryDetails.Owner, webhookInfo.TargetRepositoryDetails.Name
+	commitSha := getWebhookCommitSha(webhookInfo)
+	var key string
+	switch {
+	case webhookInfo.PullRequestId > 0:
+		key = fmt.Sprintf("%s/%s#%d", owner, repository, webhookInfo.PullRequestId)
+	case commitSha != "":
+		key = fmt.Sprintf("%s/%s@%s..%s", owner, repository, webhookInfo.BeforeCommit, commitSha)
+	default:
+		return nil, errNoWebhookContext
+	}
+	if webhookContext := fetcher.getCached(key); webhookContext != nil {
+		return webhookContext, nil
+	}
+
+	webhookContext := &WebhookContext{Owner: owner, Repository: repository}
+	var err error
+	if webhookInfo.PullRequestId > 0 {
+		// The pull request details are read at once by VCS providers that support it
+		err = fetcher.fetchPullRequest(ctx, webhookContext, webhookInfo.PullRequestId)
+	} else {
+		err = fetcher.fetchCommit(ctx, webhookContext, webhookInfo.BeforeCommit, commitSha)
+	}
+	if err != nil {
+		return nil, err
+	}
+	fetcher.setCached(key, webhookContext)
+	return webhookContext, nil
+}
+
+func (fetcher *WebhookContextFetcher) fetchPullRequest(ctx context.Context, webhookContext *WebhookContext, pullRequestID int) error {
+	details, err := fetcher.client.GetPullRequestDetails(ctx, webhookContext.Owner, webhookContext.Repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	webhookContext.PullRequest, webhookContext.Labels = &details.PullRequestInfo, details.Labels
+	webhookContext.Commit, webhookContext.Files, webhookContext.Statuses = details.HeadCommit, details.Files, details.Statuses
+	return nil
+}
+
+func (fetcher *WebhookContextFetcher) fetchCommit(ctx context.Context, webhookContext *WebhookContext, beforeSha, commitSha string) error {
+	owner, repository := webhookContext.Owner, webhookContext.Repository
+	commit, err := fetcher.client.GetCommitBySha(ctx, owner, repository, commitSha)
+	if err != nil {
+		return err
+	}
+	webhookContext.Commit = commit
+	if beforeSha != "" {
+		comparison, err := fetcher.client.CompareCommits(ctx, owner, repository, beforeSha, commitSha)
+		if err != nil {
+			return err
+		}
+		webhookContext.Files = comparison.Files
+	}
+	webhookContext.Statuses, err = fetcher.client.ListCommitStatuses(ctx, owner, repository, commitSha)
+	return err
+}
+
+func (fetcher *WebhookContextFetcher) getCached(key string) *WebhookContext {
+	fetcher.mutex.Lock()
+	defer fetcher.mutex.Unlock()
+	if cached, exists := fetcher.cache[key]; exists && time.Now().Before(cached.expiry) {
+		return cached.context
+	}
+	return nil
+}
+
+func (fetcher *WebhookContextFetcher) setCached(key string, webhookContext *WebhookContext) {
+	fetcher.mutex.Lock()
+	defer fetcher.mutex.Unlock()
+	now := time.Now()
+	// Remove the expired contexts, so that the cache doesn't grow with every handled pull request and commit
+	for cachedKey, cached := range fetcher.cache {
+		if !now.Before(cached.expiry) {
+			delete(fetcher.cache, cachedKey)
+		}
+	}
+	fetcher.cache[key] = cachedWebhookContext{context: webhookContext, expiry: now.Add(fetcher.ttl)}
+}
+
+// Return the SHA of the commit that the webhook refers to, or an empty string if there is none
+func getWebhookCommitSha(webhookInfo *WebhookInfo) string {
+	switch {
+	case webhookInfo.Commit != "":
+		return webhookInfo.Commit
+	case webhookInfo.Tag != nil && webhookInfo.Tag.Hash != "":
+		return webhookInfo.Tag.Hash
+	case webhookInfo.CommitStatus != nil && webhookInfo.CommitStatus.Hash != "":
+		return webhookInfo.CommitStatus.Hash
+	case webhookInfo.Deployment != nil && webhookInfo.Deployment.Hash != "":
+		return webhookInfo.Deployment.Hash
+	case webhookInfo.CIRun != nil:
+		return webhookInfo.CIRun.Hash
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package webhookparser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookContextFetcher(t *testing.T) {
+	var pullRequestRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/froggit-go/pulls/1":
+			atomic.AddInt32(&pullRequestRequests, 1)
+			response = `{"number":1,"title":"Update README.md","labels":[{"name":"bug"}]}`
+		case "GET /repos/jfrog/froggit-go/pulls/1/commits?page=1&per_page=100":
+			// The head commit is listed first, so it's found by its parent rather than by the order
+			response = `[{"sha":"head","commit":{"message":"second"},"parents":[{"sha":"base"}]},{"sha":"base","commit":{"message":"first"}}]`
+		case "GET /repos/jfrog/froggit-go/pulls/1/reviews?page=1&per_page=100":
+			response = `[]`
+		case "GET /repos/jfrog/froggit-go/pulls/1/files?page=1&per_page=100":
+			response = `[{"filename":"README.md","status":"modified"}]`
+		case "GET /repos/jfrog/froggit-go/commits/after":
+			response = `{"sha":"after","commit":{"message":"pushed"}}`
+		case "GET /repos/jfrog/froggit-go/compare/before...after?page=1&per_page=100":
+			response = `{"files":[{"filename":"main.go","status":"added"}]}`
+		case "GET /repos/jfrog/froggit-go/commits/head/status?page=1&per_page=100":
+			response = `{"statuses":[{"state":"success","context":"build"}]}`
+		case "GET /repos/jfrog/froggit-go/commits/head/check-runs?page=1&per_page=100",
+			"GET /repos/jfrog/froggit-go/commits/after/check-runs?page=1&per_page=100":
+			response = `{"total_count":0,"check_runs":[]}`
+		case "GET /repos/jfrog/froggit-go/commits/after/status?page=1&per_page=100":
+			response = `{"statuses":[]}`
+		default:
+			assert.Fail(t, "Unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := vcsclient.NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Build()
+	require.NoError(t, err)
+	fetcher := NewWebhookContextFetcher(client, 0)
+	ctx := context.Background()
+	repository := WebHookInfoRepoDetails{Owner: "jfrog", Name: "froggit-go"}
+
+	webhookContext, err := fetcher.Fetch(ctx, &WebhookInfo{TargetRepositoryDetails: repository, PullRequestId: 1, Event: vcsutils.PrOpened})
+	require.NoError(t, err)
+	assert.Equal(t, "jfrog", webhookContext.Owner)
+	assert.Equal(t, "froggit-go", webhookContext.Repository)
+	require.NotNil(t, webhookContext.PullRequest)
+	assert.Equal(t, "Update README.md", webhookContext.PullRequest.Title)
+	assert.Equal(t, "head", webhookContext.Commit.Hash)
+	assert.Equal(t, []vcsclient.PullRequestFile{{Path: "README.md", Status: vcsclient.FileModified}}, webhookContext.Files)
+	assert.Equal(t, []string{"bug"}, webhookContext.Labels)
+	require.Len(t, webhookContext.Statuses, 1)
+	assert.Equal(t, vcsclient.CommitStatusInfo{State: vcsclient.Pass, Title: "build"}, webhookContext.Statuses[0])
+
+	// A comment on the same pull request is handled with the cached context
+	cached, err := fetcher.Fetch(ctx, &WebhookInfo{TargetRepositoryDetails: repository, PullRequestId: 1, Event: vcsutils.PrCommentCreated})
+	require.NoError(t, err)
+	assert.Same(t, webhookContext, cached)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&pullRequestRequests))
+
+	webhookContext, err = fetcher.Fetch(ctx, &WebhookInfo{TargetRepositoryDetails: repository, BeforeCommit: "before", Commit: "after", Event: vcsutils.Push})
+	require.NoError(t, err)
+	assert.Nil(t, webhookContext.PullRequest)
+	assert.Equal(t, "after", webhookContext.Commit.Hash)
+	assert.Equal(t, []vcsclient.PullRequestFile{{Path: "main.go", Status: vcsclient.FileAdded}}, webhookContext.Files)
+	assert.Empty(t, webhookContext.Statuses)
+
+	_, err = fetcher.Fetch(ctx, &WebhookInfo{TargetRepositoryDetails: repository, BeforeCommit: "before", Event: vcsutils.BranchDeleted})
+	assert.ErrorIs(t, err, errNoWebhookContext)
+}
@@ -1,7 +1,15 @@
 package webhookparser
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/jfrog/froggit-go/vcsutils"
 )
@@ -9,6 +17,39 @@ import (
 // EventHeaderKey represents the event type of an incoming webhook from Bitbucket
 const EventHeaderKey = "X-Event-Key"
 
+const tagPrefix = "refs/tags/"
+
+// DefaultMaxPayloadSize is the default maximum size of an incoming webhook payload, which is the limit GitHub applies to its payloads
+const DefaultMaxPayloadSize int64 = 25 * 1024 * 1024
+
+// ErrUnsupportedEvent is returned when the incoming webhook event or action is not handled by the parser.
+// Use errors.Is to tell it apart from parsing and validation errors.
+var ErrUnsupportedEvent = errors.New("unsupported webhook event")
+
+// ErrDuplicateDelivery is returned when the incoming webhook delivery was already parsed, according to the deduplication store
+var ErrDuplicateDelivery = errors.New("duplicate webhook delivery")
+
+// ErrPayloadTooLarge is returned when the incoming webhook payload exceeds the maximum payload size
+var ErrPayloadTooLarge = errors.New("webhook payload is too large")
+
+// ParseOptions are the options of parsing an incoming webhook
+type ParseOptions struct {
+	// The maximum size in bytes of the webhook payload. If not set, DefaultMaxPayloadSize is used.
+	MaxPayloadSize int64
+	// If set, deliveries whose ID was already recorded in the store are rejected with ErrDuplicateDelivery
+	DeduplicationStore DeduplicationStore
+	// If true, the JSON payload is kept in WebhookInfo.RawPayload
+	IncludeRawPayload bool
+	// If set, only these events are parsed. Other events are rejected with ErrUnsupportedEvent, when possible before decoding their payload.
+	Events []vcsutils.WebhookEvent
+}
+
+// DeduplicationStore records the IDs of parsed webhook deliveries, to reject deliveries which are sent again by the VCS provider
+type DeduplicationStore interface {
+	// Record the delivery ID, and return true if it was already recorded
+	Seen(ctx context.Context, deliveryID string) (bool, error)
+}
+
 // WebhookInfo used for parsing an incoming webhook request from the VCS provider.
 type WebhookInfo struct {
 	// The target repository for pull requests and push
@@ -23,8 +64,198 @@ type WebhookInfo struct {
 	SourceBranch string `json:"source_branch,omitempty"`
 	// Seconds from epoch
 	Timestamp int64 `json:"timestamp,omitempty"`
+	// The time of the event, in the time zone of the payload. Same as Timestamp, which is kept for compatibility.
+	Time time.Time `json:"time,omitempty"`
 	// The event type
 	Event vcsutils.WebhookEvent `json:"event,omitempty"`
+	// The unique ID of the webhook delivery, which is kept when the delivery is retried or redelivered
+	DeliveryID string `json:"delivery_id,omitempty"`
+	// The event name as sent by the VCS provider, such as "pull_request" or "pullrequest:fulfilled"
+	RawEvent string `json:"raw_event,omitempty"`
+	// The JSON payload as sent by the VCS provider, for details which aren't parsed. Set only if ParseOptions.IncludeRawPayload is true.
+	RawPayload json.RawMessage `json:"raw_payload,omitempty"`
+	// The pushed or removed tag, for tag events
+	Tag *WebhookInfoTag `json:"tag,omitempty"`
+	// The pull request title, description, author and draft flag, for pull request events
+	PullRequest *WebhookInfoPullRequest `json:"pull_request,omitempty"`
+	// The added comment, for pull request comment events
+	Comment *WebhookInfoComment `json:"comment,omitempty"`
+	// The submitted review, for pull request review events
+	Review *WebhookInfoReview `json:"review,omitempty"`
+	// The created fork, for repository fork events
+	Fork *WebHookInfoRepoDetails `json:"fork,omitempty"`
+	// The created or updated commit status, for commit status events
+	CommitStatus *WebhookInfoCommitStatus `json:"commit_status,omitempty"`
+	// The published or edited release, for release events
+	Release *WebhookInfoRelease `json:"release,omitempty"`
+	// The created deployment and its status, for deployment events
+	Deployment *WebhookInfoDeployment `json:"deployment,omitempty"`
+	// The completed workflow run or check suite, for CI run events
+	CIRun *WebhookInfoCIRun `json:"ci_run,omitempty"`
+	// The paths of the files added, modified or removed by a push event, if listed in the payload.
+	// Bitbucket and Azure Repos payloads and pull request payloads don't list them, so they should be fetched using the VcsClient.
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// Whether the push event rewrote the history of the branch, so caches of the previous commits should be invalidated.
+	// Not available on Gitea, Bitbucket Server and Azure Repos. On GitLab, only force pushes which don't add commits are detected.
+	ForcePush bool `json:"force_push,omitempty"`
+	// The SHAs of the head of the branch before and after a push event, such as for listing the pushed commits using VcsClient.GetCommitsBetween.
+	// BeforeCommit is empty for created branches and for pushes sent by AWS CodeCommit triggers, and Commit is empty for deleted branches.
+	BeforeCommit string `json:"before_commit,omitempty"`
+	Commit       string `json:"commit,omitempty"`
+	// The pushed commits, for push events. Not available on Bitbucket Server.
+	// Bitbucket Cloud payloads list up to 5 commits of each change, and Azure Repos payloads list the commits of the whole push, without the changed files.
+	Commits []WebHookInfoCommit `json:"commits,omitempty"`
+	// All the ref changes of a push event, which may update several branches and tags at once.
+	// The top-level fields describe the first change.
+	Changes []WebhookInfo `json:"changes,omitempty"`
+}
+
+// WebhookInfoTag represents a tag of an incoming tag push webhook
+type WebhookInfoTag struct {
+	// Tag name
+	Name string `json:"name,omitempty"`
+	// The SHA of the commit the tag points to
+	Hash string `json:"hash,omitempty"`
+}
+
+// WebHookInfoCommit represents a pushed commit of an incoming push webhook
+type WebHookInfoCommit struct {
+	// Commit SHA
+	Hash string `json:"hash,omitempty"`
+	// Commit message
+	Message string `json:"message,omitempty"`
+	// The author of the commit
+	Author WebhookInfoUser `json:"author,omitempty"`
+	// Seconds from epoch
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// The paths of the files added by the commit
+	Added []string `json:"added,omitempty"`
+	// The paths of the files modified by the commit
+	Modified []string `json:"modified,omitempty"`
+	// The paths of the files removed by the commit
+	Removed []string `json:"removed,omitempty"`
+}
+
+// WebhookInfoPullRequest represents the details of the pull request of an incoming pull request webhook
+type WebhookInfoPullRequest struct {
+	// Pull request title
+	Title string `json:"title,omitempty"`
+	// Pull request description
+	Body string `json:"body,omitempty"`
+	// The user who opened the pull request.
+	// GitLab payloads identify the author by ID only, so it's set on open events, which the author triggers.
+	Author WebhookInfoUser `json:"author,omitempty"`
+	// Whether the pull request is a draft. Not available on Gitea and Bitbucket Server
+	Draft bool `json:"draft,omitempty"`
+	// The creation time of the pull request
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// The last update time of the pull request. Not available on Azure Repos
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// The names of the pull request labels. Not available on Bitbucket, which doesn't support labels
+	Labels []string `json:"labels,omitempty"`
+	// The labels added by the event, for GitHub labeled events and GitLab update events
+	AddedLabels []string `json:"added_labels,omitempty"`
+	// The labels removed by the event, for GitHub unlabeled events and GitLab update events
+	RemovedLabels []string `json:"removed_labels,omitempty"`
+	// The SHA of the merge commit, for pull request merge events
+	MergeCommitHash string `json:"merge_commit_hash,omitempty"`
+}
+
+// WebhookInfoComment represents a pull request comment of an incoming comment webhook
+type WebhookInfoComment struct {
+	// Comment ID
+	ID int64 `json:"id,omitempty"`
+	// Comment content
+	Body string `json:"body,omitempty"`
+	// The user who wrote the comment
+	Author WebhookInfoUser `json:"author,omitempty"`
+}
+
+// ReviewState is the state of a pull request review
+type ReviewState string
+
+const (
+	ReviewApproved         ReviewState = "approved"
+	ReviewUnapproved       ReviewState = "unapproved"
+	ReviewChangesRequested ReviewState = "changes_requested"
+	ReviewCommented        ReviewState = "commented"
+)
+
+// WebhookInfoReview represents a pull request review of an incoming review webhook
+type WebhookInfoReview struct {
+	// The review state
+	State ReviewState `json:"state,omitempty"`
+	// The review summary, if available in the payload
+	Body string `json:"body,omitempty"`
+	// The user who submitted the review
+	Reviewer WebhookInfoUser `json:"reviewer,omitempty"`
+}
+
+// WebhookInfoCommitStatus represents a commit status of a commit status event
+type WebhookInfoCommitStatus struct {
+	// The unique key of the status, which identifies the build or check
+	Key string `json:"key,omitempty"`
+	// The name of the status
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// The state of the status, as reported by the VCS provider
+	State string `json:"state,omitempty"`
+	// Link to the build or check details
+	URL string `json:"url,omitempty"`
+	// The commit SHA
+	Hash string `json:"hash,omitempty"`
+}
+
+// WebhookInfoRelease represents a release of a release event
+type WebhookInfoRelease struct {
+	ID      int64  `json:"id,omitempty"`
+	TagName string `json:"tag_name,omitempty"`
+	Name    string `json:"name,omitempty"`
+	// The release notes
+	Body       string          `json:"body,omitempty"`
+	Prerelease bool            `json:"prerelease,omitempty"`
+	Author     WebhookInfoUser `json:"author,omitempty"`
+}
+
+// WebhookInfoDeployment represents a deployment of a deployment event
+type WebhookInfoDeployment struct {
+	ID          int64  `json:"id,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	// The deployed branch, tag or commit
+	Ref string `json:"ref,omitempty"`
+	// The SHA of the deployed commit
+	Hash        string `json:"hash,omitempty"`
+	Description string `json:"description,omitempty"`
+	// The state of the deployment status, such as "success" or "failure". Empty for deployment creation events.
+	State string `json:"state,omitempty"`
+	// Link to the deployed environment, if reported by the deployment status
+	EnvironmentURL string          `json:"environment_url,omitempty"`
+	Creator        WebhookInfoUser `json:"creator,omitempty"`
+}
+
+// WebhookInfoCIRun represents a completed workflow run or check suite of a CI run event
+type WebhookInfoCIRun struct {
+	ID int64 `json:"id,omitempty"`
+	// The name of the workflow, or the name of the app which created the check suite
+	Name string `json:"name,omitempty"`
+	// The conclusion of the run, such as "success", "failure" or "cancelled"
+	Conclusion string `json:"conclusion,omitempty"`
+	// The SHA of the head commit of the run
+	Hash string `json:"hash,omitempty"`
+	// Link to the run details
+	URL string `json:"url,omitempty"`
+}
+
+// WebhookInfoUser represents a VCS user of an incoming webhook
+type WebhookInfoUser struct {
+	// The login name of the user
+	Username string `json:"username,omitempty"`
+	// The full name of the user, if available in the payload
+	DisplayName string `json:"display_name,omitempty"`
+	// The URL of the user's avatar, if available in the payload
+	AvatarURL string `json:"avatar_url,omitempty"`
+	// The email of the user, if available in the payload
+	Email string `json:"email,omitempty"`
 }
 
 // WebHookInfoRepoDetails represents repository info of an incoming webhook
@@ -41,23 +272,192 @@ type WebhookParser interface {
 	parseIncomingWebhook(payload []byte) (*WebhookInfo, error)
 }
 
+// Merge the added, modified and removed file lists of the pushed commits into a list of unique paths
+func getChangedFiles(fileLists ...[]string) []string {
+	var changedFiles []string
+	visited := make(map[string]bool)
+	for _, fileList := range fileLists {
+		for _, file := range fileList {
+			if !visited[file] {
+				visited[file] = true
+				changedFiles = append(changedFiles, file)
+			}
+		}
+	}
+	return changedFiles
+}
+
+// Return the event of a pushed branch, which is created when the previous commit SHA is empty or zeros,
+// and deleted when the new commit SHA is empty or zeros
+func getBranchEvent(before, after string) vcsutils.WebhookEvent {
+	switch {
+	case strings.Trim(before, "0") == "":
+		return vcsutils.BranchCreated
+	case strings.Trim(after, "0") == "":
+		return vcsutils.BranchDeleted
+	default:
+		return vcsutils.Push
+	}
+}
+
+// Return the commit SHA, or an empty string if it consists of zeros, as sent for created and deleted branches
+func getCommitSha(sha string) string {
+	if strings.Trim(sha, "0") == "" {
+		return ""
+	}
+	return sha
+}
+
+// Return the items of the first list which are missing from the second list
+func getMissingItems(items, other []string) []string {
+	var missingItems []string
+	for _, item := range items {
+		found := false
+		for _, otherItem := range other {
+			if item == otherItem {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missingItems = append(missingItems, item)
+		}
+	}
+	return missingItems
+}
+
+// payloadSizeLimiter fails reading a payload as soon as it exceeds the maximum payload size, so at most the maximum size is buffered
+type payloadSizeLimiter struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (limiter *payloadSizeLimiter) Read(p []byte) (int, error) {
+	if limiter.exceeded {
+		return 0, ErrPayloadTooLarge
+	}
+	// Read one extra byte to tell a payload of exactly the maximum size apart from a larger one
+	if int64(len(p)) > limiter.remaining+1 {
+		p = p[:limiter.remaining+1]
+	}
+	n, err := limiter.ReadCloser.Read(p)
+	if int64(n) > limiter.remaining {
+		limiter.exceeded = true
+		return int(limiter.remaining), ErrPayloadTooLarge
+	}
+	limiter.remaining -= int64(n)
+	return n, err
+}
+
+// payloadValidationError tells token and signature validation errors apart from parsing errors, keeping the original error message
+type payloadValidationError struct {
+	err error
+}
+
+func (validationError *payloadValidationError) Error() string {
+	return validationError.err.Error()
+}
+
+func (validationError *payloadValidationError) Unwrap() error {
+	return validationError.err
+}
+
 // ParseIncomingWebhook parse incoming webhook payload request into a structurized WebhookInfo object.
 // provider - The VCS provider
 // token    - Token to authenticate incoming webhooks. If empty, signature will not be verified.
 // request  - The HTTP request of the incoming webhook
+// Returns ErrUnsupportedEvent if the event or action is not handled by the parser.
 func ParseIncomingWebhook(provider vcsutils.VcsProvider, token []byte, request *http.Request) (*WebhookInfo, error) {
+	return ParseIncomingWebhookWithOptions(provider, token, request, ParseOptions{})
+}
+
+// ParseIncomingWebhookWithOptions parse incoming webhook payload request into a structurized WebhookInfo object, using the provided options.
+// Returns ErrPayloadTooLarge if the payload exceeds the maximum payload size.
+func ParseIncomingWebhookWithOptions(provider vcsutils.VcsProvider, token []byte, request *http.Request, options ParseOptions) (*WebhookInfo, error) {
+	maxPayloadSize := options.MaxPayloadSize
+	if maxPayloadSize <= 0 {
+		maxPayloadSize = DefaultMaxPayloadSize
+	}
 	if request.Body != nil {
 		defer request.Body.Close()
+		if request.ContentLength > maxPayloadSize {
+			return nil, ErrPayloadTooLarge
+		}
+		// The parsers buffer the size-limited payload before decoding it, since they need the raw payload to verify its signature
+		request.Body = &payloadSizeLimiter{ReadCloser: request.Body, remaining: maxPayloadSize}
+	}
+	if len(options.Events) > 0 {
+		if rawEvent := getRawEvent(provider, request.Header); !isRawEventAllowed(provider, rawEvent, options.Events) {
+			return nil, fmt.Errorf("%w: %s isn't one of the allowed events", ErrUnsupportedEvent, rawEvent)
+		}
 	}
 	parser := createWebhookParser(provider, request)
 	payload, err := parser.validatePayload(token)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, ErrPayloadTooLarge) {
+			return nil, err
+		}
+		return nil, &payloadValidationError{err: err}
 	}
 
 	webhookInfo, err := parser.parseIncomingWebhook(payload)
 	if err != nil {
 		return nil, err
 	}
+	// Azure Repos and AWS CodeCommit have no delivery ID and event headers, so the parser takes them from the payload
+	if webhookInfo.DeliveryID == "" {
+		webhookInfo.DeliveryID = getDeliveryID(provider, request.Header)
+	}
+	if webhookInfo.RawEvent == "" {
+		webhookInfo.RawEvent = getRawEvent(provider, request.Header)
+	}
+	if len(options.Events) > 0 && !isEventAllowed(webhookInfo.Event, options.Events) {
+		return nil, fmt.Errorf("%w: %s isn't one of the allowed events", ErrUnsupportedEvent, webhookInfo.Event)
+	}
+	if options.IncludeRawPayload {
+		webhookInfo.RawPayload = payload
+	}
+	if options.DeduplicationStore != nil && webhookInfo.DeliveryID != "" {
+		seen, err := options.DeduplicationStore.Seen(request.Context(), webhookInfo.DeliveryID)
+		if err != nil {
+			return nil, err
+		}
+		if seen {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateDelivery, webhookInfo.DeliveryID)
+		}
+	}
 	return webhookInfo, nil
 }
+
+// ParsePayload parse a webhook payload which was received elsewhere, for example pulled off a message queue, into a structurized WebhookInfo object.
+// provider - The VCS provider
+// headers  - The HTTP headers of the incoming webhook. Header names are case-insensitive.
+// body     - The raw payload of the incoming webhook
+// token    - Token to authenticate incoming webhooks. If empty, signature will not be verified.
+func ParsePayload(provider vcsutils.VcsProvider, headers map[string]string, body []byte, token []byte) (*WebhookInfo, error) {
+	// The parsers read the headers and payload from an HTTP request
+	request, err := http.NewRequest(http.MethodPost, "", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	return ParseIncomingWebhook(provider, token, request)
+}
+
+// ParseIncomingWebhookAutoDetect parse incoming webhook payload request into a structurized WebhookInfo object,
+// detecting the VCS provider from the request headers. Azure Repos webhooks must be parsed using ParseIncomingWebhook.
+// token    - Token to authenticate incoming webhooks. If empty, signature will not be verified.
+// request  - The HTTP request of the incoming webhook
+func ParseIncomingWebhookAutoDetect(token []byte, request *http.Request) (*WebhookInfo, error) {
+	provider, err := DetectProvider(request)
+	if err != nil {
+		if request.Body != nil {
+			_ = request.Body.Close()
+		}
+		return nil, err
+	}
+	return ParseIncomingWebhook(provider, token, request)
+}